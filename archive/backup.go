@@ -1,6 +1,7 @@
 package archive
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -17,39 +18,55 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+var (
+	errBaseNotInChain   = errors.New("the latest block of the base backup is not in the node's chain")
+	errNoNewBlocks      = errors.New("no new blocks since the base backup")
+	errInvalidBlockHash = errors.New("invalid block hash in the node's response")
+	errHashesMismatch   = errors.New("number of the block hashes doesn't match the blocks in the export event")
+)
+
+// blockWriter is the destination of the exported blocks
+type blockWriter interface {
+	writeBlock(*types.Block) error
+}
+
 // CreateBackup fetches blockchain data with the specific range via gRPC
-// and save this data as binary archive to given path
+// and saves this data as compressed chunks with a manifest to the given directory.
+// If basePath is given, the backup continues from the latest block of the base backup
 func CreateBackup(
 	conn *grpc.ClientConn,
 	logger hclog.Logger,
 	from uint64,
 	to *uint64,
 	outPath string,
-) (uint64, uint64, error) {
-	// always create new file, throw error if the file exists
-	fs, err := os.OpenFile(outPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
-	if err != nil {
-		return 0, 0, err
+	basePath string,
+	chunkSize uint64,
+) (*Manifest, error) {
+	if chunkSize == 0 {
+		chunkSize = DefaultChunkSize
 	}
 
-	closeFile := func() error {
-		if err := fs.Close(); err != nil {
-			logger.Error("an error occurred while closing file", "err", err)
+	var base *Manifest
 
-			return err
+	if basePath != "" {
+		var err error
+
+		if base, err = ReadManifest(basePath); err != nil {
+			return nil, fmt.Errorf("unable to read base backup: %w", err)
 		}
 
-		return nil
+		from = base.Latest + 1
 	}
-	removeFile := func() {
-		if err := os.Remove(outPath); err != nil {
-			logger.Error("an error occurred while removing file", "err", err)
-		}
+
+	// always create new directory, throw error if the directory exists
+	if err := os.Mkdir(outPath, 0755); err != nil {
+		return nil, err
 	}
-	// clean up function for the file when error occurs in the middle of function
-	closeAndRemoveFile := func() {
-		if err := closeFile(); err == nil {
-			removeFile()
+
+	// clean up function for the directory when error occurs in the middle of function
+	removeDir := func() {
+		if err := os.RemoveAll(outPath); err != nil {
+			logger.Error("an error occurred while removing backup directory", "err", err)
 		}
 	}
 
@@ -66,11 +83,32 @@ func CreateBackup(
 
 	clt := proto.NewSystemClient(conn)
 
-	reqTo, reqToHash, err := determineTo(ctx, clt, to)
+	manifest := &Manifest{
+		Version: manifestVersion,
+		From:    from,
+	}
+
+	if base != nil {
+		if err := checkBaseInChain(ctx, clt, base); err != nil {
+			removeDir()
+
+			return nil, err
+		}
+
+		manifest.Parent = &base.LatestHash
+	}
+
+	reqTo, _, err := determineTo(ctx, clt, to)
 	if err != nil {
-		closeAndRemoveFile()
+		removeDir()
+
+		return nil, err
+	}
+
+	if base != nil && reqTo <= base.Latest {
+		removeDir()
 
-		return 0, 0, err
+		return nil, errNoNewBlocks
 	}
 
 	stream, err := clt.Export(ctx, &proto.ExportRequest{
@@ -78,31 +116,90 @@ func CreateBackup(
 		To:   reqTo,
 	})
 	if err != nil {
-		closeAndRemoveFile()
+		removeDir()
+
+		return nil, err
+	}
+
+	var parent types.Hash
+	if manifest.Parent != nil {
+		parent = *manifest.Parent
+	}
+
+	writer := newChunkedWriter(outPath, chunkSize, from, parent)
+
+	if _, _, err := processExportStream(stream, logger, writer, from, reqTo); err != nil {
+		writer.abort()
+		removeDir()
+
+		return nil, err
+	}
+
+	if err := writer.closeChunk(); err != nil {
+		writer.abort()
+		removeDir()
 
-		return 0, 0, err
+		return nil, err
 	}
 
-	if err := writeMetadata(fs, logger, reqTo, reqToHash); err != nil {
-		closeAndRemoveFile()
+	manifest.Chunks = writer.chunks
+	manifest.Latest = writer.next - 1
+	manifest.LatestHash = writer.lastHash
+
+	if err := writeManifest(outPath, manifest); err != nil {
+		removeDir()
+
+		return nil, err
+	}
 
-		return 0, 0, err
+	logger.Info(
+		"Wrote backup manifest",
+		"from", manifest.From,
+		"latest", manifest.Latest,
+		"hash", manifest.LatestHash,
+		"chunks", len(manifest.Chunks),
+	)
+
+	return manifest, nil
+}
+
+// checkBaseInChain makes sure the node still has the latest block of the base backup,
+// so that the incremental backup extends it
+func checkBaseInChain(ctx context.Context, clt proto.SystemClient, base *Manifest) error {
+	resp, err := clt.BlockByNumber(ctx, &proto.BlockByNumberRequest{Number: base.Latest})
+	if err != nil {
+		return fmt.Errorf("%w: %v", errBaseNotInChain, err)
 	}
 
-	resFrom, resTo, err := processExportStream(stream, logger, fs, from, reqTo)
+	block, err := unmarshalBlockResponse(resp)
 	if err != nil {
-		closeAndRemoveFile()
+		return err
+	}
 
-		return 0, 0, err
+	if block.Hash() != base.LatestHash {
+		return fmt.Errorf("%w: expected %s at %d but got %s", errBaseNotInChain, base.LatestHash, base.Latest, block.Hash())
 	}
 
-	if err := closeFile(); err != nil {
-		removeFile()
+	return nil
+}
 
-		return 0, 0, err
+// unmarshalBlockResponse decodes the block in the response with the hash calculated by the node,
+// as the hash calculation of the consensus may differ from the default one
+func unmarshalBlockResponse(resp *proto.BlockResponse) (*types.Block, error) {
+	block := &types.Block{}
+	if err := block.UnmarshalRLP(resp.Data); err != nil {
+		return nil, err
 	}
 
-	return *resFrom, *resTo, nil
+	if len(resp.Hash) > 0 {
+		if len(resp.Hash) != types.HashLength {
+			return nil, errInvalidBlockHash
+		}
+
+		block.Header.Hash = types.BytesToHash(resp.Hash)
+	}
+
+	return block, nil
 }
 
 func determineTo(ctx context.Context, clt proto.SystemClient, to *uint64) (uint64, types.Hash, error) {
@@ -115,8 +212,7 @@ func determineTo(ctx context.Context, clt proto.SystemClient, to *uint64) (uint6
 		// check the existence of the block when you have targetTo
 		resp, err := clt.BlockByNumber(ctx, &proto.BlockByNumberRequest{Number: *to})
 		if err == nil && resp != nil {
-			block := types.Block{}
-			if err := block.UnmarshalRLP(resp.Data); err == nil {
+			if block, err := unmarshalBlockResponse(resp); err == nil {
 				// can use targetTo only if the node has the block at the specific height
 				return block.Number(), block.Hash(), nil
 			}
@@ -127,27 +223,10 @@ func determineTo(ctx context.Context, clt proto.SystemClient, to *uint64) (uint6
 	return uint64(status.Current.Number), types.StringToHash(status.Current.Hash), nil
 }
 
-// writeMetadata writes the latest block height and the block hash to the writer
-func writeMetadata(writer io.Writer, logger hclog.Logger, to uint64, toHash types.Hash) error {
-	metadata := Metadata{
-		Latest:     to,
-		LatestHash: toHash,
-	}
-
-	_, err := writer.Write(metadata.MarshalRLP())
-	if err != nil {
-		return err
-	}
-
-	logger.Info("Wrote metadata to backup", "latest", to, "hash", toHash)
-
-	return err
-}
-
 func processExportStream(
 	stream proto.System_ExportClient,
 	logger hclog.Logger,
-	writer io.Writer,
+	writer blockWriter,
 	targetFrom, targetTo uint64,
 ) (*uint64, *uint64, error) {
 	var from, to *uint64
@@ -193,7 +272,7 @@ func processExportStream(
 			return nil, nil, err
 		}
 
-		if _, err := writer.Write(event.Data); err != nil {
+		if err := writeEventBlocks(writer, event.Data, event.Hashes); err != nil {
 			return nil, nil, err
 		}
 
@@ -206,3 +285,41 @@ func processExportStream(
 		showProgress(event)
 	}
 }
+
+// writeEventBlocks splits the RLP encoded blocks in the export event and passes them to the writer.
+// The blocks are given the hashes calculated by the node, as the hash calculation of the consensus
+// may differ from the default one. The nodes not sending the hashes fall back to the default one
+func writeEventBlocks(writer blockWriter, data []byte, hashes [][]byte) error {
+	blockStream := newBlockStream(bytes.NewReader(data))
+
+	for i := 0; ; i++ {
+		block, err := blockStream.nextBlock()
+		if err != nil {
+			return err
+		}
+
+		if block == nil {
+			if len(hashes) > 0 && i != len(hashes) {
+				return errHashesMismatch
+			}
+
+			return nil
+		}
+
+		if len(hashes) > 0 {
+			if i >= len(hashes) {
+				return errHashesMismatch
+			}
+
+			if len(hashes[i]) != types.HashLength {
+				return errInvalidBlockHash
+			}
+
+			block.Header.Hash = types.BytesToHash(hashes[i])
+		}
+
+		if err := writer.writeBlock(block); err != nil {
+			return err
+		}
+	}
+}
@@ -7,10 +7,14 @@ import (
 	"io"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
+	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/server/proto"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
@@ -170,6 +174,17 @@ func Test_determineTo(t *testing.T) {
 	}
 }
 
+// bufferBlockWriter writes RLP encoded blocks to the buffer
+type bufferBlockWriter struct {
+	bytes.Buffer
+}
+
+func (w *bufferBlockWriter) writeBlock(block *types.Block) error {
+	_, err := w.Write(block.MarshalRLP())
+
+	return err
+}
+
 func Test_processExportStream(t *testing.T) {
 	tests := []struct {
 		name                   string
@@ -251,7 +266,7 @@ func Test_processExportStream(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			var buffer bytes.Buffer
+			var buffer bufferBlockWriter
 			from, to, err := processExportStream(tt.mockSystemExportClient, hclog.NewNullLogger(), &buffer, 0, 0)
 
 			assert.Equal(t, tt.err, err)
@@ -274,3 +289,98 @@ func Test_processExportStream(t *testing.T) {
 		})
 	}
 }
+
+// newIBFTChainedBlocks returns blocks in the given range chained by the IBFT header hashes
+func newIBFTChainedBlocks(t *testing.T, from, to uint64) []*types.Block {
+	t.Helper()
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	keyManager := signer.NewECDSAKeyManagerFromKey(key)
+	ibftSigner := signer.NewSigner(keyManager, keyManager)
+	vals := validators.NewECDSAValidatorSet(validators.NewECDSAValidator(ibftSigner.Address()))
+
+	blocks := make([]*types.Block, 0, to-from+1)
+	parent := types.ZeroHash
+
+	for i := from; i <= to; i++ {
+		header := &types.Header{
+			ParentHash: parent,
+			Number:     i,
+		}
+
+		ibftSigner.InitIBFTExtra(header, vals, nil)
+
+		header.Hash, err = ibftSigner.CalculateHeaderHash(header)
+		require.NoError(t, err)
+
+		parent = header.Hash
+		blocks = append(blocks, &types.Block{Header: header})
+	}
+
+	return blocks
+}
+
+func TestBackup_IBFTHeaderHash(t *testing.T) {
+	t.Parallel()
+
+	blocks := newIBFTChainedBlocks(t, 0, 6)
+
+	// the default hash calculation doesn't match the IBFT one
+	decoded := &types.Block{}
+	require.NoError(t, decoded.UnmarshalRLP(blocks[3].MarshalRLP()))
+	require.NotEqual(t, blocks[3].Hash(), decoded.Hash())
+
+	exportEvent := func(blocks []*types.Block) recvData {
+		event := &proto.ExportEvent{
+			From: blocks[0].Number(),
+			To:   blocks[len(blocks)-1].Number(),
+		}
+
+		for _, block := range blocks {
+			event.Data = append(event.Data, block.MarshalRLP()...)
+			event.Hashes = append(event.Hashes, block.Hash().Bytes())
+		}
+
+		return recvData{event: event}
+	}
+
+	dir := t.TempDir()
+	writer := newChunkedWriter(dir, 3, 0, types.ZeroHash)
+	stream := &mockSystemExportClient{
+		recvs: []recvData{exportEvent(blocks[:4]), exportEvent(blocks[4:])},
+	}
+
+	_, _, err := processExportStream(stream, hclog.NewNullLogger(), writer, 0, 6)
+	require.NoError(t, err)
+	require.NoError(t, writer.closeChunk())
+
+	require.Len(t, writer.chunks, 3)
+	assert.Equal(t, blocks[2].Hash(), writer.chunks[0].LastHash)
+	assert.Equal(t, blocks[6].Hash(), writer.lastHash)
+
+	require.NoError(t, writeManifest(dir, &Manifest{
+		Version:    manifestVersion,
+		From:       0,
+		Latest:     6,
+		LatestHash: writer.lastHash,
+		Chunks:     writer.chunks,
+	}))
+
+	manifest, err := VerifyBackup(dir)
+	require.NoError(t, err)
+
+	// the incremental backup continues from the block the node returns with its hash
+	clt := &systemClientMock{
+		block: &proto.BlockResponse{
+			Data: blocks[6].MarshalRLP(),
+			Hash: blocks[6].Hash().Bytes(),
+		},
+	}
+
+	assert.NoError(t, checkBaseInChain(context.Background(), clt, manifest))
+
+	clt.block.Hash = nil
+	assert.ErrorIs(t, checkBaseInChain(context.Background(), clt, manifest), errBaseNotInChain)
+}
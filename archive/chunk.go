@@ -0,0 +1,271 @@
+package archive
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	errChunkChecksumMismatch = errors.New("chunk checksum mismatch")
+	errChunkSizeMismatch     = errors.New("chunk size mismatch")
+	errUnexpectedBlockNumber = errors.New("unexpected block number")
+	errBrokenHashChain       = errors.New("block parent hash doesn't match the previous block")
+)
+
+// chunkedWriter splits the stream of blocks into zstd compressed chunk files
+// and keeps track of the chunk information for the manifest
+type chunkedWriter struct {
+	dir       string
+	chunkSize uint64
+
+	// next is the number of the next expected block
+	next uint64
+	// lastHash is the hash of the last written block
+	lastHash types.Hash
+
+	chunks []*ChunkInfo
+
+	// state of the chunk being written
+	file    *os.File
+	hasher  hash.Hash
+	encoder *zstd.Encoder
+	current *ChunkInfo
+}
+
+func newChunkedWriter(dir string, chunkSize uint64, from uint64, parent types.Hash) *chunkedWriter {
+	return &chunkedWriter{
+		dir:       dir,
+		chunkSize: chunkSize,
+		next:      from,
+		lastHash:  parent,
+		chunks:    make([]*ChunkInfo, 0),
+	}
+}
+
+// writeBlock appends the block to the current chunk,
+// making sure the block extends the previously written one.
+// The block must have the hash calculated by the node, which is recorded in the manifest
+func (w *chunkedWriter) writeBlock(block *types.Block) error {
+	if block.Number() != w.next {
+		return fmt.Errorf("%w: expected %d but got %d", errUnexpectedBlockNumber, w.next, block.Number())
+	}
+
+	if block.Number() > 0 && block.ParentHash() != w.lastHash {
+		return fmt.Errorf("%w: block %d", errBrokenHashChain, block.Number())
+	}
+
+	if w.current == nil {
+		if err := w.openChunk(block.Number()); err != nil {
+			return err
+		}
+	}
+
+	if _, err := w.encoder.Write(block.MarshalRLP()); err != nil {
+		return err
+	}
+
+	w.current.To = block.Number()
+	w.current.LastHash = block.Hash()
+	w.next = block.Number() + 1
+	w.lastHash = block.Hash()
+
+	if w.current.To-w.current.From+1 >= w.chunkSize {
+		return w.closeChunk()
+	}
+
+	return nil
+}
+
+// openChunk creates a new chunk file starting at the given block
+func (w *chunkedWriter) openChunk(from uint64) error {
+	name := fmt.Sprintf(chunkFileFormat, len(w.chunks))
+
+	file, err := os.OpenFile(filepath.Join(w.dir, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+
+	hasher := sha256.New()
+
+	encoder, err := zstd.NewWriter(io.MultiWriter(file, hasher))
+	if err != nil {
+		_ = file.Close()
+
+		return err
+	}
+
+	w.file = file
+	w.hasher = hasher
+	w.encoder = encoder
+	w.current = &ChunkInfo{
+		File: name,
+		From: from,
+		To:   from,
+	}
+
+	return nil
+}
+
+// closeChunk flushes the current chunk to disk and records its checksum
+func (w *chunkedWriter) closeChunk() error {
+	if w.current == nil {
+		return nil
+	}
+
+	if err := w.encoder.Close(); err != nil {
+		return err
+	}
+
+	if err := w.file.Sync(); err != nil {
+		return err
+	}
+
+	info, err := w.file.Stat()
+	if err != nil {
+		return err
+	}
+
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+
+	w.current.Size = info.Size()
+	w.current.Checksum = hex.EncodeToString(w.hasher.Sum(nil))
+	w.chunks = append(w.chunks, w.current)
+
+	w.file, w.hasher, w.encoder, w.current = nil, nil, nil, nil
+
+	return nil
+}
+
+// abort closes the current chunk file without recording it
+func (w *chunkedWriter) abort() {
+	if w.current == nil {
+		return
+	}
+
+	_ = w.encoder.Close()
+	_ = w.file.Close()
+
+	w.file, w.hasher, w.encoder, w.current = nil, nil, nil, nil
+}
+
+// verifyChunk checks the size and the checksum of the chunk file
+func verifyChunk(dir string, chunk *ChunkInfo) error {
+	file, err := os.Open(filepath.Join(dir, chunk.File))
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	hasher := sha256.New()
+
+	size, err := io.Copy(hasher, file)
+	if err != nil {
+		return err
+	}
+
+	if size != chunk.Size {
+		return fmt.Errorf("%w: %s has %d bytes, expected %d", errChunkSizeMismatch, chunk.File, size, chunk.Size)
+	}
+
+	if checksum := hex.EncodeToString(hasher.Sum(nil)); checksum != chunk.Checksum {
+		return fmt.Errorf("%w: %s", errChunkChecksumMismatch, chunk.File)
+	}
+
+	return nil
+}
+
+// chunkReader is a reader over the decompressed content of the chunks in order.
+// Every chunk is verified against its checksum before it is decompressed
+type chunkReader struct {
+	dir    string
+	chunks []*ChunkInfo
+
+	file    *os.File
+	decoder *zstd.Decoder
+}
+
+func newChunkReader(dir string, chunks []*ChunkInfo) *chunkReader {
+	return &chunkReader{
+		dir:    dir,
+		chunks: chunks,
+	}
+}
+
+// Read implements io.Reader
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for {
+		if r.decoder == nil {
+			if len(r.chunks) == 0 {
+				return 0, io.EOF
+			}
+
+			if err := r.openNext(); err != nil {
+				return 0, err
+			}
+		}
+
+		n, err := r.decoder.Read(p)
+		if errors.Is(err, io.EOF) {
+			r.Close()
+
+			if n > 0 {
+				return n, nil
+			}
+
+			continue
+		}
+
+		return n, err
+	}
+}
+
+// openNext verifies and opens the next chunk
+func (r *chunkReader) openNext() error {
+	chunk := r.chunks[0]
+
+	if err := verifyChunk(r.dir, chunk); err != nil {
+		return err
+	}
+
+	file, err := os.Open(filepath.Join(r.dir, chunk.File))
+	if err != nil {
+		return err
+	}
+
+	decoder, err := zstd.NewReader(file)
+	if err != nil {
+		_ = file.Close()
+
+		return err
+	}
+
+	r.chunks = r.chunks[1:]
+	r.file = file
+	r.decoder = decoder
+
+	return nil
+}
+
+// Close releases the chunk being read
+func (r *chunkReader) Close() {
+	if r.decoder == nil {
+		return
+	}
+
+	r.decoder.Close()
+	_ = r.file.Close()
+
+	r.file, r.decoder = nil, nil
+}
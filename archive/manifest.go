@@ -0,0 +1,148 @@
+package archive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// ManifestFile is the name of the manifest file in a backup directory
+	ManifestFile = "manifest.json"
+
+	// manifestVersion is the version of the chunked backup format
+	manifestVersion = 1
+
+	// DefaultChunkSize is the default number of blocks stored in a single chunk
+	DefaultChunkSize uint64 = 10000
+
+	// chunkFileFormat is the naming format of the chunk files
+	chunkFileFormat = "chunk-%06d.rlp.zst"
+)
+
+var (
+	errEmptyManifest          = errors.New("manifest doesn't contain any chunks")
+	errInvalidManifestRange   = errors.New("manifest block range doesn't match its chunks")
+	errUnsupportedManifest    = errors.New("unsupported backup manifest version")
+	errNonContiguousChunks    = errors.New("chunk block ranges are not contiguous")
+	errMissingParentHash      = errors.New("incremental backup manifest doesn't have a parent hash")
+	errUnexpectedParentHash   = errors.New("full backup manifest must not have a parent hash")
+	errInvalidChunkBlockRange = errors.New("chunk has an invalid block range")
+)
+
+// ChunkInfo describes a single compressed chunk of the backup
+type ChunkInfo struct {
+	// File is the chunk file name relative to the backup directory
+	File string `json:"file"`
+	// From is the first block number in the chunk
+	From uint64 `json:"from"`
+	// To is the last block number in the chunk
+	To uint64 `json:"to"`
+	// LastHash is the hash of the last block in the chunk
+	LastHash types.Hash `json:"lastHash"`
+	// Size is the size of the compressed chunk file in bytes
+	Size int64 `json:"size"`
+	// Checksum is the hex encoded SHA-256 digest of the compressed chunk file
+	Checksum string `json:"checksum"`
+}
+
+// Manifest is the index of a chunked backup
+type Manifest struct {
+	Version int `json:"version"`
+	// From is the first block number in the backup
+	From uint64 `json:"from"`
+	// Latest is the last block number in the backup
+	Latest uint64 `json:"latest"`
+	// LatestHash is the hash of the last block in the backup
+	LatestHash types.Hash `json:"latestHash"`
+	// Parent is the hash of the block preceding From,
+	// set only for incremental backups that continue from another backup
+	Parent *types.Hash `json:"parent,omitempty"`
+	// Chunks are the compressed chunks of the backup in ascending order
+	Chunks []*ChunkInfo `json:"chunks"`
+}
+
+// IsIncremental returns true if the backup continues from another backup
+func (m *Manifest) IsIncremental() bool {
+	return m.Parent != nil
+}
+
+// validate checks the structural consistency of the manifest
+func (m *Manifest) validate() error {
+	if m.Version != manifestVersion {
+		return fmt.Errorf("%w: %d", errUnsupportedManifest, m.Version)
+	}
+
+	if len(m.Chunks) == 0 {
+		return errEmptyManifest
+	}
+
+	if m.From > 0 && m.Parent == nil {
+		return errMissingParentHash
+	}
+
+	if m.From == 0 && m.Parent != nil {
+		return errUnexpectedParentHash
+	}
+
+	next := m.From
+
+	for _, chunk := range m.Chunks {
+		if chunk.From > chunk.To {
+			return fmt.Errorf("%w: %s", errInvalidChunkBlockRange, chunk.File)
+		}
+
+		if chunk.From != next {
+			return fmt.Errorf("%w: %s starts at %d, expected %d", errNonContiguousChunks, chunk.File, chunk.From, next)
+		}
+
+		next = chunk.To + 1
+	}
+
+	last := m.Chunks[len(m.Chunks)-1]
+	if last.To != m.Latest || last.LastHash != m.LatestHash {
+		return errInvalidManifestRange
+	}
+
+	return nil
+}
+
+// ReadManifest reads and validates the manifest of the backup in the given directory
+func ReadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("unable to parse backup manifest: %w", err)
+	}
+
+	if err := manifest.validate(); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// writeManifest writes the manifest to the backup directory.
+// The manifest is written to a temporary file first so that
+// a backup without a complete manifest is never mistaken for a valid one
+func writeManifest(dir string, manifest *Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmpPath := filepath.Join(dir, ManifestFile+".tmp")
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, filepath.Join(dir, ManifestFile))
+}
@@ -26,22 +26,73 @@ type blockchainInterface interface {
 	VerifyFinalizedBlock(*types.Block) error
 }
 
-// RestoreChain reads blocks from the archive and write to the chain
+var (
+	errBaseNotRestored = errors.New("the base backup of the incremental backup has not been restored")
+)
+
+// RestoreChain reads blocks from the archive and write to the chain.
+// The archive is either a chunked backup directory or a legacy single file backup.
+// Blocks that already exist in the chain are skipped,
+// so an interrupted restore resumes where it stopped
 func RestoreChain(chain blockchainInterface, filePath string, progression *progress.ProgressionWrapper) error {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return err
+	}
+
+	if info.IsDir() {
+		return restoreChunkedBackup(chain, filePath, progression)
+	}
+
 	fp, err := os.Open(filePath)
 	if err != nil {
 		return err
 	}
 
+	defer fp.Close()
+
 	blockStream := newBlockStream(fp)
 
 	return importBlocks(chain, blockStream, progression)
 }
 
+// restoreChunkedBackup writes the blocks of the chunked backup in the given directory to the chain
+func restoreChunkedBackup(chain blockchainInterface, dir string, progression *progress.ProgressionWrapper) error {
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return err
+	}
+
+	if hasBlock(chain, manifest.Latest, manifest.LatestHash) {
+		return nil
+	}
+
+	// incremental backup can be applied only on top of its base
+	if manifest.IsIncremental() && !hasBlock(chain, manifest.From-1, *manifest.Parent) {
+		return errBaseNotRestored
+	}
+
+	// skip the chunks that have been applied by the previous restore
+	chunks := manifest.Chunks
+	for len(chunks) > 0 && hasBlock(chain, chunks[0].To, chunks[0].LastHash) {
+		chunks = chunks[1:]
+	}
+
+	reader := newChunkReader(dir, chunks)
+	defer reader.Close()
+
+	return importBlockStream(chain, newBlockStream(reader), manifest.Latest, progression)
+}
+
+// hasBlock returns true if the chain has the block with the given hash at the given height
+func hasBlock(chain blockchainInterface, number uint64, hash types.Hash) bool {
+	block, ok := chain.GetBlockByNumber(number, false)
+
+	return ok && block.Hash() == hash
+}
+
 // import blocks scans all blocks from stream and write them to chain
 func importBlocks(chain blockchainInterface, blockStream *blockStream, progression *progress.ProgressionWrapper) error {
-	shutdownCh := common.GetTerminationSignalCh()
-
 	metadata, err := blockStream.getMetadata()
 	if err != nil {
 		return err
@@ -52,11 +103,22 @@ func importBlocks(chain blockchainInterface, blockStream *blockStream, progressi
 	}
 
 	// check whether the local chain has the latest block already
-	latestBlock, ok := chain.GetBlockByNumber(metadata.Latest, false)
-	if ok && latestBlock.Hash() == metadata.LatestHash {
+	if hasBlock(chain, metadata.Latest, metadata.LatestHash) {
 		return nil
 	}
 
+	return importBlockStream(chain, blockStream, metadata.Latest, progression)
+}
+
+// importBlockStream writes the blocks from stream to chain up to the latest block
+func importBlockStream(
+	chain blockchainInterface,
+	blockStream *blockStream,
+	latest uint64,
+	progression *progress.ProgressionWrapper,
+) error {
+	shutdownCh := common.GetTerminationSignalCh()
+
 	// skip existing blocks
 	firstBlock, err := consumeCommonBlocks(chain, blockStream, shutdownCh)
 	if err != nil {
@@ -73,7 +135,7 @@ func importBlocks(chain blockchainInterface, blockStream *blockStream, progressi
 	defer progression.StopProgression()
 
 	// Set the goal
-	progression.UpdateHighestProgression(metadata.Latest)
+	progression.UpdateHighestProgression(latest)
 
 	nextBlock := firstBlock
 
@@ -241,15 +303,14 @@ func (b *blockStream) loadPrefixSize(offset uint64, prefix byte) (uint64, uint64
 
 		b.reserveCap(offset + payloadSizeSize)
 		payloadSizeBytes := b.buffer[offset : offset+payloadSizeSize]
-		n, err := b.input.Read(payloadSizeBytes)
 
-		if err != nil {
-			return 0, 0, err
-		}
+		if _, err := io.ReadFull(b.input, payloadSizeBytes); err != nil {
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				// couldn't load required amount of bytes
+				return 0, 0, io.EOF
+			}
 
-		if uint64(n) < payloadSizeSize {
-			// couldn't load required amount of bytes
-			return 0, 0, io.EOF
+			return 0, 0, err
 		}
 
 		payloadSize := new(big.Int).SetBytes(payloadSizeBytes).Int64()
@@ -265,7 +326,8 @@ func (b *blockStream) loadPayload(offset uint64, size uint64) error {
 	b.reserveCap(offset + size)
 	buf := b.buffer[offset : offset+size]
 
-	if _, err := b.input.Read(buf); err != nil {
+	// the input may return less bytes than requested per read, e.g. a decompressor
+	if _, err := io.ReadFull(b.input, buf); err != nil {
 		return err
 	}
 
@@ -6,12 +6,14 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -463,3 +465,80 @@ func Test_reserveCap(t *testing.T) {
 		})
 	}
 }
+
+func Test_restoreChunkedBackup(t *testing.T) {
+	t.Parallel()
+
+	chainBlocks := newChainedBlocks(types.ZeroHash, 0, 9)
+
+	hashes := func(blocks []*types.Block) []types.Hash {
+		res := make([]types.Hash, len(blocks))
+		for i, b := range blocks {
+			res[i] = b.Hash()
+		}
+
+		return res
+	}
+
+	t.Run("should restore all blocks", func(t *testing.T) {
+		t.Parallel()
+
+		dir, _ := writeTestBackup(t, nil, 4, chainBlocks)
+		chain := &mockChain{genesis: chainBlocks[0]}
+
+		err := RestoreChain(chain, dir, progress.NewProgressionWrapper(progress.ChainSyncRestore))
+
+		assert.NoError(t, err)
+		assert.Equal(t, hashes(chainBlocks[1:]), hashes(chain.blocks))
+	})
+
+	t.Run("should resume partially applied restore", func(t *testing.T) {
+		t.Parallel()
+
+		dir, manifest := writeTestBackup(t, nil, 4, chainBlocks)
+		chain := &mockChain{
+			genesis: chainBlocks[0],
+			blocks:  append([]*types.Block{}, chainBlocks[1:6]...),
+		}
+
+		// the first chunk has been applied already, so it must not be read
+		require.NoError(t, os.Remove(filepath.Join(dir, manifest.Chunks[0].File)))
+
+		err := RestoreChain(chain, dir, progress.NewProgressionWrapper(progress.ChainSyncRestore))
+
+		assert.NoError(t, err)
+		assert.Equal(t, hashes(chainBlocks[1:]), hashes(chain.blocks))
+	})
+
+	t.Run("should apply incremental backup on top of base", func(t *testing.T) {
+		t.Parallel()
+
+		parent := chainBlocks[4].Hash()
+		dir, _ := writeTestBackup(t, &parent, 2, chainBlocks[5:])
+		chain := &mockChain{
+			genesis: chainBlocks[0],
+			blocks:  append([]*types.Block{}, chainBlocks[1:5]...),
+		}
+
+		err := RestoreChain(chain, dir, progress.NewProgressionWrapper(progress.ChainSyncRestore))
+
+		assert.NoError(t, err)
+		assert.Equal(t, hashes(chainBlocks[1:]), hashes(chain.blocks))
+	})
+
+	t.Run("should fail if base of incremental backup is missing", func(t *testing.T) {
+		t.Parallel()
+
+		parent := chainBlocks[4].Hash()
+		dir, _ := writeTestBackup(t, &parent, 2, chainBlocks[5:])
+		chain := &mockChain{
+			genesis: chainBlocks[0],
+			blocks:  append([]*types.Block{}, chainBlocks[1:3]...),
+		}
+
+		err := RestoreChain(chain, dir, progress.NewProgressionWrapper(progress.ChainSyncRestore))
+
+		assert.ErrorIs(t, err, errBaseNotRestored)
+		assert.Len(t, chain.blocks, 2)
+	})
+}
@@ -0,0 +1,437 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+	"github.com/klauspost/compress/zstd"
+)
+
+// nodesPerBatch is the number of trie nodes written to the storage at once on import
+const nodesPerBatch = 10000
+
+var (
+	errBlockNotFound         = errors.New("block not found")
+	errGenesisSnapshot       = errors.New("state of the genesis block is created from the genesis file")
+	errUnsupportedSnapshot   = errors.New("unsupported state snapshot version")
+	errSnapshotHeadMismatch  = errors.New("last header in state snapshot doesn't match the snapshot block")
+	errBrokenHeaderChain     = errors.New("headers in state snapshot are not consecutive")
+	errStateRootMismatch     = errors.New("state root in state snapshot doesn't match the header")
+	errSnapshotTxRoot        = errors.New("body in state snapshot doesn't match the header transactions root")
+	errMissingSnapshotBody   = errors.New("state snapshot doesn't contain the body of the block")
+	errDuplicateSnapshotData = errors.New("state snapshot contains duplicate metadata, body or receipts")
+)
+
+// StateSnapshotSummary describes the content of a state snapshot
+type StateSnapshotSummary struct {
+	*StateSnapshotMetadata
+
+	Headers int
+	Nodes   int
+	Codes   int
+}
+
+// ExportState writes the state at the block with the given number to a new snapshot file,
+// together with the genesis header and the headers from the beginning of the block's epoch,
+// which the consensus needs to restore its snapshot of the validators.
+// The chain must be opened while the node is stopped
+func ExportState(
+	db storage.Storage,
+	stateStorage itrie.Storage,
+	number uint64,
+	epochSize uint64,
+	outPath string,
+) (*StateSnapshotSummary, error) {
+	if number == 0 {
+		return nil, errGenesisSnapshot
+	}
+
+	headers, err := readSnapshotHeaders(db, number, epochSize)
+	if err != nil {
+		return nil, err
+	}
+
+	head := headers[len(headers)-1]
+
+	diff, ok := db.ReadTotalDifficulty(head.Hash)
+	if !ok {
+		return nil, fmt.Errorf("total difficulty of block %d not found", number)
+	}
+
+	body, err := db.ReadBody(head.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("body of block %d: %w", number, err)
+	}
+
+	receipts, err := db.ReadReceipts(head.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("receipts of block %d: %w", number, err)
+	}
+
+	// always create new file, throw error if the file exists
+	fs, err := os.OpenFile(outPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &StateSnapshotSummary{
+		StateSnapshotMetadata: &StateSnapshotMetadata{
+			Version:         stateSnapshotVersion,
+			Number:          head.Number,
+			Hash:            head.Hash,
+			StateRoot:       head.StateRoot,
+			TotalDifficulty: diff,
+		},
+		Headers: len(headers),
+	}
+
+	if err := writeStateSnapshot(fs, stateStorage, summary, headers, body, receipts); err != nil {
+		_ = fs.Close()
+		_ = os.Remove(outPath)
+
+		return nil, err
+	}
+
+	if err := fs.Close(); err != nil {
+		_ = os.Remove(outPath)
+
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// readSnapshotHeaders returns the genesis header followed by the canonical headers
+// from the beginning of the epoch to the block with the given number
+func readSnapshotHeaders(db storage.Storage, number uint64, epochSize uint64) ([]*types.Header, error) {
+	from := number
+	if epochSize > 0 {
+		from = number - number%epochSize
+	}
+
+	if from == 0 {
+		from = 1
+	}
+
+	numbers := []uint64{0}
+	for n := from; n <= number; n++ {
+		numbers = append(numbers, n)
+	}
+
+	headers := make([]*types.Header, 0, len(numbers))
+
+	for _, n := range numbers {
+		hash, ok := db.ReadCanonicalHash(n)
+		if !ok {
+			return nil, fmt.Errorf("%w: %d", errBlockNotFound, n)
+		}
+
+		header, err := db.ReadHeader(hash)
+		if err != nil {
+			return nil, fmt.Errorf("header of block %d: %w", n, err)
+		}
+
+		// the hash calculation of the consensus may differ from the default one
+		header.Hash = hash
+		headers = append(headers, header)
+	}
+
+	return headers, nil
+}
+
+// writeStateSnapshot writes the zstd compressed records of the state snapshot
+func writeStateSnapshot(
+	writer io.Writer,
+	stateStorage itrie.Storage,
+	summary *StateSnapshotSummary,
+	headers []*types.Header,
+	body *types.Body,
+	receipts []*types.Receipt,
+) error {
+	encoder, err := zstd.NewWriter(writer)
+	if err != nil {
+		return err
+	}
+
+	w := newSnapshotWriter(encoder)
+
+	if err := w.writeRecord(recordMetadata, summary.StateSnapshotMetadata.MarshalRLP()); err != nil {
+		return err
+	}
+
+	for _, header := range headers {
+		if err := w.writeRecord(recordHeader, header.Hash.Bytes(), header.MarshalRLP()); err != nil {
+			return err
+		}
+	}
+
+	if err := w.writeRecord(recordBody, body.MarshalRLPTo(nil)); err != nil {
+		return err
+	}
+
+	if err := w.writeRecord(recordReceipts, types.Receipts(receipts).MarshalStoreRLPTo(nil)); err != nil {
+		return err
+	}
+
+	visitor := &snapshotStateWriter{writer: w, summary: summary}
+	if err := itrie.WalkState(stateStorage, summary.StateRoot, visitor); err != nil {
+		return err
+	}
+
+	if err := w.flush(); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+// snapshotStateWriter writes the visited state to the snapshot
+type snapshotStateWriter struct {
+	writer  *snapshotWriter
+	summary *StateSnapshotSummary
+}
+
+func (s *snapshotStateWriter) VisitNode(_ types.Hash, data []byte) error {
+	s.summary.Nodes++
+
+	return s.writer.writeRecord(recordNode, data)
+}
+
+func (s *snapshotStateWriter) VisitCode(_ types.Hash, code []byte) error {
+	s.summary.Codes++
+
+	return s.writer.writeRecord(recordCode, code)
+}
+
+// ImportState writes the state from the snapshot file to the state storage
+// and sets the block of the snapshot as the head of the empty chain.
+// The chain head is written only after the imported state is verified against the state root of the header
+func ImportState(db storage.Storage, stateStorage itrie.Storage, path string) (*StateSnapshotSummary, error) {
+	if _, ok := db.ReadHeadHash(); ok {
+		return nil, blockchain.ErrChainNotEmpty
+	}
+
+	fs, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer fs.Close()
+
+	decoder, err := zstd.NewReader(fs)
+	if err != nil {
+		return nil, err
+	}
+
+	defer decoder.Close()
+
+	s := &snapshotImporter{
+		stateStorage: stateStorage,
+		batch:        stateStorage.Batch(),
+		headers:      make([]*types.Header, 0),
+	}
+
+	if err := s.readRecords(newSnapshotReader(decoder)); err != nil {
+		return nil, err
+	}
+
+	if err := s.verify(); err != nil {
+		return nil, err
+	}
+
+	if err := blockchain.WriteSnapshotHead(
+		db,
+		s.headers,
+		s.body,
+		s.receipts,
+		s.summary.TotalDifficulty,
+	); err != nil {
+		return nil, err
+	}
+
+	return s.summary, nil
+}
+
+// snapshotImporter keeps the data read from the state snapshot
+type snapshotImporter struct {
+	stateStorage itrie.Storage
+	batch        itrie.Batch
+	pending      int
+
+	summary  *StateSnapshotSummary
+	headers  []*types.Header
+	body     *types.Body
+	receipts []*types.Receipt
+}
+
+// readRecords reads all the records of the snapshot
+// and writes the trie nodes and the codes to the state storage
+func (s *snapshotImporter) readRecords(reader *snapshotReader) error {
+	for {
+		kind, payload, err := reader.nextRecord()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if s.summary == nil && kind != recordMetadata {
+			return errMissingMetadata
+		}
+
+		if err := s.handleRecord(kind, payload); err != nil {
+			return err
+		}
+	}
+
+	if s.summary == nil {
+		return errMissingMetadata
+	}
+
+	s.batch.Write()
+
+	return nil
+}
+
+func (s *snapshotImporter) handleRecord(kind recordKind, payload []byte) error {
+	switch kind {
+	case recordMetadata:
+		if s.summary != nil {
+			return errDuplicateSnapshotData
+		}
+
+		metadata := &StateSnapshotMetadata{}
+		if err := metadata.UnmarshalRLP(payload); err != nil {
+			return err
+		}
+
+		if metadata.Version != stateSnapshotVersion {
+			return fmt.Errorf("%w: %d", errUnsupportedSnapshot, metadata.Version)
+		}
+
+		s.summary = &StateSnapshotSummary{StateSnapshotMetadata: metadata}
+
+	case recordHeader:
+		if len(payload) < types.HashLength {
+			return errInvalidRecord
+		}
+
+		header := &types.Header{}
+		if err := header.UnmarshalRLP(payload[types.HashLength:]); err != nil {
+			return err
+		}
+
+		header.Hash = types.BytesToHash(payload[:types.HashLength])
+		s.headers = append(s.headers, header)
+		s.summary.Headers++
+
+	case recordBody:
+		if s.body != nil {
+			return errDuplicateSnapshotData
+		}
+
+		s.body = &types.Body{}
+		if err := s.body.UnmarshalRLP(payload); err != nil {
+			return err
+		}
+
+	case recordReceipts:
+		if s.receipts != nil {
+			return errDuplicateSnapshotData
+		}
+
+		receipts := types.Receipts{}
+		if err := receipts.UnmarshalStoreRLP(payload); err != nil {
+			return err
+		}
+
+		s.receipts = receipts
+
+	case recordNode:
+		// the nodes are stored under the hash of their content
+		s.batch.Put(crypto.Keccak256(payload), payload)
+		s.summary.Nodes++
+		s.pending++
+
+		if s.pending >= nodesPerBatch {
+			s.batch.Write()
+			s.batch = s.stateStorage.Batch()
+			s.pending = 0
+		}
+
+	case recordCode:
+		// the payload is reused by the reader
+		code := append([]byte{}, payload...)
+
+		s.stateStorage.SetCode(types.BytesToHash(crypto.Keccak256(code)), code)
+		s.summary.Codes++
+
+	default:
+		return fmt.Errorf("%w: %d", errUnknownRecord, kind)
+	}
+
+	return nil
+}
+
+// verify checks the headers and the body of the snapshot
+// and that the imported state is complete for the state root of the header
+func (s *snapshotImporter) verify() error {
+	if len(s.headers) < 2 || s.headers[0].Number != 0 {
+		return blockchain.ErrInvalidSnapshotHead
+	}
+
+	head := s.headers[len(s.headers)-1]
+	if head.Number != s.summary.Number || head.Hash != s.summary.Hash {
+		return errSnapshotHeadMismatch
+	}
+
+	for i := 1; i < len(s.headers); i++ {
+		parent, header := s.headers[i-1], s.headers[i]
+
+		// the headers between the genesis and the beginning of the epoch are not included
+		if i == 1 && header.Number != 1 {
+			continue
+		}
+
+		if header.Number != parent.Number+1 || header.ParentHash != parent.Hash {
+			return fmt.Errorf("%w: block %d", errBrokenHeaderChain, header.Number)
+		}
+	}
+
+	if head.StateRoot != s.summary.StateRoot {
+		return errStateRootMismatch
+	}
+
+	if s.body == nil || s.receipts == nil {
+		return errMissingSnapshotBody
+	}
+
+	if buildroot.CalculateTransactionsRoot(s.body.Transactions) != head.TxRoot {
+		return errSnapshotTxRoot
+	}
+
+	// every node is stored under the hash of its content,
+	// so the complete trie from the root proves the state matches the header
+	if err := itrie.WalkState(s.stateStorage, head.StateRoot, noopStateVisitor{}); err != nil {
+		return fmt.Errorf("%w: %v", errStateRootMismatch, err)
+	}
+
+	return nil
+}
+
+// noopStateVisitor visits the state without any action
+type noopStateVisitor struct{}
+
+func (noopStateVisitor) VisitNode(types.Hash, []byte) error { return nil }
+
+func (noopStateVisitor) VisitCode(types.Hash, []byte) error { return nil }
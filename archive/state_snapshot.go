@@ -0,0 +1,198 @@
+package archive
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/fastrlp"
+)
+
+// stateSnapshotVersion is the version of the state snapshot format
+const stateSnapshotVersion = 1
+
+// maxRecordSize is the maximum size of a record in the state snapshot,
+// the biggest records are the contract codes and the bodies
+const maxRecordSize = 128 * 1024 * 1024
+
+// recordKind is the kind of a record in the state snapshot
+type recordKind byte
+
+const (
+	recordMetadata recordKind = iota + 1
+	recordHeader
+	recordBody
+	recordReceipts
+	recordNode
+	recordCode
+)
+
+var (
+	errUnknownRecord   = errors.New("unknown record in state snapshot")
+	errRecordTooLarge  = errors.New("record in state snapshot is too large")
+	errInvalidRecord   = errors.New("invalid record in state snapshot")
+	errMissingMetadata = errors.New("state snapshot doesn't start with metadata")
+)
+
+// StateSnapshotMetadata is the data stored in the beginning of a state snapshot
+type StateSnapshotMetadata struct {
+	Version uint64
+	// Number is the number of the block the state belongs to
+	Number uint64
+	// Hash is the hash of the block the state belongs to
+	Hash types.Hash
+	// StateRoot is the root of the state in the snapshot
+	StateRoot types.Hash
+	// TotalDifficulty is the total difficulty of the chain at the block
+	TotalDifficulty *big.Int
+}
+
+// MarshalRLP returns RLP encoded bytes
+func (m *StateSnapshotMetadata) MarshalRLP() []byte {
+	return m.MarshalRLPTo(nil)
+}
+
+// MarshalRLPTo sets RLP encoded bytes to given byte slice
+func (m *StateSnapshotMetadata) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(m.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith appends own field into arena for encode
+func (m *StateSnapshotMetadata) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewUint(m.Version))
+	vv.Set(arena.NewUint(m.Number))
+	vv.Set(arena.NewBytes(m.Hash.Bytes()))
+	vv.Set(arena.NewBytes(m.StateRoot.Bytes()))
+	vv.Set(arena.NewBigInt(m.TotalDifficulty))
+
+	return vv
+}
+
+// UnmarshalRLP unmarshals and sets the fields from RLP encoded bytes
+func (m *StateSnapshotMetadata) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(m.UnmarshalRLPFrom, input)
+}
+
+// UnmarshalRLPFrom sets the fields from parsed RLP encoded value
+func (m *StateSnapshotMetadata) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) < 5 {
+		return fmt.Errorf("incorrect number of elements to decode StateSnapshotMetadata, expected 5 but found %d", len(elems))
+	}
+
+	if m.Version, err = elems[0].GetUint64(); err != nil {
+		return err
+	}
+
+	if m.Number, err = elems[1].GetUint64(); err != nil {
+		return err
+	}
+
+	if err = elems[2].GetHash(m.Hash[:]); err != nil {
+		return err
+	}
+
+	if err = elems[3].GetHash(m.StateRoot[:]); err != nil {
+		return err
+	}
+
+	m.TotalDifficulty = new(big.Int)
+	if err = elems[4].GetBigInt(m.TotalDifficulty); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// snapshotWriter writes the records of the state snapshot.
+// A record consists of the kind, the size of the payload as uvarint and the payload
+type snapshotWriter struct {
+	writer *bufio.Writer
+	buf    [binary.MaxVarintLen64 + 1]byte
+}
+
+func newSnapshotWriter(writer io.Writer) *snapshotWriter {
+	return &snapshotWriter{
+		writer: bufio.NewWriter(writer),
+	}
+}
+
+// writeRecord writes the record with the payload consisting of the given parts
+func (w *snapshotWriter) writeRecord(kind recordKind, parts ...[]byte) error {
+	size := 0
+	for _, part := range parts {
+		size += len(part)
+	}
+
+	w.buf[0] = byte(kind)
+	n := binary.PutUvarint(w.buf[1:], uint64(size))
+
+	if _, err := w.writer.Write(w.buf[:n+1]); err != nil {
+		return err
+	}
+
+	for _, part := range parts {
+		if _, err := w.writer.Write(part); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// flush writes the buffered records to the underlying writer
+func (w *snapshotWriter) flush() error {
+	return w.writer.Flush()
+}
+
+// snapshotReader reads the records of the state snapshot
+type snapshotReader struct {
+	reader *bufio.Reader
+	buf    []byte
+}
+
+func newSnapshotReader(reader io.Reader) *snapshotReader {
+	return &snapshotReader{
+		reader: bufio.NewReader(reader),
+	}
+}
+
+// nextRecord returns the next record, io.EOF is returned at the end of the snapshot.
+// The payload is valid until the next call
+func (r *snapshotReader) nextRecord() (recordKind, []byte, error) {
+	kind, err := r.reader.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	size, err := binary.ReadUvarint(r.reader)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%w: %v", errInvalidRecord, err)
+	}
+
+	if size > maxRecordSize {
+		return 0, nil, fmt.Errorf("%w: %d bytes", errRecordTooLarge, size)
+	}
+
+	if uint64(cap(r.buf)) < size {
+		r.buf = make([]byte, size)
+	}
+
+	r.buf = r.buf[:size]
+
+	if _, err := io.ReadFull(r.reader, r.buf); err != nil {
+		return 0, nil, fmt.Errorf("%w: %v", errInvalidRecord, err)
+	}
+
+	return recordKind(kind), r.buf, nil
+}
@@ -0,0 +1,271 @@
+package archive
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/memory"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	stateTestAddr = types.StringToAddress("1")
+	stateTestCode = []byte{0x60, 0x01, 0x60, 0x00, 0x55}
+)
+
+// newStateTestChain writes the state and the chain of the given length,
+// the state belongs to every header of the chain
+func newStateTestChain(t *testing.T, length uint64) (storage.Storage, itrie.Storage, []*types.Header) {
+	t.Helper()
+
+	stateStorage := itrie.NewMemoryStorage()
+
+	_, root := itrie.NewState(stateStorage).NewSnapshot().Commit([]*state.Object{
+		{
+			Address:   stateTestAddr,
+			Balance:   big.NewInt(100),
+			Nonce:     1,
+			Root:      types.EmptyRootHash,
+			CodeHash:  types.BytesToHash(crypto.Keccak256(stateTestCode)),
+			Code:      stateTestCode,
+			DirtyCode: true,
+			Storage: []*state.StorageObject{
+				{
+					Key: types.StringToHash("1").Bytes(),
+					Val: types.StringToHash("2").Bytes(),
+				},
+			},
+		},
+	})
+
+	db, err := memory.NewMemoryStorage(hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	headers := make([]*types.Header, 0, length)
+	parent := types.ZeroHash
+
+	for i := uint64(0); i < length; i++ {
+		header := &types.Header{
+			ParentHash:   parent,
+			Number:       i,
+			Difficulty:   1,
+			StateRoot:    types.BytesToHash(root),
+			TxRoot:       types.EmptyRootHash,
+			ReceiptsRoot: types.EmptyRootHash,
+			Sha3Uncles:   types.EmptyUncleHash,
+			ExtraData:    []byte{},
+		}
+		header.ComputeHash()
+
+		require.NoError(t, db.WriteHeader(header))
+		require.NoError(t, db.WriteCanonicalHash(i, header.Hash))
+		require.NoError(t, db.WriteTotalDifficulty(header.Hash, new(big.Int).SetUint64(i+1)))
+		require.NoError(t, db.WriteBody(header.Hash, &types.Body{}))
+		require.NoError(t, db.WriteReceipts(header.Hash, []*types.Receipt{}))
+
+		parent = header.Hash
+		headers = append(headers, header)
+	}
+
+	require.NoError(t, db.WriteHeadHash(parent))
+	require.NoError(t, db.WriteHeadNumber(length-1))
+
+	return db, stateStorage, headers
+}
+
+func newEmptyStateTestStorages(t *testing.T) (storage.Storage, itrie.Storage) {
+	t.Helper()
+
+	db, err := memory.NewMemoryStorage(hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	return db, itrie.NewMemoryStorage()
+}
+
+func TestExportImportState(t *testing.T) {
+	t.Parallel()
+
+	srcDB, srcState, headers := newStateTestChain(t, 8)
+	path := filepath.Join(t.TempDir(), "state.snap")
+
+	exported, err := ExportState(srcDB, srcState, 6, 4, path)
+	require.NoError(t, err)
+
+	// genesis and the headers from the beginning of the epoch
+	assert.Equal(t, 4, exported.Headers)
+	assert.Equal(t, 1, exported.Codes)
+	assert.Equal(t, headers[6].Hash, exported.Hash)
+
+	db, stateStorage := newEmptyStateTestStorages(t)
+
+	imported, err := ImportState(db, stateStorage, path)
+	require.NoError(t, err)
+
+	assert.Equal(t, exported, imported)
+
+	head, ok := db.ReadHeadHash()
+	assert.True(t, ok)
+	assert.Equal(t, headers[6].Hash, head)
+
+	for _, n := range []uint64{0, 4, 5, 6} {
+		hash, ok := db.ReadCanonicalHash(n)
+		assert.True(t, ok)
+		assert.Equal(t, headers[n].Hash, hash)
+
+		td, ok := db.ReadTotalDifficulty(hash)
+		assert.True(t, ok)
+		assert.Equal(t, new(big.Int).SetUint64(n+1), td)
+	}
+
+	_, ok = db.ReadCanonicalHash(3)
+	assert.False(t, ok)
+
+	snap, err := itrie.NewState(stateStorage).NewSnapshotAt(headers[6].StateRoot)
+	require.NoError(t, err)
+
+	account, err := snap.GetAccount(stateTestAddr)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(100), account.Balance)
+
+	code, ok := snap.GetCode(types.BytesToHash(account.CodeHash))
+	assert.True(t, ok)
+	assert.Equal(t, stateTestCode, code)
+
+	// the chain isn't empty anymore
+	_, err = ImportState(db, stateStorage, path)
+	assert.ErrorIs(t, err, blockchain.ErrChainNotEmpty)
+}
+
+func TestImportState_Invalid(t *testing.T) {
+	t.Parallel()
+
+	srcDB, srcState, headers := newStateTestChain(t, 4)
+	head := headers[3]
+
+	metadata := &StateSnapshotMetadata{
+		Version:         stateSnapshotVersion,
+		Number:          head.Number,
+		Hash:            head.Hash,
+		StateRoot:       head.StateRoot,
+		TotalDifficulty: big.NewInt(4),
+	}
+
+	// writeSnapshot writes the records of the snapshot for the head, with the state nodes from the storage
+	writeSnapshot := func(t *testing.T, metadata *StateSnapshotMetadata, headers []*types.Header, skipNodes bool) string {
+		t.Helper()
+
+		path := filepath.Join(t.TempDir(), "state.snap")
+
+		fs, err := os.Create(path)
+		require.NoError(t, err)
+
+		defer fs.Close()
+
+		encoder, err := zstd.NewWriter(fs)
+		require.NoError(t, err)
+
+		w := newSnapshotWriter(encoder)
+
+		require.NoError(t, w.writeRecord(recordMetadata, metadata.MarshalRLP()))
+
+		for _, header := range headers {
+			require.NoError(t, w.writeRecord(recordHeader, header.Hash.Bytes(), header.MarshalRLP()))
+		}
+
+		require.NoError(t, w.writeRecord(recordBody, (&types.Body{}).MarshalRLPTo(nil)))
+		require.NoError(t, w.writeRecord(recordReceipts, types.Receipts{}.MarshalStoreRLPTo(nil)))
+
+		if !skipNodes {
+			visitor := &snapshotStateWriter{writer: w, summary: &StateSnapshotSummary{}}
+			require.NoError(t, itrie.WalkState(srcState, head.StateRoot, visitor))
+		}
+
+		require.NoError(t, w.flush())
+		require.NoError(t, encoder.Close())
+
+		return path
+	}
+
+	tests := []struct {
+		name      string
+		metadata  *StateSnapshotMetadata
+		headers   []*types.Header
+		skipNodes bool
+		err       error
+	}{
+		{
+			name:     "valid snapshot",
+			metadata: metadata,
+			headers:  headers,
+		},
+		{
+			name:      "missing state nodes",
+			metadata:  metadata,
+			headers:   headers,
+			skipNodes: true,
+			err:       errStateRootMismatch,
+		},
+		{
+			name: "state root not matching header",
+			metadata: &StateSnapshotMetadata{
+				Version:         stateSnapshotVersion,
+				Number:          head.Number,
+				Hash:            head.Hash,
+				StateRoot:       types.StringToHash("1"),
+				TotalDifficulty: big.NewInt(4),
+			},
+			headers: headers,
+			err:     errStateRootMismatch,
+		},
+		{
+			name:     "headers not consecutive",
+			metadata: metadata,
+			headers:  []*types.Header{headers[0], headers[1], headers[3]},
+			err:      errBrokenHeaderChain,
+		},
+		{
+			name:     "last header not matching metadata",
+			metadata: metadata,
+			headers:  headers[:3],
+			err:      errSnapshotHeadMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := writeSnapshot(t, tt.metadata, tt.headers, tt.skipNodes)
+			db, stateStorage := newEmptyStateTestStorages(t)
+
+			_, err := ImportState(db, stateStorage, path)
+			if tt.err == nil {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.ErrorIs(t, err, tt.err)
+
+			// the chain head is not written for the invalid snapshot
+			_, ok := db.ReadHeadHash()
+			assert.False(t, ok)
+		})
+	}
+
+	_, err := ExportState(srcDB, srcState, 0, 4, filepath.Join(t.TempDir(), "genesis.snap"))
+	assert.ErrorIs(t, err, errGenesisSnapshot)
+}
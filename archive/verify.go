@@ -0,0 +1,68 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	errChunkRangeMismatch  = errors.New("chunk blocks don't match the chunk range")
+	errChunkTrailingBlocks = errors.New("chunk contains more blocks than its range")
+)
+
+// VerifyBackup checks the integrity of the chunked backup in the given directory
+// without importing it. It verifies the checksum of every chunk,
+// the block ranges recorded in the manifest and the hash chaining between the chunks.
+// The hash calculation of the consensus may differ from the default one, so the blocks
+// are chained by the hashes the node calculated, which are recorded in the manifest
+func VerifyBackup(dir string) (*Manifest, error) {
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// the first block of an incremental backup must extend the base backup
+	prevHash := manifest.Parent
+
+	for _, chunk := range manifest.Chunks {
+		if err := verifyChunkBlocks(dir, chunk, prevHash); err != nil {
+			return nil, err
+		}
+
+		prevHash = &chunk.LastHash
+	}
+
+	return manifest, nil
+}
+
+// verifyChunkBlocks verifies the chunk and the blocks in it,
+// the first block must extend the last block of the previous chunk
+func verifyChunkBlocks(dir string, chunk *ChunkInfo, prevHash *types.Hash) error {
+	reader := newChunkReader(dir, []*ChunkInfo{chunk})
+	defer reader.Close()
+
+	blockStream := newBlockStream(reader)
+
+	for number := chunk.From; number <= chunk.To; number++ {
+		block, err := blockStream.nextBlock()
+		if err != nil {
+			return fmt.Errorf("%s: %w", chunk.File, err)
+		}
+
+		if block == nil || block.Number() != number {
+			return fmt.Errorf("%w: %s, expected block %d", errChunkRangeMismatch, chunk.File, number)
+		}
+
+		if number == chunk.From && prevHash != nil && block.ParentHash() != *prevHash {
+			return fmt.Errorf("%w: %s, block %d", errBrokenHashChain, chunk.File, number)
+		}
+	}
+
+	if block, err := blockStream.nextBlock(); err != nil || block != nil {
+		return fmt.Errorf("%w: %s", errChunkTrailingBlocks, chunk.File)
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package archive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newChainedBlocks returns blocks in the given range chained by parent hashes
+func newChainedBlocks(parent types.Hash, from, to uint64) []*types.Block {
+	blocks := make([]*types.Block, 0, to-from+1)
+
+	for i := from; i <= to; i++ {
+		block := &types.Block{
+			Header: &types.Header{
+				ParentHash: parent,
+				Number:     i,
+				ExtraData:  []byte{},
+			},
+		}
+		block.Header.ComputeHash()
+
+		parent = block.Hash()
+		blocks = append(blocks, block)
+	}
+
+	return blocks
+}
+
+// writeTestBackup writes the blocks as a chunked backup to a new directory
+func writeTestBackup(t *testing.T, parent *types.Hash, chunkSize uint64, blocks []*types.Block) (string, *Manifest) {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	var parentHash types.Hash
+	if parent != nil {
+		parentHash = *parent
+	}
+
+	writer := newChunkedWriter(dir, chunkSize, blocks[0].Number(), parentHash)
+
+	for _, block := range blocks {
+		require.NoError(t, writer.writeBlock(block))
+	}
+
+	require.NoError(t, writer.closeChunk())
+
+	manifest := &Manifest{
+		Version:    manifestVersion,
+		From:       blocks[0].Number(),
+		Latest:     writer.next - 1,
+		LatestHash: writer.lastHash,
+		Parent:     parent,
+		Chunks:     writer.chunks,
+	}
+
+	require.NoError(t, writeManifest(dir, manifest))
+
+	return dir, manifest
+}
+
+func Test_chunkedWriter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should split blocks into chunks", func(t *testing.T) {
+		t.Parallel()
+
+		blocks := newChainedBlocks(types.ZeroHash, 0, 6)
+		_, manifest := writeTestBackup(t, nil, 3, blocks)
+
+		require.Len(t, manifest.Chunks, 3)
+
+		for i, r := range [][2]uint64{{0, 2}, {3, 5}, {6, 6}} {
+			assert.Equal(t, r[0], manifest.Chunks[i].From)
+			assert.Equal(t, r[1], manifest.Chunks[i].To)
+			assert.Equal(t, blocks[r[1]].Hash(), manifest.Chunks[i].LastHash)
+		}
+
+		assert.Equal(t, blocks[6].Hash(), manifest.LatestHash)
+	})
+
+	t.Run("should reject block not extending the previous one", func(t *testing.T) {
+		t.Parallel()
+
+		blocks := newChainedBlocks(types.ZeroHash, 0, 2)
+		writer := newChunkedWriter(t.TempDir(), 10, 0, types.ZeroHash)
+
+		require.NoError(t, writer.writeBlock(blocks[0]))
+		assert.ErrorIs(t, writer.writeBlock(newChainedBlocks(types.StringToHash("1"), 1, 1)[0]), errBrokenHashChain)
+		assert.ErrorIs(t, writer.writeBlock(blocks[2]), errUnexpectedBlockNumber)
+
+		writer.abort()
+	})
+}
+
+func TestVerifyBackup(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should verify valid backup", func(t *testing.T) {
+		t.Parallel()
+
+		blocks := newChainedBlocks(types.ZeroHash, 0, 9)
+		dir, expected := writeTestBackup(t, nil, 4, blocks)
+
+		manifest, err := VerifyBackup(dir)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, manifest)
+	})
+
+	t.Run("should verify incremental backup", func(t *testing.T) {
+		t.Parallel()
+
+		blocks := newChainedBlocks(types.ZeroHash, 0, 9)
+		parent := blocks[4].Hash()
+		dir, _ := writeTestBackup(t, &parent, 2, blocks[5:])
+
+		manifest, err := VerifyBackup(dir)
+
+		assert.NoError(t, err)
+		assert.True(t, manifest.IsIncremental())
+		assert.Equal(t, uint64(5), manifest.From)
+	})
+
+	t.Run("should fail if chunk is corrupted", func(t *testing.T) {
+		t.Parallel()
+
+		blocks := newChainedBlocks(types.ZeroHash, 0, 9)
+		dir, manifest := writeTestBackup(t, nil, 4, blocks)
+
+		path := filepath.Join(dir, manifest.Chunks[1].File)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		data[len(data)/2] ^= 0xff
+		require.NoError(t, os.WriteFile(path, data, 0644))
+
+		_, err = VerifyBackup(dir)
+		assert.ErrorIs(t, err, errChunkChecksumMismatch)
+	})
+
+	t.Run("should fail if incremental backup doesn't extend parent", func(t *testing.T) {
+		t.Parallel()
+
+		blocks := newChainedBlocks(types.ZeroHash, 5, 9)
+		parent := types.StringToHash("0xdead")
+		dir, manifest := writeTestBackup(t, &blocks[0].Header.ParentHash, 2, blocks)
+
+		manifest.Parent = &parent
+		require.NoError(t, writeManifest(dir, manifest))
+
+		_, err := VerifyBackup(dir)
+		assert.ErrorIs(t, err, errBrokenHashChain)
+	})
+
+	t.Run("should fail if manifest ranges are not contiguous", func(t *testing.T) {
+		t.Parallel()
+
+		blocks := newChainedBlocks(types.ZeroHash, 0, 9)
+		dir, manifest := writeTestBackup(t, nil, 4, blocks)
+
+		manifest.Chunks = append(manifest.Chunks[:1], manifest.Chunks[2:]...)
+		require.NoError(t, writeManifest(dir, manifest))
+
+		_, err := VerifyBackup(dir)
+		assert.ErrorIs(t, err, errNonContiguousChunks)
+	})
+}
@@ -0,0 +1,302 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// addressIndexBackfillBatch is the number of the blocks indexed at once by the backfill
+	addressIndexBackfillBatch = 100
+)
+
+var (
+	ErrAddressIndexDisabled = errors.New("address index is disabled")
+)
+
+// EnableAddressIndex enables the index of the transactions sent or received by the addresses
+// for the blocks written from now on. If the index doesn't cover the current head,
+// e.g. it's enabled for the first time or the blocks were written while it was disabled,
+// the existing blocks are indexed again by BackfillAddressIndex from the head to the genesis
+func (b *Blockchain) EnableAddressIndex() error {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	head := b.Header().Number
+
+	if indexedHead, ok := b.db.ReadAddressIndexHead(); !ok || indexedHead != head {
+		batch := b.db.NewBatch()
+
+		if err := batch.WriteAddressIndexTail(head + 1); err != nil {
+			return err
+		}
+
+		if err := batch.WriteAddressIndexHead(head); err != nil {
+			return err
+		}
+
+		if err := batch.Write(); err != nil {
+			return err
+		}
+	}
+
+	b.addressIndex = true
+
+	return nil
+}
+
+// AddressIndexTail returns the lowest number of the blocks from which the address index is complete,
+// it's zero once the backfill has reached the genesis
+func (b *Blockchain) AddressIndexTail() (uint64, bool) {
+	if !b.addressIndex {
+		return 0, false
+	}
+
+	return b.db.ReadAddressIndexTail()
+}
+
+// BackfillAddressIndex indexes the blocks below the tail of the address index, from the highest one,
+// until the index covers the whole chain or stopCh is closed
+func (b *Blockchain) BackfillAddressIndex(stopCh <-chan struct{}) error {
+	if !b.addressIndex {
+		return ErrAddressIndexDisabled
+	}
+
+	for {
+		select {
+		case <-stopCh:
+			return nil
+		default:
+		}
+
+		tail, err := b.backfillAddressIndexBatch()
+		if err != nil {
+			return err
+		}
+
+		if tail == 0 {
+			return nil
+		}
+	}
+}
+
+// backfillAddressIndexBatch indexes the next batch of the blocks below the tail and returns the new tail
+func (b *Blockchain) backfillAddressIndexBatch() (uint64, error) {
+	// the lock keeps the tail consistent with the blocks written and rewound meanwhile
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	tail, ok := b.db.ReadAddressIndexTail()
+	if !ok || tail == 0 {
+		return 0, nil
+	}
+
+	batch := b.db.NewBatch()
+	next := tail
+
+	for next > 0 && tail-next < addressIndexBackfillBatch {
+		number := next - 1
+
+		// the genesis block has no transactions
+		if number > 0 {
+			if err := b.indexCanonicalBlock(batch, number); err != nil {
+				return 0, err
+			}
+		}
+
+		next = number
+	}
+
+	if err := batch.WriteAddressIndexTail(next); err != nil {
+		return 0, err
+	}
+
+	if err := batch.Write(); err != nil {
+		return 0, err
+	}
+
+	return next, nil
+}
+
+// indexCanonicalBlock adds the transactions of the canonical block with the number to the address index
+func (b *Blockchain) indexCanonicalBlock(batch storage.Batch, number uint64) error {
+	hash, ok := b.db.ReadCanonicalHash(number)
+	if !ok {
+		return fmt.Errorf("canonical hash of block %d not found", number)
+	}
+
+	return b.indexStoredBlock(batch, number, hash)
+}
+
+// indexStoredBlock adds the transactions of the block in the storage to the address index
+func (b *Blockchain) indexStoredBlock(batch storage.Batch, number uint64, hash types.Hash) error {
+	body, ok := b.readBody(hash)
+	if !ok {
+		return fmt.Errorf("body of block %d not found", number)
+	}
+
+	receipts, err := b.db.ReadReceipts(hash)
+	if err != nil {
+		return fmt.Errorf("receipts of block %d: %w", number, err)
+	}
+
+	return writeAddressIndex(batch, number, hash, body.Transactions, receipts)
+}
+
+// updateAddressIndex adds the transactions of the written block to the address index
+// and moves the head of the index if the block becomes the head of the chain.
+// The blocks becoming canonical by a reorg are indexed as well, they may have been written while the index was disabled
+func (b *Blockchain) updateAddressIndex(
+	batch storage.Batch,
+	evnt *Event,
+	block *types.Block,
+	receipts []*types.Receipt,
+	newHead bool,
+) error {
+	if err := writeAddressIndex(batch, block.Number(), block.Hash(), block.Transactions, receipts); err != nil {
+		return err
+	}
+
+	if !newHead {
+		return nil
+	}
+
+	if evnt.Type == EventReorg {
+		for _, header := range evnt.NewChain {
+			if header.Hash == block.Hash() {
+				continue
+			}
+
+			if err := b.indexStoredBlock(batch, header.Number, header.Hash); err != nil {
+				return err
+			}
+		}
+	}
+
+	return batch.WriteAddressIndexHead(block.Number())
+}
+
+// deleteAddressIndex deletes the transactions of the block from the address index
+func (b *Blockchain) deleteAddressIndex(batch storage.Batch, header *types.Header, body *types.Body) error {
+	receipts, err := b.db.ReadReceipts(header.Hash)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+
+	for idx, txn := range body.Transactions {
+		pos := storage.TxPosition{
+			Number:    header.Number,
+			Index:     uint64(idx),
+			BlockHash: header.Hash,
+		}
+
+		for _, addr := range txAddresses(txn, idx, receipts) {
+			if err := batch.DeleteAddressIndex(addr, pos); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetTransactionsByAddress returns the positions of the canonical transactions sent or received by the address,
+// starting from the position of from in the ascending order. At most limit positions are returned,
+// the position of the next transaction is returned as well if there are more transactions
+func (b *Blockchain) GetTransactionsByAddress(
+	addr types.Address,
+	from storage.TxPosition,
+	limit int,
+) ([]storage.TxPosition, *storage.TxPosition, error) {
+	if !b.addressIndex {
+		return nil, nil, ErrAddressIndexDisabled
+	}
+
+	var (
+		positions = make([]storage.TxPosition, 0, limit)
+		next      *storage.TxPosition
+	)
+
+	err := b.db.ReadAddressIndex(addr, from, func(pos storage.TxPosition) bool {
+		// the transactions of the blocks replaced by a reorg or a rewind may remain in the index
+		if hash, ok := b.db.ReadCanonicalHash(pos.Number); !ok || hash != pos.BlockHash {
+			return true
+		}
+
+		if len(positions) == limit {
+			next = &pos
+
+			return false
+		}
+
+		positions = append(positions, pos)
+
+		return true
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return positions, next, nil
+}
+
+// writeAddressIndex adds the positions of the transactions of the block to the index of their senders and recipients
+func writeAddressIndex(
+	batch storage.Batch,
+	number uint64,
+	hash types.Hash,
+	txs []*types.Transaction,
+	receipts []*types.Receipt,
+) error {
+	for idx, txn := range txs {
+		pos := storage.TxPosition{
+			Number:    number,
+			Index:     uint64(idx),
+			BlockHash: hash,
+		}
+
+		for _, addr := range txAddresses(txn, idx, receipts) {
+			if err := batch.WriteAddressIndex(addr, pos); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// txAddresses returns the sender and the recipient of the transaction,
+// the recipient of a contract creation is the address of the created contract
+func txAddresses(txn *types.Transaction, idx int, receipts []*types.Receipt) []types.Address {
+	addrs := make([]types.Address, 0, 2)
+
+	if txn.From != types.ZeroAddress {
+		addrs = append(addrs, txn.From)
+	}
+
+	to := txn.To
+	if to == nil && idx < len(receipts) {
+		to = receipts[idx].ContractAddress
+	}
+
+	if to != nil && *to != txn.From {
+		addrs = append(addrs, *to)
+	}
+
+	return addrs
+}
+
+// rewindAddressIndex moves the head of the address index to the block with the number,
+// the tail is moved down as well if the backfill hasn't reached the block yet
+func (b *Blockchain) rewindAddressIndex(batch storage.Batch, number uint64) error {
+	if tail, ok := b.db.ReadAddressIndexTail(); ok && tail > number+1 {
+		if err := batch.WriteAddressIndexTail(number + 1); err != nil {
+			return err
+		}
+	}
+
+	return batch.WriteAddressIndexHead(number)
+}
@@ -0,0 +1,141 @@
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockchain_AddressIndex(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addrA = types.StringToAddress("a")
+		addrB = types.StringToAddress("b")
+		addrC = types.StringToAddress("c")
+		addrD = types.StringToAddress("d")
+	)
+
+	newTx := func(nonce uint64, from types.Address, to *types.Address) *types.Transaction {
+		tx := &types.Transaction{
+			Nonce:    nonce,
+			From:     from,
+			To:       to,
+			Value:    big.NewInt(1),
+			GasPrice: big.NewInt(1),
+			V:        big.NewInt(1),
+		}
+		tx.ComputeHash()
+
+		return tx
+	}
+
+	headers := NewTestHeaders(10)
+	b := NewTestBlockchain(t, headers)
+
+	txs := map[uint64][]*types.Transaction{
+		2: {newTx(0, addrA, &addrB)},
+		// contract creation by B and the transaction of A to itself
+		5: {newTx(0, addrB, nil), newTx(1, addrA, &addrA)},
+	}
+
+	for _, header := range headers[1:] {
+		block := &types.Block{Header: header, Transactions: txs[header.Number]}
+		require.NoError(t, b.writeTestBody(block))
+
+		receipts := make([]*types.Receipt, len(block.Transactions))
+		for idx, tx := range block.Transactions {
+			receipts[idx] = &types.Receipt{TxHash: tx.Hash}
+		}
+
+		if header.Number == 5 {
+			receipts[0].SetContractAddress(addrC)
+		}
+
+		require.NoError(t, b.db.WriteReceipts(header.Hash, receipts))
+	}
+
+	readAll := func(t *testing.T, addr types.Address) []storage.TxPosition {
+		t.Helper()
+
+		positions, next, err := b.GetTransactionsByAddress(addr, storage.TxPosition{}, 10)
+		require.NoError(t, err)
+		assert.Nil(t, next)
+
+		return positions
+	}
+
+	pos := func(header *types.Header, index uint64) storage.TxPosition {
+		return storage.TxPosition{Number: header.Number, Index: index, BlockHash: header.Hash}
+	}
+
+	_, _, err := b.GetTransactionsByAddress(addrA, storage.TxPosition{}, 10)
+	assert.ErrorIs(t, err, ErrAddressIndexDisabled)
+
+	require.NoError(t, b.EnableAddressIndex())
+
+	// the existing blocks are not indexed until the backfill
+	tail, ok := b.AddressIndexTail()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(10), tail)
+	assert.Empty(t, readAll(t, addrA))
+
+	require.NoError(t, b.BackfillAddressIndex(make(chan struct{})))
+
+	tail, ok = b.AddressIndexTail()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(0), tail)
+
+	assert.Equal(t, []storage.TxPosition{pos(headers[2], 0), pos(headers[5], 1)}, readAll(t, addrA))
+	assert.Equal(t, []storage.TxPosition{pos(headers[2], 0), pos(headers[5], 0)}, readAll(t, addrB))
+	assert.Equal(t, []storage.TxPosition{pos(headers[5], 0)}, readAll(t, addrC))
+
+	t.Run("pagination", func(t *testing.T) {
+		positions, next, err := b.GetTransactionsByAddress(addrA, storage.TxPosition{}, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []storage.TxPosition{pos(headers[2], 0)}, positions)
+		require.NotNil(t, next)
+		assert.Equal(t, pos(headers[5], 1), *next)
+
+		positions, next, err = b.GetTransactionsByAddress(addrA, *next, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []storage.TxPosition{pos(headers[5], 1)}, positions)
+		assert.Nil(t, next)
+	})
+
+	// the index of the rewound blocks is deleted
+	_, err = b.SetHead(4)
+	require.NoError(t, err)
+
+	assert.Equal(t, []storage.TxPosition{pos(headers[2], 0)}, readAll(t, addrA))
+	assert.Empty(t, readAll(t, addrC))
+
+	indexedHead, ok := b.db.ReadAddressIndexHead()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(4), indexedHead)
+
+	// the written block is indexed
+	header := &types.Header{
+		Number:     5,
+		ParentHash: headers[4].Hash,
+		ExtraData:  []byte{0x1},
+	}
+	header.ComputeHash()
+
+	tx := newTx(2, addrA, &addrD)
+	block := &types.Block{Header: header, Transactions: []*types.Transaction{tx}}
+
+	b.receiptsCache.Add(header.Hash, []*types.Receipt{{TxHash: tx.Hash}})
+	require.NoError(t, b.WriteBlock(block, "test"))
+
+	assert.Equal(t, []storage.TxPosition{pos(headers[2], 0), pos(header, 0)}, readAll(t, addrA))
+	assert.Equal(t, []storage.TxPosition{pos(header, 0)}, readAll(t, addrD))
+
+	indexedHead, ok = b.db.ReadAddressIndexHead()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(5), indexedHead)
+}
@@ -4,13 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
-	"path/filepath"
 	"sync"
 	"sync/atomic"
 
 	"github.com/0xPolygon/polygon-edge/blockchain/storage"
-	"github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
-	"github.com/0xPolygon/polygon-edge/blockchain/storage/memory"
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/state"
@@ -38,6 +35,13 @@ var (
 	ErrInvalidStateRoot     = errors.New("invalid block state root")
 	ErrInvalidGasUsed       = errors.New("invalid block gas used")
 	ErrInvalidReceiptsRoot  = errors.New("invalid block receipts root")
+	ErrInvalidRewindTarget  = errors.New("rewind target is not below the current head")
+	ErrEmptyChain           = errors.New("chain has no head")
+	ErrChainNotEmpty        = errors.New("chain already has a head")
+	ErrInvalidSnapshotHead  = errors.New("invalid headers of the state snapshot")
+	ErrUntrustedCheckpoint  = errors.New("header doesn't match any checkpoint")
+	ErrCheckpointNotAhead   = errors.New("checkpoint is not above the current head")
+	ErrCheckpointStart      = errors.New("consensus doesn't support starting from a checkpoint")
 )
 
 // Blockchain is a blockchain reference
@@ -73,6 +77,10 @@ type Blockchain struct {
 
 	gpAverage *gasPriceAverage // A reference to the average gas price
 
+	// addressIndex is the flag indicating whether the transactions of the written blocks
+	// are added to the index of their senders and recipients, it's set before the blocks are written
+	addressIndex bool
+
 	writeLock sync.Mutex
 }
 
@@ -100,6 +108,12 @@ type TxSigner interface {
 	Sender(tx *types.Transaction) (types.Address, error)
 }
 
+// frozenStorage is the storage moving the old blocks to the freezer
+type frozenStorage interface {
+	// TruncateFrozen writes the batch and drops the blocks from the number from the freezer
+	TruncateFrozen(number uint64, batch storage.Batch) error
+}
+
 type BlockResult struct {
 	Root     types.Hash
 	Receipts []*types.Receipt
@@ -187,7 +201,7 @@ func (b *Blockchain) GetAvgGasPrice() *big.Int {
 // NewBlockchain creates a new blockchain object
 func NewBlockchain(
 	logger hclog.Logger,
-	dataDir string,
+	db storage.Storage,
 	config *chain.Chain,
 	consensus Verifier,
 	executor Executor,
@@ -199,6 +213,7 @@ func NewBlockchain(
 		consensus: consensus,
 		executor:  executor,
 		txSigner:  txSigner,
+		db:        db,
 		stream:    &eventStream{},
 		gpAverage: &gasPriceAverage{
 			price: big.NewInt(0),
@@ -206,26 +221,6 @@ func NewBlockchain(
 		},
 	}
 
-	var (
-		db  storage.Storage
-		err error
-	)
-
-	if dataDir == "" {
-		if db, err = memory.NewMemoryStorage(nil); err != nil {
-			return nil, err
-		}
-	} else {
-		if db, err = leveldb.NewLevelDBStorage(
-			filepath.Join(dataDir, "blockchain"),
-			logger,
-		); err != nil {
-			return nil, err
-		}
-	}
-
-	b.db = db
-
 	if err := b.initCaches(defaultCacheSize); err != nil {
 		return nil, err
 	}
@@ -275,14 +270,9 @@ func (b *Blockchain) ComputeGenesis() error {
 			return fmt.Errorf("genesis file does not match current genesis")
 		}
 
-		header, ok := b.GetHeaderByHash(head)
-		if !ok {
-			return fmt.Errorf("failed to get header with hash %s", head.String())
-		}
-
-		diff, ok := b.GetTD(head)
-		if !ok {
-			return fmt.Errorf("failed to read difficulty")
+		header, diff, err := b.loadHead(head)
+		if err != nil {
+			return err
 		}
 
 		b.logger.Info(
@@ -306,6 +296,93 @@ func (b *Blockchain) ComputeGenesis() error {
 	return nil
 }
 
+// loadHead returns the head and the total difficulty after checking the consistency of the head.
+// If the head was written partially, it rewinds the head to the latest block written completely
+func (b *Blockchain) loadHead(head types.Hash) (*types.Header, *big.Int, error) {
+	headNumber, ok := b.db.ReadHeadNumber()
+	if !ok {
+		header, ok := b.GetHeaderByHash(head)
+		if !ok {
+			return nil, nil, fmt.Errorf("failed to get header with hash %s", head.String())
+		}
+
+		headNumber = header.Number
+	}
+
+	if header, diff, ok := b.readCompleteBlock(head); ok && header.Number == headNumber {
+		if canonical, ok := b.db.ReadCanonicalHash(headNumber); ok && canonical == head {
+			return header, diff, nil
+		}
+	}
+
+	for number := headNumber; ; number-- {
+		if hash, ok := b.db.ReadCanonicalHash(number); ok {
+			if header, diff, ok := b.readCompleteBlock(hash); ok && header.Number == number {
+				b.logger.Warn(
+					"head was written partially, rewinding the head",
+					"from", headNumber,
+					"to", number,
+					"hash", hash,
+				)
+
+				if err := b.writeHead(header); err != nil {
+					return nil, nil, err
+				}
+
+				return header, diff, nil
+			}
+		}
+
+		if number == 0 {
+			return nil, nil, fmt.Errorf("failed to find consistent head below %d", headNumber)
+		}
+	}
+}
+
+// readCompleteBlock returns the header and the total difficulty
+// if all the data of the block has been written to the storage.
+// The blockchain without the executor (the light client) keeps only the headers
+func (b *Blockchain) readCompleteBlock(hash types.Hash) (*types.Header, *big.Int, bool) {
+	header, ok := b.readHeader(hash)
+	if !ok {
+		return nil, nil, false
+	}
+
+	diff, ok := b.readTotalDifficulty(hash)
+	if !ok {
+		return nil, nil, false
+	}
+
+	if header.Number == 0 || b.executor == nil {
+		return header, diff, true
+	}
+
+	if _, err := b.db.ReadBody(hash); err != nil {
+		return nil, nil, false
+	}
+
+	if _, err := b.db.ReadReceipts(hash); err != nil {
+		return nil, nil, false
+	}
+
+	return header, diff, true
+}
+
+// writeHead writes the header as the head of the chain
+func (b *Blockchain) writeHead(header *types.Header) error {
+	batch := b.db.NewBatch()
+
+	if err := batch.WriteHeadHash(header.Hash); err != nil {
+		return err
+	}
+
+	if err := batch.WriteHeadNumber(header.Number); err != nil {
+		return err
+	}
+
+	return batch.Write()
+}
+
 func (b *Blockchain) GetConsensus() Verifier {
 	return b.consensus
 }
@@ -429,16 +506,26 @@ func (b *Blockchain) writeGenesisImpl(header *types.Header) error {
 	// Update the reference
 	b.genesis = header.Hash
 
+	batch := b.db.NewBatch()
+
 	// Update the DB
-	if err := b.db.WriteHeader(header); err != nil {
+	if err := batch.WriteHeader(header); err != nil {
 		return err
 	}
 
 	// Advance the head
-	if _, err := b.advanceHead(header); err != nil {
+	newTD, err := b.advanceHead(batch, header)
+	if err != nil {
 		return err
 	}
 
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	// Update the blockchain reference
+	b.setCurrentHeader(header, newTD)
+
 	// Create an event and send it to the stream
 	event := &Event{}
 	event.AddNewHeader(header)
@@ -466,41 +553,40 @@ func (b *Blockchain) GetTD(hash types.Hash) (*big.Int, bool) {
 	return b.readTotalDifficulty(hash)
 }
 
-// writeCanonicalHeader writes the new header
-func (b *Blockchain) writeCanonicalHeader(event *Event, h *types.Header) error {
+// writeCanonicalHeader writes the new header into the batch and returns the new total difficulty
+func (b *Blockchain) writeCanonicalHeader(batch storage.Batch, event *Event, h *types.Header) (*big.Int, error) {
 	parentTD, ok := b.readTotalDifficulty(h.ParentHash)
 	if !ok {
-		return fmt.Errorf("parent difficulty not found")
+		return nil, fmt.Errorf("parent difficulty not found")
 	}
 
 	newTD := big.NewInt(0).Add(parentTD, new(big.Int).SetUint64(h.Difficulty))
-	if err := b.db.WriteCanonicalHeader(h, newTD); err != nil {
-		return err
+	if err := batch.WriteCanonicalHeader(h, newTD); err != nil {
+		return nil, err
 	}
 
 	event.Type = EventHead
 	event.AddNewHeader(h)
 	event.SetDifficulty(newTD)
 
-	b.setCurrentHeader(h, newTD)
-
-	return nil
+	return newTD, nil
 }
 
-// advanceHead Sets the passed in header as the new head of the chain
-func (b *Blockchain) advanceHead(newHeader *types.Header) (*big.Int, error) {
+// advanceHead writes the passed in header as the new head of the chain into the batch
+// and returns the new total difficulty
+func (b *Blockchain) advanceHead(batch storage.Batch, newHeader *types.Header) (*big.Int, error) {
 	// Write the current head hash into storage
-	if err := b.db.WriteHeadHash(newHeader.Hash); err != nil {
+	if err := batch.WriteHeadHash(newHeader.Hash); err != nil {
 		return nil, err
 	}
 
 	// Write the current head number into storage
-	if err := b.db.WriteHeadNumber(newHeader.Number); err != nil {
+	if err := batch.WriteHeadNumber(newHeader.Number); err != nil {
 		return nil, err
 	}
 
 	// Matches the current head number with the current hash
-	if err := b.db.WriteCanonicalHash(newHeader.Number, newHeader.Hash); err != nil {
+	if err := batch.WriteCanonicalHash(newHeader.Number, newHeader.Hash); err != nil {
 		return nil, err
 	}
 
@@ -518,13 +604,10 @@ func (b *Blockchain) advanceHead(newHeader *types.Header) (*big.Int, error) {
 
 	// Calculate the new total difficulty
 	newTD := big.NewInt(0).Add(parentTD, big.NewInt(0).SetUint64(newHeader.Difficulty))
-	if err := b.db.WriteTotalDifficulty(newHeader.Hash, newTD); err != nil {
+	if err := batch.WriteTotalDifficulty(newHeader.Hash, newTD); err != nil {
 		return nil, err
 	}
 
-	// Update the blockchain reference
-	b.setCurrentHeader(newHeader, newTD)
-
 	return newTD, nil
 }
 
@@ -663,7 +746,7 @@ func (b *Blockchain) WriteHeadersWithBodies(headers []*types.Header) error {
 	// Write the actual headers
 	for _, h := range headers {
 		event := &Event{}
-		if err := b.writeHeaderImpl(event, h); err != nil {
+		if err := b.writeHeader(event, h); err != nil {
 			return err
 		}
 
@@ -674,6 +757,100 @@ func (b *Blockchain) WriteHeadersWithBodies(headers []*types.Header) error {
 	return nil
 }
 
+// WriteFinalizedHeaders verifies the finalized headers by the consensus and writes them without bodies.
+// It's used by the light client that follows only the headers
+func (b *Blockchain) WriteFinalizedHeaders(headers []*types.Header, source string) error {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	for _, header := range headers {
+		if header.Number <= b.Header().Number {
+			continue
+		}
+
+		if header.ParentHash != b.Header().Hash {
+			return ErrParentHashMismatch
+		}
+
+		if err := b.consensus.VerifyHeader(header); err != nil {
+			return fmt.Errorf("failed to verify the header %d: %w", header.Number, err)
+		}
+
+		evnt := &Event{Source: source}
+		if err := b.writeHeader(evnt, header); err != nil {
+			return err
+		}
+
+		// update snapshot
+		if err := b.consensus.ProcessHeaders([]*types.Header{header}); err != nil {
+			return err
+		}
+
+		b.dispatchEvent(evnt)
+	}
+
+	if len(headers) > 0 {
+		b.logger.Info("new headers", "from", headers[0].Number, "to", headers[len(headers)-1].Number)
+	}
+
+	return nil
+}
+
+// CheckpointVerifier is the consensus which can start verifying the headers from a trusted checkpoint
+type CheckpointVerifier interface {
+	// ProcessCheckpointHeader initializes the consensus data from the header at the checkpoint
+	ProcessCheckpointHeader(header *types.Header) error
+}
+
+// WriteCheckpointHeader writes the header at the trusted checkpoint as the new head without its ancestors,
+// so that the following headers are verified from the checkpoint instead of the genesis.
+// The headers between the previous head and the checkpoint are not available,
+// and the total difficulty is counted from the checkpoint
+func (b *Blockchain) WriteCheckpointHeader(header *types.Header, source string) error {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	if hash, ok := b.config.Params.GetCheckpoint(header.Number); !ok || hash != header.Hash {
+		return fmt.Errorf("%w: %d %s", ErrUntrustedCheckpoint, header.Number, header.Hash)
+	}
+
+	if header.Number <= b.Header().Number {
+		return ErrCheckpointNotAhead
+	}
+
+	verifier, ok := b.consensus.(CheckpointVerifier)
+	if !ok {
+		return ErrCheckpointStart
+	}
+
+	if err := verifier.ProcessCheckpointHeader(header); err != nil {
+		return err
+	}
+
+	td := new(big.Int).SetUint64(header.Difficulty)
+	batch := b.db.NewBatch()
+
+	if err := batch.WriteCanonicalHeader(header, td); err != nil {
+		return err
+	}
+
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	b.setCurrentHeader(header, td)
+
+	evnt := &Event{Source: source, Type: EventHead}
+	evnt.AddNewHeader(header)
+	evnt.SetDifficulty(td)
+
+	b.dispatchEvent(evnt)
+
+	b.logger.Info("head set to checkpoint", "number", header.Number, "hash", header.Hash)
+
+	return nil
+}
+
 // VerifyPotentialBlock does the minimal block verification without consulting the
 // consensus layer. Should only be used if consensus checks are done
 // outside the method call
@@ -888,13 +1065,19 @@ func (b *Blockchain) WriteBlock(block *types.Block, source string) error {
 
 	header := block.Header
 
-	if err := b.writeBody(block); err != nil {
+	// The body, the receipts and the header including the head update are written
+	// in one batch, so a crash in the middle doesn't leave the head referring to missing data
+	batch := b.db.NewBatch()
+
+	if err := b.writeBody(batch, block); err != nil {
 		return err
 	}
 
 	// Write the header to the chain
 	evnt := &Event{Source: source}
-	if err := b.writeHeaderImpl(evnt, header); err != nil {
+
+	newTD, err := b.writeHeaderImpl(batch, evnt, header)
+	if err != nil {
 		return err
 	}
 
@@ -904,13 +1087,27 @@ func (b *Blockchain) WriteBlock(block *types.Block, source string) error {
 		return receiptsErr
 	}
 
-	// write the receipts, do it only after the header has been written.
-	// Otherwise, a client might ask for a header once the receipt is valid,
-	// but before it is written into the storage
-	if err := b.db.WriteReceipts(block.Hash(), blockReceipts); err != nil {
+	if err := batch.WriteReceipts(block.Hash(), blockReceipts); err != nil {
 		return err
 	}
 
+	if b.addressIndex {
+		if err := b.updateAddressIndex(batch, evnt, block, blockReceipts, newTD != nil); err != nil {
+			return err
+		}
+	}
+
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	// Update the headers cache once the header is persisted
+	b.headersCache.Add(header.Hash, header)
+
+	if newTD != nil {
+		b.setCurrentHeader(header, newTD)
+	}
+
 	// update snapshot
 	if err := b.consensus.ProcessHeaders([]*types.Header{header}); err != nil {
 		return err
@@ -978,9 +1175,9 @@ func (b *Blockchain) updateGasPriceAvgWithBlock(block *types.Block) {
 	b.updateGasPriceAvg(gasPrices)
 }
 
-// writeBody writes the block body to the DB.
+// writeBody writes the block body into the batch.
 // Additionally, it also updates the txn lookup, for txnHash -> block lookups
-func (b *Blockchain) writeBody(block *types.Block) error {
+func (b *Blockchain) writeBody(batch storage.Batch, block *types.Block) error {
 	// Recover 'from' field in tx before saving
 	// Because the block passed from the consensus layer doesn't have from field in tx,
 	// due to missing encoding in RLP
@@ -989,13 +1186,13 @@ func (b *Blockchain) writeBody(block *types.Block) error {
 	}
 
 	// Write the full body (txns + receipts)
-	if err := b.db.WriteBody(block.Header.Hash, block.Body()); err != nil {
+	if err := batch.WriteBody(block.Header.Hash, block.Body()); err != nil {
 		return err
 	}
 
 	// Write txn lookups (txHash -> block)
 	for _, txn := range block.Transactions {
-		if err := b.db.WriteTxLookup(txn.Hash, block.Hash()); err != nil {
+		if err := batch.WriteTxLookup(txn.Hash, block.Hash()); err != nil {
 			return err
 		}
 	}
@@ -1010,6 +1207,16 @@ func (b *Blockchain) ReadTxLookup(hash types.Hash) (types.Hash, bool) {
 	return v, ok
 }
 
+// WriteConsensusData writes the data of the consensus with the key to the storage
+func (b *Blockchain) WriteConsensusData(key []byte, data []byte) error {
+	return b.db.WriteConsensusData(key, data)
+}
+
+// ReadConsensusData reads the data of the consensus with the key from the storage
+func (b *Blockchain) ReadConsensusData(key []byte) ([]byte, bool) {
+	return b.db.ReadConsensusData(key)
+}
+
 // recoverFromFieldsInBlock recovers 'from' fields in the transactions of the given block
 // return error if the invalid signature found
 func (b *Blockchain) recoverFromFieldsInBlock(block *types.Block) error {
@@ -1130,18 +1337,42 @@ func (b *Blockchain) dispatchEvent(evnt *Event) {
 	b.stream.push(evnt)
 }
 
-// writeHeaderImpl writes a block and the data, assumes the genesis is already set
-func (b *Blockchain) writeHeaderImpl(evnt *Event, header *types.Header) error {
+// writeHeader writes a header in a batch and updates the head if the header becomes the new head
+func (b *Blockchain) writeHeader(evnt *Event, header *types.Header) error {
+	batch := b.db.NewBatch()
+
+	newTD, err := b.writeHeaderImpl(batch, evnt, header)
+	if err != nil {
+		return err
+	}
+
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	// Update the headers cache once the header is persisted
+	b.headersCache.Add(header.Hash, header)
+
+	if newTD != nil {
+		b.setCurrentHeader(header, newTD)
+	}
+
+	return nil
+}
+
+// writeHeaderImpl writes a block and the data into the batch, assumes the genesis is already set.
+// It returns the new total difficulty if the header becomes the new head, or nil otherwise
+func (b *Blockchain) writeHeaderImpl(batch storage.Batch, evnt *Event, header *types.Header) (*big.Int, error) {
 	currentHeader := b.Header()
 
 	// Write the data
 	if header.ParentHash == currentHeader.Hash {
 		// Fast path to save the new canonical header
-		return b.writeCanonicalHeader(evnt, header)
+		return b.writeCanonicalHeader(batch, evnt, header)
 	}
 
-	if err := b.db.WriteHeader(header); err != nil {
-		return err
+	if err := batch.WriteHeader(header); err != nil {
+		return nil, err
 	}
 
 	currentTD, ok := b.readTotalDifficulty(currentHeader.Hash)
@@ -1152,7 +1383,7 @@ func (b *Blockchain) writeHeaderImpl(evnt *Event, header *types.Header) error {
 	// parent total difficulty of incoming header
 	parentTD, ok := b.readTotalDifficulty(header.ParentHash)
 	if !ok {
-		return fmt.Errorf(
+		return nil, fmt.Errorf(
 			"parent of %s (%d) not found",
 			header.Hash.String(),
 			header.Number,
@@ -1160,40 +1391,35 @@ func (b *Blockchain) writeHeaderImpl(evnt *Event, header *types.Header) error {
 	}
 
 	// Write the difficulty
-	if err := b.db.WriteTotalDifficulty(
+	if err := batch.WriteTotalDifficulty(
 		header.Hash,
 		big.NewInt(0).Add(
 			parentTD,
 			big.NewInt(0).SetUint64(header.Difficulty),
 		),
 	); err != nil {
-		return err
+		return nil, err
 	}
 
-	// Update the headers cache
-	b.headersCache.Add(header.Hash, header)
-
 	incomingTD := big.NewInt(0).Add(parentTD, big.NewInt(0).SetUint64(header.Difficulty))
 	if incomingTD.Cmp(currentTD) > 0 {
 		// new block has higher difficulty, reorg the chain
-		if err := b.handleReorg(evnt, currentHeader, header); err != nil {
-			return err
-		}
-	} else {
-		// new block has lower difficulty, create a new fork
-		evnt.AddOldHeader(header)
-		evnt.Type = EventFork
+		return b.handleReorg(batch, evnt, currentHeader, header)
+	}
 
-		if err := b.writeFork(header); err != nil {
-			return err
-		}
+	// new block has lower difficulty, create a new fork
+	evnt.AddOldHeader(header)
+	evnt.Type = EventFork
+
+	if err := b.writeFork(batch, header); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return nil, nil
 }
 
-// writeFork writes the new header forks to the DB
-func (b *Blockchain) writeFork(header *types.Header) error {
+// writeFork writes the new header forks into the batch
+func (b *Blockchain) writeFork(batch storage.Batch, header *types.Header) error {
 	forks, err := b.db.ReadForks()
 	if err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
@@ -1212,19 +1438,20 @@ func (b *Blockchain) writeFork(header *types.Header) error {
 	}
 
 	newForks = append(newForks, header.Hash)
-	if err := b.db.WriteForks(newForks); err != nil {
+	if err := batch.WriteForks(newForks); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-// handleReorg handles a reorganization event
+// handleReorg handles a reorganization event and returns the new total difficulty
 func (b *Blockchain) handleReorg(
+	batch storage.Batch,
 	evnt *Event,
 	oldHeader *types.Header,
 	newHeader *types.Header,
-) error {
+) (*big.Int, error) {
 	newChainHead := newHeader
 	oldChainHead := oldHeader
 
@@ -1237,7 +1464,7 @@ func (b *Blockchain) handleReorg(
 	for oldHeader.Number > newHeader.Number {
 		oldHeader, ok = b.readHeader(oldHeader.ParentHash)
 		if !ok {
-			return fmt.Errorf("header '%s' not found", oldHeader.ParentHash.String())
+			return nil, fmt.Errorf("header '%s' not found", oldHeader.ParentHash.String())
 		}
 
 		oldChain = append(oldChain, oldHeader)
@@ -1247,7 +1474,7 @@ func (b *Blockchain) handleReorg(
 	for newHeader.Number > oldHeader.Number {
 		newHeader, ok = b.readHeader(newHeader.ParentHash)
 		if !ok {
-			return fmt.Errorf("header '%s' not found", newHeader.ParentHash.String())
+			return nil, fmt.Errorf("header '%s' not found", newHeader.ParentHash.String())
 		}
 
 		newChain = append(newChain, newHeader)
@@ -1256,12 +1483,12 @@ func (b *Blockchain) handleReorg(
 	for oldHeader.Hash != newHeader.Hash {
 		oldHeader, ok = b.readHeader(oldHeader.ParentHash)
 		if !ok {
-			return fmt.Errorf("header '%s' not found", oldHeader.ParentHash.String())
+			return nil, fmt.Errorf("header '%s' not found", oldHeader.ParentHash.String())
 		}
 
 		newHeader, ok = b.readHeader(newHeader.ParentHash)
 		if !ok {
-			return fmt.Errorf("header '%s' not found", newHeader.ParentHash.String())
+			return nil, fmt.Errorf("header '%s' not found", newHeader.ParentHash.String())
 		}
 
 		oldChain = append(oldChain, oldHeader)
@@ -1278,27 +1505,27 @@ func (b *Blockchain) handleReorg(
 		evnt.AddNewHeader(b)
 	}
 
-	if err := b.writeFork(oldChainHead); err != nil {
-		return fmt.Errorf("failed to write the old header as fork: %w", err)
+	if err := b.writeFork(batch, oldChainHead); err != nil {
+		return nil, fmt.Errorf("failed to write the old header as fork: %w", err)
 	}
 
 	// Update canonical chain numbers
 	for _, h := range newChain {
-		if err := b.db.WriteCanonicalHash(h.Number, h.Hash); err != nil {
-			return err
+		if err := batch.WriteCanonicalHash(h.Number, h.Hash); err != nil {
+			return nil, err
 		}
 	}
 
-	diff, err := b.advanceHead(newChainHead)
+	diff, err := b.advanceHead(batch, newChainHead)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Set the event type and difficulty
 	evnt.Type = EventReorg
 	evnt.SetDifficulty(diff)
 
-	return nil
+	return diff, nil
 }
 
 // GetForks returns the forks
@@ -1349,6 +1576,222 @@ func (b *Blockchain) GetBlockByNumber(blockNumber uint64, full bool) (*types.Blo
 	return b.GetBlockByHash(blockHash, full)
 }
 
+// SetHead rewinds the chain to the block with the given number.
+// The canonical hashes, the transaction lookups and the receipts of the blocks above the number are deleted
+// and the head is moved to the block. The headers and the bodies are kept like the ones of the forks,
+// except for the blocks dropped from the freezer. It returns the removed headers from the lowest one
+func (b *Blockchain) SetHead(number uint64) ([]*types.Header, error) {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	currentHeader := b.Header()
+	if number >= currentHeader.Number {
+		return nil, fmt.Errorf(
+			"%w, head %d, target %d",
+			ErrInvalidRewindTarget,
+			currentHeader.Number,
+			number,
+		)
+	}
+
+	header, ok := b.GetHeaderByNumber(number)
+	if !ok {
+		return nil, fmt.Errorf("header %d not found", number)
+	}
+
+	diff, ok := b.readTotalDifficulty(header.Hash)
+	if !ok {
+		return nil, fmt.Errorf("difficulty of header %d not found", number)
+	}
+
+	batch := b.db.NewBatch()
+	removed := make([]*types.Header, 0, currentHeader.Number-number)
+
+	for n := number + 1; n <= currentHeader.Number; n++ {
+		removedHeader, ok := b.GetHeaderByNumber(n)
+		if !ok {
+			return nil, fmt.Errorf("header %d not found", n)
+		}
+
+		if err := b.deleteCanonicalBlock(batch, removedHeader); err != nil {
+			return nil, err
+		}
+
+		removed = append(removed, removedHeader)
+	}
+
+	if err := batch.WriteHeadHash(header.Hash); err != nil {
+		return nil, err
+	}
+
+	if err := batch.WriteHeadNumber(header.Number); err != nil {
+		return nil, err
+	}
+
+	if b.addressIndex {
+		if err := b.rewindAddressIndex(batch, number); err != nil {
+			return nil, err
+		}
+	}
+
+	if frozen, ok := b.db.(frozenStorage); ok {
+		if err := frozen.TruncateFrozen(number+1, batch); err != nil {
+			return nil, err
+		}
+	} else if err := batch.Write(); err != nil {
+		return nil, err
+	}
+
+	// the headers dropped from the freezer may remain in the cache
+	b.headersCache.Purge()
+
+	b.setCurrentHeader(header, diff)
+
+	evnt := &Event{Type: EventRewind}
+
+	for _, h := range removed {
+		evnt.AddOldHeader(h)
+	}
+
+	evnt.AddNewHeader(header)
+	evnt.SetDifficulty(diff)
+
+	b.dispatchEvent(evnt)
+
+	b.logger.Info(
+		"chain rewound",
+		"from", currentHeader.Number,
+		"to", header.Number,
+		"hash", header.Hash,
+	)
+
+	return removed, nil
+}
+
+// deleteCanonicalBlock deletes the canonical hash, the transaction lookups, the address index
+// and the receipts of the canonical block into the batch
+func (b *Blockchain) deleteCanonicalBlock(batch storage.Batch, header *types.Header) error {
+	if err := batch.DeleteCanonicalHash(header.Number); err != nil {
+		return err
+	}
+
+	body, err := b.db.ReadBody(header.Hash)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+
+	if body != nil {
+		for _, txn := range body.Transactions {
+			if err := batch.DeleteTxLookup(txn.Hash); err != nil {
+				return err
+			}
+		}
+
+		if b.addressIndex {
+			if err := b.deleteAddressIndex(batch, header, body); err != nil {
+				return err
+			}
+		}
+	}
+
+	return batch.DeleteReceipts(header.Hash)
+}
+
+// RewindStorage rewinds the chain in the storage to the block with the given number
+// while the blockchain is not running. It returns the removed headers from the lowest one
+func RewindStorage(logger hclog.Logger, db storage.Storage, number uint64) ([]*types.Header, error) {
+	b, err := NewBlockchain(logger, db, nil, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	head, ok := db.ReadHeadHash()
+	if !ok {
+		return nil, ErrEmptyChain
+	}
+
+	header, diff, err := b.loadHead(head)
+	if err != nil {
+		return nil, err
+	}
+
+	b.setCurrentHeader(header, diff)
+
+	return b.SetHead(number)
+}
+
+// WriteSnapshotHead writes the head of the chain restored from a state snapshot to the empty storage.
+// The headers start with the genesis header followed by consecutive headers ending with the head,
+// the blocks between the genesis and the consecutive headers are not written.
+// The body and the receipts belong to the head, diff is the total difficulty of the head
+func WriteSnapshotHead(
+	db storage.Storage,
+	headers []*types.Header,
+	body *types.Body,
+	receipts []*types.Receipt,
+	diff *big.Int,
+) error {
+	if _, ok := db.ReadHeadHash(); ok {
+		return ErrChainNotEmpty
+	}
+
+	if len(headers) == 0 || headers[0].Number != 0 {
+		return ErrInvalidSnapshotHead
+	}
+
+	head := headers[len(headers)-1]
+	batch := db.NewBatch()
+
+	// the total difficulty of the consecutive headers is derived from the head
+	td := new(big.Int).Set(diff)
+
+	for i := len(headers) - 1; i >= 0; i-- {
+		header := headers[i]
+
+		if i == 0 {
+			td = new(big.Int).SetUint64(header.Difficulty)
+		}
+
+		if err := batch.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if err := batch.WriteCanonicalHash(header.Number, header.Hash); err != nil {
+			return err
+		}
+
+		if err := batch.WriteTotalDifficulty(header.Hash, td); err != nil {
+			return err
+		}
+
+		td = new(big.Int).Sub(td, new(big.Int).SetUint64(header.Difficulty))
+	}
+
+	if err := batch.WriteBody(head.Hash, body); err != nil {
+		return err
+	}
+
+	for _, txn := range body.Transactions {
+		if err := batch.WriteTxLookup(txn.Hash, head.Hash); err != nil {
+			return err
+		}
+	}
+
+	if err := batch.WriteReceipts(head.Hash, receipts); err != nil {
+		return err
+	}
+
+	if err := batch.WriteHeadHash(head.Hash); err != nil {
+		return err
+	}
+
+	if err := batch.WriteHeadNumber(head.Number); err != nil {
+		return err
+	}
+
+	return batch.Write()
+}
+
 // Close closes the DB connection
 func (b *Blockchain) Close() error {
 	return b.db.Close()
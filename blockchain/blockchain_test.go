@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/freezer"
 	"github.com/0xPolygon/polygon-edge/blockchain/storage/memory"
 	"github.com/0xPolygon/polygon-edge/types"
 )
@@ -25,7 +26,7 @@ func TestGenesis(t *testing.T) {
 	genesis := &types.Header{Difficulty: 1, Number: 0}
 	genesis.ComputeHash()
 
-	_, err := b.advanceHead(genesis)
+	_, err := b.advanceTestHead(genesis)
 	assert.NoError(t, err)
 
 	header := b.Header()
@@ -533,7 +534,7 @@ func TestForkUnknownParents(t *testing.T) {
 	h1 := AppendNewTestHeaders(h0[:5], 10)
 
 	// Write genesis
-	_, err := b.advanceHead(h0[0])
+	_, err := b.advanceTestHead(h0[0])
 	assert.NoError(t, err)
 
 	// Write 10 headers
@@ -594,7 +595,7 @@ func TestBlockchainWriteBody(t *testing.T) {
 
 		assert.NoError(
 			t,
-			chain.writeBody(block),
+			chain.writeTestBody(block),
 		)
 	})
 
@@ -623,7 +624,7 @@ func TestBlockchainWriteBody(t *testing.T) {
 		assert.ErrorIs(
 			t,
 			errRecoveryAddressFailed,
-			chain.writeBody(block),
+			chain.writeTestBody(block),
 		)
 	})
 
@@ -651,7 +652,7 @@ func TestBlockchainWriteBody(t *testing.T) {
 
 		chain := newChain(t, txFromByTxHash)
 
-		assert.NoError(t, chain.writeBody(block))
+		assert.NoError(t, chain.writeTestBody(block))
 
 		readBody, ok := chain.readBody(block.Hash())
 		assert.True(t, ok)
@@ -886,7 +887,7 @@ func TestBlockchainReadBody(t *testing.T) {
 
 	txFromByTxHash[tx.Hash] = types.ZeroAddress
 
-	if err := b.writeBody(block); err != nil {
+	if err := b.writeTestBody(block); err != nil {
 		t.Fatal(err)
 	}
 
@@ -1330,3 +1331,425 @@ func TestBlockchain_VerifyBlockBody(t *testing.T) {
 		assert.ErrorIs(t, blockchain.verifyBlockBody(block), errUnableToExecute)
 	})
 }
+
+func TestBlockchain_WriteFinalizedHeaders(t *testing.T) {
+	t.Parallel()
+
+	var (
+		errInvalidSeals = errors.New("invalid seals")
+		headers         = NewTestHeaders(10)
+	)
+
+	t.Run("should verify and write the headers in order", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTestBlockchain(t, nil)
+
+		_, err := b.advanceTestHead(headers[0])
+		assert.NoError(t, err)
+
+		verified := make([]uint64, 0)
+		processed := make([]uint64, 0)
+
+		verifier := &MockVerifier{}
+		verifier.HookVerifyHeader(func(h *types.Header) error {
+			verified = append(verified, h.Number)
+
+			return nil
+		})
+		verifier.HookProcessHeaders(func(hs []*types.Header) error {
+			for _, h := range hs {
+				processed = append(processed, h.Number)
+			}
+
+			return nil
+		})
+
+		b.SetConsensus(verifier)
+
+		assert.NoError(t, b.WriteFinalizedHeaders(headers[1:], "test"))
+		assert.Equal(t, headers[9].Hash, b.Header().Hash)
+		assert.Equal(t, []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9}, verified)
+		assert.Equal(t, verified, processed)
+
+		// written headers are skipped
+		assert.NoError(t, b.WriteFinalizedHeaders(headers[5:], "test"))
+		assert.Len(t, verified, 9)
+	})
+
+	t.Run("should stop at the header failing verification", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTestBlockchain(t, nil)
+
+		_, err := b.advanceTestHead(headers[0])
+		assert.NoError(t, err)
+
+		verifier := &MockVerifier{}
+		verifier.HookVerifyHeader(func(h *types.Header) error {
+			if h.Number == 5 {
+				return errInvalidSeals
+			}
+
+			return nil
+		})
+
+		b.SetConsensus(verifier)
+
+		assert.ErrorIs(t, b.WriteFinalizedHeaders(headers[1:], "test"), errInvalidSeals)
+		assert.Equal(t, headers[4].Hash, b.Header().Hash)
+	})
+
+	t.Run("should return error if the header doesn't follow the head", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTestBlockchain(t, nil)
+
+		_, err := b.advanceTestHead(headers[0])
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, b.WriteFinalizedHeaders(headers[2:], "test"), ErrParentHashMismatch)
+		assert.Equal(t, headers[0].Hash, b.Header().Hash)
+	})
+}
+
+// failingBatchStorage is the storage whose batches fail to be written
+type failingBatchStorage struct {
+	storage.Storage
+}
+
+func (s *failingBatchStorage) NewBatch() storage.Batch {
+	return &failingBatch{s.Storage.NewBatch()}
+}
+
+type failingBatch struct {
+	storage.Batch
+}
+
+var errBatchWrite = errors.New("failed to write batch")
+
+func (b *failingBatch) Write() error {
+	return errBatchWrite
+}
+
+func TestBlockchain_WriteHeaders_KeepsCacheOnFailedBatch(t *testing.T) {
+	t.Parallel()
+
+	headers := NewTestHeaders(3)
+	forkHeaders := AppendNewTestheadersWithSeed(headers[:1], 1, 1)
+
+	b := NewTestBlockchain(t, headers[:2])
+	b.db = &failingBatchStorage{b.db}
+
+	// the header extending the head
+	assert.ErrorIs(t, b.WriteHeaders(headers[2:]), errBatchWrite)
+
+	// the header of the fork
+	assert.ErrorIs(t, b.WriteHeaders(forkHeaders[1:]), errBatchWrite)
+
+	for _, header := range []*types.Header{headers[2], forkHeaders[1]} {
+		_, ok := b.GetHeaderByHash(header.Hash)
+		assert.False(t, ok)
+	}
+
+	assert.Equal(t, headers[1].Hash, b.Header().Hash)
+}
+
+func TestBlockchain_ComputeGenesis_RewindsPartialHead(t *testing.T) {
+	t.Parallel()
+
+	newChainWithPartialHead := func(t *testing.T, writesBlockData bool) (*Blockchain, *types.Header) {
+		t.Helper()
+
+		b, err := newBlockChain(&chain.Chain{
+			Genesis: &chain.Genesis{},
+			Params: &chain.Params{
+				Forks: chain.AllForksEnabled,
+			},
+		}, nil)
+		assert.NoError(t, err)
+
+		genesis := b.Header()
+
+		header := &types.Header{
+			Number:     1,
+			ParentHash: genesis.Hash,
+			ExtraData:  []byte{},
+		}
+		header.ComputeHash()
+
+		// simulates the write of the head without the batch interrupted by a crash
+		assert.NoError(t, b.db.WriteHeader(header))
+		assert.NoError(t, b.db.WriteTotalDifficulty(header.Hash, big.NewInt(1)))
+		assert.NoError(t, b.db.WriteCanonicalHash(header.Number, header.Hash))
+		assert.NoError(t, b.db.WriteHeadHash(header.Hash))
+		assert.NoError(t, b.db.WriteHeadNumber(header.Number))
+
+		if writesBlockData {
+			assert.NoError(t, b.db.WriteBody(header.Hash, &types.Body{}))
+			assert.NoError(t, b.db.WriteReceipts(header.Hash, []*types.Receipt{}))
+		}
+
+		return b, header
+	}
+
+	t.Run("should rewind the head missing the body and the receipts", func(t *testing.T) {
+		t.Parallel()
+
+		b, _ := newChainWithPartialHead(t, false)
+
+		assert.NoError(t, b.ComputeGenesis())
+		assert.Equal(t, uint64(0), b.Header().Number)
+
+		head, ok := b.db.ReadHeadHash()
+		assert.True(t, ok)
+		assert.Equal(t, b.Genesis(), head)
+
+		headNumber, ok := b.db.ReadHeadNumber()
+		assert.True(t, ok)
+		assert.Equal(t, uint64(0), headNumber)
+	})
+
+	t.Run("should keep the head written completely", func(t *testing.T) {
+		t.Parallel()
+
+		b, header := newChainWithPartialHead(t, true)
+
+		assert.NoError(t, b.ComputeGenesis())
+		assert.Equal(t, header.Hash, b.Header().Hash)
+	})
+}
+
+func TestBlockchain_ReadFrozenBlocks(t *testing.T) {
+	t.Parallel()
+
+	headers := NewTestHeaders(10)
+	b := NewTestBlockchain(t, headers)
+
+	// NewTestBlockchain advances the head to the first header without writing it
+	assert.NoError(t, b.db.WriteHeader(headers[0]))
+
+	receipts := []*types.Receipt{
+		{
+			CumulativeGasUsed: 1,
+			TxHash:            types.StringToHash("1"),
+		},
+	}
+
+	assert.NoError(t, b.db.WriteReceipts(headers[5].Hash, receipts))
+
+	f, err := freezer.Open(t.TempDir(), true)
+	assert.NoError(t, err)
+
+	freezerStorage, err := freezer.NewStorage(hclog.NewNullLogger(), b.db, f, 3)
+	assert.NoError(t, err)
+
+	defer freezerStorage.Close()
+
+	assert.NoError(t, freezerStorage.Freeze(b.Header().Number))
+	assert.Equal(t, uint64(7), freezerStorage.Frozen())
+
+	// read the frozen blocks from the storage instead of the cache
+	b.db = freezerStorage
+	assert.NoError(t, b.initCaches(defaultCacheSize))
+
+	for _, header := range headers {
+		block, ok := b.GetBlockByNumber(header.Number, false)
+		assert.True(t, ok)
+		assert.Equal(t, header.Hash, block.Hash())
+	}
+
+	readReceipts, err := b.GetReceiptsByHash(headers[5].Hash)
+	assert.NoError(t, err)
+	assert.Equal(t, receipts, readReceipts)
+}
+
+type mockCheckpointVerifier struct {
+	*MockVerifier
+
+	processed []*types.Header
+}
+
+func (m *mockCheckpointVerifier) ProcessCheckpointHeader(header *types.Header) error {
+	m.processed = append(m.processed, header)
+
+	return nil
+}
+
+func TestBlockchain_WriteCheckpointHeader(t *testing.T) {
+	t.Parallel()
+
+	headers := NewTestHeaders(10)
+
+	b := NewTestBlockchain(t, nil)
+	b.config.Params.Checkpoints = []*chain.Checkpoint{
+		{Number: 5, Hash: headers[5].Hash},
+	}
+
+	_, err := b.advanceTestHead(headers[0])
+	assert.NoError(t, err)
+
+	// the header must match the checkpoint
+	assert.ErrorIs(t, b.WriteCheckpointHeader(headers[4], "test"), ErrUntrustedCheckpoint)
+
+	// the consensus must be able to start from the checkpoint
+	b.SetConsensus(&MockVerifier{})
+	assert.ErrorIs(t, b.WriteCheckpointHeader(headers[5], "test"), ErrCheckpointStart)
+
+	verifier := &mockCheckpointVerifier{MockVerifier: &MockVerifier{}}
+	b.SetConsensus(verifier)
+
+	sub := b.SubscribeEvents()
+	defer sub.Close()
+
+	assert.NoError(t, b.WriteCheckpointHeader(headers[5], "test"))
+	assert.Equal(t, []*types.Header{headers[5]}, verifier.processed)
+	assert.Equal(t, headers[5].Hash, b.Header().Hash)
+
+	headHash, ok := b.db.ReadHeadHash()
+	assert.True(t, ok)
+	assert.Equal(t, headers[5].Hash, headHash)
+
+	// the headers before the checkpoint are not available
+	_, ok = b.GetHeaderByNumber(4)
+	assert.False(t, ok)
+
+	evnt := sub.GetEvent()
+	assert.Equal(t, EventHead, evnt.Type)
+	assert.Equal(t, headers[5].Hash, evnt.Header().Hash)
+
+	// the following headers are written on top of the checkpoint
+	assert.NoError(t, b.WriteFinalizedHeaders(headers[6:], "test"))
+	assert.Equal(t, headers[9].Hash, b.Header().Hash)
+
+	// the checkpoint must be above the head
+	assert.ErrorIs(t, b.WriteCheckpointHeader(headers[5], "test"), ErrCheckpointNotAhead)
+}
+
+func TestBlockchain_SetHead(t *testing.T) {
+	t.Parallel()
+
+	headers := NewTestHeaders(10)
+	b := NewTestBlockchain(t, headers)
+
+	tx := &types.Transaction{
+		Value: big.NewInt(1),
+		V:     big.NewInt(1),
+		From:  types.StringToAddress("1"),
+	}
+	tx.ComputeHash()
+
+	block := &types.Block{
+		Header:       headers[7],
+		Transactions: []*types.Transaction{tx},
+	}
+
+	assert.NoError(t, b.writeTestBody(block))
+	assert.NoError(t, b.db.WriteReceipts(headers[7].Hash, []*types.Receipt{{TxHash: tx.Hash}}))
+
+	sub := b.SubscribeEvents()
+	defer sub.Close()
+
+	_, err := b.SetHead(9)
+	assert.ErrorIs(t, err, ErrInvalidRewindTarget)
+
+	removed, err := b.SetHead(4)
+	assert.NoError(t, err)
+	assert.Equal(t, headers[5:], removed)
+
+	assert.Equal(t, headers[4].Hash, b.Header().Hash)
+
+	headHash, ok := b.db.ReadHeadHash()
+	assert.True(t, ok)
+	assert.Equal(t, headers[4].Hash, headHash)
+
+	headNumber, ok := b.db.ReadHeadNumber()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(4), headNumber)
+
+	for _, header := range headers[5:] {
+		_, ok := b.GetHeaderByNumber(header.Number)
+		assert.False(t, ok)
+
+		// the headers are kept like the ones of the forks
+		_, ok = b.GetHeaderByHash(header.Hash)
+		assert.True(t, ok)
+	}
+
+	_, ok = b.ReadTxLookup(tx.Hash)
+	assert.False(t, ok)
+
+	_, err = b.GetReceiptsByHash(headers[7].Hash)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	evnt := sub.GetEvent()
+	assert.Equal(t, EventRewind, evnt.Type)
+	assert.Equal(t, headers[4].Hash, evnt.Header().Hash)
+	assert.Len(t, evnt.OldChain, 5)
+}
+
+func TestBlockchain_SetHead_Frozen(t *testing.T) {
+	t.Parallel()
+
+	headers := NewTestHeaders(10)
+	b := NewTestBlockchain(t, headers)
+
+	// NewTestBlockchain advances the head to the first header without writing it
+	assert.NoError(t, b.db.WriteHeader(headers[0]))
+
+	f, err := freezer.Open(t.TempDir(), true)
+	assert.NoError(t, err)
+
+	freezerStorage, err := freezer.NewStorage(hclog.NewNullLogger(), b.db, f, 2)
+	assert.NoError(t, err)
+
+	defer freezerStorage.Close()
+
+	assert.NoError(t, freezerStorage.Freeze(b.Header().Number))
+	assert.Equal(t, uint64(8), freezerStorage.Frozen())
+
+	b.db = freezerStorage
+
+	_, err = b.SetHead(5)
+	assert.NoError(t, err)
+
+	assert.Equal(t, uint64(6), freezerStorage.Frozen())
+
+	frozen, ok := freezerStorage.ReadFrozenCount()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(6), frozen)
+
+	_, ok = b.GetHeaderByHash(headers[5].Hash)
+	assert.True(t, ok)
+
+	// the blocks dropped from the freezer are deleted
+	_, ok = b.GetHeaderByHash(headers[6].Hash)
+	assert.False(t, ok)
+
+	// the blocks in the key-value storage are kept
+	_, ok = b.GetHeaderByHash(headers[8].Hash)
+	assert.True(t, ok)
+}
+
+func TestRewindStorage(t *testing.T) {
+	t.Parallel()
+
+	headers := NewTestHeaders(5)
+	b := NewTestBlockchain(t, headers)
+
+	_, err := RewindStorage(hclog.NewNullLogger(), b.db, 2)
+	assert.NoError(t, err)
+
+	headNumber, ok := b.db.ReadHeadNumber()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), headNumber)
+
+	_, err = RewindStorage(hclog.NewNullLogger(), b.db, 2)
+	assert.ErrorIs(t, err, ErrInvalidRewindTarget)
+
+	empty, err := memory.NewMemoryStorage(nil)
+	assert.NoError(t, err)
+
+	_, err = RewindStorage(hclog.NewNullLogger(), empty, 0)
+	assert.ErrorIs(t, err, ErrEmptyChain)
+}
@@ -0,0 +1,120 @@
+package bloombits
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/golang/snappy"
+)
+
+const (
+	// SectionSize is the number of the blocks in a section of the bloom bits index
+	SectionSize = 4096
+
+	// BloomBitLength is the number of the bits in the bloom of a header
+	BloomBitLength = types.BloomByteLength * 8
+
+	// BitsetLength is the length of the bitset of a bloom bit over the blocks of a section
+	BitsetLength = SectionSize / 8
+)
+
+var (
+	errUnexpectedBlock   = errors.New("unexpected block index in the section")
+	errSectionIncomplete = errors.New("section is not complete")
+	errInvalidBit        = errors.New("invalid bloom bit")
+	errInvalidBitset     = errors.New("invalid length of the bitset")
+)
+
+// BloomIndexes returns the indexes of the three bits set in the bloom for the data.
+// The bits are indexed from the most significant bit of the first byte of the bloom
+func BloomIndexes(data []byte) [3]uint {
+	hash := crypto.Keccak256(data)
+
+	var indexes [3]uint
+
+	for i := range indexes {
+		// the bit location counted from the least significant bit of the last byte, see types.Bloom
+		bit := (uint(hash[2*i+1]) + (uint(hash[2*i]) << 8)) & (BloomBitLength - 1)
+		indexes[i] = BloomBitLength - 1 - bit
+	}
+
+	return indexes
+}
+
+// IsSet returns whether the bit of the block with the index in the section is set in the bitset
+func IsSet(bitset []byte, index uint64) bool {
+	return bitset[index/8]&(1<<(7-index%8)) != 0
+}
+
+// Generator transposes the blooms of the blocks of a section
+// into the bitsets of the bloom bits over the blocks
+type Generator struct {
+	bitsets [BloomBitLength][BitsetLength]byte
+	next    uint64
+}
+
+// NewGenerator creates the generator of the bitsets of a section
+func NewGenerator() *Generator {
+	return &Generator{}
+}
+
+// AddBloom adds the bloom of the block with the index in the section,
+// the blocks are added in the order from the first block of the section
+func (g *Generator) AddBloom(index uint64, bloom types.Bloom) error {
+	if index != g.next || index >= SectionSize {
+		return fmt.Errorf("%w: expected %d but got %d", errUnexpectedBlock, g.next, index)
+	}
+
+	blockByte, blockMask := index/8, byte(1)<<(7-index%8)
+
+	for i, b := range bloom {
+		if b == 0 {
+			continue
+		}
+
+		for j := 0; j < 8; j++ {
+			if b&(1<<(7-j)) != 0 {
+				g.bitsets[i*8+j][blockByte] |= blockMask
+			}
+		}
+	}
+
+	g.next++
+
+	return nil
+}
+
+// Bitset returns the bitset of the bloom bit over the blocks of the complete section,
+// the bit of the first block of the section is the most significant bit of the first byte
+func (g *Generator) Bitset(bit uint) ([]byte, error) {
+	if g.next != SectionSize {
+		return nil, errSectionIncomplete
+	}
+
+	if bit >= BloomBitLength {
+		return nil, fmt.Errorf("%w: %d", errInvalidBit, bit)
+	}
+
+	return g.bitsets[bit][:], nil
+}
+
+// CompressBitset compresses the bitset for the storage, most of the bitsets are sparse
+func CompressBitset(bitset []byte) []byte {
+	return snappy.Encode(nil, bitset)
+}
+
+// DecompressBitset decompresses the bitset compressed by CompressBitset
+func DecompressBitset(data []byte) ([]byte, error) {
+	bitset, err := snappy.Decode(nil, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bitset) != BitsetLength {
+		return nil, fmt.Errorf("%w: %d", errInvalidBitset, len(bitset))
+	}
+
+	return bitset, nil
+}
@@ -0,0 +1,88 @@
+package bloombits
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bloomOf(logs ...*types.Log) types.Bloom {
+	return types.CreateBloom([]*types.Receipt{{Logs: logs}})
+}
+
+func TestBloomIndexes(t *testing.T) {
+	t.Parallel()
+
+	addr := types.StringToAddress("1")
+	bloom := bloomOf(&types.Log{Address: addr})
+
+	for _, index := range BloomIndexes(addr.Bytes()) {
+		assert.NotZero(t, bloom[index/8]&(1<<(7-index%8)))
+	}
+
+	assert.True(t, NewMatcher([][][]byte{{addr.Bytes()}}).MatchBloom(bloom))
+}
+
+func TestGenerator(t *testing.T) {
+	t.Parallel()
+
+	addrA := types.StringToAddress("a")
+	addrB := types.StringToAddress("b")
+	topic := types.StringToHash("topic")
+
+	blooms := map[uint64]types.Bloom{
+		0:               bloomOf(&types.Log{Address: addrA}),
+		10:              bloomOf(&types.Log{Address: addrB, Topics: []types.Hash{topic}}),
+		SectionSize - 1: bloomOf(&types.Log{Address: addrA, Topics: []types.Hash{topic}}),
+	}
+
+	gen := NewGenerator()
+
+	_, err := gen.Bitset(0)
+	assert.ErrorIs(t, err, errSectionIncomplete)
+
+	assert.ErrorIs(t, gen.AddBloom(1, types.Bloom{}), errUnexpectedBlock)
+
+	for i := uint64(0); i < SectionSize; i++ {
+		require.NoError(t, gen.AddBloom(i, blooms[i]))
+	}
+
+	_, err = gen.Bitset(BloomBitLength)
+	assert.ErrorIs(t, err, errInvalidBit)
+
+	bitset := func(bit uint) ([]byte, error) {
+		bits, err := gen.Bitset(bit)
+		if err != nil {
+			return nil, err
+		}
+
+		// the bitsets go through the storage
+		return DecompressBitset(CompressBitset(bits))
+	}
+
+	matches := func(filter [][][]byte) []uint64 {
+		res, err := NewMatcher(filter).Match(bitset)
+		require.NoError(t, err)
+
+		var blocks []uint64
+
+		for i := uint64(0); i < SectionSize; i++ {
+			if IsSet(res, i) {
+				blocks = append(blocks, i)
+			}
+		}
+
+		return blocks
+	}
+
+	assert.Equal(t, []uint64{0, SectionSize - 1}, matches([][][]byte{{addrA.Bytes()}}))
+	assert.Equal(t, []uint64{0, 10, SectionSize - 1}, matches([][][]byte{{addrA.Bytes(), addrB.Bytes()}}))
+	assert.Equal(t, []uint64{10, SectionSize - 1}, matches([][][]byte{nil, {topic.Bytes()}}))
+	assert.Equal(t, []uint64{SectionSize - 1}, matches([][][]byte{{addrA.Bytes()}, {topic.Bytes()}}))
+	assert.Empty(t, matches([][][]byte{{types.StringToAddress("c").Bytes()}}))
+
+	_, err = DecompressBitset(CompressBitset([]byte{0x1}))
+	assert.ErrorIs(t, err, errInvalidBitset)
+}
@@ -0,0 +1,117 @@
+package bloombits
+
+import (
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Matcher finds the blocks whose blooms may contain the logs matching a filter.
+// The filter consists of the groups of the values, a matching log has one of the values of every group
+type Matcher struct {
+	groups [][][3]uint
+}
+
+// NewMatcher creates the matcher of the filter, the empty groups match any log
+func NewMatcher(filter [][][]byte) *Matcher {
+	m := &Matcher{
+		groups: make([][][3]uint, 0, len(filter)),
+	}
+
+	for _, group := range filter {
+		if len(group) == 0 {
+			continue
+		}
+
+		indexes := make([][3]uint, len(group))
+		for i, data := range group {
+			indexes[i] = BloomIndexes(data)
+		}
+
+		m.groups = append(m.groups, indexes)
+	}
+
+	return m
+}
+
+// Empty returns whether the matcher matches any log, so the blooms can't skip any block
+func (m *Matcher) Empty() bool {
+	return len(m.groups) == 0
+}
+
+// MatchBloom returns whether the bloom may contain the logs matching the filter
+func (m *Matcher) MatchBloom(bloom types.Bloom) bool {
+	isSet := func(index uint) bool {
+		return bloom[index/8]&(1<<(7-index%8)) != 0
+	}
+
+	for _, group := range m.groups {
+		match := false
+
+		for _, indexes := range group {
+			if isSet(indexes[0]) && isSet(indexes[1]) && isSet(indexes[2]) {
+				match = true
+
+				break
+			}
+		}
+
+		if !match {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Match returns the bitset of the blocks of a section whose blooms may contain the logs matching the filter,
+// bitset returns the bitset of the bloom bit over the blocks of the section
+func (m *Matcher) Match(bitset func(bit uint) ([]byte, error)) ([]byte, error) {
+	bitsets := make(map[uint][]byte)
+
+	read := func(bit uint) ([]byte, error) {
+		if bits, ok := bitsets[bit]; ok {
+			return bits, nil
+		}
+
+		bits, err := bitset(bit)
+		if err != nil {
+			return nil, err
+		}
+
+		bitsets[bit] = bits
+
+		return bits, nil
+	}
+
+	res := make([]byte, BitsetLength)
+	for i := range res {
+		res[i] = 0xff
+	}
+
+	for _, group := range m.groups {
+		groupRes := make([]byte, BitsetLength)
+
+		for _, indexes := range group {
+			valueRes := make([]byte, BitsetLength)
+			copy(valueRes, res)
+
+			for _, index := range indexes {
+				bits, err := read(index)
+				if err != nil {
+					return nil, err
+				}
+
+				for i := range valueRes {
+					valueRes[i] &= bits[i]
+				}
+			}
+
+			for i := range groupRes {
+				groupRes[i] |= valueRes[i]
+			}
+		}
+
+		res = groupRes
+	}
+
+	return res, nil
+}
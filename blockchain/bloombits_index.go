@@ -0,0 +1,169 @@
+package blockchain
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/bloombits"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// bloomBitsConfirmations is the number of the blocks on top of a section
+	// before it's added to the bloom bits index, so that the index is rarely affected by the reorgs
+	bloomBitsConfirmations = 64
+)
+
+// BloomBitsSections returns the number of the sections of the blocks in the bloom bits index
+func (b *Blockchain) BloomBitsSections() uint64 {
+	sections, _ := b.db.ReadBloomBitsSections()
+
+	return sections
+}
+
+// IndexBloomBits adds the sections of the blocks confirmed by the head to the bloom bits index,
+// until all of them are indexed or stopCh is closed
+func (b *Blockchain) IndexBloomBits(stopCh <-chan struct{}) error {
+	for {
+		select {
+		case <-stopCh:
+			return nil
+		default:
+		}
+
+		indexed, err := b.indexBloomBitsSection()
+		if err != nil {
+			return err
+		}
+
+		if !indexed {
+			return nil
+		}
+	}
+}
+
+// indexBloomBitsSection adds the next section to the bloom bits index
+// and returns false if there is no confirmed section to be indexed
+func (b *Blockchain) indexBloomBitsSection() (bool, error) {
+	section := b.firstStaleBloomBitsSection()
+
+	start := section * bloombits.SectionSize
+	end := start + bloombits.SectionSize - 1
+
+	if b.Header().Number < end+bloomBitsConfirmations {
+		return false, nil
+	}
+
+	// the blooms are read without the lock, the section is verified against the chain before it's written
+	gen := bloombits.NewGenerator()
+
+	var endHash types.Hash
+
+	for number := start; number <= end; number++ {
+		// the genesis block has no logs
+		if number == 0 {
+			if err := gen.AddBloom(0, types.Bloom{}); err != nil {
+				return false, err
+			}
+
+			continue
+		}
+
+		header, ok := b.GetHeaderByNumber(number)
+		if !ok {
+			return false, fmt.Errorf("header %d not found", number)
+		}
+
+		if err := gen.AddBloom(number-start, header.LogsBloom); err != nil {
+			return false, err
+		}
+
+		endHash = header.Hash
+	}
+
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	if hash, ok := b.db.ReadCanonicalHash(end); !ok || hash != endHash || b.firstStaleBloomBitsSection() != section {
+		// the chain has changed meanwhile, the section is indexed again by the next call
+		return true, nil
+	}
+
+	batch := b.db.NewBatch()
+
+	for bit := uint(0); bit < bloombits.BloomBitLength; bit++ {
+		bitset, err := gen.Bitset(bit)
+		if err != nil {
+			return false, err
+		}
+
+		if err := batch.WriteBloomBits(bit, section, bloombits.CompressBitset(bitset)); err != nil {
+			return false, err
+		}
+	}
+
+	if err := batch.WriteBloomBitsSectionHead(section, endHash); err != nil {
+		return false, err
+	}
+
+	if err := batch.WriteBloomBitsSections(section + 1); err != nil {
+		return false, err
+	}
+
+	if err := batch.Write(); err != nil {
+		return false, err
+	}
+
+	b.logger.Debug("indexed bloom bits", "section", section, "from", start, "to", end)
+
+	return true, nil
+}
+
+// firstStaleBloomBitsSection returns the number of the indexed sections
+// which are still the part of the canonical chain, the sections above them are indexed again
+func (b *Blockchain) firstStaleBloomBitsSection() uint64 {
+	sections := b.BloomBitsSections()
+
+	for sections > 0 {
+		if b.isCanonicalBloomBitsSection(sections - 1) {
+			break
+		}
+
+		sections--
+	}
+
+	return sections
+}
+
+// isCanonicalBloomBitsSection returns whether the last block of the indexed section is in the canonical chain
+func (b *Blockchain) isCanonicalBloomBitsSection(section uint64) bool {
+	head, ok := b.db.ReadBloomBitsSectionHead(section)
+	if !ok {
+		return false
+	}
+
+	hash, ok := b.db.ReadCanonicalHash((section+1)*bloombits.SectionSize - 1)
+
+	return ok && hash == head
+}
+
+// MatchBloomBits returns the bitset of the blocks of the section whose blooms may contain the logs matching the filter.
+// It returns false if the section is not in the bloom bits index or it's no longer the part of the canonical chain
+func (b *Blockchain) MatchBloomBits(section uint64, matcher *bloombits.Matcher) ([]byte, bool, error) {
+	if section >= b.BloomBitsSections() || !b.isCanonicalBloomBitsSection(section) {
+		return nil, false, nil
+	}
+
+	bitset, err := matcher.Match(func(bit uint) ([]byte, error) {
+		data, ok := b.db.ReadBloomBits(bit, section)
+		if !ok {
+			return nil, fmt.Errorf("bloom bits %d of section %d not found", bit, section)
+		}
+
+		return bloombits.DecompressBitset(data)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return bitset, true, nil
+}
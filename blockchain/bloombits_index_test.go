@@ -0,0 +1,80 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/bloombits"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockchain_BloomBits(t *testing.T) {
+	t.Parallel()
+
+	addr := types.StringToAddress("1")
+	bloom := types.CreateBloom([]*types.Receipt{{Logs: []*types.Log{{Address: addr}}}})
+
+	matcher := bloombits.NewMatcher([][][]byte{{addr.Bytes()}})
+
+	// the blocks with the logs of the address
+	withLogs := []uint64{5, bloombits.SectionSize - 1}
+
+	headers := NewTestHeaders(1)
+
+	for number := uint64(1); number < bloombits.SectionSize+bloomBitsConfirmations; number++ {
+		header := &types.Header{
+			Number:     number,
+			ParentHash: headers[number-1].Hash,
+			Difficulty: number,
+		}
+
+		for _, n := range withLogs {
+			if n == number {
+				header.LogsBloom = bloom
+			}
+		}
+
+		header.ComputeHash()
+		headers = append(headers, header)
+	}
+
+	b := NewTestBlockchain(t, headers[:len(headers)-1])
+
+	// the section is not confirmed yet
+	require.NoError(t, b.IndexBloomBits(make(chan struct{})))
+	assert.Equal(t, uint64(0), b.BloomBitsSections())
+
+	_, ok, err := b.MatchBloomBits(0, matcher)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, b.WriteHeaders(headers[len(headers)-1:]))
+	require.NoError(t, b.IndexBloomBits(make(chan struct{})))
+	assert.Equal(t, uint64(1), b.BloomBitsSections())
+
+	bitset, ok, err := b.MatchBloomBits(0, matcher)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	var matched []uint64
+
+	for i := uint64(0); i < bloombits.SectionSize; i++ {
+		if bloombits.IsSet(bitset, i) {
+			matched = append(matched, i)
+		}
+	}
+
+	assert.Equal(t, withLogs, matched)
+
+	// the section is no longer used once its blocks are rewound
+	_, err = b.SetHead(bloombits.SectionSize - 2)
+	require.NoError(t, err)
+
+	_, ok, err = b.MatchBloomBits(0, matcher)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, b.IndexBloomBits(make(chan struct{})))
+	assert.Equal(t, uint64(0), b.firstStaleBloomBitsSection())
+}
@@ -0,0 +1,156 @@
+package freezer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+const (
+	headersTable  = "headers"
+	bodiesTable   = "bodies"
+	receiptsTable = "receipts"
+)
+
+var tableNames = []string{
+	headersTable,
+	bodiesTable,
+	receiptsTable,
+}
+
+var (
+	errUnknownTable = errors.New("unknown freezer table")
+)
+
+// Freezer is the append-only store of the old blocks in the flat files indexed by the block number.
+// The header, body and receipts of the block are stored in the separate tables
+type Freezer struct {
+	// lock serializes the appends and the truncations over the tables
+	lock sync.Mutex
+
+	tables map[string]*table
+}
+
+// Open opens the freezer in the directory.
+// The compression affects only the newly appended items
+func Open(dir string, compress bool) (*Freezer, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, err
+	}
+
+	f := &Freezer{
+		tables: make(map[string]*table, len(tableNames)),
+	}
+
+	for _, name := range tableNames {
+		t, err := openTable(dir, name, compress)
+		if err != nil {
+			_ = f.Close()
+
+			return nil, err
+		}
+
+		f.tables[name] = t
+	}
+
+	// the tables may have different number of items if the append has been interrupted
+	if err := f.truncate(f.minItems()); err != nil {
+		_ = f.Close()
+
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Items returns the number of the blocks in the freezer
+func (f *Freezer) Items() uint64 {
+	return f.minItems()
+}
+
+func (f *Freezer) minItems() uint64 {
+	var minItems uint64
+
+	for i, name := range tableNames {
+		items := f.tables[name].Items()
+		if i == 0 || items < minItems {
+			minItems = items
+		}
+	}
+
+	return minItems
+}
+
+// Append writes the block data at the end of the freezer.
+// Nil body or receipts represent the block without the data
+func (f *Freezer) Append(number uint64, header, body, receipts []byte) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	items := map[string][]byte{
+		headersTable:  header,
+		bodiesTable:   body,
+		receiptsTable: receipts,
+	}
+
+	for _, name := range tableNames {
+		if err := f.tables[name].Append(number, items[name]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Read returns the data of the block in the table, nil is returned for the absent data
+func (f *Freezer) Read(name string, number uint64) ([]byte, error) {
+	t, ok := f.tables[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errUnknownTable, name)
+	}
+
+	return t.Read(number)
+}
+
+// Truncate drops the blocks from the given number
+func (f *Freezer) Truncate(items uint64) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.truncate(items)
+}
+
+func (f *Freezer) truncate(items uint64) error {
+	for _, name := range tableNames {
+		if err := f.tables[name].Truncate(items); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Sync flushes the tables to the disk
+func (f *Freezer) Sync() error {
+	for _, name := range tableNames {
+		if err := f.tables[name].Sync(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close closes the tables
+func (f *Freezer) Close() error {
+	var closeErr error
+
+	for _, t := range f.tables {
+		if err := t.Close(); err != nil && closeErr == nil {
+			closeErr = err
+		}
+	}
+
+	return closeErr
+}
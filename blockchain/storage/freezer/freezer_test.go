@@ -0,0 +1,129 @@
+package freezer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreezer_AppendRead(t *testing.T) {
+	t.Parallel()
+
+	for _, compress := range []bool{false, true} {
+		compress := compress
+
+		t.Run(map[bool]string{false: "raw", true: "snappy"}[compress], func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+
+			f, err := Open(dir, compress)
+			assert.NoError(t, err)
+
+			assert.NoError(t, f.Append(0, []byte("header0"), nil, nil))
+			assert.NoError(t, f.Append(1, []byte("header1"), []byte("body1"), []byte("receipts1")))
+			assert.Equal(t, uint64(2), f.Items())
+
+			// the items are appended in order
+			assert.ErrorIs(t, f.Append(3, []byte("header3"), nil, nil), errUnorderedAppend)
+
+			assert.NoError(t, f.Close())
+
+			f, err = Open(dir, compress)
+			assert.NoError(t, err)
+
+			defer f.Close()
+
+			assert.Equal(t, uint64(2), f.Items())
+
+			data, err := f.Read(headersTable, 0)
+			assert.NoError(t, err)
+			assert.Equal(t, []byte("header0"), data)
+
+			data, err = f.Read(bodiesTable, 0)
+			assert.NoError(t, err)
+			assert.Nil(t, data)
+
+			data, err = f.Read(receiptsTable, 1)
+			assert.NoError(t, err)
+			assert.Equal(t, []byte("receipts1"), data)
+
+			_, err = f.Read(headersTable, 2)
+			assert.ErrorIs(t, err, errOutOfBounds)
+		})
+	}
+}
+
+func TestFreezer_RepairInterruptedAppend(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	f, err := Open(dir, true)
+	assert.NoError(t, err)
+
+	assert.NoError(t, f.Append(0, []byte("header0"), []byte("body0"), []byte("receipts0")))
+	assert.NoError(t, f.Append(1, []byte("header1"), []byte("body1"), []byte("receipts1")))
+
+	// the receipts of the block 1 has been written partially
+	// and the block 1 is in the headers table only
+	assert.NoError(t, f.tables[receiptsTable].data.Truncate(int64(f.tables[receiptsTable].size-1)))
+	assert.NoError(t, f.tables[bodiesTable].Truncate(1))
+	assert.NoError(t, f.Close())
+
+	indexPath := filepath.Join(dir, headersTable+".idx")
+	indexStat, err := os.Stat(indexPath)
+	assert.NoError(t, err)
+
+	// the partial index entry
+	assert.NoError(t, os.Truncate(indexPath, indexStat.Size()+3))
+
+	f, err = Open(dir, true)
+	assert.NoError(t, err)
+
+	defer f.Close()
+
+	assert.Equal(t, uint64(1), f.Items())
+
+	for _, name := range tableNames {
+		assert.Equal(t, uint64(1), f.tables[name].Items())
+	}
+
+	data, err := f.Read(receiptsTable, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("receipts0"), data)
+
+	// the block 1 can be appended again
+	assert.NoError(t, f.Append(1, []byte("header1"), []byte("body1"), []byte("receipts1")))
+
+	data, err = f.Read(headersTable, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("header1"), data)
+}
+
+func TestFreezer_Truncate(t *testing.T) {
+	t.Parallel()
+
+	f, err := Open(t.TempDir(), false)
+	assert.NoError(t, err)
+
+	defer f.Close()
+
+	for i := uint64(0); i < 5; i++ {
+		assert.NoError(t, f.Append(i, []byte{byte(i)}, []byte{byte(i)}, []byte{byte(i)}))
+	}
+
+	assert.NoError(t, f.Truncate(3))
+	assert.Equal(t, uint64(3), f.Items())
+
+	_, err = f.Read(headersTable, 3)
+	assert.ErrorIs(t, err, errOutOfBounds)
+
+	assert.NoError(t, f.Append(3, []byte{0x10}, nil, nil))
+
+	data, err := f.Read(headersTable, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x10}, data)
+}
@@ -0,0 +1,329 @@
+package freezer
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// freezeBatchSize is the maximum number of the blocks moved to the freezer at once
+	freezeBatchSize = 1000
+)
+
+var (
+	errMissingFrozenBlocks  = errors.New("freezer has less blocks than recorded in the storage")
+	errMissingCanonicalHash = errors.New("canonical hash not found")
+)
+
+// Storage is the blockchain storage moving the old blocks from the key-value storage to the freezer.
+// The headers, bodies and receipts of the moved blocks are read from the freezer transparently
+type Storage struct {
+	storage.Storage
+
+	logger  hclog.Logger
+	freezer *Freezer
+
+	// threshold is the number of the latest blocks kept in the key-value storage
+	threshold uint64
+
+	// freezeLock serializes the moves to the freezer
+	freezeLock sync.Mutex
+}
+
+// NewStorage wraps the storage with the freezer.
+// The blocks moved to the freezer but still remaining in the storage are deleted from the storage
+func NewStorage(
+	logger hclog.Logger,
+	db storage.Storage,
+	freezer *Freezer,
+	threshold uint64,
+) (*Storage, error) {
+	s := &Storage{
+		Storage:   db,
+		logger:    logger.Named("freezer"),
+		freezer:   freezer,
+		threshold: threshold,
+	}
+
+	frozen, _ := db.ReadFrozenCount()
+	items := freezer.Items()
+
+	if frozen > items {
+		return nil, fmt.Errorf("%w, recorded %d but got %d", errMissingFrozenBlocks, frozen, items)
+	}
+
+	// the canonical hashes of the frozen blocks are deleted first on the rewind,
+	// the blocks without the canonical hash are remaining from the interrupted rewind
+	for n := frozen; n < items; n++ {
+		if _, ok := db.ReadCanonicalHash(n); ok {
+			continue
+		}
+
+		s.logger.Info("truncating freezer after interrupted rewind", "from", n, "to", items-1)
+
+		if err := freezer.Truncate(n); err != nil {
+			return nil, err
+		}
+
+		items = n
+	}
+
+	if frozen < items {
+		s.logger.Info("deleting frozen blocks from storage", "from", frozen, "to", items-1)
+
+		if err := s.deleteFrozen(frozen, items); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// Frozen returns the number of the blocks moved to the freezer
+func (s *Storage) Frozen() uint64 {
+	return s.freezer.Items()
+}
+
+// Freeze moves the blocks older than the threshold from the head to the freezer
+func (s *Storage) Freeze(head uint64) error {
+	s.freezeLock.Lock()
+	defer s.freezeLock.Unlock()
+
+	if head+1 <= s.threshold {
+		return nil
+	}
+
+	limit := head + 1 - s.threshold
+
+	for from := s.freezer.Items(); from < limit; from = s.freezer.Items() {
+		to := from + freezeBatchSize
+		if to > limit {
+			to = limit
+		}
+
+		if err := s.freezeRange(from, to); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// freezeRange appends the blocks in the range [from, to) to the freezer
+// and deletes them from the key-value storage after the freezer is flushed
+func (s *Storage) freezeRange(from, to uint64) error {
+	for n := from; n < to; n++ {
+		if err := s.freezeBlock(n); err != nil {
+			return fmt.Errorf("unable to freeze block %d, %w", n, err)
+		}
+	}
+
+	if err := s.freezer.Sync(); err != nil {
+		return err
+	}
+
+	if err := s.deleteFrozen(from, to); err != nil {
+		return err
+	}
+
+	s.logger.Debug("blocks frozen", "from", from, "to", to-1)
+
+	return nil
+}
+
+// freezeBlock appends the block in the key-value storage to the freezer
+func (s *Storage) freezeBlock(n uint64) error {
+	hash, ok := s.Storage.ReadCanonicalHash(n)
+	if !ok {
+		return errMissingCanonicalHash
+	}
+
+	header, err := s.Storage.ReadHeader(hash)
+	if err != nil {
+		return err
+	}
+
+	var bodyData, receiptsData []byte
+
+	body, err := s.Storage.ReadBody(hash)
+	if err == nil {
+		bodyData = body.MarshalRLPTo(nil)
+	} else if !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+
+	receipts, err := s.Storage.ReadReceipts(hash)
+	if err == nil {
+		receiptsData = types.Receipts(receipts).MarshalStoreRLPTo(nil)
+	} else if !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+
+	return s.freezer.Append(n, header.MarshalRLPTo(nil), bodyData, receiptsData)
+}
+
+// TruncateFrozen writes the batch rewinding the chain and drops the blocks from the number from the freezer.
+// The batch is expected to delete the canonical hashes of the dropped blocks
+func (s *Storage) TruncateFrozen(number uint64, batch storage.Batch) error {
+	s.freezeLock.Lock()
+	defer s.freezeLock.Unlock()
+
+	items := s.freezer.Items()
+	if number >= items {
+		return batch.Write()
+	}
+
+	for n := number; n < items; n++ {
+		hash, ok := s.Storage.ReadCanonicalHash(n)
+		if !ok {
+			return fmt.Errorf("%w for block %d", errMissingCanonicalHash, n)
+		}
+
+		if err := batch.DeleteFrozen(hash); err != nil {
+			return err
+		}
+	}
+
+	if err := batch.WriteFrozenCount(number); err != nil {
+		return err
+	}
+
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	if err := s.freezer.Truncate(number); err != nil {
+		return err
+	}
+
+	s.logger.Info("frozen blocks dropped", "from", number, "to", items-1)
+
+	return nil
+}
+
+// deleteFrozen deletes the blocks in the range [from, to) from the key-value storage
+func (s *Storage) deleteFrozen(from, to uint64) error {
+	batch := s.Storage.NewBatch()
+
+	for n := from; n < to; n++ {
+		hash, ok := s.Storage.ReadCanonicalHash(n)
+		if !ok {
+			return fmt.Errorf("%w for block %d", errMissingCanonicalHash, n)
+		}
+
+		if err := batch.WriteFrozen(n, hash); err != nil {
+			return err
+		}
+	}
+
+	if err := batch.WriteFrozenCount(to); err != nil {
+		return err
+	}
+
+	return batch.Write()
+}
+
+// ReadHeader reads the header from the key-value storage or the freezer
+func (s *Storage) ReadHeader(hash types.Hash) (*types.Header, error) {
+	header, err := s.Storage.ReadHeader(hash)
+	if !errors.Is(err, storage.ErrNotFound) {
+		return header, err
+	}
+
+	data, err := s.readFrozen(headersTable, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	header = &types.Header{}
+	if err := header.UnmarshalRLP(data); err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+// ReadBody reads the body from the key-value storage or the freezer
+func (s *Storage) ReadBody(hash types.Hash) (*types.Body, error) {
+	body, err := s.Storage.ReadBody(hash)
+	if !errors.Is(err, storage.ErrNotFound) {
+		return body, err
+	}
+
+	data, err := s.readFrozen(bodiesTable, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	body = &types.Body{}
+	if err := body.UnmarshalRLP(data); err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// ReadReceipts reads the receipts from the key-value storage or the freezer
+func (s *Storage) ReadReceipts(hash types.Hash) ([]*types.Receipt, error) {
+	receipts, err := s.Storage.ReadReceipts(hash)
+	if !errors.Is(err, storage.ErrNotFound) {
+		return receipts, err
+	}
+
+	data, err := s.readFrozen(receiptsTable, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	frozenReceipts := types.Receipts{}
+	if err := frozenReceipts.UnmarshalStoreRLP(data); err != nil {
+		return nil, err
+	}
+
+	return frozenReceipts, nil
+}
+
+// WriteBody writes the body into the key-value storage unless the block is in the freezer
+func (s *Storage) WriteBody(hash types.Hash, body *types.Body) error {
+	if _, ok := s.Storage.ReadFrozenNumber(hash); ok {
+		return nil
+	}
+
+	return s.Storage.WriteBody(hash, body)
+}
+
+// readFrozen reads the data of the block in the freezer by the hash
+func (s *Storage) readFrozen(name string, hash types.Hash) ([]byte, error) {
+	number, ok := s.Storage.ReadFrozenNumber(hash)
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+
+	data, err := s.freezer.Read(name, number)
+	if err != nil {
+		return nil, err
+	}
+
+	if data == nil {
+		return nil, storage.ErrNotFound
+	}
+
+	return data, nil
+}
+
+// Close closes the key-value storage and the freezer
+func (s *Storage) Close() error {
+	dbErr := s.Storage.Close()
+	freezerErr := s.freezer.Close()
+
+	if dbErr != nil {
+		return dbErr
+	}
+
+	return freezerErr
+}
@@ -0,0 +1,281 @@
+package freezer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/memory"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+type testBlock struct {
+	header   *types.Header
+	body     *types.Body
+	receipts []*types.Receipt
+}
+
+// newTestChain writes the canonical chain into the storage
+func newTestChain(t *testing.T, db storage.Storage, length uint64) []*testBlock {
+	t.Helper()
+
+	blocks := make([]*testBlock, 0, length)
+	parentHash := types.ZeroHash
+
+	for i := uint64(0); i < length; i++ {
+		header := &types.Header{
+			Number:     i,
+			ParentHash: parentHash,
+		}
+		header.ComputeHash()
+
+		block := &testBlock{header: header}
+
+		// the genesis has no body and receipts
+		if i > 0 {
+			tx := &types.Transaction{
+				Nonce:    i,
+				Value:    big.NewInt(1),
+				GasPrice: big.NewInt(1),
+				V:        big.NewInt(1),
+				R:        big.NewInt(1),
+				S:        big.NewInt(1),
+				From:     types.StringToAddress("1"),
+			}
+			tx.ComputeHash()
+
+			block.body = &types.Body{Transactions: []*types.Transaction{tx}}
+			block.receipts = []*types.Receipt{
+				{
+					CumulativeGasUsed: i,
+					TxHash:            tx.Hash,
+				},
+			}
+
+			assert.NoError(t, db.WriteBody(header.Hash, block.body))
+			assert.NoError(t, db.WriteReceipts(header.Hash, block.receipts))
+		}
+
+		assert.NoError(t, db.WriteCanonicalHeader(header, big.NewInt(int64(i))))
+
+		blocks = append(blocks, block)
+		parentHash = header.Hash
+	}
+
+	return blocks
+}
+
+func newTestStorage(t *testing.T, dir string, db storage.Storage, threshold uint64) *Storage {
+	t.Helper()
+
+	f, err := Open(dir, true)
+	assert.NoError(t, err)
+
+	s, err := NewStorage(hclog.NewNullLogger(), db, f, threshold)
+	assert.NoError(t, err)
+
+	return s
+}
+
+func assertBlocks(t *testing.T, s storage.Storage, blocks []*testBlock) {
+	t.Helper()
+
+	for _, block := range blocks {
+		header, err := s.ReadHeader(block.header.Hash)
+		assert.NoError(t, err)
+		assert.Equal(t, block.header, header)
+
+		body, err := s.ReadBody(block.header.Hash)
+		receipts, receiptsErr := s.ReadReceipts(block.header.Hash)
+
+		if block.body == nil {
+			assert.ErrorIs(t, err, storage.ErrNotFound)
+			assert.ErrorIs(t, receiptsErr, storage.ErrNotFound)
+
+			continue
+		}
+
+		assert.NoError(t, err)
+		assert.Equal(t, block.body.Transactions[0].Hash, body.Transactions[0].Hash)
+		assert.Equal(t, block.body.Transactions[0].From, body.Transactions[0].From)
+
+		assert.NoError(t, receiptsErr)
+		assert.Equal(t, block.receipts, receipts)
+	}
+}
+
+func TestStorage_Freeze(t *testing.T) {
+	t.Parallel()
+
+	db, err := memory.NewMemoryStorage(nil)
+	assert.NoError(t, err)
+
+	blocks := newTestChain(t, db, 10)
+
+	s := newTestStorage(t, t.TempDir(), db, 4)
+	defer s.Close()
+
+	assert.NoError(t, s.Freeze(9))
+
+	// the latest 4 blocks are kept in the key-value storage
+	assert.Equal(t, uint64(6), s.Frozen())
+
+	frozen, ok := db.ReadFrozenCount()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(6), frozen)
+
+	for _, block := range blocks {
+		_, err := db.ReadHeader(block.header.Hash)
+
+		if block.header.Number < 6 {
+			assert.ErrorIs(t, err, storage.ErrNotFound)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+
+	assertBlocks(t, s, blocks)
+
+	// the frozen blocks are not moved again
+	assert.NoError(t, s.Freeze(9))
+	assert.Equal(t, uint64(6), s.Frozen())
+}
+
+func TestStorage_Freeze_BelowThreshold(t *testing.T) {
+	t.Parallel()
+
+	db, err := memory.NewMemoryStorage(nil)
+	assert.NoError(t, err)
+
+	newTestChain(t, db, 3)
+
+	s := newTestStorage(t, t.TempDir(), db, 4)
+	defer s.Close()
+
+	assert.NoError(t, s.Freeze(2))
+	assert.Equal(t, uint64(0), s.Frozen())
+}
+
+func TestNewStorage_DeletesFrozenBlocks(t *testing.T) {
+	t.Parallel()
+
+	db, err := memory.NewMemoryStorage(nil)
+	assert.NoError(t, err)
+
+	blocks := newTestChain(t, db, 5)
+	dir := t.TempDir()
+
+	// the blocks have been appended to the freezer but the storage has not been updated
+	f, err := Open(dir, true)
+	assert.NoError(t, err)
+
+	s := &Storage{Storage: db, logger: hclog.NewNullLogger(), freezer: f}
+
+	for n := uint64(0); n < 3; n++ {
+		assert.NoError(t, s.freezeBlock(n))
+	}
+
+	assert.NoError(t, f.Close())
+
+	s = newTestStorage(t, dir, db, 2)
+	defer s.Close()
+
+	frozen, ok := db.ReadFrozenCount()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(3), frozen)
+
+	_, err = db.ReadHeader(blocks[2].header.Hash)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	assertBlocks(t, s, blocks)
+}
+
+func TestNewStorage_MissingFrozenBlocks(t *testing.T) {
+	t.Parallel()
+
+	db, err := memory.NewMemoryStorage(nil)
+	assert.NoError(t, err)
+
+	newTestChain(t, db, 5)
+
+	batch := db.NewBatch()
+	assert.NoError(t, batch.WriteFrozenCount(3))
+	assert.NoError(t, batch.Write())
+
+	f, err := Open(t.TempDir(), true)
+	assert.NoError(t, err)
+
+	defer f.Close()
+
+	_, err = NewStorage(hclog.NewNullLogger(), db, f, 2)
+	assert.ErrorIs(t, err, errMissingFrozenBlocks)
+}
+
+func TestStorage_TruncateFrozen(t *testing.T) {
+	t.Parallel()
+
+	db, err := memory.NewMemoryStorage(nil)
+	assert.NoError(t, err)
+
+	blocks := newTestChain(t, db, 10)
+
+	s := newTestStorage(t, t.TempDir(), db, 2)
+	defer s.Close()
+
+	assert.NoError(t, s.Freeze(9))
+	assert.Equal(t, uint64(8), s.Frozen())
+
+	batch := db.NewBatch()
+	for n := uint64(5); n < 10; n++ {
+		assert.NoError(t, batch.DeleteCanonicalHash(n))
+	}
+
+	assert.NoError(t, s.TruncateFrozen(5, batch))
+	assert.Equal(t, uint64(5), s.Frozen())
+
+	frozen, ok := db.ReadFrozenCount()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(5), frozen)
+
+	assertBlocks(t, s, blocks[:5])
+
+	// the dropped frozen blocks are not found anymore
+	_, err = s.ReadHeader(blocks[6].header.Hash)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	// the blocks in the key-value storage are kept
+	assertBlocks(t, s, blocks[8:])
+}
+
+func TestNewStorage_InterruptedRewind(t *testing.T) {
+	t.Parallel()
+
+	db, err := memory.NewMemoryStorage(nil)
+	assert.NoError(t, err)
+
+	blocks := newTestChain(t, db, 6)
+	dir := t.TempDir()
+
+	s := newTestStorage(t, dir, db, 1)
+	assert.NoError(t, s.Freeze(5))
+	assert.Equal(t, uint64(5), s.Frozen())
+
+	// the batch of the rewind to the block 2 has been written but the freezer has not been truncated
+	batch := db.NewBatch()
+	for n := uint64(3); n < 6; n++ {
+		assert.NoError(t, batch.DeleteCanonicalHash(n))
+		assert.NoError(t, batch.DeleteFrozen(blocks[n].header.Hash))
+	}
+
+	assert.NoError(t, batch.WriteFrozenCount(3))
+	assert.NoError(t, batch.Write())
+	assert.NoError(t, s.Close())
+
+	s = newTestStorage(t, dir, db, 1)
+	defer s.Close()
+
+	assert.Equal(t, uint64(3), s.Frozen())
+	assertBlocks(t, s, blocks[:3])
+}
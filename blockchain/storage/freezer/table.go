@@ -0,0 +1,272 @@
+package freezer
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/golang/snappy"
+)
+
+const (
+	// indexEntrySize is the size of the entry in the index file,
+	// which is the end offset of the item in the data file
+	indexEntrySize = 8
+
+	// item flags, the first byte of the non-empty item in the data file
+	flagRaw    byte = 0
+	flagSnappy byte = 1
+)
+
+var (
+	errOutOfBounds     = errors.New("item is out of bounds")
+	errCorruptedItem   = errors.New("corrupted item")
+	errUnorderedAppend = errors.New("item is appended out of order")
+)
+
+// table is an append-only flat file of the items indexed by the sequence number.
+// The data file has the items one after another
+// and the index file has the end offsets of the items in the data file
+type table struct {
+	lock sync.RWMutex
+
+	name     string
+	compress bool
+
+	index *os.File
+	data  *os.File
+
+	// items is the number of the items in the table
+	items uint64
+	// size is the size of the data file
+	size uint64
+}
+
+// openTable opens the table in the directory, truncating the partially written items
+func openTable(dir, name string, compress bool) (*table, error) {
+	index, err := os.OpenFile(filepath.Join(dir, name+".idx"), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.OpenFile(filepath.Join(dir, name+".dat"), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		_ = index.Close()
+
+		return nil, err
+	}
+
+	t := &table{
+		name:     name,
+		compress: compress,
+		index:    index,
+		data:     data,
+	}
+
+	if err := t.repair(); err != nil {
+		_ = t.Close()
+
+		return nil, fmt.Errorf("unable to repair %s table, %w", name, err)
+	}
+
+	return t, nil
+}
+
+// repair drops the items which are not completely written in the index and the data file
+func (t *table) repair() error {
+	indexStat, err := t.index.Stat()
+	if err != nil {
+		return err
+	}
+
+	dataStat, err := t.data.Stat()
+	if err != nil {
+		return err
+	}
+
+	items := uint64(indexStat.Size()) / indexEntrySize
+	dataSize := uint64(dataStat.Size())
+
+	// drop the index entries pointing beyond the data file
+	for items > 0 {
+		end, err := t.readOffset(items - 1)
+		if err != nil {
+			return err
+		}
+
+		if end <= dataSize {
+			break
+		}
+
+		items--
+	}
+
+	return t.truncate(items)
+}
+
+// truncate drops the items from the given number
+func (t *table) truncate(items uint64) error {
+	size := uint64(0)
+
+	if items > 0 {
+		end, err := t.readOffset(items - 1)
+		if err != nil {
+			return err
+		}
+
+		size = end
+	}
+
+	if err := t.index.Truncate(int64(items * indexEntrySize)); err != nil {
+		return err
+	}
+
+	if err := t.data.Truncate(int64(size)); err != nil {
+		return err
+	}
+
+	t.items = items
+	t.size = size
+
+	return nil
+}
+
+// readOffset reads the end offset of the item from the index file
+func (t *table) readOffset(item uint64) (uint64, error) {
+	buf := make([]byte, indexEntrySize)
+	if _, err := t.index.ReadAt(buf, int64(item*indexEntrySize)); err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint64(buf), nil
+}
+
+// Append writes the item at the end of the table.
+// The empty item represents the absent data and is read as nil
+func (t *table) Append(item uint64, data []byte) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if item != t.items {
+		return fmt.Errorf("%w, %s table expects %d but got %d", errUnorderedAppend, t.name, t.items, item)
+	}
+
+	var payload []byte
+
+	if len(data) > 0 {
+		if t.compress {
+			payload = append([]byte{flagSnappy}, snappy.Encode(nil, data)...)
+		} else {
+			payload = append([]byte{flagRaw}, data...)
+		}
+	}
+
+	if _, err := t.data.WriteAt(payload, int64(t.size)); err != nil {
+		return err
+	}
+
+	end := t.size + uint64(len(payload))
+
+	offset := make([]byte, indexEntrySize)
+	binary.BigEndian.PutUint64(offset, end)
+
+	if _, err := t.index.WriteAt(offset, int64(t.items*indexEntrySize)); err != nil {
+		return err
+	}
+
+	t.items++
+	t.size = end
+
+	return nil
+}
+
+// Read returns the item, nil is returned for the absent data
+func (t *table) Read(item uint64) ([]byte, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if item >= t.items {
+		return nil, errOutOfBounds
+	}
+
+	start := uint64(0)
+
+	if item > 0 {
+		var err error
+		if start, err = t.readOffset(item - 1); err != nil {
+			return nil, err
+		}
+	}
+
+	end, err := t.readOffset(item)
+	if err != nil {
+		return nil, err
+	}
+
+	if start == end {
+		return nil, nil
+	}
+
+	if end < start {
+		return nil, errCorruptedItem
+	}
+
+	payload := make([]byte, end-start)
+	if _, err := t.data.ReadAt(payload, int64(start)); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	switch payload[0] {
+	case flagRaw:
+		return payload[1:], nil
+	case flagSnappy:
+		return snappy.Decode(nil, payload[1:])
+	default:
+		return nil, errCorruptedItem
+	}
+}
+
+// Items returns the number of the items in the table
+func (t *table) Items() uint64 {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.items
+}
+
+// Truncate drops the items from the given number
+func (t *table) Truncate(items uint64) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if items >= t.items {
+		return nil
+	}
+
+	return t.truncate(items)
+}
+
+// Sync flushes the data file and the index file to the disk
+func (t *table) Sync() error {
+	if err := t.data.Sync(); err != nil {
+		return err
+	}
+
+	return t.index.Sync()
+}
+
+// Close closes the files of the table
+func (t *table) Close() error {
+	dataErr := t.data.Close()
+	indexErr := t.index.Close()
+
+	if dataErr != nil {
+		return dataErr
+	}
+
+	return indexErr
+}
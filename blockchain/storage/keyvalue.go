@@ -39,6 +39,18 @@ var (
 
 	// TX_LOOKUP_PREFIX is the prefix for transaction lookups
 	TX_LOOKUP_PREFIX = []byte("l")
+
+	// CONSENSUS is the prefix for the data of the consensus
+	CONSENSUS = []byte("i")
+
+	// FROZEN is the prefix for the numbers of the blocks moved to the freezer
+	FROZEN = []byte("z")
+
+	// ADDRESS_INDEX is the prefix for the positions of the transactions sent or received by the addresses
+	ADDRESS_INDEX = []byte("a")
+
+	// BLOOM_BITS is the prefix for the bloom bits index of the sections of the blocks
+	BLOOM_BITS = []byte("m")
 )
 
 // Sub-prefixes
@@ -46,6 +58,8 @@ var (
 	HASH   = []byte("hash")
 	NUMBER = []byte("number")
 	EMPTY  = []byte("empty")
+	COUNT  = []byte("count")
+	TAIL   = []byte("tail")
 )
 
 // KV is a key value storage interface.
@@ -55,6 +69,19 @@ type KV interface {
 	Close() error
 	Set(p []byte, v []byte) error
 	Get(p []byte) ([]byte, bool, error)
+	NewBatch() KVBatch
+
+	// Iterate calls fn with the key-value pairs whose keys have the prefix, in the ascending order of the keys,
+	// starting from the key consisting of the prefix and start. The iteration stops when fn returns false.
+	// The slices passed to fn are valid only until fn returns
+	Iterate(prefix []byte, start []byte, fn func(k, v []byte) bool) error
+}
+
+// KVBatch is a set of key value pairs written to the KV atomically
+type KVBatch interface {
+	Set(p []byte, v []byte)
+	Delete(p []byte)
+	Write() error
 }
 
 // KeyValueStorage is a generic storage for kv databases
@@ -267,6 +294,276 @@ func (s *KeyValueStorage) ReadTxLookup(hash types.Hash) (types.Hash, bool) {
 	return types.BytesToHash(blockHash), true
 }
 
+// CONSENSUS //
+
+// WriteConsensusData writes the data of the consensus with the key
+func (s *KeyValueStorage) WriteConsensusData(key []byte, data []byte) error {
+	return s.set(CONSENSUS, key, data)
+}
+
+// ReadConsensusData reads the data of the consensus with the key
+func (s *KeyValueStorage) ReadConsensusData(key []byte) ([]byte, bool) {
+	return s.get(CONSENSUS, key)
+}
+
+// FROZEN //
+
+// ReadFrozenNumber returns the number of the block moved to the freezer by the hash
+func (s *KeyValueStorage) ReadFrozenNumber(hash types.Hash) (uint64, bool) {
+	data, ok := s.get(FROZEN, hash.Bytes())
+	if !ok || len(data) != 8 {
+		return 0, false
+	}
+
+	return s.decodeUint(data), true
+}
+
+// ReadFrozenCount returns the number of the blocks moved to the freezer
+func (s *KeyValueStorage) ReadFrozenCount() (uint64, bool) {
+	data, ok := s.get(FROZEN, COUNT)
+	if !ok || len(data) != 8 {
+		return 0, false
+	}
+
+	return s.decodeUint(data), true
+}
+
+// ADDRESS INDEX //
+
+// addressIndexKey returns the key of the transaction position in the index of the address,
+// the keys of an address are sorted by the block number and the transaction index
+func (s *KeyValueStorage) addressIndexKey(addr types.Address, number, index uint64) []byte {
+	key := make([]byte, 0, types.AddressLength+16)
+	key = append(key, addr.Bytes()...)
+	key = append(key, s.encodeUint(number)...)
+	key = append(key, s.encodeUint(index)...)
+
+	return key
+}
+
+// ReadAddressIndex calls fn with the positions of the transactions sent or received by the address,
+// starting from the position of from in the ascending order, until fn returns false
+func (s *KeyValueStorage) ReadAddressIndex(addr types.Address, from TxPosition, fn func(TxPosition) bool) error {
+	prefix := append(append([]byte{}, ADDRESS_INDEX...), addr.Bytes()...)
+	start := s.addressIndexKey(addr, from.Number, from.Index)[types.AddressLength:]
+
+	return s.db.Iterate(prefix, start, func(k, v []byte) bool {
+		if len(k) != len(prefix)+16 || len(v) != types.HashLength {
+			// skip the malformed entry
+			return true
+		}
+
+		return fn(TxPosition{
+			Number:    s.decodeUint(k[len(prefix):]),
+			Index:     s.decodeUint(k[len(prefix)+8:]),
+			BlockHash: types.BytesToHash(v),
+		})
+	})
+}
+
+// ReadAddressIndexTail returns the lowest number of the blocks from which the address index is complete
+func (s *KeyValueStorage) ReadAddressIndexTail() (uint64, bool) {
+	data, ok := s.get(ADDRESS_INDEX, TAIL)
+	if !ok || len(data) != 8 {
+		return 0, false
+	}
+
+	return s.decodeUint(data), true
+}
+
+// ReadAddressIndexHead returns the number of the head block when the address index was last updated
+func (s *KeyValueStorage) ReadAddressIndexHead() (uint64, bool) {
+	data, ok := s.get(ADDRESS_INDEX, NUMBER)
+	if !ok || len(data) != 8 {
+		return 0, false
+	}
+
+	return s.decodeUint(data), true
+}
+
+// BLOOM BITS //
+
+// bloomBitsKey returns the key of the bitset of the bloom bit in the section
+func (s *KeyValueStorage) bloomBitsKey(bit uint, section uint64) []byte {
+	key := make([]byte, 2, 10)
+	binary.BigEndian.PutUint16(key, uint16(bit))
+
+	return append(key, s.encodeUint(section)...)
+}
+
+// ReadBloomBits reads the compressed bitset of the bloom bit over the blocks of the section
+func (s *KeyValueStorage) ReadBloomBits(bit uint, section uint64) ([]byte, bool) {
+	return s.get(BLOOM_BITS, s.bloomBitsKey(bit, section))
+}
+
+// ReadBloomBitsSections returns the number of the sections in the bloom bits index
+func (s *KeyValueStorage) ReadBloomBitsSections() (uint64, bool) {
+	data, ok := s.get(BLOOM_BITS, COUNT)
+	if !ok || len(data) != 8 {
+		return 0, false
+	}
+
+	return s.decodeUint(data), true
+}
+
+// ReadBloomBitsSectionHead returns the hash of the last block of the section in the bloom bits index
+func (s *KeyValueStorage) ReadBloomBitsSectionHead(section uint64) (types.Hash, bool) {
+	data, ok := s.get(BLOOM_BITS, append(append([]byte{}, HASH...), s.encodeUint(section)...))
+	if !ok {
+		return types.Hash{}, false
+	}
+
+	return types.BytesToHash(data), true
+}
+
+// BATCH //
+
+// NewBatch creates a batch of the writes to the storage
+func (s *KeyValueStorage) NewBatch() Batch {
+	batch := s.db.NewBatch()
+
+	return &keyValueBatch{
+		KeyValueStorage: &KeyValueStorage{
+			logger: s.logger,
+			db: &batchKV{
+				db:    s.db,
+				batch: batch,
+			},
+		},
+		batch: batch,
+	}
+}
+
+// keyValueBatch is the batch of KeyValueStorage
+// that puts the writes into the KVBatch instead of the db
+type keyValueBatch struct {
+	*KeyValueStorage
+
+	batch KVBatch
+}
+
+// WriteFrozen records the number of the block moved to the freezer
+// and deletes the header, body and receipts of the block
+func (b *keyValueBatch) WriteFrozen(n uint64, hash types.Hash) error {
+	if err := b.set(FROZEN, hash.Bytes(), b.encodeUint(n)); err != nil {
+		return err
+	}
+
+	for _, p := range [][]byte{HEADER, BODY, RECEIPTS} {
+		b.delete(p, hash.Bytes())
+	}
+
+	return nil
+}
+
+// WriteFrozenCount writes the number of the blocks moved to the freezer
+func (b *keyValueBatch) WriteFrozenCount(n uint64) error {
+	return b.set(FROZEN, COUNT, b.encodeUint(n))
+}
+
+// WriteAddressIndex adds the position of the transaction to the index of the address
+func (b *keyValueBatch) WriteAddressIndex(addr types.Address, pos TxPosition) error {
+	return b.set(ADDRESS_INDEX, b.addressIndexKey(addr, pos.Number, pos.Index), pos.BlockHash.Bytes())
+}
+
+// WriteAddressIndexTail writes the lowest number of the blocks from which the address index is complete
+func (b *keyValueBatch) WriteAddressIndexTail(n uint64) error {
+	return b.set(ADDRESS_INDEX, TAIL, b.encodeUint(n))
+}
+
+// WriteAddressIndexHead writes the number of the head block the address index is updated to
+func (b *keyValueBatch) WriteAddressIndexHead(n uint64) error {
+	return b.set(ADDRESS_INDEX, NUMBER, b.encodeUint(n))
+}
+
+// WriteBloomBits writes the compressed bitset of the bloom bit over the blocks of the section
+func (b *keyValueBatch) WriteBloomBits(bit uint, section uint64, bitset []byte) error {
+	return b.set(BLOOM_BITS, b.bloomBitsKey(bit, section), bitset)
+}
+
+// WriteBloomBitsSections writes the number of the sections in the bloom bits index
+func (b *keyValueBatch) WriteBloomBitsSections(n uint64) error {
+	return b.set(BLOOM_BITS, COUNT, b.encodeUint(n))
+}
+
+// WriteBloomBitsSectionHead writes the hash of the last block of the section in the bloom bits index
+func (b *keyValueBatch) WriteBloomBitsSectionHead(section uint64, hash types.Hash) error {
+	return b.set(BLOOM_BITS, append(append([]byte{}, HASH...), b.encodeUint(section)...), hash.Bytes())
+}
+
+// DeleteCanonicalHash deletes the hash of the number block in the canonical chain
+func (b *keyValueBatch) DeleteCanonicalHash(n uint64) error {
+	b.delete(CANONICAL, b.encodeUint(n))
+
+	return nil
+}
+
+// DeleteReceipts deletes the receipts of the block
+func (b *keyValueBatch) DeleteReceipts(hash types.Hash) error {
+	b.delete(RECEIPTS, hash.Bytes())
+
+	return nil
+}
+
+// DeleteTxLookup deletes the mapping of the transaction hash to the block hash
+func (b *keyValueBatch) DeleteTxLookup(hash types.Hash) error {
+	b.delete(TX_LOOKUP_PREFIX, hash.Bytes())
+
+	return nil
+}
+
+// DeleteAddressIndex deletes the position of the transaction from the index of the address
+func (b *keyValueBatch) DeleteAddressIndex(addr types.Address, pos TxPosition) error {
+	b.delete(ADDRESS_INDEX, b.addressIndexKey(addr, pos.Number, pos.Index))
+
+	return nil
+}
+
+// DeleteFrozen deletes the record of the block moved to the freezer
+func (b *keyValueBatch) DeleteFrozen(hash types.Hash) error {
+	b.delete(FROZEN, hash.Bytes())
+
+	return nil
+}
+
+// delete puts the deletion of the key into the batch
+func (b *keyValueBatch) delete(p []byte, k []byte) {
+	b.batch.Delete(append(append([]byte{}, p...), k...))
+}
+
+// Write commits the writes in the batch to the db
+func (b *keyValueBatch) Write() error {
+	return b.batch.Write()
+}
+
+// batchKV is a KV that sets the values into the batch and gets the values from the db
+type batchKV struct {
+	db    KV
+	batch KVBatch
+}
+
+func (b *batchKV) Set(p []byte, v []byte) error {
+	b.batch.Set(p, v)
+
+	return nil
+}
+
+func (b *batchKV) Get(p []byte) ([]byte, bool, error) {
+	return b.db.Get(p)
+}
+
+func (b *batchKV) Iterate(prefix []byte, start []byte, fn func(k, v []byte) bool) error {
+	return b.db.Iterate(prefix, start, fn)
+}
+
+func (b *batchKV) NewBatch() KVBatch {
+	return b.batch
+}
+
+func (b *batchKV) Close() error {
+	return nil
+}
+
 // WRITE OPERATIONS //
 
 func (s *KeyValueStorage) writeRLP(p, k []byte, raw types.RLPMarshaler) error {
@@ -6,6 +6,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/blockchain/storage"
 	"github.com/hashicorp/go-hclog"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 // Factory creates a leveldb storage
@@ -59,7 +60,53 @@ func (l *levelDBKV) Get(p []byte) ([]byte, bool, error) {
 	return data, true, nil
 }
 
+// Iterate iterates over the key-value pairs with the prefix in leveldb storage from the start key
+func (l *levelDBKV) Iterate(prefix []byte, start []byte, fn func(k, v []byte) bool) error {
+	r := util.BytesPrefix(prefix)
+	r.Start = append(append([]byte{}, prefix...), start...)
+
+	iter := l.db.NewIterator(r, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		if !fn(iter.Key(), iter.Value()) {
+			break
+		}
+	}
+
+	return iter.Error()
+}
+
+// NewBatch creates the batch of the writes to leveldb storage
+func (l *levelDBKV) NewBatch() storage.KVBatch {
+	return &levelDBBatch{
+		db:    l.db,
+		batch: new(leveldb.Batch),
+	}
+}
+
 // Close closes the leveldb storage instance
 func (l *levelDBKV) Close() error {
 	return l.db.Close()
 }
+
+// levelDBBatch is the leveldb implementation of the kv batch
+type levelDBBatch struct {
+	db    *leveldb.DB
+	batch *leveldb.Batch
+}
+
+// Set puts the key-value pair into the batch
+func (b *levelDBBatch) Set(p []byte, v []byte) {
+	b.batch.Put(p, v)
+}
+
+// Delete puts the deletion of the key into the batch
+func (b *levelDBBatch) Delete(p []byte) {
+	b.batch.Delete(p)
+}
+
+// Write writes the key-value pairs in the batch to leveldb storage atomically
+func (b *levelDBBatch) Write() error {
+	return b.db.Write(b.batch, nil)
+}
@@ -1,6 +1,9 @@
 package memory
 
 import (
+	"sort"
+	"strings"
+
 	"github.com/0xPolygon/polygon-edge/blockchain/storage"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/hashicorp/go-hclog"
@@ -33,6 +36,76 @@ func (m *memoryKV) Get(p []byte) ([]byte, bool, error) {
 	return v, true, nil
 }
 
+func (m *memoryKV) Iterate(prefix []byte, start []byte, fn func(k, v []byte) bool) error {
+	// the order of the hex encoded keys is the order of the keys
+	hexPrefix := hex.EncodeToHex(prefix)
+	from := hex.EncodeToHex(append(append([]byte{}, prefix...), start...))
+
+	keys := make([]string, 0)
+
+	for k := range m.db {
+		if strings.HasPrefix(k, hexPrefix) && k >= from {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		key, err := hex.DecodeHex(k)
+		if err != nil {
+			return err
+		}
+
+		if !fn(key, m.db[k]) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (m *memoryKV) NewBatch() storage.KVBatch {
+	return &memoryBatch{
+		db:      m,
+		values:  map[string][]byte{},
+		deleted: map[string]bool{},
+	}
+}
+
 func (m *memoryKV) Close() error {
 	return nil
 }
+
+// memoryBatch is an in memory implementation of the kv batch
+type memoryBatch struct {
+	db      *memoryKV
+	values  map[string][]byte
+	deleted map[string]bool
+}
+
+func (b *memoryBatch) Set(p []byte, v []byte) {
+	key := hex.EncodeToHex(p)
+
+	b.values[key] = v
+	delete(b.deleted, key)
+}
+
+func (b *memoryBatch) Delete(p []byte) {
+	key := hex.EncodeToHex(p)
+
+	b.deleted[key] = true
+	delete(b.values, key)
+}
+
+func (b *memoryBatch) Write() error {
+	for k, v := range b.values {
+		b.db.db[k] = v
+	}
+
+	for k := range b.deleted {
+		delete(b.db.db, k)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package pebble
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/cockroachdb/pebble"
+	"github.com/hashicorp/go-hclog"
+)
+
+// Factory creates a pebble storage
+func Factory(config map[string]interface{}, logger hclog.Logger) (storage.Storage, error) {
+	path, ok := config["path"]
+	if !ok {
+		return nil, fmt.Errorf("path not found")
+	}
+
+	pathStr, ok := path.(string)
+	if !ok {
+		return nil, fmt.Errorf("path is not a string")
+	}
+
+	return NewPebbleStorage(pathStr, logger)
+}
+
+// NewPebbleStorage creates the new storage reference with pebble
+func NewPebbleStorage(path string, logger hclog.Logger) (storage.Storage, error) {
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	kv := &pebbleKV{db}
+
+	return storage.NewKeyValueStorage(logger.Named("pebble"), kv), nil
+}
+
+// pebbleKV is the pebble implementation of the kv storage
+type pebbleKV struct {
+	db *pebble.DB
+}
+
+// Set sets the key-value pair in pebble storage
+func (p *pebbleKV) Set(k []byte, v []byte) error {
+	return p.db.Set(k, v, pebble.NoSync)
+}
+
+// Get retrieves the key-value pair in pebble storage
+func (p *pebbleKV) Get(k []byte) ([]byte, bool, error) {
+	data, closer, err := p.db.Get(k)
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	// the returned slice is only valid until the closer is closed
+	value := make([]byte, len(data))
+	copy(value, data)
+
+	if err := closer.Close(); err != nil {
+		return nil, false, err
+	}
+
+	return value, true, nil
+}
+
+// Iterate iterates over the key-value pairs with the prefix in pebble storage from the start key
+func (p *pebbleKV) Iterate(prefix []byte, start []byte, fn func(k, v []byte) bool) error {
+	iter := p.db.NewIter(&pebble.IterOptions{
+		LowerBound: append(append([]byte{}, prefix...), start...),
+		UpperBound: prefixUpperBound(prefix),
+	})
+
+	for iter.First(); iter.Valid(); iter.Next() {
+		if !fn(iter.Key(), iter.Value()) {
+			break
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		_ = iter.Close()
+
+		return err
+	}
+
+	return iter.Close()
+}
+
+// prefixUpperBound returns the smallest key greater than all the keys with the prefix,
+// nil is returned if there is no such key
+func prefixUpperBound(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+
+	return nil
+}
+
+// NewBatch creates the batch of the writes to pebble storage
+func (p *pebbleKV) NewBatch() storage.KVBatch {
+	return &pebbleBatch{
+		batch: p.db.NewBatch(),
+	}
+}
+
+// Close closes the pebble storage instance
+func (p *pebbleKV) Close() error {
+	return p.db.Close()
+}
+
+// pebbleBatch is the pebble implementation of the kv batch
+type pebbleBatch struct {
+	batch *pebble.Batch
+}
+
+// Set puts the key-value pair into the batch
+func (b *pebbleBatch) Set(k []byte, v []byte) {
+	// pebble.Batch.Set returns an error only when the batch is already committed
+	_ = b.batch.Set(k, v, nil)
+}
+
+// Delete puts the deletion of the key into the batch
+func (b *pebbleBatch) Delete(k []byte) {
+	_ = b.batch.Delete(k, nil)
+}
+
+// Write writes the key-value pairs in the batch to pebble storage atomically
+func (b *pebbleBatch) Write() error {
+	return b.batch.Commit(pebble.NoSync)
+}
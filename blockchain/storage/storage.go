@@ -37,8 +37,76 @@ type Storage interface {
 	WriteTxLookup(hash types.Hash, blockHash types.Hash) error
 	ReadTxLookup(hash types.Hash) (types.Hash, bool)
 
+	WriteConsensusData(key []byte, data []byte) error
+	ReadConsensusData(key []byte) ([]byte, bool)
+
+	ReadFrozenNumber(hash types.Hash) (uint64, bool)
+	ReadFrozenCount() (uint64, bool)
+
+	ReadAddressIndex(addr types.Address, from TxPosition, fn func(TxPosition) bool) error
+	ReadAddressIndexTail() (uint64, bool)
+	ReadAddressIndexHead() (uint64, bool)
+
+	ReadBloomBits(bit uint, section uint64) ([]byte, bool)
+	ReadBloomBitsSections() (uint64, bool)
+	ReadBloomBitsSectionHead(section uint64) (types.Hash, bool)
+
+	NewBatch() Batch
+
 	Close() error
 }
 
+// Batch is a set of writes to the storage that are committed atomically by Write.
+// The writes in the batch are not visible to the reads until the batch is written
+type Batch interface {
+	WriteCanonicalHash(n uint64, hash types.Hash) error
+
+	WriteHeadHash(h types.Hash) error
+	WriteHeadNumber(uint64) error
+
+	WriteForks(forks []types.Hash) error
+
+	WriteTotalDifficulty(hash types.Hash, diff *big.Int) error
+
+	WriteHeader(h *types.Header) error
+
+	WriteCanonicalHeader(h *types.Header, diff *big.Int) error
+
+	WriteBody(hash types.Hash, body *types.Body) error
+
+	WriteReceipts(hash types.Hash, receipts []*types.Receipt) error
+
+	WriteTxLookup(hash types.Hash, blockHash types.Hash) error
+
+	WriteFrozen(n uint64, hash types.Hash) error
+	WriteFrozenCount(n uint64) error
+
+	WriteAddressIndex(addr types.Address, pos TxPosition) error
+	WriteAddressIndexTail(n uint64) error
+	WriteAddressIndexHead(n uint64) error
+
+	WriteBloomBits(bit uint, section uint64, bitset []byte) error
+	WriteBloomBitsSections(n uint64) error
+	WriteBloomBitsSectionHead(section uint64, hash types.Hash) error
+
+	DeleteCanonicalHash(n uint64) error
+	DeleteReceipts(hash types.Hash) error
+	DeleteTxLookup(hash types.Hash) error
+	DeleteFrozen(hash types.Hash) error
+	DeleteAddressIndex(addr types.Address, pos TxPosition) error
+
+	Write() error
+}
+
+// TxPosition is the position of a transaction in the chain
+type TxPosition struct {
+	// Number is the number of the block including the transaction
+	Number uint64
+	// Index is the index of the transaction in the block
+	Index uint64
+	// BlockHash is the hash of the block including the transaction
+	BlockHash types.Hash
+}
+
 // Factory is a factory method to create a blockchain storage
 type Factory func(config map[string]interface{}, logger hclog.Logger) (Storage, error)
@@ -48,6 +48,24 @@ func TestStorage(t *testing.T, m PlaceholderStorage) {
 	t.Run("", func(t *testing.T) {
 		testReceipts(t, m)
 	})
+	t.Run("", func(t *testing.T) {
+		testConsensusData(t, m)
+	})
+	t.Run("", func(t *testing.T) {
+		testBatch(t, m)
+	})
+	t.Run("", func(t *testing.T) {
+		testFrozen(t, m)
+	})
+	t.Run("", func(t *testing.T) {
+		testBatchDelete(t, m)
+	})
+	t.Run("", func(t *testing.T) {
+		testAddressIndex(t, m)
+	})
+	t.Run("", func(t *testing.T) {
+		testBloomBits(t, m)
+	})
 }
 
 func testCanonicalChain(t *testing.T, m PlaceholderStorage) {
@@ -446,6 +464,278 @@ func testWriteCanonicalHeader(t *testing.T, m PlaceholderStorage) {
 	}
 }
 
+func testConsensusData(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	key := []byte("key")
+
+	_, ok := s.ReadConsensusData(key)
+	assert.False(t, ok)
+
+	assert.NoError(t, s.WriteConsensusData(key, []byte{0x1, 0x2}))
+
+	data, ok := s.ReadConsensusData(key)
+	assert.True(t, ok)
+	assert.Equal(t, []byte{0x1, 0x2}, data)
+}
+
+func testBatch(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	header := &types.Header{
+		Number:    5,
+		ExtraData: []byte{},
+	}
+	header.ComputeHash()
+
+	body := &types.Body{
+		Transactions: []*types.Transaction{},
+		Uncles:       []*types.Header{},
+	}
+
+	batch := s.NewBatch()
+
+	assert.NoError(t, batch.WriteBody(header.Hash, body))
+	assert.NoError(t, batch.WriteReceipts(header.Hash, []*types.Receipt{}))
+	assert.NoError(t, batch.WriteCanonicalHeader(header, big.NewInt(10)))
+
+	// the writes are not visible until the batch is written
+	_, ok := s.ReadHeadHash()
+	assert.False(t, ok)
+
+	_, err := s.ReadBody(header.Hash)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	assert.NoError(t, batch.Write())
+
+	headHash, ok := s.ReadHeadHash()
+	assert.True(t, ok)
+	assert.Equal(t, header.Hash, headHash)
+
+	headNumber, ok := s.ReadHeadNumber()
+	assert.True(t, ok)
+	assert.Equal(t, header.Number, headNumber)
+
+	readHeader, err := s.ReadHeader(header.Hash)
+	assert.NoError(t, err)
+	assert.Equal(t, header.Number, readHeader.Number)
+
+	_, err = s.ReadBody(header.Hash)
+	assert.NoError(t, err)
+
+	_, err = s.ReadReceipts(header.Hash)
+	assert.NoError(t, err)
+
+	diff, ok := s.ReadTotalDifficulty(header.Hash)
+	assert.True(t, ok)
+	assert.Equal(t, big.NewInt(10), diff)
+}
+
+func testFrozen(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	header := &types.Header{
+		Number:    5,
+		ExtraData: []byte{},
+	}
+	header.ComputeHash()
+
+	assert.NoError(t, s.WriteCanonicalHeader(header, big.NewInt(10)))
+	assert.NoError(t, s.WriteBody(header.Hash, &types.Body{}))
+	assert.NoError(t, s.WriteReceipts(header.Hash, []*types.Receipt{}))
+
+	_, ok := s.ReadFrozenCount()
+	assert.False(t, ok)
+
+	batch := s.NewBatch()
+
+	assert.NoError(t, batch.WriteFrozen(header.Number, header.Hash))
+	assert.NoError(t, batch.WriteFrozenCount(header.Number+1))
+	assert.NoError(t, batch.Write())
+
+	number, ok := s.ReadFrozenNumber(header.Hash)
+	assert.True(t, ok)
+	assert.Equal(t, header.Number, number)
+
+	count, ok := s.ReadFrozenCount()
+	assert.True(t, ok)
+	assert.Equal(t, header.Number+1, count)
+
+	// the frozen block is deleted except for the canonical hash and the difficulty
+	_, err := s.ReadHeader(header.Hash)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = s.ReadBody(header.Hash)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = s.ReadReceipts(header.Hash)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	canonicalHash, ok := s.ReadCanonicalHash(header.Number)
+	assert.True(t, ok)
+	assert.Equal(t, header.Hash, canonicalHash)
+
+	_, ok = s.ReadTotalDifficulty(header.Hash)
+	assert.True(t, ok)
+}
+
+func testBatchDelete(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	header := &types.Header{
+		Number:    3,
+		ExtraData: []byte{},
+	}
+	header.ComputeHash()
+
+	txHash := types.StringToHash("tx")
+
+	assert.NoError(t, s.WriteCanonicalHeader(header, big.NewInt(10)))
+	assert.NoError(t, s.WriteReceipts(header.Hash, []*types.Receipt{}))
+	assert.NoError(t, s.WriteTxLookup(txHash, header.Hash))
+
+	batch := s.NewBatch()
+
+	assert.NoError(t, batch.WriteFrozen(header.Number, header.Hash))
+	assert.NoError(t, batch.Write())
+
+	batch = s.NewBatch()
+
+	assert.NoError(t, batch.DeleteCanonicalHash(header.Number))
+	assert.NoError(t, batch.DeleteReceipts(header.Hash))
+	assert.NoError(t, batch.DeleteTxLookup(txHash))
+	assert.NoError(t, batch.DeleteFrozen(header.Hash))
+
+	// the deletions are not visible until the batch is written
+	_, ok := s.ReadCanonicalHash(header.Number)
+	assert.True(t, ok)
+
+	assert.NoError(t, batch.Write())
+
+	_, ok = s.ReadCanonicalHash(header.Number)
+	assert.False(t, ok)
+
+	_, err := s.ReadReceipts(header.Hash)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, ok = s.ReadTxLookup(txHash)
+	assert.False(t, ok)
+
+	_, ok = s.ReadFrozenNumber(header.Hash)
+	assert.False(t, ok)
+
+	_, ok = s.ReadTotalDifficulty(header.Hash)
+	assert.True(t, ok)
+}
+
+func testAddressIndex(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	positions := []TxPosition{
+		{Number: 1, Index: 0, BlockHash: hash1},
+		{Number: 1, Index: 2, BlockHash: hash1},
+		{Number: 256, Index: 1, BlockHash: hash2},
+	}
+
+	batch := s.NewBatch()
+
+	// written in the reverse order
+	for i := len(positions) - 1; i >= 0; i-- {
+		assert.NoError(t, batch.WriteAddressIndex(addr1, positions[i]))
+	}
+
+	assert.NoError(t, batch.WriteAddressIndex(addr2, TxPosition{Number: 1, Index: 1, BlockHash: hash1}))
+	assert.NoError(t, batch.WriteAddressIndexTail(1))
+	assert.NoError(t, batch.WriteAddressIndexHead(256))
+	assert.NoError(t, batch.Write())
+
+	readAll := func(addr types.Address, from TxPosition, limit int) []TxPosition {
+		t.Helper()
+
+		res := []TxPosition{}
+
+		assert.NoError(t, s.ReadAddressIndex(addr, from, func(pos TxPosition) bool {
+			res = append(res, pos)
+
+			return len(res) < limit
+		}))
+
+		return res
+	}
+
+	assert.Equal(t, positions, readAll(addr1, TxPosition{}, 10))
+	assert.Equal(t, positions[:2], readAll(addr1, TxPosition{}, 2))
+	assert.Equal(t, positions[1:], readAll(addr1, TxPosition{Number: 1, Index: 1}, 10))
+	assert.Equal(t, []TxPosition{{Number: 1, Index: 1, BlockHash: hash1}}, readAll(addr2, TxPosition{}, 10))
+	assert.Empty(t, readAll(types.StringToAddress("3"), TxPosition{}, 10))
+
+	tail, ok := s.ReadAddressIndexTail()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), tail)
+
+	head, ok := s.ReadAddressIndexHead()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(256), head)
+
+	batch = s.NewBatch()
+	assert.NoError(t, batch.DeleteAddressIndex(addr1, positions[1]))
+	assert.NoError(t, batch.Write())
+
+	assert.Equal(t, []TxPosition{positions[0], positions[2]}, readAll(addr1, TxPosition{}, 10))
+}
+
+func testBloomBits(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	_, ok := s.ReadBloomBitsSections()
+	assert.False(t, ok)
+
+	batch := s.NewBatch()
+
+	assert.NoError(t, batch.WriteBloomBits(0, 1, []byte{0x1}))
+	assert.NoError(t, batch.WriteBloomBits(2047, 1, []byte{0x2}))
+	assert.NoError(t, batch.WriteBloomBitsSectionHead(1, hash1))
+	assert.NoError(t, batch.WriteBloomBitsSections(2))
+	assert.NoError(t, batch.Write())
+
+	bitset, ok := s.ReadBloomBits(0, 1)
+	assert.True(t, ok)
+	assert.Equal(t, []byte{0x1}, bitset)
+
+	bitset, ok = s.ReadBloomBits(2047, 1)
+	assert.True(t, ok)
+	assert.Equal(t, []byte{0x2}, bitset)
+
+	_, ok = s.ReadBloomBits(0, 0)
+	assert.False(t, ok)
+
+	head, ok := s.ReadBloomBitsSectionHead(1)
+	assert.True(t, ok)
+	assert.Equal(t, hash1, head)
+
+	sections, ok := s.ReadBloomBitsSections()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), sections)
+}
+
 // Storage delegators
 
 type readCanonicalHashDelegate func(uint64) (types.Hash, bool)
@@ -469,29 +759,51 @@ type writeReceiptsDelegate func(types.Hash, []*types.Receipt) error
 type readReceiptsDelegate func(types.Hash) ([]*types.Receipt, error)
 type writeTxLookupDelegate func(types.Hash, types.Hash) error
 type readTxLookupDelegate func(types.Hash) (types.Hash, bool)
+type writeConsensusDataDelegate func([]byte, []byte) error
+type readConsensusDataDelegate func([]byte) ([]byte, bool)
+type readFrozenNumberDelegate func(types.Hash) (uint64, bool)
+type readFrozenCountDelegate func() (uint64, bool)
+type readAddressIndexDelegate func(types.Address, TxPosition, func(TxPosition) bool) error
+type readAddressIndexTailDelegate func() (uint64, bool)
+type readAddressIndexHeadDelegate func() (uint64, bool)
+type readBloomBitsDelegate func(uint, uint64) ([]byte, bool)
+type readBloomBitsSectionsDelegate func() (uint64, bool)
+type readBloomBitsSectionHeadDelegate func(uint64) (types.Hash, bool)
+type newBatchDelegate func() Batch
 type closeDelegate func() error
 
 type MockStorage struct {
-	readCanonicalHashFn    readCanonicalHashDelegate
-	writeCanonicalHashFn   writeCanonicalHashDelegate
-	readHeadHashFn         readHeadHashDelegate
-	readHeadNumberFn       readHeadNumberDelegate
-	writeHeadHashFn        writeHeadHashDelegate
-	writeHeadNumberFn      writeHeadNumberDelegate
-	writeForksFn           writeForksDelegate
-	readForksFn            readForksDelegate
-	writeTotalDifficultyFn writeTotalDifficultyDelegate
-	readTotalDifficultyFn  readTotalDifficultyDelegate
-	writeHeaderFn          writeHeaderDelegate
-	readHeaderFn           readHeaderDelegate
-	writeCanonicalHeaderFn writeCanonicalHeaderDelegate
-	writeBodyFn            writeBodyDelegate
-	readBodyFn             readBodyDelegate
-	writeReceiptsFn        writeReceiptsDelegate
-	readReceiptsFn         readReceiptsDelegate
-	writeTxLookupFn        writeTxLookupDelegate
-	readTxLookupFn         readTxLookupDelegate
-	closeFn                closeDelegate
+	readCanonicalHashFn        readCanonicalHashDelegate
+	writeCanonicalHashFn       writeCanonicalHashDelegate
+	readHeadHashFn             readHeadHashDelegate
+	readHeadNumberFn           readHeadNumberDelegate
+	writeHeadHashFn            writeHeadHashDelegate
+	writeHeadNumberFn          writeHeadNumberDelegate
+	writeForksFn               writeForksDelegate
+	readForksFn                readForksDelegate
+	writeTotalDifficultyFn     writeTotalDifficultyDelegate
+	readTotalDifficultyFn      readTotalDifficultyDelegate
+	writeHeaderFn              writeHeaderDelegate
+	readHeaderFn               readHeaderDelegate
+	writeCanonicalHeaderFn     writeCanonicalHeaderDelegate
+	writeBodyFn                writeBodyDelegate
+	readBodyFn                 readBodyDelegate
+	writeReceiptsFn            writeReceiptsDelegate
+	readReceiptsFn             readReceiptsDelegate
+	writeTxLookupFn            writeTxLookupDelegate
+	readTxLookupFn             readTxLookupDelegate
+	writeConsensusDataFn       writeConsensusDataDelegate
+	readConsensusDataFn        readConsensusDataDelegate
+	readFrozenNumberFn         readFrozenNumberDelegate
+	readFrozenCountFn          readFrozenCountDelegate
+	readAddressIndexFn         readAddressIndexDelegate
+	readAddressIndexTailFn     readAddressIndexTailDelegate
+	readAddressIndexHeadFn     readAddressIndexHeadDelegate
+	readBloomBitsFn            readBloomBitsDelegate
+	readBloomBitsSectionsFn    readBloomBitsSectionsDelegate
+	readBloomBitsSectionHeadFn readBloomBitsSectionHeadDelegate
+	newBatchFn                 newBatchDelegate
+	closeFn                    closeDelegate
 }
 
 func NewMockStorage() *MockStorage {
@@ -726,6 +1038,138 @@ func (m *MockStorage) HookReadTxLookup(fn readTxLookupDelegate) {
 	m.readTxLookupFn = fn
 }
 
+func (m *MockStorage) WriteConsensusData(key []byte, data []byte) error {
+	if m.writeConsensusDataFn != nil {
+		return m.writeConsensusDataFn(key, data)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookWriteConsensusData(fn writeConsensusDataDelegate) {
+	m.writeConsensusDataFn = fn
+}
+
+func (m *MockStorage) ReadConsensusData(key []byte) ([]byte, bool) {
+	if m.readConsensusDataFn != nil {
+		return m.readConsensusDataFn(key)
+	}
+
+	return nil, false
+}
+
+func (m *MockStorage) HookReadConsensusData(fn readConsensusDataDelegate) {
+	m.readConsensusDataFn = fn
+}
+
+func (m *MockStorage) ReadFrozenNumber(hash types.Hash) (uint64, bool) {
+	if m.readFrozenNumberFn != nil {
+		return m.readFrozenNumberFn(hash)
+	}
+
+	return 0, false
+}
+
+func (m *MockStorage) HookReadFrozenNumber(fn readFrozenNumberDelegate) {
+	m.readFrozenNumberFn = fn
+}
+
+func (m *MockStorage) ReadFrozenCount() (uint64, bool) {
+	if m.readFrozenCountFn != nil {
+		return m.readFrozenCountFn()
+	}
+
+	return 0, false
+}
+
+func (m *MockStorage) HookReadFrozenCount(fn readFrozenCountDelegate) {
+	m.readFrozenCountFn = fn
+}
+
+func (m *MockStorage) ReadAddressIndex(addr types.Address, from TxPosition, fn func(TxPosition) bool) error {
+	if m.readAddressIndexFn != nil {
+		return m.readAddressIndexFn(addr, from, fn)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookReadAddressIndex(fn readAddressIndexDelegate) {
+	m.readAddressIndexFn = fn
+}
+
+func (m *MockStorage) ReadAddressIndexTail() (uint64, bool) {
+	if m.readAddressIndexTailFn != nil {
+		return m.readAddressIndexTailFn()
+	}
+
+	return 0, false
+}
+
+func (m *MockStorage) HookReadAddressIndexTail(fn readAddressIndexTailDelegate) {
+	m.readAddressIndexTailFn = fn
+}
+
+func (m *MockStorage) ReadAddressIndexHead() (uint64, bool) {
+	if m.readAddressIndexHeadFn != nil {
+		return m.readAddressIndexHeadFn()
+	}
+
+	return 0, false
+}
+
+func (m *MockStorage) HookReadAddressIndexHead(fn readAddressIndexHeadDelegate) {
+	m.readAddressIndexHeadFn = fn
+}
+
+func (m *MockStorage) ReadBloomBits(bit uint, section uint64) ([]byte, bool) {
+	if m.readBloomBitsFn != nil {
+		return m.readBloomBitsFn(bit, section)
+	}
+
+	return nil, false
+}
+
+func (m *MockStorage) HookReadBloomBits(fn readBloomBitsDelegate) {
+	m.readBloomBitsFn = fn
+}
+
+func (m *MockStorage) ReadBloomBitsSections() (uint64, bool) {
+	if m.readBloomBitsSectionsFn != nil {
+		return m.readBloomBitsSectionsFn()
+	}
+
+	return 0, false
+}
+
+func (m *MockStorage) HookReadBloomBitsSections(fn readBloomBitsSectionsDelegate) {
+	m.readBloomBitsSectionsFn = fn
+}
+
+func (m *MockStorage) ReadBloomBitsSectionHead(section uint64) (types.Hash, bool) {
+	if m.readBloomBitsSectionHeadFn != nil {
+		return m.readBloomBitsSectionHeadFn(section)
+	}
+
+	return types.Hash{}, false
+}
+
+func (m *MockStorage) HookReadBloomBitsSectionHead(fn readBloomBitsSectionHeadDelegate) {
+	m.readBloomBitsSectionHeadFn = fn
+}
+
+func (m *MockStorage) NewBatch() Batch {
+	if m.newBatchFn != nil {
+		return m.newBatchFn()
+	}
+
+	return &mockBatch{m}
+}
+
+func (m *MockStorage) HookNewBatch(fn newBatchDelegate) {
+	m.newBatchFn = fn
+}
+
 func (m *MockStorage) Close() error {
 	if m.closeFn != nil {
 		return m.closeFn()
@@ -737,3 +1181,64 @@ func (m *MockStorage) Close() error {
 func (m *MockStorage) HookClose(fn closeDelegate) {
 	m.closeFn = fn
 }
+
+// mockBatch is the batch of MockStorage that passes the writes to MockStorage immediately
+type mockBatch struct {
+	*MockStorage
+}
+
+func (b *mockBatch) WriteFrozen(n uint64, hash types.Hash) error {
+	return nil
+}
+
+func (b *mockBatch) WriteFrozenCount(n uint64) error {
+	return nil
+}
+
+func (b *mockBatch) WriteAddressIndex(addr types.Address, pos TxPosition) error {
+	return nil
+}
+
+func (b *mockBatch) WriteAddressIndexTail(n uint64) error {
+	return nil
+}
+
+func (b *mockBatch) WriteAddressIndexHead(n uint64) error {
+	return nil
+}
+
+func (b *mockBatch) WriteBloomBits(bit uint, section uint64, bitset []byte) error {
+	return nil
+}
+
+func (b *mockBatch) WriteBloomBitsSections(n uint64) error {
+	return nil
+}
+
+func (b *mockBatch) WriteBloomBitsSectionHead(section uint64, hash types.Hash) error {
+	return nil
+}
+
+func (b *mockBatch) DeleteCanonicalHash(n uint64) error {
+	return nil
+}
+
+func (b *mockBatch) DeleteReceipts(hash types.Hash) error {
+	return nil
+}
+
+func (b *mockBatch) DeleteTxLookup(hash types.Hash) error {
+	return nil
+}
+
+func (b *mockBatch) DeleteFrozen(hash types.Hash) error {
+	return nil
+}
+
+func (b *mockBatch) DeleteAddressIndex(addr types.Address, pos TxPosition) error {
+	return nil
+}
+
+func (b *mockBatch) Write() error {
+	return nil
+}
@@ -87,9 +87,10 @@ func (s *subscription) Close() {
 type EventType int
 
 const (
-	EventHead  EventType = iota // New head event
-	EventReorg                  // Chain reorganization event
-	EventFork                   // Chain fork event
+	EventHead   EventType = iota // New head event
+	EventReorg                   // Chain reorganization event
+	EventFork                    // Chain fork event
+	EventRewind                  // Chain rewind event
 )
 
 // Event is the blockchain event that gets passed to the listeners
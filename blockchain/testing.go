@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/memory"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/state"
@@ -115,7 +116,7 @@ func NewTestBlockchain(t *testing.T, headers []*types.Header) *Blockchain {
 	}
 
 	if headers != nil {
-		if _, err := b.advanceHead(headers[0]); err != nil {
+		if _, err := b.advanceTestHead(headers[0]); err != nil {
 			t.Fatal(err)
 		}
 
@@ -129,6 +130,35 @@ func NewTestBlockchain(t *testing.T, headers []*types.Header) *Blockchain {
 	return b
 }
 
+// advanceTestHead writes the header as the new head and updates the blockchain reference
+func (b *Blockchain) advanceTestHead(header *types.Header) (*big.Int, error) {
+	batch := b.db.NewBatch()
+
+	td, err := b.advanceHead(batch, header)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := batch.Write(); err != nil {
+		return nil, err
+	}
+
+	b.setCurrentHeader(header, td)
+
+	return td, nil
+}
+
+// writeTestBody writes the body of the block to the storage
+func (b *Blockchain) writeTestBody(block *types.Block) error {
+	batch := b.db.NewBatch()
+
+	if err := b.writeBody(batch, block); err != nil {
+		return err
+	}
+
+	return batch.Write()
+}
+
 type TestCallbackType string
 
 const (
@@ -343,7 +373,12 @@ func newBlockChain(config *chain.Chain, executor Executor) (*Blockchain, error)
 		executor = &mockExecutor{}
 	}
 
-	b, err := NewBlockchain(hclog.NewNullLogger(), "", config, &MockVerifier{}, executor, &mockSigner{})
+	db, err := memory.NewMemoryStorage(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := NewBlockchain(hclog.NewNullLogger(), db, config, &MockVerifier{}, executor, &mockSigner{})
 	if err != nil {
 		return nil, err
 	}
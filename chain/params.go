@@ -1,11 +1,21 @@
 package chain
 
 import (
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"math/big"
+	"strconv"
+	"strings"
 
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
+var (
+	ErrInvalidCheckpoint     = errors.New("invalid checkpoint, expected <number>:<hash>")
+	ErrConflictingCheckpoint = errors.New("conflicting checkpoints at the same height")
+)
+
 // Params are all the set of params for the chain
 type Params struct {
 	Forks          *Forks                 `json:"forks"`
@@ -13,6 +23,7 @@ type Params struct {
 	Engine         map[string]interface{} `json:"engine"`
 	Whitelists     *Whitelists            `json:"whitelists,omitempty"`
 	BlockGasTarget uint64                 `json:"blockGasTarget"`
+	Checkpoints    []*Checkpoint          `json:"checkpoints,omitempty"`
 }
 
 func (p *Params) GetEngine() string {
@@ -24,6 +35,66 @@ func (p *Params) GetEngine() string {
 	return ""
 }
 
+// GetCheckpoint returns the hash of the checkpoint at the given height, if any
+func (p *Params) GetCheckpoint(number uint64) (types.Hash, bool) {
+	for _, c := range p.Checkpoints {
+		if c.Number == number {
+			return c.Hash, true
+		}
+	}
+
+	return types.ZeroHash, false
+}
+
+// AddCheckpoint adds the checkpoint, failing if it conflicts with the existing one at the same height
+func (p *Params) AddCheckpoint(checkpoint *Checkpoint) error {
+	if hash, ok := p.GetCheckpoint(checkpoint.Number); ok {
+		if hash != checkpoint.Hash {
+			return fmt.Errorf("%w %d", ErrConflictingCheckpoint, checkpoint.Number)
+		}
+
+		return nil
+	}
+
+	p.Checkpoints = append(p.Checkpoints, checkpoint)
+
+	return nil
+}
+
+// Checkpoint is a trusted block hash at the height.
+// The chain conflicting with a checkpoint is rejected
+type Checkpoint struct {
+	Number uint64     `json:"number"`
+	Hash   types.Hash `json:"hash"`
+}
+
+// ParseCheckpoint parses the checkpoint in the format of <number>:<hash>
+func ParseCheckpoint(raw string) (*Checkpoint, error) {
+	parts := strings.Split(raw, ":")
+	if len(parts) != 2 {
+		return nil, ErrInvalidCheckpoint
+	}
+
+	number, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCheckpoint, err.Error())
+	}
+
+	hash, err := hex.DecodeString(strings.TrimPrefix(parts[1], "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCheckpoint, err.Error())
+	}
+
+	if len(hash) != types.HashLength {
+		return nil, fmt.Errorf("%w: hash must be %d bytes", ErrInvalidCheckpoint, types.HashLength)
+	}
+
+	return &Checkpoint{
+		Number: number,
+		Hash:   types.BytesToHash(hash),
+	}, nil
+}
+
 // Whitelists specifies supported whitelists
 type Whitelists struct {
 	Deployment []types.Address `json:"deployment,omitempty"`
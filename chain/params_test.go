@@ -3,7 +3,11 @@ package chain
 import (
 	"encoding/json"
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestParamsForks(t *testing.T) {
@@ -54,3 +58,94 @@ func TestParamsForksInTime(t *testing.T) {
 	expect("constantinople", ff.Constantinople, false)
 	expect("eip150", ff.EIP150, false)
 }
+
+func TestParseCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	validHash := "0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347"
+
+	tests := []struct {
+		name     string
+		input    string
+		expected *Checkpoint
+		err      error
+	}{
+		{
+			name:  "should parse checkpoint",
+			input: "1000:" + validHash,
+			expected: &Checkpoint{
+				Number: 1000,
+				Hash:   types.StringToHash(validHash),
+			},
+		},
+		{
+			name:  "should return error if the separator is missing",
+			input: validHash,
+			err:   ErrInvalidCheckpoint,
+		},
+		{
+			name:  "should return error if the number is invalid",
+			input: "0x10:" + validHash,
+			err:   ErrInvalidCheckpoint,
+		},
+		{
+			name:  "should return error if the hash is short",
+			input: "1000:0x1234",
+			err:   ErrInvalidCheckpoint,
+		},
+		{
+			name:  "should return error if the hash is not hex",
+			input: "1000:0x" + strings.Repeat("z", 64),
+			err:   ErrInvalidCheckpoint,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			checkpoint, err := ParseCheckpoint(test.input)
+
+			assert.ErrorIs(t, err, test.err)
+			assert.Equal(t, test.expected, checkpoint)
+		})
+	}
+}
+
+func TestParamsCheckpoints(t *testing.T) {
+	t.Parallel()
+
+	var params *Params
+
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"checkpoints": [
+			{
+				"number": 100,
+				"hash": "0x0000000000000000000000000000000000000000000000000000000000000001"
+			}
+		]
+	}`), &params))
+
+	hash, ok := params.GetCheckpoint(100)
+	assert.True(t, ok)
+	assert.Equal(t, types.StringToHash("0x1"), hash)
+
+	_, ok = params.GetCheckpoint(101)
+	assert.False(t, ok)
+
+	// same checkpoint is accepted
+	assert.NoError(t, params.AddCheckpoint(&Checkpoint{Number: 100, Hash: types.StringToHash("0x1")}))
+	assert.Len(t, params.Checkpoints, 1)
+
+	// conflicting checkpoint is rejected
+	assert.ErrorIs(
+		t,
+		params.AddCheckpoint(&Checkpoint{Number: 100, Hash: types.StringToHash("0x2")}),
+		ErrConflictingCheckpoint,
+	)
+
+	assert.NoError(t, params.AddCheckpoint(&Checkpoint{Number: 200, Hash: types.StringToHash("0x2")}))
+	assert.Len(t, params.Checkpoints, 2)
+}
@@ -1,7 +1,9 @@
 package backup
 
 import (
+	"github.com/0xPolygon/polygon-edge/archive"
 	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/backup/verify"
 	"github.com/spf13/cobra"
 
 	"github.com/0xPolygon/polygon-edge/command/helper"
@@ -10,7 +12,7 @@ import (
 func GetCommand() *cobra.Command {
 	backupCmd := &cobra.Command{
 		Use:     "backup",
-		Short:   "Create blockchain backup file by fetching blockchain data from the running node",
+		Short:   "Create blockchain backup by fetching blockchain data from the running node",
 		PreRunE: runPreRun,
 		Run:     runCommand,
 	}
@@ -20,6 +22,11 @@ func GetCommand() *cobra.Command {
 	setFlags(backupCmd)
 	helper.SetRequiredFlags(backupCmd, params.getRequiredFlags())
 
+	backupCmd.AddCommand(
+		// backup verify
+		verify.GetCommand(),
+	)
+
 	return backupCmd
 }
 
@@ -28,7 +35,7 @@ func setFlags(cmd *cobra.Command) {
 		&params.out,
 		outFlag,
 		"",
-		"the export path for the backup",
+		"the export directory for the backup, must not exist",
 	)
 
 	cmd.Flags().StringVar(
@@ -44,6 +51,20 @@ func setFlags(cmd *cobra.Command) {
 		"",
 		"the end height of the chain in backup",
 	)
+
+	cmd.Flags().StringVar(
+		&params.base,
+		baseFlag,
+		"",
+		"the directory of a previous backup to create an incremental backup from its latest block",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.chunkSize,
+		chunkSizeFlag,
+		archive.DefaultChunkSize,
+		"the number of blocks in each compressed chunk of the backup",
+	)
 }
 
 func runPreRun(_ *cobra.Command, _ []string) error {
@@ -11,9 +11,11 @@ import (
 )
 
 const (
-	outFlag  = "out"
-	fromFlag = "from"
-	toFlag   = "to"
+	outFlag       = "out"
+	fromFlag      = "from"
+	toFlag        = "to"
+	baseFlag      = "base"
+	chunkSizeFlag = "chunk-size"
 )
 
 var (
@@ -21,12 +23,17 @@ var (
 )
 
 var (
-	errDecodeRange  = errors.New("unable to decode range value")
-	errInvalidRange = errors.New(`invalid "to" value; must be >= "from"`)
+	errDecodeRange      = errors.New("unable to decode range value")
+	errInvalidRange     = errors.New(`invalid "to" value; must be >= "from"`)
+	errFromWithBase     = errors.New(`"from" can't be set for incremental backup, it continues from the base backup`)
+	errInvalidChunkSize = errors.New("chunk size must be greater than 0")
 )
 
 type backupParams struct {
-	out string
+	out  string
+	base string
+
+	chunkSize uint64
 
 	fromRaw string
 	toRaw   string
@@ -34,13 +41,20 @@ type backupParams struct {
 	from uint64
 	to   *uint64
 
-	resFrom uint64
-	resTo   uint64
+	manifest *archive.Manifest
 }
 
 func (p *backupParams) validateFlags() error {
 	var parseErr error
 
+	if p.chunkSize == 0 {
+		return errInvalidChunkSize
+	}
+
+	if p.base != "" && p.fromRaw != "0" {
+		return errFromWithBase
+	}
+
 	if p.from, parseErr = types.ParseUint64orHex(&p.fromRaw); parseErr != nil {
 		return errDecodeRange
 	}
@@ -76,8 +90,7 @@ func (p *backupParams) createBackup(grpcAddress string) error {
 		return err
 	}
 
-	// resFrom and resTo represents the range of blocks that can be included in the file
-	resFrom, resTo, err := archive.CreateBackup(
+	manifest, err := archive.CreateBackup(
 		connection,
 		hclog.New(&hclog.LoggerOptions{
 			Name:  "backup",
@@ -86,21 +99,24 @@ func (p *backupParams) createBackup(grpcAddress string) error {
 		p.from,
 		p.to,
 		p.out,
+		p.base,
+		p.chunkSize,
 	)
 	if err != nil {
 		return err
 	}
 
-	p.resFrom = resFrom
-	p.resTo = resTo
+	p.manifest = manifest
 
 	return nil
 }
 
 func (p *backupParams) getResult() command.CommandResult {
 	return &BackupResult{
-		From: p.resFrom,
-		To:   p.resTo,
-		Out:  p.out,
+		From:   p.manifest.From,
+		To:     p.manifest.Latest,
+		Out:    p.out,
+		Base:   p.base,
+		Chunks: len(p.manifest.Chunks),
 	}
 }
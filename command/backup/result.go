@@ -8,21 +8,31 @@ import (
 )
 
 type BackupResult struct {
-	From uint64 `json:"from"`
-	To   uint64 `json:"to"`
-	Out  string `json:"out"`
+	From   uint64 `json:"from"`
+	To     uint64 `json:"to"`
+	Out    string `json:"out"`
+	Base   string `json:"base,omitempty"`
+	Chunks int    `json:"chunks"`
 }
 
 func (r *BackupResult) GetOutput() string {
 	var buffer bytes.Buffer
 
 	buffer.WriteString("\n[BACKUP]\n")
-	buffer.WriteString("Exported backup file successfully:\n")
-	buffer.WriteString(helper.FormatKV([]string{
-		fmt.Sprintf("File|%s", r.Out),
+	buffer.WriteString("Exported backup successfully:\n")
+
+	vals := []string{
+		fmt.Sprintf("Directory|%s", r.Out),
 		fmt.Sprintf("From|%d", r.From),
 		fmt.Sprintf("To|%d", r.To),
-	}))
+		fmt.Sprintf("Chunks|%d", r.Chunks),
+	}
+
+	if r.Base != "" {
+		vals = append(vals, fmt.Sprintf("Base|%s", r.Base))
+	}
+
+	buffer.WriteString(helper.FormatKV(vals))
 
 	return buffer.String()
 }
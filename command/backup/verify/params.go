@@ -0,0 +1,53 @@
+package verify
+
+import (
+	"github.com/0xPolygon/polygon-edge/archive"
+	"github.com/0xPolygon/polygon-edge/command"
+)
+
+const (
+	pathFlag = "path"
+)
+
+var (
+	params = &verifyParams{}
+)
+
+type verifyParams struct {
+	path string
+
+	manifest *archive.Manifest
+}
+
+func (p *verifyParams) getRequiredFlags() []string {
+	return []string{
+		pathFlag,
+	}
+}
+
+func (p *verifyParams) verify() error {
+	manifest, err := archive.VerifyBackup(p.path)
+	if err != nil {
+		return err
+	}
+
+	p.manifest = manifest
+
+	return nil
+}
+
+func (p *verifyParams) getResult() command.CommandResult {
+	result := &VerifyResult{
+		Path:       p.path,
+		From:       p.manifest.From,
+		To:         p.manifest.Latest,
+		LatestHash: p.manifest.LatestHash.String(),
+		Chunks:     len(p.manifest.Chunks),
+	}
+
+	if p.manifest.IsIncremental() {
+		result.Parent = p.manifest.Parent.String()
+	}
+
+	return result
+}
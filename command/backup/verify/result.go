@@ -0,0 +1,41 @@
+package verify
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type VerifyResult struct {
+	Path       string `json:"path"`
+	From       uint64 `json:"from"`
+	To         uint64 `json:"to"`
+	LatestHash string `json:"latestHash"`
+	Parent     string `json:"parent,omitempty"`
+	Chunks     int    `json:"chunks"`
+}
+
+func (r *VerifyResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[BACKUP VERIFY]\n")
+	buffer.WriteString("Backup is valid:\n")
+
+	vals := []string{
+		fmt.Sprintf("Directory|%s", r.Path),
+		fmt.Sprintf("From|%d", r.From),
+		fmt.Sprintf("To|%d", r.To),
+		fmt.Sprintf("Latest hash|%s", r.LatestHash),
+		fmt.Sprintf("Chunks|%d", r.Chunks),
+	}
+
+	if r.Parent != "" {
+		vals = append(vals, fmt.Sprintf("Parent hash|%s", r.Parent))
+	}
+
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
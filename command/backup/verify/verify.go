@@ -0,0 +1,43 @@
+package verify
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	verifyCmd := &cobra.Command{
+		Use: "verify",
+		Short: "Verifies the chunk checksums and the hash chaining of the blocks " +
+			"in the backup without importing it",
+		Run: runCommand,
+	}
+
+	setFlags(verifyCmd)
+	helper.SetRequiredFlags(verifyCmd, params.getRequiredFlags())
+
+	return verifyCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.path,
+		pathFlag,
+		"",
+		"the directory of the backup to verify",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.verify(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,55 @@
+package convert
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	dbConvertCmd := &cobra.Command{
+		Use: "convert",
+		Short: "Converts the blockchain and state databases in the data directory to the other db engine. " +
+			"The node using the data directory must be stopped",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(dbConvertCmd)
+	helper.SetRequiredFlags(dbConvertCmd, params.getRequiredFlags())
+
+	return dbConvertCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory used for storing Polygon Edge client data",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawTo,
+		toFlag,
+		"",
+		"the db engine to convert the databases to (leveldb, pebble)",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.convert(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,62 @@
+package convert
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/helper/dbengine"
+)
+
+const (
+	dataDirFlag = "data-dir"
+	toFlag      = "to"
+)
+
+var (
+	params = &convertParams{}
+)
+
+type convertParams struct {
+	dataDir  string
+	rawTo    string
+	toEngine dbengine.Engine
+
+	results []dbengine.ConvertResult
+}
+
+func (p *convertParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+		toFlag,
+	}
+}
+
+func (p *convertParams) validateFlags() error {
+	var err error
+
+	p.toEngine, err = dbengine.ParseEngine(p.rawTo)
+
+	return err
+}
+
+func (p *convertParams) convert() error {
+	var err error
+
+	p.results, err = dbengine.Convert(p.dataDir, p.toEngine)
+
+	return err
+}
+
+func (p *convertParams) getResult() command.CommandResult {
+	databases := make([]DBConvertDatabase, len(p.results))
+
+	for i, res := range p.results {
+		databases[i] = DBConvertDatabase{
+			Path: res.Path,
+			Keys: res.Keys,
+		}
+	}
+
+	return &DBConvertResult{
+		Engine:    string(p.toEngine),
+		Databases: databases,
+	}
+}
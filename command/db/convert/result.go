@@ -0,0 +1,35 @@
+package convert
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type DBConvertDatabase struct {
+	Path string `json:"path"`
+	Keys uint64 `json:"keys"`
+}
+
+type DBConvertResult struct {
+	Engine    string              `json:"engine"`
+	Databases []DBConvertDatabase `json:"databases"`
+}
+
+func (r *DBConvertResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	vals := make([]string, 0, len(r.Databases)+1)
+	vals = append(vals, fmt.Sprintf("DB Engine|%s", r.Engine))
+
+	for _, db := range r.Databases {
+		vals = append(vals, fmt.Sprintf("%s|%d keys", db.Path, db.Keys))
+	}
+
+	buffer.WriteString("\n[DB CONVERT]\n")
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
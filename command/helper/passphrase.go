@@ -0,0 +1,152 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	secretsHelper "github.com/0xPolygon/polygon-edge/secrets/helper"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+const (
+	KeystorePassphraseFileFlag = "keystore-passphrase-file"
+	KeystorePassphraseEnvFlag  = "keystore-passphrase-env"
+)
+
+var (
+	errNoPassphrase = fmt.Errorf(
+		"no keystore passphrase given, use --%s or --%s, or run in a terminal",
+		KeystorePassphraseFileFlag,
+		KeystorePassphraseEnvFlag,
+	)
+	errEmptyPassphrase    = errors.New("keystore passphrase is empty")
+	errPassphraseMismatch = errors.New("keystore passphrases don't match")
+)
+
+// KeystorePassphraseParams are the sources of the passphrase encrypting the local secrets
+type KeystorePassphraseParams struct {
+	// File is the path to the file containing the passphrase
+	File string
+
+	// Env is the name of the environment variable containing the passphrase
+	Env string
+}
+
+// RegisterKeystorePassphraseFlags registers the flags of the keystore passphrase sources
+func RegisterKeystorePassphraseFlags(cmd *cobra.Command, params *KeystorePassphraseParams) {
+	cmd.Flags().StringVar(
+		&params.File,
+		KeystorePassphraseFileFlag,
+		"",
+		"the path to the file containing the passphrase of the encrypted local secrets",
+	)
+
+	cmd.Flags().StringVar(
+		&params.Env,
+		KeystorePassphraseEnvFlag,
+		"",
+		"the name of the environment variable containing the passphrase of the encrypted local secrets",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(KeystorePassphraseFileFlag, KeystorePassphraseEnvFlag)
+}
+
+// IsSet checks if the passphrase is given by the file or the environment variable
+func (p *KeystorePassphraseParams) IsSet() bool {
+	return p.File != "" || p.Env != ""
+}
+
+// ReadPassphrase reads the passphrase from the file or the environment variable,
+// or prompts for it in the terminal, twice if it's a new passphrase
+func (p *KeystorePassphraseParams) ReadPassphrase(isNew bool) (string, error) {
+	var passphrase string
+
+	switch {
+	case p.File != "":
+		data, err := os.ReadFile(p.File)
+		if err != nil {
+			return "", fmt.Errorf("unable to read keystore passphrase file, %w", err)
+		}
+
+		// Only the line break the editors add is trimmed, the passphrase may contain spaces
+		passphrase = strings.TrimRight(string(data), "\r\n")
+	case p.Env != "":
+		value, ok := os.LookupEnv(p.Env)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s of keystore passphrase is not set", p.Env)
+		}
+
+		passphrase = value
+	default:
+		return promptPassphrase(isNew)
+	}
+
+	if passphrase == "" {
+		return "", errEmptyPassphrase
+	}
+
+	return passphrase, nil
+}
+
+// ReadLocalSecretsPassphrase reads the passphrase of the local secrets in the data directory,
+// it's only prompted for if some of the secrets are encrypted. The passphrase is empty if it's not needed
+func (p *KeystorePassphraseParams) ReadLocalSecretsPassphrase(dataDir string) (string, error) {
+	if p.IsSet() {
+		return p.ReadPassphrase(false)
+	}
+
+	encrypted, err := secretsHelper.HasEncryptedLocalSecrets(dataDir)
+	if err != nil || !encrypted {
+		return "", err
+	}
+
+	return promptPassphrase(false)
+}
+
+// promptPassphrase prompts for the passphrase in the terminal without echoing it
+func promptPassphrase(isNew bool) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", errNoPassphrase
+	}
+
+	read := func(prompt string) (string, error) {
+		// The prompt goes to stderr, so it doesn't mix with the command output
+		fmt.Fprint(os.Stderr, prompt)
+		defer fmt.Fprintln(os.Stderr)
+
+		passphrase, err := term.ReadPassword(fd)
+		if err != nil {
+			return "", fmt.Errorf("unable to read keystore passphrase, %w", err)
+		}
+
+		return string(passphrase), nil
+	}
+
+	passphrase, err := read("Keystore passphrase: ")
+	if err != nil {
+		return "", err
+	}
+
+	if passphrase == "" {
+		return "", errEmptyPassphrase
+	}
+
+	if !isNew {
+		return passphrase, nil
+	}
+
+	confirmation, err := read("Repeat keystore passphrase: ")
+	if err != nil {
+		return "", err
+	}
+
+	if confirmation != passphrase {
+		return "", errPassphraseMismatch
+	}
+
+	return passphrase, nil
+}
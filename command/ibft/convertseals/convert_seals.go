@@ -0,0 +1,86 @@
+package convertseals
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	convertSealsCmd := &cobra.Command{
+		Use: "convert-seals",
+		Short: "Schedule a fork switching ECDSA validators to BLS validators with aggregated seals. " +
+			"The fork is added to genesis.json, or written to the file to be proposed by ibft governance",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(convertSealsCmd)
+	helper.SetRequiredFlags(convertSealsCmd, params.getRequiredFlags())
+
+	return convertSealsCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.genesisPath,
+		chainFlag,
+		fmt.Sprintf("./%s", command.DefaultGenesisFileName),
+		"the genesis file to read the last fork from and to update",
+	)
+
+	cmd.Flags().StringVar(
+		&params.fromRaw,
+		fromFlag,
+		"",
+		"the height to switch to the aggregated seals",
+	)
+
+	cmd.Flags().StringVar(
+		&params.outputPath,
+		outputFlag,
+		"",
+		"the file to write the fork to instead of updating the genesis file, "+
+			"which can be proposed by ibft governance propose",
+	)
+
+	{
+		// PoA Configuration
+		cmd.Flags().StringVar(
+			&params.ibftValidatorPrefixPath,
+			command.IBFTValidatorPrefixFlag,
+			"",
+			"prefix path for validator folder directory. "+
+				"Needs to be present in PoA if ibft-validator is omitted",
+		)
+
+		cmd.Flags().StringArrayVar(
+			&params.ibftValidatorsRaw,
+			command.IBFTValidatorFlag,
+			[]string{},
+			"BLS validators in the format of [address]:[BLS public key], can be used multiple times. "+
+				"Needs to be present in PoA if ibft-validators-prefix-path is omitted",
+		)
+
+		cmd.MarkFlagsMutuallyExclusive(command.IBFTValidatorPrefixFlag, command.IBFTValidatorFlag)
+	}
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.initRawParams()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.scheduleFork(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,250 @@
+package convertseals
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/fork"
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+)
+
+const (
+	chainFlag  = "chain"
+	fromFlag   = "from"
+	outputFlag = "output"
+)
+
+var (
+	ErrFromPositive          = errors.New(`"from" must be positive number`)
+	ErrIBFTConfigNotFound    = errors.New(`"ibft" config doesn't exist in "engine" of genesis.json'`)
+	ErrLessFromThanLastFrom  = errors.New(`"from" must be greater than the beginning height of last fork`)
+	ErrAlreadyAggregatedSeal = errors.New("the last fork already uses the aggregated seals")
+	ErrValidatorsRequired    = errors.New("BLS validators are required to convert the seals in PoA")
+	ErrValidatorsInPoS       = errors.New("validators can't be specified in PoS, they are fetched from the contract")
+)
+
+var (
+	params = &convertSealsParams{}
+)
+
+type convertSealsParams struct {
+	genesisPath string
+	outputPath  string
+
+	fromRaw string
+	from    uint64
+
+	// PoA
+	ibftValidatorPrefixPath string
+	ibftValidatorsRaw       []string
+	ibftValidators          validators.Validators
+
+	genesisConfig *chain.Chain
+	ibftConfig    map[string]interface{}
+	forks         fork.IBFTForks
+	newFork       *fork.IBFTFork
+}
+
+func (p *convertSealsParams) getRequiredFlags() []string {
+	return []string{
+		fromFlag,
+	}
+}
+
+func (p *convertSealsParams) initRawParams() error {
+	if err := p.initFrom(); err != nil {
+		return err
+	}
+
+	if err := p.initValidators(); err != nil {
+		return err
+	}
+
+	if err := p.initChain(); err != nil {
+		return err
+	}
+
+	return p.initNewFork()
+}
+
+func (p *convertSealsParams) initFrom() error {
+	from, err := types.ParseUint64orHex(&p.fromRaw)
+	if err != nil {
+		return fmt.Errorf("unable to parse from value, %w", err)
+	}
+
+	if from <= 0 {
+		return ErrFromPositive
+	}
+
+	p.from = from
+
+	return nil
+}
+
+func (p *convertSealsParams) initValidators() error {
+	p.ibftValidators = validators.NewBLSValidatorSet()
+
+	if p.ibftValidatorPrefixPath != "" {
+		vals, err := command.GetValidatorsFromPrefixPath(
+			p.ibftValidatorPrefixPath,
+			validators.BLSValidatorType,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to read from prefix: %w", err)
+		}
+
+		if err := p.ibftValidators.Merge(vals); err != nil {
+			return err
+		}
+	}
+
+	if len(p.ibftValidatorsRaw) > 0 {
+		vals, err := validators.ParseValidators(validators.BLSValidatorType, p.ibftValidatorsRaw)
+		if err != nil {
+			return err
+		}
+
+		if err := p.ibftValidators.Merge(vals); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *convertSealsParams) initChain() error {
+	cc, err := chain.Import(p.genesisPath)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to load chain config from %s: %w",
+			p.genesisPath,
+			err,
+		)
+	}
+
+	ibftConfig, ok := cc.Params.Engine["ibft"].(map[string]interface{})
+	if !ok {
+		return ErrIBFTConfigNotFound
+	}
+
+	forks, err := fork.GetIBFTForks(ibftConfig)
+	if err != nil {
+		return err
+	}
+
+	p.genesisConfig = cc
+	p.ibftConfig = ibftConfig
+	p.forks = forks
+
+	return nil
+}
+
+// initNewFork creates the fork that takes over the last fork
+// except for the validator type and the version of IBFT Extra
+func (p *convertSealsParams) initNewFork() error {
+	lastFork := p.forks[len(p.forks)-1]
+
+	if lastFork.ExtraVersion == signer.AggregatedSealsExtraVersion {
+		return ErrAlreadyAggregatedSeal
+	}
+
+	if p.from <= lastFork.From.Value {
+		return ErrLessFromThanLastFrom
+	}
+
+	newFork := *lastFork
+	newFork.From = common.JSONNumber{Value: p.from}
+	newFork.To = nil
+	newFork.Deployment = nil
+	newFork.ValidatorType = validators.BLSValidatorType
+	newFork.ExtraVersion = signer.AggregatedSealsExtraVersion
+	newFork.Validators = nil
+
+	switch newFork.Type {
+	case fork.PoA:
+		if p.ibftValidators.Len() == 0 {
+			return ErrValidatorsRequired
+		}
+
+		newFork.Validators = p.ibftValidators
+	case fork.PoS:
+		if p.ibftValidators.Len() > 0 {
+			return ErrValidatorsInPoS
+		}
+	}
+
+	if err := newFork.Validate(); err != nil {
+		return err
+	}
+
+	p.newFork = &newFork
+
+	return nil
+}
+
+// scheduleFork writes the new fork to the output file, or to the genesis file if the output is not given
+func (p *convertSealsParams) scheduleFork() error {
+	if p.outputPath != "" {
+		rawFork, err := json.MarshalIndent(p.newFork, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		return os.WriteFile(p.outputPath, rawFork, 0600)
+	}
+
+	lastFork := p.forks[len(p.forks)-1]
+	lastFork.To = &common.JSONNumber{Value: p.from - 1}
+
+	p.ibftConfig["types"] = append(p.forks, p.newFork)
+
+	// remove leftover config
+	delete(p.ibftConfig, "type")
+
+	p.genesisConfig.Params.Engine["ibft"] = p.ibftConfig
+
+	// Remove the current genesis configuration from disk
+	if err := os.Remove(p.genesisPath); err != nil {
+		return err
+	}
+
+	// Save the new genesis configuration
+	return helper.WriteGenesisConfigToDisk(
+		p.genesisConfig,
+		p.genesisPath,
+	)
+}
+
+func (p *convertSealsParams) getResult() command.CommandResult {
+	result := &ConvertSealsResult{
+		Type:          p.newFork.Type,
+		ValidatorType: p.newFork.ValidatorType,
+		ExtraVersion:  p.newFork.ExtraVersion,
+		From:          p.newFork.From,
+	}
+
+	if p.outputPath != "" {
+		result.Output = p.outputPath
+	} else {
+		result.Chain = p.genesisPath
+	}
+
+	if p.newFork.Validators != nil {
+		result.Validators = make([]string, 0, p.newFork.Validators.Len())
+
+		for idx := 0; idx < p.newFork.Validators.Len(); idx++ {
+			result.Validators = append(result.Validators, p.newFork.Validators.At(uint64(idx)).String())
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,51 @@
+package convertseals
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/fork"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/validators"
+)
+
+type ConvertSealsResult struct {
+	Chain         string                   `json:"chain,omitempty"`
+	Output        string                   `json:"output,omitempty"`
+	Type          fork.IBFTType            `json:"type"`
+	ValidatorType validators.ValidatorType `json:"validator_type"`
+	ExtraVersion  uint64                   `json:"extraVersion"`
+	From          common.JSONNumber        `json:"from"`
+	Validators    []string                 `json:"validators,omitempty"`
+}
+
+func (r *ConvertSealsResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[NEW IBFT FORK]\n")
+
+	outputs := []string{}
+
+	if r.Output != "" {
+		outputs = append(outputs, fmt.Sprintf("Output|%s", r.Output))
+	} else {
+		outputs = append(outputs, fmt.Sprintf("Chain|%s", r.Chain))
+	}
+
+	outputs = append(outputs,
+		fmt.Sprintf("Type|%s", r.Type),
+		fmt.Sprintf("ValidatorType|%s", r.ValidatorType),
+		fmt.Sprintf("ExtraVersion|%d", r.ExtraVersion),
+		fmt.Sprintf("From|%d", r.From.Value),
+	)
+
+	for idx, validator := range r.Validators {
+		outputs = append(outputs, fmt.Sprintf("Validator %d|%s", idx+1, validator))
+	}
+
+	buffer.WriteString(helper.FormatKV(outputs))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
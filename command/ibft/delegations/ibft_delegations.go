@@ -0,0 +1,48 @@
+package delegations
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	delegationsCmd := &cobra.Command{
+		Use:     "delegations",
+		Short:   "Returns the stake of the validator and the delegations to the validator",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(delegationsCmd)
+
+	helper.SetRequiredFlags(delegationsCmd, params.getRequiredFlags())
+
+	return delegationsCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.validatorRaw,
+		validatorFlag,
+		"",
+		"the address of the validator",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initDelegations(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
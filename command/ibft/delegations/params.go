@@ -0,0 +1,77 @@
+package delegations
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	ibftOp "github.com/0xPolygon/polygon-edge/consensus/ibft/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	validatorFlag = "validator"
+)
+
+var (
+	errInvalidAddressFormat = errors.New("invalid address format")
+)
+
+var (
+	params = &delegationsParams{}
+)
+
+type delegationsParams struct {
+	validatorRaw string
+
+	delegationsResponse *ibftOp.DelegationsResp
+}
+
+func (p *delegationsParams) getRequiredFlags() []string {
+	return []string{
+		validatorFlag,
+	}
+}
+
+func (p *delegationsParams) validateFlags() error {
+	var validator types.Address
+	if err := validator.UnmarshalText([]byte(p.validatorRaw)); err != nil {
+		return errInvalidAddressFormat
+	}
+
+	return nil
+}
+
+func (p *delegationsParams) initDelegations(grpcAddress string) error {
+	client, err := helper.GetIBFTOperatorClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	p.delegationsResponse, err = client.Delegations(
+		context.Background(),
+		&ibftOp.DelegationsReq{
+			Validator: p.validatorRaw,
+		},
+	)
+
+	return err
+}
+
+func (p *delegationsParams) getResult() *DelegationsResult {
+	resp := p.delegationsResponse
+
+	res := &DelegationsResult{
+		Validator:      resp.Validator,
+		StakedAmount:   resp.StakedAmount,
+		DelegatedStake: resp.DelegatedStake,
+		Delegations:    make([]Delegation, len(resp.Delegations)),
+	}
+
+	for i, d := range resp.Delegations {
+		res.Delegations[i].Delegator = d.Delegator
+		res.Delegations[i].Amount = d.Amount
+	}
+
+	return res
+}
@@ -0,0 +1,50 @@
+package delegations
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type Delegation struct {
+	Delegator string `json:"delegator"`
+	Amount    string `json:"amount"`
+}
+
+type DelegationsResult struct {
+	Validator      string       `json:"validator"`
+	StakedAmount   string       `json:"staked_amount"`
+	DelegatedStake string       `json:"delegated_stake"`
+	Delegations    []Delegation `json:"delegations"`
+}
+
+func (r *DelegationsResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[VALIDATOR STAKE]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Validator|%s", r.Validator),
+		fmt.Sprintf("Staked amount|%s", r.StakedAmount),
+		fmt.Sprintf("Delegated stake|%s", r.DelegatedStake),
+	}))
+	buffer.WriteString("\n")
+
+	numDelegations := len(r.Delegations)
+	delegations := make([]string, numDelegations+1)
+	delegations[0] = "No delegations found"
+
+	if numDelegations > 0 {
+		delegations[0] = "DELEGATOR|AMOUNT"
+
+		for i, d := range r.Delegations {
+			delegations[i+1] = fmt.Sprintf("%s|%s", d.Delegator, d.Amount)
+		}
+	}
+
+	buffer.WriteString("\n[DELEGATIONS]\n")
+	buffer.WriteString(helper.FormatList(delegations))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,30 @@
+package governance
+
+import (
+	"github.com/0xPolygon/polygon-edge/command/ibft/governance/proposals"
+	"github.com/0xPolygon/polygon-edge/command/ibft/governance/propose"
+	"github.com/0xPolygon/polygon-edge/command/ibft/governance/vote"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	governanceCmd := &cobra.Command{
+		Use:   "governance",
+		Short: "Top level command for the governance of the IBFT forks. Only accepts subcommands.",
+	}
+
+	registerSubcommands(governanceCmd)
+
+	return governanceCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// ibft governance propose
+		propose.GetCommand(),
+		// ibft governance vote
+		vote.GetCommand(),
+		// ibft governance proposals
+		proposals.GetCommand(),
+	)
+}
@@ -0,0 +1,46 @@
+package proposals
+
+import (
+	"context"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	ibftOp "github.com/0xPolygon/polygon-edge/consensus/ibft/proto"
+	"github.com/spf13/cobra"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+func GetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "proposals",
+		Short: "Returns the pending proposals of the IBFT forks and the accepted ones",
+		Run:   runCommand,
+	}
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	proposalsResponse, err := getForkProposals(helper.GetGRPCAddress(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(
+		newProposalsResult(proposalsResponse),
+	)
+}
+
+func getForkProposals(grpcAddress string) (*ibftOp.ForkProposalsResp, error) {
+	client, err := helper.GetIBFTOperatorClientConnection(
+		grpcAddress,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.ForkProposals(context.Background(), &empty.Empty{})
+}
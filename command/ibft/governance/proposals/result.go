@@ -0,0 +1,76 @@
+package proposals
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	ibftOp "github.com/0xPolygon/polygon-edge/consensus/ibft/proto"
+)
+
+type ForkProposal struct {
+	ID       string          `json:"id"`
+	Fork     json.RawMessage `json:"fork"`
+	Proposer string          `json:"proposer"`
+	Height   uint64          `json:"height"`
+	Votes    []string        `json:"votes"`
+}
+
+type ProposalsResult struct {
+	Pending  []ForkProposal `json:"pending"`
+	Accepted []ForkProposal `json:"accepted"`
+}
+
+func newProposalsResult(resp *ibftOp.ForkProposalsResp) *ProposalsResult {
+	return &ProposalsResult{
+		Pending:  toForkProposals(resp.Pending),
+		Accepted: toForkProposals(resp.Accepted),
+	}
+}
+
+func toForkProposals(protoProposals []*ibftOp.ForkProposalsResp_ForkProposal) []ForkProposal {
+	proposals := make([]ForkProposal, len(protoProposals))
+
+	for i, p := range protoProposals {
+		proposals[i].ID = p.Id
+		proposals[i].Fork = p.Fork
+		proposals[i].Proposer = p.Proposer
+		proposals[i].Height = p.Height
+		proposals[i].Votes = p.Votes
+	}
+
+	return proposals
+}
+
+func (r *ProposalsResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	writeProposals(&buffer, "PENDING FORK PROPOSALS", "No pending proposals", r.Pending)
+	writeProposals(&buffer, "ACCEPTED FORK PROPOSALS", "No accepted proposals", r.Accepted)
+
+	return buffer.String()
+}
+
+func writeProposals(buffer *bytes.Buffer, title, emptyMessage string, proposals []ForkProposal) {
+	buffer.WriteString(fmt.Sprintf("\n[%s]\n", title))
+
+	if len(proposals) == 0 {
+		buffer.WriteString(emptyMessage)
+		buffer.WriteString("\n")
+
+		return
+	}
+
+	for _, p := range proposals {
+		buffer.WriteString(helper.FormatKV([]string{
+			fmt.Sprintf("ID|%s", p.ID),
+			fmt.Sprintf("Fork|%s", string(p.Fork)),
+			fmt.Sprintf("Proposer|%s", p.Proposer),
+			fmt.Sprintf("Proposed at|%d", p.Height),
+			fmt.Sprintf("Votes|%s", strings.Join(p.Votes, ", ")),
+		}))
+		buffer.WriteString("\n\n")
+	}
+}
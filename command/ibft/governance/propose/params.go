@@ -0,0 +1,69 @@
+package propose
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	ibftOp "github.com/0xPolygon/polygon-edge/consensus/ibft/proto"
+)
+
+const (
+	forkFlag = "fork"
+)
+
+var (
+	params = &proposeParams{}
+)
+
+type proposeParams struct {
+	forkPath string
+
+	rawFork []byte
+	id      string
+}
+
+func (p *proposeParams) getRequiredFlags() []string {
+	return []string{
+		forkFlag,
+	}
+}
+
+func (p *proposeParams) initRawParams() error {
+	rawFork, err := os.ReadFile(p.forkPath)
+	if err != nil {
+		return fmt.Errorf("failed to read the fork: %w", err)
+	}
+
+	p.rawFork = rawFork
+
+	return nil
+}
+
+func (p *proposeParams) proposeFork(grpcAddress string) error {
+	client, err := helper.GetIBFTOperatorClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.ProposeFork(
+		context.Background(),
+		&ibftOp.ProposeForkReq{
+			Fork: p.rawFork,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	p.id = resp.Id
+
+	return nil
+}
+
+func (p *proposeParams) getResult() *ProposeResult {
+	return &ProposeResult{
+		ID: p.id,
+	}
+}
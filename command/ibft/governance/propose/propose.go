@@ -0,0 +1,48 @@
+package propose
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	proposeCmd := &cobra.Command{
+		Use:     "propose",
+		Short:   "Proposes the IBFT fork in the next block proposed by the node, the proposal is the vote of the node",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(proposeCmd)
+
+	helper.SetRequiredFlags(proposeCmd, params.getRequiredFlags())
+
+	return proposeCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.forkPath,
+		forkFlag,
+		"",
+		"the path to the JSON file of the IBFT fork, in the same format as the fork in the genesis",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.initRawParams()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.proposeFork(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
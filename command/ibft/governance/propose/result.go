@@ -0,0 +1,19 @@
+package propose
+
+import (
+	"bytes"
+	"fmt"
+)
+
+type ProposeResult struct {
+	ID string `json:"id"`
+}
+
+func (r *ProposeResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[FORK PROPOSAL]\n")
+	buffer.WriteString(fmt.Sprintf("Submitted the fork proposal %s, it's included when the node proposes a block\n", r.ID))
+
+	return buffer.String()
+}
@@ -0,0 +1,64 @@
+package vote
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	ibftOp "github.com/0xPolygon/polygon-edge/consensus/ibft/proto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	idFlag = "id"
+)
+
+var (
+	errInvalidIDFormat = errors.New("invalid proposal id format")
+)
+
+var (
+	params = &voteParams{}
+)
+
+type voteParams struct {
+	id string
+}
+
+func (p *voteParams) getRequiredFlags() []string {
+	return []string{
+		idFlag,
+	}
+}
+
+func (p *voteParams) validateFlags() error {
+	rawID, err := hex.DecodeHex(p.id)
+	if err != nil || len(rawID) != types.HashLength {
+		return errInvalidIDFormat
+	}
+
+	return nil
+}
+
+func (p *voteParams) voteFork(grpcAddress string) error {
+	client, err := helper.GetIBFTOperatorClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.VoteFork(
+		context.Background(),
+		&ibftOp.VoteForkReq{
+			Id: p.id,
+		},
+	)
+
+	return err
+}
+
+func (p *voteParams) getResult() *VoteResult {
+	return &VoteResult{
+		ID: p.id,
+	}
+}
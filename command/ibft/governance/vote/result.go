@@ -0,0 +1,19 @@
+package vote
+
+import (
+	"bytes"
+	"fmt"
+)
+
+type VoteResult struct {
+	ID string `json:"id"`
+}
+
+func (r *VoteResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[FORK VOTE]\n")
+	buffer.WriteString(fmt.Sprintf("Submitted the vote for %s, it's included when the node proposes a block\n", r.ID))
+
+	return buffer.String()
+}
@@ -0,0 +1,48 @@
+package vote
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	voteCmd := &cobra.Command{
+		Use:     "vote",
+		Short:   "Votes for the proposal of the IBFT fork, the vote is written in the next block proposed by the node",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(voteCmd)
+
+	helper.SetRequiredFlags(voteCmd, params.getRequiredFlags())
+
+	return voteCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.id,
+		idFlag,
+		"",
+		"the ID of the fork proposal",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.voteFork(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
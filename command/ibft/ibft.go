@@ -3,9 +3,14 @@ package ibft
 import (
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/command/ibft/candidates"
+	"github.com/0xPolygon/polygon-edge/command/ibft/convertseals"
+	"github.com/0xPolygon/polygon-edge/command/ibft/delegations"
+	"github.com/0xPolygon/polygon-edge/command/ibft/governance"
 	"github.com/0xPolygon/polygon-edge/command/ibft/propose"
 	"github.com/0xPolygon/polygon-edge/command/ibft/quorum"
+	"github.com/0xPolygon/polygon-edge/command/ibft/rotatekey"
 	"github.com/0xPolygon/polygon-edge/command/ibft/snapshot"
+	"github.com/0xPolygon/polygon-edge/command/ibft/stats"
 	"github.com/0xPolygon/polygon-edge/command/ibft/status"
 	_switch "github.com/0xPolygon/polygon-edge/command/ibft/switch"
 	"github.com/spf13/cobra"
@@ -38,5 +43,15 @@ func registerSubcommands(baseCmd *cobra.Command) {
 		_switch.GetCommand(),
 		// ibft quorum
 		quorum.GetCommand(),
+		// ibft validators-stats
+		stats.GetCommand(),
+		// ibft delegations
+		delegations.GetCommand(),
+		// ibft governance
+		governance.GetCommand(),
+		// ibft convert-seals
+		convertseals.GetCommand(),
+		// ibft rotate-key
+		rotatekey.GetCommand(),
 	)
 }
@@ -0,0 +1,45 @@
+package rotatekey
+
+import (
+	"context"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	ibftOp "github.com/0xPolygon/polygon-edge/consensus/ibft/proto"
+	"github.com/spf13/cobra"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+func GetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use: "rotate-key",
+		Short: "Proposes the key rotation of the validator to the keys staged by \"secrets stage\". " +
+			"The new keys become effective at the beginning of the next epoch",
+		Run: runCommand,
+	}
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	resp, err := rotateKey(helper.GetGRPCAddress(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(newIBFTRotateKeyResult(resp))
+}
+
+func rotateKey(grpcAddress string) (*ibftOp.RotateKeyResp, error) {
+	client, err := helper.GetIBFTOperatorClientConnection(
+		grpcAddress,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.RotateKey(context.Background(), &empty.Empty{})
+}
@@ -0,0 +1,50 @@
+package rotatekey
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	ibftOp "github.com/0xPolygon/polygon-edge/consensus/ibft/proto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+)
+
+type IBFTRotateKeyResult struct {
+	Validator       string `json:"validator"`
+	NewAddress      string `json:"new_address"`
+	NewBLSPublicKey string `json:"new_bls_public_key,omitempty"`
+}
+
+func newIBFTRotateKeyResult(resp *ibftOp.RotateKeyResp) *IBFTRotateKeyResult {
+	res := &IBFTRotateKeyResult{
+		Validator:  resp.Validator,
+		NewAddress: resp.NewAddress,
+	}
+
+	if len(resp.NewBlsPublicKey) > 0 {
+		res.NewBLSPublicKey = hex.EncodeToHex(resp.NewBlsPublicKey)
+	}
+
+	return res
+}
+
+func (r *IBFTRotateKeyResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	vals := []string{
+		fmt.Sprintf("Validator|%s", r.Validator),
+		fmt.Sprintf("New address|%s", r.NewAddress),
+	}
+
+	if r.NewBLSPublicKey != "" {
+		vals = append(vals, fmt.Sprintf("New BLS Public key|%s", r.NewBLSPublicKey))
+	}
+
+	buffer.WriteString("\n[KEY ROTATION]\n")
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+	buffer.WriteString("The rotation is included when the validator proposes a block, " +
+		"the new keys become effective at the next epoch\n")
+
+	return buffer.String()
+}
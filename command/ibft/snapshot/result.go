@@ -16,11 +16,17 @@ type IBFTSnapshotVote struct {
 	Vote     ibftHelper.Vote `json:"vote"`
 }
 
+type IBFTSnapshotRotation struct {
+	Validator    string               `json:"validator"`
+	NewValidator validators.Validator `json:"new_validator"`
+}
+
 type IBFTSnapshotResult struct {
 	Number     uint64                 `json:"number"`
 	Hash       string                 `json:"hash"`
 	Votes      []IBFTSnapshotVote     `json:"votes"`
 	Validators []validators.Validator `json:"validators"`
+	Rotations  []IBFTSnapshotRotation `json:"rotations,omitempty"`
 }
 
 func newIBFTSnapshotResult(resp *ibftOp.Snapshot) (*IBFTSnapshotResult, error) {
@@ -29,6 +35,7 @@ func newIBFTSnapshotResult(resp *ibftOp.Snapshot) (*IBFTSnapshotResult, error) {
 		Hash:       resp.Hash,
 		Votes:      make([]IBFTSnapshotVote, len(resp.Votes)),
 		Validators: make([]validators.Validator, len(resp.Validators)),
+		Rotations:  make([]IBFTSnapshotRotation, len(resp.Rotations)),
 	}
 
 	for i, v := range resp.Votes {
@@ -37,31 +44,43 @@ func newIBFTSnapshotResult(resp *ibftOp.Snapshot) (*IBFTSnapshotResult, error) {
 		res.Votes[i].Vote = ibftHelper.BoolToVote(v.Auth)
 	}
 
-	var (
-		validatorType validators.ValidatorType
-		err           error
-	)
+	var err error
 
 	for i, v := range resp.Validators {
-		if validatorType, err = validators.ParseValidatorType(v.Type); err != nil {
+		if res.Validators[i], err = protoToValidator(v); err != nil {
 			return nil, err
 		}
+	}
 
-		validator, err := validators.NewValidatorFromType(validatorType)
-		if err != nil {
-			return nil, err
-		}
+	for i, r := range resp.Rotations {
+		res.Rotations[i].Validator = r.Validator
 
-		if err := validator.SetFromBytes(v.Data); err != nil {
+		if res.Rotations[i].NewValidator, err = protoToValidator(r.NewValidator); err != nil {
 			return nil, err
 		}
-
-		res.Validators[i] = validator
 	}
 
 	return res, nil
 }
 
+func protoToValidator(v *ibftOp.Snapshot_Validator) (validators.Validator, error) {
+	validatorType, err := validators.ParseValidatorType(v.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	validator, err := validators.NewValidatorFromType(validatorType)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validator.SetFromBytes(v.Data); err != nil {
+		return nil, err
+	}
+
+	return validator, nil
+}
+
 func (r *IBFTSnapshotResult) GetOutput() string {
 	var buffer bytes.Buffer
 
@@ -69,6 +88,7 @@ func (r *IBFTSnapshotResult) GetOutput() string {
 	r.writeBlockData(&buffer)
 	r.writeVoteData(&buffer)
 	r.writeValidatorData(&buffer)
+	r.writeRotationData(&buffer)
 
 	return buffer.String()
 }
@@ -121,3 +141,20 @@ func (r *IBFTSnapshotResult) writeValidatorData(buffer *bytes.Buffer) {
 	buffer.WriteString(helper.FormatList(validators))
 	buffer.WriteString("\n")
 }
+
+func (r *IBFTSnapshotResult) writeRotationData(buffer *bytes.Buffer) {
+	if len(r.Rotations) == 0 {
+		return
+	}
+
+	rotations := make([]string, len(r.Rotations)+1)
+	rotations[0] = "VALIDATOR|NEW VALIDATOR"
+
+	for i, d := range r.Rotations {
+		rotations[i+1] = fmt.Sprintf("%s|%s", d.Validator, d.NewValidator.String())
+	}
+
+	buffer.WriteString("\n[KEY ROTATIONS AT NEXT EPOCH]\n")
+	buffer.WriteString(helper.FormatList(rotations))
+	buffer.WriteString("\n")
+}
@@ -0,0 +1,46 @@
+package stats
+
+import (
+	"context"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	ibftOp "github.com/0xPolygon/polygon-edge/consensus/ibft/proto"
+	"github.com/spf13/cobra"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+func GetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validators-stats",
+		Short: "Returns the participation of the validators in the recent blocks",
+		Run:   runCommand,
+	}
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	statsResponse, err := getValidatorStats(helper.GetGRPCAddress(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(
+		newValidatorStatsResult(statsResponse),
+	)
+}
+
+func getValidatorStats(grpcAddress string) (*ibftOp.ValidatorStatsResp, error) {
+	client, err := helper.GetIBFTOperatorClientConnection(
+		grpcAddress,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.ValidatorStats(context.Background(), &empty.Empty{})
+}
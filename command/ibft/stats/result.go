@@ -0,0 +1,76 @@
+package stats
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	ibftOp "github.com/0xPolygon/polygon-edge/consensus/ibft/proto"
+)
+
+type ValidatorStats struct {
+	Address        string `json:"address"`
+	ProposedBlocks uint64 `json:"proposed_blocks"`
+	CommittedSeals uint64 `json:"committed_seals"`
+	LastSeen       uint64 `json:"last_seen"`
+}
+
+type ValidatorStatsResult struct {
+	Window     uint64           `json:"window"`
+	From       uint64           `json:"from"`
+	To         uint64           `json:"to"`
+	Validators []ValidatorStats `json:"validators"`
+}
+
+func newValidatorStatsResult(resp *ibftOp.ValidatorStatsResp) *ValidatorStatsResult {
+	res := &ValidatorStatsResult{
+		Window:     resp.Window,
+		From:       resp.From,
+		To:         resp.To,
+		Validators: make([]ValidatorStats, len(resp.Validators)),
+	}
+
+	for i, v := range resp.Validators {
+		res.Validators[i].Address = v.Address
+		res.Validators[i].ProposedBlocks = v.ProposedBlocks
+		res.Validators[i].CommittedSeals = v.CommittedSeals
+		res.Validators[i].LastSeen = v.LastSeen
+	}
+
+	return res
+}
+
+func (r *ValidatorStatsResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[VALIDATORS STATS]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Window|%d", r.Window),
+		fmt.Sprintf("Blocks|%d - %d", r.From, r.To),
+	}))
+	buffer.WriteString("\n")
+
+	numValidators := len(r.Validators)
+	validators := make([]string, numValidators+1)
+	validators[0] = "No validators found"
+
+	if numValidators > 0 {
+		validators[0] = "ADDRESS|PROPOSED BLOCKS|COMMITTED SEALS|LAST SEEN"
+
+		for i, v := range r.Validators {
+			validators[i+1] = fmt.Sprintf(
+				"%s|%d|%d|%d",
+				v.Address,
+				v.ProposedBlocks,
+				v.CommittedSeals,
+				v.LastSeen,
+			)
+		}
+	}
+
+	buffer.WriteString("\n[VALIDATORS]\n")
+	buffer.WriteString(helper.FormatList(validators))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,55 @@
+package rewind
+
+import (
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	dataDirFlag = "data-dir"
+	toFlag      = "to"
+)
+
+var (
+	params = &rewindParams{}
+)
+
+type rewindParams struct {
+	dataDir string
+	to      uint64
+
+	removed []*types.Header
+}
+
+func (p *rewindParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+		toFlag,
+	}
+}
+
+func (p *rewindParams) rewind() error {
+	logger := hclog.NewNullLogger()
+
+	db, err := server.OpenBlockchainStorage(p.dataDir, logger)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	p.removed, err = blockchain.RewindStorage(logger, db, p.to)
+
+	return err
+}
+
+func (p *rewindParams) getResult() command.CommandResult {
+	return &RewindResult{
+		From:    p.removed[len(p.removed)-1].Number,
+		To:      p.to,
+		Removed: uint64(len(p.removed)),
+	}
+}
@@ -0,0 +1,28 @@
+package rewind
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type RewindResult struct {
+	From    uint64 `json:"from"`
+	To      uint64 `json:"to"`
+	Removed uint64 `json:"removed"`
+}
+
+func (r *RewindResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[REWIND]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Previous head|%d", r.From),
+		fmt.Sprintf("New head|%d", r.To),
+		fmt.Sprintf("Removed blocks|%d", r.Removed),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
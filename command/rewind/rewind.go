@@ -0,0 +1,50 @@
+package rewind
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	rewindCmd := &cobra.Command{
+		Use: "rewind",
+		Short: "Rewinds the chain in the data directory to the block with the given number, " +
+			"removing the blocks above it. The node using the data directory must be stopped",
+		Run: runCommand,
+	}
+
+	setFlags(rewindCmd)
+	helper.SetRequiredFlags(rewindCmd, params.getRequiredFlags())
+
+	return rewindCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory used for storing Polygon Edge client data",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.to,
+		toFlag,
+		0,
+		"the number of the block to be the new head of the chain",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.rewind(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -5,14 +5,17 @@ import (
 	"os"
 
 	"github.com/0xPolygon/polygon-edge/command/backup"
+	"github.com/0xPolygon/polygon-edge/command/db"
 	"github.com/0xPolygon/polygon-edge/command/genesis"
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/command/ibft"
 	"github.com/0xPolygon/polygon-edge/command/license"
 	"github.com/0xPolygon/polygon-edge/command/monitor"
 	"github.com/0xPolygon/polygon-edge/command/peers"
+	"github.com/0xPolygon/polygon-edge/command/rewind"
 	"github.com/0xPolygon/polygon-edge/command/secrets"
 	"github.com/0xPolygon/polygon-edge/command/server"
+	"github.com/0xPolygon/polygon-edge/command/state"
 	"github.com/0xPolygon/polygon-edge/command/status"
 	"github.com/0xPolygon/polygon-edge/command/txpool"
 	"github.com/0xPolygon/polygon-edge/command/version"
@@ -48,6 +51,9 @@ func (rc *RootCommand) registerSubCommands() {
 		monitor.GetCommand(),
 		ibft.GetCommand(),
 		backup.GetCommand(),
+		db.GetCommand(),
+		rewind.GetCommand(),
+		state.GetCommand(),
 		genesis.GetCommand(),
 		server.GetCommand(),
 		whitelist.GetCommand(),
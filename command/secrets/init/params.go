@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/0xPolygon/polygon-edge/command"
+	cmdHelper "github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/secrets/helper"
 )
@@ -15,6 +16,7 @@ const (
 	blsFlag     = "bls"
 	networkFlag = "network"
 	numFlag     = "num"
+	encryptFlag = "encrypt"
 )
 
 var (
@@ -30,6 +32,10 @@ type initParams struct {
 	generatesBLS     bool
 	generatesNetwork bool
 
+	encrypts         bool
+	passphraseParams cmdHelper.KeystorePassphraseParams
+	passphrase       string
+
 	secretsManager secrets.SecretsManager
 	secretsConfig  *secrets.SecretsManagerConfig
 }
@@ -42,6 +48,19 @@ func (ip *initParams) validateFlags() error {
 	return nil
 }
 
+// initPassphrase reads the new passphrase encrypting the local secrets
+func (ip *initParams) initPassphrase() error {
+	if ip.hasConfigPath() || !(ip.encrypts || ip.passphraseParams.IsSet()) {
+		return nil
+	}
+
+	var err error
+
+	ip.passphrase, err = ip.passphraseParams.ReadPassphrase(true)
+
+	return err
+}
+
 func (ip *initParams) initSecrets() error {
 	if err := ip.initSecretsManager(); err != nil {
 		return err
@@ -89,7 +108,7 @@ func (ip *initParams) parseConfig() error {
 }
 
 func (ip *initParams) initLocalSecretsManager() error {
-	local, err := helper.SetupLocalSecretsManager(ip.dataDir)
+	local, err := helper.SetupEncryptedLocalSecretsManager(ip.dataDir, ip.passphrase)
 	if err != nil {
 		return err
 	}
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/0xPolygon/polygon-edge/command"
+	cmdHelper "github.com/0xPolygon/polygon-edge/command/helper"
 )
 
 const (
@@ -84,6 +85,21 @@ func setFlags(cmd *cobra.Command) {
 		true,
 		"the flag indicating whether new BLS key is created",
 	)
+
+	cmd.Flags().BoolVar(
+		&basicParams.encrypts,
+		encryptFlag,
+		false,
+		"the flag indicating whether the keys are encrypted by the passphrase prompted for, "+
+			"only for the local FS. The keys are encrypted if the passphrase is given by the flags too",
+	)
+
+	cmdHelper.RegisterKeystorePassphraseFlags(cmd, &basicParams.passphraseParams)
+
+	// The keys in the remote secrets manager aren't encrypted by the passphrase
+	cmd.MarkFlagsMutuallyExclusive(encryptFlag, configFlag)
+	cmd.MarkFlagsMutuallyExclusive(cmdHelper.KeystorePassphraseFileFlag, configFlag)
+	cmd.MarkFlagsMutuallyExclusive(cmdHelper.KeystorePassphraseEnvFlag, configFlag)
 }
 
 func runPreRun(_ *cobra.Command, _ []string) error {
@@ -98,6 +114,13 @@ func runCommand(cmd *cobra.Command, _ []string) {
 	outputter := command.InitializeOutputter(cmd)
 	defer outputter.WriteOutput()
 
+	// All the secrets are encrypted by the same passphrase
+	if err := basicParams.initPassphrase(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
 	paramsList := newParamsList(basicParams, initNumber)
 	results := make(Results, len(paramsList))
 
@@ -135,6 +158,7 @@ func newParamsList(params initParams, num int) []initParams {
 			generatesECDSA:   params.generatesECDSA,
 			generatesBLS:     params.generatesBLS,
 			generatesNetwork: params.generatesNetwork,
+			passphrase:       params.passphrase,
 		}
 	}
 
@@ -0,0 +1,70 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	cmdHelper "github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/secrets/helper"
+)
+
+const (
+	dataDirFlag = "data-dir"
+)
+
+var (
+	params = &migrateParams{}
+)
+
+var (
+	errInvalidParams = errors.New("no data directory passed in")
+)
+
+type migrateParams struct {
+	dataDir string
+
+	passphraseParams cmdHelper.KeystorePassphraseParams
+
+	encryptedSecrets []string
+}
+
+func (mp *migrateParams) validateFlags() error {
+	if mp.dataDir == "" {
+		return errInvalidParams
+	}
+
+	if !common.DirectoryExists(mp.dataDir) {
+		dataDirAbs, _ := filepath.Abs(mp.dataDir)
+
+		return fmt.Errorf("the data directory provided does not exist: %s", dataDirAbs)
+	}
+
+	return nil
+}
+
+func (mp *migrateParams) migrateSecrets() error {
+	// The keys encrypted already are checked against the passphrase,
+	// otherwise it's a new passphrase confirmed on the prompt
+	hasEncrypted, err := helper.HasEncryptedLocalSecrets(mp.dataDir)
+	if err != nil {
+		return err
+	}
+
+	passphrase, err := mp.passphraseParams.ReadPassphrase(!hasEncrypted)
+	if err != nil {
+		return err
+	}
+
+	mp.encryptedSecrets, err = helper.EncryptLocalSecrets(mp.dataDir, passphrase)
+
+	return err
+}
+
+func (mp *migrateParams) getResult() command.CommandResult {
+	return &SecretsMigrateResult{
+		EncryptedSecrets: mp.encryptedSecrets,
+	}
+}
@@ -0,0 +1,34 @@
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type SecretsMigrateResult struct {
+	EncryptedSecrets []string `json:"encrypted_secrets"`
+}
+
+func (r *SecretsMigrateResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[SECRETS MIGRATE]\n")
+
+	if len(r.EncryptedSecrets) == 0 {
+		buffer.WriteString("No plaintext secrets found, all the secrets are encrypted\n")
+
+		return buffer.String()
+	}
+
+	vals := make([]string, 0, len(r.EncryptedSecrets))
+	for _, name := range r.EncryptedSecrets {
+		vals = append(vals, fmt.Sprintf("Encrypted|%s", name))
+	}
+
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,50 @@
+package migrate
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	cmdHelper "github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	secretsMigrateCmd := &cobra.Command{
+		Use: "migrate",
+		Short: "Encrypts the plaintext keys in the data directory of the local FS secrets manager " +
+			"by the keystore passphrase. The node reads the encrypted keys once it's given the passphrase",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(secretsMigrateCmd)
+
+	return secretsMigrateCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the directory for the Polygon Edge data",
+	)
+
+	cmdHelper.RegisterKeystorePassphraseFlags(cmd, &params.passphraseParams)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.migrateSecrets(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
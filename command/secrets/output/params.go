@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/0xPolygon/polygon-edge/command"
+	cmdHelper "github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/secrets/helper"
@@ -39,6 +40,8 @@ type outputParams struct {
 	outputValidator bool
 	outputBLS       bool
 
+	passphraseParams cmdHelper.KeystorePassphraseParams
+
 	secretsManager secrets.SecretsManager
 	secretsConfig  *secrets.SecretsManagerConfig
 
@@ -134,7 +137,12 @@ func (op *outputParams) initLocalSecretsManager() error {
 		return fmt.Errorf(strings.Join(errs, "\n"))
 	}
 
-	local, err := helper.SetupLocalSecretsManager(op.dataDir)
+	passphrase, err := op.passphraseParams.ReadLocalSecretsPassphrase(op.dataDir)
+	if err != nil {
+		return err
+	}
+
+	local, err := helper.SetupEncryptedLocalSecretsManager(op.dataDir, passphrase)
 	if err != nil {
 		return err
 	}
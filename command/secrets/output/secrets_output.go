@@ -2,6 +2,7 @@ package output
 
 import (
 	"github.com/0xPolygon/polygon-edge/command"
+	cmdHelper "github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/spf13/cobra"
 )
 
@@ -58,8 +59,12 @@ func setFlags(cmd *cobra.Command) {
 			"from the provided secrets manager",
 	)
 
+	cmdHelper.RegisterKeystorePassphraseFlags(cmd, &params.passphraseParams)
+
 	cmd.MarkFlagsMutuallyExclusive(dataDirFlag, configFlag)
 	cmd.MarkFlagsMutuallyExclusive(nodeIDFlag, validatorFlag, blsFlag)
+	cmd.MarkFlagsMutuallyExclusive(cmdHelper.KeystorePassphraseFileFlag, configFlag)
+	cmd.MarkFlagsMutuallyExclusive(cmdHelper.KeystorePassphraseEnvFlag, configFlag)
 }
 
 func runPreRun(_ *cobra.Command, _ []string) error {
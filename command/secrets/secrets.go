@@ -4,7 +4,9 @@ import (
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/command/secrets/generate"
 	initCmd "github.com/0xPolygon/polygon-edge/command/secrets/init"
+	"github.com/0xPolygon/polygon-edge/command/secrets/migrate"
 	"github.com/0xPolygon/polygon-edge/command/secrets/output"
+	"github.com/0xPolygon/polygon-edge/command/secrets/stage"
 	"github.com/spf13/cobra"
 )
 
@@ -29,5 +31,9 @@ func registerSubcommands(baseCmd *cobra.Command) {
 		generate.GetCommand(),
 		// secrets output public data
 		output.GetCommand(),
+		// secrets stage
+		stage.GetCommand(),
+		// secrets migrate
+		migrate.GetCommand(),
 	)
 }
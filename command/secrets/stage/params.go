@@ -0,0 +1,128 @@
+package stage
+
+import (
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	cmdHelper "github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/0xPolygon/polygon-edge/secrets/helper"
+)
+
+const (
+	dataDirFlag = "data-dir"
+	configFlag  = "config"
+	ecdsaFlag   = "ecdsa"
+	blsFlag     = "bls"
+)
+
+var (
+	params = &stageParams{}
+)
+
+var (
+	errInvalidConfig   = errors.New("invalid secrets configuration")
+	errInvalidParams   = errors.New("no config file or data directory passed in")
+	errUnsupportedType = errors.New("unsupported secrets manager")
+	errNoKeyToStage    = errors.New("at least one of ecdsa or bls flag needs to be set")
+)
+
+type stageParams struct {
+	dataDir      string
+	configPath   string
+	stagesECDSA  bool
+	stagesBLS    bool
+	stagedResult *SecretsStageResult
+
+	passphraseParams cmdHelper.KeystorePassphraseParams
+
+	secretsManager secrets.SecretsManager
+	secretsConfig  *secrets.SecretsManagerConfig
+}
+
+func (sp *stageParams) validateFlags() error {
+	if sp.dataDir == "" && sp.configPath == "" {
+		return errInvalidParams
+	}
+
+	if !sp.stagesECDSA && !sp.stagesBLS {
+		return errNoKeyToStage
+	}
+
+	return nil
+}
+
+func (sp *stageParams) stageSecrets() error {
+	if err := sp.initSecretsManager(); err != nil {
+		return err
+	}
+
+	return sp.stageValidatorKey()
+}
+
+func (sp *stageParams) initSecretsManager() error {
+	var err error
+	if sp.configPath != "" {
+		if err = sp.parseConfig(); err != nil {
+			return err
+		}
+
+		sp.secretsManager, err = helper.InitCloudSecretsManager(sp.secretsConfig)
+
+		return err
+	}
+
+	// The staged keys are encrypted like the validator keys
+	passphrase, err := sp.passphraseParams.ReadLocalSecretsPassphrase(sp.dataDir)
+	if err != nil {
+		return err
+	}
+
+	sp.secretsManager, err = helper.SetupEncryptedLocalSecretsManager(sp.dataDir, passphrase)
+
+	return err
+}
+
+func (sp *stageParams) parseConfig() error {
+	secretsConfig, readErr := secrets.ReadConfig(sp.configPath)
+	if readErr != nil {
+		return errInvalidConfig
+	}
+
+	if !secrets.SupportedServiceManager(secretsConfig.Type) {
+		return errUnsupportedType
+	}
+
+	sp.secretsConfig = secretsConfig
+
+	return nil
+}
+
+func (sp *stageParams) stageValidatorKey() error {
+	sp.stagedResult = &SecretsStageResult{}
+
+	if sp.stagesECDSA {
+		address, err := helper.StageECDSAValidatorKey(sp.secretsManager)
+		if err != nil {
+			return err
+		}
+
+		sp.stagedResult.Address = address.String()
+	}
+
+	if sp.stagesBLS {
+		pubkey, err := helper.StageBLSValidatorKey(sp.secretsManager)
+		if err != nil {
+			return err
+		}
+
+		sp.stagedResult.BLSPubkey = hex.EncodeToHex(pubkey)
+	}
+
+	return nil
+}
+
+func (sp *stageParams) getResult() command.CommandResult {
+	return sp.stagedResult
+}
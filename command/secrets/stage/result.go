@@ -0,0 +1,34 @@
+package stage
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type SecretsStageResult struct {
+	Address   string `json:"address,omitempty"`
+	BLSPubkey string `json:"bls_pubkey,omitempty"`
+}
+
+func (r *SecretsStageResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	vals := make([]string, 0, 2)
+
+	if r.Address != "" {
+		vals = append(vals, fmt.Sprintf("Staged public key (address)|%s", r.Address))
+	}
+
+	if r.BLSPubkey != "" {
+		vals = append(vals, fmt.Sprintf("Staged BLS Public key|%s", r.BLSPubkey))
+	}
+
+	buffer.WriteString("\n[SECRETS STAGE]\n")
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+	buffer.WriteString("Run \"ibft rotate-key\" to propose the key rotation to the staged keys\n")
+
+	return buffer.String()
+}
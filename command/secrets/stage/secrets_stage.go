@@ -0,0 +1,77 @@
+package stage
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	cmdHelper "github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	secretsStageCmd := &cobra.Command{
+		Use: "stage",
+		Short: "Generates new validator keys and stages them in the specified Secrets Manager. " +
+			"The staged keys replace the validator keys once the key rotation is applied",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(secretsStageCmd)
+
+	return secretsStageCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the directory for the Polygon Edge data if the local FS is used",
+	)
+
+	cmd.Flags().StringVar(
+		&params.configPath,
+		configFlag,
+		"",
+		"the path to the SecretsManager config file, "+
+			"if omitted, the local FS secrets manager is used",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(dataDirFlag, configFlag)
+
+	cmd.Flags().BoolVar(
+		&params.stagesECDSA,
+		ecdsaFlag,
+		false,
+		"the flag indicating whether new ECDSA key is staged, which changes the validator address",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.stagesBLS,
+		blsFlag,
+		false,
+		"the flag indicating whether new BLS key is staged",
+	)
+
+	cmdHelper.RegisterKeystorePassphraseFlags(cmd, &params.passphraseParams)
+
+	cmd.MarkFlagsMutuallyExclusive(cmdHelper.KeystorePassphraseFileFlag, configFlag)
+	cmd.MarkFlagsMutuallyExclusive(cmdHelper.KeystorePassphraseEnvFlag, configFlag)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.stageSecrets(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
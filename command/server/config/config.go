@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/0xPolygon/polygon-edge/helper/dbengine"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/hashicorp/hcl"
 	"gopkg.in/yaml.v3"
@@ -13,24 +14,33 @@ import (
 
 // Config defines the server configuration params
 type Config struct {
-	GenesisPath              string     `json:"chain_config" yaml:"chain_config"`
-	SecretsConfigPath        string     `json:"secrets_config" yaml:"secrets_config"`
-	DataDir                  string     `json:"data_dir" yaml:"data_dir"`
-	BlockGasTarget           string     `json:"block_gas_target" yaml:"block_gas_target"`
-	GRPCAddr                 string     `json:"grpc_addr" yaml:"grpc_addr"`
-	JSONRPCAddr              string     `json:"jsonrpc_addr" yaml:"jsonrpc_addr"`
-	Telemetry                *Telemetry `json:"telemetry" yaml:"telemetry"`
-	Network                  *Network   `json:"network" yaml:"network"`
-	ShouldSeal               bool       `json:"seal" yaml:"seal"`
-	TxPool                   *TxPool    `json:"tx_pool" yaml:"tx_pool"`
-	LogLevel                 string     `json:"log_level" yaml:"log_level"`
-	RestoreFile              string     `json:"restore_file" yaml:"restore_file"`
-	BlockTime                uint64     `json:"block_time_s" yaml:"block_time_s"`
-	Headers                  *Headers   `json:"headers" yaml:"headers"`
-	LogFilePath              string     `json:"log_to" yaml:"log_to"`
-	JSONRPCBatchRequestLimit uint64     `json:"json_rpc_batch_request_limit" yaml:"json_rpc_batch_request_limit"`
-	JSONRPCBlockRangeLimit   uint64     `json:"json_rpc_block_range_limit" yaml:"json_rpc_block_range_limit"`
-	JSONLogFormat            bool       `json:"json_log_format" yaml:"json_log_format"`
+	GenesisPath                   string     `json:"chain_config" yaml:"chain_config"`
+	SecretsConfigPath             string     `json:"secrets_config" yaml:"secrets_config"`
+	DataDir                       string     `json:"data_dir" yaml:"data_dir"`
+	DBEngine                      string     `json:"db_engine" yaml:"db_engine"`
+	BlockGasTarget                string     `json:"block_gas_target" yaml:"block_gas_target"`
+	GRPCAddr                      string     `json:"grpc_addr" yaml:"grpc_addr"`
+	JSONRPCAddr                   string     `json:"jsonrpc_addr" yaml:"jsonrpc_addr"`
+	Telemetry                     *Telemetry `json:"telemetry" yaml:"telemetry"`
+	Network                       *Network   `json:"network" yaml:"network"`
+	ShouldSeal                    bool       `json:"seal" yaml:"seal"`
+	LightMode                     bool       `json:"light" yaml:"light"`
+	Checkpoints                   []string   `json:"checkpoints" yaml:"checkpoints"`
+	TxPool                        *TxPool    `json:"tx_pool" yaml:"tx_pool"`
+	LogLevel                      string     `json:"log_level" yaml:"log_level"`
+	RestoreFile                   string     `json:"restore_file" yaml:"restore_file"`
+	BlockTime                     uint64     `json:"block_time_s" yaml:"block_time_s"`
+	Headers                       *Headers   `json:"headers" yaml:"headers"`
+	LogFilePath                   string     `json:"log_to" yaml:"log_to"`
+	JSONRPCBatchRequestLimit      uint64     `json:"json_rpc_batch_request_limit" yaml:"json_rpc_batch_request_limit"`
+	JSONRPCBlockRangeLimit        uint64     `json:"json_rpc_block_range_limit" yaml:"json_rpc_block_range_limit"`
+	JSONRPCIndexedBlockRangeLimit uint64     `json:"json_rpc_indexed_block_range_limit" yaml:"json_rpc_indexed_block_range_limit"`
+	JSONRPCAdmin                  bool       `json:"json_rpc_admin" yaml:"json_rpc_admin"`
+	JSONLogFormat                 bool       `json:"json_log_format" yaml:"json_log_format"`
+	Freezer                       *Freezer   `json:"freezer" yaml:"freezer"`
+	AddressIndex                  bool       `json:"address_index" yaml:"address_index"`
+	KeystorePassphraseFile        string     `json:"keystore_passphrase_file" yaml:"keystore_passphrase_file"`
+	KeystorePassphraseEnv         string     `json:"keystore_passphrase_env" yaml:"keystore_passphrase_env"`
 }
 
 // Telemetry holds the config details for metric services.
@@ -56,6 +66,12 @@ type TxPool struct {
 	MaxAccountEnqueued uint64 `json:"max_account_enqueued" yaml:"max_account_enqueued"`
 }
 
+// Freezer defines the configuration params of the store of the old blocks
+type Freezer struct {
+	Threshold   uint64 `json:"threshold" yaml:"threshold"`
+	Compression bool   `json:"compression" yaml:"compression"`
+}
+
 // Headers defines the HTTP response headers required to enable CORS.
 type Headers struct {
 	AccessControlAllowOrigins []string `json:"access_control_allow_origins" yaml:"access_control_allow_origins"`
@@ -75,6 +91,10 @@ const (
 	// DefaultJSONRPCBlockRangeLimit maximum block range allowed for json_rpc
 	// requests with fromBlock/toBlock values (e.g. eth_getLogs)
 	DefaultJSONRPCBlockRangeLimit uint64 = 1000
+
+	// DefaultJSONRPCIndexedBlockRangeLimit maximum block range allowed for json_rpc
+	// requests filtered by the addresses or the topics, which skip the blocks with the bloom bits index
+	DefaultJSONRPCIndexedBlockRangeLimit uint64 = 100000
 )
 
 // DefaultConfig returns the default server configuration
@@ -84,6 +104,7 @@ func DefaultConfig() *Config {
 	return &Config{
 		GenesisPath:    "./genesis.json",
 		DataDir:        "",
+		DBEngine:       string(dbengine.DefaultEngine),
 		BlockGasTarget: "0x0", // Special value signaling the parent gas limit should be applied
 		Network: &Network{
 			NoDiscover:       defaultNetworkConfig.NoDiscover,
@@ -108,9 +129,14 @@ func DefaultConfig() *Config {
 		Headers: &Headers{
 			AccessControlAllowOrigins: []string{"*"},
 		},
-		LogFilePath:              "",
-		JSONRPCBatchRequestLimit: DefaultJSONRPCBatchRequestLimit,
-		JSONRPCBlockRangeLimit:   DefaultJSONRPCBlockRangeLimit,
+		LogFilePath:                   "",
+		JSONRPCBatchRequestLimit:      DefaultJSONRPCBatchRequestLimit,
+		JSONRPCBlockRangeLimit:        DefaultJSONRPCBlockRangeLimit,
+		JSONRPCIndexedBlockRangeLimit: DefaultJSONRPCIndexedBlockRangeLimit,
+		Freezer: &Freezer{
+			Threshold:   0,
+			Compression: true,
+		},
 	}
 }
 
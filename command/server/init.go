@@ -12,6 +12,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/helper/dbengine"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
@@ -50,6 +51,14 @@ func (p *serverParams) initRawParams() error {
 		return err
 	}
 
+	if err := p.initKeystorePassphrase(); err != nil {
+		return err
+	}
+
+	if err := p.initDBEngine(); err != nil {
+		return err
+	}
+
 	if err := p.initBlockTime(); err != nil {
 		return err
 	}
@@ -80,6 +89,18 @@ func (p *serverParams) initDataDirLocation() error {
 	return nil
 }
 
+func (p *serverParams) initDBEngine() error {
+	var parseErr error
+
+	if p.dbEngine, parseErr = dbengine.ParseEngine(
+		p.rawConfig.DBEngine,
+	); parseErr != nil {
+		return parseErr
+	}
+
+	return nil
+}
+
 func (p *serverParams) initLogFileLocation() {
 	if p.isLogFileLocationSet() {
 		p.logFileLocation = p.rawConfig.LogFilePath
@@ -114,6 +135,27 @@ func (p *serverParams) initSecretsConfig() error {
 	return nil
 }
 
+func (p *serverParams) initKeystorePassphrase() error {
+	if !p.isLocalSecretsManager() {
+		return nil
+	}
+
+	passphraseParams := &helper.KeystorePassphraseParams{
+		File: p.rawConfig.KeystorePassphraseFile,
+		Env:  p.rawConfig.KeystorePassphraseEnv,
+	}
+
+	var err error
+
+	if p.keystorePassphrase, err = passphraseParams.ReadLocalSecretsPassphrase(
+		p.rawConfig.DataDir,
+	); err != nil {
+		return fmt.Errorf("unable to read keystore passphrase, %w", err)
+	}
+
+	return nil
+}
+
 func (p *serverParams) initGenesisConfig() error {
 	var parseErr error
 
@@ -128,6 +170,18 @@ func (p *serverParams) initGenesisConfig() error {
 		p.genesisConfig.Params.BlockGasTarget = p.blockGasTarget
 	}
 
+	// checkpoint flags are added to the checkpoints in genesis.json
+	for _, rawCheckpoint := range p.rawConfig.Checkpoints {
+		checkpoint, err := chain.ParseCheckpoint(rawCheckpoint)
+		if err != nil {
+			return err
+		}
+
+		if err := p.genesisConfig.Params.AddCheckpoint(checkpoint); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -6,6 +6,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command/server/config"
+	"github.com/0xPolygon/polygon-edge/helper/dbengine"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
@@ -14,30 +15,38 @@ import (
 )
 
 const (
-	configFlag                   = "config"
-	genesisPathFlag              = "chain"
-	dataDirFlag                  = "data-dir"
-	libp2pAddressFlag            = "libp2p"
-	prometheusAddressFlag        = "prometheus"
-	natFlag                      = "nat"
-	dnsFlag                      = "dns"
-	sealFlag                     = "seal"
-	maxPeersFlag                 = "max-peers"
-	maxInboundPeersFlag          = "max-inbound-peers"
-	maxOutboundPeersFlag         = "max-outbound-peers"
-	priceLimitFlag               = "price-limit"
-	jsonRPCBatchRequestLimitFlag = "json-rpc-batch-request-limit"
-	jsonRPCBlockRangeLimitFlag   = "json-rpc-block-range-limit"
-	maxSlotsFlag                 = "max-slots"
-	maxEnqueuedFlag              = "max-enqueued"
-	blockGasTargetFlag           = "block-gas-target"
-	secretsConfigFlag            = "secrets-config"
-	restoreFlag                  = "restore"
-	blockTimeFlag                = "block-time"
-	devIntervalFlag              = "dev-interval"
-	devFlag                      = "dev"
-	corsOriginFlag               = "access-control-allow-origins"
-	logFileLocationFlag          = "log-to"
+	configFlag                        = "config"
+	genesisPathFlag                   = "chain"
+	dataDirFlag                       = "data-dir"
+	dbEngineFlag                      = "db-engine"
+	libp2pAddressFlag                 = "libp2p"
+	prometheusAddressFlag             = "prometheus"
+	natFlag                           = "nat"
+	dnsFlag                           = "dns"
+	sealFlag                          = "seal"
+	lightFlag                         = "light"
+	checkpointFlag                    = "checkpoint"
+	maxPeersFlag                      = "max-peers"
+	maxInboundPeersFlag               = "max-inbound-peers"
+	maxOutboundPeersFlag              = "max-outbound-peers"
+	priceLimitFlag                    = "price-limit"
+	jsonRPCBatchRequestLimitFlag      = "json-rpc-batch-request-limit"
+	jsonRPCBlockRangeLimitFlag        = "json-rpc-block-range-limit"
+	jsonRPCIndexedBlockRangeLimitFlag = "json-rpc-indexed-block-range-limit"
+	jsonRPCAdminFlag                  = "json-rpc-admin"
+	maxSlotsFlag                      = "max-slots"
+	maxEnqueuedFlag                   = "max-enqueued"
+	blockGasTargetFlag                = "block-gas-target"
+	secretsConfigFlag                 = "secrets-config"
+	restoreFlag                       = "restore"
+	blockTimeFlag                     = "block-time"
+	devIntervalFlag                   = "dev-interval"
+	devFlag                           = "dev"
+	corsOriginFlag                    = "access-control-allow-origins"
+	logFileLocationFlag               = "log-to"
+	freezerThresholdFlag              = "freezer-threshold"
+	freezerCompressionFlag            = "freezer-compression"
+	addressIndexFlag                  = "address-index"
 )
 
 // Flags that are deprecated, but need to be preserved for
@@ -56,6 +65,7 @@ var (
 			Telemetry: &config.Telemetry{},
 			Network:   &config.Network{},
 			TxPool:    &config.TxPool{},
+			Freezer:   &config.Freezer{},
 		},
 	}
 )
@@ -75,6 +85,8 @@ type serverParams struct {
 	grpcAddress       *net.TCPAddr
 	jsonRPCAddress    *net.TCPAddr
 
+	dbEngine dbengine.Engine
+
 	blockGasTarget uint64
 	devInterval    uint64
 	isDevMode      bool
@@ -86,6 +98,8 @@ type serverParams struct {
 	genesisConfig *chain.Chain
 	secretsConfig *secrets.SecretsManagerConfig
 
+	keystorePassphrase string
+
 	logFileLocation string
 }
 
@@ -102,6 +116,10 @@ func (p *serverParams) isSecretsConfigPathSet() bool {
 	return p.rawConfig.SecretsConfigPath != ""
 }
 
+func (p *serverParams) isLocalSecretsManager() bool {
+	return p.secretsConfig == nil || p.secretsConfig.Type == secrets.Local
+}
+
 func (p *serverParams) isPrometheusAddressSet() bool {
 	return p.rawConfig.Telemetry.PrometheusAddr != ""
 }
@@ -150,6 +168,8 @@ func (p *serverParams) generateConfig() *server.Config {
 			AccessControlAllowOrigin: p.corsAllowedOrigins,
 			BatchLengthLimit:         p.rawConfig.JSONRPCBatchRequestLimit,
 			BlockRangeLimit:          p.rawConfig.JSONRPCBlockRangeLimit,
+			IndexedBlockRangeLimit:   p.rawConfig.JSONRPCIndexedBlockRangeLimit,
+			AdminEnabled:             p.rawConfig.JSONRPCAdmin,
 		},
 		GRPCAddr:   p.grpcAddress,
 		LibP2PAddr: p.libp2pAddress,
@@ -168,7 +188,9 @@ func (p *serverParams) generateConfig() *server.Config {
 			Chain:            p.genesisConfig,
 		},
 		DataDir:            p.rawConfig.DataDir,
+		DBEngine:           p.dbEngine,
 		Seal:               p.rawConfig.ShouldSeal,
+		LightMode:          p.rawConfig.LightMode,
 		PriceLimit:         p.rawConfig.TxPool.PriceLimit,
 		MaxSlots:           p.rawConfig.TxPool.MaxSlots,
 		MaxAccountEnqueued: p.rawConfig.TxPool.MaxAccountEnqueued,
@@ -178,5 +200,11 @@ func (p *serverParams) generateConfig() *server.Config {
 		LogLevel:           hclog.LevelFromString(p.rawConfig.LogLevel),
 		JSONLogFormat:      p.rawConfig.JSONLogFormat,
 		LogFilePath:        p.logFileLocation,
+		Freezer: &server.Freezer{
+			Threshold:   p.rawConfig.Freezer.Threshold,
+			Compression: p.rawConfig.Freezer.Compression,
+		},
+		AddressIndex:       p.rawConfig.AddressIndex,
+		KeystorePassphrase: p.keystorePassphrase,
 	}
 }
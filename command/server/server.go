@@ -67,6 +67,14 @@ func setFlags(cmd *cobra.Command) {
 		"the data directory used for storing Polygon Edge client data",
 	)
 
+	cmd.Flags().StringVar(
+		&params.rawConfig.DBEngine,
+		dbEngineFlag,
+		defaultConfig.DBEngine,
+		"the db engine used for the blockchain and state databases (leveldb, pebble). "+
+			"The data dir must be converted with the db convert command to change the engine",
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.Network.Libp2pAddr,
 		libp2pAddressFlag,
@@ -115,7 +123,7 @@ func setFlags(cmd *cobra.Command) {
 		&params.rawConfig.RestoreFile,
 		restoreFlag,
 		"",
-		"the path to the archive blockchain data to restore on initialization",
+		"the path to the backup directory or the legacy archive file to restore on initialization",
 	)
 
 	cmd.Flags().BoolVar(
@@ -125,6 +133,23 @@ func setFlags(cmd *cobra.Command) {
 		"the flag indicating that the client should seal blocks",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.rawConfig.LightMode,
+		lightFlag,
+		defaultConfig.LightMode,
+		"the flag indicating that the client should run as a light client following only the headers "+
+			"and fetching the state with proofs from the peers (IBFT PoA only)",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.Checkpoints,
+		checkpointFlag,
+		[]string{},
+		"the trusted block hash at the height (format: <number>:<hash>), "+
+			"the peers serving the chain conflicting with the checkpoint are rejected. "+
+			"The light client starts verifying the headers from the latest checkpoint at the last block of an epoch",
+	)
+
 	cmd.Flags().BoolVar(
 		&params.rawConfig.Network.NoDiscover,
 		command.NoDiscoverFlag,
@@ -214,6 +239,21 @@ func setFlags(cmd *cobra.Command) {
 			"that consider fromBlock/toBlock values (e.g. eth_getLogs), value of 0 disables it",
 	)
 
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCIndexedBlockRangeLimit,
+		jsonRPCIndexedBlockRangeLimitFlag,
+		defaultConfig.JSONRPCIndexedBlockRangeLimit,
+		"max block range to be considered when executing eth_getLogs requests filtered by the addresses "+
+			"or the topics, the blocks are skipped with the bloom bits index, value of 0 disables it",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.JSONRPCAdmin,
+		jsonRPCAdminFlag,
+		defaultConfig.JSONRPCAdmin,
+		"the flag indicating whether the json-rpc methods changing the node state (e.g. debug_setHead) are enabled",
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.LogFilePath,
 		logFileLocationFlag,
@@ -221,6 +261,46 @@ func setFlags(cmd *cobra.Command) {
 		"write all logs to the file at specified location instead of writing them to console",
 	)
 
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.Freezer.Threshold,
+		freezerThresholdFlag,
+		defaultConfig.Freezer.Threshold,
+		"the number of the latest blocks kept in the blockchain database, "+
+			"the older blocks are moved to the append-only freezer files. The freezer is disabled if it's 0",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.Freezer.Compression,
+		freezerCompressionFlag,
+		defaultConfig.Freezer.Compression,
+		"the flag indicating whether the blocks moved to the freezer are compressed with snappy",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.AddressIndex,
+		addressIndexFlag,
+		defaultConfig.AddressIndex,
+		"the flag indicating whether the transactions are indexed by their senders and recipients "+
+			"for edge_getTransactionsByAddress, the existing blocks are indexed in the background",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.KeystorePassphraseFile,
+		helper.KeystorePassphraseFileFlag,
+		defaultConfig.KeystorePassphraseFile,
+		"the path to the file containing the passphrase of the encrypted local secrets, "+
+			"the passphrase is prompted for if it's not given and the secrets are encrypted",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.KeystorePassphraseEnv,
+		helper.KeystorePassphraseEnvFlag,
+		defaultConfig.KeystorePassphraseEnv,
+		"the name of the environment variable containing the passphrase of the encrypted local secrets",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(helper.KeystorePassphraseFileFlag, helper.KeystorePassphraseEnvFlag)
+
 	setLegacyFlags(cmd)
 
 	setDevFlags(cmd)
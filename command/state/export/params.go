@@ -0,0 +1,70 @@
+package stateexport
+
+import (
+	"github.com/0xPolygon/polygon-edge/archive"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	dataDirFlag   = "data-dir"
+	blockFlag     = "block"
+	outFlag       = "out"
+	epochSizeFlag = "epoch-size"
+)
+
+var (
+	params = &exportParams{}
+)
+
+type exportParams struct {
+	dataDir   string
+	block     uint64
+	out       string
+	epochSize uint64
+
+	summary *archive.StateSnapshotSummary
+}
+
+func (p *exportParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+		blockFlag,
+		outFlag,
+	}
+}
+
+func (p *exportParams) export() error {
+	logger := hclog.NewNullLogger()
+
+	db, err := server.OpenBlockchainStorage(p.dataDir, logger)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	stateStorage, err := server.OpenStateStorage(p.dataDir, logger)
+	if err != nil {
+		return err
+	}
+
+	defer stateStorage.Close()
+
+	p.summary, err = archive.ExportState(db, stateStorage, p.block, p.epochSize, p.out)
+
+	return err
+}
+
+func (p *exportParams) getResult() command.CommandResult {
+	return &StateExportResult{
+		File:      p.out,
+		Number:    p.summary.Number,
+		Hash:      p.summary.Hash.String(),
+		StateRoot: p.summary.StateRoot.String(),
+		Headers:   p.summary.Headers,
+		Nodes:     p.summary.Nodes,
+		Codes:     p.summary.Codes,
+	}
+}
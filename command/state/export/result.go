@@ -0,0 +1,36 @@
+package stateexport
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type StateExportResult struct {
+	File      string `json:"file"`
+	Number    uint64 `json:"number"`
+	Hash      string `json:"hash"`
+	StateRoot string `json:"stateRoot"`
+	Headers   int    `json:"headers"`
+	Nodes     int    `json:"nodes"`
+	Codes     int    `json:"codes"`
+}
+
+func (r *StateExportResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[STATE EXPORT]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("File|%s", r.File),
+		fmt.Sprintf("Block|%d", r.Number),
+		fmt.Sprintf("Hash|%s", r.Hash),
+		fmt.Sprintf("State root|%s", r.StateRoot),
+		fmt.Sprintf("Headers|%d", r.Headers),
+		fmt.Sprintf("Trie nodes|%d", r.Nodes),
+		fmt.Sprintf("Contract codes|%d", r.Codes),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,65 @@
+package stateexport
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/consensus/ibft"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	stateExportCmd := &cobra.Command{
+		Use: "export",
+		Short: "Exports the state at the given block and the headers needed to continue the chain from it " +
+			"to a state snapshot file. The node using the data directory must be stopped",
+		Run: runCommand,
+	}
+
+	setFlags(stateExportCmd)
+	helper.SetRequiredFlags(stateExportCmd, params.getRequiredFlags())
+
+	return stateExportCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory used for storing Polygon Edge client data",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.block,
+		blockFlag,
+		0,
+		"the number of the block to export the state at",
+	)
+
+	cmd.Flags().StringVar(
+		&params.out,
+		outFlag,
+		"",
+		"the path of the state snapshot file, must not exist",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.epochSize,
+		epochSizeFlag,
+		ibft.DefaultEpochSize,
+		"the epoch size of the chain, the headers from the beginning of the block's epoch are exported",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.export(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
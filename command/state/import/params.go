@@ -0,0 +1,80 @@
+package stateimport
+
+import (
+	"github.com/0xPolygon/polygon-edge/archive"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/helper/dbengine"
+	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	dataDirFlag  = "data-dir"
+	fileFlag     = "file"
+	dbEngineFlag = "db-engine"
+)
+
+var (
+	params = &importParams{}
+)
+
+type importParams struct {
+	dataDir     string
+	file        string
+	rawDBEngine string
+	dbEngine    dbengine.Engine
+
+	summary *archive.StateSnapshotSummary
+}
+
+func (p *importParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+		fileFlag,
+	}
+}
+
+func (p *importParams) validateFlags() error {
+	var err error
+
+	p.dbEngine, err = dbengine.ParseEngine(p.rawDBEngine)
+
+	return err
+}
+
+func (p *importParams) importState() error {
+	logger := hclog.NewNullLogger()
+
+	if err := server.SetupDataDir(p.dataDir, p.dbEngine); err != nil {
+		return err
+	}
+
+	db, err := server.OpenBlockchainStorage(p.dataDir, logger)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	stateStorage, err := server.OpenStateStorage(p.dataDir, logger)
+	if err != nil {
+		return err
+	}
+
+	defer stateStorage.Close()
+
+	p.summary, err = archive.ImportState(db, stateStorage, p.file)
+
+	return err
+}
+
+func (p *importParams) getResult() command.CommandResult {
+	return &StateImportResult{
+		DataDir:   p.dataDir,
+		Number:    p.summary.Number,
+		Hash:      p.summary.Hash.String(),
+		StateRoot: p.summary.StateRoot.String(),
+		Nodes:     p.summary.Nodes,
+		Codes:     p.summary.Codes,
+	}
+}
@@ -0,0 +1,35 @@
+package stateimport
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type StateImportResult struct {
+	DataDir   string `json:"dataDir"`
+	Number    uint64 `json:"number"`
+	Hash      string `json:"hash"`
+	StateRoot string `json:"stateRoot"`
+	Nodes     int    `json:"nodes"`
+	Codes     int    `json:"codes"`
+}
+
+func (r *StateImportResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[STATE IMPORT]\n")
+	buffer.WriteString("Imported and verified the state snapshot, the chain head is set to the block:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Data dir|%s", r.DataDir),
+		fmt.Sprintf("Block|%d", r.Number),
+		fmt.Sprintf("Hash|%s", r.Hash),
+		fmt.Sprintf("State root|%s", r.StateRoot),
+		fmt.Sprintf("Trie nodes|%d", r.Nodes),
+		fmt.Sprintf("Contract codes|%d", r.Codes),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
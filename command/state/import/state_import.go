@@ -0,0 +1,63 @@
+package stateimport
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/helper/dbengine"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	stateImportCmd := &cobra.Command{
+		Use: "import",
+		Short: "Imports the state snapshot file into the empty data directory and sets the block " +
+			"of the snapshot as the head of the chain. The state is verified against the state root of the block",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(stateImportCmd)
+	helper.SetRequiredFlags(stateImportCmd, params.getRequiredFlags())
+
+	return stateImportCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory used for storing Polygon Edge client data",
+	)
+
+	cmd.Flags().StringVar(
+		&params.file,
+		fileFlag,
+		"",
+		"the path of the state snapshot file",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawDBEngine,
+		dbEngineFlag,
+		string(dbengine.DefaultEngine),
+		"the db engine used for the new databases (leveldb, pebble)",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.importState(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
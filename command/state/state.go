@@ -0,0 +1,27 @@
+package state
+
+import (
+	stateexport "github.com/0xPolygon/polygon-edge/command/state/export"
+	stateimport "github.com/0xPolygon/polygon-edge/command/state/import"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	stateCmd := &cobra.Command{
+		Use:   "state",
+		Short: "Top level command for exporting and importing state snapshots. Only accepts subcommands.",
+	}
+
+	registerSubcommands(stateCmd)
+
+	return stateCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// state export
+		stateexport.GetCommand(),
+		// state import
+		stateimport.GetCommand(),
+	)
+}
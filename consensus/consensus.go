@@ -69,6 +69,7 @@ type Params struct {
 	Grpc           *grpc.Server
 	Logger         hclog.Logger
 	SecretsManager secrets.SecretsManager
+	RemoteSigner   *secrets.RemoteSignerConfig
 	BlockTime      uint64
 }
 
@@ -3,10 +3,10 @@ package ibft
 import (
 	"context"
 	"fmt"
-	"math"
 	"time"
 
 	"github.com/0xPolygon/go-ibft/messages"
+	protoIBFT "github.com/0xPolygon/go-ibft/messages/proto"
 	"github.com/0xPolygon/polygon-edge/consensus"
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
@@ -104,6 +104,8 @@ func (i *backendIBFT) InsertBlock(
 	}
 
 	i.updateMetrics(newBlock)
+	i.trackCommittedSeals(newBlock)
+	i.processForkGovernance(newBlock)
 
 	i.logger.Info(
 		"block committed",
@@ -138,7 +140,9 @@ func (i *backendIBFT) MaximumFaultyNodes() uint64 {
 	return uint64(CalcMaxFaultyNodes(i.currentValidators))
 }
 
-func (i *backendIBFT) Quorum(blockNumber uint64) uint64 {
+// HasQuorum returns whether the senders of the messages reach the quorum at the given height.
+// If the quorum is weighted by stake, the senders need to have more than 2/3 of the total voting power
+func (i *backendIBFT) HasQuorum(blockNumber uint64, msgs []*protoIBFT.Message, _ protoIBFT.MessageType) bool {
 	validators, err := i.forkManager.GetValidators(blockNumber)
 	if err != nil {
 		i.logger.Error(
@@ -147,13 +151,32 @@ func (i *backendIBFT) Quorum(blockNumber uint64) uint64 {
 			"err", err,
 		)
 
-		// return Math.MaxInt32 to prevent overflow when casting to int in go-ibft package
-		return math.MaxInt32
+		return false
 	}
 
-	quorumFn := i.quorumSize(blockNumber)
+	votingPowers, err := i.forkManager.GetVotingPowers(blockNumber)
+	if err != nil {
+		i.logger.Error(
+			"failed to get voting powers when calculating quorum",
+			"height", blockNumber,
+			"err", err,
+		)
+
+		return false
+	}
 
-	return uint64(quorumFn(validators))
+	if votingPowers == nil {
+		quorumFn := i.quorumSize(blockNumber)
+
+		return len(msgs) >= quorumFn(validators)
+	}
+
+	senders := make([]types.Address, len(msgs))
+	for idx, msg := range msgs {
+		senders[idx] = types.BytesToAddress(msg.From)
+	}
+
+	return HasWeightedQuorum(validators, votingPowers, senders)
 }
 
 // buildBlock builds the block, based on the passed in snapshot and parent header
@@ -184,7 +207,7 @@ func (i *backendIBFT) buildBlock(parent *types.Header) (*types.Block, error) {
 	}
 
 	// Set the header timestamp
-	potentialTimestamp := i.calcHeaderTimestamp(parent.Timestamp, time.Now())
+	potentialTimestamp := calcHeaderTimestamp(parent.Timestamp, time.Now(), i.getBlockTime(header.Number))
 	header.Timestamp = uint64(potentialTimestamp.Unix())
 
 	parentCommittedSeals, err := i.extractParentCommittedSeals(parent)
@@ -199,16 +222,20 @@ func (i *backendIBFT) buildBlock(parent *types.Header) (*types.Block, error) {
 		return nil, err
 	}
 
+	// Submit the evidences of the misbehaviors and the governance votes before the other transactions
+	systemTxs := i.writeSlashingTransactions(header, transition)
+	systemTxs = append(systemTxs, i.writeGovernanceTransactions(header, transition)...)
+
 	// Get the block transactions
 	writeCtx, cancelFn := context.WithDeadline(context.Background(), potentialTimestamp)
 	defer cancelFn()
 
-	txs := i.writeTransactions(
+	txs := append(systemTxs, i.writeTransactions(
 		writeCtx,
 		gasLimit,
 		header.Number,
 		transition,
-	)
+	)...)
 
 	if err := i.PreCommitState(header, transition); err != nil {
 		return nil, err
@@ -244,10 +271,10 @@ func (i *backendIBFT) buildBlock(parent *types.Header) (*types.Block, error) {
 
 // calcHeaderTimestamp calculates the new block timestamp, based
 // on the block time and parent timestamp
-func (i *backendIBFT) calcHeaderTimestamp(parentUnix uint64, currentTime time.Time) time.Time {
+func calcHeaderTimestamp(parentUnix uint64, currentTime time.Time, blockTime time.Duration) time.Time {
 	var (
 		parentTimestamp    = time.Unix(int64(parentUnix), 0)
-		potentialTimestamp = parentTimestamp.Add(i.blockTime)
+		potentialTimestamp = parentTimestamp.Add(blockTime)
 	)
 
 	if potentialTimestamp.Before(currentTime) {
@@ -255,7 +282,7 @@ func (i *backendIBFT) calcHeaderTimestamp(parentUnix uint64, currentTime time.Ti
 		// has passed, round it to the nearest
 		// multiple of block time
 		// t........t+blockT...x (t+blockT.x; now).....t+blockT (potential)
-		potentialTimestamp = roundUpTime(currentTime, i.blockTime)
+		potentialTimestamp = roundUpTime(currentTime, blockTime)
 	}
 
 	return potentialTimestamp
@@ -1,9 +1,12 @@
 package ibft
 
 import (
+	"math/big"
 	"testing"
 	"time"
 
+	protoIBFT "github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -45,16 +48,13 @@ func TestIBFTBackend_CalculateHeaderTimestamp(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			t.Parallel()
 
-			i := &backendIBFT{
-				blockTime: time.Duration(testCase.blockTime) * time.Second,
-			}
-
 			assert.Equal(
 				t,
 				testCase.expectedTimestamp.Unix(),
-				i.calcHeaderTimestamp(
+				calcHeaderTimestamp(
 					uint64(testCase.parentTimestamp),
 					testCase.currentTime,
+					time.Duration(testCase.blockTime)*time.Second,
 				).Unix(),
 			)
 		})
@@ -111,3 +111,82 @@ func TestIBFTBackend_RoundUpTime(t *testing.T) {
 		})
 	}
 }
+
+func TestIBFTBackend_HasQuorum(t *testing.T) {
+	t.Parallel()
+
+	pool := newTesterAccountPool(t)
+	pool.add("A", "B", "C", "D")
+
+	votingPowers := map[types.Address]*big.Int{
+		pool.get("A").Address(): big.NewInt(70),
+		pool.get("B").Address(): big.NewInt(10),
+		pool.get("C").Address(): big.NewInt(10),
+		pool.get("D").Address(): big.NewInt(10),
+	}
+
+	testTable := []struct {
+		name         string
+		votingPowers map[types.Address]*big.Int
+		senders      []string
+		expected     bool
+	}{
+		{
+			name:     "count-based quorum is reached",
+			senders:  []string{"B", "C", "D"},
+			expected: true,
+		},
+		{
+			name:     "count-based quorum is not reached",
+			senders:  []string{"A", "B"},
+			expected: false,
+		},
+		{
+			name:         "senders have more than 2/3 of the voting power",
+			votingPowers: votingPowers,
+			senders:      []string{"A", "B"},
+			expected:     true,
+		},
+		{
+			name:         "majority of the senders without enough voting power",
+			votingPowers: votingPowers,
+			senders:      []string{"B", "C", "D"},
+			expected:     false,
+		},
+		{
+			name:         "sender is counted once",
+			votingPowers: votingPowers,
+			senders:      []string{"B", "B", "B", "C", "D"},
+			expected:     false,
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			backend := &backendIBFT{
+				forkManager: &mockForkManager{
+					vals:         pool.ValidatorSet(),
+					votingPowers: testCase.votingPowers,
+				},
+			}
+
+			msgs := make([]*protoIBFT.Message, len(testCase.senders))
+			for idx, name := range testCase.senders {
+				msgs[idx] = &protoIBFT.Message{
+					Type: protoIBFT.MessageType_COMMIT,
+					From: pool.get(name).Address().Bytes(),
+				}
+			}
+
+			assert.Equal(
+				t,
+				testCase.expected,
+				backend.HasQuorum(1, msgs, protoIBFT.MessageType_COMMIT),
+			)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package ibft
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/contracts/staking"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrDelegationDisabled = errors.New("delegation is not enabled")
+)
+
+// ValidatorDelegations is the stake of the validator and the delegations to the validator
+type ValidatorDelegations struct {
+	Validator      types.Address
+	StakedAmount   *big.Int
+	DelegatedStake *big.Int
+	Delegations    []staking.Delegation
+}
+
+// GetDelegations returns the delegations to the validator at the latest state
+func (i *backendIBFT) GetDelegations(validator types.Address) (*ValidatorDelegations, error) {
+	header := i.blockchain.Header()
+
+	if !i.delegation.IsActive(header.Number) {
+		return nil, ErrDelegationDisabled
+	}
+
+	transition, err := i.executor.BeginTxn(header.StateRoot, header, types.ZeroAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	stakedAmount, err := staking.QueryAccountStake(transition, types.ZeroAddress, validator)
+	if err != nil {
+		return nil, err
+	}
+
+	delegatedStake, err := staking.QueryDelegatedStake(transition, types.ZeroAddress, validator)
+	if err != nil {
+		return nil, err
+	}
+
+	delegations, err := staking.QueryDelegations(transition, types.ZeroAddress, validator)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ValidatorDelegations{
+		Validator:      validator,
+		StakedAmount:   stakedAmount,
+		DelegatedStake: delegatedStake,
+		Delegations:    delegations,
+	}, nil
+}
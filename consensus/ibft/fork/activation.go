@@ -0,0 +1,54 @@
+package fork
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+)
+
+var (
+	ErrInvalidActivation = errors.New("invalid activation in IBFT config")
+)
+
+// Activation is the block from which an optional feature of IBFT is enabled,
+// defined in params.engine.ibft of genesis.json so that all the nodes enable it at the same height
+type Activation struct {
+	From common.JSONNumber `json:"from"`
+}
+
+// IsActive returns whether the feature is enabled at the given height, the nil activation is never active
+func (a *Activation) IsActive(height uint64) bool {
+	return a != nil && height >= a.From.Value
+}
+
+// ParseActivation reads the activation of the optional feature from IBFT config.
+// The feature is enabled from the genesis by true, or from the given block by {"from": <block>}.
+// It returns nil if the feature is disabled
+func ParseActivation(ibftConfig map[string]interface{}, key string) (*Activation, error) {
+	switch value := ibftConfig[key].(type) {
+	case nil:
+		return nil, nil
+	case bool:
+		if !value {
+			return nil, nil
+		}
+
+		return &Activation{}, nil
+	case map[string]interface{}:
+		bytes, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+
+		activation := &Activation{}
+		if err := json.Unmarshal(bytes, activation); err != nil {
+			return nil, fmt.Errorf("%w: %s, %v", ErrInvalidActivation, key, err)
+		}
+
+		return activation, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidActivation, key)
+	}
+}
@@ -0,0 +1,85 @@
+package fork
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseActivation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		config   map[string]interface{}
+		expected *Activation
+		err      error
+	}{
+		{
+			name:     "should return nil if the key is not defined",
+			config:   map[string]interface{}{},
+			expected: nil,
+		},
+		{
+			name:     "should return nil if the feature is disabled",
+			config:   map[string]interface{}{KeyDelegation: false},
+			expected: nil,
+		},
+		{
+			name:     "should activate at genesis by true",
+			config:   map[string]interface{}{KeyDelegation: true},
+			expected: &Activation{},
+		},
+		{
+			name: "should activate at the given block",
+			config: map[string]interface{}{
+				KeyDelegation: map[string]interface{}{
+					"from": float64(100),
+				},
+			},
+			expected: &Activation{From: common.JSONNumber{Value: 100}},
+		},
+		{
+			name:   "should return error for the invalid value",
+			config: map[string]interface{}{KeyDelegation: "100"},
+			err:    ErrInvalidActivation,
+		},
+		{
+			name: "should return error for the invalid block",
+			config: map[string]interface{}{
+				KeyDelegation: map[string]interface{}{
+					"from": "block",
+				},
+			},
+			err: ErrInvalidActivation,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			activation, err := ParseActivation(test.config, KeyDelegation)
+
+			assert.ErrorIs(t, err, test.err)
+			assert.Equal(t, test.expected, activation)
+		})
+	}
+}
+
+func TestActivation_IsActive(t *testing.T) {
+	t.Parallel()
+
+	var disabled *Activation
+
+	assert.False(t, disabled.IsActive(0))
+	assert.True(t, (&Activation{}).IsActive(0))
+
+	activation := &Activation{From: common.JSONNumber{Value: 100}}
+
+	assert.False(t, activation.IsActive(99))
+	assert.True(t, activation.IsActive(100))
+}
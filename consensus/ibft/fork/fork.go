@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/validators"
 )
@@ -13,10 +14,19 @@ const (
 	KeyType          = "type"
 	KeyTypes         = "types"
 	KeyValidatorType = "validator_type"
+	KeyDelegation    = "delegation"
 )
 
 var (
 	ErrUndefinedIBFTConfig = errors.New("IBFT config is not defined")
+	ErrInvalidEpochSize    = errors.New("epoch size must be greater than 0")
+	ErrInvalidBlockTime    = errors.New("block time must be greater than 0")
+	ErrMisalignedEpoch     = errors.New(
+		"fork changing epoch size must start right after the last block of an epoch of both sizes",
+	)
+
+	ErrUnsupportedExtraVersion = errors.New("unsupported IBFT extra version")
+	ErrExtraVersionRequiresBLS = errors.New("IBFT extra version with aggregated seals requires BLS validators")
 )
 
 // IBFT Fork represents setting in params.engine.ibft of genesis.json
@@ -33,6 +43,23 @@ type IBFTFork struct {
 	// PoS
 	MaxValidatorCount *common.JSONNumber `json:"maxValidatorCount,omitempty"`
 	MinValidatorCount *common.JSONNumber `json:"minValidatorCount,omitempty"`
+
+	// WeightedQuorum weights the votes of PoS validators by their stake
+	WeightedQuorum bool `json:"weightedQuorum,omitempty"`
+
+	// Block reward and fee distribution
+	Rewards *Rewards `json:"rewards,omitempty"`
+
+	// Timing parameters, the global values are used if they are not defined
+	// BlockTime is the minimum block generation time in seconds
+	BlockTime *common.JSONNumber `json:"blockTime,omitempty"`
+	// EpochSize is the number of blocks in an epoch
+	EpochSize *common.JSONNumber `json:"epochSize,omitempty"`
+	// RoundTimeout is the time in seconds added to the base timeout of each IBFT round
+	RoundTimeout *common.JSONNumber `json:"roundTimeout,omitempty"`
+
+	// ExtraVersion is the version of IBFT Extra in the headers, 0 is the legacy format
+	ExtraVersion uint64 `json:"extraVersion,omitempty"`
 }
 
 func (f *IBFTFork) UnmarshalJSON(data []byte) error {
@@ -45,6 +72,12 @@ func (f *IBFTFork) UnmarshalJSON(data []byte) error {
 		Validators        interface{}               `json:"validators,omitempty"`
 		MaxValidatorCount *common.JSONNumber        `json:"maxValidatorCount,omitempty"`
 		MinValidatorCount *common.JSONNumber        `json:"minValidatorCount,omitempty"`
+		WeightedQuorum    bool                      `json:"weightedQuorum,omitempty"`
+		Rewards           *Rewards                  `json:"rewards,omitempty"`
+		BlockTime         *common.JSONNumber        `json:"blockTime,omitempty"`
+		EpochSize         *common.JSONNumber        `json:"epochSize,omitempty"`
+		RoundTimeout      *common.JSONNumber        `json:"roundTimeout,omitempty"`
+		ExtraVersion      uint64                    `json:"extraVersion,omitempty"`
 	}{}
 
 	if err := json.Unmarshal(data, &raw); err != nil {
@@ -57,6 +90,12 @@ func (f *IBFTFork) UnmarshalJSON(data []byte) error {
 	f.To = raw.To
 	f.MaxValidatorCount = raw.MaxValidatorCount
 	f.MinValidatorCount = raw.MinValidatorCount
+	f.WeightedQuorum = raw.WeightedQuorum
+	f.Rewards = raw.Rewards
+	f.BlockTime = raw.BlockTime
+	f.EpochSize = raw.EpochSize
+	f.RoundTimeout = raw.RoundTimeout
+	f.ExtraVersion = raw.ExtraVersion
 
 	f.ValidatorType = validators.ECDSAValidatorType
 	if raw.ValidatorType != nil {
@@ -81,6 +120,48 @@ func (f *IBFTFork) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Validate checks the parameters of the fork which can't be checked by the types
+func (f *IBFTFork) Validate() error {
+	if f.WeightedQuorum && f.Type != PoS {
+		return ErrWeightedQuorumRequiresPoS
+	}
+
+	if f.EpochSize != nil && f.EpochSize.Value == 0 {
+		return ErrInvalidEpochSize
+	}
+
+	if f.BlockTime != nil && f.BlockTime.Value == 0 {
+		return ErrInvalidBlockTime
+	}
+
+	switch f.ExtraVersion {
+	case signer.LegacyExtraVersion:
+	case signer.AggregatedSealsExtraVersion:
+		if f.ValidatorType != validators.BLSValidatorType {
+			return ErrExtraVersionRequiresBLS
+		}
+	default:
+		return ErrUnsupportedExtraVersion
+	}
+
+	return nil
+}
+
+// getEpochSize returns the epoch size of the fork, or the given default if the fork doesn't define it
+func (f *IBFTFork) getEpochSize(defaultEpochSize uint64) uint64 {
+	if f.EpochSize != nil {
+		return f.EpochSize.Value
+	}
+
+	return defaultEpochSize
+}
+
+// GetDelegationActivation returns the activation of the delegated staking in IBFT config,
+// nil if the delegation is disabled
+func GetDelegationActivation(ibftConfig map[string]interface{}) (*Activation, error) {
+	return ParseActivation(ibftConfig, KeyDelegation)
+}
+
 // GetIBFTForks returns IBFT fork configurations from chain config
 func GetIBFTForks(ibftConfig map[string]interface{}) (IBFTForks, error) {
 	// no fork, only specifying IBFT type in chain config
@@ -143,6 +224,29 @@ func (fs *IBFTForks) getFork(height uint64) *IBFTFork {
 	return nil
 }
 
+// validateEpochs checks that the forks changing the epoch size start right after the last block
+// of an epoch of both the previous and the new epoch size, so that the epochs continue across the forks
+// and their last blocks stay at the multiples of the epoch size
+func (fs *IBFTForks) validateEpochs(defaultEpochSize uint64) error {
+	for idx := 1; idx < len(*fs); idx++ {
+		var (
+			fork     = (*fs)[idx]
+			prevSize = (*fs)[idx-1].getEpochSize(defaultEpochSize)
+			size     = fork.getEpochSize(defaultEpochSize)
+		)
+
+		if size == prevSize {
+			continue
+		}
+
+		if fork.From.Value == 0 || (fork.From.Value-1)%prevSize != 0 || (fork.From.Value-1)%size != 0 {
+			return ErrMisalignedEpoch
+		}
+	}
+
+	return nil
+}
+
 // filterByType returns new list of IBFTFork whose type matches with the given type
 func (fs *IBFTForks) filterByType(ibftType IBFTType) IBFTForks {
 	filteredForks := make(IBFTForks, 0)
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	testHelper "github.com/0xPolygon/polygon-edge/helper/tests"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -57,6 +58,38 @@ func TestIBFTForkUnmarshalJSON(t *testing.T) {
 				MinValidatorCount: &common.JSONNumber{Value: 1},
 			},
 		},
+		{
+			name: "should parse weighted quorum",
+			data: fmt.Sprintf(`{
+				"type": "%s",
+				"from": %d,
+				"weightedQuorum": true
+			}`, PoS, 10),
+			expected: &IBFTFork{
+				Type:           PoS,
+				ValidatorType:  validators.ECDSAValidatorType,
+				From:           common.JSONNumber{Value: 10},
+				WeightedQuorum: true,
+			},
+		},
+		{
+			name: "should parse timing parameters",
+			data: fmt.Sprintf(`{
+				"type": "%s",
+				"from": %d,
+				"blockTime": %d,
+				"epochSize": %d,
+				"roundTimeout": %d
+			}`, PoS, 10, 1, 20, 5),
+			expected: &IBFTFork{
+				Type:          PoS,
+				ValidatorType: validators.ECDSAValidatorType,
+				From:          common.JSONNumber{Value: 10},
+				BlockTime:     &common.JSONNumber{Value: 1},
+				EpochSize:     &common.JSONNumber{Value: 20},
+				RoundTimeout:  &common.JSONNumber{Value: 5},
+			},
+		},
 		{
 			name: "should parse without validators",
 			data: fmt.Sprintf(`{
@@ -109,6 +142,95 @@ func TestIBFTForkUnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestIBFTForkValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		fork *IBFTFork
+		err  error
+	}{
+		{
+			name: "should succeed without timing parameters",
+			fork: &IBFTFork{
+				Type: PoA,
+			},
+			err: nil,
+		},
+		{
+			name: "should succeed with timing parameters",
+			fork: &IBFTFork{
+				Type:         PoS,
+				BlockTime:    &common.JSONNumber{Value: 1},
+				EpochSize:    &common.JSONNumber{Value: 10},
+				RoundTimeout: &common.JSONNumber{Value: 0},
+			},
+			err: nil,
+		},
+		{
+			name: "should return error if weighted quorum is enabled in PoA",
+			fork: &IBFTFork{
+				Type:           PoA,
+				WeightedQuorum: true,
+			},
+			err: ErrWeightedQuorumRequiresPoS,
+		},
+		{
+			name: "should return error if epoch size is zero",
+			fork: &IBFTFork{
+				Type:      PoS,
+				EpochSize: &common.JSONNumber{Value: 0},
+			},
+			err: ErrInvalidEpochSize,
+		},
+		{
+			name: "should return error if block time is zero",
+			fork: &IBFTFork{
+				Type:      PoA,
+				BlockTime: &common.JSONNumber{Value: 0},
+			},
+			err: ErrInvalidBlockTime,
+		},
+		{
+			name: "should succeed with aggregated seals in BLS",
+			fork: &IBFTFork{
+				Type:          PoA,
+				ValidatorType: validators.BLSValidatorType,
+				ExtraVersion:  signer.AggregatedSealsExtraVersion,
+			},
+			err: nil,
+		},
+		{
+			name: "should return error if aggregated seals is enabled in ECDSA",
+			fork: &IBFTFork{
+				Type:          PoA,
+				ValidatorType: validators.ECDSAValidatorType,
+				ExtraVersion:  signer.AggregatedSealsExtraVersion,
+			},
+			err: ErrExtraVersionRequiresBLS,
+		},
+		{
+			name: "should return error if extra version is unknown",
+			fork: &IBFTFork{
+				Type:          PoA,
+				ValidatorType: validators.BLSValidatorType,
+				ExtraVersion:  2,
+			},
+			err: ErrUnsupportedExtraVersion,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.ErrorIs(t, test.fork.Validate(), test.err)
+		})
+	}
+}
+
 func TestGetIBFTForks(t *testing.T) {
 	t.Parallel()
 
@@ -331,3 +453,57 @@ func TestIBFTForks_filterByType(t *testing.T) {
 		forks.filterByType(PoS),
 	)
 }
+
+func TestIBFTForks_validateEpochs(t *testing.T) {
+	t.Parallel()
+
+	newForks := func(from uint64, epochSize *common.JSONNumber) IBFTForks {
+		return IBFTForks{
+			{
+				Type: PoA,
+				From: common.JSONNumber{Value: 0},
+				To:   &common.JSONNumber{Value: from - 1},
+			},
+			{
+				Type:      PoS,
+				From:      common.JSONNumber{Value: from},
+				EpochSize: epochSize,
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		forks    IBFTForks
+		expected error
+	}{
+		{
+			name:  "should accept the fork keeping the epoch size at any height",
+			forks: newForks(16, &common.JSONNumber{Value: 10}),
+		},
+		{
+			name:  "should accept the fork changing the epoch size after the last block of an epoch of both sizes",
+			forks: newForks(21, &common.JSONNumber{Value: 20}),
+		},
+		{
+			name:     "should reject the fork starting in the middle of an epoch of the previous size",
+			forks:    newForks(16, &common.JSONNumber{Value: 5}),
+			expected: ErrMisalignedEpoch,
+		},
+		{
+			name:     "should reject the fork starting in the middle of an epoch of the new size",
+			forks:    newForks(11, &common.JSONNumber{Value: 20}),
+			expected: ErrMisalignedEpoch,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.ErrorIs(t, test.forks.validateEpochs(10), test.expected)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package fork
 
 import (
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/hook"
+	"github.com/0xPolygon/polygon-edge/types"
 )
 
 // PoAHookRegisterer that registers hooks for PoA mode
@@ -60,7 +61,7 @@ func (r *PoAHookRegister) RegisterHooks(hooks *hook.Hooks, height uint64) {
 // PoAHookRegisterer that registers hooks for PoS mode
 type PoSHookRegister struct {
 	posForks            IBFTForks
-	epochSize           uint64
+	epochSize           uint64 // default epoch size for the forks which don't define it
 	deployContractForks map[uint64]*IBFTFork
 }
 
@@ -92,7 +93,7 @@ func NewPoSHookRegister(
 func (r *PoSHookRegister) RegisterHooks(hooks *hook.Hooks, height uint64) {
 	if currentFork := r.posForks.getFork(height); currentFork != nil {
 		// in PoS mode currently
-		registerTxInclusionGuardHooks(hooks, r.epochSize)
+		registerTxInclusionGuardHooks(hooks, currentFork.getEpochSize(r.epochSize))
 	}
 
 	if deploymentFork, ok := r.deployContractForks[height]; ok {
@@ -100,3 +101,30 @@ func (r *PoSHookRegister) RegisterHooks(hooks *hook.Hooks, height uint64) {
 		registerStakingContractDeploymentHooks(hooks, deploymentFork)
 	}
 }
+
+// RewardHookRegister that registers hooks for block rewards and fee distribution
+type RewardHookRegister struct {
+	forks          IBFTForks
+	getSealSigners func(*types.Header) ([]types.Address, error)
+	delegation     *Activation
+}
+
+// NewRewardHookRegister is a constructor of RewardHookRegister
+func NewRewardHookRegister(
+	forks IBFTForks,
+	getSealSigners func(*types.Header) ([]types.Address, error),
+	delegation *Activation,
+) *RewardHookRegister {
+	return &RewardHookRegister{
+		forks:          forks,
+		getSealSigners: getSealSigners,
+		delegation:     delegation,
+	}
+}
+
+// RegisterHooks registers hooks to mint the block reward and to distribute the fees
+func (r *RewardHookRegister) RegisterHooks(hooks *hook.Hooks, height uint64) {
+	if currentFork := r.forks.getFork(height); currentFork != nil && currentFork.Rewards != nil {
+		registerRewardHooks(hooks, currentFork.Rewards, r.getSealSigners, r.delegation)
+	}
+}
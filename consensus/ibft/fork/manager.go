@@ -2,9 +2,13 @@ package fork
 
 import (
 	"errors"
+	"math/big"
+	"sync"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/hook"
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
+	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -25,6 +29,10 @@ var (
 	ErrSignerNotFound         = errors.New("signer not found")
 	ErrValidatorStoreNotFound = errors.New("validator set not found")
 	ErrKeyManagerNotFound     = errors.New("key manager not found")
+
+	ErrWeightedQuorumRequiresPoS = errors.New("weighted quorum is supported only in PoS")
+	ErrScheduledForkHasEnd       = errors.New("scheduled fork must not have the end")
+	ErrScheduledForkNotLater     = errors.New("scheduled fork must start after the last fork")
 )
 
 // ValidatorStore is an interface that ForkManager calls for Validator Store
@@ -34,6 +42,14 @@ type ValidatorStore interface {
 	Close() error
 	// GetValidators is a method to return validators at the given height
 	GetValidators(height, epochSize, forkFrom uint64) (validators.Validators, error)
+	// Rewind is a method to drop the data derived from the blocks above the given height
+	Rewind(height uint64) error
+}
+
+// VotingPowerStore is an interface of the validator store that provides the voting powers of the validators
+type VotingPowerStore interface {
+	// GetVotingPowers is a method to return the voting powers of the validators at the given height
+	GetVotingPowers(height, epochSize, forkFrom uint64) (map[types.Address]*big.Int, error)
 }
 
 // HookRegister is an interface that ForkManager calls for hook registrations
@@ -57,20 +73,39 @@ type HooksInterface interface {
 // ForkManager is the module that has Fork configuration and multiple version of submodules
 // and returns the proper submodule at specified height
 type ForkManager struct {
+	// lock protects the forks and the submodules replaced by the scheduled forks
+	lock sync.RWMutex
+	// scheduleLock serializes the scheduling of the forks
+	scheduleLock sync.Mutex
+
 	logger         hclog.Logger
 	blockchain     store.HeaderGetter
 	executor       contract.Executor
 	secretsManager secrets.SecretsManager
+	remoteSigner   *signer.RemoteSignerClient
 
 	// configuration
-	forks     IBFTForks
-	filePath  string
-	epochSize uint64
+	forks       IBFTForks
+	filePath    string
+	epochSize   uint64
+	delegation  *Activation
+	initialized bool
+
+	// unscheduledForks is the forks before the first scheduled fork, nil if no fork has been scheduled
+	unscheduledForks IBFTForks
 
 	// submodule lookup
 	keyManagers     map[validators.ValidatorType]signer.KeyManager
 	validatorStores map[store.SourceType]ValidatorStore
 	hooksRegisters  map[IBFTType]HooksRegister
+
+	// rewardHooksRegister wraps the hooks by the types, so it's separated from hooksRegisters
+	rewardHooksRegister HooksRegister
+
+	// stagedValidator is the validator with the staged keys waiting for the key rotation
+	stagedValidator validators.Validator
+	// stagedValidatorLoaded is the flag whether stagedValidator has been loaded from the secrets
+	stagedValidatorLoaded bool
 }
 
 // NewForkManager is a constructor of ForkManager
@@ -79,6 +114,7 @@ func NewForkManager(
 	blockchain store.HeaderGetter,
 	executor contract.Executor,
 	secretManager secrets.SecretsManager,
+	remoteSigner *signer.RemoteSignerClient,
 	filePath string,
 	epochSize uint64,
 	ibftConfig map[string]interface{},
@@ -88,19 +124,30 @@ func NewForkManager(
 		return nil, err
 	}
 
+	delegation, err := GetDelegationActivation(ibftConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	fm := &ForkManager{
 		logger:          logger.Named(loggerName),
 		blockchain:      blockchain,
 		executor:        executor,
 		secretsManager:  secretManager,
+		remoteSigner:    remoteSigner,
 		filePath:        filePath,
 		epochSize:       epochSize,
+		delegation:      delegation,
 		forks:           forks,
 		keyManagers:     make(map[validators.ValidatorType]signer.KeyManager),
 		validatorStores: make(map[store.SourceType]ValidatorStore),
 		hooksRegisters:  make(map[IBFTType]HooksRegister),
 	}
 
+	if err := fm.recoverStagedKeys(); err != nil {
+		return nil, err
+	}
+
 	// Need initialization of signers in the constructor
 	// because hash calculation is called from blockchain initialization
 	if err := fm.initializeKeyManagers(); err != nil {
@@ -112,17 +159,139 @@ func NewForkManager(
 
 // Initialize initializes ForkManager on initialization phase
 func (m *ForkManager) Initialize() error {
+	forks := m.getForks()
+
+	for _, fork := range forks {
+		if err := fork.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := forks.validateEpochs(m.epochSize); err != nil {
+		return err
+	}
+
 	if err := m.initializeValidatorStores(); err != nil {
 		return err
 	}
 
 	m.initializeHooksRegisters()
 
+	m.lock.Lock()
+	m.initialized = true
+	m.lock.Unlock()
+
+	return nil
+}
+
+// ScheduleFork appends the fork decided after the genesis, e.g. by the governance.
+// The fork must start after the last fork and is applied from its starting height.
+// The last fork ends at the height before the scheduled fork
+func (m *ForkManager) ScheduleFork(fork *IBFTFork) error {
+	m.scheduleLock.Lock()
+	defer m.scheduleLock.Unlock()
+
+	if fork.To != nil {
+		return ErrScheduledForkHasEnd
+	}
+
+	if err := fork.Validate(); err != nil {
+		return err
+	}
+
+	forks := m.getForks()
+	newForks := make(IBFTForks, len(forks), len(forks)+1)
+
+	copy(newForks, forks)
+
+	if len(newForks) > 0 {
+		// copy the last fork not to change the fork referred by the other modules
+		lastFork := *newForks[len(newForks)-1]
+
+		if fork.From.Value <= lastFork.From.Value ||
+			(lastFork.To != nil && lastFork.To.Value+1 != fork.From.Value) {
+			return ErrScheduledForkNotLater
+		}
+
+		lastFork.To = &common.JSONNumber{Value: fork.From.Value - 1}
+		newForks[len(newForks)-1] = &lastFork
+	}
+
+	newForks = append(newForks, fork)
+	if err := newForks.validateEpochs(m.epochSize); err != nil {
+		return err
+	}
+
+	if err := m.initializeKeyManager(fork.ValidatorType); err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	if m.unscheduledForks == nil {
+		m.unscheduledForks = forks
+	}
+
+	m.forks = newForks
+	initialized := m.initialized
+	m.lock.Unlock()
+
+	// the submodules are initialized in Initialize otherwise
+	if !initialized {
+		return nil
+	}
+
+	if err := m.initializeValidatorStore(ibftTypesToSourceType[fork.Type]); err != nil {
+		return err
+	}
+
+	m.initializeHooksRegisters()
+
+	return nil
+}
+
+// ResetScheduledForks removes all the forks scheduled after the genesis,
+// e.g. to schedule them again from the chain after the rewind
+func (m *ForkManager) ResetScheduledForks() {
+	m.scheduleLock.Lock()
+	defer m.scheduleLock.Unlock()
+
+	m.lock.Lock()
+	if m.unscheduledForks == nil {
+		m.lock.Unlock()
+
+		return
+	}
+
+	m.forks = m.unscheduledForks
+	m.unscheduledForks = nil
+	initialized := m.initialized
+	m.lock.Unlock()
+
+	// the validator stores of the removed forks are kept, they are used again if the forks are scheduled again
+	if initialized {
+		m.initializeHooksRegisters()
+	}
+}
+
+// Rewind makes the validator stores drop the data derived from the blocks above the given height
+func (m *ForkManager) Rewind(height uint64) error {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for _, store := range m.validatorStores {
+		if err := store.Rewind(height); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 // Close calls termination process of submodules
 func (m *ForkManager) Close() error {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
 	for _, store := range m.validatorStores {
 		if err := store.Close(); err != nil {
 			return err
@@ -147,15 +316,21 @@ func (m *ForkManager) GetSigner(height uint64) (signer.Signer, error) {
 		}
 	}
 
-	return signer.NewSigner(
+	extraVersion := signer.LegacyExtraVersion
+	if fork := m.getFork(height); fork != nil {
+		extraVersion = fork.ExtraVersion
+	}
+
+	return signer.NewVersionedSigner(
 		keyManager,
 		parentKeyManager,
+		extraVersion,
 	), nil
 }
 
 // GetValidatorStore returns a proper validator set at specified height
 func (m *ForkManager) GetValidatorStore(height uint64) (ValidatorStore, error) {
-	fork := m.forks.getFork(height)
+	fork := m.getFork(height)
 	if fork == nil {
 		return nil, ErrForkNotFound
 	}
@@ -170,7 +345,7 @@ func (m *ForkManager) GetValidatorStore(height uint64) (ValidatorStore, error) {
 
 // GetValidators returns validators at specified height
 func (m *ForkManager) GetValidators(height uint64) (validators.Validators, error) {
-	fork := m.forks.getFork(height)
+	fork := m.getFork(height)
 	if fork == nil {
 		return nil, ErrForkNotFound
 	}
@@ -182,23 +357,173 @@ func (m *ForkManager) GetValidators(height uint64) (validators.Validators, error
 
 	return set.GetValidators(
 		height,
-		m.epochSize,
+		fork.getEpochSize(m.epochSize),
 		fork.From.Value,
 	)
 }
 
+// IsWeightedQuorum returns whether the votes are weighted by the stake at specified height
+func (m *ForkManager) IsWeightedQuorum(height uint64) bool {
+	fork := m.getFork(height)
+
+	return fork != nil && fork.WeightedQuorum
+}
+
+// HasRewards returns whether the fork at specified height mints the block reward and distributes the fees
+func (m *ForkManager) HasRewards(height uint64) bool {
+	fork := m.getFork(height)
+
+	return fork != nil && fork.Rewards != nil
+}
+
+// GetVotingPowers returns the voting powers of the validators at specified height.
+// It returns nil if the weighted quorum is not enabled at the height
+func (m *ForkManager) GetVotingPowers(height uint64) (map[types.Address]*big.Int, error) {
+	fork := m.getFork(height)
+	if fork == nil {
+		return nil, ErrForkNotFound
+	}
+
+	if !fork.WeightedQuorum {
+		return nil, nil
+	}
+
+	set, ok := m.getValidatorStoreByIBFTFork(fork).(VotingPowerStore)
+	if !ok {
+		return nil, ErrValidatorStoreNotFound
+	}
+
+	return set.GetVotingPowers(
+		height,
+		fork.getEpochSize(m.epochSize),
+		fork.From.Value,
+	)
+}
+
+// GetEpochSize returns the epoch size at specified height
+func (m *ForkManager) GetEpochSize(height uint64) uint64 {
+	if fork := m.getFork(height); fork != nil {
+		return fork.getEpochSize(m.epochSize)
+	}
+
+	return m.epochSize
+}
+
+// GetEpoch returns the epoch at specified height. The epochs are counted across the forks,
+// the forks changing the epoch size start right after the last block of an epoch
+func (m *ForkManager) GetEpoch(height uint64) uint64 {
+	var (
+		forks = m.getForks()
+		size  = m.epochSize
+
+		// epochs before the fork which changed the epoch size last, and its beginning
+		epochs uint64
+		base   uint64
+	)
+
+	for idx, fork := range forks {
+		if fork.From.Value > height {
+			break
+		}
+
+		forkSize := fork.getEpochSize(m.epochSize)
+		if idx > 0 && forkSize != size {
+			epochs += (fork.From.Value - 1 - base) / size
+			base = fork.From.Value - 1
+		}
+
+		size = forkSize
+	}
+
+	// the last block of the epoch belongs to the epoch
+	return epochs + (height-base+size-1)/size
+}
+
+// GetBlockTime returns the block time defined by the fork at specified height.
+// It returns 0 if the fork doesn't define it
+func (m *ForkManager) GetBlockTime(height uint64) time.Duration {
+	if fork := m.getFork(height); fork != nil && fork.BlockTime != nil {
+		return time.Duration(fork.BlockTime.Value) * time.Second
+	}
+
+	return 0
+}
+
+// GetRoundTimeout returns the additional round timeout defined by the fork at specified height.
+// It returns 0 if the fork doesn't define it
+func (m *ForkManager) GetRoundTimeout(height uint64) time.Duration {
+	if fork := m.getFork(height); fork != nil && fork.RoundTimeout != nil {
+		return time.Duration(fork.RoundTimeout.Value) * time.Second
+	}
+
+	return 0
+}
+
 // GetHooks returns a hooks at specified height
 func (m *ForkManager) GetHooks(height uint64) HooksInterface {
-	hooks := &hook.Hooks{}
+	m.lock.RLock()
 
+	hooksRegisters := make([]HooksRegister, 0, len(m.hooksRegisters)+1)
 	for _, r := range m.hooksRegisters {
+		hooksRegisters = append(hooksRegisters, r)
+	}
+
+	if m.rewardHooksRegister != nil {
+		hooksRegisters = append(hooksRegisters, m.rewardHooksRegister)
+	}
+
+	m.lock.RUnlock()
+
+	hooks := &hook.Hooks{}
+
+	// the reward hooks need to be registered after the others
+	for _, r := range hooksRegisters {
 		r.RegisterHooks(hooks, height)
 	}
 
 	return hooks
 }
 
+// getForks returns the current forks, the returned forks are not modified by ScheduleFork
+func (m *ForkManager) getForks() IBFTForks {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.forks
+}
+
+// getFork returns the fork in which the given height is
+func (m *ForkManager) getFork(height uint64) *IBFTFork {
+	forks := m.getForks()
+
+	return forks.getFork(height)
+}
+
+// getParentCommittedSealSigners returns the addresses of the validators
+// who signed the committed seals of the parent block
+func (m *ForkManager) getParentCommittedSealSigners(header *types.Header) ([]types.Address, error) {
+	// genesis doesn't have committed seals
+	if header.Number <= 1 {
+		return nil, nil
+	}
+
+	parentSigner, err := m.GetSigner(header.Number - 1)
+	if err != nil {
+		return nil, err
+	}
+
+	parentValidators, err := m.GetValidators(header.Number - 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return parentSigner.GetParentCommittedSealSigners(header, parentValidators)
+}
+
 func (m *ForkManager) getValidatorStoreByIBFTFork(fork *IBFTFork) ValidatorStore {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
 	set, ok := m.validatorStores[ibftTypesToSourceType[fork.Type]]
 	if !ok {
 		return nil
@@ -208,12 +533,15 @@ func (m *ForkManager) getValidatorStoreByIBFTFork(fork *IBFTFork) ValidatorStore
 }
 
 func (m *ForkManager) getKeyManager(height uint64) (signer.KeyManager, error) {
-	fork := m.forks.getFork(height)
+	fork := m.getFork(height)
 	if fork == nil {
 		return nil, ErrForkNotFound
 	}
 
+	m.lock.RLock()
 	keyManager, ok := m.keyManagers[fork.ValidatorType]
+	m.lock.RUnlock()
+
 	if !ok {
 		return nil, ErrKeyManagerNotFound
 	}
@@ -223,7 +551,7 @@ func (m *ForkManager) getKeyManager(height uint64) (signer.KeyManager, error) {
 
 // initializeKeyManagers initialize all key managers based on Fork configuration
 func (m *ForkManager) initializeKeyManagers() error {
-	for _, fork := range m.forks {
+	for _, fork := range m.getForks() {
 		if err := m.initializeKeyManager(fork.ValidatorType); err != nil {
 			return err
 		}
@@ -234,11 +562,24 @@ func (m *ForkManager) initializeKeyManagers() error {
 
 // initializeKeyManager initializes the sp
 func (m *ForkManager) initializeKeyManager(valType validators.ValidatorType) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
 	if _, ok := m.keyManagers[valType]; ok {
 		return nil
 	}
 
-	keyManager, err := signer.NewKeyManagerFromType(m.secretsManager, valType)
+	var (
+		keyManager signer.KeyManager
+		err        error
+	)
+
+	if m.remoteSigner != nil {
+		keyManager, err = signer.NewRemoteKeyManager(m.remoteSigner, valType)
+	} else {
+		keyManager, err = signer.NewKeyManagerFromType(m.secretsManager, valType)
+	}
+
 	if err != nil {
 		return err
 	}
@@ -250,7 +591,7 @@ func (m *ForkManager) initializeKeyManager(valType validators.ValidatorType) err
 
 // initializeValidatorStores initializes all validator sets based on Fork configuration
 func (m *ForkManager) initializeValidatorStores() error {
-	for _, fork := range m.forks {
+	for _, fork := range m.getForks() {
 		sourceType := ibftTypesToSourceType[fork.Type]
 		if err := m.initializeValidatorStore(sourceType); err != nil {
 			return err
@@ -262,10 +603,16 @@ func (m *ForkManager) initializeValidatorStores() error {
 
 // initializeValidatorStore initializes the specified validator set
 func (m *ForkManager) initializeValidatorStore(setType store.SourceType) error {
-	if _, ok := m.validatorStores[setType]; ok {
+	m.lock.RLock()
+	_, ok := m.validatorStores[setType]
+	m.lock.RUnlock()
+
+	if ok {
 		return nil
 	}
 
+	// the lock is not held during the creation because the stores may call GetSigner
+
 	var (
 		valStore ValidatorStore
 		err      error
@@ -278,7 +625,7 @@ func (m *ForkManager) initializeValidatorStore(setType store.SourceType) error {
 			m.blockchain,
 			m.GetSigner,
 			m.filePath,
-			m.epochSize,
+			m.GetEpochSize,
 		)
 	case store.Contract:
 		valStore, err = NewContractValidatorStoreWrapper(
@@ -286,6 +633,7 @@ func (m *ForkManager) initializeValidatorStore(setType store.SourceType) error {
 			m.blockchain,
 			m.executor,
 			m.GetSigner,
+			m.delegation.IsActive,
 		)
 	}
 
@@ -293,33 +641,59 @@ func (m *ForkManager) initializeValidatorStore(setType store.SourceType) error {
 		return err
 	}
 
+	m.lock.Lock()
 	m.validatorStores[setType] = valStore
+	m.lock.Unlock()
 
 	return nil
 }
 
-// initializeHooksRegisters initialize all HookRegisters to be used
+// initializeHooksRegisters initialize all HookRegisters to be used.
+// The registers keep the forks at the creation, so they are recreated for the scheduled forks
 func (m *ForkManager) initializeHooksRegisters() {
-	for _, fork := range m.forks {
-		m.initializeHooksRegister(fork.Type)
+	var (
+		forks               = m.getForks()
+		hooksRegisters      = make(map[IBFTType]HooksRegister)
+		rewardHooksRegister HooksRegister
+	)
+
+	for _, fork := range forks {
+		m.initializeHooksRegister(hooksRegisters, forks, fork.Type)
+
+		if fork.Rewards != nil && rewardHooksRegister == nil {
+			rewardHooksRegister = NewRewardHookRegister(
+				forks,
+				m.getParentCommittedSealSigners,
+				m.delegation,
+			)
+		}
 	}
+
+	m.lock.Lock()
+	m.hooksRegisters = hooksRegisters
+	m.rewardHooksRegister = rewardHooksRegister
+	m.lock.Unlock()
 }
 
 // initializeHooksRegister initialize HookRegister by IBFTType
-func (m *ForkManager) initializeHooksRegister(ibftType IBFTType) {
-	if _, ok := m.hooksRegisters[ibftType]; ok {
+func (m *ForkManager) initializeHooksRegister(
+	hooksRegisters map[IBFTType]HooksRegister,
+	forks IBFTForks,
+	ibftType IBFTType,
+) {
+	if _, ok := hooksRegisters[ibftType]; ok {
 		return
 	}
 
 	switch ibftType {
 	case PoA:
-		m.hooksRegisters[PoA] = NewPoAHookRegisterer(
+		hooksRegisters[PoA] = NewPoAHookRegisterer(
 			m.getValidatorStoreByIBFTFork,
-			m.forks,
+			forks,
 		)
 	case PoS:
-		m.hooksRegisters[PoS] = NewPoSHookRegister(
-			m.forks,
+		hooksRegisters[PoS] = NewPoSHookRegister(
+			forks,
 			m.epochSize,
 		)
 	}
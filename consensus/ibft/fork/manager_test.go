@@ -2,8 +2,10 @@ package fork
 
 import (
 	"errors"
+	"math/big"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/hook"
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
@@ -24,6 +26,7 @@ type mockValidatorStore struct {
 
 	CloseFunc         func() error
 	GetValidatorsFunc func(uint64, uint64, uint64) (validators.Validators, error)
+	RewindFunc        func(uint64) error
 }
 
 func (m *mockValidatorStore) Close() error {
@@ -34,6 +37,20 @@ func (m *mockValidatorStore) GetValidators(height, epoch, from uint64) (validato
 	return m.GetValidatorsFunc(height, epoch, from)
 }
 
+func (m *mockValidatorStore) Rewind(height uint64) error {
+	return m.RewindFunc(height)
+}
+
+type mockVotingPowerStore struct {
+	mockValidatorStore
+
+	GetVotingPowersFunc func(uint64, uint64, uint64) (map[types.Address]*big.Int, error)
+}
+
+func (m *mockVotingPowerStore) GetVotingPowers(height, epoch, from uint64) (map[types.Address]*big.Int, error) {
+	return m.GetVotingPowersFunc(height, epoch, from)
+}
+
 type mockHooksRegister struct {
 	RegisterHooksFunc func(hooks *hook.Hooks, height uint64)
 }
@@ -76,6 +93,7 @@ func TestNewForkManager(t *testing.T) {
 			nil,
 			nil,
 			nil,
+			nil,
 			"",
 			0,
 			map[string]interface{}{},
@@ -105,6 +123,7 @@ func TestNewForkManager(t *testing.T) {
 			nil,
 			nil,
 			secretManager,
+			nil,
 			"",
 			epochSize,
 			map[string]interface{}{
@@ -153,6 +172,7 @@ func TestNewForkManager(t *testing.T) {
 			blockchain,
 			nil,
 			secretManager,
+			nil,
 			dirPath,
 			epochSize,
 			map[string]interface{}{
@@ -234,6 +254,7 @@ func TestNewForkManager(t *testing.T) {
 			blockchain,
 			nil,
 			secretManager,
+			nil,
 			dirPath,
 			epochSize,
 			map[string]interface{}{
@@ -279,6 +300,7 @@ func TestNewForkManager(t *testing.T) {
 			nil,
 			nil,
 			secretManager,
+			nil,
 			"",
 			epochSize,
 			map[string]interface{}{
@@ -712,6 +734,223 @@ func TestForkManagerGetValidators(t *testing.T) {
 	}
 }
 
+func TestForkManagerGetVotingPowers(t *testing.T) {
+	t.Parallel()
+
+	var (
+		epochSize uint64 = 10
+
+		votingPowers = map[types.Address]*big.Int{
+			types.StringToAddress("1"): big.NewInt(100),
+		}
+	)
+
+	tests := []struct {
+		name                 string
+		forks                IBFTForks
+		validatorStores      map[store.SourceType]ValidatorStore
+		height               uint64
+		expectedVotingPowers map[types.Address]*big.Int
+		expectedErr          error
+	}{
+		{
+			name:            "should return ErrForkNotFound if fork not found",
+			forks:           IBFTForks{},
+			validatorStores: map[store.SourceType]ValidatorStore{},
+			height:          25,
+			expectedErr:     ErrForkNotFound,
+		},
+		{
+			name: "should return nil if weighted quorum is disabled",
+			forks: IBFTForks{
+				{
+					Type:          PoS,
+					ValidatorType: validators.ECDSAValidatorType,
+					From:          common.JSONNumber{Value: 0},
+				},
+			},
+			validatorStores: map[store.SourceType]ValidatorStore{},
+			height:          25,
+		},
+		{
+			name: "should return ErrValidatorStoreNotFound if validator store doesn't provide voting powers",
+			forks: IBFTForks{
+				{
+					Type:           PoS,
+					ValidatorType:  validators.ECDSAValidatorType,
+					From:           common.JSONNumber{Value: 0},
+					WeightedQuorum: true,
+				},
+			},
+			validatorStores: map[store.SourceType]ValidatorStore{
+				store.Contract: &mockValidatorStore{},
+			},
+			height:      25,
+			expectedErr: ErrValidatorStoreNotFound,
+		},
+		{
+			name: "should return voting powers",
+			forks: IBFTForks{
+				{
+					Type:           PoS,
+					ValidatorType:  validators.ECDSAValidatorType,
+					From:           common.JSONNumber{Value: 10},
+					WeightedQuorum: true,
+				},
+			},
+			validatorStores: map[store.SourceType]ValidatorStore{
+				store.Contract: &mockVotingPowerStore{
+					GetVotingPowersFunc: func(u1, u2, u3 uint64) (map[types.Address]*big.Int, error) {
+						assert.Equal(t, uint64(25), u1) // height
+						assert.Equal(t, epochSize, u2)  // epochSize
+						assert.Equal(t, uint64(10), u3) // from
+
+						return votingPowers, nil
+					},
+				},
+			},
+			height:               25,
+			expectedVotingPowers: votingPowers,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fm := &ForkManager{
+				forks:           test.forks,
+				validatorStores: test.validatorStores,
+				epochSize:       epochSize,
+			}
+
+			votingPowers, err := fm.GetVotingPowers(test.height)
+
+			assert.Equal(t, test.expectedVotingPowers, votingPowers)
+			assert.Equal(t, test.expectedErr, err)
+		})
+	}
+}
+
+func TestForkManagerTimingParameters(t *testing.T) {
+	t.Parallel()
+
+	var defaultEpochSize uint64 = 10
+
+	fm := &ForkManager{
+		forks: IBFTForks{
+			{
+				Type: PoA,
+				From: common.JSONNumber{Value: 0},
+				To:   &common.JSONNumber{Value: 99},
+			},
+			{
+				Type:         PoA,
+				From:         common.JSONNumber{Value: 100},
+				BlockTime:    &common.JSONNumber{Value: 1},
+				EpochSize:    &common.JSONNumber{Value: 20},
+				RoundTimeout: &common.JSONNumber{Value: 5},
+			},
+		},
+		epochSize: defaultEpochSize,
+	}
+
+	tests := []struct {
+		name                 string
+		height               uint64
+		expectedEpochSize    uint64
+		expectedBlockTime    time.Duration
+		expectedRoundTimeout time.Duration
+	}{
+		{
+			name:                 "should return the default values if the fork doesn't define them",
+			height:               99,
+			expectedEpochSize:    defaultEpochSize,
+			expectedBlockTime:    0,
+			expectedRoundTimeout: 0,
+		},
+		{
+			name:                 "should return the values defined by the fork",
+			height:               100,
+			expectedEpochSize:    20,
+			expectedBlockTime:    time.Second,
+			expectedRoundTimeout: 5 * time.Second,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expectedEpochSize, fm.GetEpochSize(test.height))
+			assert.Equal(t, test.expectedBlockTime, fm.GetBlockTime(test.height))
+			assert.Equal(t, test.expectedRoundTimeout, fm.GetRoundTimeout(test.height))
+		})
+	}
+}
+
+func TestForkManagerGetEpoch(t *testing.T) {
+	t.Parallel()
+
+	// the epoch size changes from 10 to 15 after the block 30, then to 5 after the block 60
+	fm := &ForkManager{
+		forks: IBFTForks{
+			{
+				Type: PoA,
+				From: common.JSONNumber{Value: 0},
+				To:   &common.JSONNumber{Value: 20},
+			},
+			{
+				Type: PoS,
+				From: common.JSONNumber{Value: 21},
+				To:   &common.JSONNumber{Value: 30},
+			},
+			{
+				Type:      PoS,
+				From:      common.JSONNumber{Value: 31},
+				To:        &common.JSONNumber{Value: 60},
+				EpochSize: &common.JSONNumber{Value: 15},
+			},
+			{
+				Type:      PoS,
+				From:      common.JSONNumber{Value: 61},
+				EpochSize: &common.JSONNumber{Value: 5},
+			},
+		},
+		epochSize: 10,
+	}
+
+	assert.NoError(t, fm.forks.validateEpochs(fm.epochSize))
+
+	tests := []struct {
+		height uint64
+		epoch  uint64
+	}{
+		{height: 0, epoch: 0},
+		{height: 1, epoch: 1},
+		{height: 10, epoch: 1},
+		{height: 11, epoch: 2},
+		// the fork not changing the epoch size doesn't need to be aligned
+		{height: 21, epoch: 3},
+		{height: 30, epoch: 3},
+		{height: 31, epoch: 4},
+		{height: 45, epoch: 4},
+		{height: 46, epoch: 5},
+		{height: 60, epoch: 5},
+		{height: 61, epoch: 6},
+		{height: 65, epoch: 6},
+		{height: 66, epoch: 7},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.epoch, fm.GetEpoch(test.height), "height %d", test.height)
+	}
+}
+
 func TestForkManagerGetHooks(t *testing.T) {
 	t.Parallel()
 
@@ -924,3 +1163,266 @@ func TestForkManager_initializeHooksRegisters(t *testing.T) {
 		fm.hooksRegisters[PoS],
 	)
 }
+
+func newTestECDSAKeyManager(t *testing.T) signer.KeyManager {
+	t.Helper()
+
+	key, _, err := crypto.GenerateAndEncodeECDSAPrivateKey()
+	assert.NoError(t, err)
+
+	return signer.NewECDSAKeyManagerFromKey(key)
+}
+
+func TestForkManager_ScheduleFork(t *testing.T) {
+	t.Parallel()
+
+	newForks := func(lastTo *common.JSONNumber) IBFTForks {
+		return IBFTForks{
+			{
+				Type:          PoA,
+				ValidatorType: validators.ECDSAValidatorType,
+				From:          common.JSONNumber{Value: 0},
+				To:            lastTo,
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		forks          IBFTForks
+		fork           *IBFTFork
+		expectedErr    error
+		expectedLastTo uint64
+	}{
+		{
+			name:  "should return error if the fork has the end",
+			forks: newForks(nil),
+			fork: &IBFTFork{
+				Type:          PoS,
+				ValidatorType: validators.ECDSAValidatorType,
+				From:          common.JSONNumber{Value: 10},
+				To:            &common.JSONNumber{Value: 20},
+			},
+			expectedErr: ErrScheduledForkHasEnd,
+		},
+		{
+			name:  "should return error if weighted quorum is enabled in PoA",
+			forks: newForks(nil),
+			fork: &IBFTFork{
+				Type:           PoA,
+				ValidatorType:  validators.ECDSAValidatorType,
+				From:           common.JSONNumber{Value: 10},
+				WeightedQuorum: true,
+			},
+			expectedErr: ErrWeightedQuorumRequiresPoS,
+		},
+		{
+			name:  "should return error if the fork doesn't start after the last fork",
+			forks: newForks(nil),
+			fork: &IBFTFork{
+				Type:          PoS,
+				ValidatorType: validators.ECDSAValidatorType,
+				From:          common.JSONNumber{Value: 0},
+			},
+			expectedErr: ErrScheduledForkNotLater,
+		},
+		{
+			name:  "should return error if the fork doesn't follow the end of the last fork",
+			forks: newForks(&common.JSONNumber{Value: 5}),
+			fork: &IBFTFork{
+				Type:          PoS,
+				ValidatorType: validators.ECDSAValidatorType,
+				From:          common.JSONNumber{Value: 10},
+			},
+			expectedErr: ErrScheduledForkNotLater,
+		},
+		{
+			name:  "should return error if the fork changing the epoch size doesn't start after an epoch",
+			forks: newForks(nil),
+			fork: &IBFTFork{
+				Type:          PoS,
+				ValidatorType: validators.ECDSAValidatorType,
+				From:          common.JSONNumber{Value: 10},
+				EpochSize:     &common.JSONNumber{Value: 3},
+			},
+			expectedErr: ErrMisalignedEpoch,
+		},
+		{
+			name:  "should end the last fork before the scheduled fork",
+			forks: newForks(nil),
+			fork: &IBFTFork{
+				Type:          PoS,
+				ValidatorType: validators.ECDSAValidatorType,
+				From:          common.JSONNumber{Value: 10},
+			},
+			expectedLastTo: 9,
+		},
+		{
+			name:  "should append the fork following the end of the last fork",
+			forks: newForks(&common.JSONNumber{Value: 9}),
+			fork: &IBFTFork{
+				Type:          PoS,
+				ValidatorType: validators.ECDSAValidatorType,
+				From:          common.JSONNumber{Value: 10},
+			},
+			expectedLastTo: 9,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				originalLastFork = *test.forks[len(test.forks)-1]
+				fm               = &ForkManager{
+					forks:     test.forks,
+					epochSize: 5,
+					keyManagers: map[validators.ValidatorType]signer.KeyManager{
+						validators.ECDSAValidatorType: newTestECDSAKeyManager(t),
+					},
+				}
+			)
+
+			err := fm.ScheduleFork(test.fork)
+			assert.ErrorIs(t, err, test.expectedErr)
+
+			// the given forks are never modified
+			assert.Equal(t, originalLastFork, *test.forks[len(test.forks)-1])
+
+			if test.expectedErr != nil {
+				assert.Equal(t, test.forks, fm.forks)
+
+				return
+			}
+
+			assert.Len(t, fm.forks, len(test.forks)+1)
+			assert.Equal(t, test.expectedLastTo, fm.forks[len(test.forks)-1].To.Value)
+			assert.Equal(t, test.fork, fm.getFork(10))
+			assert.Equal(t, fm.forks[0], fm.getFork(9))
+		})
+	}
+}
+
+func TestForkManager_ScheduleForkAfterInitialize(t *testing.T) {
+	t.Parallel()
+
+	fm := &ForkManager{
+		logger:     hclog.NewNullLogger(),
+		blockchain: &store.MockBlockchain{},
+		executor:   &MockExecutor{},
+		forks: IBFTForks{
+			{
+				Type:          PoA,
+				ValidatorType: validators.ECDSAValidatorType,
+				From:          common.JSONNumber{Value: 0},
+			},
+		},
+		keyManagers: map[validators.ValidatorType]signer.KeyManager{
+			validators.ECDSAValidatorType: newTestECDSAKeyManager(t),
+		},
+		validatorStores: map[store.SourceType]ValidatorStore{
+			store.Snapshot: &mockValidatorStore{},
+		},
+		hooksRegisters: map[IBFTType]HooksRegister{},
+		initialized:    true,
+	}
+
+	assert.NoError(t, fm.ScheduleFork(&IBFTFork{
+		Type:          PoS,
+		ValidatorType: validators.ECDSAValidatorType,
+		From:          common.JSONNumber{Value: 10},
+		Rewards: &Rewards{
+			BlockReward:   big.NewInt(1),
+			ProposerShare: 10000,
+		},
+	}))
+
+	assert.NotNil(t, fm.validatorStores[store.Contract])
+	assert.NotNil(t, fm.hooksRegisters[PoA])
+	assert.NotNil(t, fm.hooksRegisters[PoS])
+	assert.NotNil(t, fm.rewardHooksRegister)
+}
+
+func TestForkManager_ResetScheduledForks(t *testing.T) {
+	t.Parallel()
+
+	genesisForks := IBFTForks{
+		{
+			Type:          PoA,
+			ValidatorType: validators.ECDSAValidatorType,
+			From:          common.JSONNumber{Value: 0},
+		},
+	}
+
+	fm := &ForkManager{
+		forks:     genesisForks,
+		epochSize: 5,
+		keyManagers: map[validators.ValidatorType]signer.KeyManager{
+			validators.ECDSAValidatorType: newTestECDSAKeyManager(t),
+		},
+	}
+
+	// nothing happens before any fork is scheduled
+	fm.ResetScheduledForks()
+	assert.Equal(t, genesisForks, fm.forks)
+
+	for _, from := range []uint64{10, 20} {
+		assert.NoError(t, fm.ScheduleFork(&IBFTFork{
+			Type:          PoS,
+			ValidatorType: validators.ECDSAValidatorType,
+			From:          common.JSONNumber{Value: from},
+		}))
+	}
+
+	assert.Len(t, fm.forks, 3)
+
+	fm.ResetScheduledForks()
+	assert.Equal(t, genesisForks, fm.forks)
+	assert.Nil(t, fm.forks[0].To)
+
+	// the fork can be scheduled again after the reset
+	assert.NoError(t, fm.ScheduleFork(&IBFTFork{
+		Type:          PoS,
+		ValidatorType: validators.ECDSAValidatorType,
+		From:          common.JSONNumber{Value: 10},
+	}))
+}
+
+func TestForkManager_Rewind(t *testing.T) {
+	t.Parallel()
+
+	var (
+		rewound = make(map[store.SourceType]uint64)
+		errTest = errors.New("test")
+
+		newStore = func(sourceType store.SourceType, err error) ValidatorStore {
+			return &mockValidatorStore{
+				RewindFunc: func(height uint64) error {
+					rewound[sourceType] = height
+
+					return err
+				},
+			}
+		}
+	)
+
+	fm := &ForkManager{
+		validatorStores: map[store.SourceType]ValidatorStore{
+			store.Snapshot: newStore(store.Snapshot, nil),
+			store.Contract: newStore(store.Contract, nil),
+		},
+	}
+
+	assert.NoError(t, fm.Rewind(10))
+	assert.Equal(t, map[store.SourceType]uint64{
+		store.Snapshot: 10,
+		store.Contract: 10,
+	}, rewound)
+
+	fm.validatorStores[store.Contract] = newStore(store.Contract, errTest)
+
+	assert.ErrorIs(t, fm.Rewind(5), errTest)
+}
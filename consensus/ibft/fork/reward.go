@@ -0,0 +1,263 @@
+package fork
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/hook"
+	"github.com/0xPolygon/polygon-edge/contracts/staking"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	stakingHelper "github.com/0xPolygon/polygon-edge/helper/staking"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// RewardShareDenominator is the denominator of the shares in Rewards, shares are in basis points
+	RewardShareDenominator uint64 = 10000
+)
+
+var (
+	ErrInvalidRewardShares = fmt.Errorf("sum of reward shares must be %d", RewardShareDenominator)
+	ErrMissingTreasury     = errors.New("treasury address is required for non-zero treasury share")
+	ErrInvalidBlockReward  = errors.New("block reward must not be negative")
+)
+
+// Rewards represents the block reward and how the block reward and the fees are split
+// among the proposer, the signers of the committed seals and the treasury
+type Rewards struct {
+	BlockReward   *big.Int      `json:"blockReward"`
+	ProposerShare uint64        `json:"proposerShare"`
+	SignersShare  uint64        `json:"signersShare"`
+	TreasuryShare uint64        `json:"treasuryShare"`
+	Treasury      types.Address `json:"treasury"`
+}
+
+func (r *Rewards) MarshalJSON() ([]byte, error) {
+	blockReward := "0x0"
+	if r.BlockReward != nil {
+		blockReward = hex.EncodeBig(r.BlockReward)
+	}
+
+	return json.Marshal(&struct {
+		BlockReward   string        `json:"blockReward"`
+		ProposerShare uint64        `json:"proposerShare"`
+		SignersShare  uint64        `json:"signersShare"`
+		TreasuryShare uint64        `json:"treasuryShare"`
+		Treasury      types.Address `json:"treasury"`
+	}{
+		BlockReward:   blockReward,
+		ProposerShare: r.ProposerShare,
+		SignersShare:  r.SignersShare,
+		TreasuryShare: r.TreasuryShare,
+		Treasury:      r.Treasury,
+	})
+}
+
+func (r *Rewards) UnmarshalJSON(data []byte) error {
+	raw := struct {
+		BlockReward   *string       `json:"blockReward,omitempty"`
+		ProposerShare uint64        `json:"proposerShare"`
+		SignersShare  uint64        `json:"signersShare"`
+		TreasuryShare uint64        `json:"treasuryShare"`
+		Treasury      types.Address `json:"treasury"`
+	}{}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	blockReward, err := types.ParseUint256orHex(raw.BlockReward)
+	if err != nil {
+		return err
+	}
+
+	if blockReward == nil {
+		blockReward = big.NewInt(0)
+	}
+
+	r.BlockReward = blockReward
+	r.ProposerShare = raw.ProposerShare
+	r.SignersShare = raw.SignersShare
+	r.TreasuryShare = raw.TreasuryShare
+	r.Treasury = raw.Treasury
+
+	return r.validate()
+}
+
+// validate checks the shares add up and the treasury is set if it receives a share
+func (r *Rewards) validate() error {
+	if r.BlockReward != nil && r.BlockReward.Sign() < 0 {
+		return ErrInvalidBlockReward
+	}
+
+	if r.ProposerShare+r.SignersShare+r.TreasuryShare != RewardShareDenominator {
+		return ErrInvalidRewardShares
+	}
+
+	if r.TreasuryShare > 0 && r.Treasury == types.ZeroAddress {
+		return ErrMissingTreasury
+	}
+
+	return nil
+}
+
+// share returns the given share of the amount
+func share(amount *big.Int, share uint64) *big.Int {
+	res := new(big.Int).Mul(amount, new(big.Int).SetUint64(share))
+
+	return res.Div(res, new(big.Int).SetUint64(RewardShareDenominator))
+}
+
+// registerRewardHooks registers the hook to mint the block reward and to distribute
+// the block reward and the fees, which are withheld from the proposer at the heights with the rewards. It needs to be registered after the other hooks
+// because it wraps the existing PreCommitState hook
+func registerRewardHooks(
+	hooks *hook.Hooks,
+	rewards *Rewards,
+	getSealSigners func(*types.Header) ([]types.Address, error),
+	delegation *Activation,
+) {
+	preCommitState := hooks.PreCommitStateFunc
+
+	hooks.PreCommitStateFunc = func(header *types.Header, txn *state.Transition) error {
+		if preCommitState != nil {
+			if err := preCommitState(header, txn); err != nil {
+				return err
+			}
+		}
+
+		// the seals of the block are not known during its execution,
+		// so the signers of the parent block are rewarded
+		signers, err := getSealSigners(header)
+		if err != nil {
+			return err
+		}
+
+		distributeRewards(txn, rewards, signers, delegation.IsActive(header.Number))
+
+		return nil
+	}
+}
+
+// distributeRewards mints the block reward and distributes it with the fees withheld from the proposer.
+// The shares of the signers and the treasury are credited to them, the rest is credited to the proposer.
+// If the delegation is enabled, the share of each signer is split with its delegators.
+// The remainders of the divisions are left to the proposer
+func distributeRewards(
+	txn *state.Transition,
+	rewards *Rewards,
+	signers []types.Address,
+	delegation bool,
+) {
+	var (
+		stateTxn = txn.Txn()
+		proposer = txn.GetTxContext().Coinbase
+		total    = txn.TotalFees()
+	)
+
+	if rewards.BlockReward != nil {
+		total.Add(total, rewards.BlockReward)
+	}
+
+	credits := make(map[types.Address]*big.Int)
+	credit := func(addr types.Address, amount *big.Int) {
+		if c, ok := credits[addr]; ok {
+			c.Add(c, amount)
+
+			return
+		}
+
+		credits[addr] = new(big.Int).Set(amount)
+	}
+
+	proposerShare := new(big.Int).Set(total)
+
+	if rewards.TreasuryShare > 0 {
+		treasuryShare := share(total, rewards.TreasuryShare)
+
+		credit(rewards.Treasury, treasuryShare)
+		proposerShare.Sub(proposerShare, treasuryShare)
+	}
+
+	if len(signers) > 0 && rewards.SignersShare > 0 {
+		perSigner := new(big.Int).Div(
+			share(total, rewards.SignersShare),
+			big.NewInt(int64(len(signers))),
+		)
+
+		for _, signer := range signers {
+			signerShares := map[types.Address]*big.Int{signer: perSigner}
+			if delegation {
+				signerShares = splitWithDelegators(txn, signer, perSigner)
+			}
+
+			for addr, amount := range signerShares {
+				credit(addr, amount)
+			}
+
+			proposerShare.Sub(proposerShare, perSigner)
+		}
+	}
+
+	credit(proposer, proposerShare)
+
+	// sort the recipients to update the state in the same order on all nodes
+	recipients := make([]types.Address, 0, len(credits))
+	for addr := range credits {
+		recipients = append(recipients, addr)
+	}
+
+	sort.Slice(recipients, func(i, j int) bool {
+		return bytes.Compare(recipients[i].Bytes(), recipients[j].Bytes()) < 0
+	})
+
+	for _, addr := range recipients {
+		if amount := credits[addr]; amount.Sign() > 0 {
+			stateTxn.AddBalance(addr, amount)
+		}
+	}
+}
+
+// splitWithDelegators splits the reward of the validator among the validator and its delegators
+// in proportion to the stakes in the staking contract. The remainders of the divisions are left to the validator
+func splitWithDelegators(
+	host stakingHelper.StorageHost,
+	validator types.Address,
+	amount *big.Int,
+) map[types.Address]*big.Int {
+	var (
+		shares         = map[types.Address]*big.Int{}
+		delegatedStake = stakingHelper.GetDelegatedStake(host, staking.AddrStakingContract, validator)
+		totalStake     = new(big.Int).Add(
+			stakingHelper.GetStakedAmount(host, staking.AddrStakingContract, validator),
+			delegatedStake,
+		)
+		validatorShare = new(big.Int).Set(amount)
+	)
+
+	if delegatedStake.Sign() > 0 {
+		for _, delegator := range stakingHelper.GetDelegators(host, staking.AddrStakingContract, validator) {
+			delegation := stakingHelper.GetDelegation(host, staking.AddrStakingContract, delegator, validator)
+
+			delegatorShare := new(big.Int).Mul(amount, delegation)
+			delegatorShare.Div(delegatorShare, totalStake)
+
+			shares[delegator] = delegatorShare
+			validatorShare.Sub(validatorShare, delegatorShare)
+		}
+	}
+
+	// a validator may delegate to itself
+	if share, ok := shares[validator]; ok {
+		validatorShare.Add(validatorShare, share)
+	}
+
+	shares[validator] = validatorShare
+
+	return shares
+}
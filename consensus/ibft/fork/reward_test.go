@@ -0,0 +1,444 @@
+package fork
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/hook"
+	"github.com/0xPolygon/polygon-edge/contracts/staking"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	stakingHelper "github.com/0xPolygon/polygon-edge/helper/staking"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewardsUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		data     string
+		expected *Rewards
+		err      error
+	}{
+		{
+			name: "should parse hex block reward",
+			data: `{
+				"blockReward": "0x64",
+				"proposerShare": 5000,
+				"signersShare": 3000,
+				"treasuryShare": 2000,
+				"treasury": "0x0000000000000000000000000000000000000010"
+			}`,
+			expected: &Rewards{
+				BlockReward:   big.NewInt(100),
+				ProposerShare: 5000,
+				SignersShare:  3000,
+				TreasuryShare: 2000,
+				Treasury:      types.StringToAddress("0x10"),
+			},
+		},
+		{
+			name: "should parse decimal block reward",
+			data: `{
+				"blockReward": "1000000000000000000",
+				"proposerShare": 10000
+			}`,
+			expected: &Rewards{
+				BlockReward:   big.NewInt(1000000000000000000),
+				ProposerShare: 10000,
+			},
+		},
+		{
+			name: "should default block reward to zero",
+			data: `{
+				"proposerShare": 5000,
+				"signersShare": 5000
+			}`,
+			expected: &Rewards{
+				BlockReward:   big.NewInt(0),
+				ProposerShare: 5000,
+				SignersShare:  5000,
+			},
+		},
+		{
+			name: "should return error if the sum of shares is not 10000",
+			data: `{
+				"proposerShare": 5000,
+				"signersShare": 3000
+			}`,
+			err: ErrInvalidRewardShares,
+		},
+		{
+			name: "should return error if treasury is missing",
+			data: `{
+				"proposerShare": 5000,
+				"treasuryShare": 5000
+			}`,
+			err: ErrMissingTreasury,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			rewards := &Rewards{}
+
+			err := json.Unmarshal([]byte(test.data), rewards)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, rewards)
+		})
+	}
+}
+
+func TestIBFTForkUnmarshalJSONWithRewards(t *testing.T) {
+	t.Parallel()
+
+	fork := &IBFTFork{}
+
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"type": "PoA",
+		"from": "0x0",
+		"rewards": {
+			"blockReward": "0x1",
+			"proposerShare": 10000
+		}
+	}`), fork))
+
+	assert.Equal(t, &Rewards{
+		BlockReward:   big.NewInt(1),
+		ProposerShare: 10000,
+	}, fork.Rewards)
+}
+
+func Test_registerRewardHooks(t *testing.T) {
+	t.Parallel()
+
+	var (
+		proposer = types.StringToAddress("0x1")
+		signer1  = types.StringToAddress("0x2")
+		signer2  = types.StringToAddress("0x3")
+		treasury = types.StringToAddress("0x4")
+
+		errGetSigners = errors.New("failed to get signers")
+	)
+
+	tests := []struct {
+		name     string
+		rewards  *Rewards
+		signers  []types.Address
+		err      error
+		expected map[types.Address]int64
+	}{
+		{
+			name: "should mint block reward to the proposer",
+			rewards: &Rewards{
+				BlockReward:   big.NewInt(1000),
+				ProposerShare: 10000,
+			},
+			signers: []types.Address{signer1, signer2},
+			expected: map[types.Address]int64{
+				proposer: 1000,
+				signer1:  0,
+				signer2:  0,
+			},
+		},
+		{
+			name: "should split block reward among proposer, signers and treasury",
+			rewards: &Rewards{
+				BlockReward:   big.NewInt(1001),
+				ProposerShare: 5000,
+				SignersShare:  3000,
+				TreasuryShare: 2000,
+				Treasury:      treasury,
+			},
+			signers: []types.Address{signer1, signer2},
+			// signers share 300.3 is rounded down to 300, 150 for each signer
+			// treasury share 200.2 is rounded down to 200
+			expected: map[types.Address]int64{
+				proposer: 501,
+				signer1:  150,
+				signer2:  150,
+				treasury: 200,
+			},
+		},
+		{
+			name: "should reward the proposer being a signer as both",
+			rewards: &Rewards{
+				BlockReward:   big.NewInt(1000),
+				ProposerShare: 5000,
+				SignersShare:  5000,
+			},
+			signers: []types.Address{proposer, signer1},
+			expected: map[types.Address]int64{
+				proposer: 750,
+				signer1:  250,
+			},
+		},
+		{
+			name: "should give signers share to the proposer if no signers",
+			rewards: &Rewards{
+				BlockReward:   big.NewInt(1000),
+				ProposerShare: 5000,
+				SignersShare:  5000,
+			},
+			signers: nil,
+			expected: map[types.Address]int64{
+				proposer: 1000,
+			},
+		},
+		{
+			name: "should return error if signers can't be fetched",
+			rewards: &Rewards{
+				BlockReward:   big.NewInt(1000),
+				ProposerShare: 10000,
+			},
+			err: errGetSigners,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				hooks               = &hook.Hooks{}
+				calledPreCommitHook = false
+			)
+
+			// existing hook should be called as well
+			hooks.PreCommitStateFunc = func(*types.Header, *state.Transition) error {
+				calledPreCommitHook = true
+
+				return nil
+			}
+
+			registerRewardHooks(hooks, test.rewards, func(*types.Header) ([]types.Address, error) {
+				return test.signers, test.err
+			}, nil)
+
+			txn := newTestTransition(t)
+			txn.ContextPtr().Coinbase = proposer
+
+			err := hooks.PreCommitState(&types.Header{Number: 10}, txn)
+
+			assert.True(t, calledPreCommitHook)
+			assert.ErrorIs(t, err, test.err)
+
+			for addr, balance := range test.expected {
+				assert.Equal(t, big.NewInt(balance), txn.GetBalance(addr), addr.String())
+			}
+		})
+	}
+}
+
+func Test_registerRewardHooks_WithheldFees(t *testing.T) {
+	t.Parallel()
+
+	var (
+		proposer = types.StringToAddress("0x1")
+		signer   = types.StringToAddress("0x2")
+		treasury = types.StringToAddress("0x3")
+		sender   = types.StringToAddress("0x4")
+		receiver = types.StringToAddress("0x5")
+	)
+
+	st := itrie.NewState(itrie.NewMemoryStorage())
+	ex := state.NewExecutor(&chain.Params{
+		Forks: chain.AllForksEnabled,
+	}, st, hclog.NewNullLogger())
+
+	rootHash := ex.WriteGenesis(map[types.Address]*chain.GenesisAccount{
+		sender: {Balance: big.NewInt(1000000)},
+	})
+	ex.GetHash = func(*types.Header) state.GetHashByNumber {
+		return func(uint64) types.Hash {
+			return rootHash
+		}
+	}
+	ex.WithholdFees = func(*types.Header) bool {
+		return true
+	}
+
+	txn, err := ex.BeginTxn(rootHash, &types.Header{Number: 10, GasLimit: 100000}, proposer)
+	assert.NoError(t, err)
+
+	// the fee is 21000 * 10 = 210000
+	assert.NoError(t, txn.Write(&types.Transaction{
+		From:     sender,
+		To:       &receiver,
+		Value:    big.NewInt(1),
+		Gas:      21000,
+		GasPrice: big.NewInt(10),
+	}))
+
+	assert.Equal(t, big.NewInt(210000), txn.TotalFees())
+	assert.Equal(t, big.NewInt(0), txn.GetBalance(proposer))
+
+	hooks := &hook.Hooks{}
+	registerRewardHooks(hooks, &Rewards{
+		BlockReward:   big.NewInt(1000),
+		ProposerShare: 5000,
+		SignersShare:  3000,
+		TreasuryShare: 2000,
+		Treasury:      treasury,
+	}, func(*types.Header) ([]types.Address, error) {
+		return []types.Address{signer}, nil
+	}, nil)
+
+	assert.NoError(t, hooks.PreCommitState(&types.Header{Number: 10}, txn))
+
+	// the total 211000 is split 50%, 30% and 20%
+	assert.Equal(t, big.NewInt(105500), txn.GetBalance(proposer))
+	assert.Equal(t, big.NewInt(63300), txn.GetBalance(signer))
+	assert.Equal(t, big.NewInt(42200), txn.GetBalance(treasury))
+}
+
+func TestRewardHookRegister(t *testing.T) {
+	t.Parallel()
+
+	forks := IBFTForks{
+		{
+			Type: PoA,
+			From: common.JSONNumber{Value: 0},
+			To:   &common.JSONNumber{Value: 9},
+		},
+		{
+			Type: PoA,
+			From: common.JSONNumber{Value: 10},
+			Rewards: &Rewards{
+				BlockReward:   big.NewInt(1),
+				ProposerShare: 10000,
+			},
+		},
+	}
+
+	register := NewRewardHookRegister(forks, func(*types.Header) ([]types.Address, error) {
+		return nil, nil
+	}, nil)
+
+	hooks := &hook.Hooks{}
+	register.RegisterHooks(hooks, 9)
+	assert.Nil(t, hooks.PreCommitStateFunc)
+
+	register.RegisterHooks(hooks, 10)
+	assert.NotNil(t, hooks.PreCommitStateFunc)
+}
+
+type mockStakingStorage map[types.Hash]types.Hash
+
+func (m mockStakingStorage) GetStorage(_ types.Address, key types.Hash) types.Hash {
+	return m[key]
+}
+
+func (m mockStakingStorage) SetStorage(
+	_ types.Address,
+	key types.Hash,
+	value types.Hash,
+	_ *chain.ForksInTime,
+) runtime.StorageStatus {
+	m[key] = value
+
+	return runtime.StorageModified
+}
+
+func Test_splitWithDelegators(t *testing.T) {
+	t.Parallel()
+
+	var (
+		validator  = types.StringToAddress("1")
+		delegator1 = types.StringToAddress("2")
+		delegator2 = types.StringToAddress("3")
+		// same as the default staked balance of the predeployed validators
+		stake = big.NewInt(0).Mul(big.NewInt(10), big.NewInt(1e18))
+	)
+
+	tests := []struct {
+		name        string
+		delegations map[types.Address]*big.Int
+		amount      *big.Int
+		expected    map[types.Address]*big.Int
+	}{
+		{
+			name:        "should give all to the validator without delegations",
+			delegations: map[types.Address]*big.Int{},
+			amount:      big.NewInt(41),
+			expected: map[types.Address]*big.Int{
+				validator: big.NewInt(41),
+			},
+		},
+		{
+			name: "should split in proportion to the stakes and leave the remainder to the validator",
+			delegations: map[types.Address]*big.Int{
+				delegator1: stake,
+				delegator2: new(big.Int).Mul(stake, big.NewInt(2)),
+			},
+			amount: big.NewInt(41),
+			expected: map[types.Address]*big.Int{
+				validator:  big.NewInt(11),
+				delegator1: big.NewInt(10),
+				delegator2: big.NewInt(20),
+			},
+		},
+		{
+			name: "should add the self delegation to the validator",
+			delegations: map[types.Address]*big.Int{
+				validator: stake,
+			},
+			amount: big.NewInt(40),
+			expected: map[types.Address]*big.Int{
+				validator: big.NewInt(40),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			vals := validators.NewECDSAValidatorSet(validators.NewECDSAValidator(validator))
+
+			account, err := stakingHelper.PredeployStakingSC(vals, stakingHelper.PredeployParams{
+				MinValidatorCount: 1,
+				MaxValidatorCount: 10,
+			})
+			assert.NoError(t, err)
+
+			host := mockStakingStorage(account.Storage)
+
+			for delegator, amount := range test.delegations {
+				assert.NoError(t, stakingHelper.Delegate(
+					host,
+					staking.AddrStakingContract,
+					delegator,
+					validator,
+					amount,
+					&chain.ForksInTime{},
+				))
+			}
+
+			assert.Equal(t, test.expected, splitWithDelegators(host, validator, test.amount))
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package fork
+
+import (
+	"crypto/ecdsa"
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/0xPolygon/polygon-edge/secrets/helper"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/0xPolygon/polygon-edge/validators/store/snapshot"
+	"github.com/coinbase/kryptology/pkg/signatures/bls/bls_sig"
+)
+
+var (
+	ErrKeyRotationNotSupported = errors.New("key rotation is supported only by the snapshot validator store")
+	ErrKeyRotationRemoteSigner = errors.New("keys of the remote signer need to be rotated in the remote signer")
+	ErrKeyRotationSigningKey   = errors.New("validator key kept by the secrets manager needs to be rotated in the secrets manager")
+	ErrNoStagedKey             = errors.New("staged validator key not found")
+)
+
+// KeyRotatable is an interface of the ValidatorStore that records the key rotations of the validators
+type KeyRotatable interface {
+	// ProposeRotation sets the key rotation of the local validator to be proposed
+	ProposeRotation(*snapshot.KeyRotation) error
+}
+
+// stagedKeyPairs are the secrets replaced by the staged secrets after the key rotation is applied
+var stagedKeyPairs = [][2]string{
+	{secrets.ValidatorKey, secrets.ValidatorKeyStaged},
+	{secrets.ValidatorBLSKey, secrets.ValidatorBLSKeyStaged},
+}
+
+// CreateKeyRotation creates the key rotation to the staged keys in SecretsManager
+// and passes it to the validator store so that the validator proposes it in the next block
+func (m *ForkManager) CreateKeyRotation(height uint64) (*snapshot.KeyRotation, error) {
+	if m.remoteSigner != nil {
+		return nil, ErrKeyRotationRemoteSigner
+	}
+
+	if _, ok := secrets.GetSigner(m.secretsManager, secrets.ValidatorKey); ok {
+		return nil, ErrKeyRotationSigningKey
+	}
+
+	fork := m.getFork(height)
+	if fork == nil {
+		return nil, ErrForkNotFound
+	}
+
+	rotatable, ok := m.getValidatorStoreByIBFTFork(fork).(KeyRotatable)
+	if !ok {
+		return nil, ErrKeyRotationNotSupported
+	}
+
+	keyManager, err := m.getKeyManager(height)
+	if err != nil {
+		return nil, err
+	}
+
+	newValidator, newECDSAKey, blsKey, err := m.loadStagedValidator(fork.ValidatorType)
+	if err != nil {
+		return nil, err
+	}
+
+	rotation, err := snapshot.NewKeyRotation(keyManager.Address(), newValidator, newECDSAKey, blsKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rotatable.ProposeRotation(rotation); err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	m.stagedValidator = newValidator
+	m.stagedValidatorLoaded = true
+	m.lock.Unlock()
+
+	return rotation, nil
+}
+
+// PromoteStagedKeys replaces the validator keys by the staged keys
+// once the validators at the given height have the staged validator.
+// It returns true if the keys are replaced
+func (m *ForkManager) PromoteStagedKeys(height uint64) (bool, error) {
+	if err := m.initializeStagedValidator(height); err != nil {
+		return false, err
+	}
+
+	m.lock.RLock()
+	stagedValidator := m.stagedValidator
+	m.lock.RUnlock()
+
+	if stagedValidator == nil {
+		return false, nil
+	}
+
+	vals, err := m.GetValidators(height)
+	if err != nil {
+		return false, err
+	}
+
+	index := vals.Index(stagedValidator.Addr())
+	if index == -1 || !vals.At(uint64(index)).Equal(stagedValidator) {
+		return false, nil
+	}
+
+	for _, pair := range stagedKeyPairs {
+		if pair[1] == secrets.ValidatorBLSKeyStaged && stagedValidator.Type() != validators.BLSValidatorType {
+			continue
+		}
+
+		if err := helper.PromoteStagedValidatorKey(m.secretsManager, pair[0], pair[1]); err != nil {
+			return false, err
+		}
+	}
+
+	// create the new key managers before replacing not to lose the key managers in the middle
+	keyManagers := make(map[validators.ValidatorType]signer.KeyManager)
+
+	for _, fork := range m.getForks() {
+		if _, ok := keyManagers[fork.ValidatorType]; ok {
+			continue
+		}
+
+		if keyManagers[fork.ValidatorType], err = signer.NewKeyManagerFromType(
+			m.secretsManager,
+			fork.ValidatorType,
+		); err != nil {
+			return false, err
+		}
+	}
+
+	m.lock.Lock()
+	m.keyManagers = keyManagers
+	m.stagedValidator = nil
+	m.lock.Unlock()
+
+	m.logger.Info("validator keys have been rotated", "address", stagedValidator.Addr())
+
+	return true, nil
+}
+
+// recoverStagedKeys completes the promotion of the staged keys interrupted before the restart.
+// The validator key is missing only if the promotion stopped after removing it
+func (m *ForkManager) recoverStagedKeys() error {
+	if m.remoteSigner != nil || m.secretsManager == nil {
+		return nil
+	}
+
+	hasBLSFork := false
+
+	for _, fork := range m.getForks() {
+		if fork.ValidatorType == validators.BLSValidatorType {
+			hasBLSFork = true
+		}
+	}
+
+	for _, pair := range stagedKeyPairs {
+		if pair[1] == secrets.ValidatorBLSKeyStaged && !hasBLSFork {
+			continue
+		}
+
+		if m.secretsManager.HasSecret(pair[0]) || !m.secretsManager.HasSecret(pair[1]) {
+			continue
+		}
+
+		if err := helper.PromoteStagedValidatorKey(m.secretsManager, pair[0], pair[1]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// initializeStagedValidator loads the validator with the staged keys once
+// so that the keys are promoted after the key rotation created before the restart is applied
+func (m *ForkManager) initializeStagedValidator(height uint64) error {
+	m.lock.RLock()
+	loaded := m.stagedValidatorLoaded
+	m.lock.RUnlock()
+
+	if loaded {
+		return nil
+	}
+
+	var stagedValidator validators.Validator
+
+	if fork := m.getFork(height); fork != nil && m.remoteSigner == nil && m.secretsManager != nil {
+		var err error
+
+		stagedValidator, _, _, err = m.loadStagedValidator(fork.ValidatorType)
+		if err != nil && !errors.Is(err, ErrNoStagedKey) {
+			return err
+		}
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	// CreateKeyRotation may have set it in the meantime
+	if !m.stagedValidatorLoaded {
+		m.stagedValidator = stagedValidator
+		m.stagedValidatorLoaded = true
+	}
+
+	return nil
+}
+
+// loadStagedValidator returns the validator with the staged keys,
+// the staged ECDSA key if it's staged and the BLS key of the validator for BLS validator type
+func (m *ForkManager) loadStagedValidator(
+	valType validators.ValidatorType,
+) (validators.Validator, *ecdsa.PrivateKey, *bls_sig.SecretKey, error) {
+	var (
+		hasStagedECDSAKey = m.secretsManager.HasSecret(secrets.ValidatorKeyStaged)
+		hasStagedBLSKey   = valType == validators.BLSValidatorType &&
+			m.secretsManager.HasSecret(secrets.ValidatorBLSKeyStaged)
+	)
+
+	if !hasStagedECDSAKey && !hasStagedBLSKey {
+		return nil, nil, nil, ErrNoStagedKey
+	}
+
+	var (
+		address     types.Address
+		newECDSAKey *ecdsa.PrivateKey
+	)
+
+	if hasStagedECDSAKey {
+		key, err := m.loadECDSAKey(secrets.ValidatorKeyStaged)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		newECDSAKey = key
+		address = crypto.PubKeyToAddress(&key.PublicKey)
+	} else {
+		key, err := m.loadECDSAKey(secrets.ValidatorKey)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		address = crypto.PubKeyToAddress(&key.PublicKey)
+	}
+
+	switch valType {
+	case validators.ECDSAValidatorType:
+		return validators.NewECDSAValidator(address), newECDSAKey, nil, nil
+	case validators.BLSValidatorType:
+		name := secrets.ValidatorBLSKey
+		if hasStagedBLSKey {
+			name = secrets.ValidatorBLSKeyStaged
+		}
+
+		blsKey, err := m.loadBLSKey(name)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		blsPubKey, err := crypto.BLSSecretKeyToPubkeyBytes(blsKey)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		return validators.NewBLSValidator(address, blsPubKey), newECDSAKey, blsKey, nil
+	default:
+		return nil, nil, nil, validators.ErrInvalidValidatorType
+	}
+}
+
+func (m *ForkManager) loadECDSAKey(name string) (*ecdsa.PrivateKey, error) {
+	keyBytes, err := m.secretsManager.GetSecret(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.BytesToECDSAPrivateKey(keyBytes)
+}
+
+func (m *ForkManager) loadBLSKey(name string) (*bls_sig.SecretKey, error) {
+	keyBytes, err := m.secretsManager.GetSecret(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.BytesToBLSSecretKey(keyBytes)
+}
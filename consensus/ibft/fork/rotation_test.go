@@ -0,0 +1,117 @@
+package fork
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/0xPolygon/polygon-edge/secrets/helper"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/0xPolygon/polygon-edge/validators/store"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForkManager_PromoteStagedKeys(t *testing.T) {
+	t.Parallel()
+
+	secretsManager, err := helper.SetupLocalSecretsManager(createTestTempDirectory(t))
+	assert.NoError(t, err)
+
+	oldAddr, err := helper.InitECDSAValidatorKey(secretsManager)
+	assert.NoError(t, err)
+
+	newAddr, err := helper.StageECDSAValidatorKey(secretsManager)
+	assert.NoError(t, err)
+
+	stagedKey, err := secretsManager.GetSecret(secrets.ValidatorKeyStaged)
+	assert.NoError(t, err)
+
+	// the staged validator joins the set at height 20
+	getValidators := func(height, _, _ uint64) (validators.Validators, error) {
+		addr := oldAddr
+		if height >= 20 {
+			addr = newAddr
+		}
+
+		return validators.NewECDSAValidatorSet(
+			validators.NewECDSAValidator(addr),
+			validators.NewECDSAValidator(types.StringToAddress("1")),
+		), nil
+	}
+
+	fm := &ForkManager{
+		logger: hclog.NewNullLogger(),
+		forks: IBFTForks{
+			{
+				Type:          PoA,
+				ValidatorType: validators.ECDSAValidatorType,
+				From:          common.JSONNumber{Value: 0},
+			},
+		},
+		validatorStores: map[store.SourceType]ValidatorStore{
+			store.Snapshot: &mockValidatorStore{
+				GetValidatorsFunc: getValidators,
+			},
+		},
+		secretsManager: secretsManager,
+		keyManagers:    map[validators.ValidatorType]signer.KeyManager{},
+		epochSize:      10,
+	}
+
+	assert.NoError(t, fm.initializeKeyManagers())
+
+	promoted, err := fm.PromoteStagedKeys(19)
+
+	assert.NoError(t, err)
+	assert.False(t, promoted)
+	assert.True(t, secretsManager.HasSecret(secrets.ValidatorKeyStaged))
+
+	promoted, err = fm.PromoteStagedKeys(20)
+
+	assert.NoError(t, err)
+	assert.True(t, promoted)
+	assert.False(t, secretsManager.HasSecret(secrets.ValidatorKeyStaged))
+
+	validatorKey, err := secretsManager.GetSecret(secrets.ValidatorKey)
+	assert.NoError(t, err)
+	assert.Equal(t, stagedKey, validatorKey)
+
+	keyManager, err := fm.getKeyManager(20)
+	assert.NoError(t, err)
+	assert.Equal(t, newAddr, keyManager.Address())
+}
+
+func TestForkManager_recoverStagedKeys(t *testing.T) {
+	t.Parallel()
+
+	secretsManager, err := helper.SetupLocalSecretsManager(createTestTempDirectory(t))
+	assert.NoError(t, err)
+
+	_, err = helper.StageECDSAValidatorKey(secretsManager)
+	assert.NoError(t, err)
+
+	stagedKey, err := secretsManager.GetSecret(secrets.ValidatorKeyStaged)
+	assert.NoError(t, err)
+
+	// the promotion stopped after the validator key has been removed
+	fm := &ForkManager{
+		forks: IBFTForks{
+			{
+				Type:          PoA,
+				ValidatorType: validators.ECDSAValidatorType,
+				From:          common.JSONNumber{Value: 0},
+			},
+		},
+		secretsManager: secretsManager,
+	}
+
+	assert.NoError(t, fm.recoverStagedKeys())
+
+	validatorKey, err := secretsManager.GetSecret(secrets.ValidatorKey)
+	assert.NoError(t, err)
+	assert.Equal(t, stagedKey, validatorKey)
+	assert.False(t, secretsManager.HasSecret(secrets.ValidatorKeyStaged))
+}
@@ -1,9 +1,11 @@
 package fork
 
 import (
+	"math/big"
 	"path/filepath"
 
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
+	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/validators"
 	"github.com/0xPolygon/polygon-edge/validators/store"
 	"github.com/0xPolygon/polygon-edge/validators/store/contract"
@@ -49,7 +51,7 @@ func NewSnapshotValidatorStoreWrapper(
 	blockchain store.HeaderGetter,
 	getSigner func(uint64) (signer.Signer, error),
 	dirPath string,
-	epochSize uint64,
+	getEpochSize func(uint64) uint64,
 ) (*SnapshotValidatorStoreWrapper, error) {
 	snapshotMeta, err := loadSnapshotMetadata(filepath.Join(dirPath, snapshotMetadataFilename))
 	if err != nil {
@@ -72,7 +74,7 @@ func NewSnapshotValidatorStoreWrapper(
 
 			return snapshot.SignerInterface(rawSigner), nil
 		},
-		epochSize,
+		getEpochSize,
 		snapshotMeta,
 		snapshots,
 	)
@@ -100,12 +102,14 @@ func NewContractValidatorStoreWrapper(
 	blockchain store.HeaderGetter,
 	executor contract.Executor,
 	getSigner func(uint64) (signer.Signer, error),
+	delegation func(height uint64) bool,
 ) (*ContractValidatorStoreWrapper, error) {
 	contractStore, err := contract.NewContractValidatorStore(
 		logger,
 		blockchain,
 		executor,
 		contract.DefaultValidatorSetCacheSize,
+		delegation,
 	)
 
 	if err != nil {
@@ -142,6 +146,25 @@ func (w *ContractValidatorStoreWrapper) GetValidators(
 	)
 }
 
+// GetVotingPowers gets and returns the voting powers of the validators at the given height
+func (w *ContractValidatorStoreWrapper) GetVotingPowers(
+	height, epochSize, forkFrom uint64,
+) (map[types.Address]*big.Int, error) {
+	signer, err := w.getSigner(height)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.GetVotingPowersByHeight(
+		signer.Type(),
+		calculateContractStoreFetchingHeight(
+			height,
+			epochSize,
+			forkFrom,
+		),
+	)
+}
+
 // calculateContractStoreFetchingHeight calculates the block height at which ContractStore fetches validators
 // based on height, epoch, and fork beginning height
 func calculateContractStoreFetchingHeight(height, epochSize, forkFrom uint64) uint64 {
@@ -127,7 +127,9 @@ func TestSnapshotValidatorStoreWrapper(t *testing.T) {
 					return test.signer, nil
 				},
 				dirPath,
-				test.epochSize,
+				func(uint64) uint64 {
+					return test.epochSize
+				},
 			)
 
 			testHelper.AssertErrorMessageContains(
@@ -177,7 +179,9 @@ func TestSnapshotValidatorStoreWrapperGetValidators(t *testing.T) {
 		func(u uint64) (snapshot.SignerInterface, error) {
 			return nil, nil
 		},
-		epochSize,
+		func(uint64) uint64 {
+			return epochSize
+		},
 		metadata,
 		snapshots,
 	)
@@ -225,7 +229,9 @@ func TestSnapshotValidatorStoreWrapperClose(t *testing.T) {
 		func(u uint64) (snapshot.SignerInterface, error) {
 			return nil, nil
 		},
-		epochSize,
+		func(uint64) uint64 {
+			return epochSize
+		},
 		metadata,
 		snapshots,
 	)
@@ -274,6 +280,7 @@ func TestNewContractValidatorStoreWrapper(t *testing.T) {
 		func(u uint64) (signer.Signer, error) {
 			return nil, nil
 		},
+		nil,
 	)
 
 	assert.NoError(t, err)
@@ -289,6 +296,7 @@ func TestNewContractValidatorStoreWrapperClose(t *testing.T) {
 		func(u uint64) (signer.Signer, error) {
 			return nil, nil
 		},
+		nil,
 	)
 
 	assert.NoError(t, err)
@@ -308,6 +316,7 @@ func TestNewContractValidatorStoreWrapperGetValidators(t *testing.T) {
 			func(u uint64) (signer.Signer, error) {
 				return nil, errTest
 			},
+			nil,
 		)
 
 		assert.NoError(t, err)
@@ -334,6 +343,7 @@ func TestNewContractValidatorStoreWrapperGetValidators(t *testing.T) {
 					nil,
 				), nil
 			},
+			nil,
 		)
 
 		assert.NoError(t, err)
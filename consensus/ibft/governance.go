@@ -0,0 +1,677 @@
+package ibft
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/fork"
+	"github.com/0xPolygon/polygon-edge/contracts/governance"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+)
+
+const (
+	// KeyGovernance is the key of the activation of the governance of the IBFT forks in IBFT config
+	KeyGovernance = "governance"
+
+	// governanceTxGasLimit is the gas limit of the transaction submitting the proposal or the vote
+	governanceTxGasLimit = 1000000
+)
+
+var (
+	// governanceStateKey is the key of the governance state in the storage
+	governanceStateKey = []byte("governance-state")
+
+	ErrGovernanceDisabled  = errors.New("governance is not enabled")
+	ErrInvalidForkProposal = errors.New("invalid fork proposal")
+	ErrForkProposalExists  = errors.New("fork proposal already exists")
+	ErrForkProposalUnknown = errors.New("fork proposal not found")
+	ErrForkAlreadyVoted    = errors.New("fork proposal has been voted already")
+	ErrNotValidator        = errors.New("the node is not a validator")
+)
+
+// ForkProposal is the proposal of the IBFT fork voted by the validators
+type ForkProposal struct {
+	ID       types.Hash      `json:"id"`
+	Fork     *fork.IBFTFork  `json:"fork"`
+	Proposer types.Address   `json:"proposer"`
+	Height   uint64          `json:"height"`
+	Votes    []types.Address `json:"votes"`
+}
+
+// Copy returns a deep copy of the proposal except for the fork, which is not modified
+func (p *ForkProposal) Copy() *ForkProposal {
+	votes := make([]types.Address, len(p.Votes))
+	copy(votes, p.Votes)
+
+	return &ForkProposal{
+		ID:       p.ID,
+		Fork:     p.Fork,
+		Proposer: p.Proposer,
+		Height:   p.Height,
+		Votes:    votes,
+	}
+}
+
+// hasVoted returns whether the address has voted for the proposal
+func (p *ForkProposal) hasVoted(addr types.Address) bool {
+	for _, vote := range p.Votes {
+		if vote == addr {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasQuorum returns whether more than 2/3 of the validators have voted for the proposal
+func (p *ForkProposal) hasQuorum(vals validators.Validators) bool {
+	votes := 0
+
+	for _, vote := range p.Votes {
+		if vals.Includes(vote) {
+			votes++
+		}
+	}
+
+	return 3*votes > 2*vals.Len()
+}
+
+// governanceState is the proposals processed until the height, which is persisted in the storage
+type governanceState struct {
+	Height    uint64          `json:"height"`
+	Proposals []*ForkProposal `json:"proposals"`
+	Accepted  []*ForkProposal `json:"accepted"`
+}
+
+// forkGovernance counts the votes for the fork proposals recorded by the governance contract
+// and schedules the forks accepted by the validators
+type forkGovernance struct {
+	sync.RWMutex
+
+	store participationStore
+	state *governanceState
+
+	// pending is the inputs to the governance contract submitted by the operator,
+	// which are written when the node proposes the block.
+	// The governance contract accepts only the transactions of the block proposer,
+	// so an input may wait for a full rotation of the validators before it's written
+	pending map[types.Hash][]byte
+}
+
+func newForkGovernance(store participationStore) *forkGovernance {
+	return &forkGovernance{
+		store: store,
+		state: &governanceState{
+			Proposals: []*ForkProposal{},
+			Accepted:  []*ForkProposal{},
+		},
+		pending: make(map[types.Hash][]byte),
+	}
+}
+
+// load restores the governance state from the storage
+func (g *forkGovernance) load() error {
+	g.Lock()
+	defer g.Unlock()
+
+	data, ok := g.store.ReadConsensusData(governanceStateKey)
+	if !ok {
+		return nil
+	}
+
+	return json.Unmarshal(data, g.state)
+}
+
+// height returns the last height processed
+func (g *forkGovernance) height() uint64 {
+	g.RLock()
+	defer g.RUnlock()
+
+	return g.state.Height
+}
+
+// acceptedForks returns the forks accepted by the validators
+func (g *forkGovernance) acceptedForks() []*fork.IBFTFork {
+	g.RLock()
+	defer g.RUnlock()
+
+	forks := make([]*fork.IBFTFork, len(g.state.Accepted))
+	for idx, proposal := range g.state.Accepted {
+		forks[idx] = proposal.Fork
+	}
+
+	return forks
+}
+
+// proposals returns the pending proposals and the accepted ones
+func (g *forkGovernance) proposals() ([]*ForkProposal, []*ForkProposal) {
+	g.RLock()
+	defer g.RUnlock()
+
+	copyProposals := func(proposals []*ForkProposal) []*ForkProposal {
+		res := make([]*ForkProposal, len(proposals))
+		for idx, proposal := range proposals {
+			res[idx] = proposal.Copy()
+		}
+
+		return res
+	}
+
+	return copyProposals(g.state.Proposals), copyProposals(g.state.Accepted)
+}
+
+// getProposal returns the pending proposal by the ID
+func (g *forkGovernance) getProposal(id types.Hash) *ForkProposal {
+	g.RLock()
+	defer g.RUnlock()
+
+	if proposal := g.findProposal(id); proposal != nil {
+		return proposal.Copy()
+	}
+
+	return nil
+}
+
+func (g *forkGovernance) findProposal(id types.Hash) *ForkProposal {
+	for _, proposal := range g.state.Proposals {
+		if proposal.ID == id {
+			return proposal
+		}
+	}
+
+	return nil
+}
+
+// enqueue adds the input to be written in the block proposed by the node
+func (g *forkGovernance) enqueue(id types.Hash, input []byte) {
+	g.Lock()
+	defer g.Unlock()
+
+	g.pending[id] = input
+}
+
+// pendingInputs returns the inputs to be written in the block
+func (g *forkGovernance) pendingInputs() [][]byte {
+	g.RLock()
+	defer g.RUnlock()
+
+	inputs := make([][]byte, 0, len(g.pending))
+	for _, input := range g.pending {
+		inputs = append(inputs, input)
+	}
+
+	return inputs
+}
+
+// dropFailed removes the pending inputs whose transactions have failed in the block,
+// as they would fail again in every block the node proposes.
+// It returns the IDs of the removed inputs
+func (g *forkGovernance) dropFailed(failedInputs [][]byte) []types.Hash {
+	g.Lock()
+	defer g.Unlock()
+
+	dropped := make([]types.Hash, 0)
+
+	for id, input := range g.pending {
+		for _, failed := range failedInputs {
+			if bytes.Equal(input, failed) {
+				delete(g.pending, id)
+
+				dropped = append(dropped, id)
+
+				break
+			}
+		}
+	}
+
+	return dropped
+}
+
+// process applies the proposals and the votes in the block and schedules the accepted forks.
+// A fork is accepted only if it starts at least an epoch after the block
+// so that all the nodes have processed the acceptance before the fork begins,
+// the proposals which can't satisfy it anymore are dropped.
+// It returns the accepted proposals
+func (g *forkGovernance) process(
+	number uint64,
+	epochSize uint64,
+	events []*governance.Event,
+	vals validators.Validators,
+	localAddress types.Address,
+	scheduleFork func(*fork.IBFTFork) error,
+) ([]*ForkProposal, error) {
+	g.Lock()
+	defer g.Unlock()
+
+	// the block has been processed before the restart
+	if number <= g.state.Height {
+		return nil, nil
+	}
+
+	for _, event := range events {
+		if event.Sender == localAddress {
+			delete(g.pending, event.ID)
+		}
+
+		proposal := g.findProposal(event.ID)
+
+		if proposal == nil && event.Fork != nil {
+			newFork, err := decodeForkProposal(event.Fork)
+			if err != nil {
+				continue
+			}
+
+			proposal = &ForkProposal{
+				ID:       event.ID,
+				Fork:     newFork,
+				Proposer: event.Sender,
+				Height:   number,
+				Votes:    []types.Address{},
+			}
+
+			g.state.Proposals = append(g.state.Proposals, proposal)
+		}
+
+		if proposal != nil && !proposal.hasVoted(event.Sender) {
+			proposal.Votes = append(proposal.Votes, event.Sender)
+		}
+	}
+
+	var (
+		remaining = make([]*ForkProposal, 0, len(g.state.Proposals))
+		accepted  = make([]*ForkProposal, 0)
+	)
+
+	for _, proposal := range g.state.Proposals {
+		if proposal.Fork.From.Value < number+epochSize {
+			continue
+		}
+
+		if !proposal.hasQuorum(vals) {
+			remaining = append(remaining, proposal)
+
+			continue
+		}
+
+		// the proposal conflicting with the scheduled forks is dropped
+		if err := scheduleFork(proposal.Fork); err != nil {
+			continue
+		}
+
+		accepted = append(accepted, proposal)
+	}
+
+	g.state.Height = number
+	g.state.Proposals = remaining
+	g.state.Accepted = append(g.state.Accepted, accepted...)
+
+	if err := g.save(); err != nil {
+		return nil, err
+	}
+
+	return accepted, nil
+}
+
+// reset clears the processed proposals and the votes so that they are counted again from the chain,
+// e.g. after the chain has been rewound. The inputs submitted by the operator are kept
+func (g *forkGovernance) reset() error {
+	g.Lock()
+	defer g.Unlock()
+
+	g.state = &governanceState{
+		Proposals: []*ForkProposal{},
+		Accepted:  []*ForkProposal{},
+	}
+
+	return g.save()
+}
+
+// save persists the governance state in the storage
+func (g *forkGovernance) save() error {
+	data, err := json.Marshal(g.state)
+	if err != nil {
+		return err
+	}
+
+	return g.store.WriteConsensusData(governanceStateKey, data)
+}
+
+// decodeForkProposal decodes the fork in the proposal
+func decodeForkProposal(data []byte) (*fork.IBFTFork, error) {
+	newFork := &fork.IBFTFork{}
+	if err := json.Unmarshal(data, newFork); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidForkProposal, err)
+	}
+
+	if _, err := fork.ParseIBFTType(string(newFork.Type)); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidForkProposal, err)
+	}
+
+	if _, err := validators.ParseValidatorType(string(newFork.ValidatorType)); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidForkProposal, err)
+	}
+
+	if newFork.To != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidForkProposal, fork.ErrScheduledForkHasEnd)
+	}
+
+	if err := newFork.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidForkProposal, err)
+	}
+
+	return newFork, nil
+}
+
+// loadScheduledForks restores the forks accepted by the governance to the fork manager
+func (i *backendIBFT) loadScheduledForks() error {
+	if i.forkGovernance == nil {
+		return nil
+	}
+
+	if err := i.forkGovernance.load(); err != nil {
+		return err
+	}
+
+	for _, acceptedFork := range i.forkGovernance.acceptedForks() {
+		if err := i.forkManager.ScheduleFork(acceptedFork); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// syncForkGovernance processes the blocks inserted after the last processed block, e.g. before the crash
+func (i *backendIBFT) syncForkGovernance() {
+	if i.forkGovernance == nil {
+		return
+	}
+
+	// the governance contract doesn't record any log before the activation
+	start := i.forkGovernance.height() + 1
+	if i.governance != nil && start < i.governance.From.Value {
+		start = i.governance.From.Value
+	}
+
+	for number := start; number <= i.blockchain.Header().Number; number++ {
+		block, ok := i.blockchain.GetBlockByNumber(number, true)
+		if !ok {
+			i.logger.Error("failed to get block for governance", "height", number)
+
+			return
+		}
+
+		i.processForkGovernance(block)
+	}
+}
+
+// rewindForkGovernance derives the governance state from the logs of the blocks up to the new head
+// after the chain has been rewound, the forks accepted in the removed blocks are unscheduled
+func (i *backendIBFT) rewindForkGovernance() error {
+	if i.forkGovernance == nil {
+		return nil
+	}
+
+	if err := i.forkGovernance.reset(); err != nil {
+		return err
+	}
+
+	i.forkManager.ResetScheduledForks()
+
+	i.syncForkGovernance()
+
+	return nil
+}
+
+// processForkGovernance counts the proposals and the votes in the inserted block
+func (i *backendIBFT) processForkGovernance(block *types.Block) {
+	if i.forkGovernance == nil {
+		return
+	}
+
+	receipts, err := i.blockchain.GetReceiptsByHash(block.Hash())
+	if err != nil {
+		i.logger.Error("failed to get receipts for governance", "height", block.Number(), "err", err)
+
+		return
+	}
+
+	events := make([]*governance.Event, 0)
+
+	for _, receipt := range receipts {
+		for _, log := range receipt.Logs {
+			event, err := governance.ParseLog(log)
+			if err != nil {
+				i.logger.Debug("skip invalid governance log", "height", block.Number(), "err", err)
+
+				continue
+			}
+
+			if event != nil {
+				events = append(events, event)
+			}
+		}
+	}
+
+	vals, err := i.forkManager.GetValidators(block.Number())
+	if err != nil {
+		i.logger.Error("failed to get validators for governance", "height", block.Number(), "err", err)
+
+		return
+	}
+
+	var localAddress types.Address
+	if i.currentSigner != nil {
+		localAddress = i.currentSigner.Address()
+	}
+
+	for _, id := range i.forkGovernance.dropFailed(failedGovernanceInputs(block, receipts, localAddress)) {
+		i.logger.Warn(
+			"governance transaction failed, the proposal or the vote is dropped",
+			"id", id,
+			"height", block.Number(),
+		)
+	}
+
+	accepted, err := i.forkGovernance.process(
+		block.Number(),
+		i.forkManager.GetEpochSize(block.Number()),
+		events,
+		vals,
+		localAddress,
+		i.forkManager.ScheduleFork,
+	)
+	if err != nil {
+		i.logger.Error("failed to process governance", "height", block.Number(), "err", err)
+
+		return
+	}
+
+	for _, proposal := range accepted {
+		i.logger.Info(
+			"fork scheduled by governance",
+			"id", proposal.ID,
+			"type", proposal.Fork.Type,
+			"validator_type", proposal.Fork.ValidatorType,
+			"from", proposal.Fork.From.Value,
+		)
+	}
+}
+
+// failedGovernanceInputs returns the inputs of the transactions from the address
+// to the governance contract which have failed in the block
+func failedGovernanceInputs(block *types.Block, receipts []*types.Receipt, from types.Address) [][]byte {
+	inputs := make([][]byte, 0)
+
+	if from == types.ZeroAddress || len(block.Transactions) != len(receipts) {
+		return inputs
+	}
+
+	for idx, tx := range block.Transactions {
+		if tx.From != from || tx.To == nil || *tx.To != governance.AddrGovernanceContract {
+			continue
+		}
+
+		if status := receipts[idx].Status; status != nil && *status == types.ReceiptFailed {
+			inputs = append(inputs, tx.Input)
+		}
+	}
+
+	return inputs
+}
+
+// writeGovernanceTransactions submits the proposals and the votes of the node
+// to the governance contract as the transactions signed by the proposer
+func (i *backendIBFT) writeGovernanceTransactions(
+	header *types.Header,
+	transition *state.Transition,
+) []*types.Transaction {
+	if i.forkGovernance == nil ||
+		!i.governance.IsActive(header.Number) ||
+		!i.currentHooks.ShouldWriteTransactions(header.Number) {
+		return nil
+	}
+
+	var (
+		proposer = i.currentSigner.Address()
+		txSigner = crypto.NewSigner(
+			i.config.Params.Forks.At(header.Number),
+			uint64(i.config.Params.ChainID),
+		)
+		executed = make([]*types.Transaction, 0)
+	)
+
+	for _, input := range i.forkGovernance.pendingInputs() {
+		tx, err := i.currentSigner.SignTx(&types.Transaction{
+			Nonce:    transition.GetNonce(proposer),
+			GasPrice: big.NewInt(0),
+			Gas:      governanceTxGasLimit,
+			To:       &governance.AddrGovernanceContract,
+			Value:    big.NewInt(0),
+			Input:    input,
+			From:     proposer,
+		}, txSigner)
+		if err != nil {
+			i.logger.Error("failed to sign governance transaction", "err", err)
+
+			continue
+		}
+
+		if err := transition.Write(tx); err != nil {
+			i.logger.Error("failed to write governance transaction", "err", err)
+
+			continue
+		}
+
+		executed = append(executed, tx)
+	}
+
+	return executed
+}
+
+// ProposeFork submits the proposal of the fork, which is also the vote of the node.
+// The proposal is written in the next block proposed by the node. It returns the ID of the proposal
+func (i *backendIBFT) ProposeFork(rawFork []byte) (types.Hash, error) {
+	if i.forkGovernance == nil {
+		return types.ZeroHash, ErrGovernanceDisabled
+	}
+
+	newFork, err := decodeForkProposal(rawFork)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	next := i.blockchain.Header().Number + 1
+	if newFork.From.Value < next+i.forkManager.GetEpochSize(next) {
+		return types.ZeroHash, fmt.Errorf(
+			"%w: fork must start at least an epoch after the next block",
+			ErrInvalidForkProposal,
+		)
+	}
+
+	if err := i.checkLocalValidator(); err != nil {
+		return types.ZeroHash, err
+	}
+
+	// the fork is encoded again so that the same fork has the same ID
+	encodedFork, err := json.Marshal(newFork)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	id := governance.ProposalID(encodedFork)
+	if i.forkGovernance.getProposal(id) != nil {
+		return types.ZeroHash, ErrForkProposalExists
+	}
+
+	input, err := governance.EncodeProposeForkInput(encodedFork)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	i.forkGovernance.enqueue(id, input)
+
+	return id, nil
+}
+
+// VoteFork submits the vote of the node for the proposal.
+// The vote is written in the next block proposed by the node
+func (i *backendIBFT) VoteFork(id types.Hash) error {
+	if i.forkGovernance == nil {
+		return ErrGovernanceDisabled
+	}
+
+	proposal := i.forkGovernance.getProposal(id)
+	if proposal == nil {
+		return ErrForkProposalUnknown
+	}
+
+	if err := i.checkLocalValidator(); err != nil {
+		return err
+	}
+
+	if proposal.hasVoted(i.currentSigner.Address()) {
+		return ErrForkAlreadyVoted
+	}
+
+	input, err := governance.EncodeVoteForkInput(id)
+	if err != nil {
+		return err
+	}
+
+	i.forkGovernance.enqueue(id, input)
+
+	return nil
+}
+
+// GetForkProposals returns the pending proposals and the accepted ones
+func (i *backendIBFT) GetForkProposals() ([]*ForkProposal, []*ForkProposal, error) {
+	if i.forkGovernance == nil {
+		return nil, nil, ErrGovernanceDisabled
+	}
+
+	pending, accepted := i.forkGovernance.proposals()
+
+	return pending, accepted, nil
+}
+
+// checkLocalValidator returns error if the node is not a validator in the next block
+func (i *backendIBFT) checkLocalValidator() error {
+	vals, err := i.forkManager.GetValidators(i.blockchain.Header().Number + 1)
+	if err != nil {
+		return err
+	}
+
+	if i.currentSigner == nil || !vals.Includes(i.currentSigner.Address()) {
+		return ErrNotValidator
+	}
+
+	return nil
+}
@@ -0,0 +1,301 @@
+package ibft
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/fork"
+	"github.com/0xPolygon/polygon-edge/contracts/governance"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeForkProposal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		data     string
+		expected *fork.IBFTFork
+		err      error
+	}{
+		{
+			name: "should decode the fork",
+			data: `{"type":"PoS","validator_type":"bls","from":"0x64"}`,
+			expected: &fork.IBFTFork{
+				Type:          fork.PoS,
+				ValidatorType: validators.BLSValidatorType,
+				From:          common.JSONNumber{Value: 100},
+			},
+		},
+		{
+			name: "should return error if the data is not JSON",
+			data: `fork`,
+			err:  ErrInvalidForkProposal,
+		},
+		{
+			name: "should return error if the IBFT type is unknown",
+			data: `{"type":"PoW","from":"0x64"}`,
+			err:  ErrInvalidForkProposal,
+		},
+		{
+			name: "should return error if the validator type is unknown",
+			data: `{"type":"PoS","validator_type":"fake","from":"0x64"}`,
+			err:  ErrInvalidForkProposal,
+		},
+		{
+			name: "should return error if the fork has the end",
+			data: `{"type":"PoS","from":"0x64","to":"0xc8"}`,
+			err:  ErrInvalidForkProposal,
+		},
+		{
+			name: "should return error if weighted quorum is enabled in PoA",
+			data: `{"type":"PoA","from":"0x64","weightedQuorum":true}`,
+			err:  ErrInvalidForkProposal,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := decodeForkProposal([]byte(test.data))
+			assert.ErrorIs(t, err, test.err)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestForkGovernance_process(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1 = types.StringToAddress("1")
+		addr2 = types.StringToAddress("2")
+		addr3 = types.StringToAddress("3")
+		addr4 = types.StringToAddress("4")
+		vals  = validators.NewECDSAValidatorSet(
+			validators.NewECDSAValidator(addr1),
+			validators.NewECDSAValidator(addr2),
+			validators.NewECDSAValidator(addr3),
+		)
+
+		rawFork = []byte(`{"type":"PoS","from":"0x64"}`)
+		id      = governance.ProposalID(rawFork)
+
+		store     = newMockParticipationStore()
+		gov       = newForkGovernance(store)
+		scheduled = make([]*fork.IBFTFork, 0)
+		schedule  = func(f *fork.IBFTFork) error {
+			scheduled = append(scheduled, f)
+
+			return nil
+		}
+	)
+
+	gov.enqueue(id, []byte{0x1})
+
+	// addr1 proposes and the pending input of addr1 is removed
+	accepted, err := gov.process(1, 10, []*governance.Event{
+		{ID: id, Sender: addr1, Fork: rawFork},
+	}, vals, addr1, schedule)
+	assert.NoError(t, err)
+	assert.Empty(t, accepted)
+	assert.Empty(t, gov.pendingInputs())
+
+	// the vote of the non-validator is not counted
+	accepted, err = gov.process(2, 10, []*governance.Event{
+		{ID: id, Sender: addr4},
+		{ID: types.StringToHash("unknown"), Sender: addr2},
+	}, vals, addr1, schedule)
+	assert.NoError(t, err)
+	assert.Empty(t, accepted)
+
+	pending, _ := gov.proposals()
+	assert.Len(t, pending, 1)
+	assert.Equal(t, []types.Address{addr1, addr4}, pending[0].Votes)
+
+	// the duplicated vote is ignored, more than 2/3 of the validators are necessary
+	accepted, err = gov.process(3, 10, []*governance.Event{
+		{ID: id, Sender: addr1},
+		{ID: id, Sender: addr2},
+	}, vals, addr1, schedule)
+	assert.NoError(t, err)
+	assert.Empty(t, accepted)
+
+	accepted, err = gov.process(4, 10, []*governance.Event{
+		{ID: id, Sender: addr3},
+	}, vals, addr1, schedule)
+	assert.NoError(t, err)
+	assert.Len(t, accepted, 1)
+	assert.Equal(t, uint64(100), accepted[0].Fork.From.Value)
+	assert.Equal(t, scheduled, gov.acceptedForks())
+
+	pending, acceptedProposals := gov.proposals()
+	assert.Empty(t, pending)
+	assert.Len(t, acceptedProposals, 1)
+
+	// the processed block is skipped
+	accepted, err = gov.process(4, 10, []*governance.Event{
+		{ID: id, Sender: addr3},
+	}, vals, addr1, schedule)
+	assert.NoError(t, err)
+	assert.Nil(t, accepted)
+
+	// the state is restored from the storage
+	restored := newForkGovernance(store)
+	assert.NoError(t, restored.load())
+	assert.Equal(t, uint64(4), restored.height())
+	assert.Equal(t, gov.acceptedForks(), restored.acceptedForks())
+
+	// the reset state is persisted so that the votes are counted again from the chain
+	assert.NoError(t, gov.reset())
+	assert.Equal(t, uint64(0), gov.height())
+	assert.Empty(t, gov.acceptedForks())
+
+	restored = newForkGovernance(store)
+	assert.NoError(t, restored.load())
+	assert.Equal(t, uint64(0), restored.height())
+	assert.Empty(t, restored.acceptedForks())
+}
+
+func TestForkGovernance_processRequiresEpochBeforeFork(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1 = types.StringToAddress("1")
+		vals  = validators.NewECDSAValidatorSet(
+			validators.NewECDSAValidator(addr1),
+		)
+
+		rawFork = []byte(`{"type":"PoS","from":"0x64"}`)
+		id      = governance.ProposalID(rawFork)
+
+		gov      = newForkGovernance(newMockParticipationStore())
+		schedule = func(*fork.IBFTFork) error {
+			return nil
+		}
+	)
+
+	// the fork starting less than an epoch after the block is not accepted
+	accepted, err := gov.process(91, 10, []*governance.Event{
+		{ID: id, Sender: addr1, Fork: rawFork},
+	}, vals, addr1, schedule)
+	assert.NoError(t, err)
+	assert.Empty(t, accepted)
+
+	pending, acceptedProposals := gov.proposals()
+	assert.Empty(t, pending)
+	assert.Empty(t, acceptedProposals)
+
+	// the fork starting an epoch after the block is accepted
+	gov = newForkGovernance(newMockParticipationStore())
+
+	accepted, err = gov.process(90, 10, []*governance.Event{
+		{ID: id, Sender: addr1, Fork: rawFork},
+	}, vals, addr1, schedule)
+	assert.NoError(t, err)
+	assert.Len(t, accepted, 1)
+}
+
+func TestForkGovernance_processDropsProposals(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1 = types.StringToAddress("1")
+		vals  = validators.NewECDSAValidatorSet(
+			validators.NewECDSAValidator(addr1),
+		)
+
+		expiredFork     = []byte(`{"type":"PoS","from":"0x1"}`)
+		conflictingFork = []byte(`{"type":"PoS","from":"0x64"}`)
+		invalidFork     = []byte(`{"type":"PoW","from":"0x64"}`)
+
+		gov = newForkGovernance(newMockParticipationStore())
+	)
+
+	accepted, err := gov.process(1, 10, []*governance.Event{
+		{ID: governance.ProposalID(expiredFork), Sender: addr1, Fork: expiredFork},
+		{ID: governance.ProposalID(conflictingFork), Sender: addr1, Fork: conflictingFork},
+		{ID: governance.ProposalID(invalidFork), Sender: addr1, Fork: invalidFork},
+	}, vals, addr1, func(*fork.IBFTFork) error {
+		return errors.New("conflicting")
+	})
+
+	assert.NoError(t, err)
+	assert.Empty(t, accepted)
+
+	pending, acceptedProposals := gov.proposals()
+	assert.Empty(t, pending)
+	assert.Empty(t, acceptedProposals)
+}
+
+func TestForkGovernance_dropFailed(t *testing.T) {
+	t.Parallel()
+
+	var (
+		id1 = types.StringToHash("1")
+		id2 = types.StringToHash("2")
+
+		gov = newForkGovernance(newMockParticipationStore())
+	)
+
+	gov.enqueue(id1, []byte{0x1})
+	gov.enqueue(id2, []byte{0x2})
+
+	assert.Empty(t, gov.dropFailed([][]byte{{0x3}}))
+	assert.Len(t, gov.pendingInputs(), 2)
+
+	// the failed input isn't written in the later blocks
+	assert.Equal(t, []types.Hash{id1}, gov.dropFailed([][]byte{{0x1}}))
+	assert.Equal(t, [][]byte{{0x2}}, gov.pendingInputs())
+}
+
+func TestFailedGovernanceInputs(t *testing.T) {
+	t.Parallel()
+
+	var (
+		local = types.StringToAddress("1")
+		other = types.StringToAddress("2")
+
+		governanceTx = func(from types.Address, input byte) *types.Transaction {
+			return &types.Transaction{
+				From:  from,
+				To:    &governance.AddrGovernanceContract,
+				Input: []byte{input},
+			}
+		}
+		receipt = func(status types.ReceiptStatus) *types.Receipt {
+			r := &types.Receipt{}
+			r.SetStatus(status)
+
+			return r
+		}
+
+		block = &types.Block{
+			Header: &types.Header{Number: 1},
+			Transactions: []*types.Transaction{
+				governanceTx(local, 0x1),
+				governanceTx(local, 0x2),
+				governanceTx(other, 0x3),
+				{From: local, To: &other, Input: []byte{0x4}},
+			},
+		}
+		receipts = []*types.Receipt{
+			receipt(types.ReceiptSuccess),
+			receipt(types.ReceiptFailed),
+			receipt(types.ReceiptFailed),
+			receipt(types.ReceiptFailed),
+		}
+	)
+
+	// only the failed transactions from the node to the governance contract are returned
+	assert.Equal(t, [][]byte{{0x2}}, failedGovernanceInputs(block, receipts, local))
+
+	// the node isn't a validator
+	assert.Empty(t, failedGovernanceInputs(block, receipts, types.ZeroAddress))
+}
@@ -2,12 +2,16 @@ package ibft
 
 import (
 	"crypto/ecdsa"
+	"math/big"
 	"strconv"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/fork"
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/0xPolygon/polygon-edge/validators/store"
 )
 
 type testerAccount struct {
@@ -90,3 +94,54 @@ func (ap *testerAccountPool) ValidatorSet() validators.Validators {
 
 	return v
 }
+
+func newTestSigner(account *testerAccount) signer.Signer {
+	keyManager := signer.NewECDSAKeyManagerFromKey(account.priv)
+
+	return signer.NewSigner(keyManager, keyManager)
+}
+
+// mockForkManager returns the same signer, validators and voting powers at all heights in PoS
+type mockForkManager struct {
+	forkManagerInterface
+
+	signer       signer.Signer
+	vals         validators.Validators
+	votingPowers map[types.Address]*big.Int
+}
+
+func (m *mockForkManager) GetSigner(uint64) (signer.Signer, error) {
+	return m.signer, nil
+}
+
+func (m *mockForkManager) GetValidators(uint64) (validators.Validators, error) {
+	return m.vals, nil
+}
+
+func (m *mockForkManager) GetValidatorStore(uint64) (fork.ValidatorStore, error) {
+	return &mockContractValidatorStore{}, nil
+}
+
+func (m *mockForkManager) GetHooks(uint64) fork.HooksInterface {
+	return nil
+}
+
+func (m *mockForkManager) GetEpochSize(uint64) uint64 {
+	return 100
+}
+
+func (m *mockForkManager) IsWeightedQuorum(uint64) bool {
+	return m.votingPowers != nil
+}
+
+func (m *mockForkManager) GetVotingPowers(uint64) (map[types.Address]*big.Int, error) {
+	return m.votingPowers, nil
+}
+
+type mockContractValidatorStore struct {
+	fork.ValidatorStore
+}
+
+func (m *mockContractValidatorStore) SourceType() store.SourceType {
+	return store.Contract
+}
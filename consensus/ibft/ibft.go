@@ -3,6 +3,8 @@ package ibft
 import (
 	"errors"
 	"fmt"
+	"math/big"
+	"path/filepath"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
@@ -10,6 +12,8 @@ import (
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/fork"
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/proto"
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
+	"github.com/0xPolygon/polygon-edge/contracts/governance"
+	"github.com/0xPolygon/polygon-edge/contracts/staking"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
@@ -17,8 +21,10 @@ import (
 	"github.com/0xPolygon/polygon-edge/syncer"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/0xPolygon/polygon-edge/validators/store/snapshot"
 	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
+	lru "github.com/hashicorp/golang-lru"
 	"google.golang.org/grpc"
 )
 
@@ -31,6 +37,9 @@ const (
 
 	// consensusMetrics is a prefix used for consensus-related metrics
 	consensusMetrics = "consensus"
+
+	// slashingProtectionFile is the default file of the slashing protection records for the remote signer
+	slashingProtectionFile = "slashing_protection.json"
 )
 
 var (
@@ -40,6 +49,7 @@ var (
 	ErrInvalidSha3Uncles            = errors.New("invalid sha3 uncles")
 	ErrWrongDifficulty              = errors.New("wrong difficulty")
 	ErrParentCommittedSealsNotFound = errors.New("parent committed seals not found")
+	ErrInvalidTimestamp             = errors.New("timestamp is earlier than the block time after the parent")
 )
 
 type txPoolInterface interface {
@@ -60,6 +70,17 @@ type forkManagerInterface interface {
 	GetValidatorStore(uint64) (fork.ValidatorStore, error)
 	GetValidators(uint64) (validators.Validators, error)
 	GetHooks(uint64) fork.HooksInterface
+	IsWeightedQuorum(uint64) bool
+	GetVotingPowers(uint64) (map[types.Address]*big.Int, error)
+	ScheduleFork(*fork.IBFTFork) error
+	ResetScheduledForks()
+	Rewind(uint64) error
+	GetEpochSize(uint64) uint64
+	GetEpoch(uint64) uint64
+	GetBlockTime(uint64) time.Duration
+	GetRoundTimeout(uint64) time.Duration
+	CreateKeyRotation(uint64) (*snapshot.KeyRotation, error)
+	PromoteStagedKeys(uint64) (bool, error)
 }
 
 // backendIBFT represents the IBFT consensus mechanism object
@@ -83,12 +104,18 @@ type backendIBFT struct {
 	currentSigner     signer.Signer         // Signer at current sequence
 	currentValidators validators.Validators // signer at current sequence
 	currentHooks      fork.HooksInterface   // Hooks at current sequence
+	preVerifiedSeals  *lru.Cache            // Headers whose seals have been verified in advance
+	evidencePool      *evidencePool         // Evidences of the misbehaviors of the validators
+	participation     *participationTracker // Participation of the validators in the recent blocks
+	forkGovernance    *forkGovernance       // Governance of the IBFT forks, nil if the governance is disabled
 
 	// Configurations
 	config             *consensus.Config // Consensus configuration
-	epochSize          uint64
 	quorumSizeBlockNum uint64
-	blockTime          time.Duration // Minimum block generation time in seconds
+	blockTime          time.Duration    // Minimum block generation time unless the fork defines it
+	slashingConfig     *slashingConfig  // Slashing configuration, nil if slashing is disabled
+	delegation         *fork.Activation // Activation of the delegated staking, nil if the delegation is disabled
+	governance         *fork.Activation // Activation of the fork governance, nil if the governance is disabled
 
 	// Channels
 	closeCh chan struct{} // Channel for closing
@@ -96,39 +123,49 @@ type backendIBFT struct {
 
 // Factory implements the base consensus Factory method
 func Factory(params *consensus.Params) (consensus.Consensus, error) {
-	// defaults for user set fields in genesis
-	var (
-		epochSize          = uint64(DefaultEpochSize)
-		quorumSizeBlockNum = uint64(0)
-	)
+	epochSize, quorumSizeBlockNum, err := parseConfigParams(params.Config.Config)
+	if err != nil {
+		return nil, err
+	}
 
-	if definedEpochSize, ok := params.Config.Config[KeyEpochSize]; ok {
-		// Epoch size is defined, use the passed in one
-		readSize, ok := definedEpochSize.(float64)
-		if !ok {
-			return nil, errors.New("invalid type assertion")
-		}
+	slashing, err := parseSlashingConfig(params.Config.Config)
+	if err != nil {
+		return nil, err
+	}
 
-		epochSize = uint64(readSize)
+	participationWindow, err := parseParticipationWindow(params.Config.Config)
+	if err != nil {
+		return nil, err
 	}
 
-	if rawBlockNum, ok := params.Config.Config["quorumSizeBlockNum"]; ok {
-		// Block number specified for quorum size switch
-		readBlockNum, ok := rawBlockNum.(float64)
-		if !ok {
-			return nil, errors.New("invalid type assertion")
-		}
+	delegation, err := fork.GetDelegationActivation(params.Config.Config)
+	if err != nil {
+		return nil, err
+	}
 
-		quorumSizeBlockNum = uint64(readBlockNum)
+	governanceActivation, err := fork.ParseActivation(params.Config.Config, KeyGovernance)
+	if err != nil {
+		return nil, err
 	}
 
 	logger := params.Logger.Named("ibft")
 
+	preVerifiedSeals, err := lru.New(preVerifiedSealsCacheSize)
+	if err != nil {
+		return nil, err
+	}
+
+	remoteSigner, err := newRemoteSignerClient(params.RemoteSigner, params.Config.Path)
+	if err != nil {
+		return nil, err
+	}
+
 	forkManager, err := fork.NewForkManager(
 		logger,
 		params.Blockchain,
 		params.Executor,
 		params.SecretsManager,
+		remoteSigner,
 		params.Config.Path,
 		epochSize,
 		params.Config.Config,
@@ -140,37 +177,143 @@ func Factory(params *consensus.Params) (consensus.Consensus, error) {
 
 	p := &backendIBFT{
 		// References
-		logger:     logger,
-		blockchain: params.Blockchain,
-		network:    params.Network,
-		executor:   params.Executor,
-		txpool:     params.TxPool,
-		syncer: syncer.NewSyncer(
-			params.Logger,
-			params.Network,
-			params.Blockchain,
-			time.Duration(params.BlockTime)*3*time.Second,
-		),
-		secretsManager: params.SecretsManager,
-		Grpc:           params.Grpc,
-		forkManager:    forkManager,
+		logger:           logger,
+		blockchain:       params.Blockchain,
+		network:          params.Network,
+		executor:         params.Executor,
+		txpool:           params.TxPool,
+		secretsManager:   params.SecretsManager,
+		Grpc:             params.Grpc,
+		forkManager:      forkManager,
+		preVerifiedSeals: preVerifiedSeals,
+		participation:    newParticipationTracker(params.Blockchain, participationWindow),
 
 		// Configurations
 		config:             params.Config,
-		epochSize:          epochSize,
 		quorumSizeBlockNum: quorumSizeBlockNum,
 		blockTime:          time.Duration(params.BlockTime) * time.Second,
+		slashingConfig:     slashing,
+		delegation:         delegation,
+		governance:         governanceActivation,
 
 		// Channels
 		closeCh: make(chan struct{}),
 	}
 
+	// the entry points added to the staking contract are executed natively
+	// from the activation blocks defined in genesis.json
+	var systemRuntimes staking.SystemRuntimes
+
+	if slashing != nil {
+		p.evidencePool = newEvidencePool(slashing)
+
+		systemRuntimes = append(
+			systemRuntimes,
+			staking.Activate(staking.NewSlashingRuntime(), slashing.From.Value),
+		)
+	}
+
+	if delegation != nil {
+		systemRuntimes = append(
+			systemRuntimes,
+			staking.Activate(staking.NewDelegationRuntime(), delegation.From.Value),
+		)
+	}
+
+	if governanceActivation != nil {
+		p.forkGovernance = newForkGovernance(params.Blockchain)
+
+		systemRuntimes = append(
+			systemRuntimes,
+			staking.Activate(governance.NewGovernanceRuntime(), governanceActivation.From.Value),
+		)
+	}
+
+	// the forks accepted by the governance are necessary before the blockchain computes the hashes
+	if err := p.loadScheduledForks(); err != nil {
+		return nil, err
+	}
+
+	if params.Executor != nil {
+		if len(systemRuntimes) > 0 {
+			params.Executor.SystemRuntime = systemRuntimes
+		}
+
+		// the fees are withheld from the proposer to be distributed by the reward hook
+		params.Executor.WithholdFees = func(header *types.Header) bool {
+			return forkManager.HasRewards(header.Number)
+		}
+	}
+
+	p.syncer = syncer.NewSyncer(
+		params.Logger,
+		params.Network,
+		params.Blockchain,
+		time.Duration(params.BlockTime)*3*time.Second,
+		p,
+		params.Config.Params.Checkpoints,
+	)
+
 	// Istanbul requires a different header hash function
 	p.SetHeaderHash()
 
 	return p, nil
 }
 
+// parseConfigParams reads the epoch size and the height of quorum size switch from IBFT config
+func parseConfigParams(config map[string]interface{}) (uint64, uint64, error) {
+	// defaults for user set fields in genesis
+	var (
+		epochSize          = uint64(DefaultEpochSize)
+		quorumSizeBlockNum = uint64(0)
+	)
+
+	if definedEpochSize, ok := config[KeyEpochSize]; ok {
+		// Epoch size is defined, use the passed in one
+		readSize, ok := definedEpochSize.(float64)
+		if !ok {
+			return 0, 0, errors.New("invalid type assertion")
+		}
+
+		epochSize = uint64(readSize)
+	}
+
+	if rawBlockNum, ok := config["quorumSizeBlockNum"]; ok {
+		// Block number specified for quorum size switch
+		readBlockNum, ok := rawBlockNum.(float64)
+		if !ok {
+			return 0, 0, errors.New("invalid type assertion")
+		}
+
+		quorumSizeBlockNum = uint64(readBlockNum)
+	}
+
+	return epochSize, quorumSizeBlockNum, nil
+}
+
+// newRemoteSignerClient creates the client of the remote signer if it's configured.
+// The slashing protection records are kept in the consensus directory by default
+func newRemoteSignerClient(
+	config *secrets.RemoteSignerConfig,
+	consensusPath string,
+) (*signer.RemoteSignerClient, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	clientConfig := *config
+	if clientConfig.SlashingProtectionPath == "" {
+		clientConfig.SlashingProtectionPath = filepath.Join(consensusPath, slashingProtectionFile)
+	}
+
+	client, err := signer.NewRemoteSignerClient(&clientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up remote signer: %w", err)
+	}
+
+	return client, nil
+}
+
 func (i *backendIBFT) Initialize() error {
 	// register the grpc operator
 	if i.Grpc != nil {
@@ -192,6 +335,14 @@ func (i *backendIBFT) Initialize() error {
 		return err
 	}
 
+	// restore the participation of the validators
+	if err := i.participation.load(); err != nil {
+		return err
+	}
+
+	// count the votes in the blocks which haven't been processed by the governance
+	i.syncForkGovernance()
+
 	i.logger.Info("validator key", "addr", i.currentSigner.Address().String())
 
 	i.consensus = newIBFT(
@@ -200,9 +351,6 @@ func (i *backendIBFT) Initialize() error {
 		i,
 	)
 
-	// Ensure consensus takes into account user configured block production time
-	i.consensus.ExtendRoundTimeout(i.blockTime)
-
 	return nil
 }
 
@@ -213,6 +361,9 @@ func (i *backendIBFT) startSyncing() {
 			i.logger.Error("failed to call PostInsertBlock", "height", block.Header.Number, "error", err)
 		}
 
+		i.trackCommittedSeals(block)
+		i.processForkGovernance(block)
+
 		if err := i.updateCurrentModules(block.Number() + 1); err != nil {
 			i.logger.Error("failed to update sub modules", "height", block.Number()+1, "err", err)
 		}
@@ -263,7 +414,18 @@ func (i *backendIBFT) startConsensus() {
 		eventCh := newBlockSub.GetEventCh()
 
 		for {
-			if ev := <-eventCh; ev.Source == "syncer" {
+			ev := <-eventCh
+
+			if ev.Type == blockchain.EventRewind {
+				i.rewind(ev.Header().Number)
+
+				// the sequence is restarted at the height following the new head
+				syncerBlockCh <- struct{}{}
+
+				continue
+			}
+
+			if ev.Source == "syncer" {
 				if ev.NewChain[0].Number < i.blockchain.Header().Number {
 					// The blockchain notification system can eventually deliver
 					// stale block notifications. These should be ignored
@@ -304,6 +466,9 @@ func (i *backendIBFT) startConsensus() {
 		i.txpool.SetSealing(isValidator)
 
 		if isValidator {
+			// Ensure consensus takes into account the block production time at the height
+			i.consensus.ExtendRoundTimeout(i.getRoundTimeout(pending))
+
 			sequenceCh = i.consensus.runSequence(pending)
 		}
 
@@ -324,6 +489,31 @@ func (i *backendIBFT) startConsensus() {
 	}
 }
 
+// rewind drops the snapshots, the votes, the participation and the governance state
+// derived from the blocks removed by the rewind of the chain
+func (i *backendIBFT) rewind(height uint64) {
+	if err := i.forkManager.Rewind(height); err != nil {
+		i.logger.Error("failed to rewind validator stores", "height", height, "err", err)
+	}
+
+	// the participation in the head is tracked when the next block is inserted
+	if height > 0 {
+		if err := i.participation.rewind(height - 1); err != nil {
+			i.logger.Error("failed to rewind participation", "height", height, "err", err)
+		}
+	}
+
+	if err := i.rewindForkGovernance(); err != nil {
+		i.logger.Error("failed to rewind governance", "height", height, "err", err)
+	}
+
+	if err := i.updateCurrentModules(height + 1); err != nil {
+		i.logger.Error("failed to update submodules", "height", height+1, "err", err)
+	}
+
+	i.logger.Info("consensus rewound", "height", height)
+}
+
 // isActiveValidator returns whether my signer belongs to current validators
 func (i *backendIBFT) isActiveValidator() bool {
 	return i.currentValidators.Includes(i.currentSigner.Address())
@@ -368,6 +558,11 @@ func (i *backendIBFT) verifyHeaderImpl(
 		return ErrWrongDifficulty
 	}
 
+	// timestamp has to respect the block time
+	if err := i.verifyHeaderTimestamp(parent, header); err != nil {
+		return err
+	}
+
 	// ensure the extra data is correctly formatted
 	if _, err := headerSigner.GetIBFTExtra(header); err != nil {
 		return err
@@ -430,15 +625,12 @@ func (i *backendIBFT) VerifyHeader(header *types.Header) error {
 
 	// verify the Committed Seals
 	// CommittedSeals exists only in the finalized header
-	if err := headerSigner.VerifyCommittedSeals(
-		header,
-		validators,
-		i.quorumSize(header.Number)(validators),
-	); err != nil {
-		return err
+	// They can be skipped if they have been verified in advance by the expected validators
+	if i.hasPreVerifiedCommittedSeals(header, validators) {
+		return nil
 	}
 
-	return nil
+	return i.verifyCommittedSealsQuorum(header, headerSigner, validators)
 }
 
 // quorumSize returns a callback that when executed on a Validators computes
@@ -482,18 +674,49 @@ func (i *backendIBFT) PreCommitState(header *types.Header, txn *state.Transition
 	return hooks.PreCommitState(header, txn)
 }
 
-// GetEpoch returns the current epoch
+// GetEpoch returns the current epoch, counted across the forks changing the epoch size
 func (i *backendIBFT) GetEpoch(number uint64) uint64 {
-	if number%i.epochSize == 0 {
-		return number / i.epochSize
-	}
-
-	return number/i.epochSize + 1
+	return i.forkManager.GetEpoch(number)
 }
 
 // IsLastOfEpoch checks if the block number is the last of the epoch
 func (i *backendIBFT) IsLastOfEpoch(number uint64) bool {
-	return number > 0 && number%i.epochSize == 0
+	return number > 0 && number%i.forkManager.GetEpochSize(number) == 0
+}
+
+// getBlockTime returns the minimum block generation time at the given height
+func (i *backendIBFT) getBlockTime(height uint64) time.Duration {
+	if blockTime := i.forkManager.GetBlockTime(height); blockTime > 0 {
+		return blockTime
+	}
+
+	return i.blockTime
+}
+
+// getRoundTimeout returns the time added to the base timeout of each round at the given height.
+// The rounds are extended by the block time unless the fork defines the round timeout
+func (i *backendIBFT) getRoundTimeout(height uint64) time.Duration {
+	if roundTimeout := i.forkManager.GetRoundTimeout(height); roundTimeout > 0 {
+		return roundTimeout
+	}
+
+	return i.getBlockTime(height)
+}
+
+// verifyHeaderTimestamp verifies the header is not created earlier than the block time after the parent.
+// It's verified only if the fork defines the block time,
+// because the block time of the node configuration may differ among the nodes
+func (i *backendIBFT) verifyHeaderTimestamp(parent, header *types.Header) error {
+	blockTime := i.forkManager.GetBlockTime(header.Number)
+	if blockTime == 0 {
+		return nil
+	}
+
+	if header.Timestamp < parent.Timestamp+uint64(blockTime/time.Second) {
+		return ErrInvalidTimestamp
+	}
+
+	return nil
 }
 
 // Close closes the IBFT consensus mechanism, and does write back to disk
@@ -538,6 +761,11 @@ func (i *backendIBFT) SetHeaderHash() {
 func (i *backendIBFT) updateCurrentModules(height uint64) error {
 	lastSigner := i.currentSigner
 
+	// the staged keys need to be used from the height the key rotation is applied
+	if _, err := i.forkManager.PromoteStagedKeys(height); err != nil {
+		return err
+	}
+
 	signer, validators, hooks, err := getModulesFromForkManager(i.forkManager, height)
 	if err != nil {
 		return err
@@ -578,13 +806,19 @@ func (i *backendIBFT) verifyParentCommittedSeals(
 		return err
 	}
 
+	// skip the verification for the past header
+	// if the seals have been verified in advance by the expected validators
+	if !shouldVerifyParentCommittedSeals && i.hasPreVerifiedParentCommittedSeals(header, parentValidators) {
+		return nil
+	}
+
 	// if shouldVerifyParentCommittedSeals is false, skip the verification
 	// when header doesn't have Parent Committed Seals (Backward Compatibility)
-	return parentSigner.VerifyParentCommittedSeals(
+	return i.verifyParentCommittedSealsQuorum(
 		parent,
 		header,
+		parentSigner,
 		parentValidators,
-		i.quorumSize(parent.Number)(parentValidators),
 		shouldVerifyParentCommittedSeals,
 	)
 }
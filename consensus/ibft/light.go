@@ -0,0 +1,125 @@
+package ibft
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/consensus"
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/fork"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrLightClientUnsupportedFork = errors.New("light client supports only PoA forks")
+)
+
+// checkpointStore is the validator store which can be initialized from the header at the checkpoint
+type checkpointStore interface {
+	TrustCheckpoint(header *types.Header) error
+}
+
+// LightVerifier verifies the finalized headers for the light client.
+// It follows the validator set through the headers without the state,
+// so it supports only the forks whose validators are kept in snapshot
+type LightVerifier struct {
+	backend *backendIBFT
+}
+
+// NewLightVerifier creates LightVerifier from the IBFT configuration
+func NewLightVerifier(params *consensus.Params) (*LightVerifier, error) {
+	forks, err := fork.GetIBFTForks(params.Config.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range forks {
+		// validators in contract can't be fetched without the state
+		if f.Type != fork.PoA {
+			return nil, fmt.Errorf("%w, fork from %d is %s", ErrLightClientUnsupportedFork, f.From.Value, f.Type)
+		}
+	}
+
+	epochSize, quorumSizeBlockNum, err := parseConfigParams(params.Config.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := params.Logger.Named("ibft")
+
+	forkManager, err := fork.NewForkManager(
+		logger,
+		params.Blockchain,
+		nil,
+		params.SecretsManager,
+		nil,
+		params.Config.Path,
+		epochSize,
+		params.Config.Config,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	backend := &backendIBFT{
+		logger:             logger,
+		blockchain:         params.Blockchain,
+		secretsManager:     params.SecretsManager,
+		forkManager:        forkManager,
+		config:             params.Config,
+		quorumSizeBlockNum: quorumSizeBlockNum,
+		closeCh:            make(chan struct{}),
+	}
+
+	// Istanbul requires a different header hash function
+	backend.SetHeaderHash()
+
+	return &LightVerifier{
+		backend: backend,
+	}, nil
+}
+
+// Initialize loads the validator snapshots
+func (v *LightVerifier) Initialize() error {
+	return v.backend.forkManager.Initialize()
+}
+
+// VerifyHeader verifies the header fields and the seals against the validators at the height
+func (v *LightVerifier) VerifyHeader(header *types.Header) error {
+	return v.backend.VerifyHeader(header)
+}
+
+// ProcessHeaders updates the validator snapshot based on the verified headers
+func (v *LightVerifier) ProcessHeaders(headers []*types.Header) error {
+	return v.backend.ProcessHeaders(headers)
+}
+
+// ProcessCheckpointHeader initializes the validator snapshot from the header at the trusted checkpoint
+func (v *LightVerifier) ProcessCheckpointHeader(header *types.Header) error {
+	validatorStore, err := v.backend.forkManager.GetValidatorStore(header.Number)
+	if err != nil {
+		return err
+	}
+
+	store, ok := validatorStore.(checkpointStore)
+	if !ok {
+		return ErrLightClientUnsupportedFork
+	}
+
+	return store.TrustCheckpoint(header)
+}
+
+// GetBlockCreator retrieves the block signer from the extra data field
+func (v *LightVerifier) GetBlockCreator(header *types.Header) (types.Address, error) {
+	return v.backend.GetBlockCreator(header)
+}
+
+// PreCommitState is not called in light client since it doesn't execute blocks
+func (v *LightVerifier) PreCommitState(header *types.Header, txn *state.Transition) error {
+	return v.backend.PreCommitState(header, txn)
+}
+
+// Close saves the validator snapshots
+func (v *LightVerifier) Close() error {
+	return v.backend.forkManager.Close()
+}
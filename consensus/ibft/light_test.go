@@ -0,0 +1,105 @@
+package ibft
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/consensus"
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/fork"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLightVerifier_UnsupportedFork(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config map[string]interface{}
+	}{
+		{
+			name: "should reject PoS",
+			config: map[string]interface{}{
+				"type": "PoS",
+			},
+		},
+		{
+			name: "should reject forks including PoS",
+			config: map[string]interface{}{
+				"types": []interface{}{
+					map[string]interface{}{
+						"type": "PoA",
+						"from": "0x0",
+						"to":   "0x9",
+					},
+					map[string]interface{}{
+						"type":       "PoS",
+						"deployment": "0xa",
+						"from":       "0xa",
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewLightVerifier(&consensus.Params{
+				Config: &consensus.Config{
+					Config: test.config,
+					Path:   t.TempDir(),
+				},
+				Logger: hclog.NewNullLogger(),
+			})
+
+			assert.ErrorIs(t, err, ErrLightClientUnsupportedFork)
+		})
+	}
+}
+
+type mockCheckpointValidatorStore struct {
+	fork.ValidatorStore
+
+	trusted *types.Header
+}
+
+func (m *mockCheckpointValidatorStore) TrustCheckpoint(header *types.Header) error {
+	m.trusted = header
+
+	return nil
+}
+
+type mockCheckpointForkManager struct {
+	mockForkManager
+
+	store fork.ValidatorStore
+}
+
+func (m *mockCheckpointForkManager) GetValidatorStore(uint64) (fork.ValidatorStore, error) {
+	return m.store, nil
+}
+
+func TestLightVerifier_ProcessCheckpointHeader(t *testing.T) {
+	t.Parallel()
+
+	header := &types.Header{Number: 100}
+
+	// the validators in contract can't be initialized from the header
+	verifier := &LightVerifier{
+		backend: &backendIBFT{
+			forkManager: &mockForkManager{},
+		},
+	}
+
+	assert.ErrorIs(t, verifier.ProcessCheckpointHeader(header), ErrLightClientUnsupportedFork)
+
+	store := &mockCheckpointValidatorStore{}
+	verifier.backend.forkManager = &mockCheckpointForkManager{store: store}
+
+	assert.NoError(t, verifier.ProcessCheckpointHeader(header))
+	assert.Equal(t, header, store.trusted)
+}
@@ -2,21 +2,25 @@ package ibft
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/proto"
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
 	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/validators"
 	"github.com/0xPolygon/polygon-edge/validators/store"
+	"github.com/0xPolygon/polygon-edge/validators/store/snapshot"
 	empty "google.golang.org/protobuf/types/known/emptypb"
 )
 
 var (
 	ErrVotingNotSupported = errors.New("voting is not supported")
 	ErrHeaderNotFound     = errors.New("header not found")
+	ErrInvalidProposalID  = errors.New("invalid proposal id")
 )
 
 type operator struct {
@@ -32,6 +36,11 @@ type Votable interface {
 	Propose(validators.Validator, bool, types.Address) error
 }
 
+// KeyRotationRecorder is an interface of the ValidatorStore that records the key rotations
+type KeyRotationRecorder interface {
+	Rotations(uint64) ([]*snapshot.KeyRotation, error)
+}
+
 // Status returns the status of the IBFT client
 func (o *operator) Status(ctx context.Context, req *empty.Empty) (*proto.IbftStatusResp, error) {
 	signer, err := o.getLatestSigner()
@@ -72,6 +81,15 @@ func (o *operator) GetSnapshot(ctx context.Context, req *proto.SnapshotReq) (*pr
 		Validators: validatorsToProtoValidators(validators),
 	}
 
+	if recorder, ok := validatorsStore.(KeyRotationRecorder); ok {
+		rotations, err := recorder.Rotations(height)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Rotations = rotationsToProtoRotations(rotations)
+	}
+
 	votes, err := getVotes(validatorsStore, height)
 	if err != nil {
 		return nil, err
@@ -120,6 +138,118 @@ func (o *operator) Candidates(ctx context.Context, req *empty.Empty) (*proto.Can
 	}, nil
 }
 
+// ValidatorStats returns the participation of the validators in the recent blocks
+func (o *operator) ValidatorStats(ctx context.Context, req *empty.Empty) (*proto.ValidatorStatsResp, error) {
+	stats, err := o.ibft.GetValidatorStats()
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.ValidatorStatsResp{
+		Window:     stats.Window,
+		From:       stats.From,
+		To:         stats.To,
+		Validators: participationToProtoValidatorStats(stats.Validators),
+	}, nil
+}
+
+// Delegations returns the stake of the validator and the delegations to the validator
+func (o *operator) Delegations(ctx context.Context, req *proto.DelegationsReq) (*proto.DelegationsResp, error) {
+	var validator types.Address
+	if err := validator.UnmarshalText([]byte(req.Validator)); err != nil {
+		return nil, fmt.Errorf("invalid validator address: %w", err)
+	}
+
+	delegations, err := o.ibft.GetDelegations(validator)
+	if err != nil {
+		return nil, err
+	}
+
+	protoDelegations := make([]*proto.DelegationsResp_Delegation, len(delegations.Delegations))
+	for idx, d := range delegations.Delegations {
+		protoDelegations[idx] = &proto.DelegationsResp_Delegation{
+			Delegator: d.Delegator.String(),
+			Amount:    d.Amount.String(),
+		}
+	}
+
+	return &proto.DelegationsResp{
+		Validator:      delegations.Validator.String(),
+		StakedAmount:   delegations.StakedAmount.String(),
+		DelegatedStake: delegations.DelegatedStake.String(),
+		Delegations:    protoDelegations,
+	}, nil
+}
+
+// ProposeFork submits the proposal of the IBFT fork to the governance
+func (o *operator) ProposeFork(ctx context.Context, req *proto.ProposeForkReq) (*proto.ProposeForkResp, error) {
+	id, err := o.ibft.ProposeFork(req.Fork)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.ProposeForkResp{
+		Id: id.String(),
+	}, nil
+}
+
+// VoteFork submits the vote for the proposal of the IBFT fork to the governance
+func (o *operator) VoteFork(ctx context.Context, req *proto.VoteForkReq) (*empty.Empty, error) {
+	rawID, err := hex.DecodeHex(req.Id)
+	if err != nil || len(rawID) != types.HashLength {
+		return nil, ErrInvalidProposalID
+	}
+
+	if err := o.ibft.VoteFork(types.BytesToHash(rawID)); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// ForkProposals returns the pending proposals of the IBFT forks and the accepted ones
+func (o *operator) ForkProposals(ctx context.Context, req *empty.Empty) (*proto.ForkProposalsResp, error) {
+	pending, accepted, err := o.ibft.GetForkProposals()
+	if err != nil {
+		return nil, err
+	}
+
+	protoPending, err := forkProposalsToProtoForkProposals(pending)
+	if err != nil {
+		return nil, err
+	}
+
+	protoAccepted, err := forkProposalsToProtoForkProposals(accepted)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.ForkProposalsResp{
+		Pending:  protoPending,
+		Accepted: protoAccepted,
+	}, nil
+}
+
+// RotateKey creates the key rotation to the staged keys, which is proposed by the validator
+// and applied at the beginning of the next epoch
+func (o *operator) RotateKey(ctx context.Context, req *empty.Empty) (*proto.RotateKeyResp, error) {
+	rotation, err := o.ibft.forkManager.CreateKeyRotation(o.ibft.blockchain.Header().Number + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.RotateKeyResp{
+		Validator:  rotation.Validator.String(),
+		NewAddress: rotation.NewValidator.Addr().String(),
+	}
+
+	if blsValidator, ok := rotation.NewValidator.(*validators.BLSValidator); ok {
+		resp.NewBlsPublicKey = blsValidator.BLSPublicKey
+	}
+
+	return resp, nil
+}
+
 // parseCandidate parses proto.Candidate and maps to validator
 func (o *operator) parseCandidate(req *proto.Candidate) (validators.Validator, error) {
 	signer, err := o.getLatestSigner()
@@ -197,6 +327,24 @@ func validatorsToProtoValidators(validators validators.Validators) []*proto.Snap
 	return protoValidators
 }
 
+// rotationsToProtoRotations converts key rotations to response of rotations
+func rotationsToProtoRotations(rotations []*snapshot.KeyRotation) []*proto.Snapshot_Rotation {
+	protoRotations := make([]*proto.Snapshot_Rotation, len(rotations))
+
+	for idx, rotation := range rotations {
+		protoRotations[idx] = &proto.Snapshot_Rotation{
+			Validator: rotation.Validator.String(),
+			NewValidator: &proto.Snapshot_Validator{
+				Type:    string(rotation.NewValidator.Type()),
+				Address: rotation.NewValidator.Addr().String(),
+				Data:    rotation.NewValidator.Bytes(),
+			},
+		}
+	}
+
+	return protoRotations
+}
+
 // votesToProtoVotes converts votes to response of votes
 func votesToProtoVotes(votes []*store.Vote) []*proto.Snapshot_Vote {
 	protoVotes := make([]*proto.Snapshot_Vote, len(votes))
@@ -212,6 +360,24 @@ func votesToProtoVotes(votes []*store.Vote) []*proto.Snapshot_Vote {
 	return protoVotes
 }
 
+// participationToProtoValidatorStats converts participation to response of validator stats
+func participationToProtoValidatorStats(
+	participation []*ValidatorParticipation,
+) []*proto.ValidatorStatsResp_ValidatorStats {
+	protoStats := make([]*proto.ValidatorStatsResp_ValidatorStats, len(participation))
+
+	for idx, p := range participation {
+		protoStats[idx] = &proto.ValidatorStatsResp_ValidatorStats{
+			Address:        p.Address.String(),
+			ProposedBlocks: p.ProposedBlocks,
+			CommittedSeals: p.CommittedSeals,
+			LastSeen:       p.LastSeen,
+		}
+	}
+
+	return protoStats
+}
+
 func candidatesToProtoCandidates(candidates []*store.Candidate) []*proto.Candidate {
 	protoCandidates := make([]*proto.Candidate, len(candidates))
 
@@ -238,3 +404,30 @@ func getVotes(validatorStore store.ValidatorStore, height uint64) ([]*store.Vote
 
 	return votableStore.Votes(height)
 }
+
+// forkProposalsToProtoForkProposals converts fork proposals to response of fork proposals
+func forkProposalsToProtoForkProposals(proposals []*ForkProposal) ([]*proto.ForkProposalsResp_ForkProposal, error) {
+	protoProposals := make([]*proto.ForkProposalsResp_ForkProposal, len(proposals))
+
+	for idx, proposal := range proposals {
+		rawFork, err := json.Marshal(proposal.Fork)
+		if err != nil {
+			return nil, err
+		}
+
+		votes := make([]string, len(proposal.Votes))
+		for voteIdx, vote := range proposal.Votes {
+			votes[voteIdx] = vote.String()
+		}
+
+		protoProposals[idx] = &proto.ForkProposalsResp_ForkProposal{
+			Id:       proposal.ID.String(),
+			Fork:     rawFork,
+			Proposer: proposal.Proposer.String(),
+			Height:   proposal.Height,
+			Votes:    votes,
+		}
+	}
+
+	return protoProposals, nil
+}
@@ -0,0 +1,574 @@
+package ibft
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/umbracle/fastrlp"
+)
+
+const (
+	KeyParticipationWindow = "participationWindow"
+
+	// DefaultParticipationWindow is the default number of the recent blocks
+	// in which the participation of the validators is tracked
+	DefaultParticipationWindow = 1000
+)
+
+var (
+	// participationStatsKey is the key of the participation stats in the storage
+	participationStatsKey = []byte("participation-stats")
+	// participationRecordPrefix is the prefix of the key of the participation record in the storage
+	participationRecordPrefix = []byte("participation-record")
+
+	ErrInvalidParticipationWindow = errors.New("participation window must be greater than zero")
+)
+
+// participationStore is the storage to persist the participation of the validators
+type participationStore interface {
+	WriteConsensusData(key []byte, data []byte) error
+	ReadConsensusData(key []byte) ([]byte, bool)
+}
+
+// ValidatorParticipation is the participation of the validator in the recent blocks
+type ValidatorParticipation struct {
+	Address types.Address
+	// ProposedBlocks is the number of the blocks proposed by the validator in the window
+	ProposedBlocks uint64
+	// CommittedSeals is the number of the committed seals contributed by the validator in the window
+	CommittedSeals uint64
+	// LastSeen is the latest height the validator proposed or sealed
+	LastSeen uint64
+}
+
+// ParticipationStats is the participation of the validators in the rolling window
+type ParticipationStats struct {
+	// Window is the maximum number of the blocks to be tracked
+	Window uint64
+	// From and To are the range of the tracked blocks, To is zero if no block has been tracked
+	From       uint64
+	To         uint64
+	Validators []*ValidatorParticipation
+}
+
+// Copy returns the deep copy of the stats
+func (s *ParticipationStats) Copy() *ParticipationStats {
+	clone := &ParticipationStats{
+		Window:     s.Window,
+		From:       s.From,
+		To:         s.To,
+		Validators: make([]*ValidatorParticipation, len(s.Validators)),
+	}
+
+	for idx, v := range s.Validators {
+		copied := *v
+		clone.Validators[idx] = &copied
+	}
+
+	return clone
+}
+
+// get returns the participation of the validator, adds new one if it doesn't exist
+func (s *ParticipationStats) get(addr types.Address) *ValidatorParticipation {
+	idx := sort.Search(len(s.Validators), func(i int) bool {
+		return bytes.Compare(s.Validators[i].Address.Bytes(), addr.Bytes()) >= 0
+	})
+
+	if idx < len(s.Validators) && s.Validators[idx].Address == addr {
+		return s.Validators[idx]
+	}
+
+	v := &ValidatorParticipation{Address: addr}
+
+	// keep the validators sorted by address
+	s.Validators = append(s.Validators, nil)
+	copy(s.Validators[idx+1:], s.Validators[idx:])
+	s.Validators[idx] = v
+
+	return v
+}
+
+// add applies the participation in the block
+func (s *ParticipationStats) add(record *participationRecord) {
+	proposer := s.get(record.Proposer)
+	proposer.ProposedBlocks++
+	proposer.LastSeen = record.Number
+
+	for _, signer := range record.Signers {
+		v := s.get(signer)
+		v.CommittedSeals++
+		v.LastSeen = record.Number
+	}
+}
+
+// remove reverts the participation in the block leaving the window
+func (s *ParticipationStats) remove(record *participationRecord) {
+	if proposer := s.get(record.Proposer); proposer.ProposedBlocks > 0 {
+		proposer.ProposedBlocks--
+	}
+
+	for _, signer := range record.Signers {
+		if v := s.get(signer); v.CommittedSeals > 0 {
+			v.CommittedSeals--
+		}
+	}
+}
+
+// MarshalRLPTo defines the marshal function wrapper for ParticipationStats
+func (s *ParticipationStats) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(s.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith defines the marshal function implementation for ParticipationStats
+func (s *ParticipationStats) MarshalRLPWith(ar *fastrlp.Arena) *fastrlp.Value {
+	vv := ar.NewArray()
+
+	vv.Set(ar.NewUint(s.Window))
+	vv.Set(ar.NewUint(s.From))
+	vv.Set(ar.NewUint(s.To))
+
+	vals := ar.NewArray()
+
+	for _, v := range s.Validators {
+		val := ar.NewArray()
+		val.Set(ar.NewCopyBytes(v.Address.Bytes()))
+		val.Set(ar.NewUint(v.ProposedBlocks))
+		val.Set(ar.NewUint(v.CommittedSeals))
+		val.Set(ar.NewUint(v.LastSeen))
+
+		vals.Set(val)
+	}
+
+	vv.Set(vals)
+
+	return vv
+}
+
+// UnmarshalRLP defines the unmarshal function wrapper for ParticipationStats
+func (s *ParticipationStats) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(s.UnmarshalRLPFrom, input)
+}
+
+// UnmarshalRLPFrom defines the unmarshal implementation for ParticipationStats
+func (s *ParticipationStats) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) != 4 {
+		return fmt.Errorf("incorrect number of elements to decode participation stats, expected 4 but found %d", len(elems))
+	}
+
+	if s.Window, err = elems[0].GetUint64(); err != nil {
+		return err
+	}
+
+	if s.From, err = elems[1].GetUint64(); err != nil {
+		return err
+	}
+
+	if s.To, err = elems[2].GetUint64(); err != nil {
+		return err
+	}
+
+	vals, err := elems[3].GetElems()
+	if err != nil {
+		return err
+	}
+
+	s.Validators = make([]*ValidatorParticipation, len(vals))
+
+	for idx, val := range vals {
+		fields, err := val.GetElems()
+		if err != nil {
+			return err
+		}
+
+		if len(fields) != 4 {
+			return fmt.Errorf(
+				"incorrect number of elements to decode validator participation, expected 4 but found %d",
+				len(fields),
+			)
+		}
+
+		participation := &ValidatorParticipation{}
+
+		if err := fields[0].GetAddr(participation.Address[:]); err != nil {
+			return err
+		}
+
+		if participation.ProposedBlocks, err = fields[1].GetUint64(); err != nil {
+			return err
+		}
+
+		if participation.CommittedSeals, err = fields[2].GetUint64(); err != nil {
+			return err
+		}
+
+		if participation.LastSeen, err = fields[3].GetUint64(); err != nil {
+			return err
+		}
+
+		s.Validators[idx] = participation
+	}
+
+	return nil
+}
+
+// participationRecord is the participation in the block,
+// it's kept until the block leaves the window
+type participationRecord struct {
+	Number   uint64
+	Proposer types.Address
+	Signers  []types.Address
+}
+
+// MarshalRLPTo defines the marshal function wrapper for participationRecord
+func (r *participationRecord) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(r.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith defines the marshal function implementation for participationRecord
+func (r *participationRecord) MarshalRLPWith(ar *fastrlp.Arena) *fastrlp.Value {
+	vv := ar.NewArray()
+
+	vv.Set(ar.NewUint(r.Number))
+	vv.Set(ar.NewCopyBytes(r.Proposer.Bytes()))
+
+	signers := ar.NewArray()
+	for _, signer := range r.Signers {
+		signers.Set(ar.NewCopyBytes(signer.Bytes()))
+	}
+
+	vv.Set(signers)
+
+	return vv
+}
+
+// UnmarshalRLP defines the unmarshal function wrapper for participationRecord
+func (r *participationRecord) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(r.UnmarshalRLPFrom, input)
+}
+
+// UnmarshalRLPFrom defines the unmarshal implementation for participationRecord
+func (r *participationRecord) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) != 3 {
+		return fmt.Errorf("incorrect number of elements to decode participation record, expected 3 but found %d", len(elems))
+	}
+
+	if r.Number, err = elems[0].GetUint64(); err != nil {
+		return err
+	}
+
+	if err := elems[1].GetAddr(r.Proposer[:]); err != nil {
+		return err
+	}
+
+	signers, err := elems[2].GetElems()
+	if err != nil {
+		return err
+	}
+
+	r.Signers = make([]types.Address, len(signers))
+
+	for idx, signer := range signers {
+		if err := signer.GetAddr(r.Signers[idx][:]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// participationTracker tracks the blocks proposed and the committed seals contributed
+// by the validators in the rolling window and persists them in the storage
+type participationTracker struct {
+	sync.RWMutex
+
+	store  participationStore
+	window uint64
+	stats  *ParticipationStats
+}
+
+func newParticipationTracker(store participationStore, window uint64) *participationTracker {
+	return &participationTracker{
+		store:  store,
+		window: window,
+		stats: &ParticipationStats{
+			Window:     window,
+			Validators: []*ValidatorParticipation{},
+		},
+	}
+}
+
+// load restores the stats from the storage,
+// the stats are reset if the window has been changed
+func (t *participationTracker) load() error {
+	t.Lock()
+	defer t.Unlock()
+
+	data, ok := t.store.ReadConsensusData(participationStatsKey)
+	if !ok {
+		return nil
+	}
+
+	stats := &ParticipationStats{}
+	if err := stats.UnmarshalRLP(data); err != nil {
+		return err
+	}
+
+	if stats.Window == t.window {
+		t.stats = stats
+	}
+
+	return nil
+}
+
+// track applies the participation in the new block and drops the block leaving the window
+func (t *participationTracker) track(number uint64, proposer types.Address, signers []types.Address) error {
+	t.Lock()
+	defer t.Unlock()
+
+	// the records are not consecutive after the gap or the rewind
+	if t.stats.To == 0 || number != t.stats.To+1 {
+		t.stats = &ParticipationStats{
+			Window:     t.window,
+			From:       number,
+			Validators: []*ValidatorParticipation{},
+		}
+	}
+
+	if number >= t.stats.From+t.window {
+		old, err := t.readRecord(number - t.window)
+		if err != nil {
+			return err
+		}
+
+		if old != nil {
+			t.stats.remove(old)
+		}
+
+		t.stats.From = number - t.window + 1
+	}
+
+	record := &participationRecord{
+		Number:   number,
+		Proposer: proposer,
+		Signers:  signers,
+	}
+
+	t.stats.add(record)
+	t.stats.To = number
+
+	if err := t.store.WriteConsensusData(t.recordKey(number), record.MarshalRLPTo(nil)); err != nil {
+		return err
+	}
+
+	return t.store.WriteConsensusData(participationStatsKey, t.stats.MarshalRLPTo(nil))
+}
+
+// rewind drops the records of the blocks above the given height from the stats,
+// the window is filled again by the blocks inserted after the rewind
+func (t *participationTracker) rewind(number uint64) error {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.stats.To <= number {
+		return nil
+	}
+
+	if t.stats.From > number {
+		t.stats = &ParticipationStats{
+			Window:     t.window,
+			Validators: []*ValidatorParticipation{},
+		}
+	} else {
+		for height := number + 1; height <= t.stats.To; height++ {
+			record, err := t.readRecord(height)
+			if err != nil {
+				return err
+			}
+
+			if record != nil {
+				t.stats.remove(record)
+			}
+		}
+
+		t.stats.To = number
+
+		if err := t.restoreLastSeen(); err != nil {
+			return err
+		}
+	}
+
+	return t.store.WriteConsensusData(participationStatsKey, t.stats.MarshalRLPTo(nil))
+}
+
+// restoreLastSeen finds the latest appearance in the window of the validators seen after the end of the stats,
+// it's reset if the validator doesn't appear in the window
+func (t *participationTracker) restoreLastSeen() error {
+	seen := make(map[types.Address]uint64)
+
+	for _, v := range t.stats.Validators {
+		if v.LastSeen > t.stats.To {
+			v.LastSeen = 0
+			seen[v.Address] = 0
+		}
+	}
+
+	for height := t.stats.To; height >= t.stats.From && len(seen) > 0; height-- {
+		record, err := t.readRecord(height)
+		if err != nil {
+			return err
+		}
+
+		if record == nil {
+			continue
+		}
+
+		for _, addr := range append([]types.Address{record.Proposer}, record.Signers...) {
+			if _, ok := seen[addr]; ok {
+				t.stats.get(addr).LastSeen = height
+
+				delete(seen, addr)
+			}
+		}
+
+		if height == 0 {
+			break
+		}
+	}
+
+	return nil
+}
+
+// recordKey returns the key of the record, the records are stored in the ring of the window size
+func (t *participationTracker) recordKey(number uint64) []byte {
+	key := make([]byte, len(participationRecordPrefix)+8)
+
+	copy(key, participationRecordPrefix)
+	binary.BigEndian.PutUint64(key[len(participationRecordPrefix):], number%t.window)
+
+	return key
+}
+
+// readRecord reads the record of the given height, returns nil if it has been overwritten
+func (t *participationTracker) readRecord(number uint64) (*participationRecord, error) {
+	data, ok := t.store.ReadConsensusData(t.recordKey(number))
+	if !ok {
+		return nil, nil
+	}
+
+	record := &participationRecord{}
+	if err := record.UnmarshalRLP(data); err != nil {
+		return nil, err
+	}
+
+	if record.Number != number {
+		return nil, nil
+	}
+
+	return record, nil
+}
+
+// getStats returns the stats including the given validators even if they haven't participated
+func (t *participationTracker) getStats(vals validators.Validators) *ParticipationStats {
+	t.RLock()
+	stats := t.stats.Copy()
+	t.RUnlock()
+
+	if vals != nil {
+		for idx := 0; idx < vals.Len(); idx++ {
+			stats.get(vals.At(uint64(idx)).Addr())
+		}
+	}
+
+	return stats
+}
+
+// parseParticipationWindow reads the size of the participation window from IBFT config
+func parseParticipationWindow(config map[string]interface{}) (uint64, error) {
+	rawWindow, ok := config[KeyParticipationWindow]
+	if !ok {
+		return DefaultParticipationWindow, nil
+	}
+
+	window, ok := rawWindow.(float64)
+	if !ok {
+		return 0, errors.New("invalid type assertion")
+	}
+
+	if window < 1 {
+		return 0, ErrInvalidParticipationWindow
+	}
+
+	return uint64(window), nil
+}
+
+// trackCommittedSeals tracks the proposer and the signers of the committed seals of the parent of the inserted block.
+// The committed seals in the block itself are not covered by the block hash and may differ among the nodes,
+// so the signers are read from the parent committed seals in the inserted block, which all nodes agree on
+func (i *backendIBFT) trackCommittedSeals(block *types.Block) {
+	// genesis doesn't have committed seals
+	if block.Number() <= 1 {
+		return
+	}
+
+	parentNumber := block.Number() - 1
+
+	parent, ok := i.blockchain.GetHeaderByHash(block.ParentHash())
+	if !ok {
+		i.logger.Error("failed to get parent header", "height", parentNumber)
+
+		return
+	}
+
+	parentSigner, parentVals, _, err := getModulesFromForkManager(i.forkManager, parentNumber)
+	if err != nil {
+		i.logger.Error("failed to get modules", "height", parentNumber, "err", err)
+
+		return
+	}
+
+	signers, err := parentSigner.GetParentCommittedSealSigners(block.Header, parentVals)
+	if err != nil {
+		i.logger.Error("failed to get parent committed seal signers", "height", block.Number(), "err", err)
+
+		return
+	}
+
+	proposer, err := parentSigner.EcrecoverFromHeader(parent)
+	if err != nil {
+		i.logger.Error("failed to recover proposer", "height", parentNumber, "err", err)
+
+		return
+	}
+
+	if err := i.participation.track(parentNumber, proposer, signers); err != nil {
+		i.logger.Error("failed to track participation", "height", parentNumber, "err", err)
+	}
+
+	i.updateEvidencePool(block, parentVals, signers)
+}
+
+// GetValidatorStats returns the participation of the validators in the recent blocks
+func (i *backendIBFT) GetValidatorStats() (*ParticipationStats, error) {
+	vals, err := i.forkManager.GetValidators(i.blockchain.Header().Number + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.participation.getStats(vals), nil
+}
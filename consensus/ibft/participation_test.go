@@ -0,0 +1,191 @@
+package ibft
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockParticipationStore struct {
+	data map[string][]byte
+}
+
+func newMockParticipationStore() *mockParticipationStore {
+	return &mockParticipationStore{
+		data: make(map[string][]byte),
+	}
+}
+
+func (m *mockParticipationStore) WriteConsensusData(key []byte, data []byte) error {
+	m.data[string(key)] = data
+
+	return nil
+}
+
+func (m *mockParticipationStore) ReadConsensusData(key []byte) ([]byte, bool) {
+	data, ok := m.data[string(key)]
+
+	return data, ok
+}
+
+func TestParseParticipationWindow(t *testing.T) {
+	t.Parallel()
+
+	window, err := parseParticipationWindow(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(DefaultParticipationWindow), window)
+
+	window, err = parseParticipationWindow(map[string]interface{}{
+		KeyParticipationWindow: float64(10),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), window)
+
+	_, err = parseParticipationWindow(map[string]interface{}{
+		KeyParticipationWindow: float64(0),
+	})
+	assert.ErrorIs(t, err, ErrInvalidParticipationWindow)
+}
+
+func TestParticipationStatsRLP(t *testing.T) {
+	t.Parallel()
+
+	stats := &ParticipationStats{
+		Window: 10,
+		From:   1,
+		To:     5,
+		Validators: []*ValidatorParticipation{
+			{
+				Address:        types.StringToAddress("1"),
+				ProposedBlocks: 2,
+				CommittedSeals: 5,
+				LastSeen:       5,
+			},
+		},
+	}
+
+	decoded := &ParticipationStats{}
+
+	assert.NoError(t, decoded.UnmarshalRLP(stats.MarshalRLPTo(nil)))
+	assert.Equal(t, stats, decoded)
+}
+
+func TestParticipationTracker(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1 = types.StringToAddress("1")
+		addr2 = types.StringToAddress("2")
+		addr3 = types.StringToAddress("3")
+
+		store   = newMockParticipationStore()
+		tracker = newParticipationTracker(store, 3)
+	)
+
+	// addr1 proposes and addr2 signs the first 3 blocks
+	for number := uint64(1); number <= 3; number++ {
+		assert.NoError(t, tracker.track(number, addr1, []types.Address{addr1, addr2}))
+	}
+
+	// addr2 proposes and addr1 stops signing
+	assert.NoError(t, tracker.track(4, addr2, []types.Address{addr2}))
+
+	expected := &ParticipationStats{
+		Window: 3,
+		From:   2,
+		To:     4,
+		Validators: []*ValidatorParticipation{
+			{Address: addr1, ProposedBlocks: 2, CommittedSeals: 2, LastSeen: 3},
+			{Address: addr2, ProposedBlocks: 1, CommittedSeals: 3, LastSeen: 4},
+		},
+	}
+
+	assert.Equal(t, expected, tracker.getStats(nil))
+
+	// the validators who haven't participated are included
+	stats := tracker.getStats(validators.NewECDSAValidatorSet(
+		validators.NewECDSAValidator(addr1),
+		validators.NewECDSAValidator(addr3),
+	))
+	assert.Len(t, stats.Validators, 3)
+	assert.Equal(t, &ValidatorParticipation{Address: addr3}, stats.Validators[2])
+
+	// the stats are restored from the store
+	restored := newParticipationTracker(store, 3)
+	assert.NoError(t, restored.load())
+	assert.Equal(t, expected, restored.getStats(nil))
+
+	// the stats are reset if the window has been changed
+	resized := newParticipationTracker(store, 5)
+	assert.NoError(t, resized.load())
+	assert.Equal(t, &ParticipationStats{
+		Window:     5,
+		Validators: []*ValidatorParticipation{},
+	}, resized.getStats(nil))
+
+	// the stats are reset after the gap
+	assert.NoError(t, restored.track(10, addr3, []types.Address{addr3}))
+	assert.Equal(t, &ParticipationStats{
+		Window: 3,
+		From:   10,
+		To:     10,
+		Validators: []*ValidatorParticipation{
+			{Address: addr3, ProposedBlocks: 1, CommittedSeals: 1, LastSeen: 10},
+		},
+	}, restored.getStats(nil))
+}
+
+func TestParticipationTracker_rewind(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1 = types.StringToAddress("1")
+		addr2 = types.StringToAddress("2")
+
+		store   = newMockParticipationStore()
+		tracker = newParticipationTracker(store, 5)
+	)
+
+	// addr1 proposes the first 3 blocks and addr2 proposes and signs the next 2 blocks
+	for number := uint64(1); number <= 3; number++ {
+		assert.NoError(t, tracker.track(number, addr1, []types.Address{addr1}))
+	}
+
+	for number := uint64(4); number <= 5; number++ {
+		assert.NoError(t, tracker.track(number, addr2, []types.Address{addr1, addr2}))
+	}
+
+	// the records above the height are dropped and the last seen heights are restored
+	assert.NoError(t, tracker.rewind(3))
+
+	expected := &ParticipationStats{
+		Window: 5,
+		From:   1,
+		To:     3,
+		Validators: []*ValidatorParticipation{
+			{Address: addr1, ProposedBlocks: 3, CommittedSeals: 3, LastSeen: 3},
+			{Address: addr2, ProposedBlocks: 0, CommittedSeals: 0, LastSeen: 0},
+		},
+	}
+
+	assert.Equal(t, expected, tracker.getStats(nil))
+
+	// the rewound stats are persisted
+	restored := newParticipationTracker(store, 5)
+	assert.NoError(t, restored.load())
+	assert.Equal(t, expected, restored.getStats(nil))
+
+	// the tracking continues from the new head
+	assert.NoError(t, tracker.track(4, addr2, []types.Address{addr2}))
+	assert.Equal(t, uint64(1), tracker.getStats(nil).From)
+	assert.Equal(t, uint64(4), tracker.getStats(nil).To)
+
+	// the stats are reset if the height is before the window
+	assert.NoError(t, tracker.rewind(0))
+	assert.Equal(t, &ParticipationStats{
+		Window:     5,
+		Validators: []*ValidatorParticipation{},
+	}, tracker.getStats(nil))
+}
@@ -132,6 +132,7 @@ type Snapshot struct {
 	Number     uint64                `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
 	Hash       string                `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
 	Votes      []*Snapshot_Vote      `protobuf:"bytes,4,rep,name=votes,proto3" json:"votes,omitempty"`
+	Rotations  []*Snapshot_Rotation  `protobuf:"bytes,5,rep,name=rotations,proto3" json:"rotations,omitempty"`
 }
 
 func (x *Snapshot) Reset() {
@@ -194,6 +195,13 @@ func (x *Snapshot) GetVotes() []*Snapshot_Vote {
 	return nil
 }
 
+func (x *Snapshot) GetRotations() []*Snapshot_Rotation {
+	if x != nil {
+		return x.Rotations
+	}
+	return nil
+}
+
 type ProposeReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -206,20 +214,699 @@ type ProposeReq struct {
 func (x *ProposeReq) Reset() {
 	*x = ProposeReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[3]
+		mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProposeReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProposeReq) ProtoMessage() {}
+
+func (x *ProposeReq) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProposeReq.ProtoReflect.Descriptor instead.
+func (*ProposeReq) Descriptor() ([]byte, []int) {
+	return file_consensus_ibft_proto_ibft_operator_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ProposeReq) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ProposeReq) GetAuth() bool {
+	if x != nil {
+		return x.Auth
+	}
+	return false
+}
+
+type CandidatesResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Candidates []*Candidate `protobuf:"bytes,1,rep,name=candidates,proto3" json:"candidates,omitempty"`
+}
+
+func (x *CandidatesResp) Reset() {
+	*x = CandidatesResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CandidatesResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CandidatesResp) ProtoMessage() {}
+
+func (x *CandidatesResp) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CandidatesResp.ProtoReflect.Descriptor instead.
+func (*CandidatesResp) Descriptor() ([]byte, []int) {
+	return file_consensus_ibft_proto_ibft_operator_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CandidatesResp) GetCandidates() []*Candidate {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+type Candidate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address   string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	BlsPubkey []byte `protobuf:"bytes,2,opt,name=bls_pubkey,json=blsPubkey,proto3" json:"bls_pubkey,omitempty"`
+	Auth      bool   `protobuf:"varint,3,opt,name=auth,proto3" json:"auth,omitempty"`
+}
+
+func (x *Candidate) Reset() {
+	*x = Candidate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Candidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Candidate) ProtoMessage() {}
+
+func (x *Candidate) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Candidate.ProtoReflect.Descriptor instead.
+func (*Candidate) Descriptor() ([]byte, []int) {
+	return file_consensus_ibft_proto_ibft_operator_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Candidate) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Candidate) GetBlsPubkey() []byte {
+	if x != nil {
+		return x.BlsPubkey
+	}
+	return nil
+}
+
+func (x *Candidate) GetAuth() bool {
+	if x != nil {
+		return x.Auth
+	}
+	return false
+}
+
+type ValidatorStatsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Window     uint64                               `protobuf:"varint,1,opt,name=window,proto3" json:"window,omitempty"`
+	From       uint64                               `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To         uint64                               `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	Validators []*ValidatorStatsResp_ValidatorStats `protobuf:"bytes,4,rep,name=validators,proto3" json:"validators,omitempty"`
+}
+
+func (x *ValidatorStatsResp) Reset() {
+	*x = ValidatorStatsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidatorStatsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorStatsResp) ProtoMessage() {}
+
+func (x *ValidatorStatsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorStatsResp.ProtoReflect.Descriptor instead.
+func (*ValidatorStatsResp) Descriptor() ([]byte, []int) {
+	return file_consensus_ibft_proto_ibft_operator_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ValidatorStatsResp) GetWindow() uint64 {
+	if x != nil {
+		return x.Window
+	}
+	return 0
+}
+
+func (x *ValidatorStatsResp) GetFrom() uint64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *ValidatorStatsResp) GetTo() uint64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *ValidatorStatsResp) GetValidators() []*ValidatorStatsResp_ValidatorStats {
+	if x != nil {
+		return x.Validators
+	}
+	return nil
+}
+
+type DelegationsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Validator string `protobuf:"bytes,1,opt,name=validator,proto3" json:"validator,omitempty"`
+}
+
+func (x *DelegationsReq) Reset() {
+	*x = DelegationsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DelegationsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DelegationsReq) ProtoMessage() {}
+
+func (x *DelegationsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DelegationsReq.ProtoReflect.Descriptor instead.
+func (*DelegationsReq) Descriptor() ([]byte, []int) {
+	return file_consensus_ibft_proto_ibft_operator_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DelegationsReq) GetValidator() string {
+	if x != nil {
+		return x.Validator
+	}
+	return ""
+}
+
+type DelegationsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Validator      string                        `protobuf:"bytes,1,opt,name=validator,proto3" json:"validator,omitempty"`
+	StakedAmount   string                        `protobuf:"bytes,2,opt,name=staked_amount,json=stakedAmount,proto3" json:"staked_amount,omitempty"`
+	DelegatedStake string                        `protobuf:"bytes,3,opt,name=delegated_stake,json=delegatedStake,proto3" json:"delegated_stake,omitempty"`
+	Delegations    []*DelegationsResp_Delegation `protobuf:"bytes,4,rep,name=delegations,proto3" json:"delegations,omitempty"`
+}
+
+func (x *DelegationsResp) Reset() {
+	*x = DelegationsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DelegationsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DelegationsResp) ProtoMessage() {}
+
+func (x *DelegationsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DelegationsResp.ProtoReflect.Descriptor instead.
+func (*DelegationsResp) Descriptor() ([]byte, []int) {
+	return file_consensus_ibft_proto_ibft_operator_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DelegationsResp) GetValidator() string {
+	if x != nil {
+		return x.Validator
+	}
+	return ""
+}
+
+func (x *DelegationsResp) GetStakedAmount() string {
+	if x != nil {
+		return x.StakedAmount
+	}
+	return ""
+}
+
+func (x *DelegationsResp) GetDelegatedStake() string {
+	if x != nil {
+		return x.DelegatedStake
+	}
+	return ""
+}
+
+func (x *DelegationsResp) GetDelegations() []*DelegationsResp_Delegation {
+	if x != nil {
+		return x.Delegations
+	}
+	return nil
+}
+
+type ProposeForkReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// JSON encoded IBFT fork
+	Fork []byte `protobuf:"bytes,1,opt,name=fork,proto3" json:"fork,omitempty"`
+}
+
+func (x *ProposeForkReq) Reset() {
+	*x = ProposeForkReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProposeForkReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProposeForkReq) ProtoMessage() {}
+
+func (x *ProposeForkReq) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProposeForkReq.ProtoReflect.Descriptor instead.
+func (*ProposeForkReq) Descriptor() ([]byte, []int) {
+	return file_consensus_ibft_proto_ibft_operator_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ProposeForkReq) GetFork() []byte {
+	if x != nil {
+		return x.Fork
+	}
+	return nil
+}
+
+type ProposeForkResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *ProposeForkResp) Reset() {
+	*x = ProposeForkResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProposeForkResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProposeForkResp) ProtoMessage() {}
+
+func (x *ProposeForkResp) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProposeForkResp.ProtoReflect.Descriptor instead.
+func (*ProposeForkResp) Descriptor() ([]byte, []int) {
+	return file_consensus_ibft_proto_ibft_operator_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ProposeForkResp) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type VoteForkReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *VoteForkReq) Reset() {
+	*x = VoteForkReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VoteForkReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoteForkReq) ProtoMessage() {}
+
+func (x *VoteForkReq) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoteForkReq.ProtoReflect.Descriptor instead.
+func (*VoteForkReq) Descriptor() ([]byte, []int) {
+	return file_consensus_ibft_proto_ibft_operator_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *VoteForkReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ForkProposalsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pending  []*ForkProposalsResp_ForkProposal `protobuf:"bytes,1,rep,name=pending,proto3" json:"pending,omitempty"`
+	Accepted []*ForkProposalsResp_ForkProposal `protobuf:"bytes,2,rep,name=accepted,proto3" json:"accepted,omitempty"`
+}
+
+func (x *ForkProposalsResp) Reset() {
+	*x = ForkProposalsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ForkProposalsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForkProposalsResp) ProtoMessage() {}
+
+func (x *ForkProposalsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForkProposalsResp.ProtoReflect.Descriptor instead.
+func (*ForkProposalsResp) Descriptor() ([]byte, []int) {
+	return file_consensus_ibft_proto_ibft_operator_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ForkProposalsResp) GetPending() []*ForkProposalsResp_ForkProposal {
+	if x != nil {
+		return x.Pending
+	}
+	return nil
+}
+
+func (x *ForkProposalsResp) GetAccepted() []*ForkProposalsResp_ForkProposal {
+	if x != nil {
+		return x.Accepted
+	}
+	return nil
+}
+
+type RotateKeyResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Validator  string `protobuf:"bytes,1,opt,name=validator,proto3" json:"validator,omitempty"`
+	NewAddress string `protobuf:"bytes,2,opt,name=new_address,json=newAddress,proto3" json:"new_address,omitempty"`
+	// BLS public key of the validator after the rotation, empty for ECDSA validators
+	NewBlsPublicKey []byte `protobuf:"bytes,3,opt,name=new_bls_public_key,json=newBlsPublicKey,proto3" json:"new_bls_public_key,omitempty"`
+}
+
+func (x *RotateKeyResp) Reset() {
+	*x = RotateKeyResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RotateKeyResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateKeyResp) ProtoMessage() {}
+
+func (x *RotateKeyResp) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateKeyResp.ProtoReflect.Descriptor instead.
+func (*RotateKeyResp) Descriptor() ([]byte, []int) {
+	return file_consensus_ibft_proto_ibft_operator_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RotateKeyResp) GetValidator() string {
+	if x != nil {
+		return x.Validator
+	}
+	return ""
+}
+
+func (x *RotateKeyResp) GetNewAddress() string {
+	if x != nil {
+		return x.NewAddress
+	}
+	return ""
+}
+
+func (x *RotateKeyResp) GetNewBlsPublicKey() []byte {
+	if x != nil {
+		return x.NewBlsPublicKey
+	}
+	return nil
+}
+
+type Snapshot_Validator struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type    string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Data    []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *Snapshot_Validator) Reset() {
+	*x = Snapshot_Validator{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Snapshot_Validator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshot_Validator) ProtoMessage() {}
+
+func (x *Snapshot_Validator) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snapshot_Validator.ProtoReflect.Descriptor instead.
+func (*Snapshot_Validator) Descriptor() ([]byte, []int) {
+	return file_consensus_ibft_proto_ibft_operator_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *Snapshot_Validator) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Snapshot_Validator) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Snapshot_Validator) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type Snapshot_Vote struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Validator string `protobuf:"bytes,1,opt,name=validator,proto3" json:"validator,omitempty"`
+	Proposed  string `protobuf:"bytes,2,opt,name=proposed,proto3" json:"proposed,omitempty"`
+	Auth      bool   `protobuf:"varint,3,opt,name=auth,proto3" json:"auth,omitempty"`
+}
+
+func (x *Snapshot_Vote) Reset() {
+	*x = Snapshot_Vote{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ProposeReq) String() string {
+func (x *Snapshot_Vote) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProposeReq) ProtoMessage() {}
+func (*Snapshot_Vote) ProtoMessage() {}
 
-func (x *ProposeReq) ProtoReflect() protoreflect.Message {
-	mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[3]
+func (x *Snapshot_Vote) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -230,50 +917,58 @@ func (x *ProposeReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProposeReq.ProtoReflect.Descriptor instead.
-func (*ProposeReq) Descriptor() ([]byte, []int) {
-	return file_consensus_ibft_proto_ibft_operator_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use Snapshot_Vote.ProtoReflect.Descriptor instead.
+func (*Snapshot_Vote) Descriptor() ([]byte, []int) {
+	return file_consensus_ibft_proto_ibft_operator_proto_rawDescGZIP(), []int{2, 1}
 }
 
-func (x *ProposeReq) GetAddress() string {
+func (x *Snapshot_Vote) GetValidator() string {
 	if x != nil {
-		return x.Address
+		return x.Validator
 	}
 	return ""
 }
 
-func (x *ProposeReq) GetAuth() bool {
+func (x *Snapshot_Vote) GetProposed() string {
+	if x != nil {
+		return x.Proposed
+	}
+	return ""
+}
+
+func (x *Snapshot_Vote) GetAuth() bool {
 	if x != nil {
 		return x.Auth
 	}
 	return false
 }
 
-type CandidatesResp struct {
+type Snapshot_Rotation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Candidates []*Candidate `protobuf:"bytes,1,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	Validator    string              `protobuf:"bytes,1,opt,name=validator,proto3" json:"validator,omitempty"`
+	NewValidator *Snapshot_Validator `protobuf:"bytes,2,opt,name=new_validator,json=newValidator,proto3" json:"new_validator,omitempty"`
 }
 
-func (x *CandidatesResp) Reset() {
-	*x = CandidatesResp{}
+func (x *Snapshot_Rotation) Reset() {
+	*x = Snapshot_Rotation{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[4]
+		mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CandidatesResp) String() string {
+func (x *Snapshot_Rotation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CandidatesResp) ProtoMessage() {}
+func (*Snapshot_Rotation) ProtoMessage() {}
 
-func (x *CandidatesResp) ProtoReflect() protoreflect.Message {
-	mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[4]
+func (x *Snapshot_Rotation) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -284,45 +979,53 @@ func (x *CandidatesResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CandidatesResp.ProtoReflect.Descriptor instead.
-func (*CandidatesResp) Descriptor() ([]byte, []int) {
-	return file_consensus_ibft_proto_ibft_operator_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use Snapshot_Rotation.ProtoReflect.Descriptor instead.
+func (*Snapshot_Rotation) Descriptor() ([]byte, []int) {
+	return file_consensus_ibft_proto_ibft_operator_proto_rawDescGZIP(), []int{2, 2}
 }
 
-func (x *CandidatesResp) GetCandidates() []*Candidate {
+func (x *Snapshot_Rotation) GetValidator() string {
 	if x != nil {
-		return x.Candidates
+		return x.Validator
+	}
+	return ""
+}
+
+func (x *Snapshot_Rotation) GetNewValidator() *Snapshot_Validator {
+	if x != nil {
+		return x.NewValidator
 	}
 	return nil
 }
 
-type Candidate struct {
+type ValidatorStatsResp_ValidatorStats struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Address   string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-	BlsPubkey []byte `protobuf:"bytes,2,opt,name=bls_pubkey,json=blsPubkey,proto3" json:"bls_pubkey,omitempty"`
-	Auth      bool   `protobuf:"varint,3,opt,name=auth,proto3" json:"auth,omitempty"`
+	Address        string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	ProposedBlocks uint64 `protobuf:"varint,2,opt,name=proposed_blocks,json=proposedBlocks,proto3" json:"proposed_blocks,omitempty"`
+	CommittedSeals uint64 `protobuf:"varint,3,opt,name=committed_seals,json=committedSeals,proto3" json:"committed_seals,omitempty"`
+	LastSeen       uint64 `protobuf:"varint,4,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
 }
 
-func (x *Candidate) Reset() {
-	*x = Candidate{}
+func (x *ValidatorStatsResp_ValidatorStats) Reset() {
+	*x = ValidatorStatsResp_ValidatorStats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[5]
+		mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Candidate) String() string {
+func (x *ValidatorStatsResp_ValidatorStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Candidate) ProtoMessage() {}
+func (*ValidatorStatsResp_ValidatorStats) ProtoMessage() {}
 
-func (x *Candidate) ProtoReflect() protoreflect.Message {
-	mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[5]
+func (x *ValidatorStatsResp_ValidatorStats) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -333,59 +1036,65 @@ func (x *Candidate) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Candidate.ProtoReflect.Descriptor instead.
-func (*Candidate) Descriptor() ([]byte, []int) {
-	return file_consensus_ibft_proto_ibft_operator_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ValidatorStatsResp_ValidatorStats.ProtoReflect.Descriptor instead.
+func (*ValidatorStatsResp_ValidatorStats) Descriptor() ([]byte, []int) {
+	return file_consensus_ibft_proto_ibft_operator_proto_rawDescGZIP(), []int{6, 0}
 }
 
-func (x *Candidate) GetAddress() string {
+func (x *ValidatorStatsResp_ValidatorStats) GetAddress() string {
 	if x != nil {
 		return x.Address
 	}
 	return ""
 }
 
-func (x *Candidate) GetBlsPubkey() []byte {
+func (x *ValidatorStatsResp_ValidatorStats) GetProposedBlocks() uint64 {
 	if x != nil {
-		return x.BlsPubkey
+		return x.ProposedBlocks
 	}
-	return nil
+	return 0
 }
 
-func (x *Candidate) GetAuth() bool {
+func (x *ValidatorStatsResp_ValidatorStats) GetCommittedSeals() uint64 {
 	if x != nil {
-		return x.Auth
+		return x.CommittedSeals
 	}
-	return false
+	return 0
 }
 
-type Snapshot_Validator struct {
+func (x *ValidatorStatsResp_ValidatorStats) GetLastSeen() uint64 {
+	if x != nil {
+		return x.LastSeen
+	}
+	return 0
+}
+
+type DelegationsResp_Delegation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type    string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
-	Data    []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Delegator string `protobuf:"bytes,1,opt,name=delegator,proto3" json:"delegator,omitempty"`
+	Amount    string `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
 }
 
-func (x *Snapshot_Validator) Reset() {
-	*x = Snapshot_Validator{}
+func (x *DelegationsResp_Delegation) Reset() {
+	*x = DelegationsResp_Delegation{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[6]
+		mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Snapshot_Validator) String() string {
+func (x *DelegationsResp_Delegation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Snapshot_Validator) ProtoMessage() {}
+func (*DelegationsResp_Delegation) ProtoMessage() {}
 
-func (x *Snapshot_Validator) ProtoReflect() protoreflect.Message {
-	mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[6]
+func (x *DelegationsResp_Delegation) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -396,59 +1105,55 @@ func (x *Snapshot_Validator) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Snapshot_Validator.ProtoReflect.Descriptor instead.
-func (*Snapshot_Validator) Descriptor() ([]byte, []int) {
-	return file_consensus_ibft_proto_ibft_operator_proto_rawDescGZIP(), []int{2, 0}
+// Deprecated: Use DelegationsResp_Delegation.ProtoReflect.Descriptor instead.
+func (*DelegationsResp_Delegation) Descriptor() ([]byte, []int) {
+	return file_consensus_ibft_proto_ibft_operator_proto_rawDescGZIP(), []int{8, 0}
 }
 
-func (x *Snapshot_Validator) GetType() string {
+func (x *DelegationsResp_Delegation) GetDelegator() string {
 	if x != nil {
-		return x.Type
+		return x.Delegator
 	}
 	return ""
 }
 
-func (x *Snapshot_Validator) GetAddress() string {
+func (x *DelegationsResp_Delegation) GetAmount() string {
 	if x != nil {
-		return x.Address
+		return x.Amount
 	}
 	return ""
 }
 
-func (x *Snapshot_Validator) GetData() []byte {
-	if x != nil {
-		return x.Data
-	}
-	return nil
-}
-
-type Snapshot_Vote struct {
+type ForkProposalsResp_ForkProposal struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Validator string `protobuf:"bytes,1,opt,name=validator,proto3" json:"validator,omitempty"`
-	Proposed  string `protobuf:"bytes,2,opt,name=proposed,proto3" json:"proposed,omitempty"`
-	Auth      bool   `protobuf:"varint,3,opt,name=auth,proto3" json:"auth,omitempty"`
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// JSON encoded IBFT fork
+	Fork     []byte   `protobuf:"bytes,2,opt,name=fork,proto3" json:"fork,omitempty"`
+	Proposer string   `protobuf:"bytes,3,opt,name=proposer,proto3" json:"proposer,omitempty"`
+	Height   uint64   `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	Votes    []string `protobuf:"bytes,5,rep,name=votes,proto3" json:"votes,omitempty"`
 }
 
-func (x *Snapshot_Vote) Reset() {
-	*x = Snapshot_Vote{}
+func (x *ForkProposalsResp_ForkProposal) Reset() {
+	*x = ForkProposalsResp_ForkProposal{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[7]
+		mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Snapshot_Vote) String() string {
+func (x *ForkProposalsResp_ForkProposal) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Snapshot_Vote) ProtoMessage() {}
+func (*ForkProposalsResp_ForkProposal) ProtoMessage() {}
 
-func (x *Snapshot_Vote) ProtoReflect() protoreflect.Message {
-	mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[7]
+func (x *ForkProposalsResp_ForkProposal) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_ibft_proto_ibft_operator_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -459,30 +1164,44 @@ func (x *Snapshot_Vote) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Snapshot_Vote.ProtoReflect.Descriptor instead.
-func (*Snapshot_Vote) Descriptor() ([]byte, []int) {
-	return file_consensus_ibft_proto_ibft_operator_proto_rawDescGZIP(), []int{2, 1}
+// Deprecated: Use ForkProposalsResp_ForkProposal.ProtoReflect.Descriptor instead.
+func (*ForkProposalsResp_ForkProposal) Descriptor() ([]byte, []int) {
+	return file_consensus_ibft_proto_ibft_operator_proto_rawDescGZIP(), []int{12, 0}
 }
 
-func (x *Snapshot_Vote) GetValidator() string {
+func (x *ForkProposalsResp_ForkProposal) GetId() string {
 	if x != nil {
-		return x.Validator
+		return x.Id
 	}
 	return ""
 }
 
-func (x *Snapshot_Vote) GetProposed() string {
+func (x *ForkProposalsResp_ForkProposal) GetFork() []byte {
 	if x != nil {
-		return x.Proposed
+		return x.Fork
+	}
+	return nil
+}
+
+func (x *ForkProposalsResp_ForkProposal) GetProposer() string {
+	if x != nil {
+		return x.Proposer
 	}
 	return ""
 }
 
-func (x *Snapshot_Vote) GetAuth() bool {
+func (x *ForkProposalsResp_ForkProposal) GetHeight() uint64 {
 	if x != nil {
-		return x.Auth
+		return x.Height
 	}
-	return false
+	return 0
+}
+
+func (x *ForkProposalsResp_ForkProposal) GetVotes() []string {
+	if x != nil {
+		return x.Votes
+	}
+	return nil
 }
 
 var File_consensus_ibft_proto_ibft_operator_proto protoreflect.FileDescriptor
@@ -498,8 +1217,8 @@ var file_consensus_ibft_proto_ibft_operator_proto_rawDesc = []byte{
 	0x3d, 0x0a, 0x0b, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x12, 0x16,
 	0x0a, 0x06, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
 	0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0xbc,
-	0x02, 0x0a, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x36, 0x0a, 0x0a, 0x76,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0xd8,
+	0x03, 0x0a, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x36, 0x0a, 0x0a, 0x76,
 	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
 	0x16, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x2e, 0x56, 0x61,
 	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
@@ -508,47 +1227,149 @@ var file_consensus_ibft_proto_ibft_operator_proto_rawDesc = []byte{
 	0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12,
 	0x27, 0x0a, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
 	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x2e, 0x56, 0x6f, 0x74,
-	0x65, 0x52, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x1a, 0x4d, 0x0a, 0x09, 0x56, 0x61, 0x6c, 0x69,
-	0x64, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64,
-	0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72,
-	0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x54, 0x0a, 0x04, 0x56, 0x6f, 0x74, 0x65, 0x12,
-	0x1c, 0x0a, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x1a, 0x0a,
-	0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x75, 0x74,
-	0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68, 0x22, 0x3a, 0x0a,
-	0x0a, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x61,
-	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64,
-	0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68, 0x22, 0x3f, 0x0a, 0x0e, 0x43, 0x61, 0x6e,
-	0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2d, 0x0a, 0x0a, 0x63,
-	0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x0d, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x0a,
-	0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x22, 0x58, 0x0a, 0x09, 0x43, 0x61,
-	0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x65, 0x52, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x33, 0x0a, 0x09, 0x72, 0x6f, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x2e, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x09, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x4d, 0x0a,
+	0x09, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x54, 0x0a, 0x04,
+	0x56, 0x6f, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x6f, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x61, 0x75,
+	0x74, 0x68, 0x1a, 0x65, 0x0a, 0x08, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c,
+	0x0a, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x3b, 0x0a, 0x0d,
+	0x6e, 0x65, 0x77, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x0c, 0x6e, 0x65, 0x77,
+	0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x22, 0x3a, 0x0a, 0x0a, 0x50, 0x72, 0x6f,
+	0x70, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
 	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x73, 0x5f, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x6c, 0x73, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79,
-	0x12, 0x12, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04,
-	0x61, 0x75, 0x74, 0x68, 0x32, 0xde, 0x01, 0x0a, 0x0c, 0x49, 0x62, 0x66, 0x74, 0x4f, 0x70, 0x65,
-	0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x2c, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x53, 0x6e, 0x61, 0x70,
-	0x73, 0x68, 0x6f, 0x74, 0x12, 0x0f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
-	0x6f, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73,
-	0x68, 0x6f, 0x74, 0x12, 0x30, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x12, 0x0d,
-	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x1a, 0x16, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x38, 0x0a, 0x0a, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61,
-	0x74, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x12, 0x2e, 0x76, 0x31,
-	0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12,
-	0x34, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x1a, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x62, 0x66, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x42, 0x17, 0x5a, 0x15, 0x2f, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e,
-	0x73, 0x75, 0x73, 0x2f, 0x69, 0x62, 0x66, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x04, 0x61, 0x75, 0x74, 0x68, 0x22, 0x3f, 0x0a, 0x0e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61,
+	0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2d, 0x0a, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69,
+	0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x0a, 0x63, 0x61, 0x6e, 0x64,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x22, 0x58, 0x0a, 0x09, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64,
+	0x61, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1d, 0x0a,
+	0x0a, 0x62, 0x6c, 0x73, 0x5f, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x09, 0x62, 0x6c, 0x73, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04,
+	0x61, 0x75, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68,
+	0x22, 0xb3, 0x02, 0x0a, 0x12, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f,
+	0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12,
+	0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x66,
+	0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x02, 0x74, 0x6f, 0x12, 0x45, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x2e,
+	0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0a,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x1a, 0x99, 0x01, 0x0a, 0x0e, 0x56,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x72, 0x6f, 0x70, 0x6f,
+	0x73, 0x65, 0x64, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0e, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73,
+	0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x65,
+	0x61, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x74, 0x65, 0x64, 0x53, 0x65, 0x61, 0x6c, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x73,
+	0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6c, 0x61,
+	0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x22, 0x2e, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x12, 0x1c, 0x0a, 0x09, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x22, 0x83, 0x02, 0x0a, 0x0f, 0x44, 0x65, 0x6c, 0x65, 0x67,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x6b,
+	0x65, 0x64, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x64, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x27, 0x0a,
+	0x0f, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x6b, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65,
+	0x64, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x12, 0x40, 0x0a, 0x0b, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x76, 0x31,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x64, 0x65, 0x6c,
+	0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x42, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65,
+	0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61,
+	0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x65, 0x67,
+	0x61, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x24, 0x0a, 0x0e,
+	0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x46, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x12, 0x12,
+	0x0a, 0x04, 0x66, 0x6f, 0x72, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x66, 0x6f,
+	0x72, 0x6b, 0x22, 0x21, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x46, 0x6f, 0x72,
+	0x6b, 0x52, 0x65, 0x73, 0x70, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x1d, 0x0a, 0x0b, 0x56, 0x6f, 0x74, 0x65, 0x46, 0x6f, 0x72,
+	0x6b, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x22, 0x8f, 0x02, 0x0a, 0x11, 0x46, 0x6f, 0x72, 0x6b, 0x50, 0x72, 0x6f,
+	0x70, 0x6f, 0x73, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x3c, 0x0a, 0x07, 0x70, 0x65,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x76, 0x31,
+	0x2e, 0x46, 0x6f, 0x72, 0x6b, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x2e, 0x46, 0x6f, 0x72, 0x6b, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x52,
+	0x07, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x3e, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x65,
+	0x70, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x76, 0x31, 0x2e,
+	0x46, 0x6f, 0x72, 0x6b, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x2e, 0x46, 0x6f, 0x72, 0x6b, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x52, 0x08,
+	0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x1a, 0x7c, 0x0a, 0x0c, 0x46, 0x6f, 0x72, 0x6b,
+	0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x6f, 0x72, 0x6b,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x66, 0x6f, 0x72, 0x6b, 0x12, 0x1a, 0x0a, 0x08,
+	0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x22, 0x7b, 0x0a, 0x0d, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65,
+	0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x77, 0x5f, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x77, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2b, 0x0a, 0x12, 0x6e, 0x65, 0x77, 0x5f, 0x62, 0x6c,
+	0x73, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0f, 0x6e, 0x65, 0x77, 0x42, 0x6c, 0x73, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x4b, 0x65, 0x79, 0x32, 0xbd, 0x04, 0x0a, 0x0c, 0x49, 0x62, 0x66, 0x74, 0x4f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x12, 0x2c, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x12, 0x0f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x52, 0x65, 0x71, 0x1a, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x12, 0x30, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x12, 0x0d, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x1a, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x12, 0x38, 0x0a, 0x0a, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x12, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x34,
+	0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x1a, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x62, 0x66, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x12, 0x40, 0x0a, 0x0e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16,
+	0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x36, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x67,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x44,
+	0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x36,
+	0x0a, 0x0b, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x46, 0x6f, 0x72, 0x6b, 0x12, 0x12, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x46, 0x6f, 0x72, 0x6b, 0x52, 0x65,
+	0x71, 0x1a, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x46, 0x6f,
+	0x72, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x12, 0x33, 0x0a, 0x08, 0x56, 0x6f, 0x74, 0x65, 0x46, 0x6f,
+	0x72, 0x6b, 0x12, 0x0f, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x6f, 0x74, 0x65, 0x46, 0x6f, 0x72, 0x6b,
+	0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3e, 0x0a, 0x0d, 0x46,
+	0x6f, 0x72, 0x6b, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x73, 0x12, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f, 0x72, 0x6b, 0x50, 0x72,
+	0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x36, 0x0a, 0x09, 0x52,
+	0x6f, 0x74, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x1a, 0x11, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x52,
+	0x65, 0x73, 0x70, 0x42, 0x17, 0x5a, 0x15, 0x2f, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75,
+	0x73, 0x2f, 0x69, 0x62, 0x66, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -563,35 +1384,65 @@ func file_consensus_ibft_proto_ibft_operator_proto_rawDescGZIP() []byte {
 	return file_consensus_ibft_proto_ibft_operator_proto_rawDescData
 }
 
-var file_consensus_ibft_proto_ibft_operator_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_consensus_ibft_proto_ibft_operator_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_consensus_ibft_proto_ibft_operator_proto_goTypes = []interface{}{
-	(*IbftStatusResp)(nil),     // 0: v1.IbftStatusResp
-	(*SnapshotReq)(nil),        // 1: v1.SnapshotReq
-	(*Snapshot)(nil),           // 2: v1.Snapshot
-	(*ProposeReq)(nil),         // 3: v1.ProposeReq
-	(*CandidatesResp)(nil),     // 4: v1.CandidatesResp
-	(*Candidate)(nil),          // 5: v1.Candidate
-	(*Snapshot_Validator)(nil), // 6: v1.Snapshot.Validator
-	(*Snapshot_Vote)(nil),      // 7: v1.Snapshot.Vote
-	(*empty.Empty)(nil),        // 8: google.protobuf.Empty
+	(*IbftStatusResp)(nil),                    // 0: v1.IbftStatusResp
+	(*SnapshotReq)(nil),                       // 1: v1.SnapshotReq
+	(*Snapshot)(nil),                          // 2: v1.Snapshot
+	(*ProposeReq)(nil),                        // 3: v1.ProposeReq
+	(*CandidatesResp)(nil),                    // 4: v1.CandidatesResp
+	(*Candidate)(nil),                         // 5: v1.Candidate
+	(*ValidatorStatsResp)(nil),                // 6: v1.ValidatorStatsResp
+	(*DelegationsReq)(nil),                    // 7: v1.DelegationsReq
+	(*DelegationsResp)(nil),                   // 8: v1.DelegationsResp
+	(*ProposeForkReq)(nil),                    // 9: v1.ProposeForkReq
+	(*ProposeForkResp)(nil),                   // 10: v1.ProposeForkResp
+	(*VoteForkReq)(nil),                       // 11: v1.VoteForkReq
+	(*ForkProposalsResp)(nil),                 // 12: v1.ForkProposalsResp
+	(*RotateKeyResp)(nil),                     // 13: v1.RotateKeyResp
+	(*Snapshot_Validator)(nil),                // 14: v1.Snapshot.Validator
+	(*Snapshot_Vote)(nil),                     // 15: v1.Snapshot.Vote
+	(*Snapshot_Rotation)(nil),                 // 16: v1.Snapshot.Rotation
+	(*ValidatorStatsResp_ValidatorStats)(nil), // 17: v1.ValidatorStatsResp.ValidatorStats
+	(*DelegationsResp_Delegation)(nil),        // 18: v1.DelegationsResp.Delegation
+	(*ForkProposalsResp_ForkProposal)(nil),    // 19: v1.ForkProposalsResp.ForkProposal
+	(*empty.Empty)(nil),                       // 20: google.protobuf.Empty
 }
 var file_consensus_ibft_proto_ibft_operator_proto_depIdxs = []int32{
-	6, // 0: v1.Snapshot.validators:type_name -> v1.Snapshot.Validator
-	7, // 1: v1.Snapshot.votes:type_name -> v1.Snapshot.Vote
-	5, // 2: v1.CandidatesResp.candidates:type_name -> v1.Candidate
-	1, // 3: v1.IbftOperator.GetSnapshot:input_type -> v1.SnapshotReq
-	5, // 4: v1.IbftOperator.Propose:input_type -> v1.Candidate
-	8, // 5: v1.IbftOperator.Candidates:input_type -> google.protobuf.Empty
-	8, // 6: v1.IbftOperator.Status:input_type -> google.protobuf.Empty
-	2, // 7: v1.IbftOperator.GetSnapshot:output_type -> v1.Snapshot
-	8, // 8: v1.IbftOperator.Propose:output_type -> google.protobuf.Empty
-	4, // 9: v1.IbftOperator.Candidates:output_type -> v1.CandidatesResp
-	0, // 10: v1.IbftOperator.Status:output_type -> v1.IbftStatusResp
-	7, // [7:11] is the sub-list for method output_type
-	3, // [3:7] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	14, // 0: v1.Snapshot.validators:type_name -> v1.Snapshot.Validator
+	15, // 1: v1.Snapshot.votes:type_name -> v1.Snapshot.Vote
+	16, // 2: v1.Snapshot.rotations:type_name -> v1.Snapshot.Rotation
+	5,  // 3: v1.CandidatesResp.candidates:type_name -> v1.Candidate
+	17, // 4: v1.ValidatorStatsResp.validators:type_name -> v1.ValidatorStatsResp.ValidatorStats
+	18, // 5: v1.DelegationsResp.delegations:type_name -> v1.DelegationsResp.Delegation
+	19, // 6: v1.ForkProposalsResp.pending:type_name -> v1.ForkProposalsResp.ForkProposal
+	19, // 7: v1.ForkProposalsResp.accepted:type_name -> v1.ForkProposalsResp.ForkProposal
+	14, // 8: v1.Snapshot.Rotation.new_validator:type_name -> v1.Snapshot.Validator
+	1,  // 9: v1.IbftOperator.GetSnapshot:input_type -> v1.SnapshotReq
+	5,  // 10: v1.IbftOperator.Propose:input_type -> v1.Candidate
+	20, // 11: v1.IbftOperator.Candidates:input_type -> google.protobuf.Empty
+	20, // 12: v1.IbftOperator.Status:input_type -> google.protobuf.Empty
+	20, // 13: v1.IbftOperator.ValidatorStats:input_type -> google.protobuf.Empty
+	7,  // 14: v1.IbftOperator.Delegations:input_type -> v1.DelegationsReq
+	9,  // 15: v1.IbftOperator.ProposeFork:input_type -> v1.ProposeForkReq
+	11, // 16: v1.IbftOperator.VoteFork:input_type -> v1.VoteForkReq
+	20, // 17: v1.IbftOperator.ForkProposals:input_type -> google.protobuf.Empty
+	20, // 18: v1.IbftOperator.RotateKey:input_type -> google.protobuf.Empty
+	2,  // 19: v1.IbftOperator.GetSnapshot:output_type -> v1.Snapshot
+	20, // 20: v1.IbftOperator.Propose:output_type -> google.protobuf.Empty
+	4,  // 21: v1.IbftOperator.Candidates:output_type -> v1.CandidatesResp
+	0,  // 22: v1.IbftOperator.Status:output_type -> v1.IbftStatusResp
+	6,  // 23: v1.IbftOperator.ValidatorStats:output_type -> v1.ValidatorStatsResp
+	8,  // 24: v1.IbftOperator.Delegations:output_type -> v1.DelegationsResp
+	10, // 25: v1.IbftOperator.ProposeFork:output_type -> v1.ProposeForkResp
+	20, // 26: v1.IbftOperator.VoteFork:output_type -> google.protobuf.Empty
+	12, // 27: v1.IbftOperator.ForkProposals:output_type -> v1.ForkProposalsResp
+	13, // 28: v1.IbftOperator.RotateKey:output_type -> v1.RotateKeyResp
+	19, // [19:29] is the sub-list for method output_type
+	9,  // [9:19] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_consensus_ibft_proto_ibft_operator_proto_init() }
@@ -673,7 +1524,7 @@ func file_consensus_ibft_proto_ibft_operator_proto_init() {
 			}
 		}
 		file_consensus_ibft_proto_ibft_operator_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Snapshot_Validator); i {
+			switch v := v.(*ValidatorStatsResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -685,6 +1536,102 @@ func file_consensus_ibft_proto_ibft_operator_proto_init() {
 			}
 		}
 		file_consensus_ibft_proto_ibft_operator_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DelegationsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_ibft_proto_ibft_operator_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DelegationsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_ibft_proto_ibft_operator_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProposeForkReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_ibft_proto_ibft_operator_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProposeForkResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_ibft_proto_ibft_operator_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VoteForkReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_ibft_proto_ibft_operator_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ForkProposalsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_ibft_proto_ibft_operator_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RotateKeyResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_ibft_proto_ibft_operator_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Snapshot_Validator); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_ibft_proto_ibft_operator_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Snapshot_Vote); i {
 			case 0:
 				return &v.state
@@ -696,6 +1643,54 @@ func file_consensus_ibft_proto_ibft_operator_proto_init() {
 				return nil
 			}
 		}
+		file_consensus_ibft_proto_ibft_operator_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Snapshot_Rotation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_ibft_proto_ibft_operator_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidatorStatsResp_ValidatorStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_ibft_proto_ibft_operator_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DelegationsResp_Delegation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_ibft_proto_ibft_operator_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ForkProposalsResp_ForkProposal); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -703,7 +1698,7 @@ func file_consensus_ibft_proto_ibft_operator_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_consensus_ibft_proto_ibft_operator_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -27,6 +27,12 @@ type IbftOperatorClient interface {
 	Propose(ctx context.Context, in *Candidate, opts ...grpc.CallOption) (*empty.Empty, error)
 	Candidates(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CandidatesResp, error)
 	Status(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*IbftStatusResp, error)
+	ValidatorStats(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ValidatorStatsResp, error)
+	Delegations(ctx context.Context, in *DelegationsReq, opts ...grpc.CallOption) (*DelegationsResp, error)
+	ProposeFork(ctx context.Context, in *ProposeForkReq, opts ...grpc.CallOption) (*ProposeForkResp, error)
+	VoteFork(ctx context.Context, in *VoteForkReq, opts ...grpc.CallOption) (*empty.Empty, error)
+	ForkProposals(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ForkProposalsResp, error)
+	RotateKey(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RotateKeyResp, error)
 }
 
 type ibftOperatorClient struct {
@@ -73,6 +79,60 @@ func (c *ibftOperatorClient) Status(ctx context.Context, in *empty.Empty, opts .
 	return out, nil
 }
 
+func (c *ibftOperatorClient) ValidatorStats(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ValidatorStatsResp, error) {
+	out := new(ValidatorStatsResp)
+	err := c.cc.Invoke(ctx, "/v1.IbftOperator/ValidatorStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ibftOperatorClient) Delegations(ctx context.Context, in *DelegationsReq, opts ...grpc.CallOption) (*DelegationsResp, error) {
+	out := new(DelegationsResp)
+	err := c.cc.Invoke(ctx, "/v1.IbftOperator/Delegations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ibftOperatorClient) ProposeFork(ctx context.Context, in *ProposeForkReq, opts ...grpc.CallOption) (*ProposeForkResp, error) {
+	out := new(ProposeForkResp)
+	err := c.cc.Invoke(ctx, "/v1.IbftOperator/ProposeFork", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ibftOperatorClient) VoteFork(ctx context.Context, in *VoteForkReq, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/v1.IbftOperator/VoteFork", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ibftOperatorClient) ForkProposals(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ForkProposalsResp, error) {
+	out := new(ForkProposalsResp)
+	err := c.cc.Invoke(ctx, "/v1.IbftOperator/ForkProposals", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ibftOperatorClient) RotateKey(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RotateKeyResp, error) {
+	out := new(RotateKeyResp)
+	err := c.cc.Invoke(ctx, "/v1.IbftOperator/RotateKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // IbftOperatorServer is the server API for IbftOperator service.
 // All implementations must embed UnimplementedIbftOperatorServer
 // for forward compatibility
@@ -81,6 +141,12 @@ type IbftOperatorServer interface {
 	Propose(context.Context, *Candidate) (*empty.Empty, error)
 	Candidates(context.Context, *empty.Empty) (*CandidatesResp, error)
 	Status(context.Context, *empty.Empty) (*IbftStatusResp, error)
+	ValidatorStats(context.Context, *empty.Empty) (*ValidatorStatsResp, error)
+	Delegations(context.Context, *DelegationsReq) (*DelegationsResp, error)
+	ProposeFork(context.Context, *ProposeForkReq) (*ProposeForkResp, error)
+	VoteFork(context.Context, *VoteForkReq) (*empty.Empty, error)
+	ForkProposals(context.Context, *empty.Empty) (*ForkProposalsResp, error)
+	RotateKey(context.Context, *empty.Empty) (*RotateKeyResp, error)
 	mustEmbedUnimplementedIbftOperatorServer()
 }
 
@@ -100,6 +166,24 @@ func (UnimplementedIbftOperatorServer) Candidates(context.Context, *empty.Empty)
 func (UnimplementedIbftOperatorServer) Status(context.Context, *empty.Empty) (*IbftStatusResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
 }
+func (UnimplementedIbftOperatorServer) ValidatorStats(context.Context, *empty.Empty) (*ValidatorStatsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidatorStats not implemented")
+}
+func (UnimplementedIbftOperatorServer) Delegations(context.Context, *DelegationsReq) (*DelegationsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delegations not implemented")
+}
+func (UnimplementedIbftOperatorServer) ProposeFork(context.Context, *ProposeForkReq) (*ProposeForkResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProposeFork not implemented")
+}
+func (UnimplementedIbftOperatorServer) VoteFork(context.Context, *VoteForkReq) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VoteFork not implemented")
+}
+func (UnimplementedIbftOperatorServer) ForkProposals(context.Context, *empty.Empty) (*ForkProposalsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForkProposals not implemented")
+}
+func (UnimplementedIbftOperatorServer) RotateKey(context.Context, *empty.Empty) (*RotateKeyResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateKey not implemented")
+}
 func (UnimplementedIbftOperatorServer) mustEmbedUnimplementedIbftOperatorServer() {}
 
 // UnsafeIbftOperatorServer may be embedded to opt out of forward compatibility for this service.
@@ -185,6 +269,114 @@ func _IbftOperator_Status_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _IbftOperator_ValidatorStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IbftOperatorServer).ValidatorStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.IbftOperator/ValidatorStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IbftOperatorServer).ValidatorStats(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IbftOperator_Delegations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DelegationsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IbftOperatorServer).Delegations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.IbftOperator/Delegations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IbftOperatorServer).Delegations(ctx, req.(*DelegationsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IbftOperator_ProposeFork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProposeForkReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IbftOperatorServer).ProposeFork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.IbftOperator/ProposeFork",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IbftOperatorServer).ProposeFork(ctx, req.(*ProposeForkReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IbftOperator_VoteFork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoteForkReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IbftOperatorServer).VoteFork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.IbftOperator/VoteFork",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IbftOperatorServer).VoteFork(ctx, req.(*VoteForkReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IbftOperator_ForkProposals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IbftOperatorServer).ForkProposals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.IbftOperator/ForkProposals",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IbftOperatorServer).ForkProposals(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IbftOperator_RotateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IbftOperatorServer).RotateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.IbftOperator/RotateKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IbftOperatorServer).RotateKey(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // IbftOperator_ServiceDesc is the grpc.ServiceDesc for IbftOperator service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -208,6 +400,30 @@ var IbftOperator_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Status",
 			Handler:    _IbftOperator_Status_Handler,
 		},
+		{
+			MethodName: "ValidatorStats",
+			Handler:    _IbftOperator_ValidatorStats_Handler,
+		},
+		{
+			MethodName: "Delegations",
+			Handler:    _IbftOperator_Delegations_Handler,
+		},
+		{
+			MethodName: "ProposeFork",
+			Handler:    _IbftOperator_ProposeFork_Handler,
+		},
+		{
+			MethodName: "VoteFork",
+			Handler:    _IbftOperator_VoteFork_Handler,
+		},
+		{
+			MethodName: "ForkProposals",
+			Handler:    _IbftOperator_ForkProposals_Handler,
+		},
+		{
+			MethodName: "RotateKey",
+			Handler:    _IbftOperator_RotateKey_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "consensus/ibft/proto/ibft_operator.proto",
@@ -0,0 +1,304 @@
+package ibft
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+)
+
+var (
+	errUnsupportedBatchVerification = errors.New("seals can't be verified in batch")
+)
+
+const (
+	// preVerifiedSealsCacheSize is the number of headers whose seals are kept as verified in advance
+	preVerifiedSealsCacheSize = 4096
+)
+
+// preVerifiedSeals is a result of verifying the seals of a header in advance
+type preVerifiedSeals struct {
+	// validators that signed the Committed Seals
+	validators validators.Validators
+	// validators that signed the Parent Committed Seals, nil if they have not been verified
+	parentValidators validators.Validators
+}
+
+// preVerifiedSealsKey returns the cache key of the given header.
+// Header hash doesn't cover the seals in IBFT Extra, so whole Extra is included in the key
+func preVerifiedSealsKey(header *types.Header) types.Hash {
+	return types.BytesToHash(crypto.Keccak256(header.Hash.Bytes(), header.ExtraData))
+}
+
+// PreVerifyHeaders verifies the Committed Seals and the Parent Committed Seals of the given
+// contiguous headers following the parent concurrently, using the validators written in the IBFT Extra
+// of each header. VerifyHeader skips the seal verification of the headers later
+// if the validators turn out to be the ones expected at the height
+func (i *backendIBFT) PreVerifyHeaders(parent *types.Header, headers []*types.Header) error {
+	// fast path for the aggregated seals, the headers are verified one by one if it fails
+	// in order to find the invalid header
+	if i.preVerifyAggregatedSeals(parent, headers) {
+		return nil
+	}
+
+	var (
+		errs       = make([]error, len(headers))
+		jobCh      = make(chan int)
+		numWorkers = runtime.NumCPU()
+		wg         sync.WaitGroup
+	)
+
+	if numWorkers > len(headers) {
+		numWorkers = len(headers)
+	}
+
+	for w := 0; w < numWorkers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for idx := range jobCh {
+				headerParent := parent
+				if idx > 0 {
+					headerParent = headers[idx-1]
+				}
+
+				errs[idx] = i.preVerifyHeaderSeals(headerParent, headers[idx])
+			}
+		}()
+	}
+
+	for idx := range headers {
+		jobCh <- idx
+	}
+
+	close(jobCh)
+	wg.Wait()
+
+	for idx, err := range errs {
+		if err != nil {
+			return fmt.Errorf("failed to verify seals of header %d: %w", headers[idx].Number, err)
+		}
+	}
+
+	return nil
+}
+
+// preVerifyHeaderSeals verifies the seals of the header and stores the result.
+// Parent Committed Seals are verified only if the parent is given
+func (i *backendIBFT) preVerifyHeaderSeals(parent, header *types.Header) error {
+	// the voting powers need the state, so the seals are verified in VerifyHeader
+	if i.forkManager.IsWeightedQuorum(header.Number) {
+		return nil
+	}
+
+	headerSigner, err := i.forkManager.GetSigner(header.Number)
+	if err != nil {
+		return err
+	}
+
+	headerValidators, err := headerSigner.GetValidators(header)
+	if err != nil {
+		return err
+	}
+
+	if err := headerSigner.VerifyCommittedSeals(
+		header,
+		headerValidators,
+		i.quorumSize(header.Number)(headerValidators),
+	); err != nil {
+		return err
+	}
+
+	result := &preVerifiedSeals{
+		validators: headerValidators,
+	}
+
+	if parent != nil && !parent.IsGenesis() && !i.forkManager.IsWeightedQuorum(parent.Number) {
+		parentSigner, err := i.forkManager.GetSigner(parent.Number)
+		if err != nil {
+			return err
+		}
+
+		parentValidators, err := parentSigner.GetValidators(parent)
+		if err != nil {
+			return err
+		}
+
+		if err := parentSigner.VerifyParentCommittedSeals(
+			parent,
+			header,
+			parentValidators,
+			i.quorumSize(parent.Number)(parentValidators),
+			false,
+		); err != nil {
+			return err
+		}
+
+		result.parentValidators = parentValidators
+	}
+
+	if i.preVerifiedSeals != nil {
+		i.preVerifiedSeals.Add(preVerifiedSealsKey(header), result)
+	}
+
+	return nil
+}
+
+// preVerifyAggregatedSeals verifies the aggregated BLS seals of all the given headers by a batched pairing check
+// and stores the results. It returns false if any header can't be verified in the batch or the batch is invalid
+func (i *backendIBFT) preVerifyAggregatedSeals(parent *types.Header, headers []*types.Header) bool {
+	var (
+		items   = make([]*signer.AggregatedSealsBatchItem, 0, 2*len(headers))
+		results = make([]*preVerifiedSeals, len(headers))
+	)
+
+	for idx, header := range headers {
+		headerParent := parent
+		if idx > 0 {
+			headerParent = headers[idx-1]
+		}
+
+		headerItems, result, err := i.getAggregatedSealsBatchItems(headerParent, header)
+		if err != nil {
+			return false
+		}
+
+		items = append(items, headerItems...)
+		results[idx] = result
+	}
+
+	if err := signer.VerifyAggregatedSealsBatch(items); err != nil {
+		i.logger.Debug("failed to verify aggregated seals in batch", "err", err)
+
+		return false
+	}
+
+	if i.preVerifiedSeals != nil {
+		for idx, header := range headers {
+			i.preVerifiedSeals.Add(preVerifiedSealsKey(header), results[idx])
+		}
+	}
+
+	return true
+}
+
+// getAggregatedSealsBatchItems returns the aggregated seals in the header to be verified in a batch
+// and the result stored after the verification.
+// Parent Committed Seals are included only if the parent is given
+func (i *backendIBFT) getAggregatedSealsBatchItems(
+	parent, header *types.Header,
+) ([]*signer.AggregatedSealsBatchItem, *preVerifiedSeals, error) {
+	// the voting powers need the state, so the seals are verified in VerifyHeader
+	if i.forkManager.IsWeightedQuorum(header.Number) {
+		return nil, nil, errUnsupportedBatchVerification
+	}
+
+	headerSigner, err := i.forkManager.GetSigner(header.Number)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if headerSigner.Type() != validators.BLSValidatorType {
+		return nil, nil, errUnsupportedBatchVerification
+	}
+
+	headerValidators, err := headerSigner.GetValidators(header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	item, err := headerSigner.GetCommittedSealsBatchItem(
+		header,
+		headerValidators,
+		i.quorumSize(header.Number)(headerValidators),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		items  = []*signer.AggregatedSealsBatchItem{item}
+		result = &preVerifiedSeals{
+			validators: headerValidators,
+		}
+	)
+
+	if parent == nil || parent.IsGenesis() {
+		return items, result, nil
+	}
+
+	if i.forkManager.IsWeightedQuorum(parent.Number) {
+		return nil, nil, errUnsupportedBatchVerification
+	}
+
+	parentSigner, err := i.forkManager.GetSigner(parent.Number)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	parentValidators, err := parentSigner.GetValidators(parent)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	parentItem, err := parentSigner.GetParentCommittedSealsBatchItem(
+		parent,
+		header,
+		parentValidators,
+		i.quorumSize(parent.Number)(parentValidators),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the header may not have Parent Committed Seals for backward compatibility
+	if parentItem != nil {
+		items = append(items, parentItem)
+	}
+
+	result.parentValidators = parentValidators
+
+	return items, result, nil
+}
+
+// getPreVerifiedSeals returns the result of the verification in advance for the header
+func (i *backendIBFT) getPreVerifiedSeals(header *types.Header) (*preVerifiedSeals, bool) {
+	if i.preVerifiedSeals == nil {
+		return nil, false
+	}
+
+	raw, ok := i.preVerifiedSeals.Get(preVerifiedSealsKey(header))
+	if !ok {
+		return nil, false
+	}
+
+	result, ok := raw.(*preVerifiedSeals)
+
+	return result, ok
+}
+
+// hasPreVerifiedCommittedSeals returns whether Committed Seals of the header
+// have been verified in advance by the given validators
+func (i *backendIBFT) hasPreVerifiedCommittedSeals(header *types.Header, validators validators.Validators) bool {
+	result, ok := i.getPreVerifiedSeals(header)
+
+	return ok && result.validators.Equal(validators)
+}
+
+// hasPreVerifiedParentCommittedSeals returns whether Parent Committed Seals of the header
+// have been verified in advance by the given validators
+func (i *backendIBFT) hasPreVerifiedParentCommittedSeals(
+	header *types.Header,
+	parentValidators validators.Validators,
+) bool {
+	result, ok := i.getPreVerifiedSeals(header)
+
+	return ok && result.parentValidators != nil && result.parentValidators.Equal(parentValidators)
+}
@@ -0,0 +1,59 @@
+package ibft
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeals_PreVerifiedSeals(t *testing.T) {
+	t.Parallel()
+
+	pool := newTesterAccountPool(t)
+	pool.add("A", "B", "C")
+
+	validatorSet := pool.ValidatorSet()
+
+	pool.add("D")
+
+	otherValidatorSet := pool.ValidatorSet()
+
+	cache, err := lru.New(preVerifiedSealsCacheSize)
+	assert.NoError(t, err)
+
+	i := &backendIBFT{
+		preVerifiedSeals: cache,
+	}
+
+	header := (&types.Header{
+		Number:    10,
+		ExtraData: []byte{0x1},
+	}).ComputeHash()
+
+	// only the committed seals have been verified
+	i.preVerifiedSeals.Add(preVerifiedSealsKey(header), &preVerifiedSeals{
+		validators: validatorSet,
+	})
+
+	assert.True(t, i.hasPreVerifiedCommittedSeals(header, validatorSet))
+	assert.False(t, i.hasPreVerifiedCommittedSeals(header, otherValidatorSet))
+	assert.False(t, i.hasPreVerifiedParentCommittedSeals(header, validatorSet))
+
+	// the seals in the extra are not covered by the header hash
+	resealed := header.Copy()
+	resealed.ExtraData = []byte{0x2}
+
+	assert.Equal(t, header.Hash, resealed.Hash)
+	assert.False(t, i.hasPreVerifiedCommittedSeals(resealed, validatorSet))
+
+	// the parent committed seals have been verified as well
+	i.preVerifiedSeals.Add(preVerifiedSealsKey(header), &preVerifiedSeals{
+		validators:       validatorSet,
+		parentValidators: validatorSet,
+	})
+
+	assert.True(t, i.hasPreVerifiedParentCommittedSeals(header, validatorSet))
+	assert.False(t, i.hasPreVerifiedParentCommittedSeals(header, otherValidatorSet))
+}
@@ -2,17 +2,29 @@ package signer
 
 import (
 	"crypto/ecdsa"
+	"crypto/rand"
 	"fmt"
 	"math/big"
+	"math/bits"
 
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/coinbase/kryptology/pkg/core/curves/native"
+	"github.com/coinbase/kryptology/pkg/core/curves/native/bls12381"
 	"github.com/coinbase/kryptology/pkg/signatures/bls/bls_sig"
 	"github.com/umbracle/fastrlp"
 )
 
+const (
+	// blsSignatureDST is the domain separation tag of the BLS signatures in the proof of possession scheme
+	blsSignatureDST = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+
+	// batchScalarBytes is the size of the random scalars multiplied to the seals in the batch verification
+	batchScalarBytes = 16
+)
+
 // BLSKeyManager is a module that holds ECDSA and BLS keys
 // and implements methods of signing by these keys
 type BLSKeyManager struct {
@@ -23,6 +35,10 @@ type BLSKeyManager struct {
 
 // NewBLSKeyManager initializes BLSKeyManager by the ECDSA key and BLS key which are loaded from SecretsManager
 func NewBLSKeyManager(manager secrets.SecretsManager) (KeyManager, error) {
+	if signer, ok := secrets.GetSigner(manager, secrets.ValidatorKey); ok {
+		return newSecretsSignerKeyManager(manager, signer, validators.BLSValidatorType)
+	}
+
 	ecdsaKey, err := getOrCreateECDSAKey(manager)
 	if err != nil {
 		return nil, err
@@ -150,6 +166,39 @@ func (s *BLSKeyManager) VerifyCommittedSeals(
 	return verifyBLSCommittedSealsImpl(committedSeal, message, vals)
 }
 
+func (s *BLSKeyManager) GetCommittedSealSigners(
+	rawCommittedSeal Seals,
+	_ []byte,
+	vals validators.Validators,
+) ([]types.Address, error) {
+	committedSeal, ok := rawCommittedSeal.(*AggregatedSeal)
+	if !ok {
+		return nil, ErrInvalidCommittedSealType
+	}
+
+	if committedSeal.Bitmap == nil {
+		return nil, nil
+	}
+
+	signers := make([]types.Address, 0, vals.Len())
+
+	// the signers are identified by the bitmap, the signature is verified separately
+	for idx := 0; idx < vals.Len(); idx++ {
+		if committedSeal.Bitmap.Bit(idx) == 0 {
+			continue
+		}
+
+		validator := vals.At(uint64(idx))
+		if validator == nil {
+			return nil, ErrValidatorNotFound
+		}
+
+		signers = append(signers, validator.Addr())
+	}
+
+	return signers, nil
+}
+
 func (s *BLSKeyManager) SignIBFTMessage(msg []byte) ([]byte, error) {
 	return crypto.Sign(s.ecdsaKey, msg)
 }
@@ -163,8 +212,19 @@ type AggregatedSeal struct {
 	Signature []byte
 }
 
+// Num returns the number of the signers in the bitmap
 func (s *AggregatedSeal) Num() int {
-	return s.Bitmap.BitLen()
+	if s.Bitmap == nil {
+		return 0
+	}
+
+	num := 0
+
+	for _, word := range s.Bitmap.Bits() {
+		num += bits.OnesCount(uint(word))
+	}
+
+	return num
 }
 
 func (s *AggregatedSeal) MarshalRLPWith(ar *fastrlp.Arena) *fastrlp.Value {
@@ -311,3 +371,154 @@ func verifyBLSCommittedSealsImpl(
 
 	return numKeys, nil
 }
+
+// verifySealsBitmap checks the bitmap of the aggregated seals doesn't refer to the outside of the validators
+func verifySealsBitmap(seals Seals, vals validators.Validators) error {
+	aggregatedSeal, ok := seals.(*AggregatedSeal)
+	if !ok || aggregatedSeal.Bitmap == nil {
+		return nil
+	}
+
+	if aggregatedSeal.Bitmap.BitLen() > vals.Len() {
+		return ErrInvalidSealsBitmap
+	}
+
+	return nil
+}
+
+// AggregatedSealsBatchItem is the aggregated seals of a header to be verified in a batch
+type AggregatedSealsBatchItem struct {
+	// Seals is the aggregated seals in the header
+	Seals *AggregatedSeal
+	// Message is the digest signed by the seals
+	Message []byte
+	// Validators is the validators who are expected to sign the message
+	Validators validators.Validators
+	// QuorumSize is the number of the signers required
+	QuorumSize int
+}
+
+// VerifyAggregatedSealsBatch verifies the aggregated seals of the multiple headers by a single pairing check.
+// Each seal is multiplied by a random scalar so that invalid seals can't cancel each other out.
+// The failure doesn't tell which seals are invalid, the seals need to be verified one by one to find them
+func VerifyAggregatedSealsBatch(items []*AggregatedSealsBatchItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	var (
+		engine              = new(bls12381.Engine)
+		aggregatedSignature = new(bls12381.G2).Identity()
+	)
+
+	for _, item := range items {
+		if len(item.Seals.Signature) == 0 || item.Seals.Num() == 0 {
+			return ErrEmptyCommittedSeals
+		}
+
+		if err := verifySealsBitmap(item.Seals, item.Validators); err != nil {
+			return err
+		}
+
+		pubKey, numKeys, err := createAggregatedBLSPubKeys(item.Validators, item.Seals.Bitmap)
+		if err != nil {
+			return fmt.Errorf("failed to aggregate BLS Public Keys: %w", err)
+		}
+
+		if numKeys < item.QuorumSize {
+			return ErrNotEnoughCommittedSeals
+		}
+
+		pubKeyPoint, err := multiPublicKeyToPoint(pubKey)
+		if err != nil {
+			return err
+		}
+
+		signaturePoint, err := signatureToPoint(item.Seals.Signature)
+		if err != nil {
+			return err
+		}
+
+		scalar, err := randomBatchScalar()
+		if err != nil {
+			return err
+		}
+
+		// e(r * pk, H(m)) for each seal
+		engine.AddPair(
+			new(bls12381.G1).Mul(pubKeyPoint, scalar),
+			new(bls12381.G2).Hash(native.EllipticPointHasherSha256(), item.Message, []byte(blsSignatureDST)),
+		)
+
+		aggregatedSignature.Add(aggregatedSignature, new(bls12381.G2).Mul(signaturePoint, scalar))
+	}
+
+	// e(r_1 * pk_1, H(m_1)) * ... * e(r_N * pk_N, H(m_N)) == e(g1, r_1 * sig_1 + ... + r_N * sig_N)
+	engine.AddPairInvG1(new(bls12381.G1).Generator(), aggregatedSignature)
+
+	if !engine.Check() {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// multiPublicKeyToPoint converts the aggregated public key to the point on G1
+func multiPublicKeyToPoint(pubKey *bls_sig.MultiPublicKey) (*bls12381.G1, error) {
+	raw, err := pubKey.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	var compressed [bls12381.FieldBytes]byte
+
+	copy(compressed[:], raw)
+
+	point, err := new(bls12381.G1).FromCompressed(&compressed)
+	if err != nil {
+		return nil, err
+	}
+
+	if point.IsIdentity() == 1 {
+		return nil, ErrInvalidSignature
+	}
+
+	return point, nil
+}
+
+// signatureToPoint converts the aggregated signature to the point on G2
+func signatureToPoint(signature []byte) (*bls12381.G2, error) {
+	if len(signature) != bls12381.WideFieldBytes {
+		return nil, ErrInvalidSignature
+	}
+
+	var compressed [bls12381.WideFieldBytes]byte
+
+	copy(compressed[:], signature)
+
+	point, err := new(bls12381.G2).FromCompressed(&compressed)
+	if err != nil {
+		return nil, err
+	}
+
+	if point.IsIdentity() == 1 || point.InCorrectSubgroup() == 0 {
+		return nil, ErrInvalidSignature
+	}
+
+	return point, nil
+}
+
+// randomBatchScalar returns a non-zero random scalar for the batch verification
+func randomBatchScalar() (*native.Field, error) {
+	buf := make([]byte, batchScalarBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+
+	value := new(big.Int).SetBytes(buf)
+	if value.Sign() == 0 {
+		value.SetUint64(1)
+	}
+
+	return bls12381.Bls12381FqNew().SetBigInt(value), nil
+}
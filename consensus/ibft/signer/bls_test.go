@@ -12,6 +12,8 @@ import (
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/coinbase/kryptology/pkg/core/curves/native"
+	"github.com/coinbase/kryptology/pkg/core/curves/native/bls12381"
 	"github.com/coinbase/kryptology/pkg/signatures/bls/bls_sig"
 	"github.com/stretchr/testify/assert"
 )
@@ -587,6 +589,38 @@ func TestBLSKeyManagerVerifyCommittedSeals(t *testing.T) {
 	}
 }
 
+func TestBLSKeyManagerGetCommittedSealSigners(t *testing.T) {
+	t.Parallel()
+
+	blsKeyManager1, _, _ := newTestBLSKeyManager(t)
+	blsKeyManager2, _, _ := newTestBLSKeyManager(t)
+	blsKeyManager3, _, _ := newTestBLSKeyManager(t)
+
+	vals := validators.NewBLSValidatorSet(
+		testBLSKeyManagerToBLSValidator(t, blsKeyManager1),
+		testBLSKeyManagerToBLSValidator(t, blsKeyManager2),
+		testBLSKeyManagerToBLSValidator(t, blsKeyManager3),
+	)
+
+	bitmap := new(big.Int)
+	bitmap.SetBit(bitmap, 0, 1)
+	bitmap.SetBit(bitmap, 2, 1)
+
+	_, err := blsKeyManager1.GetCommittedSealSigners(&SerializedSeal{}, nil, vals)
+	assert.ErrorIs(t, err, ErrInvalidCommittedSealType)
+
+	res, err := blsKeyManager1.GetCommittedSealSigners(&AggregatedSeal{Bitmap: bitmap}, nil, vals)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]types.Address{
+			blsKeyManager1.Address(),
+			blsKeyManager3.Address(),
+		},
+		res,
+	)
+}
+
 func TestBLSKeyManagerSignIBFTMessageAndEcrecover(t *testing.T) {
 	t.Parallel()
 
@@ -1071,3 +1105,160 @@ func Test_verifyBLSCommittedSealsImpl(t *testing.T) {
 		})
 	}
 }
+
+func TestAggregatedSealNum(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		seal     *AggregatedSeal
+		expected int
+	}{
+		{
+			name:     "should return zero if bitmap is nil",
+			seal:     &AggregatedSeal{},
+			expected: 0,
+		},
+		{
+			name:     "should return the number of the signers in the bitmap",
+			seal:     newTestAggregatedSeals([]int{0, 2, 5}, nil),
+			expected: 3,
+		},
+		{
+			name:     "should count the signers over the word size",
+			seal:     newTestAggregatedSeals([]int{1, 64, 130}, nil),
+			expected: 3,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, test.seal.Num())
+		})
+	}
+}
+
+func TestVerifyAggregatedSealsBatch(t *testing.T) {
+	t.Parallel()
+
+	validatorKeyManager1, _, _ := newTestBLSKeyManager(t)
+	validatorKeyManager2, _, _ := newTestBLSKeyManager(t)
+	validatorKeyManager3, _, _ := newTestBLSKeyManager(t)
+
+	vals := validators.NewBLSValidatorSet(
+		testBLSKeyManagerToBLSValidator(t, validatorKeyManager1),
+		testBLSKeyManagerToBLSValidator(t, validatorKeyManager2),
+		testBLSKeyManagerToBLSValidator(t, validatorKeyManager3),
+	)
+
+	msg1 := crypto.Keccak256(wrapCommitHash([]byte("header1")))
+	msg2 := crypto.Keccak256(wrapCommitHash([]byte("header2")))
+
+	sig1 := testCreateAggregatedSignature(t, msg1, validatorKeyManager1, validatorKeyManager2)
+	sig2 := testCreateAggregatedSignature(t, msg2, validatorKeyManager2, validatorKeyManager3)
+
+	// shift both signatures by the same point in the opposite directions,
+	// their sum doesn't change but each signature is invalid
+	shiftSignature := func(sig []byte, negate bool) []byte {
+		t.Helper()
+
+		point, err := signatureToPoint(sig)
+		assert.NoError(t, err)
+
+		delta := new(bls12381.G2).Hash(native.EllipticPointHasherSha256(), []byte("delta"), []byte(blsSignatureDST))
+		if negate {
+			delta.Neg(delta)
+		}
+
+		shifted := new(bls12381.G2).Add(point, delta).ToCompressed()
+
+		return shifted[:]
+	}
+
+	newItem := func(bitFlags []int, sig, msg []byte) *AggregatedSealsBatchItem {
+		return &AggregatedSealsBatchItem{
+			Seals:      newTestAggregatedSeals(bitFlags, sig),
+			Message:    msg,
+			Validators: vals,
+			QuorumSize: 2,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		items       []*AggregatedSealsBatchItem
+		expectedErr error
+	}{
+		{
+			name:        "should succeed if no items are given",
+			items:       nil,
+			expectedErr: nil,
+		},
+		{
+			name: "should succeed if all seals are valid",
+			items: []*AggregatedSealsBatchItem{
+				newItem([]int{0, 1}, sig1, msg1),
+				newItem([]int{1, 2}, sig2, msg2),
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "should return ErrEmptyCommittedSeals if bitmap is zero",
+			items: []*AggregatedSealsBatchItem{
+				newItem([]int{}, sig1, msg1),
+			},
+			expectedErr: ErrEmptyCommittedSeals,
+		},
+		{
+			name: "should return ErrInvalidSealsBitmap if bitmap refers to non validator",
+			items: []*AggregatedSealsBatchItem{
+				newItem([]int{0, 1, 3}, sig1, msg1),
+			},
+			expectedErr: ErrInvalidSealsBitmap,
+		},
+		{
+			name: "should return ErrNotEnoughCommittedSeals if the signers don't reach quorum",
+			items: []*AggregatedSealsBatchItem{
+				newItem([]int{0}, sig1, msg1),
+			},
+			expectedErr: ErrNotEnoughCommittedSeals,
+		},
+		{
+			name: "should return ErrInvalidSignature if one of the seals is for the different message",
+			items: []*AggregatedSealsBatchItem{
+				newItem([]int{0, 1}, sig1, msg1),
+				newItem([]int{1, 2}, sig2, msg1),
+			},
+			expectedErr: ErrInvalidSignature,
+		},
+		{
+			name: "should return ErrInvalidSignature if the invalid seals cancel each other out",
+			items: []*AggregatedSealsBatchItem{
+				newItem([]int{0, 1}, shiftSignature(sig1, false), msg1),
+				newItem([]int{1, 2}, shiftSignature(sig2, true), msg2),
+			},
+			expectedErr: ErrInvalidSignature,
+		},
+		{
+			name: "should return ErrInvalidSignature if signature has wrong length",
+			items: []*AggregatedSealsBatchItem{
+				newItem([]int{0, 1}, []byte{0x1}, msg1),
+			},
+			expectedErr: ErrInvalidSignature,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.ErrorIs(t, VerifyAggregatedSealsBatch(test.items), test.expectedErr)
+		})
+	}
+}
@@ -20,6 +20,10 @@ type ECDSAKeyManager struct {
 
 // NewECDSAKeyManager initializes ECDSAKeyManager by the ECDSA key loaded from SecretsManager
 func NewECDSAKeyManager(manager secrets.SecretsManager) (KeyManager, error) {
+	if signer, ok := secrets.GetSigner(manager, secrets.ValidatorKey); ok {
+		return newSecretsSignerKeyManager(manager, signer, validators.ECDSAValidatorType)
+	}
+
 	key, err := getOrCreateECDSAKey(manager)
 	if err != nil {
 		return nil, err
@@ -129,6 +133,34 @@ func (s *ECDSAKeyManager) VerifyCommittedSeals(
 	return s.verifyCommittedSealsImpl(committedSeal, digest, vals)
 }
 
+func (s *ECDSAKeyManager) GetCommittedSealSigners(
+	rawCommittedSeal Seals,
+	digest []byte,
+	vals validators.Validators,
+) ([]types.Address, error) {
+	committedSeal, ok := rawCommittedSeal.(*SerializedSeal)
+	if !ok {
+		return nil, ErrInvalidCommittedSealType
+	}
+
+	signers := make([]types.Address, 0, committedSeal.Num())
+
+	for _, seal := range *committedSeal {
+		addr, err := s.Ecrecover(seal, digest)
+		if err != nil {
+			return nil, err
+		}
+
+		if !vals.Includes(addr) {
+			return nil, ErrNonValidatorCommittedSeal
+		}
+
+		signers = append(signers, addr)
+	}
+
+	return signers, nil
+}
+
 func (s *ECDSAKeyManager) SignIBFTMessage(msg []byte) ([]byte, error) {
 	return crypto.Sign(s.key, msg)
 }
@@ -424,6 +424,92 @@ func TestECDSAKeyManagerVerifyCommittedSeals(t *testing.T) {
 	}
 }
 
+func TestECDSAKeyManagerGetCommittedSealSigners(t *testing.T) {
+	t.Parallel()
+
+	ecdsaKeyManager1, _ := newTestECDSAKeyManager(t)
+	ecdsaKeyManager2, _ := newTestECDSAKeyManager(t)
+
+	msg := crypto.Keccak256(
+		wrapCommitHash(
+			hex.MustDecodeHex(testHeaderHashHex),
+		),
+	)
+
+	committedSeal1, err := ecdsaKeyManager1.SignCommittedSeal(msg)
+	assert.NoError(t, err)
+
+	committedSeal2, err := ecdsaKeyManager2.SignCommittedSeal(msg)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		committedSeals Seals
+		validators     validators.Validators
+		expectedRes    []types.Address
+		expectedErr    error
+	}{
+		{
+			name:           "should return ErrInvalidCommittedSealType if the Seals is not *SerializedSeal",
+			committedSeals: &AggregatedSeal{},
+			validators:     nil,
+			expectedRes:    nil,
+			expectedErr:    ErrInvalidCommittedSealType,
+		},
+		{
+			name: "should return ErrNonValidatorCommittedSeal if the signer is not a validator",
+			committedSeals: &SerializedSeal{
+				committedSeal1,
+				committedSeal2,
+			},
+			validators: validators.NewECDSAValidatorSet(
+				validators.NewECDSAValidator(
+					ecdsaKeyManager1.Address(),
+				),
+			),
+			expectedRes: nil,
+			expectedErr: ErrNonValidatorCommittedSeal,
+		},
+		{
+			name: "should return the signers of the seals",
+			committedSeals: &SerializedSeal{
+				committedSeal2,
+				committedSeal1,
+			},
+			validators: validators.NewECDSAValidatorSet(
+				validators.NewECDSAValidator(
+					ecdsaKeyManager1.Address(),
+				),
+				validators.NewECDSAValidator(
+					ecdsaKeyManager2.Address(),
+				),
+			),
+			expectedRes: []types.Address{
+				ecdsaKeyManager2.Address(),
+				ecdsaKeyManager1.Address(),
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := ecdsaKeyManager1.GetCommittedSealSigners(
+				test.committedSeals,
+				msg,
+				test.validators,
+			)
+
+			assert.Equal(t, test.expectedRes, res)
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
 func TestECDSAKeyManagerSignIBFTMessageAndEcrecover(t *testing.T) {
 	t.Parallel()
 
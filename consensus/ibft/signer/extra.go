@@ -22,12 +22,23 @@ var (
 	zeroBytes = make([]byte, 32)
 )
 
+const (
+	// LegacyExtraVersion is the version of IBFT Extra that doesn't have the version field
+	LegacyExtraVersion uint64 = 0
+
+	// AggregatedSealsExtraVersion is the version of IBFT Extra whose seals are
+	// the bitmap of the signers and the aggregated BLS signature
+	AggregatedSealsExtraVersion uint64 = 1
+)
+
 // IstanbulExtra defines the structure of the extra field for Istanbul
 type IstanbulExtra struct {
 	Validators           validators.Validators
 	ProposerSeal         []byte
 	CommittedSeals       Seals
 	ParentCommittedSeals Seals
+	// Version is written after ParentCommittedSeals unless it's the legacy version
+	Version uint64
 }
 
 type Seals interface {
@@ -62,6 +73,14 @@ func (i *IstanbulExtra) MarshalRLPWith(ar *fastrlp.Arena) *fastrlp.Value {
 	// ParentCommittedSeal
 	if i.ParentCommittedSeals != nil {
 		vv.Set(i.ParentCommittedSeals.MarshalRLPWith(ar))
+	} else if i.Version != LegacyExtraVersion {
+		// keep the position of the version
+		vv.Set(ar.NewNullArray())
+	}
+
+	// Version
+	if i.Version != LegacyExtraVersion {
+		vv.Set(ar.NewUint(i.Version))
 	}
 
 	return vv
@@ -105,6 +124,13 @@ func (i *IstanbulExtra) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) er
 		}
 	}
 
+	// Version
+	if len(elems) >= 5 {
+		if i.Version, err = elems[4].GetUint64(); err != nil {
+			return fmt.Errorf("failed to decode Version: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -208,9 +234,9 @@ func packProposerSealIntoExtra(
 			// CommittedSeal
 			newArrayValue.Set(oldValues[2])
 
-			// ParentCommittedSeal
-			if len(oldValues) >= 4 {
-				newArrayValue.Set(oldValues[3])
+			// ParentCommittedSeal and Version
+			for _, v := range oldValues[3:] {
+				newArrayValue.Set(v)
 			}
 
 			return nil
@@ -239,9 +265,9 @@ func packCommittedSealsIntoExtra(
 			// CommittedSeal
 			newArrayValue.Set(committedSeal.MarshalRLPWith(ar))
 
-			// ParentCommittedSeal
-			if len(oldValues) >= 4 {
-				newArrayValue.Set(oldValues[3])
+			// ParentCommittedSeal and Version
+			for _, v := range oldValues[3:] {
+				newArrayValue.Set(v)
 			}
 
 			return nil
@@ -93,6 +93,38 @@ func TestIstanbulExtraMarshalAndUnmarshal(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "BLSExtra with Version",
+			extra: &IstanbulExtra{
+				Validators: validators.NewBLSValidatorSet(
+					blsValidator1,
+				),
+				ProposerSeal: testProposerSeal,
+				CommittedSeals: &AggregatedSeal{
+					Bitmap:    new(big.Int).SetBytes([]byte{0x8}),
+					Signature: []byte{0x1},
+				},
+				ParentCommittedSeals: &AggregatedSeal{
+					Bitmap:    new(big.Int).SetBytes([]byte{0x9}),
+					Signature: []byte{0x2},
+				},
+				Version: AggregatedSealsExtraVersion,
+			},
+		},
+		{
+			name: "BLSExtra with Version without ParentCommittedSeals",
+			extra: &IstanbulExtra{
+				Validators: validators.NewBLSValidatorSet(
+					blsValidator1,
+				),
+				ProposerSeal: testProposerSeal,
+				CommittedSeals: &AggregatedSeal{
+					Bitmap:    new(big.Int).SetBytes([]byte{0x8}),
+					Signature: []byte{0x1},
+				},
+				Version: AggregatedSealsExtraVersion,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -28,17 +28,18 @@ func (m *MockSecretManager) SetSecret(name string, key []byte) error {
 }
 
 type MockKeyManager struct {
-	TypeFunc                   func() validators.ValidatorType
-	AddressFunc                func() types.Address
-	NewEmptyValidatorsFunc     func() validators.Validators
-	NewEmptyCommittedSealsFunc func() Seals
-	SignProposerSealFunc       func([]byte) ([]byte, error)
-	SignCommittedSealFunc      func([]byte) ([]byte, error)
-	VerifyCommittedSealFunc    func(validators.Validators, types.Address, []byte, []byte) error
-	GenerateCommittedSealsFunc func(map[types.Address][]byte, validators.Validators) (Seals, error)
-	VerifyCommittedSealsFunc   func(Seals, []byte, validators.Validators) (int, error)
-	SignIBFTMessageFunc        func([]byte) ([]byte, error)
-	EcrecoverFunc              func([]byte, []byte) (types.Address, error)
+	TypeFunc                    func() validators.ValidatorType
+	AddressFunc                 func() types.Address
+	NewEmptyValidatorsFunc      func() validators.Validators
+	NewEmptyCommittedSealsFunc  func() Seals
+	SignProposerSealFunc        func([]byte) ([]byte, error)
+	SignCommittedSealFunc       func([]byte) ([]byte, error)
+	VerifyCommittedSealFunc     func(validators.Validators, types.Address, []byte, []byte) error
+	GenerateCommittedSealsFunc  func(map[types.Address][]byte, validators.Validators) (Seals, error)
+	VerifyCommittedSealsFunc    func(Seals, []byte, validators.Validators) (int, error)
+	GetCommittedSealSignersFunc func(Seals, []byte, validators.Validators) ([]types.Address, error)
+	SignIBFTMessageFunc         func([]byte) ([]byte, error)
+	EcrecoverFunc               func([]byte, []byte) (types.Address, error)
 }
 
 func (m *MockKeyManager) Type() validators.ValidatorType {
@@ -79,6 +80,10 @@ func (m *MockKeyManager) VerifyCommittedSeals(seals Seals, hash []byte, vals val
 	return m.VerifyCommittedSealsFunc(seals, hash, vals)
 }
 
+func (m *MockKeyManager) GetCommittedSealSigners(seals Seals, hash []byte, vals validators.Validators) ([]types.Address, error) {
+	return m.GetCommittedSealSignersFunc(seals, hash, vals)
+}
+
 func (m *MockKeyManager) SignIBFTMessage(msg []byte) ([]byte, error) {
 	return m.SignIBFTMessageFunc(msg)
 }
@@ -0,0 +1,322 @@
+package signer
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+)
+
+const (
+	// defaultRemoteSignerTimeout is the timeout of a request to the remote signer if not configured
+	defaultRemoteSignerTimeout = 5 * time.Second
+
+	// maxRemoteSignerResponseSize is the limit of the response body read from the remote signer
+	maxRemoteSignerResponseSize = 1 << 16
+
+	// Web3Signer API endpoints
+	remoteSignerUpcheckPath         = "/upcheck"
+	remoteSignerECDSAPublicKeysPath = "/api/v1/eth1/publicKeys"
+	remoteSignerBLSPublicKeysPath   = "/api/v1/eth2/publicKeys"
+	remoteSignerECDSASignPath       = "/api/v1/eth1/sign/"
+	remoteSignerBLSSignPath         = "/api/v1/eth2/sign/"
+)
+
+var (
+	ErrRemoteSignerURLRequired  = errors.New("URL of remote signer is required")
+	ErrRemoteSignerKeyRequired  = errors.New("public key of remote signer is required")
+	ErrRemoteSignerKeyNotFound  = errors.New("public key is not served by remote signer")
+	ErrRemoteSignerRequest      = errors.New("request to remote signer failed")
+	ErrRemoteSignerBadSignature = errors.New("remote signer returned invalid signature")
+)
+
+// RemoteSignerClient is a client of the external signing service following Web3Signer API.
+// The keys are identified by their public keys and the 32 bytes digests are sent in "data" for ECDSA
+// and in "signingRoot" for BLS. Unlike eth1 signing of Web3Signer, the digest must be signed as is.
+// The client is shared by the KeyManagers of all validator types so that they use the same slashing protection
+type RemoteSignerClient struct {
+	url    string
+	client *http.Client
+
+	ecdsaPubKey    *ecdsa.PublicKey
+	ecdsaPubKeyHex string
+	blsPubKey      []byte
+	blsPubKeyHex   string
+	address        types.Address
+
+	protection *SlashingProtectionDB
+}
+
+// NewRemoteSignerClient creates RemoteSignerClient from the configuration,
+// checks the signer serves the keys and loads the slashing protection records
+func NewRemoteSignerClient(config *secrets.RemoteSignerConfig) (*RemoteSignerClient, error) {
+	if config.URL == "" {
+		return nil, ErrRemoteSignerURLRequired
+	}
+
+	if config.ECDSAPublicKey == "" {
+		return nil, ErrRemoteSignerKeyRequired
+	}
+
+	rawECDSAPubKey, err := hex.DecodeHex(config.ECDSAPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode ECDSA public key: %w", err)
+	}
+
+	ecdsaPubKey, err := crypto.ParsePublicKey(rawECDSAPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ECDSA public key: %w", err)
+	}
+
+	timeout := defaultRemoteSignerTimeout
+	if config.TimeoutSeconds > 0 {
+		timeout = time.Duration(config.TimeoutSeconds) * time.Second
+	}
+
+	c := &RemoteSignerClient{
+		url:            strings.TrimSuffix(config.URL, "/"),
+		client:         &http.Client{Timeout: timeout},
+		ecdsaPubKey:    ecdsaPubKey,
+		ecdsaPubKeyHex: hex.EncodeToHex(crypto.MarshalPublicKey(ecdsaPubKey)),
+		address:        crypto.PubKeyToAddress(ecdsaPubKey),
+	}
+
+	if config.BLSPublicKey != "" {
+		if c.blsPubKey, err = hex.DecodeHex(config.BLSPublicKey); err != nil {
+			return nil, fmt.Errorf("failed to decode BLS public key: %w", err)
+		}
+
+		if _, err := crypto.UnmarshalBLSPublicKey(c.blsPubKey); err != nil {
+			return nil, fmt.Errorf("failed to parse BLS public key: %w", err)
+		}
+
+		c.blsPubKeyHex = hex.EncodeToHex(c.blsPubKey)
+	}
+
+	if err := c.checkPublicKeys(); err != nil {
+		return nil, err
+	}
+
+	if c.protection, err = NewSlashingProtectionDB(config.SlashingProtectionPath, c.address); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Address returns the address of the ECDSA key in the remote signer
+func (c *RemoteSignerClient) Address() types.Address {
+	return c.address
+}
+
+// checkPublicKeys checks the remote signer is up and serves the configured keys
+func (c *RemoteSignerClient) checkPublicKeys() error {
+	if _, err := c.do(http.MethodGet, remoteSignerUpcheckPath, nil); err != nil {
+		return err
+	}
+
+	if err := c.checkPublicKey(remoteSignerECDSAPublicKeysPath, c.ecdsaPubKeyHex); err != nil {
+		return err
+	}
+
+	if c.blsPubKey != nil {
+		if err := c.checkPublicKey(remoteSignerBLSPublicKeysPath, c.blsPubKeyHex); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *RemoteSignerClient) checkPublicKey(path, pubKey string) error {
+	body, err := c.do(http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+
+	var pubKeys []string
+	if err := json.Unmarshal(body, &pubKeys); err != nil {
+		return fmt.Errorf("failed to decode public keys: %w", err)
+	}
+
+	for _, key := range pubKeys {
+		if strings.EqualFold(key, pubKey) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrRemoteSignerKeyNotFound, pubKey)
+}
+
+// signByECDSA requests the ECDSA signature of the digest and checks it's signed by the expected key
+func (c *RemoteSignerClient) signByECDSA(digest []byte) ([]byte, error) {
+	sig, err := c.sign(remoteSignerECDSASignPath+c.ecdsaPubKeyHex, map[string]string{
+		"data": hex.EncodeToHex(digest),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sig) != IstanbulExtraSeal {
+		return nil, ErrRemoteSignerBadSignature
+	}
+
+	// Web3Signer returns V in 27 or 28
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+
+	signer, err := ecrecover(sig, digest)
+	if err != nil || signer != c.address {
+		return nil, ErrRemoteSignerBadSignature
+	}
+
+	return sig, nil
+}
+
+// signByBLS requests the BLS signature of the digest and checks it's signed by the expected key
+func (c *RemoteSignerClient) signByBLS(digest []byte) ([]byte, error) {
+	if c.blsPubKey == nil {
+		return nil, ErrRemoteSignerKeyRequired
+	}
+
+	sig, err := c.sign(remoteSignerBLSSignPath+c.blsPubKeyHex, map[string]string{
+		"signingRoot": hex.EncodeToHex(digest),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := crypto.VerifyBLSSignatureFromBytes(c.blsPubKey, sig, digest); err != nil {
+		return nil, ErrRemoteSignerBadSignature
+	}
+
+	return sig, nil
+}
+
+// sign sends the signing request and decodes the signature
+// from either the JSON object or the plain hex string in the response
+func (c *RemoteSignerClient) sign(path string, request interface{}) ([]byte, error) {
+	rawRequest, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.do(http.MethodPost, path, rawRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Signature string `json:"signature"`
+	}
+
+	rawSig := strings.TrimSpace(string(body))
+	if err := json.Unmarshal(body, &response); err == nil {
+		rawSig = response.Signature
+	}
+
+	sig, err := hex.DecodeHex(rawSig)
+	if err != nil {
+		return nil, ErrRemoteSignerBadSignature
+	}
+
+	return sig, nil
+}
+
+func (c *RemoteSignerClient) do(method, path string, body []byte) ([]byte, error) {
+	req, err := http.NewRequest(method, c.url+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRemoteSignerRequest, err)
+	}
+
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(io.LimitReader(res.Body, maxRemoteSignerResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRemoteSignerRequest, err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s %s returned %d", ErrRemoteSignerRequest, method, path, res.StatusCode)
+	}
+
+	return resBody, nil
+}
+
+// RemoteKeyManager is a KeyManager that delegates the signing to the remote signer.
+// The verification is done locally by the KeyManager of the validator type without keys
+type RemoteKeyManager struct {
+	KeyManager
+
+	client *RemoteSignerClient
+}
+
+// NewRemoteKeyManager creates RemoteKeyManager of the validator type
+func NewRemoteKeyManager(client *RemoteSignerClient, validatorType validators.ValidatorType) (KeyManager, error) {
+	var verifier KeyManager
+
+	switch validatorType {
+	case validators.ECDSAValidatorType:
+		verifier = &ECDSAKeyManager{address: client.address}
+	case validators.BLSValidatorType:
+		if client.blsPubKey == nil {
+			return nil, ErrRemoteSignerKeyRequired
+		}
+
+		verifier = &BLSKeyManager{address: client.address}
+	default:
+		return nil, fmt.Errorf("unsupported validator type: %s", validatorType)
+	}
+
+	return &RemoteKeyManager{
+		KeyManager: verifier,
+		client:     client,
+	}, nil
+}
+
+// SignProposerSeal signs the given digest by the remote ECDSA key for ProposerSeal
+func (m *RemoteKeyManager) SignProposerSeal(digest []byte) ([]byte, error) {
+	return m.client.signByECDSA(digest)
+}
+
+// SignCommittedSeal signs the given digest by the remote key of the validator type for committed seal
+func (m *RemoteKeyManager) SignCommittedSeal(digest []byte) ([]byte, error) {
+	if m.Type() == validators.BLSValidatorType {
+		return m.client.signByBLS(digest)
+	}
+
+	return m.client.signByECDSA(digest)
+}
+
+// SignIBFTMessage signs the given digest by the remote ECDSA key
+func (m *RemoteKeyManager) SignIBFTMessage(digest []byte) ([]byte, error) {
+	return m.client.signByECDSA(digest)
+}
+
+// ProtectIBFTMessage checks the IBFT message doesn't conflict with the messages signed before
+func (m *RemoteKeyManager) ProtectIBFTMessage(msg []byte) error {
+	return m.client.protection.ProtectIBFTMessage(msg)
+}
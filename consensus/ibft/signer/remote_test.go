@@ -0,0 +1,449 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	protoIBFT "github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/coinbase/kryptology/pkg/signatures/bls/bls_sig"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+// mockRemoteSigner is a signing service serving Web3Signer API with the keys in memory
+type mockRemoteSigner struct {
+	*httptest.Server
+
+	ecdsaKey       *ecdsa.PrivateKey
+	ecdsaPubKeyHex string
+	blsKey         *bls_sig.SecretKey
+	blsPubKeyHex   string
+
+	lock sync.Mutex
+	// wrongKey makes the server sign by the other key
+	wrongKey bool
+	// numSigned is the number of the signing requests served
+	numSigned int
+}
+
+func newMockRemoteSigner(t *testing.T) *mockRemoteSigner {
+	t.Helper()
+
+	ecdsaKey, _ := newTestECDSAKey(t)
+	blsKey, _ := newTestBLSKey(t)
+
+	blsPubKey, err := crypto.BLSSecretKeyToPubkeyBytes(blsKey)
+	assert.NoError(t, err)
+
+	m := &mockRemoteSigner{
+		ecdsaKey:       ecdsaKey,
+		ecdsaPubKeyHex: hex.EncodeToHex(crypto.MarshalPublicKey(&ecdsaKey.PublicKey)),
+		blsKey:         blsKey,
+		blsPubKeyHex:   hex.EncodeToHex(blsPubKey),
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(remoteSignerUpcheckPath, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("OK"))
+	})
+	mux.HandleFunc(remoteSignerECDSAPublicKeysPath, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]string{m.ecdsaPubKeyHex})
+	})
+	mux.HandleFunc(remoteSignerBLSPublicKeysPath, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]string{m.blsPubKeyHex})
+	})
+	mux.HandleFunc(remoteSignerECDSASignPath, func(w http.ResponseWriter, r *http.Request) {
+		digest, ok := m.readSigningRequest(w, r, remoteSignerECDSASignPath, m.ecdsaPubKeyHex, "data")
+		if !ok {
+			return
+		}
+
+		key := m.ecdsaKey
+		if m.isWrongKey() {
+			key, _ = newTestECDSAKey(t)
+		}
+
+		sig, err := crypto.Sign(key, digest)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		// Web3Signer returns V in 27 or 28 as plain text
+		sig[64] += 27
+
+		_, _ = w.Write([]byte(hex.EncodeToHex(sig)))
+	})
+	mux.HandleFunc(remoteSignerBLSSignPath, func(w http.ResponseWriter, r *http.Request) {
+		digest, ok := m.readSigningRequest(w, r, remoteSignerBLSSignPath, m.blsPubKeyHex, "signingRoot")
+		if !ok {
+			return
+		}
+
+		key := m.blsKey
+		if m.isWrongKey() {
+			key, _ = newTestBLSKey(t)
+		}
+
+		sig, err := crypto.SignByBLS(key, digest)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"signature": hex.EncodeToHex(sig),
+		})
+	})
+
+	m.Server = httptest.NewServer(mux)
+
+	t.Cleanup(m.Close)
+
+	return m
+}
+
+func (m *mockRemoteSigner) readSigningRequest(
+	w http.ResponseWriter,
+	r *http.Request,
+	prefix, identifier, field string,
+) ([]byte, bool) {
+	if r.Method != http.MethodPost || !strings.EqualFold(strings.TrimPrefix(r.URL.Path, prefix), identifier) {
+		w.WriteHeader(http.StatusNotFound)
+
+		return nil, false
+	}
+
+	request := map[string]string{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return nil, false
+	}
+
+	digest, err := hex.DecodeHex(request[field])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return nil, false
+	}
+
+	m.lock.Lock()
+	m.numSigned++
+	m.lock.Unlock()
+
+	return digest, true
+}
+
+func (m *mockRemoteSigner) isWrongKey() bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.wrongKey
+}
+
+func (m *mockRemoteSigner) setWrongKey(wrongKey bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.wrongKey = wrongKey
+}
+
+func (m *mockRemoteSigner) signed() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.numSigned
+}
+
+func (m *mockRemoteSigner) config(t *testing.T) *secrets.RemoteSignerConfig {
+	t.Helper()
+
+	return &secrets.RemoteSignerConfig{
+		URL:                    m.URL,
+		ECDSAPublicKey:         m.ecdsaPubKeyHex,
+		BLSPublicKey:           m.blsPubKeyHex,
+		SlashingProtectionPath: filepath.Join(t.TempDir(), "slashing_protection.json"),
+	}
+}
+
+func newTestIBFTMessage(
+	t *testing.T,
+	msgType protoIBFT.MessageType,
+	height, round uint64,
+	proposalHash []byte,
+) []byte {
+	t.Helper()
+
+	msg := &protoIBFT.Message{
+		View: &protoIBFT.View{
+			Height: height,
+			Round:  round,
+		},
+		Type: msgType,
+	}
+
+	switch msgType {
+	case protoIBFT.MessageType_PREPARE:
+		msg.Payload = &protoIBFT.Message_PrepareData{
+			PrepareData: &protoIBFT.PrepareMessage{ProposalHash: proposalHash},
+		}
+	case protoIBFT.MessageType_COMMIT:
+		msg.Payload = &protoIBFT.Message_CommitData{
+			CommitData: &protoIBFT.CommitMessage{ProposalHash: proposalHash},
+		}
+	case protoIBFT.MessageType_ROUND_CHANGE:
+		msg.Payload = &protoIBFT.Message_RoundChangeData{
+			RoundChangeData: &protoIBFT.RoundChangeMessage{},
+		}
+	}
+
+	raw, err := proto.Marshal(msg)
+	assert.NoError(t, err)
+
+	return raw
+}
+
+func TestNewRemoteSignerClient(t *testing.T) {
+	t.Parallel()
+
+	server := newMockRemoteSigner(t)
+	otherKey, _ := newTestECDSAKey(t)
+
+	tests := []struct {
+		name        string
+		modify      func(*secrets.RemoteSignerConfig)
+		expectedErr error
+	}{
+		{
+			name:        "should succeed",
+			modify:      func(*secrets.RemoteSignerConfig) {},
+			expectedErr: nil,
+		},
+		{
+			name: "should return error if URL is empty",
+			modify: func(c *secrets.RemoteSignerConfig) {
+				c.URL = ""
+			},
+			expectedErr: ErrRemoteSignerURLRequired,
+		},
+		{
+			name: "should return error if ECDSA public key is empty",
+			modify: func(c *secrets.RemoteSignerConfig) {
+				c.ECDSAPublicKey = ""
+			},
+			expectedErr: ErrRemoteSignerKeyRequired,
+		},
+		{
+			name: "should return error if the signer doesn't serve the key",
+			modify: func(c *secrets.RemoteSignerConfig) {
+				c.ECDSAPublicKey = hex.EncodeToHex(crypto.MarshalPublicKey(&otherKey.PublicKey))
+			},
+			expectedErr: ErrRemoteSignerKeyNotFound,
+		},
+		{
+			name: "should return error if the signer is down",
+			modify: func(c *secrets.RemoteSignerConfig) {
+				c.URL = "http://127.0.0.1:1"
+			},
+			expectedErr: ErrRemoteSignerRequest,
+		},
+		{
+			name: "should return error if slashing protection path is empty",
+			modify: func(c *secrets.RemoteSignerConfig) {
+				c.SlashingProtectionPath = ""
+			},
+			expectedErr: ErrSlashingProtectionPathRequired,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := server.config(t)
+			test.modify(config)
+
+			client, err := NewRemoteSignerClient(config)
+
+			assert.ErrorIs(t, err, test.expectedErr)
+
+			if test.expectedErr == nil {
+				assert.Equal(t, crypto.PubKeyToAddress(&server.ecdsaKey.PublicKey), client.Address())
+			}
+		})
+	}
+}
+
+func TestRemoteKeyManager(t *testing.T) {
+	t.Parallel()
+
+	server := newMockRemoteSigner(t)
+
+	client, err := NewRemoteSignerClient(server.config(t))
+	assert.NoError(t, err)
+
+	blsValidator := testBLSKeyManagerToBLSValidator(
+		t,
+		NewBLSKeyManagerFromKeys(server.ecdsaKey, server.blsKey),
+	)
+	digest := crypto.Keccak256([]byte("digest"))
+
+	t.Run("ECDSA", func(t *testing.T) {
+		t.Parallel()
+
+		km, err := NewRemoteKeyManager(client, validators.ECDSAValidatorType)
+		assert.NoError(t, err)
+
+		assert.Equal(t, validators.ECDSAValidatorType, km.Type())
+		assert.Equal(t, client.Address(), km.Address())
+
+		for _, sign := range []func([]byte) ([]byte, error){
+			km.SignProposerSeal,
+			km.SignCommittedSeal,
+			km.SignIBFTMessage,
+		} {
+			sig, err := sign(digest)
+			assert.NoError(t, err)
+
+			signer, err := km.Ecrecover(sig, digest)
+			assert.NoError(t, err)
+			assert.Equal(t, client.Address(), signer)
+		}
+
+		seal, err := km.SignCommittedSeal(digest)
+		assert.NoError(t, err)
+
+		assert.NoError(t, km.VerifyCommittedSeal(
+			validators.NewECDSAValidatorSet(validators.NewECDSAValidator(client.Address())),
+			client.Address(),
+			seal,
+			digest,
+		))
+	})
+
+	t.Run("BLS", func(t *testing.T) {
+		t.Parallel()
+
+		km, err := NewRemoteKeyManager(client, validators.BLSValidatorType)
+		assert.NoError(t, err)
+
+		assert.Equal(t, validators.BLSValidatorType, km.Type())
+
+		proposerSeal, err := km.SignProposerSeal(digest)
+		assert.NoError(t, err)
+
+		signer, err := km.Ecrecover(proposerSeal, digest)
+		assert.NoError(t, err)
+		assert.Equal(t, client.Address(), signer)
+
+		seal, err := km.SignCommittedSeal(digest)
+		assert.NoError(t, err)
+
+		assert.NoError(t, km.VerifyCommittedSeal(
+			validators.NewBLSValidatorSet(blsValidator),
+			client.Address(),
+			seal,
+			digest,
+		))
+	})
+}
+
+func TestRemoteKeyManagerBadSignature(t *testing.T) {
+	t.Parallel()
+
+	server := newMockRemoteSigner(t)
+
+	client, err := NewRemoteSignerClient(server.config(t))
+	assert.NoError(t, err)
+
+	server.setWrongKey(true)
+
+	ecdsaKeyManager, err := NewRemoteKeyManager(client, validators.ECDSAValidatorType)
+	assert.NoError(t, err)
+
+	blsKeyManager, err := NewRemoteKeyManager(client, validators.BLSValidatorType)
+	assert.NoError(t, err)
+
+	digest := crypto.Keccak256([]byte("digest"))
+
+	_, err = ecdsaKeyManager.SignIBFTMessage(digest)
+	assert.ErrorIs(t, err, ErrRemoteSignerBadSignature)
+
+	_, err = blsKeyManager.SignCommittedSeal(digest)
+	assert.ErrorIs(t, err, ErrRemoteSignerBadSignature)
+}
+
+func TestNewRemoteKeyManagerWithoutBLSKey(t *testing.T) {
+	t.Parallel()
+
+	server := newMockRemoteSigner(t)
+
+	config := server.config(t)
+	config.BLSPublicKey = ""
+
+	client, err := NewRemoteSignerClient(config)
+	assert.NoError(t, err)
+
+	_, err = NewRemoteKeyManager(client, validators.BLSValidatorType)
+	assert.ErrorIs(t, err, ErrRemoteSignerKeyRequired)
+}
+
+func TestSignerSignIBFTMessageWithRemoteSigner(t *testing.T) {
+	t.Parallel()
+
+	server := newMockRemoteSigner(t)
+
+	client, err := NewRemoteSignerClient(server.config(t))
+	assert.NoError(t, err)
+
+	km, err := NewRemoteKeyManager(client, validators.ECDSAValidatorType)
+	assert.NoError(t, err)
+
+	signer := NewSigner(km, nil)
+
+	var (
+		proposal1 = types.StringToHash("1").Bytes()
+		proposal2 = types.StringToHash("2").Bytes()
+	)
+
+	commit := newTestIBFTMessage(t, protoIBFT.MessageType_COMMIT, 10, 0, proposal1)
+
+	sig, err := signer.SignIBFTMessage(commit)
+	assert.NoError(t, err)
+
+	from, err := signer.EcrecoverFromIBFTMessage(sig, commit)
+	assert.NoError(t, err)
+	assert.Equal(t, client.Address(), from)
+
+	numSigned := server.signed()
+
+	// the conflicting message doesn't reach the remote signer
+	_, err = signer.SignIBFTMessage(
+		newTestIBFTMessage(t, protoIBFT.MessageType_COMMIT, 10, 0, proposal2),
+	)
+	assert.ErrorIs(t, err, ErrSlashableIBFTMessage)
+	assert.Equal(t, numSigned, server.signed())
+
+	// the message in the next round is not conflicting
+	_, err = signer.SignIBFTMessage(
+		newTestIBFTMessage(t, protoIBFT.MessageType_COMMIT, 10, 1, proposal2),
+	)
+	assert.NoError(t, err)
+}
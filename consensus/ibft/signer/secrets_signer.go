@@ -0,0 +1,78 @@
+package signer
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/0xPolygon/polygon-edge/validators"
+)
+
+// SecretsSignerKeyManager is a KeyManager that delegates the signing by the ECDSA validator key
+// to the secrets manager keeping the key. The other methods are done by the KeyManager of the validator type
+type SecretsSignerKeyManager struct {
+	KeyManager
+
+	signer secrets.SecretsSigner
+}
+
+// newSecretsSignerKeyManager creates SecretsSignerKeyManager of the validator type,
+// the BLS key of BLS validators is loaded from the secrets manager
+func newSecretsSignerKeyManager(
+	manager secrets.SecretsManager,
+	signer secrets.SecretsSigner,
+	validatorType validators.ValidatorType,
+) (KeyManager, error) {
+	rawPubKey, err := signer.SigningPublicKey(secrets.ValidatorKey)
+	if err != nil {
+		return nil, err
+	}
+
+	pubKey, err := crypto.ParsePublicKey(rawPubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	address := crypto.PubKeyToAddress(pubKey)
+
+	var keyManager KeyManager
+
+	switch validatorType {
+	case validators.ECDSAValidatorType:
+		keyManager = &ECDSAKeyManager{address: address}
+	case validators.BLSValidatorType:
+		blsKey, err := getOrCreateBLSKey(manager)
+		if err != nil {
+			return nil, err
+		}
+
+		keyManager = &BLSKeyManager{blsKey: blsKey, address: address}
+	default:
+		return nil, fmt.Errorf("unsupported validator type: %s", validatorType)
+	}
+
+	return &SecretsSignerKeyManager{
+		KeyManager: keyManager,
+		signer:     signer,
+	}, nil
+}
+
+// SignProposerSeal signs the given digest by the ECDSA key in the secrets manager for ProposerSeal
+func (m *SecretsSignerKeyManager) SignProposerSeal(digest []byte) ([]byte, error) {
+	return m.signer.Sign(secrets.ValidatorKey, digest)
+}
+
+// SignCommittedSeal signs the given digest for committed seal,
+// by the BLS key for BLS validators and by the ECDSA key in the secrets manager otherwise
+func (m *SecretsSignerKeyManager) SignCommittedSeal(digest []byte) ([]byte, error) {
+	if m.Type() == validators.BLSValidatorType {
+		return m.KeyManager.SignCommittedSeal(digest)
+	}
+
+	return m.signer.Sign(secrets.ValidatorKey, digest)
+}
+
+// SignIBFTMessage signs the given digest by the ECDSA key in the secrets manager
+func (m *SecretsSignerKeyManager) SignIBFTMessage(digest []byte) ([]byte, error) {
+	return m.signer.Sign(secrets.ValidatorKey, digest)
+}
@@ -0,0 +1,118 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockSecretsSigner is a secrets manager signing with the ECDSA validator key it doesn't expose
+type mockSecretsSigner struct {
+	MockSecretManager
+
+	ecdsaKey *ecdsa.PrivateKey
+}
+
+func (m *mockSecretsSigner) IsSigningKey(name string) bool {
+	return name == secrets.ValidatorKey
+}
+
+func (m *mockSecretsSigner) SigningPublicKey(name string) ([]byte, error) {
+	return crypto.MarshalPublicKey(&m.ecdsaKey.PublicKey), nil
+}
+
+func (m *mockSecretsSigner) Sign(name string, data []byte) ([]byte, error) {
+	return crypto.Sign(m.ecdsaKey, data)
+}
+
+func newMockSecretsSigner(t *testing.T) *mockSecretsSigner {
+	t.Helper()
+
+	ecdsaKey, _ := newTestECDSAKey(t)
+	_, blsKeyEncoded := newTestBLSKey(t)
+
+	return &mockSecretsSigner{
+		MockSecretManager: MockSecretManager{
+			HasSecretFn: func(name string) bool {
+				return name == secrets.ValidatorBLSKey
+			},
+			GetSecretFn: func(name string) ([]byte, error) {
+				if name != secrets.ValidatorBLSKey {
+					// the ECDSA key must not be read
+					return nil, secrets.ErrSigningKey
+				}
+
+				return blsKeyEncoded, nil
+			},
+		},
+		ecdsaKey: ecdsaKey,
+	}
+}
+
+func TestSecretsSignerKeyManager(t *testing.T) {
+	t.Parallel()
+
+	manager := newMockSecretsSigner(t)
+	address := crypto.PubKeyToAddress(&manager.ecdsaKey.PublicKey)
+	digest := crypto.Keccak256([]byte("digest"))
+
+	t.Run("ECDSA", func(t *testing.T) {
+		t.Parallel()
+
+		km, err := NewECDSAKeyManager(manager)
+		assert.NoError(t, err)
+
+		assert.IsType(t, &SecretsSignerKeyManager{}, km)
+		assert.Equal(t, validators.ECDSAValidatorType, km.Type())
+		assert.Equal(t, address, km.Address())
+
+		for _, sign := range []func([]byte) ([]byte, error){
+			km.SignProposerSeal,
+			km.SignCommittedSeal,
+			km.SignIBFTMessage,
+		} {
+			sig, err := sign(digest)
+			assert.NoError(t, err)
+
+			signer, err := km.Ecrecover(sig, digest)
+			assert.NoError(t, err)
+			assert.Equal(t, address, signer)
+		}
+	})
+
+	t.Run("BLS", func(t *testing.T) {
+		t.Parallel()
+
+		km, err := NewBLSKeyManager(manager)
+		assert.NoError(t, err)
+
+		assert.Equal(t, validators.BLSValidatorType, km.Type())
+		assert.Equal(t, address, km.Address())
+
+		proposerSeal, err := km.SignProposerSeal(digest)
+		assert.NoError(t, err)
+
+		signer, err := km.Ecrecover(proposerSeal, digest)
+		assert.NoError(t, err)
+		assert.Equal(t, address, signer)
+
+		secretsSignerKM, ok := km.(*SecretsSignerKeyManager)
+		assert.True(t, ok)
+
+		seal, err := km.SignCommittedSeal(digest)
+		assert.NoError(t, err)
+
+		assert.NoError(t, km.VerifyCommittedSeal(
+			validators.NewBLSValidatorSet(
+				testBLSKeyManagerToBLSValidator(t, secretsSignerKM.KeyManager),
+			),
+			address,
+			seal,
+			digest,
+		))
+	})
+}
@@ -2,6 +2,7 @@ package signer
 
 import (
 	"errors"
+	"math/big"
 
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -21,6 +22,8 @@ var (
 	ErrInvalidValidators          = errors.New("invalid validators type")
 	ErrInvalidValidator           = errors.New("invalid validator type")
 	ErrInvalidSignature           = errors.New("invalid signature")
+	ErrInvalidExtraVersion        = errors.New("invalid IBFT extra version")
+	ErrInvalidSealsBitmap         = errors.New("bitmap of seals refers to non validator")
 )
 
 // Signer is responsible for signing for blocks and messages in IBFT
@@ -48,6 +51,7 @@ type Signer interface {
 		validators validators.Validators,
 		quorumSize int,
 	) error
+	GetCommittedSealSigners(*types.Header, validators.Validators) ([]types.Address, error)
 
 	// ParentCommittedSeals
 	VerifyParentCommittedSeals(
@@ -56,11 +60,27 @@ type Signer interface {
 		quorum int,
 		mustExist bool,
 	) error
+	GetParentCommittedSealSigners(*types.Header, validators.Validators) ([]types.Address, error)
+
+	// Aggregated seals to be verified in a batch
+	GetCommittedSealsBatchItem(
+		header *types.Header,
+		validators validators.Validators,
+		quorumSize int,
+	) (*AggregatedSealsBatchItem, error)
+	GetParentCommittedSealsBatchItem(
+		parent, header *types.Header,
+		parentValidators validators.Validators,
+		quorumSize int,
+	) (*AggregatedSealsBatchItem, error)
 
 	// IBFTMessage
 	SignIBFTMessage([]byte) ([]byte, error)
 	EcrecoverFromIBFTMessage([]byte, []byte) (types.Address, error)
 
+	// Transaction
+	SignTx(*types.Transaction, crypto.TxSigner) (*types.Transaction, error)
+
 	// Hash of Header
 	CalculateHeaderHash(*types.Header) (types.Hash, error)
 }
@@ -69,16 +89,27 @@ type Signer interface {
 type SignerImpl struct {
 	keyManager       KeyManager
 	parentKeyManager KeyManager
+	extraVersion     uint64
 }
 
-// NewSigner is a constructor of SignerImpl
+// NewSigner is a constructor of SignerImpl that uses the legacy IBFT Extra
 func NewSigner(
 	keyManager KeyManager,
 	parentKeyManager KeyManager,
+) *SignerImpl {
+	return NewVersionedSigner(keyManager, parentKeyManager, LegacyExtraVersion)
+}
+
+// NewVersionedSigner is a constructor of SignerImpl that uses the given version of IBFT Extra
+func NewVersionedSigner(
+	keyManager KeyManager,
+	parentKeyManager KeyManager,
+	extraVersion uint64,
 ) *SignerImpl {
 	return &SignerImpl{
 		keyManager:       keyManager,
 		parentKeyManager: parentKeyManager,
+		extraVersion:     extraVersion,
 	}
 }
 
@@ -103,6 +134,7 @@ func (s *SignerImpl) InitIBFTExtra(
 		header,
 		validators,
 		parentCommittedSeals,
+		s.extraVersion,
 	)
 }
 
@@ -127,6 +159,11 @@ func (s *SignerImpl) GetIBFTExtra(header *types.Header) (*IstanbulExtra, error)
 		return nil, err
 	}
 
+	// genesis is created before the forks are applied
+	if header.Number > 0 && extra.Version != s.extraVersion {
+		return nil, ErrInvalidExtraVersion
+	}
+
 	return extra, nil
 }
 
@@ -234,6 +271,10 @@ func (s *SignerImpl) VerifyCommittedSeals(
 		return err
 	}
 
+	if err := s.verifySealsBitmap(extra.CommittedSeals, validators); err != nil {
+		return err
+	}
+
 	rawMsg := crypto.Keccak256(
 		wrapCommitHash(hash[:]),
 	)
@@ -254,6 +295,33 @@ func (s *SignerImpl) VerifyCommittedSeals(
 	return nil
 }
 
+// GetCommittedSealSigners returns the addresses of the validators
+// who signed CommittedSeals in IBFT Extra of the header
+func (s *SignerImpl) GetCommittedSealSigners(
+	header *types.Header,
+	validators validators.Validators,
+) ([]types.Address, error) {
+	extra, err := s.GetIBFTExtra(header)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := s.CalculateHeaderHash(header)
+	if err != nil {
+		return nil, err
+	}
+
+	rawMsg := crypto.Keccak256(
+		wrapCommitHash(hash[:]),
+	)
+
+	return s.keyManager.GetCommittedSealSigners(
+		extra.CommittedSeals,
+		rawMsg,
+		validators,
+	)
+}
+
 // VerifyParentCommittedSeals verifies ParentCommittedSeals in IBFT Extra of the header
 func (s *SignerImpl) VerifyParentCommittedSeals(
 	parent, header *types.Header,
@@ -277,6 +345,10 @@ func (s *SignerImpl) VerifyParentCommittedSeals(
 		return nil
 	}
 
+	if err := s.verifySealsBitmap(parentCommittedSeals, parentValidators); err != nil {
+		return err
+	}
+
 	rawMsg := crypto.Keccak256(
 		wrapCommitHash(parent.Hash.Bytes()),
 	)
@@ -297,8 +369,118 @@ func (s *SignerImpl) VerifyParentCommittedSeals(
 	return nil
 }
 
+// GetParentCommittedSealSigners returns the addresses of the validators
+// who signed ParentCommittedSeals in IBFT Extra of the header
+func (s *SignerImpl) GetParentCommittedSealSigners(
+	header *types.Header,
+	parentValidators validators.Validators,
+) ([]types.Address, error) {
+	parentCommittedSeals, err := s.GetParentCommittedSeals(header)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentCommittedSeals == nil || parentCommittedSeals.Num() == 0 {
+		return nil, nil
+	}
+
+	rawMsg := crypto.Keccak256(
+		wrapCommitHash(header.ParentHash.Bytes()),
+	)
+
+	return s.keyManager.GetCommittedSealSigners(
+		parentCommittedSeals,
+		rawMsg,
+		parentValidators,
+	)
+}
+
+// GetCommittedSealsBatchItem returns CommittedSeals in IBFT Extra of the header
+// to be verified with the ones of the other headers by VerifyAggregatedSealsBatch
+func (s *SignerImpl) GetCommittedSealsBatchItem(
+	header *types.Header,
+	validators validators.Validators,
+	quorumSize int,
+) (*AggregatedSealsBatchItem, error) {
+	extra, err := s.GetIBFTExtra(header)
+	if err != nil {
+		return nil, err
+	}
+
+	committedSeals, ok := extra.CommittedSeals.(*AggregatedSeal)
+	if !ok {
+		return nil, ErrInvalidCommittedSealType
+	}
+
+	hash, err := s.CalculateHeaderHash(header)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AggregatedSealsBatchItem{
+		Seals:      committedSeals,
+		Message:    crypto.Keccak256(wrapCommitHash(hash[:])),
+		Validators: validators,
+		QuorumSize: quorumSize,
+	}, nil
+}
+
+// GetParentCommittedSealsBatchItem returns ParentCommittedSeals in IBFT Extra of the header
+// to be verified with the ones of the other headers by VerifyAggregatedSealsBatch.
+// It returns nil if the header doesn't have ParentCommittedSeals
+func (s *SignerImpl) GetParentCommittedSealsBatchItem(
+	parent, header *types.Header,
+	parentValidators validators.Validators,
+	quorumSize int,
+) (*AggregatedSealsBatchItem, error) {
+	rawParentCommittedSeals, err := s.GetParentCommittedSeals(header)
+	if err != nil {
+		return nil, err
+	}
+
+	if rawParentCommittedSeals == nil || rawParentCommittedSeals.Num() == 0 {
+		return nil, nil
+	}
+
+	parentCommittedSeals, ok := rawParentCommittedSeals.(*AggregatedSeal)
+	if !ok {
+		return nil, ErrInvalidCommittedSealType
+	}
+
+	return &AggregatedSealsBatchItem{
+		Seals:      parentCommittedSeals,
+		Message:    crypto.Keccak256(wrapCommitHash(parent.Hash.Bytes())),
+		Validators: parentValidators,
+		QuorumSize: quorumSize,
+	}, nil
+}
+
+// SignTx signs the transaction created by the validator, such as the slashing transaction
+func (s *SignerImpl) SignTx(tx *types.Transaction, txSigner crypto.TxSigner) (*types.Transaction, error) {
+	tx = tx.Copy()
+
+	hash := txSigner.Hash(tx)
+
+	sig, err := s.keyManager.SignIBFTMessage(hash.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	tx.R = new(big.Int).SetBytes(sig[:32])
+	tx.S = new(big.Int).SetBytes(sig[32:64])
+	tx.V = new(big.Int).SetBytes(txSigner.CalculateV(sig[64]))
+
+	return tx.ComputeHash(), nil
+}
+
 // SignIBFTMessage signs arbitrary message
 func (s *SignerImpl) SignIBFTMessage(msg []byte) ([]byte, error) {
+	if protector, ok := s.keyManager.(SlashingProtector); ok {
+		if err := protector.ProtectIBFTMessage(msg); err != nil {
+			return nil, err
+		}
+	}
+
 	return s.keyManager.SignIBFTMessage(crypto.Keccak256(msg))
 }
 
@@ -312,12 +494,14 @@ func (s *SignerImpl) initIbftExtra(
 	header *types.Header,
 	validators validators.Validators,
 	parentCommittedSeal Seals,
+	version uint64,
 ) {
 	putIbftExtra(header, &IstanbulExtra{
 		Validators:           validators,
 		ProposerSeal:         []byte{},
 		CommittedSeals:       s.keyManager.NewEmptyCommittedSeals(),
 		ParentCommittedSeals: parentCommittedSeal,
+		Version:              version,
 	})
 }
 
@@ -377,7 +561,17 @@ func (s *SignerImpl) filterHeaderForHash(header *types.Header) (*types.Header, e
 
 	// This will effectively remove the Seal and CommittedSeals from the IBFT Extra of header,
 	// while keeping proposer vanity, validator set, and ParentCommittedSeals
-	s.initIbftExtra(clone, extra.Validators, parentCommittedSeals)
+	s.initIbftExtra(clone, extra.Validators, parentCommittedSeals, extra.Version)
 
 	return clone, nil
 }
+
+// verifySealsBitmap checks the bitmap of the aggregated seals strictly in the versioned IBFT Extra.
+// The legacy IBFT Extra is not checked for backward compatibility
+func (s *SignerImpl) verifySealsBitmap(seals Seals, validators validators.Validators) error {
+	if s.extraVersion == LegacyExtraVersion {
+		return nil
+	}
+
+	return verifySealsBitmap(seals, validators)
+}
@@ -442,6 +442,66 @@ func TestSignerGetIBFTExtra(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			name: "should return IstanbulExtra with version for the versioned signer",
+			header: &types.Header{
+				Number: 1,
+				ExtraData: append(
+					make([]byte, IstanbulExtraVanity),
+					(&IstanbulExtra{
+						Validators:     blsValidators,
+						ProposerSeal:   testProposerSeal,
+						CommittedSeals: testAggregatedSeals1,
+						Version:        AggregatedSealsExtraVersion,
+					}).MarshalRLPTo(nil)...,
+				),
+			},
+			signer: NewVersionedSigner(
+				&MockKeyManager{
+					NewEmptyValidatorsFunc: func() validators.Validators {
+						return blsValidators
+					},
+					NewEmptyCommittedSealsFunc: func() Seals {
+						return &AggregatedSeal{}
+					},
+				},
+				nil,
+				AggregatedSealsExtraVersion,
+			),
+			expectedExtra: &IstanbulExtra{
+				Validators:     blsValidators,
+				ProposerSeal:   testProposerSeal,
+				CommittedSeals: testAggregatedSeals1,
+				Version:        AggregatedSealsExtraVersion,
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "should return error if the version of IstanbulExtra is different from the signer's one",
+			header: &types.Header{
+				Number: 1,
+				ExtraData: getTestExtraBytes(
+					blsValidators,
+					testProposerSeal,
+					testAggregatedSeals1,
+					nil,
+				),
+			},
+			signer: NewVersionedSigner(
+				&MockKeyManager{
+					NewEmptyValidatorsFunc: func() validators.Validators {
+						return blsValidators
+					},
+					NewEmptyCommittedSealsFunc: func() Seals {
+						return &AggregatedSeal{}
+					},
+				},
+				nil,
+				AggregatedSealsExtraVersion,
+			),
+			expectedExtra: nil,
+			expectedErr:   ErrInvalidExtraVersion,
+		},
 	}
 
 	for _, test := range tests {
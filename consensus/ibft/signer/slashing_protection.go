@@ -0,0 +1,269 @@
+package signer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	protoIBFT "github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// slashingProtectionRetention is the number of heights whose signed messages are kept
+	slashingProtectionRetention = 256
+)
+
+var (
+	ErrSlashingProtectionPathRequired = errors.New("path of slashing protection is required")
+	ErrSlashingProtectionAddress      = errors.New("slashing protection records belong to another validator")
+	ErrSlashableIBFTMessage           = errors.New("IBFT message conflicts with the signed one at the same view")
+	ErrBelowSlashingWatermark         = errors.New("IBFT message is older than the slashing protection records")
+)
+
+// SlashingProtector is implemented by the KeyManager that refuses to sign the IBFT message
+// conflicting with the messages it signed before
+type SlashingProtector interface {
+	// ProtectIBFTMessage records the raw IBFT message about to be signed,
+	// or returns error if it conflicts with the signed one
+	ProtectIBFTMessage(msg []byte) error
+}
+
+// signedMessageKey is the view and the type of the signed IBFT message
+type signedMessageKey struct {
+	msgType protoIBFT.MessageType
+	height  uint64
+	round   uint64
+}
+
+// slashingProtectionRecord is the JSON representation of the signed IBFT message
+type slashingProtectionRecord struct {
+	Type         string     `json:"type"`
+	Height       uint64     `json:"height"`
+	Round        uint64     `json:"round"`
+	ProposalHash types.Hash `json:"proposalHash"`
+}
+
+// slashingProtectionFile is the JSON representation of SlashingProtectionDB
+type slashingProtectionFile struct {
+	Address      types.Address               `json:"address"`
+	LowWatermark uint64                      `json:"lowWatermark"`
+	Records      []*slashingProtectionRecord `json:"records"`
+}
+
+// SlashingProtectionDB keeps the proposal hashes of the IBFT messages the validator signed in the local file.
+// A validator must not sign the different proposals in PREPREPARE, PREPARE or COMMIT of the same view.
+// The records older than the retention are pruned and the messages below them are refused
+type SlashingProtectionDB struct {
+	lock sync.Mutex
+
+	path         string
+	address      types.Address
+	lowWatermark uint64
+	records      map[signedMessageKey]types.Hash
+}
+
+// NewSlashingProtectionDB loads the slashing protection records of the validator from the file,
+// or creates the empty records if the file doesn't exist
+func NewSlashingProtectionDB(path string, address types.Address) (*SlashingProtectionDB, error) {
+	if path == "" {
+		return nil, ErrSlashingProtectionPathRequired
+	}
+
+	db := &SlashingProtectionDB{
+		path:    path,
+		address: address,
+		records: make(map[signedMessageKey]types.Hash),
+	}
+
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return db, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	file := &slashingProtectionFile{}
+	if err := json.Unmarshal(raw, file); err != nil {
+		return nil, fmt.Errorf("failed to decode slashing protection records: %w", err)
+	}
+
+	if file.Address != address {
+		return nil, ErrSlashingProtectionAddress
+	}
+
+	db.lowWatermark = file.LowWatermark
+
+	for _, record := range file.Records {
+		msgType, ok := protoIBFT.MessageType_value[record.Type]
+		if !ok {
+			return nil, fmt.Errorf("unknown message type in slashing protection records: %s", record.Type)
+		}
+
+		db.records[signedMessageKey{
+			msgType: protoIBFT.MessageType(msgType),
+			height:  record.Height,
+			round:   record.Round,
+		}] = record.ProposalHash
+	}
+
+	return db, nil
+}
+
+// ProtectIBFTMessage records the proposal hash of the raw IBFT message before it's signed.
+// It returns error if the validator signed the different proposal at the same view
+func (db *SlashingProtectionDB) ProtectIBFTMessage(rawMsg []byte) error {
+	msg := &protoIBFT.Message{}
+	if err := proto.Unmarshal(rawMsg, msg); err != nil {
+		return err
+	}
+
+	proposalHash, ok := getSlashableProposalHash(msg)
+	if !ok || msg.View == nil {
+		// the message can't be slashed
+		return nil
+	}
+
+	key := signedMessageKey{
+		msgType: msg.Type,
+		height:  msg.View.Height,
+		round:   msg.View.Round,
+	}
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if key.height < db.lowWatermark {
+		return ErrBelowSlashingWatermark
+	}
+
+	if signedHash, ok := db.records[key]; ok {
+		if signedHash != proposalHash {
+			return ErrSlashableIBFTMessage
+		}
+
+		// signing the same message again is safe
+		return nil
+	}
+
+	db.records[key] = proposalHash
+	db.prune(key.height)
+
+	// the message must not be signed unless the record is persisted
+	if err := db.save(); err != nil {
+		delete(db.records, key)
+
+		return fmt.Errorf("failed to save slashing protection records: %w", err)
+	}
+
+	return nil
+}
+
+// prune drops the records older than the retention from the latest height
+func (db *SlashingProtectionDB) prune(latestHeight uint64) {
+	if latestHeight < slashingProtectionRetention {
+		return
+	}
+
+	lowWatermark := latestHeight - slashingProtectionRetention
+	if lowWatermark <= db.lowWatermark {
+		return
+	}
+
+	db.lowWatermark = lowWatermark
+
+	for key := range db.records {
+		if key.height < lowWatermark {
+			delete(db.records, key)
+		}
+	}
+}
+
+// save writes the records to the temporary file and replaces the file with it
+func (db *SlashingProtectionDB) save() error {
+	file := &slashingProtectionFile{
+		Address:      db.address,
+		LowWatermark: db.lowWatermark,
+		Records:      make([]*slashingProtectionRecord, 0, len(db.records)),
+	}
+
+	for key, hash := range db.records {
+		file.Records = append(file.Records, &slashingProtectionRecord{
+			Type:         key.msgType.String(),
+			Height:       key.height,
+			Round:        key.round,
+			ProposalHash: hash,
+		})
+	}
+
+	sort.Slice(file.Records, func(i, j int) bool {
+		a, b := file.Records[i], file.Records[j]
+		if a.Height != b.Height {
+			return a.Height < b.Height
+		}
+
+		if a.Round != b.Round {
+			return a.Round < b.Round
+		}
+
+		return a.Type < b.Type
+	})
+
+	raw, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(db.path), 0750); err != nil {
+		return err
+	}
+
+	tmpPath := db.path + ".tmp"
+
+	tmpFile, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmpFile.Write(raw); err != nil {
+		tmpFile.Close()
+
+		return err
+	}
+
+	if err := tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+
+		return err
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, db.path)
+}
+
+// getSlashableProposalHash returns the proposal hash of the message
+// that is slashed if the validator signs the different one at the same view
+func getSlashableProposalHash(msg *protoIBFT.Message) (types.Hash, bool) {
+	var proposalHash []byte
+
+	switch payload := msg.Payload.(type) {
+	case *protoIBFT.Message_PreprepareData:
+		proposalHash = payload.PreprepareData.GetProposalHash()
+	case *protoIBFT.Message_PrepareData:
+		proposalHash = payload.PrepareData.GetProposalHash()
+	case *protoIBFT.Message_CommitData:
+		proposalHash = payload.CommitData.GetProposalHash()
+	default:
+		return types.Hash{}, false
+	}
+
+	return types.BytesToHash(proposalHash), true
+}
@@ -0,0 +1,152 @@
+package signer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	protoIBFT "github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlashingProtectionDBProtectIBFTMessage(t *testing.T) {
+	t.Parallel()
+
+	var (
+		proposal1 = types.StringToHash("1").Bytes()
+		proposal2 = types.StringToHash("2").Bytes()
+	)
+
+	tests := []struct {
+		name        string
+		signed      [][]byte
+		msg         []byte
+		expectedErr error
+	}{
+		{
+			name:        "should accept the first message",
+			signed:      nil,
+			msg:         newTestIBFTMessage(t, protoIBFT.MessageType_PREPARE, 1, 0, proposal1),
+			expectedErr: nil,
+		},
+		{
+			name: "should accept the same message again",
+			signed: [][]byte{
+				newTestIBFTMessage(t, protoIBFT.MessageType_PREPARE, 1, 0, proposal1),
+			},
+			msg:         newTestIBFTMessage(t, protoIBFT.MessageType_PREPARE, 1, 0, proposal1),
+			expectedErr: nil,
+		},
+		{
+			name: "should refuse the different proposal in the same view",
+			signed: [][]byte{
+				newTestIBFTMessage(t, protoIBFT.MessageType_PREPARE, 1, 0, proposal1),
+			},
+			msg:         newTestIBFTMessage(t, protoIBFT.MessageType_PREPARE, 1, 0, proposal2),
+			expectedErr: ErrSlashableIBFTMessage,
+		},
+		{
+			name: "should accept the different proposal in the other round",
+			signed: [][]byte{
+				newTestIBFTMessage(t, protoIBFT.MessageType_COMMIT, 1, 0, proposal1),
+			},
+			msg:         newTestIBFTMessage(t, protoIBFT.MessageType_COMMIT, 1, 1, proposal2),
+			expectedErr: nil,
+		},
+		{
+			name: "should accept the different proposal in the other message type",
+			signed: [][]byte{
+				newTestIBFTMessage(t, protoIBFT.MessageType_PREPARE, 1, 0, proposal1),
+			},
+			msg:         newTestIBFTMessage(t, protoIBFT.MessageType_COMMIT, 1, 0, proposal2),
+			expectedErr: nil,
+		},
+		{
+			name: "should accept round change messages without records",
+			signed: [][]byte{
+				newTestIBFTMessage(t, protoIBFT.MessageType_ROUND_CHANGE, 1, 0, nil),
+			},
+			msg:         newTestIBFTMessage(t, protoIBFT.MessageType_ROUND_CHANGE, 1, 0, nil),
+			expectedErr: nil,
+		},
+		{
+			name: "should refuse the message below the watermark",
+			signed: [][]byte{
+				newTestIBFTMessage(t, protoIBFT.MessageType_PREPARE, slashingProtectionRetention+10, 0, proposal1),
+			},
+			msg:         newTestIBFTMessage(t, protoIBFT.MessageType_PREPARE, 9, 0, proposal1),
+			expectedErr: ErrBelowSlashingWatermark,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, err := NewSlashingProtectionDB(filepath.Join(t.TempDir(), "protection.json"), testAddr1)
+			assert.NoError(t, err)
+
+			for _, msg := range test.signed {
+				assert.NoError(t, db.ProtectIBFTMessage(msg))
+			}
+
+			assert.ErrorIs(t, db.ProtectIBFTMessage(test.msg), test.expectedErr)
+		})
+	}
+}
+
+func TestSlashingProtectionDBPersistence(t *testing.T) {
+	t.Parallel()
+
+	var (
+		path      = filepath.Join(t.TempDir(), "consensus", "protection.json")
+		proposal1 = types.StringToHash("1").Bytes()
+		proposal2 = types.StringToHash("2").Bytes()
+	)
+
+	db, err := NewSlashingProtectionDB(path, testAddr1)
+	assert.NoError(t, err)
+
+	assert.NoError(t, db.ProtectIBFTMessage(
+		newTestIBFTMessage(t, protoIBFT.MessageType_COMMIT, 5, 2, proposal1),
+	))
+
+	// the records survive the restart
+	reloaded, err := NewSlashingProtectionDB(path, testAddr1)
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, reloaded.ProtectIBFTMessage(
+		newTestIBFTMessage(t, protoIBFT.MessageType_COMMIT, 5, 2, proposal2),
+	), ErrSlashableIBFTMessage)
+
+	// the records can't be used by the other validator
+	_, err = NewSlashingProtectionDB(path, types.StringToAddress("2"))
+	assert.ErrorIs(t, err, ErrSlashingProtectionAddress)
+
+	// the records of the broken file aren't ignored
+	assert.NoError(t, os.WriteFile(path, []byte("{"), 0600))
+
+	_, err = NewSlashingProtectionDB(path, testAddr1)
+	assert.Error(t, err)
+}
+
+func TestSlashingProtectionDBPrune(t *testing.T) {
+	t.Parallel()
+
+	db, err := NewSlashingProtectionDB(filepath.Join(t.TempDir(), "protection.json"), testAddr1)
+	assert.NoError(t, err)
+
+	proposal := types.StringToHash("1").Bytes()
+
+	for height := uint64(1); height <= slashingProtectionRetention+5; height++ {
+		assert.NoError(t, db.ProtectIBFTMessage(
+			newTestIBFTMessage(t, protoIBFT.MessageType_PREPARE, height, 0, proposal),
+		))
+	}
+
+	assert.Equal(t, uint64(5), db.lowWatermark)
+	assert.Len(t, db.records, slashingProtectionRetention+1)
+}
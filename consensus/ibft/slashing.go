@@ -0,0 +1,676 @@
+package ibft
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	protoIBFT "github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/fork"
+	"github.com/0xPolygon/polygon-edge/contracts/staking"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/0xPolygon/polygon-edge/validators/store"
+	"github.com/umbracle/fastrlp"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	KeySlashing = "slashing"
+
+	// slashingTxGasLimit is the gas limit of the transaction submitting the evidence
+	slashingTxGasLimit = 200000
+)
+
+var (
+	ErrInvalidEvidence       = errors.New("invalid evidence")
+	ErrInvalidSlashingTx     = errors.New("invalid slashing transaction")
+	errStaleEvidence         = errors.New("evidence is too old or too new")
+	errUnknownEvidenceType   = errors.New("unknown evidence type")
+	errDowntimeNotConfigured = errors.New("downtime slashing is not configured")
+)
+
+// slashingConfig is the configuration of slashing in params.engine.ibft of genesis.json
+type slashingConfig struct {
+	// Activation is the block from which the validators can be slashed
+	fork.Activation
+
+	// DowntimeThreshold is the number of consecutive blocks without the committed seal of the validator
+	// to slash the validator, zero disables the downtime slashing
+	DowntimeThreshold uint64 `json:"downtimeThreshold"`
+}
+
+// parseSlashingConfig reads the slashing configuration from IBFT config, returns nil if slashing is disabled
+func parseSlashingConfig(config map[string]interface{}) (*slashingConfig, error) {
+	rawConfig, ok := config[KeySlashing]
+	if !ok {
+		return nil, nil
+	}
+
+	bytes, err := json.Marshal(rawConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	slashing := &slashingConfig{}
+	if err := json.Unmarshal(bytes, slashing); err != nil {
+		return nil, err
+	}
+
+	return slashing, nil
+}
+
+// EvidenceType is the type of the misbehavior
+type EvidenceType uint8
+
+const (
+	// DoubleSignEvidenceType is the evidence of two signed messages
+	// for the same height and round with different proposal hashes
+	DoubleSignEvidenceType EvidenceType = iota + 1
+	// DowntimeEvidenceType is the evidence of the blocks without the committed seal of the validator
+	DowntimeEvidenceType
+)
+
+// Evidence is the proof of the misbehavior of the validator, submitted to the staking contract
+type Evidence struct {
+	Type      EvidenceType
+	Validator types.Address
+	// From and To are the range of heights in which the validator misbehaved
+	From uint64
+	To   uint64
+	// Messages are the marshalled IBFT messages signed by the validator for double signing
+	Messages [][]byte
+}
+
+// MarshalRLPTo defines the marshal function wrapper for Evidence
+func (e *Evidence) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(e.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith defines the marshal function implementation for Evidence
+func (e *Evidence) MarshalRLPWith(ar *fastrlp.Arena) *fastrlp.Value {
+	vv := ar.NewArray()
+
+	vv.Set(ar.NewUint(uint64(e.Type)))
+	vv.Set(ar.NewCopyBytes(e.Validator.Bytes()))
+	vv.Set(ar.NewUint(e.From))
+	vv.Set(ar.NewUint(e.To))
+
+	msgs := ar.NewArray()
+	for _, msg := range e.Messages {
+		msgs.Set(ar.NewCopyBytes(msg))
+	}
+
+	vv.Set(msgs)
+
+	return vv
+}
+
+// UnmarshalRLP defines the unmarshal function wrapper for Evidence
+func (e *Evidence) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(e.UnmarshalRLPFrom, input)
+}
+
+// UnmarshalRLPFrom defines the unmarshal implementation for Evidence
+func (e *Evidence) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) != 5 {
+		return fmt.Errorf("incorrect number of elements to decode evidence, expected 5 but found %d", len(elems))
+	}
+
+	typ, err := elems[0].GetUint64()
+	if err != nil {
+		return err
+	}
+
+	e.Type = EvidenceType(typ)
+
+	if err := elems[1].GetAddr(e.Validator[:]); err != nil {
+		return err
+	}
+
+	if e.From, err = elems[2].GetUint64(); err != nil {
+		return err
+	}
+
+	if e.To, err = elems[3].GetUint64(); err != nil {
+		return err
+	}
+
+	msgs, err := elems[4].GetElems()
+	if err != nil {
+		return err
+	}
+
+	e.Messages = make([][]byte, len(msgs))
+
+	for idx, msg := range msgs {
+		if e.Messages[idx], err = msg.GetBytes(nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newDoubleSignEvidence creates the evidence from two conflicting messages of the same sender
+func newDoubleSignEvidence(first, second *protoIBFT.Message) (*Evidence, error) {
+	rawFirst, err := proto.Marshal(first)
+	if err != nil {
+		return nil, err
+	}
+
+	rawSecond, err := proto.Marshal(second)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Evidence{
+		Type:      DoubleSignEvidenceType,
+		Validator: types.BytesToAddress(first.From),
+		From:      first.View.Height,
+		To:        first.View.Height,
+		Messages:  [][]byte{rawFirst, rawSecond},
+	}, nil
+}
+
+// messageProposalHash returns the proposal hash the message votes for,
+// PREPREPARE and ROUND CHANGE are not used for the evidence due to their size.
+// The payload is checked explicitly since the messages in the evidence are not trusted
+func messageProposalHash(msg *protoIBFT.Message) []byte {
+	switch payload := msg.Payload.(type) {
+	case *protoIBFT.Message_PrepareData:
+		if msg.Type == protoIBFT.MessageType_PREPARE && payload.PrepareData != nil {
+			return payload.PrepareData.ProposalHash
+		}
+	case *protoIBFT.Message_CommitData:
+		if msg.Type == protoIBFT.MessageType_COMMIT && payload.CommitData != nil {
+			return payload.CommitData.ProposalHash
+		}
+	}
+
+	return nil
+}
+
+// messageKey identifies the vote of the validator
+type messageKey struct {
+	height  uint64
+	round   uint64
+	msgType protoIBFT.MessageType
+	from    types.Address
+}
+
+// evidencePool collects the evidences of the misbehaviors from the messages and the committed seals
+type evidencePool struct {
+	sync.Mutex
+
+	downtimeThreshold uint64
+
+	// first vote of the validators in the recent views
+	votes map[messageKey]*protoIBFT.Message
+	// evidences waiting for the submission
+	pending map[types.Address]*Evidence
+	// number of the consecutive blocks without the committed seal of the validators
+	missed map[types.Address]uint64
+	// latest height of the block whose committed seals have been tracked
+	lastTracked uint64
+}
+
+func newEvidencePool(config *slashingConfig) *evidencePool {
+	return &evidencePool{
+		downtimeThreshold: config.DowntimeThreshold,
+		votes:             make(map[messageKey]*protoIBFT.Message),
+		pending:           make(map[types.Address]*Evidence),
+		missed:            make(map[types.Address]uint64),
+	}
+}
+
+// addMessage records the vote and creates the evidence if it conflicts with the previous vote
+func (p *evidencePool) addMessage(msg *protoIBFT.Message) (*Evidence, error) {
+	hash := messageProposalHash(msg)
+	if len(hash) == 0 || msg.View == nil {
+		return nil, nil
+	}
+
+	key := messageKey{
+		height:  msg.View.Height,
+		round:   msg.View.Round,
+		msgType: msg.Type,
+		from:    types.BytesToAddress(msg.From),
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	prev, ok := p.votes[key]
+	if !ok {
+		p.votes[key] = msg
+
+		return nil, nil
+	}
+
+	if bytes.Equal(messageProposalHash(prev), hash) {
+		return nil, nil
+	}
+
+	if _, ok := p.pending[key.from]; ok {
+		return nil, nil
+	}
+
+	evidence, err := newDoubleSignEvidence(prev, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	p.pending[key.from] = evidence
+
+	return evidence, nil
+}
+
+// trackCommittedSeals counts the consecutive blocks without the committed seals of the validators
+// and creates the evidence when the count reaches the threshold
+func (p *evidencePool) trackCommittedSeals(
+	height uint64,
+	vals validators.Validators,
+	signers []types.Address,
+) []*Evidence {
+	p.Lock()
+	defer p.Unlock()
+
+	// drop the votes of the finalized heights
+	for key := range p.votes {
+		if key.height <= height {
+			delete(p.votes, key)
+		}
+	}
+
+	if p.downtimeThreshold == 0 {
+		return nil
+	}
+
+	// the counts are valid only for the consecutive blocks
+	if p.lastTracked+1 != height {
+		p.missed = make(map[types.Address]uint64)
+	}
+
+	p.lastTracked = height
+
+	signed := make(map[types.Address]bool, len(signers))
+	for _, signer := range signers {
+		signed[signer] = true
+	}
+
+	missed := make(map[types.Address]uint64, vals.Len())
+	evidences := make([]*Evidence, 0)
+
+	for idx := 0; idx < vals.Len(); idx++ {
+		addr := vals.At(uint64(idx)).Addr()
+		if signed[addr] {
+			continue
+		}
+
+		count := p.missed[addr] + 1
+		if count < p.downtimeThreshold {
+			missed[addr] = count
+
+			continue
+		}
+
+		if _, ok := p.pending[addr]; ok {
+			continue
+		}
+
+		evidence := &Evidence{
+			Type:      DowntimeEvidenceType,
+			Validator: addr,
+			From:      height - count + 1,
+			To:        height,
+		}
+
+		p.pending[addr] = evidence
+		evidences = append(evidences, evidence)
+	}
+
+	// the validators who left the validator set or signed are reset
+	p.missed = missed
+
+	return evidences
+}
+
+// evidences returns the pending evidences in the order of the validator address
+func (p *evidencePool) evidences() []*Evidence {
+	p.Lock()
+	defer p.Unlock()
+
+	evidences := make([]*Evidence, 0, len(p.pending))
+	for _, evidence := range p.pending {
+		evidences = append(evidences, evidence)
+	}
+
+	sort.Slice(evidences, func(i, j int) bool {
+		return bytes.Compare(evidences[i].Validator.Bytes(), evidences[j].Validator.Bytes()) < 0
+	})
+
+	return evidences
+}
+
+// remove drops the pending evidence of the validator
+func (p *evidencePool) remove(validator types.Address) {
+	p.Lock()
+	defer p.Unlock()
+
+	delete(p.pending, validator)
+}
+
+// isSlashingEnabled returns whether the validators can be slashed at the given height,
+// slashing requires the staking contract used in PoS
+func (i *backendIBFT) isSlashingEnabled(height uint64) bool {
+	if i.evidencePool == nil || !i.slashingConfig.IsActive(height) {
+		return false
+	}
+
+	validatorStore, err := i.forkManager.GetValidatorStore(height)
+	if err != nil {
+		return false
+	}
+
+	return validatorStore.SourceType() == store.Contract
+}
+
+// verifyEvidence verifies the evidence submitted in the block at the given height
+func (i *backendIBFT) verifyEvidence(evidence *Evidence, height uint64) error {
+	// the evidence must be in the range of the last epoch
+	// so that the old misbehaviors are not punished repeatedly
+	if evidence.From > evidence.To || evidence.To >= height || evidence.To+i.forkManager.GetEpochSize(height) < height {
+		return errStaleEvidence
+	}
+
+	switch evidence.Type {
+	case DoubleSignEvidenceType:
+		return i.verifyDoubleSignEvidence(evidence)
+	case DowntimeEvidenceType:
+		return i.verifyDowntimeEvidence(evidence, height)
+	default:
+		return errUnknownEvidenceType
+	}
+}
+
+// verifyDoubleSignEvidence verifies that the validator signed two votes
+// for the same height and round with different proposal hashes
+func (i *backendIBFT) verifyDoubleSignEvidence(evidence *Evidence) error {
+	if len(evidence.Messages) != 2 || evidence.From != evidence.To {
+		return ErrInvalidEvidence
+	}
+
+	msgs := make([]*protoIBFT.Message, len(evidence.Messages))
+
+	for idx, raw := range evidence.Messages {
+		msg := &protoIBFT.Message{}
+		if err := proto.Unmarshal(raw, msg); err != nil {
+			return err
+		}
+
+		if msg.View == nil || msg.View.Height != evidence.To {
+			return ErrInvalidEvidence
+		}
+
+		if err := i.verifyMessageSigner(msg, evidence.Validator); err != nil {
+			return err
+		}
+
+		msgs[idx] = msg
+	}
+
+	first, second := msgs[0], msgs[1]
+
+	if first.Type != second.Type || first.View.Round != second.View.Round {
+		return ErrInvalidEvidence
+	}
+
+	firstHash, secondHash := messageProposalHash(first), messageProposalHash(second)
+	if len(firstHash) == 0 || len(secondHash) == 0 || bytes.Equal(firstHash, secondHash) {
+		return ErrInvalidEvidence
+	}
+
+	return nil
+}
+
+// verifyMessageSigner verifies the message was signed by the validator at the height of the message
+func (i *backendIBFT) verifyMessageSigner(msg *protoIBFT.Message, validator types.Address) error {
+	signer, vals, _, err := getModulesFromForkManager(i.forkManager, msg.View.Height)
+	if err != nil {
+		return err
+	}
+
+	msgNoSig, err := msg.PayloadNoSig()
+	if err != nil {
+		return err
+	}
+
+	signerAddress, err := signer.EcrecoverFromIBFTMessage(msg.Signature, msgNoSig)
+	if err != nil {
+		return err
+	}
+
+	if signerAddress != validator || !bytes.Equal(msg.From, validator.Bytes()) || !vals.Includes(validator) {
+		return ErrInvalidEvidence
+	}
+
+	return nil
+}
+
+// verifyDowntimeEvidence verifies that the blocks in the range don't have the committed seal of the validator.
+// The signers of each block are read from the parent committed seals of the next block, which are covered by its hash
+func (i *backendIBFT) verifyDowntimeEvidence(evidence *Evidence, height uint64) error {
+	threshold := uint64(0)
+	if i.slashingConfig != nil {
+		threshold = i.slashingConfig.DowntimeThreshold
+	}
+
+	if threshold == 0 {
+		return errDowntimeNotConfigured
+	}
+
+	// the range is bounded by the threshold to limit the cost of the verification
+	if evidence.To-evidence.From+1 != threshold || len(evidence.Messages) != 0 {
+		return ErrInvalidEvidence
+	}
+
+	// the next block of the range must precede the block including the evidence
+	if evidence.To+1 >= height {
+		return ErrInvalidEvidence
+	}
+
+	for number := evidence.From; number <= evidence.To; number++ {
+		child, ok := i.blockchain.GetHeaderByNumber(number + 1)
+		if !ok {
+			return fmt.Errorf("header %d not found", number+1)
+		}
+
+		signer, vals, _, err := getModulesFromForkManager(i.forkManager, number)
+		if err != nil {
+			return err
+		}
+
+		if !vals.Includes(evidence.Validator) {
+			return ErrInvalidEvidence
+		}
+
+		signers, err := signer.GetParentCommittedSealSigners(child, vals)
+		if err != nil {
+			return err
+		}
+
+		// the block without the parent committed seals doesn't prove the absence
+		if len(signers) == 0 {
+			return ErrInvalidEvidence
+		}
+
+		for _, addr := range signers {
+			if addr == evidence.Validator {
+				return ErrInvalidEvidence
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeSlashingTransactions submits the pending evidences to the staking contract
+// as the transactions signed by the proposer
+func (i *backendIBFT) writeSlashingTransactions(
+	header *types.Header,
+	transition *state.Transition,
+) []*types.Transaction {
+	if !i.isSlashingEnabled(header.Number) || !i.currentHooks.ShouldWriteTransactions(header.Number) {
+		return nil
+	}
+
+	var (
+		proposer = i.currentSigner.Address()
+		txSigner = crypto.NewSigner(
+			i.config.Params.Forks.At(header.Number),
+			uint64(i.config.Params.ChainID),
+		)
+		executed = make([]*types.Transaction, 0)
+	)
+
+	for _, evidence := range i.evidencePool.evidences() {
+		if err := i.verifyEvidence(evidence, header.Number); err != nil {
+			i.logger.Debug("drop evidence", "validator", evidence.Validator, "err", err)
+			i.evidencePool.remove(evidence.Validator)
+
+			continue
+		}
+
+		input, err := staking.EncodeSlashInput(evidence.Validator, evidence.MarshalRLPTo(nil))
+		if err != nil {
+			i.logger.Error("failed to encode slashing transaction", "err", err)
+
+			continue
+		}
+
+		tx, err := i.currentSigner.SignTx(&types.Transaction{
+			Nonce:    transition.GetNonce(proposer),
+			GasPrice: big.NewInt(0),
+			Gas:      slashingTxGasLimit,
+			To:       &staking.AddrStakingContract,
+			Value:    big.NewInt(0),
+			Input:    input,
+			From:     proposer,
+		}, txSigner)
+		if err != nil {
+			i.logger.Error("failed to sign slashing transaction", "err", err)
+
+			continue
+		}
+
+		if err := transition.Write(tx); err != nil {
+			i.logger.Error("failed to write slashing transaction", "validator", evidence.Validator, "err", err)
+
+			continue
+		}
+
+		i.logger.Info("submit evidence", "type", evidence.Type, "validator", evidence.Validator)
+
+		executed = append(executed, tx)
+	}
+
+	return executed
+}
+
+// verifySlashingTransactions verifies the evidences submitted by the proposer in the block
+func (i *backendIBFT) verifySlashingTransactions(block *types.Block) error {
+	if !i.isSlashingEnabled(block.Number()) {
+		return nil
+	}
+
+	proposer, err := i.extractProposer(block.Header)
+	if err != nil {
+		return err
+	}
+
+	txSigner := crypto.NewSigner(
+		i.config.Params.Forks.At(block.Number()),
+		uint64(i.config.Params.ChainID),
+	)
+
+	for _, tx := range block.Transactions {
+		if tx.To == nil || *tx.To != staking.AddrStakingContract || !staking.IsSlashInput(tx.Input) {
+			continue
+		}
+
+		from, err := txSigner.Sender(tx)
+		if err != nil {
+			return err
+		}
+
+		// the calls from the others are reverted by the staking contract
+		if from != proposer {
+			continue
+		}
+
+		validator, rawEvidence, err := staking.DecodeSlashInput(tx.Input)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSlashingTx, err)
+		}
+
+		evidence := &Evidence{}
+		if err := evidence.UnmarshalRLP(rawEvidence); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSlashingTx, err)
+		}
+
+		if evidence.Validator != validator {
+			return ErrInvalidSlashingTx
+		}
+
+		if err := i.verifyEvidence(evidence, block.Number()); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidEvidence, err)
+		}
+	}
+
+	return nil
+}
+
+// updateEvidencePool tracks the parent committed seals of the inserted block
+// and drops the evidences submitted in the block
+func (i *backendIBFT) updateEvidencePool(
+	block *types.Block,
+	parentVals validators.Validators,
+	parentSigners []types.Address,
+) {
+	if i.evidencePool == nil {
+		return
+	}
+
+	// the parent without the committed seals breaks the consecutive blocks
+	if i.isSlashingEnabled(block.Number()+1) && len(parentSigners) > 0 {
+		for _, evidence := range i.evidencePool.trackCommittedSeals(block.Number()-1, parentVals, parentSigners) {
+			i.logger.Warn(
+				"validator missed committed seals",
+				"validator", evidence.Validator,
+				"blocks", evidence.To-evidence.From+1,
+			)
+		}
+	}
+
+	for _, tx := range block.Transactions {
+		if tx.To == nil || *tx.To != staking.AddrStakingContract {
+			continue
+		}
+
+		if validator, _, err := staking.DecodeSlashInput(tx.Input); err == nil {
+			i.evidencePool.remove(validator)
+		}
+	}
+}
@@ -0,0 +1,382 @@
+package ibft
+
+import (
+	"errors"
+	"testing"
+
+	protoIBFT "github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestVote(
+	msgType protoIBFT.MessageType,
+	from types.Address,
+	height, round uint64,
+	hash types.Hash,
+) *protoIBFT.Message {
+	msg := &protoIBFT.Message{
+		View: &protoIBFT.View{
+			Height: height,
+			Round:  round,
+		},
+		From: from.Bytes(),
+		Type: msgType,
+	}
+
+	switch msgType {
+	case protoIBFT.MessageType_PREPARE:
+		msg.Payload = &protoIBFT.Message_PrepareData{
+			PrepareData: &protoIBFT.PrepareMessage{
+				ProposalHash: hash.Bytes(),
+			},
+		}
+	case protoIBFT.MessageType_COMMIT:
+		msg.Payload = &protoIBFT.Message_CommitData{
+			CommitData: &protoIBFT.CommitMessage{
+				ProposalHash: hash.Bytes(),
+			},
+		}
+	}
+
+	return msg
+}
+
+func TestParseSlashingConfig(t *testing.T) {
+	t.Parallel()
+
+	config, err := parseSlashingConfig(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Nil(t, config)
+
+	config, err = parseSlashingConfig(map[string]interface{}{
+		KeySlashing: map[string]interface{}{
+			"downtimeThreshold": float64(100),
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &slashingConfig{DowntimeThreshold: 100}, config)
+	assert.True(t, config.IsActive(0))
+
+	config, err = parseSlashingConfig(map[string]interface{}{
+		KeySlashing: map[string]interface{}{
+			"from":              "0x64",
+			"downtimeThreshold": float64(100),
+		},
+	})
+	assert.NoError(t, err)
+	assert.False(t, config.IsActive(99))
+	assert.True(t, config.IsActive(100))
+}
+
+func TestEvidenceRLP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		evidence *Evidence
+	}{
+		{
+			name: "double sign evidence",
+			evidence: &Evidence{
+				Type:      DoubleSignEvidenceType,
+				Validator: types.StringToAddress("1"),
+				From:      10,
+				To:        10,
+				Messages:  [][]byte{{0x1, 0x2}, {0x3}},
+			},
+		},
+		{
+			name: "downtime evidence",
+			evidence: &Evidence{
+				Type:      DowntimeEvidenceType,
+				Validator: types.StringToAddress("2"),
+				From:      1,
+				To:        100,
+				Messages:  [][]byte{},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			decoded := &Evidence{}
+
+			assert.NoError(t, decoded.UnmarshalRLP(test.evidence.MarshalRLPTo(nil)))
+			assert.Equal(t, test.evidence, decoded)
+		})
+	}
+}
+
+func TestEvidencePool_addMessage(t *testing.T) {
+	t.Parallel()
+
+	var (
+		validator = types.StringToAddress("1")
+		hash1     = types.StringToHash("1")
+		hash2     = types.StringToHash("2")
+	)
+
+	pool := newEvidencePool(&slashingConfig{})
+
+	// the first vote and the same vote again
+	evidence, err := pool.addMessage(newTestVote(protoIBFT.MessageType_PREPARE, validator, 1, 0, hash1))
+	assert.NoError(t, err)
+	assert.Nil(t, evidence)
+
+	evidence, err = pool.addMessage(newTestVote(protoIBFT.MessageType_PREPARE, validator, 1, 0, hash1))
+	assert.NoError(t, err)
+	assert.Nil(t, evidence)
+
+	// the votes in the other round and of the other type don't conflict
+	evidence, err = pool.addMessage(newTestVote(protoIBFT.MessageType_PREPARE, validator, 1, 1, hash2))
+	assert.NoError(t, err)
+	assert.Nil(t, evidence)
+
+	evidence, err = pool.addMessage(newTestVote(protoIBFT.MessageType_COMMIT, validator, 1, 0, hash2))
+	assert.NoError(t, err)
+	assert.Nil(t, evidence)
+
+	// conflicting vote
+	evidence, err = pool.addMessage(newTestVote(protoIBFT.MessageType_PREPARE, validator, 1, 0, hash2))
+	assert.NoError(t, err)
+	assert.NotNil(t, evidence)
+	assert.Equal(t, DoubleSignEvidenceType, evidence.Type)
+	assert.Equal(t, validator, evidence.Validator)
+	assert.Equal(t, uint64(1), evidence.From)
+	assert.Equal(t, uint64(1), evidence.To)
+	assert.Len(t, evidence.Messages, 2)
+
+	assert.Equal(t, []*Evidence{evidence}, pool.evidences())
+
+	pool.remove(validator)
+	assert.Empty(t, pool.evidences())
+}
+
+func TestEvidencePool_trackCommittedSeals(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1 = types.StringToAddress("1")
+		addr2 = types.StringToAddress("2")
+		vals  = validators.NewECDSAValidatorSet(
+			validators.NewECDSAValidator(addr1),
+			validators.NewECDSAValidator(addr2),
+		)
+	)
+
+	pool := newEvidencePool(&slashingConfig{DowntimeThreshold: 3})
+
+	assert.Empty(t, pool.trackCommittedSeals(1, vals, []types.Address{addr1}))
+	assert.Empty(t, pool.trackCommittedSeals(2, vals, []types.Address{addr1}))
+
+	// the count is reset if the blocks are not consecutive
+	assert.Empty(t, pool.trackCommittedSeals(4, vals, []types.Address{addr1}))
+	assert.Empty(t, pool.trackCommittedSeals(5, vals, []types.Address{addr1}))
+
+	evidences := pool.trackCommittedSeals(6, vals, []types.Address{addr1})
+	assert.Equal(t, []*Evidence{
+		{
+			Type:      DowntimeEvidenceType,
+			Validator: addr2,
+			From:      4,
+			To:        6,
+		},
+	}, evidences)
+
+	// the count is reset if the validator signs
+	pool.remove(addr2)
+
+	assert.Empty(t, pool.trackCommittedSeals(7, vals, []types.Address{addr1}))
+	assert.Empty(t, pool.trackCommittedSeals(8, vals, []types.Address{addr1, addr2}))
+	assert.Empty(t, pool.trackCommittedSeals(9, vals, []types.Address{addr1}))
+	assert.Empty(t, pool.trackCommittedSeals(10, vals, []types.Address{addr1}))
+}
+
+func TestMessageProposalHash(t *testing.T) {
+	t.Parallel()
+
+	hash := types.StringToHash("1")
+
+	assert.Equal(t, hash.Bytes(), messageProposalHash(
+		newTestVote(protoIBFT.MessageType_COMMIT, types.ZeroAddress, 1, 0, hash),
+	))
+
+	// the type and the payload mismatch
+	msg := newTestVote(protoIBFT.MessageType_PREPARE, types.ZeroAddress, 1, 0, hash)
+	msg.Type = protoIBFT.MessageType_COMMIT
+
+	assert.Nil(t, messageProposalHash(msg))
+	assert.Nil(t, messageProposalHash(&protoIBFT.Message{Type: protoIBFT.MessageType_COMMIT}))
+}
+
+// newTestSlashingChain creates the headers sealed by the validators,
+// the committed seals of each header are signed by the given validators
+// and the parent committed seals of the next header are signed by the canonical signers
+func newTestSlashingChain(
+	t *testing.T,
+	pool *testerAccountPool,
+	length uint64,
+	canonicalSigners []string,
+	localSigners []string,
+) (*blockchain.Blockchain, []*types.Block) {
+	t.Helper()
+
+	var (
+		vals     = pool.ValidatorSet()
+		proposer = newTestSigner(pool.get("A"))
+		headers  = make(map[types.Hash]*types.Header)
+		hashes   = make(map[uint64]types.Hash)
+		blocks   = make([]*types.Block, 0, length)
+
+		parentHash  types.Hash
+		parentSeals signer.Seals
+	)
+
+	sealMap := func(hash types.Hash, names []string) map[types.Address][]byte {
+		seals := make(map[types.Address][]byte, len(names))
+
+		for _, name := range names {
+			seal, err := newTestSigner(pool.get(name)).CreateCommittedSeal(hash.Bytes())
+			require.NoError(t, err)
+
+			seals[pool.get(name).Address()] = seal
+		}
+
+		return seals
+	}
+
+	for number := uint64(1); number <= length; number++ {
+		header := &types.Header{
+			Number:     number,
+			ParentHash: parentHash,
+		}
+
+		proposer.InitIBFTExtra(header, vals, parentSeals)
+
+		header, err := proposer.WriteProposerSeal(header)
+		require.NoError(t, err)
+
+		header.Hash, err = proposer.CalculateHeaderHash(header)
+		require.NoError(t, err)
+
+		canonical, err := proposer.WriteCommittedSeals(header.Copy(), sealMap(header.Hash, canonicalSigners))
+		require.NoError(t, err)
+
+		local, err := proposer.WriteCommittedSeals(header.Copy(), sealMap(header.Hash, localSigners))
+		require.NoError(t, err)
+
+		extra, err := proposer.GetIBFTExtra(canonical)
+		require.NoError(t, err)
+
+		headers[local.Hash] = local
+		hashes[number] = local.Hash
+		blocks = append(blocks, &types.Block{Header: local})
+
+		parentHash, parentSeals = header.Hash, extra.CommittedSeals
+	}
+
+	chain, err := blockchain.NewMockBlockchain(map[blockchain.TestCallbackType]interface{}{
+		blockchain.StorageCallback: func(storage *storage.MockStorage) {
+			storage.HookReadCanonicalHash(func(number uint64) (types.Hash, bool) {
+				hash, ok := hashes[number]
+
+				return hash, ok
+			})
+			storage.HookReadHeader(func(hash types.Hash) (*types.Header, error) {
+				header, ok := headers[hash]
+				if !ok {
+					return nil, errors.New("header not found")
+				}
+
+				return header, nil
+			})
+		},
+	})
+	require.NoError(t, err)
+
+	return chain, blocks
+}
+
+func TestDowntimeEvidence_DifferentCommittedSeals(t *testing.T) {
+	t.Parallel()
+
+	const threshold = 3
+
+	pool := newTesterAccountPool(t)
+	pool.add("A", "B", "C", "D")
+
+	var (
+		vals     = pool.ValidatorSet()
+		absent   = pool.get("D").Address()
+		evidence = &Evidence{
+			Type:      DowntimeEvidenceType,
+			Validator: absent,
+			From:      1,
+			To:        threshold,
+		}
+	)
+
+	// D is absent in the parent committed seals on all nodes,
+	// but the nodes received the different committed seals of each block.
+	// The first node has the committed seals of the canonical signers,
+	// the second node has the late committed seal of D instead of C
+	nodes := make([]*backendIBFT, 0, 2)
+
+	for _, localSigners := range [][]string{
+		{"A", "B", "C"},
+		{"A", "B", "D"},
+	} {
+		chain, blocks := newTestSlashingChain(t, pool, threshold+2, []string{"A", "B", "C"}, localSigners)
+
+		node := &backendIBFT{
+			logger:     hclog.NewNullLogger(),
+			blockchain: chain,
+			forkManager: &mockForkManager{
+				signer: newTestSigner(pool.get("A")),
+				vals:   vals,
+			},
+			slashingConfig: &slashingConfig{DowntimeThreshold: threshold},
+			evidencePool:   newEvidencePool(&slashingConfig{DowntimeThreshold: threshold}),
+			participation:  newParticipationTracker(newMockParticipationStore(), 10),
+		}
+
+		// the committed seals of each block are tracked when its child is inserted
+		for _, block := range blocks[:threshold+1] {
+			node.trackCommittedSeals(block)
+		}
+
+		assert.Equal(t, []*Evidence{evidence}, node.evidencePool.evidences())
+
+		stats := node.participation.getStats(nil)
+		assert.Equal(t, uint64(threshold), stats.To)
+		assert.Equal(t, uint64(0), stats.get(absent).CommittedSeals)
+
+		nodes = append(nodes, node)
+	}
+
+	for _, node := range nodes {
+		assert.NoError(t, node.verifyEvidence(evidence, threshold+2))
+
+		// the child of the last block in the range must precede the block including the evidence
+		assert.ErrorIs(t, node.verifyEvidence(evidence, threshold+1), ErrInvalidEvidence)
+
+		// C signed the parent committed seals
+		assert.ErrorIs(t, node.verifyEvidence(&Evidence{
+			Type:      DowntimeEvidenceType,
+			Validator: pool.get("C").Address(),
+			From:      1,
+			To:        threshold,
+		}, threshold+2), ErrInvalidEvidence)
+	}
+}
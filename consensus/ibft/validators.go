@@ -2,6 +2,8 @@ package ibft
 
 import (
 	"math"
+	"math/big"
+	"sort"
 
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/validators"
@@ -48,6 +50,104 @@ func OptimalQuorumSize(set validators.Validators) int {
 	return int(math.Ceil(2 * float64(set.Len()) / 3))
 }
 
+// MinWeightedQuorumSize returns the minimum number of the validators that can have
+// more than 2/3 of the total voting power, which are the ones with the highest voting powers
+func MinWeightedQuorumSize(votingPowers map[types.Address]*big.Int) QuorumImplementation {
+	return func(set validators.Validators) int {
+		return weightedQuorumSize(set, votingPowers)
+	}
+}
+
+// HasWeightedQuorum returns whether the given signers have more than 2/3 of the total voting power
+func HasWeightedQuorum(
+	set validators.Validators,
+	votingPowers map[types.Address]*big.Int,
+	signers []types.Address,
+) bool {
+	total := totalVotingPower(set, votingPowers)
+	if total.Sign() == 0 {
+		return len(signers) >= OptimalQuorumSize(set)
+	}
+
+	var (
+		signed = new(big.Int)
+		seen   = make(map[types.Address]bool, len(signers))
+	)
+
+	for _, signer := range signers {
+		if seen[signer] || !set.Includes(signer) {
+			continue
+		}
+
+		seen[signer] = true
+
+		if power, ok := votingPowers[signer]; ok {
+			signed.Add(signed, power)
+		}
+	}
+
+	return hasSuperMajority(signed, total)
+}
+
+// weightedQuorumSize returns the number of the validators, picked from the highest voting power,
+// that have more than 2/3 of the total voting power
+func weightedQuorumSize(
+	set validators.Validators,
+	votingPowers map[types.Address]*big.Int,
+) int {
+	powers := make([]*big.Int, 0, set.Len())
+
+	for idx := 0; idx < set.Len(); idx++ {
+		power, ok := votingPowers[set.At(uint64(idx)).Addr()]
+		if !ok {
+			power = big.NewInt(0)
+		}
+
+		powers = append(powers, power)
+	}
+
+	sort.Slice(powers, func(i, j int) bool {
+		return powers[i].Cmp(powers[j]) > 0
+	})
+
+	total := totalVotingPower(set, votingPowers)
+	if total.Sign() == 0 {
+		// fallback to the equal weights if no one has staked
+		return OptimalQuorumSize(set)
+	}
+
+	sum := new(big.Int)
+
+	for idx, power := range powers {
+		sum.Add(sum, power)
+
+		if hasSuperMajority(sum, total) {
+			return idx + 1
+		}
+	}
+
+	return set.Len()
+}
+
+// totalVotingPower returns the sum of the voting powers of the validators
+func totalVotingPower(set validators.Validators, votingPowers map[types.Address]*big.Int) *big.Int {
+	total := new(big.Int)
+
+	for idx := 0; idx < set.Len(); idx++ {
+		if power, ok := votingPowers[set.At(uint64(idx)).Addr()]; ok {
+			total.Add(total, power)
+		}
+	}
+
+	return total
+}
+
+// hasSuperMajority returns whether the given voting power is more than 2/3 of the total
+func hasSuperMajority(power, total *big.Int) bool {
+	// power * 3 > total * 2
+	return new(big.Int).Mul(power, big.NewInt(3)).Cmp(new(big.Int).Mul(total, big.NewInt(2))) > 0
+}
+
 func CalcProposer(
 	validators validators.Validators,
 	round uint64,
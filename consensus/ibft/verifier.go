@@ -60,6 +60,12 @@ func (i *backendIBFT) IsValidBlock(proposal []byte) bool {
 		return false
 	}
 
+	if err := i.verifySlashingTransactions(newBlock); err != nil {
+		i.logger.Error("slashing transaction verification failed", "err", err)
+
+		return false
+	}
+
 	return true
 }
 
@@ -107,6 +113,15 @@ func (i *backendIBFT) IsValidSender(msg *protoIBFT.Message) bool {
 		return false
 	}
 
+	if i.evidencePool != nil {
+		evidence, err := i.evidencePool.addMessage(msg)
+		if err != nil {
+			i.logger.Error("failed to create evidence", "signer", signerAddress, "err", err)
+		} else if evidence != nil {
+			i.logger.Warn("validator signed conflicting messages", "signer", signerAddress, "height", msg.View.Height)
+		}
+	}
+
 	return true
 }
 
@@ -0,0 +1,103 @@
+package ibft
+
+import (
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+)
+
+var (
+	ErrNotEnoughVotingPower = errors.New("signers don't have enough voting power")
+)
+
+// verifyCommittedSealsQuorum verifies the Committed Seals of the header and checks they reach the quorum.
+// If the quorum is weighted by stake, the signers need to have more than 2/3 of the total voting power
+func (i *backendIBFT) verifyCommittedSealsQuorum(
+	header *types.Header,
+	headerSigner signer.Signer,
+	vals validators.Validators,
+) error {
+	votingPowers, err := i.forkManager.GetVotingPowers(header.Number)
+	if err != nil {
+		return err
+	}
+
+	if votingPowers == nil {
+		return headerSigner.VerifyCommittedSeals(
+			header,
+			vals,
+			i.quorumSize(header.Number)(vals),
+		)
+	}
+
+	if err := headerSigner.VerifyCommittedSeals(
+		header,
+		vals,
+		MinWeightedQuorumSize(votingPowers)(vals),
+	); err != nil {
+		return err
+	}
+
+	signers, err := headerSigner.GetCommittedSealSigners(header, vals)
+	if err != nil {
+		return err
+	}
+
+	if !HasWeightedQuorum(vals, votingPowers, signers) {
+		return ErrNotEnoughVotingPower
+	}
+
+	return nil
+}
+
+// verifyParentCommittedSealsQuorum verifies the Parent Committed Seals of the header
+// and checks they reach the quorum in the same way as verifyCommittedSealsQuorum
+func (i *backendIBFT) verifyParentCommittedSealsQuorum(
+	parent, header *types.Header,
+	parentSigner signer.Signer,
+	parentValidators validators.Validators,
+	mustExist bool,
+) error {
+	votingPowers, err := i.forkManager.GetVotingPowers(parent.Number)
+	if err != nil {
+		return err
+	}
+
+	if votingPowers == nil {
+		return parentSigner.VerifyParentCommittedSeals(
+			parent,
+			header,
+			parentValidators,
+			i.quorumSize(parent.Number)(parentValidators),
+			mustExist,
+		)
+	}
+
+	if err := parentSigner.VerifyParentCommittedSeals(
+		parent,
+		header,
+		parentValidators,
+		MinWeightedQuorumSize(votingPowers)(parentValidators),
+		mustExist,
+	); err != nil {
+		return err
+	}
+
+	signers, err := parentSigner.GetParentCommittedSealSigners(header, parentValidators)
+	if err != nil {
+		return err
+	}
+
+	// the seals don't exist in the past header
+	if len(signers) == 0 && !mustExist {
+		return nil
+	}
+
+	if !HasWeightedQuorum(parentValidators, votingPowers, signers) {
+		return ErrNotEnoughVotingPower
+	}
+
+	return nil
+}
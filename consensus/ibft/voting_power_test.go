@@ -0,0 +1,221 @@
+package ibft
+
+import (
+	"math/big"
+	"testing"
+
+	protoIBFT "github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMinWeightedQuorumSize(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1 = types.StringToAddress("1")
+		addr2 = types.StringToAddress("2")
+		addr3 = types.StringToAddress("3")
+		addr4 = types.StringToAddress("4")
+
+		vals = validators.NewECDSAValidatorSet(
+			validators.NewECDSAValidator(addr1),
+			validators.NewECDSAValidator(addr2),
+			validators.NewECDSAValidator(addr3),
+			validators.NewECDSAValidator(addr4),
+		)
+	)
+
+	tests := []struct {
+		name         string
+		votingPowers map[types.Address]*big.Int
+		minQuorum    int
+	}{
+		{
+			name: "equal voting powers",
+			votingPowers: map[types.Address]*big.Int{
+				addr1: big.NewInt(10),
+				addr2: big.NewInt(10),
+				addr3: big.NewInt(10),
+				addr4: big.NewInt(10),
+			},
+			minQuorum: 3,
+		},
+		{
+			name: "a validator has the most of voting power",
+			votingPowers: map[types.Address]*big.Int{
+				addr1: big.NewInt(70),
+				addr2: big.NewInt(10),
+				addr3: big.NewInt(10),
+				addr4: big.NewInt(10),
+			},
+			minQuorum: 1,
+		},
+		{
+			name: "a validator doesn't have voting power",
+			votingPowers: map[types.Address]*big.Int{
+				addr1: big.NewInt(40),
+				addr2: big.NewInt(30),
+				addr3: big.NewInt(30),
+			},
+			minQuorum: 2,
+		},
+		{
+			name:         "nobody has voting power",
+			votingPowers: map[types.Address]*big.Int{},
+			minQuorum:    OptimalQuorumSize(vals),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.minQuorum, MinWeightedQuorumSize(test.votingPowers)(vals))
+		})
+	}
+}
+
+func TestHasWeightedQuorum(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1 = types.StringToAddress("1")
+		addr2 = types.StringToAddress("2")
+		addr3 = types.StringToAddress("3")
+		addr4 = types.StringToAddress("4")
+
+		vals = validators.NewECDSAValidatorSet(
+			validators.NewECDSAValidator(addr1),
+			validators.NewECDSAValidator(addr2),
+			validators.NewECDSAValidator(addr3),
+		)
+
+		votingPowers = map[types.Address]*big.Int{
+			addr1: big.NewInt(50),
+			addr2: big.NewInt(30),
+			addr3: big.NewInt(40),
+			addr4: big.NewInt(100),
+		}
+	)
+
+	tests := []struct {
+		name     string
+		signers  []types.Address
+		expected bool
+	}{
+		{
+			name:     "more than 2/3 of voting power",
+			signers:  []types.Address{addr1, addr3},
+			expected: true,
+		},
+		{
+			name:     "exactly 2/3 of voting power isn't enough",
+			signers:  []types.Address{addr1, addr2, addr1},
+			expected: false,
+		},
+		{
+			name:     "the majority of validators without enough voting power",
+			signers:  []types.Address{addr2, addr3},
+			expected: false,
+		},
+		{
+			name:     "voting power of non-validator isn't counted",
+			signers:  []types.Address{addr2, addr3, addr4},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, HasWeightedQuorum(vals, votingPowers, test.signers))
+		})
+	}
+}
+
+func TestWeightedQuorum_SkewedStakesValidatorDown(t *testing.T) {
+	t.Parallel()
+
+	pool := newTesterAccountPool(t)
+	pool.add("A", "B", "C", "D")
+
+	var (
+		vals         = pool.ValidatorSet()
+		votingPowers = map[types.Address]*big.Int{
+			pool.get("A").Address(): big.NewInt(70),
+			pool.get("B").Address(): big.NewInt(10),
+			pool.get("C").Address(): big.NewInt(10),
+			pool.get("D").Address(): big.NewInt(10),
+		}
+		headerSigner = newTestSigner(pool.get("A"))
+	)
+
+	backend := &backendIBFT{
+		forkManager: &mockForkManager{
+			signer:       headerSigner,
+			vals:         vals,
+			votingPowers: votingPowers,
+		},
+	}
+
+	commitMessages := func(names ...string) []*protoIBFT.Message {
+		msgs := make([]*protoIBFT.Message, len(names))
+
+		for idx, name := range names {
+			msgs[idx] = &protoIBFT.Message{
+				Type: protoIBFT.MessageType_COMMIT,
+				From: pool.get(name).Address().Bytes(),
+			}
+		}
+
+		return msgs
+	}
+
+	sealedHeader := func(names ...string) *types.Header {
+		header := &types.Header{Number: 10}
+
+		headerSigner.InitIBFTExtra(header, vals, nil)
+
+		header, err := headerSigner.WriteProposerSeal(header)
+		require.NoError(t, err)
+
+		header.Hash, err = headerSigner.CalculateHeaderHash(header)
+		require.NoError(t, err)
+
+		seals := make(map[types.Address][]byte, len(names))
+
+		for _, name := range names {
+			seal, err := newTestSigner(pool.get(name)).CreateCommittedSeal(header.Hash.Bytes())
+			require.NoError(t, err)
+
+			seals[pool.get(name).Address()] = seal
+		}
+
+		header, err = headerSigner.WriteCommittedSeals(header, seals)
+		require.NoError(t, err)
+
+		return header
+	}
+
+	// D is down, the others have 90% of the voting power,
+	// go-ibft finalizes the block and the committed seals are valid
+	assert.True(t, backend.HasQuorum(10, commitMessages("A", "B", "C"), protoIBFT.MessageType_COMMIT))
+	assert.NoError(t, backend.verifyCommittedSealsQuorum(sealedHeader("A", "B", "C"), headerSigner, vals))
+
+	// A is down, the others have 30% of the voting power,
+	// go-ibft doesn't finalize the block which couldn't be verified
+	assert.False(t, backend.HasQuorum(10, commitMessages("B", "C", "D"), protoIBFT.MessageType_COMMIT))
+	assert.ErrorIs(
+		t,
+		backend.verifyCommittedSealsQuorum(sealedHeader("B", "C", "D"), headerSigner, vals),
+		ErrNotEnoughVotingPower,
+	)
+}
@@ -8,6 +8,15 @@ var (
 	// ABI for Staking Contract
 	StakingABI = abi.MustNewABI(StakingJSONABI)
 
+	// ABI for the slashing entry point of Staking Contract
+	SlashingABI = abi.MustNewABI(SlashingJSONABI)
+
+	// ABI for the delegation entry points of Staking Contract
+	DelegationABI = abi.MustNewABI(DelegationJSONABI)
+
+	// ABI for the governance system contract of the IBFT fork parameters
+	GovernanceABI = abi.MustNewABI(GovernanceJSONABI)
+
 	// ABI for Contract used in e2e stress test
 	StressTestABI = abi.MustNewABI(StressTestJSONABI)
 )
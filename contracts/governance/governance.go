@@ -0,0 +1,267 @@
+package governance
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/contracts/abis"
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/ethgo/abi"
+)
+
+const (
+	methodProposeFork = "proposeFork"
+	methodVoteFork    = "voteFork"
+	eventForkProposed = "ForkProposed"
+	eventForkVoted    = "ForkVoted"
+
+	// governanceGasCost is the gas consumed by the proposal and the vote
+	governanceGasCost uint64 = 30000
+)
+
+var (
+	// governance system contract address
+	AddrGovernanceContract = types.StringToAddress("1002")
+
+	// forkProposedDataType is the type of the non-indexed data of ForkProposed event
+	forkProposedDataType = abi.MustNewType("tuple(bytes fork)")
+)
+
+var (
+	ErrGovernanceNotBySystem  = errors.New("governance is allowed only for the block proposer")
+	ErrInvalidGovernanceCall  = errors.New("invalid governance call")
+	ErrFailedTypeAssertion    = errors.New("failed type assertion")
+	ErrEmptyForkProposal      = errors.New("fork proposal is empty")
+	ErrUnknownGovernanceInput = errors.New("unknown governance input")
+	ErrInvalidGovernanceLog   = errors.New("invalid governance log")
+)
+
+// Event is the proposal or the vote recorded by the governance contract
+type Event struct {
+	// ID is the ID of the proposal
+	ID types.Hash
+	// Sender is the validator who proposed or voted
+	Sender types.Address
+	// Fork is the encoded fork of the proposal, nil for the vote
+	Fork []byte
+}
+
+// ProposalID returns the ID of the proposal of the encoded fork
+func ProposalID(fork []byte) types.Hash {
+	return types.BytesToHash(keccak.Keccak256(nil, fork))
+}
+
+// EncodeProposeForkInput encodes the input to propose the encoded fork
+func EncodeProposeForkInput(fork []byte) ([]byte, error) {
+	if len(fork) == 0 {
+		return nil, ErrEmptyForkProposal
+	}
+
+	return abis.GovernanceABI.Methods[methodProposeFork].Encode(map[string]interface{}{
+		"fork": fork,
+	})
+}
+
+// EncodeVoteForkInput encodes the input to vote for the proposal
+func EncodeVoteForkInput(id types.Hash) ([]byte, error) {
+	return abis.GovernanceABI.Methods[methodVoteFork].Encode(map[string]interface{}{
+		"id": id,
+	})
+}
+
+// DecodeInput decodes the input of the governance contract.
+// It returns the encoded fork for the proposal, or the proposal ID for the vote
+func DecodeInput(input []byte) ([]byte, *types.Hash, error) {
+	method := governanceMethod(input)
+	if method == nil {
+		return nil, nil, ErrUnknownGovernanceInput
+	}
+
+	decoded, err := method.Inputs.Decode(input[4:])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	args, ok := decoded.(map[string]interface{})
+	if !ok {
+		return nil, nil, ErrFailedTypeAssertion
+	}
+
+	if method.Name == methodProposeFork {
+		fork, ok := args["fork"].([]byte)
+		if !ok {
+			return nil, nil, ErrFailedTypeAssertion
+		}
+
+		if len(fork) == 0 {
+			return nil, nil, ErrEmptyForkProposal
+		}
+
+		return fork, nil, nil
+	}
+
+	rawID, ok := args["id"].([32]byte)
+	if !ok {
+		return nil, nil, ErrFailedTypeAssertion
+	}
+
+	id := types.Hash(rawID)
+
+	return nil, &id, nil
+}
+
+// ParseLog parses the log emitted by the governance contract.
+// It returns nil if the log is not emitted by the governance contract
+func ParseLog(log *types.Log) (*Event, error) {
+	if log.Address != AddrGovernanceContract || len(log.Topics) == 0 {
+		return nil, nil
+	}
+
+	switch log.Topics[0] {
+	case types.Hash(abis.GovernanceABI.Events[eventForkProposed].ID()):
+		if len(log.Topics) != 3 {
+			return nil, ErrInvalidGovernanceLog
+		}
+
+		decoded, err := forkProposedDataType.Decode(log.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		args, ok := decoded.(map[string]interface{})
+		if !ok {
+			return nil, ErrFailedTypeAssertion
+		}
+
+		fork, ok := args["fork"].([]byte)
+		if !ok || len(fork) == 0 {
+			return nil, ErrInvalidGovernanceLog
+		}
+
+		return &Event{
+			ID:     log.Topics[1],
+			Sender: types.BytesToAddress(log.Topics[2].Bytes()),
+			Fork:   fork,
+		}, nil
+	case types.Hash(abis.GovernanceABI.Events[eventForkVoted].ID()):
+		if len(log.Topics) != 3 {
+			return nil, ErrInvalidGovernanceLog
+		}
+
+		return &Event{
+			ID:     log.Topics[1],
+			Sender: types.BytesToAddress(log.Topics[2].Bytes()),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// governanceMethod returns the method called by the input, nil if the input doesn't call the governance contract
+func governanceMethod(input []byte) *abi.Method {
+	if len(input) < 4 {
+		return nil
+	}
+
+	for _, method := range abis.GovernanceABI.Methods {
+		if bytes.Equal(input[:4], method.ID()) {
+			return method
+		}
+	}
+
+	return nil
+}
+
+// GovernanceRuntime is the governance system contract of the IBFT fork parameters.
+// Only the block proposer can call it, so the proposals and the votes are submitted
+// by the validators in the system transactions. The contract only records them in the logs,
+// the votes are counted by the consensus which knows the validator set
+type GovernanceRuntime struct{}
+
+// NewGovernanceRuntime is a constructor of GovernanceRuntime
+func NewGovernanceRuntime() *GovernanceRuntime {
+	return &GovernanceRuntime{}
+}
+
+// Name returns the name of the runtime
+func (r *GovernanceRuntime) Name() string {
+	return "governance"
+}
+
+// CanRun returns whether the call is for the governance contract
+func (r *GovernanceRuntime) CanRun(c *runtime.Contract, _ runtime.Host, _ *chain.ForksInTime) bool {
+	return c.CodeAddress == AddrGovernanceContract
+}
+
+// Run records the proposal or the vote of the block proposer
+func (r *GovernanceRuntime) Run(
+	c *runtime.Contract,
+	host runtime.Host,
+	_ *chain.ForksInTime,
+) *runtime.ExecutionResult {
+	if c.Gas < governanceGasCost {
+		return &runtime.ExecutionResult{
+			GasLeft: 0,
+			Err:     runtime.ErrOutOfGas,
+		}
+	}
+
+	gasLeft := c.Gas - governanceGasCost
+
+	revert := func(err error) *runtime.ExecutionResult {
+		return &runtime.ExecutionResult{
+			ReturnValue: []byte(err.Error()),
+			GasLeft:     gasLeft,
+			Err:         runtime.ErrExecutionReverted,
+		}
+	}
+
+	if c.Caller != host.GetTxContext().Coinbase {
+		return revert(ErrGovernanceNotBySystem)
+	}
+
+	if c.Value != nil && c.Value.Sign() != 0 {
+		return revert(ErrInvalidGovernanceCall)
+	}
+
+	fork, id, err := DecodeInput(c.Input)
+	if err != nil {
+		return revert(err)
+	}
+
+	if fork != nil {
+		data, err := forkProposedDataType.Encode(map[string]interface{}{
+			"fork": fork,
+		})
+		if err != nil {
+			return revert(err)
+		}
+
+		host.EmitLog(
+			AddrGovernanceContract,
+			[]types.Hash{
+				types.Hash(abis.GovernanceABI.Events[eventForkProposed].ID()),
+				ProposalID(fork),
+				types.BytesToHash(c.Caller.Bytes()),
+			},
+			data,
+		)
+	} else {
+		host.EmitLog(
+			AddrGovernanceContract,
+			[]types.Hash{
+				types.Hash(abis.GovernanceABI.Events[eventForkVoted].ID()),
+				*id,
+				types.BytesToHash(c.Caller.Bytes()),
+			},
+			nil,
+		)
+	}
+
+	return &runtime.ExecutionResult{
+		GasLeft: gasLeft,
+	}
+}
@@ -0,0 +1,204 @@
+package governance
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	addr1 = types.StringToAddress("1")
+	addr2 = types.StringToAddress("2")
+)
+
+type mockHost struct {
+	runtime.Host
+
+	coinbase types.Address
+	logs     []*types.Log
+}
+
+func (m *mockHost) GetTxContext() runtime.TxContext {
+	return runtime.TxContext{Coinbase: m.coinbase}
+}
+
+func (m *mockHost) EmitLog(addr types.Address, topics []types.Hash, data []byte) {
+	m.logs = append(m.logs, &types.Log{
+		Address: addr,
+		Topics:  topics,
+		Data:    data,
+	})
+}
+
+func TestGovernanceInput(t *testing.T) {
+	t.Parallel()
+
+	fork := []byte(`{"type":"PoS","from":"0x64"}`)
+
+	proposeInput, err := EncodeProposeForkInput(fork)
+	assert.NoError(t, err)
+
+	decodedFork, decodedID, err := DecodeInput(proposeInput)
+	assert.NoError(t, err)
+	assert.Equal(t, fork, decodedFork)
+	assert.Nil(t, decodedID)
+
+	id := ProposalID(fork)
+
+	voteInput, err := EncodeVoteForkInput(id)
+	assert.NoError(t, err)
+
+	decodedFork, decodedID, err = DecodeInput(voteInput)
+	assert.NoError(t, err)
+	assert.Nil(t, decodedFork)
+	assert.Equal(t, &id, decodedID)
+
+	_, err = EncodeProposeForkInput(nil)
+	assert.ErrorIs(t, err, ErrEmptyForkProposal)
+
+	_, _, err = DecodeInput([]byte{0x1})
+	assert.ErrorIs(t, err, ErrUnknownGovernanceInput)
+}
+
+func TestGovernanceRuntime(t *testing.T) {
+	t.Parallel()
+
+	fork := []byte(`{"type":"PoS","from":"0x64"}`)
+	id := ProposalID(fork)
+
+	proposeInput, err := EncodeProposeForkInput(fork)
+	assert.NoError(t, err)
+
+	voteInput, err := EncodeVoteForkInput(id)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		caller        types.Address
+		value         *big.Int
+		gas           uint64
+		input         []byte
+		err           error
+		expectedEvent *Event
+	}{
+		{
+			name:   "should record the proposal",
+			caller: addr1,
+			value:  big.NewInt(0),
+			gas:    governanceGasCost,
+			input:  proposeInput,
+			expectedEvent: &Event{
+				ID:     id,
+				Sender: addr1,
+				Fork:   fork,
+			},
+		},
+		{
+			name:   "should record the vote",
+			caller: addr1,
+			value:  big.NewInt(0),
+			gas:    governanceGasCost,
+			input:  voteInput,
+			expectedEvent: &Event{
+				ID:     id,
+				Sender: addr1,
+			},
+		},
+		{
+			name:   "should revert if the caller is not the proposer",
+			caller: addr2,
+			value:  big.NewInt(0),
+			gas:    governanceGasCost,
+			input:  voteInput,
+			err:    runtime.ErrExecutionReverted,
+		},
+		{
+			name:   "should revert if the value is given",
+			caller: addr1,
+			value:  big.NewInt(1),
+			gas:    governanceGasCost,
+			input:  voteInput,
+			err:    runtime.ErrExecutionReverted,
+		},
+		{
+			name:   "should revert if the input is unknown",
+			caller: addr1,
+			value:  big.NewInt(0),
+			gas:    governanceGasCost,
+			input:  []byte{0x1, 0x2, 0x3, 0x4},
+			err:    runtime.ErrExecutionReverted,
+		},
+		{
+			name:   "should return error if gas is not enough",
+			caller: addr1,
+			value:  big.NewInt(0),
+			gas:    governanceGasCost - 1,
+			input:  voteInput,
+			err:    runtime.ErrOutOfGas,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				host     = &mockHost{coinbase: addr1}
+				contract = runtime.NewContractCall(
+					1,
+					test.caller,
+					test.caller,
+					AddrGovernanceContract,
+					test.value,
+					test.gas,
+					nil,
+					test.input,
+				)
+				governance = NewGovernanceRuntime()
+			)
+
+			assert.True(t, governance.CanRun(contract, host, &chain.ForksInTime{}))
+
+			result := governance.Run(contract, host, &chain.ForksInTime{})
+			assert.ErrorIs(t, result.Err, test.err)
+
+			if test.expectedEvent == nil {
+				assert.Empty(t, host.logs)
+
+				return
+			}
+
+			assert.Len(t, host.logs, 1)
+
+			event, err := ParseLog(host.logs[0])
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedEvent, event)
+		})
+	}
+}
+
+func TestParseLog(t *testing.T) {
+	t.Parallel()
+
+	// the logs of the other contracts are ignored
+	event, err := ParseLog(&types.Log{
+		Address: addr1,
+		Topics:  []types.Hash{{0x1}},
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, event)
+
+	// the unknown events are ignored
+	event, err = ParseLog(&types.Log{
+		Address: AddrGovernanceContract,
+		Topics:  []types.Hash{{0x1}},
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, event)
+}
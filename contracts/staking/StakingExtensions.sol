@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.7;
+
+// The slashing and the delegation entry points of the staking contract.
+//
+// The code of the staking contract, built from the external staking contract, is already in the state
+// of the running chains and replacing it would need a state migration at a hard fork,
+// so SlashingRuntime and DelegationRuntime execute these entry points natively
+// on the storage of the staking contract from their activation blocks.
+// The storage variables are the ones of the staking contract followed by the delegations.
+// helper/staking/storage_layout.go is generated from them by `make generate-staking-layout`,
+// run it after changing the storage variables.
+abstract contract StakingExtensions {
+    // Storage of the staking contract, slots 0 - 7
+    address[] internal _validators; // slot 0
+    mapping(address => bool) internal _addressToIsValidator; // slot 1
+    mapping(address => uint256) internal _addressToStakedAmount; // slot 2
+    mapping(address => uint256) internal _addressToValidatorIndex; // slot 3
+    uint256 internal _stakedAmount; // slot 4
+    uint256 internal _minimumNumValidators; // slot 5
+    uint256 internal _maximumNumValidators; // slot 6
+    mapping(address => bytes) internal _addressToBLSPublicKey; // slot 7
+
+    // Storage of the delegations, slots 8 - 13
+    mapping(address => mapping(address => uint256)) internal _delegations; // slot 8, validator => delegator => amount
+    mapping(address => uint256) internal _delegatedStake; // slot 9, validator => total delegated amount
+    mapping(address => address[]) internal _delegators; // slot 10, validator => delegators
+    mapping(address => mapping(address => uint256)) internal _delegatorIndex; // slot 11, validator => delegator => index + 1
+    address[] internal _delegationCandidates; // slot 12, validators having delegated stake
+    mapping(address => uint256) internal _candidateIndex; // slot 13, validator => index + 1
+
+    event Slashed(address indexed validator, uint256 amount);
+    event Delegated(address indexed delegator, address indexed validator, uint256 amount);
+    event Undelegated(address indexed delegator, address indexed validator, uint256 amount);
+
+    // The validator is removed from the validator set in the same way as on unstaking,
+    // and its stake is confiscated and left locked in the contract.
+    // Only the transaction of the block proposer to this contract can call it,
+    // the consensus verifies the evidence of those transactions
+    function slash(address validator, bytes calldata evidence) public {
+        require(
+            msg.sender == block.coinbase && tx.origin == msg.sender,
+            "slashing is allowed only in the transaction of the block proposer to the contract"
+        );
+        require(_addressToIsValidator[validator], "account is not a validator");
+        require(
+            _validators.length > _minimumNumValidators,
+            "validators can't be less than the minimum required validator number"
+        );
+
+        evidence;
+
+        uint256 index = _addressToValidatorIndex[validator];
+        uint256 lastIndex = _validators.length - 1;
+
+        if (index != lastIndex) {
+            address last = _validators[lastIndex];
+
+            _validators[index] = last;
+            _addressToValidatorIndex[last] = index;
+        }
+
+        _validators.pop();
+        _addressToIsValidator[validator] = false;
+        _addressToValidatorIndex[validator] = 0;
+
+        uint256 amount = _addressToStakedAmount[validator];
+
+        _addressToStakedAmount[validator] = 0;
+        _stakedAmount -= amount;
+
+        emit Slashed(validator, amount);
+    }
+
+    // The validator becomes a candidate of the validator set even if it hasn't staked by itself
+    function delegate(address validator) public payable {
+        require(msg.value > 0, "delegation amount must be greater than zero");
+        require(validator != address(0), "delegation to zero address is not allowed");
+
+        _delegations[validator][msg.sender] += msg.value;
+        _delegatedStake[validator] += msg.value;
+
+        if (_delegatorIndex[validator][msg.sender] == 0) {
+            _delegators[validator].push(msg.sender);
+            _delegatorIndex[validator][msg.sender] = _delegators[validator].length;
+        }
+
+        if (_candidateIndex[validator] == 0) {
+            _delegationCandidates.push(validator);
+            _candidateIndex[validator] = _delegationCandidates.length;
+        }
+
+        emit Delegated(msg.sender, validator, msg.value);
+    }
+
+    // The amount is returned to the delegator with the remaining gas,
+    // after the delegation is updated so that the delegator can't reenter with the same delegation
+    function undelegate(address validator, uint256 amount) public {
+        require(amount > 0, "delegation amount must be greater than zero");
+        require(_delegations[validator][msg.sender] >= amount, "delegated amount is less than the requested amount");
+
+        _delegations[validator][msg.sender] -= amount;
+        _delegatedStake[validator] -= amount;
+
+        if (_delegations[validator][msg.sender] == 0) {
+            _removeDelegator(validator, msg.sender);
+        }
+
+        if (_delegatedStake[validator] == 0) {
+            _removeCandidate(validator);
+        }
+
+        (bool success, ) = payable(msg.sender).call{value: amount}("");
+        require(success, "failed to return the undelegated amount");
+
+        emit Undelegated(msg.sender, validator, amount);
+    }
+
+    function delegation(address delegator, address validator) public view returns (uint256) {
+        return _delegations[validator][delegator];
+    }
+
+    function delegatedStake(address validator) public view returns (uint256) {
+        return _delegatedStake[validator];
+    }
+
+    function delegators(address validator) public view returns (address[] memory) {
+        return _delegators[validator];
+    }
+
+    function delegationCandidates() public view returns (address[] memory) {
+        return _delegationCandidates;
+    }
+
+    // The last element is moved to the position of the removed one
+    function _removeDelegator(address validator, address delegator) private {
+        address[] storage list = _delegators[validator];
+        uint256 index = _delegatorIndex[validator][delegator] - 1;
+        uint256 lastIndex = list.length - 1;
+
+        if (index != lastIndex) {
+            address last = list[lastIndex];
+
+            list[index] = last;
+            _delegatorIndex[validator][last] = index + 1;
+        }
+
+        list.pop();
+        delete _delegatorIndex[validator][delegator];
+    }
+
+    function _removeCandidate(address validator) private {
+        uint256 index = _candidateIndex[validator] - 1;
+        uint256 lastIndex = _delegationCandidates.length - 1;
+
+        if (index != lastIndex) {
+            address last = _delegationCandidates[lastIndex];
+
+            _delegationCandidates[index] = last;
+            _candidateIndex[last] = index + 1;
+        }
+
+        _delegationCandidates.pop();
+        delete _candidateIndex[validator];
+    }
+}
@@ -0,0 +1,494 @@
+package staking
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/contracts/abis"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	stakingHelper "github.com/0xPolygon/polygon-edge/helper/staking"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
+)
+
+const (
+	methodDelegate             = "delegate"
+	methodUndelegate           = "undelegate"
+	methodDelegation           = "delegation"
+	methodDelegatedStake       = "delegatedStake"
+	methodDelegators           = "delegators"
+	methodDelegationCandidates = "delegationCandidates"
+	eventDelegated             = "Delegated"
+	eventUndelegated           = "Undelegated"
+
+	// delegateGasCost is the gas consumed by delegate and undelegate
+	delegateGasCost uint64 = 50000
+	// delegationViewGasCost is the gas consumed by the view methods, per read address in the arrays
+	delegationViewGasCost uint64 = 2100
+)
+
+var (
+	ErrInvalidDelegationCall = errors.New("invalid delegation call")
+)
+
+// DelegationRuntime is the delegation entry points of the staking contract.
+// The code of the contract is already in the state of the running chains, and replacing it
+// would need a state migration at a hard fork, so the entry points are executed natively
+// on the storage of the contract from the activation block in genesis.json, in the same way as slashing.
+// StakingExtensions.sol is the Solidity source of the entry points,
+// the storage slots accessed by helper/staking are generated from it
+type DelegationRuntime struct{}
+
+// NewDelegationRuntime is a constructor of DelegationRuntime
+func NewDelegationRuntime() *DelegationRuntime {
+	return &DelegationRuntime{}
+}
+
+// Name returns the name of the runtime
+func (r *DelegationRuntime) Name() string {
+	return "staking-delegation"
+}
+
+// CanRun returns whether the call is for the delegation entry points of the staking contract.
+// delegate and undelegate run only in the direct calls to the contract, otherwise the value credited
+// to the delegation wouldn't reach the contract or the static call would write the storage.
+// The other calls are left to the bytecode of the contract, which reverts them
+func (r *DelegationRuntime) CanRun(c *runtime.Contract, _ runtime.Host, _ *chain.ForksInTime) bool {
+	if c.CodeAddress != AddrStakingContract || c.Address != AddrStakingContract {
+		return false
+	}
+
+	method := delegationMethod(c.Input)
+	if method == nil {
+		return false
+	}
+
+	if method.Name == methodDelegate || method.Name == methodUndelegate {
+		return isDirectCall(c)
+	}
+
+	return true
+}
+
+// Run executes the delegation entry point called by the input
+func (r *DelegationRuntime) Run(
+	c *runtime.Contract,
+	host runtime.Host,
+	config *chain.ForksInTime,
+) *runtime.ExecutionResult {
+	method := delegationMethod(c.Input)
+
+	args, err := decodeMethodArgs(method, c.Input[4:])
+	if err != nil {
+		return &runtime.ExecutionResult{
+			GasLeft: c.Gas,
+			Err:     runtime.ErrExecutionReverted,
+		}
+	}
+
+	if method.Name == methodDelegate {
+		return r.delegate(c, host, config, args)
+	}
+
+	if c.Value != nil && c.Value.Sign() != 0 {
+		return &runtime.ExecutionResult{
+			ReturnValue: []byte(ErrInvalidDelegationCall.Error()),
+			GasLeft:     c.Gas,
+			Err:         runtime.ErrExecutionReverted,
+		}
+	}
+
+	if method.Name == methodUndelegate {
+		return r.undelegate(c, host, config, args)
+	}
+
+	return r.view(c, host, method, args)
+}
+
+// delegate adds the value of the call to the delegation from the caller to the validator
+func (r *DelegationRuntime) delegate(
+	c *runtime.Contract,
+	host runtime.Host,
+	config *chain.ForksInTime,
+	args map[string]interface{},
+) *runtime.ExecutionResult {
+	if c.Gas < delegateGasCost {
+		return &runtime.ExecutionResult{
+			GasLeft: 0,
+			Err:     runtime.ErrOutOfGas,
+		}
+	}
+
+	gasLeft := c.Gas - delegateGasCost
+	validator := addressArg(args, "validator")
+
+	if err := stakingHelper.Delegate(host, AddrStakingContract, c.Caller, validator, c.Value, config); err != nil {
+		return revertWith(err, gasLeft)
+	}
+
+	emitDelegationLog(host, eventDelegated, c.Caller, validator, c.Value)
+
+	return &runtime.ExecutionResult{
+		GasLeft: gasLeft,
+	}
+}
+
+// undelegate subtracts the amount from the delegation from the caller to the validator
+// and returns the amount to the caller
+func (r *DelegationRuntime) undelegate(
+	c *runtime.Contract,
+	host runtime.Host,
+	config *chain.ForksInTime,
+	args map[string]interface{},
+) *runtime.ExecutionResult {
+	if c.Gas < delegateGasCost {
+		return &runtime.ExecutionResult{
+			GasLeft: 0,
+			Err:     runtime.ErrOutOfGas,
+		}
+	}
+
+	var (
+		gasLeft   = c.Gas - delegateGasCost
+		validator = addressArg(args, "validator")
+		amount, _ = args["amount"].(*big.Int)
+	)
+
+	if err := stakingHelper.Undelegate(host, AddrStakingContract, c.Caller, validator, amount, config); err != nil {
+		return revertWith(err, gasLeft)
+	}
+
+	// the amount is returned with all but one 64th of the remaining gas as the CALL of Solidity does,
+	// so the contracts delegating the stake can run their receive functions
+	transferGas := gasLeft - gasLeft/64
+	gasLeft -= transferGas
+
+	result := host.Callx(runtime.NewContractCall(
+		c.Depth+1,
+		c.Origin,
+		AddrStakingContract,
+		c.Caller,
+		amount,
+		transferGas,
+		host.GetCode(c.Caller),
+		nil,
+	), host)
+
+	gasLeft += result.GasLeft
+
+	if result.Failed() {
+		return revertWith(result.Err, gasLeft)
+	}
+
+	emitDelegationLog(host, eventUndelegated, c.Caller, validator, amount)
+
+	return &runtime.ExecutionResult{
+		GasLeft: gasLeft,
+	}
+}
+
+// view executes the view methods of the delegations
+func (r *DelegationRuntime) view(
+	c *runtime.Contract,
+	host runtime.Host,
+	method *abi.Method,
+	args map[string]interface{},
+) *runtime.ExecutionResult {
+	var (
+		output   interface{}
+		numReads = uint64(1)
+	)
+
+	switch method.Name {
+	case methodDelegation:
+		output = stakingHelper.GetDelegation(
+			host,
+			AddrStakingContract,
+			addressArg(args, "delegator"),
+			addressArg(args, "validator"),
+		)
+	case methodDelegatedStake:
+		output = stakingHelper.GetDelegatedStake(host, AddrStakingContract, addressArg(args, "validator"))
+	case methodDelegators, methodDelegationCandidates:
+		var addrs []types.Address
+		if method.Name == methodDelegators {
+			addrs = stakingHelper.GetDelegators(host, AddrStakingContract, addressArg(args, "validator"))
+		} else {
+			addrs = stakingHelper.GetDelegationCandidates(host, AddrStakingContract)
+		}
+
+		ethgoAddrs := make([]ethgo.Address, len(addrs))
+		for idx, addr := range addrs {
+			ethgoAddrs[idx] = ethgo.Address(addr)
+		}
+
+		output = ethgoAddrs
+		numReads += uint64(len(addrs))
+	}
+
+	gasCost := numReads * delegationViewGasCost
+	if c.Gas < gasCost {
+		return &runtime.ExecutionResult{
+			GasLeft: 0,
+			Err:     runtime.ErrOutOfGas,
+		}
+	}
+
+	returnValue, err := method.Outputs.Encode([]interface{}{output})
+	if err != nil {
+		return revertWith(err, c.Gas-gasCost)
+	}
+
+	return &runtime.ExecutionResult{
+		ReturnValue: returnValue,
+		GasLeft:     c.Gas - gasCost,
+	}
+}
+
+// delegationMethod returns the delegation method called by the input, nil if the input doesn't call it
+func delegationMethod(input []byte) *abi.Method {
+	if len(input) < 4 {
+		return nil
+	}
+
+	for _, method := range abis.DelegationABI.Methods {
+		if bytes.Equal(input[:4], method.ID()) {
+			return method
+		}
+	}
+
+	return nil
+}
+
+// decodeMethodArgs decodes the arguments of the method call
+func decodeMethodArgs(method *abi.Method, input []byte) (map[string]interface{}, error) {
+	if len(method.Inputs.TupleElems()) == 0 {
+		return map[string]interface{}{}, nil
+	}
+
+	decoded, err := method.Inputs.Decode(input)
+	if err != nil {
+		return nil, err
+	}
+
+	args, ok := decoded.(map[string]interface{})
+	if !ok {
+		return nil, ErrFailedTypeAssertion
+	}
+
+	return args, nil
+}
+
+// addressArg returns the address argument by name, zero address if not found
+func addressArg(args map[string]interface{}, name string) types.Address {
+	addr, _ := args[name].(ethgo.Address)
+
+	return types.Address(addr)
+}
+
+func revertWith(err error, gasLeft uint64) *runtime.ExecutionResult {
+	return &runtime.ExecutionResult{
+		ReturnValue: []byte(err.Error()),
+		GasLeft:     gasLeft,
+		Err:         runtime.ErrExecutionReverted,
+	}
+}
+
+func emitDelegationLog(host runtime.Host, event string, delegator, validator types.Address, amount *big.Int) {
+	host.EmitLog(
+		AddrStakingContract,
+		[]types.Hash{
+			types.Hash(abis.DelegationABI.Events[event].ID()),
+			types.BytesToHash(delegator.Bytes()),
+			types.BytesToHash(validator.Bytes()),
+		},
+		common.PadLeftOrTrim(amount.Bytes(), 32),
+	)
+}
+
+// EncodeDelegateInput encodes the input of delegate
+func EncodeDelegateInput(validator types.Address) ([]byte, error) {
+	return abis.DelegationABI.Methods[methodDelegate].Encode(map[string]interface{}{
+		"validator": ethgo.Address(validator),
+	})
+}
+
+// EncodeUndelegateInput encodes the input of undelegate
+func EncodeUndelegateInput(validator types.Address, amount *big.Int) ([]byte, error) {
+	return abis.DelegationABI.Methods[methodUndelegate].Encode(map[string]interface{}{
+		"validator": ethgo.Address(validator),
+		"amount":    amount,
+	})
+}
+
+// Delegation is the amount delegated by the delegator
+type Delegation struct {
+	Delegator types.Address
+	Amount    *big.Int
+}
+
+// QueryDelegatedStake is a helper function to get the total amount delegated to the validator from contract
+func QueryDelegatedStake(t TxQueryHandler, from types.Address, validator types.Address) (*big.Int, error) {
+	return queryUint256(t, from, abis.DelegationABI.Methods[methodDelegatedStake], map[string]interface{}{
+		"validator": ethgo.Address(validator),
+	})
+}
+
+// QueryDelegationCandidates is a helper function to get the addresses having delegated stake from contract
+func QueryDelegationCandidates(t TxQueryHandler, from types.Address) ([]types.Address, error) {
+	return queryAddresses(t, from, abis.DelegationABI.Methods[methodDelegationCandidates], nil)
+}
+
+// QueryDelegations is a helper function to get the delegations to the validator from contract
+func QueryDelegations(t TxQueryHandler, from types.Address, validator types.Address) ([]Delegation, error) {
+	delegators, err := queryAddresses(t, from, abis.DelegationABI.Methods[methodDelegators], map[string]interface{}{
+		"validator": ethgo.Address(validator),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	delegations := make([]Delegation, 0, len(delegators))
+
+	for _, delegator := range delegators {
+		amount, err := queryUint256(t, from, abis.DelegationABI.Methods[methodDelegation], map[string]interface{}{
+			"delegator": ethgo.Address(delegator),
+			"validator": ethgo.Address(validator),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		delegations = append(delegations, Delegation{
+			Delegator: delegator,
+			Amount:    amount,
+		})
+	}
+
+	return delegations, nil
+}
+
+// QueryMaximumNumValidators is a helper function to get the maximum number of validators from contract
+func QueryMaximumNumValidators(t TxQueryHandler, from types.Address) (*big.Int, error) {
+	return queryUint256(t, from, abis.StakingABI.Methods[methodMaximumNumValidators], nil)
+}
+
+// QueryValidatorThreshold is a helper function to get the minimum stake of a validator from contract
+func QueryValidatorThreshold(t TxQueryHandler, from types.Address) (*big.Int, error) {
+	return queryUint256(t, from, abis.StakingABI.Methods[methodValidatorThreshold], nil)
+}
+
+// QueryBLSPublicKey is a helper function to get the BLS Public Key registered by the account from contract
+func QueryBLSPublicKey(t TxQueryHandler, from types.Address, account types.Address) ([]byte, error) {
+	res, err := callView(t, from, abis.StakingABI.Methods[methodAddressToBLSPublicKey], []interface{}{
+		ethgo.Address(account),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := res.([]byte)
+	if !ok {
+		return nil, ErrFailedTypeAssertion
+	}
+
+	return key, nil
+}
+
+// queryUint256 calls the view method returning an uint256
+func queryUint256(
+	t TxQueryHandler,
+	from types.Address,
+	method *abi.Method,
+	args interface{},
+) (*big.Int, error) {
+	res, err := callView(t, from, method, args)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := res.(*big.Int)
+	if !ok {
+		return nil, ErrFailedTypeAssertion
+	}
+
+	return value, nil
+}
+
+// queryAddresses calls the view method returning an array of addresses
+func queryAddresses(
+	t TxQueryHandler,
+	from types.Address,
+	method *abi.Method,
+	args interface{},
+) ([]types.Address, error) {
+	res, err := callView(t, from, method, args)
+	if err != nil {
+		return nil, err
+	}
+
+	ethgoAddrs, ok := res.([]ethgo.Address)
+	if !ok {
+		return nil, ErrFailedTypeAssertion
+	}
+
+	addrs := make([]types.Address, len(ethgoAddrs))
+	for idx, addr := range ethgoAddrs {
+		addrs[idx] = types.Address(addr)
+	}
+
+	return addrs, nil
+}
+
+// callView calls the view method of the staking contract and returns the first output
+func callView(
+	t TxQueryHandler,
+	from types.Address,
+	method *abi.Method,
+	args interface{},
+) (interface{}, error) {
+	if method == nil {
+		return nil, ErrMethodNotFoundInABI
+	}
+
+	input := method.ID()
+
+	if args != nil {
+		var err error
+
+		if input, err = method.Encode(args); err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := t.Apply(createCallViewTx(
+		from,
+		AddrStakingContract,
+		input,
+		t.GetNonce(from),
+	))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Failed() {
+		return nil, res.Err
+	}
+
+	decodedResults, err := method.Outputs.Decode(res.ReturnValue)
+	if err != nil {
+		return nil, err
+	}
+
+	results, ok := decodedResults.(map[string]interface{})
+	if !ok {
+		return nil, ErrFailedTypeAssertion
+	}
+
+	return results["0"], nil
+}
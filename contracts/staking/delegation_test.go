@@ -0,0 +1,399 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/contracts/abis"
+	stakingHelper "github.com/0xPolygon/polygon-edge/helper/staking"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/ethgo"
+)
+
+type mockDelegationHost struct {
+	*mockStorageHost
+
+	transfers   map[types.Address]*big.Int
+	transferGas uint64
+}
+
+// transferGasUsed is the gas the receiver of the transfer consumes in the tests
+const transferGasUsed = 10000
+
+func (m *mockDelegationHost) GetCode(types.Address) []byte {
+	return nil
+}
+
+func (m *mockDelegationHost) Callx(c *runtime.Contract, _ runtime.Host) *runtime.ExecutionResult {
+	m.transfers[c.Address] = c.Value
+	m.transferGas = c.Gas
+
+	return &runtime.ExecutionResult{GasLeft: c.Gas - transferGasUsed}
+}
+
+func newMockDelegationHost(t *testing.T, addrs ...types.Address) *mockDelegationHost {
+	t.Helper()
+
+	return &mockDelegationHost{
+		mockStorageHost: newMockStorageHost(t, types.ZeroAddress, addrs...),
+		transfers:       map[types.Address]*big.Int{},
+	}
+}
+
+func runDelegation(
+	t *testing.T,
+	host runtime.Host,
+	caller types.Address,
+	value *big.Int,
+	gas uint64,
+	input []byte,
+) *runtime.ExecutionResult {
+	t.Helper()
+
+	var (
+		contract = runtime.NewContractCall(
+			1,
+			caller,
+			caller,
+			AddrStakingContract,
+			value,
+			gas,
+			nil,
+			input,
+		)
+		delegation = NewDelegationRuntime()
+	)
+
+	assert.True(t, delegation.CanRun(contract, host, &chain.ForksInTime{}))
+
+	return delegation.Run(contract, host, &chain.ForksInTime{})
+}
+
+func TestDelegationRuntime_CanRun(t *testing.T) {
+	t.Parallel()
+
+	delegateInput, err := EncodeDelegateInput(addr1)
+	assert.NoError(t, err)
+
+	slashInput, err := EncodeSlashInput(addr1, nil)
+	assert.NoError(t, err)
+
+	viewInput := abis.DelegationABI.Methods[methodDelegationCandidates].ID()
+
+	tests := []struct {
+		name     string
+		address  types.Address
+		input    []byte
+		callType runtime.CallType
+		context  types.Address
+		static   bool
+		expected bool
+	}{
+		{
+			name:     "should run delegate",
+			address:  AddrStakingContract,
+			input:    delegateInput,
+			expected: true,
+		},
+		{
+			name:     "should not run the other methods of the staking contract",
+			address:  AddrStakingContract,
+			input:    slashInput,
+			expected: false,
+		},
+		{
+			name:     "should not run the calls to the other contracts",
+			address:  addr1,
+			input:    delegateInput,
+			expected: false,
+		},
+		{
+			name:     "should not run short input",
+			address:  AddrStakingContract,
+			input:    []byte{0x1},
+			expected: false,
+		},
+		{
+			name:     "should not run delegate by DELEGATECALL",
+			address:  AddrStakingContract,
+			input:    delegateInput,
+			callType: runtime.DelegateCall,
+			context:  addr2,
+			expected: false,
+		},
+		{
+			name:     "should not run delegate by CALLCODE",
+			address:  AddrStakingContract,
+			input:    delegateInput,
+			callType: runtime.CallCode,
+			context:  addr2,
+			expected: false,
+		},
+		{
+			name:     "should not run delegate by STATICCALL",
+			address:  AddrStakingContract,
+			input:    delegateInput,
+			callType: runtime.StaticCall,
+			static:   true,
+			expected: false,
+		},
+		{
+			name:     "should not run delegate in the static context",
+			address:  AddrStakingContract,
+			input:    delegateInput,
+			static:   true,
+			expected: false,
+		},
+		{
+			name:     "should run the views by STATICCALL",
+			address:  AddrStakingContract,
+			input:    viewInput,
+			callType: runtime.StaticCall,
+			static:   true,
+			expected: true,
+		},
+		{
+			name:     "should not run the views by DELEGATECALL",
+			address:  AddrStakingContract,
+			input:    viewInput,
+			callType: runtime.DelegateCall,
+			context:  addr2,
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			contract := runtime.NewContractCall(1, addr2, addr2, test.address, nil, 0, nil, test.input)
+			contract.Type = test.callType
+			contract.Static = test.static
+
+			if test.context != types.ZeroAddress {
+				contract.Address = test.context
+			}
+
+			assert.Equal(
+				t,
+				test.expected,
+				NewDelegationRuntime().CanRun(contract, nil, &chain.ForksInTime{}),
+			)
+		})
+	}
+}
+
+func TestDelegationRuntime_Delegate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		validator  types.Address
+		value      *big.Int
+		gas        uint64
+		err        error
+		delegation *big.Int
+		candidates []types.Address
+	}{
+		{
+			name:       "should delegate to the validator",
+			validator:  addr1,
+			value:      big.NewInt(100),
+			gas:        delegateGasCost,
+			delegation: big.NewInt(100),
+			candidates: []types.Address{addr1},
+		},
+		{
+			name:       "should revert if the value is zero",
+			validator:  addr1,
+			value:      big.NewInt(0),
+			gas:        delegateGasCost,
+			err:        runtime.ErrExecutionReverted,
+			delegation: big.NewInt(0),
+			candidates: []types.Address{},
+		},
+		{
+			name:       "should revert if the validator is zero address",
+			validator:  types.ZeroAddress,
+			value:      big.NewInt(100),
+			gas:        delegateGasCost,
+			err:        runtime.ErrExecutionReverted,
+			delegation: big.NewInt(0),
+			candidates: []types.Address{},
+		},
+		{
+			name:       "should return error if gas is not enough",
+			validator:  addr1,
+			value:      big.NewInt(100),
+			gas:        delegateGasCost - 1,
+			err:        runtime.ErrOutOfGas,
+			delegation: big.NewInt(0),
+			candidates: []types.Address{},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			host := newMockDelegationHost(t, addr1)
+
+			input, err := EncodeDelegateInput(test.validator)
+			assert.NoError(t, err)
+
+			result := runDelegation(t, host, addr2, test.value, test.gas, input)
+			assert.ErrorIs(t, result.Err, test.err)
+
+			assert.Equal(
+				t,
+				test.delegation.String(),
+				stakingHelper.GetDelegation(host, AddrStakingContract, addr2, test.validator).String(),
+			)
+			assert.Equal(
+				t,
+				test.candidates,
+				stakingHelper.GetDelegationCandidates(host, AddrStakingContract),
+			)
+
+			if test.err == nil {
+				assert.Equal(t, 1, host.logs)
+			}
+		})
+	}
+}
+
+func TestDelegationRuntime_Undelegate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		amount     *big.Int
+		err        error
+		delegation *big.Int
+		delegators []types.Address
+		candidates []types.Address
+	}{
+		{
+			name:       "should undelegate a part of the delegation",
+			amount:     big.NewInt(40),
+			delegation: big.NewInt(60),
+			delegators: []types.Address{addr2},
+			candidates: []types.Address{addr1},
+		},
+		{
+			name:       "should remove the delegator and the candidate if all is undelegated",
+			amount:     big.NewInt(100),
+			delegation: big.NewInt(0),
+			delegators: []types.Address{},
+			candidates: []types.Address{},
+		},
+		{
+			name:       "should revert if the amount exceeds the delegation",
+			amount:     big.NewInt(101),
+			err:        runtime.ErrExecutionReverted,
+			delegation: big.NewInt(100),
+			delegators: []types.Address{addr2},
+			candidates: []types.Address{addr1},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			host := newMockDelegationHost(t, addr1)
+
+			delegateInput, err := EncodeDelegateInput(addr1)
+			assert.NoError(t, err)
+
+			result := runDelegation(t, host, addr2, big.NewInt(100), delegateGasCost, delegateInput)
+			assert.NoError(t, result.Err)
+
+			undelegateInput, err := EncodeUndelegateInput(addr1, test.amount)
+			assert.NoError(t, err)
+
+			result = runDelegation(
+				t,
+				host,
+				addr2,
+				big.NewInt(0),
+				delegateGasCost+64000,
+				undelegateInput,
+			)
+			assert.ErrorIs(t, result.Err, test.err)
+
+			assert.Equal(
+				t,
+				test.delegation.String(),
+				stakingHelper.GetDelegation(host, AddrStakingContract, addr2, addr1).String(),
+			)
+			assert.Equal(
+				t,
+				test.delegation.String(),
+				stakingHelper.GetDelegatedStake(host, AddrStakingContract, addr1).String(),
+			)
+			assert.Equal(t, test.delegators, stakingHelper.GetDelegators(host, AddrStakingContract, addr1))
+			assert.Equal(t, test.candidates, stakingHelper.GetDelegationCandidates(host, AddrStakingContract))
+
+			if test.err == nil {
+				assert.Equal(t, test.amount, host.transfers[addr2])
+
+				// the remaining gas except for one 64th is forwarded to the delegator
+				assert.Equal(t, uint64(63000), host.transferGas)
+				assert.Equal(t, uint64(64000-transferGasUsed), result.GasLeft)
+			} else {
+				assert.Empty(t, host.transfers)
+			}
+		})
+	}
+}
+
+func TestDelegationRuntime_View(t *testing.T) {
+	t.Parallel()
+
+	addr3 := types.StringToAddress("3")
+	host := newMockDelegationHost(t, addr1)
+
+	for _, delegator := range []types.Address{addr2, addr3} {
+		input, err := EncodeDelegateInput(addr1)
+		assert.NoError(t, err)
+
+		result := runDelegation(t, host, delegator, big.NewInt(50), delegateGasCost, input)
+		assert.NoError(t, result.Err)
+	}
+
+	method := abis.DelegationABI.Methods[methodDelegators]
+
+	input, err := method.Encode(map[string]interface{}{
+		"validator": ethgo.Address(addr1),
+	})
+	assert.NoError(t, err)
+
+	// reject value
+	result := runDelegation(t, host, addr2, big.NewInt(1), 3*delegationViewGasCost, input)
+	assert.ErrorIs(t, result.Err, runtime.ErrExecutionReverted)
+
+	// not enough gas for 2 addresses
+	result = runDelegation(t, host, addr2, big.NewInt(0), 3*delegationViewGasCost-1, input)
+	assert.ErrorIs(t, result.Err, runtime.ErrOutOfGas)
+
+	result = runDelegation(t, host, addr2, big.NewInt(0), 3*delegationViewGasCost, input)
+	assert.NoError(t, result.Err)
+	assert.Equal(t, uint64(0), result.GasLeft)
+
+	decoded, err := method.Outputs.Decode(result.ReturnValue)
+	assert.NoError(t, err)
+
+	output, ok := decoded.(map[string]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, []ethgo.Address{ethgo.Address(addr2), ethgo.Address(addr3)}, output["0"])
+}
@@ -15,6 +15,10 @@ import (
 const (
 	methodValidators             = "validators"
 	methodValidatorBLSPublicKeys = "validatorBLSPublicKeys"
+	methodAccountStake           = "accountStake"
+	methodMaximumNumValidators   = "maximumNumValidators"
+	methodValidatorThreshold     = "VALIDATOR_THRESHOLD"
+	methodAddressToBLSPublicKey  = "_addressToBLSPublicKey"
 )
 
 var (
@@ -56,13 +60,13 @@ func decodeWeb3ArrayOfBytes(
 func createCallViewTx(
 	from types.Address,
 	contractAddress types.Address,
-	methodID []byte,
+	input []byte,
 	nonce uint64,
 ) *types.Transaction {
 	return &types.Transaction{
 		From:     from,
 		To:       &contractAddress,
-		Input:    methodID,
+		Input:    input,
 		Nonce:    nonce,
 		Gas:      queryGasLimit,
 		Value:    big.NewInt(0),
@@ -163,3 +167,55 @@ func QueryBLSPublicKeys(t TxQueryHandler, from types.Address) ([][]byte, error)
 
 	return decodeBLSPublicKeys(method, res.ReturnValue)
 }
+
+// decodeAccountStake parses contract call result and returns the staked amount
+func decodeAccountStake(method *abi.Method, returnValue []byte) (*big.Int, error) {
+	decodedResults, err := method.Outputs.Decode(returnValue)
+	if err != nil {
+		return nil, err
+	}
+
+	results, ok := decodedResults.(map[string]interface{})
+	if !ok {
+		return nil, ErrFailedTypeAssertion
+	}
+
+	amount, ok := results["0"].(*big.Int)
+	if !ok {
+		return nil, ErrFailedTypeAssertion
+	}
+
+	return amount, nil
+}
+
+// QueryAccountStake is a helper function to get the amount staked by the given account from contract
+func QueryAccountStake(t TxQueryHandler, from types.Address, account types.Address) (*big.Int, error) {
+	method, ok := abis.StakingABI.Methods[methodAccountStake]
+	if !ok {
+		return nil, ErrMethodNotFoundInABI
+	}
+
+	input, err := method.Encode(map[string]interface{}{
+		"addr": ethgo.Address(account),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.Apply(createCallViewTx(
+		from,
+		AddrStakingContract,
+		input,
+		t.GetNonce(from),
+	))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Failed() {
+		return nil, res.Err
+	}
+
+	return decodeAccountStake(method, res.ReturnValue)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/ethgo"
 )
 
 var (
@@ -220,3 +221,57 @@ func TestQueryValidators(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryAccountStake(t *testing.T) {
+	t.Parallel()
+
+	method := abis.StakingABI.Methods["accountStake"]
+	assert.NotNil(t, method)
+
+	input, err := method.Encode(map[string]interface{}{
+		"addr": ethgo.Address(addr2),
+	})
+	assert.NoError(t, err)
+
+	tx := createCallViewTx(addr1, AddrStakingContract, input, 0).ComputeHash()
+
+	tests := []struct {
+		name     string
+		res      *runtime.ExecutionResult
+		expected *big.Int
+		err      error
+	}{
+		{
+			name: "should return the staked amount",
+			res: &runtime.ExecutionResult{
+				ReturnValue: leftPad(big.NewInt(1000).Bytes(), 32),
+			},
+			expected: big.NewInt(1000),
+		},
+		{
+			name: "should return error if the call is reverted",
+			res: &runtime.ExecutionResult{
+				Err: runtime.ErrExecutionReverted,
+			},
+			err: runtime.ErrExecutionReverted,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			mock := &TxMock{
+				hashToRes: map[types.Hash]*runtime.ExecutionResult{
+					tx.Hash: test.res,
+				},
+			}
+
+			amount, err := QueryAccountStake(mock, addr1, addr2)
+			assert.ErrorIs(t, err, test.err)
+			assert.Equal(t, test.expected, amount)
+		})
+	}
+}
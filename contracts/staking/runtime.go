@@ -0,0 +1,68 @@
+package staking
+
+import (
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+)
+
+// SystemRuntimes is the list of the entry points of the staking contract executed natively.
+// The first runtime that can run the call executes it
+type SystemRuntimes []runtime.Runtime
+
+// Name returns the name of the runtime
+func (rs SystemRuntimes) Name() string {
+	return "staking-system"
+}
+
+// CanRun returns whether any of the runtimes can run the call
+func (rs SystemRuntimes) CanRun(c *runtime.Contract, host runtime.Host, config *chain.ForksInTime) bool {
+	return rs.find(c, host, config) != nil
+}
+
+// Run executes the call by the first runtime that can run it
+func (rs SystemRuntimes) Run(
+	c *runtime.Contract,
+	host runtime.Host,
+	config *chain.ForksInTime,
+) *runtime.ExecutionResult {
+	return rs.find(c, host, config).Run(c, host, config)
+}
+
+// isDirectCall returns whether the call is a message call to the staking contract itself,
+// which transfers the value of the call to the contract and can change its storage.
+// The static calls, and the calls running the code of the contract in the context of another account
+// by DELEGATECALL or CALLCODE, don't satisfy it
+func isDirectCall(c *runtime.Contract) bool {
+	return c.Type == runtime.Call && c.Address == AddrStakingContract && !c.Static
+}
+
+func (rs SystemRuntimes) find(c *runtime.Contract, host runtime.Host, config *chain.ForksInTime) runtime.Runtime {
+	for _, r := range rs {
+		if r.CanRun(c, host, config) {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// activatedRuntime runs the calls only from the block the entry points are activated at
+type activatedRuntime struct {
+	runtime.Runtime
+
+	from uint64
+}
+
+// Activate returns the runtime running the calls from the given block,
+// the calls in the earlier blocks are left to the other runtimes
+func Activate(r runtime.Runtime, from uint64) runtime.Runtime {
+	return &activatedRuntime{
+		Runtime: r,
+		from:    from,
+	}
+}
+
+// CanRun returns whether the entry points are activated and the runtime can run the call
+func (r *activatedRuntime) CanRun(c *runtime.Contract, host runtime.Host, config *chain.ForksInTime) bool {
+	return uint64(host.GetTxContext().Number) >= r.from && r.Runtime.CanRun(c, host, config)
+}
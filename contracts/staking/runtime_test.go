@@ -0,0 +1,304 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	stakingHelper "github.com/0xPolygon/polygon-edge/helper/staking"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// opcodes of the calls made by the proxy contract in the tests
+const (
+	opCall         = byte(0xf1)
+	opCallCode     = byte(0xf2)
+	opDelegateCall = byte(0xf4)
+	opStaticCall   = byte(0xfa)
+)
+
+var (
+	proxyAddr    = types.StringToAddress("0x100")
+	testGasLimit = uint64(1000000)
+)
+
+type mockBlockHost struct {
+	runtime.Host
+
+	number int64
+}
+
+func (m *mockBlockHost) GetTxContext() runtime.TxContext {
+	return runtime.TxContext{Number: m.number}
+}
+
+func TestActivate(t *testing.T) {
+	t.Parallel()
+
+	input, err := EncodeDelegateInput(addr1)
+	assert.NoError(t, err)
+
+	var (
+		contract = runtime.NewContractCall(1, addr2, addr2, AddrStakingContract, nil, 0, nil, input)
+		systemRT = SystemRuntimes{Activate(NewDelegationRuntime(), 10)}
+	)
+
+	assert.False(t, systemRT.CanRun(contract, &mockBlockHost{number: 9}, &chain.ForksInTime{}))
+	assert.True(t, systemRT.CanRun(contract, &mockBlockHost{number: 10}, &chain.ForksInTime{}))
+
+	// the activated runtime still runs only its entry points
+	contract = runtime.NewContractCall(1, addr2, addr2, addr1, nil, 0, nil, input)
+	assert.False(t, systemRT.CanRun(contract, &mockBlockHost{number: 10}, &chain.ForksInTime{}))
+}
+
+// proxyCode returns the code of the contract forwarding its calldata to the staking contract
+// by the given call opcode, it returns the return data of the call and reverts if the call fails.
+// The calls without calldata, such as the return of the undelegated amount, are accepted
+func proxyCode(op byte) []byte {
+	code := []byte{
+		0x36, 0x60, 0x05, 0x57, 0x00, 0x5b, // STOP unless CALLDATASIZE
+		0x36, 0x60, 0x00, 0x60, 0x00, 0x37, // CALLDATACOPY(0, 0, CALLDATASIZE)
+		0x60, 0x00, 0x60, 0x00, 0x36, 0x60, 0x00, // retSize, retOffset, argsSize, argsOffset
+	}
+
+	if op == opCall || op == opCallCode {
+		code = append(code, 0x34) // CALLVALUE
+	}
+
+	code = append(code, 0x73) // PUSH20
+	code = append(code, AddrStakingContract.Bytes()...)
+	code = append(code, 0x5a, op) // GAS, call
+
+	// RETURNDATACOPY(0, 0, RETURNDATASIZE), then jump to RETURN if the call succeeded, or REVERT
+	returnDest := byte(len(code) + 13)
+
+	return append(
+		code,
+		0x3d, 0x60, 0x00, 0x60, 0x00, 0x3e,
+		0x60, returnDest, 0x57,
+		0x3d, 0x60, 0x00, 0xfd,
+		0x5b, 0x3d, 0x60, 0x00, 0xf3,
+	)
+}
+
+// newTestStakingTransition returns the transition with the predeployed staking contract
+// executing the entry points by the given system runtimes, and the proxy contract using the given opcode
+func newTestStakingTransition(
+	t *testing.T,
+	coinbase types.Address,
+	proxyOp byte,
+	systemRuntime runtime.Runtime,
+	vals validators.Validators,
+	accounts ...types.Address,
+) *state.Transition {
+	t.Helper()
+
+	ex := state.NewExecutor(&chain.Params{
+		Forks: chain.AllForksEnabled,
+	}, itrie.NewState(itrie.NewMemoryStorage()), hclog.NewNullLogger())
+	ex.SystemRuntime = systemRuntime
+
+	rootHash := ex.WriteGenesis(nil)
+
+	ex.GetHash = func(h *types.Header) state.GetHashByNumber {
+		return func(i uint64) types.Hash {
+			return rootHash
+		}
+	}
+
+	transition, err := ex.BeginTxn(rootHash, &types.Header{GasLimit: testGasLimit}, coinbase)
+	require.NoError(t, err)
+
+	stakingAccount, err := stakingHelper.PredeployStakingSC(vals, stakingHelper.PredeployParams{
+		MinValidatorCount: 0,
+		MaxValidatorCount: 10,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, transition.SetAccountDirectly(AddrStakingContract, stakingAccount))
+	require.NoError(t, transition.SetAccountDirectly(proxyAddr, &chain.GenesisAccount{
+		Code:    proxyCode(proxyOp),
+		Balance: big.NewInt(0),
+	}))
+
+	for _, account := range accounts {
+		require.NoError(t, transition.SetAccountDirectly(account, &chain.GenesisAccount{
+			Balance: big.NewInt(1000000),
+		}))
+	}
+
+	return transition
+}
+
+func TestDelegationRuntime_ThroughProxy(t *testing.T) {
+	t.Parallel()
+
+	var (
+		delegator = types.StringToAddress("0x200")
+		validator = addr1
+		amount    = big.NewInt(1000)
+	)
+
+	delegateInput, err := EncodeDelegateInput(validator)
+	require.NoError(t, err)
+
+	undelegateInput, err := EncodeUndelegateInput(validator, amount)
+	require.NoError(t, err)
+
+	t.Run("should delegate the value forwarded by CALL", func(t *testing.T) {
+		t.Parallel()
+
+		transition := newTestStakingTransition(t, types.ZeroAddress, opCall, NewDelegationRuntime(), nil, delegator)
+
+		result := transition.Call2(delegator, proxyAddr, delegateInput, amount, testGasLimit)
+		require.NoError(t, result.Err)
+
+		assert.Equal(t, amount, transition.GetBalance(AddrStakingContract))
+		assert.Equal(t, amount, stakingHelper.GetDelegation(transition, AddrStakingContract, proxyAddr, validator))
+	})
+
+	tests := []struct {
+		name  string
+		op    byte
+		value *big.Int
+	}{
+		{
+			name:  "DELEGATECALL",
+			op:    opDelegateCall,
+			value: amount,
+		},
+		{
+			name:  "CALLCODE",
+			op:    opCallCode,
+			value: amount,
+		},
+		{
+			name:  "STATICCALL",
+			op:    opStaticCall,
+			value: big.NewInt(0),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run("should revert delegate and undelegate by "+test.name, func(t *testing.T) {
+			t.Parallel()
+
+			transition := newTestStakingTransition(t, types.ZeroAddress, test.op, NewDelegationRuntime(), nil, delegator)
+
+			// both the delegator and the proxy, the callers of the staking contract
+			// depending on the opcode, have delegated so that undelegate would have the amount to return
+			result := transition.Call2(delegator, AddrStakingContract, delegateInput, amount, testGasLimit)
+			require.NoError(t, result.Err)
+
+			require.NoError(t, stakingHelper.Delegate(
+				transition,
+				AddrStakingContract,
+				proxyAddr,
+				validator,
+				amount,
+				&chain.ForksInTime{},
+			))
+			transition.Txn().AddBalance(AddrStakingContract, amount)
+
+			result = transition.Call2(delegator, proxyAddr, delegateInput, test.value, testGasLimit)
+			assert.ErrorIs(t, result.Err, runtime.ErrExecutionReverted)
+
+			result = transition.Call2(delegator, proxyAddr, undelegateInput, big.NewInt(0), testGasLimit)
+			assert.ErrorIs(t, result.Err, runtime.ErrExecutionReverted)
+
+			total := new(big.Int).Mul(amount, big.NewInt(2))
+
+			assert.Equal(t, total, transition.GetBalance(AddrStakingContract))
+			assert.Equal(t, total, stakingHelper.GetDelegatedStake(transition, AddrStakingContract, validator))
+			assert.Equal(t, amount, stakingHelper.GetDelegation(transition, AddrStakingContract, delegator, validator))
+			assert.Equal(t, amount, stakingHelper.GetDelegation(transition, AddrStakingContract, proxyAddr, validator))
+		})
+	}
+}
+
+func TestSlashingRuntime_ThroughProxy(t *testing.T) {
+	t.Parallel()
+
+	var (
+		proposer = addr2
+		slashed  = addr1
+	)
+
+	vals := validators.NewECDSAValidatorSet()
+	for _, addr := range []types.Address{slashed, proposer, types.StringToAddress("3")} {
+		require.NoError(t, vals.Add(validators.NewECDSAValidator(addr)))
+	}
+
+	input, err := EncodeSlashInput(slashed, nil)
+	require.NoError(t, err)
+
+	isValidator := func(transition *state.Transition) bool {
+		addrs, err := QueryValidators(transition, types.ZeroAddress)
+		require.NoError(t, err)
+
+		for _, addr := range addrs {
+			if addr == slashed {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	t.Run("should slash by the transaction of the proposer to the contract", func(t *testing.T) {
+		t.Parallel()
+
+		transition := newTestStakingTransition(t, proposer, opCall, NewSlashingRuntime(), vals, proposer)
+
+		result := transition.Call2(proposer, AddrStakingContract, input, big.NewInt(0), testGasLimit)
+		require.NoError(t, result.Err)
+
+		assert.False(t, isValidator(transition))
+	})
+
+	tests := []struct {
+		name string
+		op   byte
+	}{
+		{
+			name: "CALL",
+			op:   opCall,
+		},
+		{
+			name: "DELEGATECALL",
+			op:   opDelegateCall,
+		},
+		{
+			name: "CALLCODE",
+			op:   opCallCode,
+		},
+		{
+			name: "STATICCALL",
+			op:   opStaticCall,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run("should revert the slashing of the proposer by "+test.name+" from a contract", func(t *testing.T) {
+			t.Parallel()
+
+			transition := newTestStakingTransition(t, proposer, test.op, NewSlashingRuntime(), vals, proposer)
+
+			result := transition.Call2(proposer, proxyAddr, input, big.NewInt(0), testGasLimit)
+			assert.ErrorIs(t, result.Err, runtime.ErrExecutionReverted)
+
+			assert.True(t, isValidator(transition))
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package staking
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/contracts/abis"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	stakingHelper "github.com/0xPolygon/polygon-edge/helper/staking"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/ethgo"
+)
+
+const (
+	methodSlash  = "slash"
+	eventSlashed = "Slashed"
+
+	// slashGasCost is the gas consumed by the slashing entry point
+	slashGasCost uint64 = 50000
+)
+
+var (
+	ErrSlashNotBySystem = errors.New("slashing is allowed only in the transaction of the block proposer to the contract")
+	ErrInvalidSlashCall = errors.New("invalid slash call")
+)
+
+// EncodeSlashInput encodes the input of the slashing entry point of the staking contract
+func EncodeSlashInput(validator types.Address, evidence []byte) ([]byte, error) {
+	return abis.SlashingABI.Methods[methodSlash].Encode(map[string]interface{}{
+		"validator": ethgo.Address(validator),
+		"evidence":  evidence,
+	})
+}
+
+// DecodeSlashInput decodes the input of the slashing entry point of the staking contract
+func DecodeSlashInput(input []byte) (types.Address, []byte, error) {
+	if !IsSlashInput(input) {
+		return types.ZeroAddress, nil, ErrInvalidSlashCall
+	}
+
+	decoded, err := abis.SlashingABI.Methods[methodSlash].Inputs.Decode(input[4:])
+	if err != nil {
+		return types.ZeroAddress, nil, err
+	}
+
+	args, ok := decoded.(map[string]interface{})
+	if !ok {
+		return types.ZeroAddress, nil, ErrFailedTypeAssertion
+	}
+
+	validator, ok := args["validator"].(ethgo.Address)
+	if !ok {
+		return types.ZeroAddress, nil, ErrFailedTypeAssertion
+	}
+
+	evidence, ok := args["evidence"].([]byte)
+	if !ok {
+		return types.ZeroAddress, nil, ErrFailedTypeAssertion
+	}
+
+	return types.Address(validator), evidence, nil
+}
+
+// IsSlashInput returns whether the input calls the slashing entry point
+func IsSlashInput(input []byte) bool {
+	return len(input) >= 4 && bytes.Equal(input[:4], abis.SlashingABI.Methods[methodSlash].ID())
+}
+
+// SlashingRuntime is the slashing entry point of the staking contract.
+// The code of the contract is already in the state of the running chains,
+// so the entry point is executed natively on the storage of the contract from the activation block.
+// It runs only in the transaction the block proposer sends to the contract directly,
+// the one whose evidence is verified by the consensus, and the calls from the contracts are reverted.
+// StakingExtensions.sol is the Solidity source of the entry point
+type SlashingRuntime struct{}
+
+// NewSlashingRuntime is a constructor of SlashingRuntime
+func NewSlashingRuntime() *SlashingRuntime {
+	return &SlashingRuntime{}
+}
+
+// Name returns the name of the runtime
+func (r *SlashingRuntime) Name() string {
+	return "staking-slashing"
+}
+
+// CanRun returns whether the call is for the slashing entry point of the staking contract,
+// the calls by DELEGATECALL, CALLCODE or STATICCALL are left to the bytecode of the contract, which reverts them
+func (r *SlashingRuntime) CanRun(c *runtime.Contract, _ runtime.Host, _ *chain.ForksInTime) bool {
+	return c.CodeAddress == AddrStakingContract && IsSlashInput(c.Input) && isDirectCall(c)
+}
+
+// Run removes the validator from the validator set and confiscates its stake
+func (r *SlashingRuntime) Run(
+	c *runtime.Contract,
+	host runtime.Host,
+	config *chain.ForksInTime,
+) *runtime.ExecutionResult {
+	if c.Gas < slashGasCost {
+		return &runtime.ExecutionResult{
+			GasLeft: 0,
+			Err:     runtime.ErrOutOfGas,
+		}
+	}
+
+	gasLeft := c.Gas - slashGasCost
+
+	revert := func(err error) *runtime.ExecutionResult {
+		return &runtime.ExecutionResult{
+			ReturnValue: []byte(err.Error()),
+			GasLeft:     gasLeft,
+			Err:         runtime.ErrExecutionReverted,
+		}
+	}
+
+	// consensus verifies the evidence of the top-level transactions to the contract only,
+	// so the slashing from any other path, such as from a contract deployed by the proposer, is rejected
+	if c.Depth != 1 || c.Origin != c.Caller || c.Caller != host.GetTxContext().Coinbase {
+		return revert(ErrSlashNotBySystem)
+	}
+
+	if c.Value != nil && c.Value.Sign() != 0 {
+		return revert(ErrInvalidSlashCall)
+	}
+
+	validator, _, err := DecodeSlashInput(c.Input)
+	if err != nil {
+		return revert(err)
+	}
+
+	amount, err := stakingHelper.SlashValidator(host, AddrStakingContract, validator, config)
+	if err != nil {
+		return revert(err)
+	}
+
+	host.EmitLog(
+		AddrStakingContract,
+		[]types.Hash{
+			types.Hash(abis.SlashingABI.Events[eventSlashed].ID()),
+			types.BytesToHash(validator.Bytes()),
+		},
+		common.PadLeftOrTrim(amount.Bytes(), 32),
+	)
+
+	return &runtime.ExecutionResult{
+		GasLeft: gasLeft,
+	}
+}
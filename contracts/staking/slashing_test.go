@@ -0,0 +1,250 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	stakingHelper "github.com/0xPolygon/polygon-edge/helper/staking"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockStorageHost struct {
+	runtime.Host
+
+	coinbase types.Address
+	storage  map[types.Hash]types.Hash
+	logs     int
+}
+
+func (m *mockStorageHost) GetTxContext() runtime.TxContext {
+	return runtime.TxContext{Coinbase: m.coinbase}
+}
+
+func (m *mockStorageHost) GetStorage(_ types.Address, key types.Hash) types.Hash {
+	return m.storage[key]
+}
+
+func (m *mockStorageHost) SetStorage(
+	_ types.Address,
+	key types.Hash,
+	value types.Hash,
+	_ *chain.ForksInTime,
+) runtime.StorageStatus {
+	m.storage[key] = value
+
+	return runtime.StorageModified
+}
+
+func (m *mockStorageHost) EmitLog(types.Address, []types.Hash, []byte) {
+	m.logs++
+}
+
+func newMockStorageHost(t *testing.T, coinbase types.Address, addrs ...types.Address) *mockStorageHost {
+	t.Helper()
+
+	vals := validators.NewECDSAValidatorSet()
+	for _, addr := range addrs {
+		assert.NoError(t, vals.Add(validators.NewECDSAValidator(addr)))
+	}
+
+	account, err := stakingHelper.PredeployStakingSC(vals, stakingHelper.PredeployParams{
+		MinValidatorCount: 1,
+		MaxValidatorCount: 10,
+	})
+	assert.NoError(t, err)
+
+	return &mockStorageHost{
+		coinbase: coinbase,
+		storage:  account.Storage,
+	}
+}
+
+func TestSlashInput(t *testing.T) {
+	t.Parallel()
+
+	evidence := []byte{0x1, 0x2, 0x3}
+
+	input, err := EncodeSlashInput(addr1, evidence)
+	assert.NoError(t, err)
+	assert.True(t, IsSlashInput(input))
+
+	validator, decodedEvidence, err := DecodeSlashInput(input)
+	assert.NoError(t, err)
+	assert.Equal(t, addr1, validator)
+	assert.Equal(t, evidence, decodedEvidence)
+
+	_, _, err = DecodeSlashInput([]byte{0x1})
+	assert.ErrorIs(t, err, ErrInvalidSlashCall)
+}
+
+func TestSlashingRuntime(t *testing.T) {
+	t.Parallel()
+
+	addr3 := types.StringToAddress("3")
+
+	input, err := EncodeSlashInput(addr1, nil)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		caller      types.Address
+		origin      types.Address
+		depth       int
+		validators  []types.Address
+		gas         uint64
+		err         error
+		expectedLen uint64
+	}{
+		{
+			name:        "should remove the validator",
+			caller:      addr2,
+			validators:  []types.Address{addr1, addr2, addr3},
+			gas:         slashGasCost,
+			expectedLen: 2,
+		},
+		{
+			name:        "should revert if the caller is not the proposer",
+			caller:      addr3,
+			validators:  []types.Address{addr1, addr2, addr3},
+			gas:         slashGasCost,
+			err:         runtime.ErrExecutionReverted,
+			expectedLen: 3,
+		},
+		{
+			name:        "should revert if the proposer calls it from a contract",
+			caller:      addr2,
+			origin:      addr2,
+			depth:       2,
+			validators:  []types.Address{addr1, addr2, addr3},
+			gas:         slashGasCost,
+			err:         runtime.ErrExecutionReverted,
+			expectedLen: 3,
+		},
+		{
+			name:        "should revert if the caller is not the sender of the transaction",
+			caller:      addr2,
+			origin:      addr3,
+			depth:       2,
+			validators:  []types.Address{addr1, addr2, addr3},
+			gas:         slashGasCost,
+			err:         runtime.ErrExecutionReverted,
+			expectedLen: 3,
+		},
+		{
+			name:        "should revert if the validator count reaches the minimum",
+			caller:      addr2,
+			validators:  []types.Address{addr1},
+			gas:         slashGasCost,
+			err:         runtime.ErrExecutionReverted,
+			expectedLen: 1,
+		},
+		{
+			name:        "should return error if gas is not enough",
+			caller:      addr2,
+			validators:  []types.Address{addr1, addr2, addr3},
+			gas:         slashGasCost - 1,
+			err:         runtime.ErrOutOfGas,
+			expectedLen: 3,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			origin, depth := test.origin, test.depth
+			if depth == 0 {
+				origin, depth = test.caller, 1
+			}
+
+			var (
+				host     = newMockStorageHost(t, addr2, test.validators...)
+				contract = runtime.NewContractCall(
+					depth,
+					origin,
+					test.caller,
+					AddrStakingContract,
+					big.NewInt(0),
+					test.gas,
+					nil,
+					input,
+				)
+				slashing = NewSlashingRuntime()
+			)
+
+			assert.True(t, slashing.CanRun(contract, host, &chain.ForksInTime{}))
+
+			result := slashing.Run(contract, host, &chain.ForksInTime{})
+			assert.ErrorIs(t, result.Err, test.err)
+
+			numValidators := new(big.Int).SetBytes(host.storage[types.Hash{}].Bytes())
+			assert.Equal(t, test.expectedLen, numValidators.Uint64())
+
+			if test.err == nil {
+				assert.Equal(t, 1, host.logs)
+			}
+		})
+	}
+}
+
+func TestSlashingRuntime_CanRun(t *testing.T) {
+	t.Parallel()
+
+	input, err := EncodeSlashInput(addr1, nil)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		callType runtime.CallType
+		context  types.Address
+		static   bool
+		expected bool
+	}{
+		{
+			name:     "should run the call",
+			callType: runtime.Call,
+			context:  AddrStakingContract,
+			expected: true,
+		},
+		{
+			name:     "should not run DELEGATECALL",
+			callType: runtime.DelegateCall,
+			context:  addr2,
+			expected: false,
+		},
+		{
+			name:     "should not run CALLCODE",
+			callType: runtime.CallCode,
+			context:  addr2,
+			expected: false,
+		},
+		{
+			name:     "should not run STATICCALL",
+			callType: runtime.StaticCall,
+			context:  AddrStakingContract,
+			static:   true,
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			contract := runtime.NewContractCall(1, addr2, addr2, AddrStakingContract, nil, 0, nil, input)
+			contract.Type = test.callType
+			contract.Address = test.context
+			contract.Static = test.static
+
+			assert.Equal(t, test.expected, NewSlashingRuntime().CanRun(contract, nil, &chain.ForksInTime{}))
+		})
+	}
+}
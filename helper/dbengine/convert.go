@@ -0,0 +1,309 @@
+package dbengine
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+const (
+	// convertingSuffix is the suffix of the database being written by the converter
+	convertingSuffix = ".converting"
+
+	// convertedSuffix is the suffix of the source database replaced by the converter
+	convertedSuffix = ".converted"
+
+	// maxBatchSize is the size of the key-value pairs written at once by the converter
+	maxBatchSize = 16 * 1024 * 1024
+)
+
+var (
+	ErrNoDatabase  = errors.New("data dir has no databases")
+	ErrSameEngine  = errors.New("data dir is already using the db engine")
+	errEmptyEngine = errors.New("db engine is not specified")
+)
+
+// reader iterates all the key-value pairs in the database
+type reader interface {
+	Iterate(fn func(k, v []byte) error) error
+	Close() error
+}
+
+// writer writes the key-value pairs to the database in batches
+type writer interface {
+	Put(k, v []byte) error
+	Close() error
+}
+
+// ConvertResult is the result of the conversion of a database in the data dir
+type ConvertResult struct {
+	Path string
+	Keys uint64
+}
+
+// Convert converts the databases in the data dir to the given engine.
+// The node must be stopped while the data dir is converted
+func Convert(dataDir string, to Engine) ([]ConvertResult, error) {
+	if to == "" {
+		return nil, errEmptyEngine
+	}
+
+	from, err := ReadEngine(dataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if from == "" {
+		return nil, fmt.Errorf("%w: %s", ErrNoDatabase, dataDir)
+	}
+
+	if from == to {
+		return nil, fmt.Errorf("%w: %s", ErrSameEngine, to)
+	}
+
+	results := make([]ConvertResult, 0, len(DatabasePaths))
+
+	// write all the converted databases before any of the sources is replaced
+	for _, name := range DatabasePaths {
+		path := filepath.Join(dataDir, name)
+
+		hasData, err := hasDatabase(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !hasData {
+			continue
+		}
+
+		keys, err := convertDatabase(path, path+convertingSuffix, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert %s, %w", path, err)
+		}
+
+		results = append(results, ConvertResult{Path: path, Keys: keys})
+	}
+
+	for _, res := range results {
+		if err := os.Rename(res.Path, res.Path+convertedSuffix); err != nil {
+			return nil, err
+		}
+
+		if err := os.Rename(res.Path+convertingSuffix, res.Path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := WriteEngine(dataDir, to); err != nil {
+		return nil, err
+	}
+
+	for _, res := range results {
+		if err := os.RemoveAll(res.Path + convertedSuffix); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// convertDatabase copies all the key-value pairs of the database at the source path
+// into the new database at the destination path
+func convertDatabase(srcPath, dstPath string, from, to Engine) (uint64, error) {
+	// remove the leftover of the interrupted conversion
+	if err := os.RemoveAll(dstPath); err != nil {
+		return 0, err
+	}
+
+	src, err := openReader(srcPath, from)
+	if err != nil {
+		return 0, err
+	}
+
+	defer src.Close()
+
+	dst, err := openWriter(dstPath, to)
+	if err != nil {
+		return 0, err
+	}
+
+	keys := uint64(0)
+
+	if err := src.Iterate(func(k, v []byte) error {
+		keys++
+
+		return dst.Put(k, v)
+	}); err != nil {
+		_ = dst.Close()
+
+		return 0, err
+	}
+
+	if err := dst.Close(); err != nil {
+		return 0, err
+	}
+
+	return keys, nil
+}
+
+func openReader(path string, engine Engine) (reader, error) {
+	switch engine {
+	case LevelDB:
+		db, err := leveldb.OpenFile(path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return &levelDBReader{db}, nil
+	case Pebble:
+		db, err := pebble.Open(path, &pebble.Options{})
+		if err != nil {
+			return nil, err
+		}
+
+		return &pebbleReader{db}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEngine, engine)
+	}
+}
+
+func openWriter(path string, engine Engine) (writer, error) {
+	switch engine {
+	case LevelDB:
+		db, err := leveldb.OpenFile(path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return &levelDBWriter{db: db, batch: new(leveldb.Batch)}, nil
+	case Pebble:
+		db, err := pebble.Open(path, &pebble.Options{})
+		if err != nil {
+			return nil, err
+		}
+
+		return &pebbleWriter{db: db, batch: db.NewBatch()}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEngine, engine)
+	}
+}
+
+type levelDBReader struct {
+	db *leveldb.DB
+}
+
+func (r *levelDBReader) Iterate(fn func(k, v []byte) error) error {
+	iter := r.db.NewIterator(nil, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		if err := fn(iter.Key(), iter.Value()); err != nil {
+			return err
+		}
+	}
+
+	return iter.Error()
+}
+
+func (r *levelDBReader) Close() error {
+	return r.db.Close()
+}
+
+type levelDBWriter struct {
+	db    *leveldb.DB
+	batch *leveldb.Batch
+}
+
+func (w *levelDBWriter) Put(k, v []byte) error {
+	w.batch.Put(k, v)
+
+	if len(w.batch.Dump()) < maxBatchSize {
+		return nil
+	}
+
+	return w.flush()
+}
+
+func (w *levelDBWriter) flush() error {
+	if err := w.db.Write(w.batch, &opt.WriteOptions{Sync: true}); err != nil {
+		return err
+	}
+
+	w.batch.Reset()
+
+	return nil
+}
+
+func (w *levelDBWriter) Close() error {
+	if err := w.flush(); err != nil {
+		_ = w.db.Close()
+
+		return err
+	}
+
+	return w.db.Close()
+}
+
+type pebbleReader struct {
+	db *pebble.DB
+}
+
+func (r *pebbleReader) Iterate(fn func(k, v []byte) error) error {
+	iter := r.db.NewIter(nil)
+
+	for iter.First(); iter.Valid(); iter.Next() {
+		if err := fn(iter.Key(), iter.Value()); err != nil {
+			_ = iter.Close()
+
+			return err
+		}
+	}
+
+	return iter.Close()
+}
+
+func (r *pebbleReader) Close() error {
+	return r.db.Close()
+}
+
+type pebbleWriter struct {
+	db    *pebble.DB
+	batch *pebble.Batch
+}
+
+func (w *pebbleWriter) Put(k, v []byte) error {
+	if err := w.batch.Set(k, v, nil); err != nil {
+		return err
+	}
+
+	if w.batch.Len() < maxBatchSize {
+		return nil
+	}
+
+	return w.flush()
+}
+
+func (w *pebbleWriter) flush() error {
+	if err := w.batch.Commit(pebble.Sync); err != nil {
+		return err
+	}
+
+	w.batch = w.db.NewBatch()
+
+	return nil
+}
+
+func (w *pebbleWriter) Close() error {
+	if err := w.flush(); err != nil {
+		_ = w.db.Close()
+
+		return err
+	}
+
+	return w.db.Close()
+}
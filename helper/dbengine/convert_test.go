@@ -0,0 +1,88 @@
+package dbengine
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestDatabase(t *testing.T, path string, engine Engine, data map[string]string) {
+	t.Helper()
+
+	w, err := openWriter(path, engine)
+	assert.NoError(t, err)
+
+	for k, v := range data {
+		assert.NoError(t, w.Put([]byte(k), []byte(v)))
+	}
+
+	assert.NoError(t, w.Close())
+}
+
+func readTestDatabase(t *testing.T, path string, engine Engine) map[string]string {
+	t.Helper()
+
+	r, err := openReader(path, engine)
+	assert.NoError(t, err)
+
+	data := map[string]string{}
+
+	assert.NoError(t, r.Iterate(func(k, v []byte) error {
+		data[string(k)] = string(v)
+
+		return nil
+	}))
+	assert.NoError(t, r.Close())
+
+	return data
+}
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+
+	data := make(map[string]string)
+	for i := 0; i < 100; i++ {
+		data[fmt.Sprintf("key%d", i)] = fmt.Sprintf("value%d", i)
+	}
+
+	for _, path := range DatabasePaths {
+		writeTestDatabase(t, filepath.Join(dataDir, path), LevelDB, data)
+	}
+
+	// the data dir created before the engine was recorded
+	results, err := Convert(dataDir, Pebble)
+
+	assert.NoError(t, err)
+	assert.Len(t, results, len(DatabasePaths))
+
+	for _, res := range results {
+		assert.Equal(t, uint64(len(data)), res.Keys)
+		assert.Equal(t, data, readTestDatabase(t, res.Path, Pebble))
+	}
+
+	assert.NoError(t, EnsureEngine(dataDir, Pebble))
+
+	_, err = Convert(dataDir, Pebble)
+	assert.ErrorIs(t, err, ErrSameEngine)
+
+	_, err = Convert(dataDir, LevelDB)
+	assert.NoError(t, err)
+
+	for _, path := range DatabasePaths {
+		assert.Equal(t, data, readTestDatabase(t, filepath.Join(dataDir, path), LevelDB))
+	}
+
+	assert.NoError(t, EnsureEngine(dataDir, LevelDB))
+}
+
+func TestConvert_NoDatabase(t *testing.T) {
+	t.Parallel()
+
+	_, err := Convert(t.TempDir(), Pebble)
+
+	assert.ErrorIs(t, err, ErrNoDatabase)
+}
@@ -0,0 +1,134 @@
+package dbengine
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Engine is the key-value database engine backing the chain and trie databases
+type Engine string
+
+const (
+	LevelDB Engine = "leveldb"
+	Pebble  Engine = "pebble"
+)
+
+// DefaultEngine is the engine used when none is configured
+const DefaultEngine = LevelDB
+
+// engineFileName is the name of the file in the data dir recording the engine
+const engineFileName = "db_engine"
+
+var (
+	// DatabasePaths are the sub-directories of the data dir opened with the engine
+	DatabasePaths = []string{
+		"blockchain",
+		"trie",
+	}
+
+	engines = map[Engine]bool{
+		LevelDB: true,
+		Pebble:  true,
+	}
+)
+
+var (
+	ErrUnknownEngine  = errors.New("unknown db engine")
+	ErrEngineMismatch = errors.New("db engine mismatch")
+)
+
+// ParseEngine parses the engine name, an empty name results in the default engine
+func ParseEngine(name string) (Engine, error) {
+	if name == "" {
+		return DefaultEngine, nil
+	}
+
+	engine := Engine(strings.ToLower(name))
+	if !engines[engine] {
+		return "", fmt.Errorf("%w: %s", ErrUnknownEngine, name)
+	}
+
+	return engine, nil
+}
+
+// ReadEngine returns the engine recorded in the data dir.
+// The data dir created before the engine was recorded is treated as LevelDB,
+// and an empty string is returned if the data dir has no databases yet
+func ReadEngine(dataDir string) (Engine, error) {
+	raw, err := os.ReadFile(filepath.Join(dataDir, engineFileName))
+	if err == nil {
+		return ParseEngine(strings.TrimSpace(string(raw)))
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("unable to read db engine file, %w", err)
+	}
+
+	for _, path := range DatabasePaths {
+		hasData, err := hasDatabase(filepath.Join(dataDir, path))
+		if err != nil {
+			return "", err
+		}
+
+		if hasData {
+			return LevelDB, nil
+		}
+	}
+
+	return "", nil
+}
+
+// WriteEngine records the engine in the data dir
+func WriteEngine(dataDir string, engine Engine) error {
+	path := filepath.Join(dataDir, engineFileName)
+	tmpPath := path + ".tmp"
+
+	if err := os.WriteFile(tmpPath, []byte(engine), 0600); err != nil {
+		return fmt.Errorf("unable to write db engine file, %w", err)
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// EnsureEngine checks the databases in the data dir are opened with the given engine
+// and records the engine if the data dir has no databases yet
+func EnsureEngine(dataDir string, engine Engine) error {
+	recorded, err := ReadEngine(dataDir)
+	if err != nil {
+		return err
+	}
+
+	if recorded == "" {
+		return WriteEngine(dataDir, engine)
+	}
+
+	if recorded != engine {
+		return fmt.Errorf(
+			"%w: data dir %s was created with %s, but %s is configured. "+
+				"Use the db convert command to convert the data dir",
+			ErrEngineMismatch,
+			dataDir,
+			recorded,
+			engine,
+		)
+	}
+
+	return nil
+}
+
+// hasDatabase checks if the directory at the path has any files
+func hasDatabase(path string) (bool, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return len(entries) > 0, nil
+}
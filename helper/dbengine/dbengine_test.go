@@ -0,0 +1,89 @@
+package dbengine
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEngine(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected Engine
+		err      error
+	}{
+		{
+			name:     "should return the default engine for empty name",
+			input:    "",
+			expected: DefaultEngine,
+		},
+		{
+			name:     "should parse the engine name ignoring the case",
+			input:    "Pebble",
+			expected: Pebble,
+		},
+		{
+			name:  "should return error for unknown engine",
+			input: "rocksdb",
+			err:   ErrUnknownEngine,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			engine, err := ParseEngine(test.input)
+
+			assert.Equal(t, test.expected, engine)
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
+
+func TestEnsureEngine(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should record the engine in the new data dir", func(t *testing.T) {
+		t.Parallel()
+
+		dataDir := t.TempDir()
+
+		assert.NoError(t, EnsureEngine(dataDir, Pebble))
+		assert.NoError(t, EnsureEngine(dataDir, Pebble))
+
+		engine, err := ReadEngine(dataDir)
+
+		assert.NoError(t, err)
+		assert.Equal(t, Pebble, engine)
+	})
+
+	t.Run("should return error if the engine differs from the recorded one", func(t *testing.T) {
+		t.Parallel()
+
+		dataDir := t.TempDir()
+
+		assert.NoError(t, WriteEngine(dataDir, LevelDB))
+		assert.ErrorIs(t, EnsureEngine(dataDir, Pebble), ErrEngineMismatch)
+	})
+
+	t.Run("should treat the existing data dir without the record as leveldb", func(t *testing.T) {
+		t.Parallel()
+
+		dataDir := t.TempDir()
+		blockchainPath := filepath.Join(dataDir, "blockchain")
+
+		assert.NoError(t, os.Mkdir(blockchainPath, 0750))
+		assert.NoError(t, os.WriteFile(filepath.Join(blockchainPath, "CURRENT"), []byte{}, 0600))
+
+		assert.ErrorIs(t, EnsureEngine(dataDir, Pebble), ErrEngineMismatch)
+		assert.NoError(t, EnsureEngine(dataDir, LevelDB))
+	})
+}
@@ -0,0 +1,265 @@
+package keystore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/sha3"
+)
+
+// The keys are encrypted in the Web3 Secret Storage format (version 3),
+// by AES-128-CTR with the key derived from the passphrase by scrypt
+const (
+	encryptedKeyVersion = 3
+
+	cipherAES128CTR = "aes-128-ctr"
+
+	kdfScrypt = "scrypt"
+	kdfPBKDF2 = "pbkdf2"
+
+	// StandardScryptN and StandardScryptP are the scrypt parameters of the encrypted keys,
+	// taking about 256MB of memory and a second of CPU time to derive the key
+	StandardScryptN = 1 << 18
+	StandardScryptP = 1
+
+	// LightScryptN and LightScryptP are the scrypt parameters taking about 4MB of memory
+	// and 100ms of CPU time, for the environments which can't afford the standard ones
+	LightScryptN = 1 << 12
+	LightScryptP = 6
+
+	scryptR     = 8
+	scryptDKLen = 32
+
+	saltLength = 32
+)
+
+var (
+	ErrDecrypt = errors.New("could not decrypt key with given passphrase")
+
+	errUnsupportedVersion = errors.New("unsupported version of encrypted key")
+	errUnsupportedCipher  = errors.New("unsupported cipher of encrypted key")
+	errUnsupportedKDF     = errors.New("unsupported key derivation function of encrypted key")
+	errInvalidKDFParams   = errors.New("invalid key derivation params of encrypted key")
+)
+
+// encryptedKeyJSON is the encrypted key in the Web3 Secret Storage format
+type encryptedKeyJSON struct {
+	Crypto  cryptoJSON `json:"crypto"`
+	ID      string     `json:"id"`
+	Version int        `json:"version"`
+}
+
+type cryptoJSON struct {
+	Cipher       string                 `json:"cipher"`
+	CipherText   string                 `json:"ciphertext"`
+	CipherParams cipherParamsJSON       `json:"cipherparams"`
+	KDF          string                 `json:"kdf"`
+	KDFParams    map[string]interface{} `json:"kdfparams"`
+	MAC          string                 `json:"mac"`
+}
+
+type cipherParamsJSON struct {
+	IV string `json:"iv"`
+}
+
+// IsEncryptedKey checks if the data is a key in the Web3 Secret Storage format
+func IsEncryptedKey(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '{' {
+		return false
+	}
+
+	var key encryptedKeyJSON
+	if err := json.Unmarshal(data, &key); err != nil {
+		return false
+	}
+
+	return key.Version != 0 && key.Crypto.Cipher != ""
+}
+
+// EncryptKey encrypts the key by the passphrase into the Web3 Secret Storage format,
+// deriving the encryption key by scrypt with the given parameters
+func EncryptKey(key []byte, passphrase string, scryptN, scryptP int) ([]byte, error) {
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("unable to generate salt, %w", err)
+	}
+
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, scryptDKLen)
+	if err != nil {
+		return nil, fmt.Errorf("unable to derive key, %w", err)
+	}
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, fmt.Errorf("unable to generate IV, %w", err)
+	}
+
+	cipherText, err := aesCTRXOR(derivedKey[:16], key, iv)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate key ID, %w", err)
+	}
+
+	return json.MarshalIndent(&encryptedKeyJSON{
+		Crypto: cryptoJSON{
+			Cipher:     cipherAES128CTR,
+			CipherText: hex.EncodeToString(cipherText),
+			CipherParams: cipherParamsJSON{
+				IV: hex.EncodeToString(iv),
+			},
+			KDF: kdfScrypt,
+			KDFParams: map[string]interface{}{
+				"n":     scryptN,
+				"r":     scryptR,
+				"p":     scryptP,
+				"dklen": scryptDKLen,
+				"salt":  hex.EncodeToString(salt),
+			},
+			MAC: hex.EncodeToString(keystoreMAC(derivedKey, cipherText)),
+		},
+		ID:      id.String(),
+		Version: encryptedKeyVersion,
+	}, "", "  ")
+}
+
+// DecryptKey decrypts the key in the Web3 Secret Storage format by the passphrase
+func DecryptKey(data []byte, passphrase string) ([]byte, error) {
+	var key encryptedKeyJSON
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("unable to parse encrypted key, %w", err)
+	}
+
+	if key.Version != encryptedKeyVersion {
+		return nil, fmt.Errorf("%w: %d", errUnsupportedVersion, key.Version)
+	}
+
+	if key.Crypto.Cipher != cipherAES128CTR {
+		return nil, fmt.Errorf("%w: %s", errUnsupportedCipher, key.Crypto.Cipher)
+	}
+
+	cipherText, err := hex.DecodeString(key.Crypto.CipherText)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode cipher text, %w", err)
+	}
+
+	iv, err := hex.DecodeString(key.Crypto.CipherParams.IV)
+	if err != nil || len(iv) != aes.BlockSize {
+		return nil, fmt.Errorf("invalid IV of encrypted key")
+	}
+
+	mac, err := hex.DecodeString(key.Crypto.MAC)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode MAC, %w", err)
+	}
+
+	derivedKey, err := deriveKey(&key.Crypto, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare(keystoreMAC(derivedKey, cipherText), mac) != 1 {
+		return nil, ErrDecrypt
+	}
+
+	return aesCTRXOR(derivedKey[:16], cipherText, iv)
+}
+
+// deriveKey derives the encryption key from the passphrase by the key derivation function of the encrypted key
+func deriveKey(c *cryptoJSON, passphrase string) ([]byte, error) {
+	intParam := func(name string) (int, error) {
+		// the numbers of JSON are decoded as float64
+		value, ok := c.KDFParams[name].(float64)
+		if !ok || value <= 0 || value != float64(int(value)) {
+			return 0, fmt.Errorf("%w: %s", errInvalidKDFParams, name)
+		}
+
+		return int(value), nil
+	}
+
+	saltHex, _ := c.KDFParams["salt"].(string)
+
+	salt, err := hex.DecodeString(saltHex)
+	if err != nil {
+		return nil, fmt.Errorf("%w: salt", errInvalidKDFParams)
+	}
+
+	dkLen, err := intParam("dklen")
+	if err != nil {
+		return nil, err
+	}
+
+	// the first half is the AES-128 key, the second half is the MAC key
+	if dkLen < 32 {
+		return nil, fmt.Errorf("%w: dklen", errInvalidKDFParams)
+	}
+
+	switch c.KDF {
+	case kdfScrypt:
+		n, err := intParam("n")
+		if err != nil {
+			return nil, err
+		}
+
+		r, err := intParam("r")
+		if err != nil {
+			return nil, err
+		}
+
+		p, err := intParam("p")
+		if err != nil {
+			return nil, err
+		}
+
+		return scrypt.Key([]byte(passphrase), salt, n, r, p, dkLen)
+	case kdfPBKDF2:
+		if prf, _ := c.KDFParams["prf"].(string); prf != "hmac-sha256" {
+			return nil, fmt.Errorf("%w: prf", errInvalidKDFParams)
+		}
+
+		iterations, err := intParam("c")
+		if err != nil {
+			return nil, err
+		}
+
+		return pbkdf2.Key([]byte(passphrase), salt, iterations, dkLen, sha256.New), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedKDF, c.KDF)
+	}
+}
+
+// keystoreMAC is the MAC of the cipher text, the Keccak-256 hash of the second half of the derived key and the cipher text
+func keystoreMAC(derivedKey, cipherText []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(derivedKey[16:32])
+	h.Write(cipherText)
+
+	return h.Sum(nil)
+}
+
+func aesCTRXOR(key, in, iv []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, len(in))
+	cipher.NewCTR(block, iv).XORKeyStream(out, in)
+
+	return out, nil
+}
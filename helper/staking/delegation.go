@@ -0,0 +1,235 @@
+package staking
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrInvalidDelegationAmount  = errors.New("delegation amount must be greater than zero")
+	ErrInsufficientDelegation   = errors.New("delegated amount is less than the requested amount")
+	ErrDelegationToZeroAddress  = errors.New("delegation to zero address is not allowed")
+	errAddressArrayIndexInvalid = errors.New("invalid index in address array")
+)
+
+// contractStorage reads and writes the storage of the staking contract.
+// The delegations are kept in the same layout as Solidity keeps the variables declared
+// after the variables of the staking contract in contracts/staking/StakingExtensions.sol,
+// their slots in storage_layout.go are generated from the source
+type contractStorage struct {
+	host     StorageHost
+	contract types.Address
+	config   *chain.ForksInTime
+}
+
+func (s *contractStorage) get(key []byte) *big.Int {
+	return new(big.Int).SetBytes(s.host.GetStorage(s.contract, types.BytesToHash(key)).Bytes())
+}
+
+func (s *contractStorage) set(key []byte, value *big.Int) {
+	s.host.SetStorage(s.contract, types.BytesToHash(key), types.BytesToHash(value.Bytes()), s.config)
+}
+
+// getNestedAddressMapping returns the key for the SC storage mapping (address => address => something)
+func getNestedAddressMapping(outer, inner types.Address, slot int64) []byte {
+	return keccak.Keccak256(nil, append(
+		common.PadLeftOrTrim(inner.Bytes(), 32),
+		common.PadLeftOrTrim(getAddressMapping(outer, slot), 32)...,
+	))
+}
+
+// addressArray is a dynamic array of addresses with the index mapping to find the element
+type addressArray struct {
+	// lenKey is the key of the length, the elements follow keccak(lenKey)
+	lenKey []byte
+	// indexKey returns the key of the index + 1 of the address
+	indexKey func(types.Address) []byte
+}
+
+func (a *addressArray) elementKey(index uint64) []byte {
+	return getIndexWithOffset(keccak.Keccak256(nil, common.PadLeftOrTrim(a.lenKey, 32)), index)
+}
+
+func (a *addressArray) list(s *contractStorage) []types.Address {
+	length := s.get(a.lenKey).Uint64()
+	addrs := make([]types.Address, 0, length)
+
+	for i := uint64(0); i < length; i++ {
+		addrs = append(addrs, types.BytesToAddress(s.get(a.elementKey(i)).Bytes()))
+	}
+
+	return addrs
+}
+
+func (a *addressArray) add(s *contractStorage, addr types.Address) {
+	if s.get(a.indexKey(addr)).Sign() != 0 {
+		return
+	}
+
+	length := s.get(a.lenKey).Uint64()
+
+	s.set(a.elementKey(length), new(big.Int).SetBytes(addr.Bytes()))
+	s.set(a.indexKey(addr), new(big.Int).SetUint64(length+1))
+	s.set(a.lenKey, new(big.Int).SetUint64(length+1))
+}
+
+func (a *addressArray) remove(s *contractStorage, addr types.Address) error {
+	indexPlusOne := s.get(a.indexKey(addr)).Uint64()
+	if indexPlusOne == 0 {
+		return nil
+	}
+
+	var (
+		index     = indexPlusOne - 1
+		lastIndex = s.get(a.lenKey).Uint64() - 1
+	)
+
+	if index > lastIndex {
+		return errAddressArrayIndexInvalid
+	}
+
+	// move the last element to the position of the removed one
+	if index != lastIndex {
+		last := s.get(a.elementKey(lastIndex))
+
+		s.set(a.elementKey(index), last)
+		s.set(a.indexKey(types.BytesToAddress(last.Bytes())), new(big.Int).SetUint64(index+1))
+	}
+
+	s.set(a.elementKey(lastIndex), big.NewInt(0))
+	s.set(a.indexKey(addr), big.NewInt(0))
+	s.set(a.lenKey, new(big.Int).SetUint64(lastIndex))
+
+	return nil
+}
+
+func delegatorsArray(validator types.Address) *addressArray {
+	return &addressArray{
+		lenKey: getAddressMapping(validator, delegatorsSlot),
+		indexKey: func(delegator types.Address) []byte {
+			return getNestedAddressMapping(validator, delegator, delegatorIndexSlot)
+		},
+	}
+}
+
+func candidatesArray() *addressArray {
+	return &addressArray{
+		lenKey: big.NewInt(delegationCandidatesSlot).Bytes(),
+		indexKey: func(candidate types.Address) []byte {
+			return getAddressMapping(candidate, candidateIndexSlot)
+		},
+	}
+}
+
+// Delegate adds the amount to the delegation from the delegator to the validator.
+// The validator becomes a candidate of the validator set even if it hasn't staked by itself
+func Delegate(
+	host StorageHost,
+	contract types.Address,
+	delegator, validator types.Address,
+	amount *big.Int,
+	config *chain.ForksInTime,
+) error {
+	if amount == nil || amount.Sign() <= 0 {
+		return ErrInvalidDelegationAmount
+	}
+
+	if validator == types.ZeroAddress {
+		return ErrDelegationToZeroAddress
+	}
+
+	var (
+		s                 = &contractStorage{host: host, contract: contract, config: config}
+		delegationIndex   = getNestedAddressMapping(validator, delegator, delegationsSlot)
+		delegatedStakeKey = getAddressMapping(validator, delegatedStakeSlot)
+	)
+
+	s.set(delegationIndex, new(big.Int).Add(s.get(delegationIndex), amount))
+	s.set(delegatedStakeKey, new(big.Int).Add(s.get(delegatedStakeKey), amount))
+
+	delegatorsArray(validator).add(s, delegator)
+	candidatesArray().add(s, validator)
+
+	return nil
+}
+
+// Undelegate subtracts the amount from the delegation from the delegator to the validator.
+// The caller is responsible for returning the amount to the delegator
+func Undelegate(
+	host StorageHost,
+	contract types.Address,
+	delegator, validator types.Address,
+	amount *big.Int,
+	config *chain.ForksInTime,
+) error {
+	if amount == nil || amount.Sign() <= 0 {
+		return ErrInvalidDelegationAmount
+	}
+
+	var (
+		s                 = &contractStorage{host: host, contract: contract, config: config}
+		delegationIndex   = getNestedAddressMapping(validator, delegator, delegationsSlot)
+		delegatedStakeKey = getAddressMapping(validator, delegatedStakeSlot)
+		delegation        = s.get(delegationIndex)
+	)
+
+	if delegation.Cmp(amount) < 0 {
+		return ErrInsufficientDelegation
+	}
+
+	delegation.Sub(delegation, amount)
+	delegatedStake := new(big.Int).Sub(s.get(delegatedStakeKey), amount)
+
+	s.set(delegationIndex, delegation)
+	s.set(delegatedStakeKey, delegatedStake)
+
+	if delegation.Sign() == 0 {
+		if err := delegatorsArray(validator).remove(s, delegator); err != nil {
+			return err
+		}
+	}
+
+	if delegatedStake.Sign() == 0 {
+		if err := candidatesArray().remove(s, validator); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetDelegation returns the amount delegated from the delegator to the validator
+func GetDelegation(host StorageHost, contract types.Address, delegator, validator types.Address) *big.Int {
+	s := &contractStorage{host: host, contract: contract}
+
+	return s.get(getNestedAddressMapping(validator, delegator, delegationsSlot))
+}
+
+// GetDelegatedStake returns the total amount delegated to the validator
+func GetDelegatedStake(host StorageHost, contract types.Address, validator types.Address) *big.Int {
+	s := &contractStorage{host: host, contract: contract}
+
+	return s.get(getAddressMapping(validator, delegatedStakeSlot))
+}
+
+// GetStakedAmount returns the amount the validator has staked by itself
+func GetStakedAmount(host StorageHost, contract types.Address, validator types.Address) *big.Int {
+	s := &contractStorage{host: host, contract: contract}
+
+	return s.get(getAddressMapping(validator, addressToStakedAmountSlot))
+}
+
+// GetDelegators returns the addresses delegating to the validator
+func GetDelegators(host StorageHost, contract types.Address, validator types.Address) []types.Address {
+	return delegatorsArray(validator).list(&contractStorage{host: host, contract: contract})
+}
+
+// GetDelegationCandidates returns the addresses having delegated stake
+func GetDelegationCandidates(host StorageHost, contract types.Address) []types.Address {
+	return candidatesArray().list(&contractStorage{host: host, contract: contract})
+}
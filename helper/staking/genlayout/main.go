@@ -0,0 +1,241 @@
+// genlayout generates the storage slots of the staking contract from the storage variables
+// declared in the Solidity source, so that the slots accessed natively by helper/staking
+// can't diverge from the contract source.
+//
+// The slots are assigned in the order of the declarations as the Solidity compiler does.
+// Only the types taking whole slots are supported, the packed value types are rejected.
+//
+// Usage:
+//
+//	go run ./genlayout -source <contract.sol> -out <file.go>
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	errNoStateVariables = errors.New("no storage variables in the source")
+	errUnsupportedType  = errors.New("unsupported type, only the types taking whole slots are supported")
+	errInvalidVariable  = errors.New("invalid storage variable declaration")
+)
+
+var (
+	// wholeSlotValueTypes are the value types taking a whole slot
+	wholeSlotValueTypes = map[string]bool{
+		"uint":    true,
+		"uint256": true,
+		"int":     true,
+		"int256":  true,
+		"bytes32": true,
+	}
+
+	// skippedKeywords are the keywords of the declarations at the contract level which are not storage variables
+	skippedKeywords = map[string]bool{
+		"event":    true,
+		"error":    true,
+		"using":    true,
+		"struct":   true,
+		"enum":     true,
+		"function": true,
+		"modifier": true,
+	}
+
+	// variableModifiers are the keywords following the type in the storage variable declarations
+	variableModifiers = map[string]bool{
+		"internal": true,
+		"private":  true,
+		"public":   true,
+		"override": true,
+	}
+
+	identifierRegexp = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+	commentRegexp    = regexp.MustCompile(`(?s)//[^\n]*|/\*.*?\*/`)
+)
+
+// storageVariable is the storage variable of the contract and its slot
+type storageVariable struct {
+	Name string
+	Type string
+	Slot int
+}
+
+func main() {
+	source := flag.String("source", "", "path to the Solidity source of the contract")
+	out := flag.String("out", "", "path to the generated Go file")
+	pkg := flag.String("package", "staking", "package of the generated Go file")
+
+	flag.Parse()
+
+	if err := run(*source, *out, *pkg); err != nil {
+		fmt.Fprintln(os.Stderr, "genlayout:", err)
+		os.Exit(1)
+	}
+}
+
+func run(source, out, pkg string) error {
+	code, err := os.ReadFile(source)
+	if err != nil {
+		return err
+	}
+
+	vars, err := parseStorageVariables(string(code))
+	if err != nil {
+		return err
+	}
+
+	generated, err := generate(pkg, filepath.ToSlash(filepath.Base(source)), vars)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(out, generated, 0600)
+}
+
+// parseStorageVariables returns the storage variables declared at the contract level with their slots
+func parseStorageVariables(code string) ([]storageVariable, error) {
+	var (
+		vars      []storageVariable
+		depth     int
+		statement strings.Builder
+	)
+
+	for _, ch := range commentRegexp.ReplaceAllString(code, "") {
+		switch {
+		case ch == '{':
+			depth++
+
+			statement.Reset()
+		case ch == '}':
+			depth--
+
+			statement.Reset()
+		case ch == ';' && depth == 1:
+			variable, ok, err := parseDeclaration(statement.String())
+			if err != nil {
+				return nil, err
+			}
+
+			if ok {
+				variable.Slot = len(vars)
+				vars = append(vars, variable)
+			}
+
+			statement.Reset()
+		case depth == 1:
+			statement.WriteRune(ch)
+		}
+	}
+
+	if len(vars) == 0 {
+		return nil, errNoStateVariables
+	}
+
+	return vars, nil
+}
+
+// parseDeclaration parses the statement at the contract level,
+// it returns false if the statement doesn't declare a storage variable
+func parseDeclaration(statement string) (storageVariable, bool, error) {
+	// the initial value doesn't affect the layout
+	if idx := strings.Index(statement, "="); idx >= 0 && !strings.HasPrefix(statement[idx:], "=>") {
+		statement = statement[:idx]
+	}
+
+	fields := strings.Fields(statement)
+	if len(fields) == 0 || skippedKeywords[fields[0]] {
+		return storageVariable{}, false, nil
+	}
+
+	name := fields[len(fields)-1]
+	if !identifierRegexp.MatchString(name) {
+		return storageVariable{}, false, fmt.Errorf("%w: %s", errInvalidVariable, strings.TrimSpace(statement))
+	}
+
+	var typeFields []string
+
+	for _, field := range fields[:len(fields)-1] {
+		switch {
+		case field == "constant" || field == "immutable":
+			// the constants are not kept in the storage
+			return storageVariable{}, false, nil
+		case variableModifiers[field]:
+		default:
+			typeFields = append(typeFields, field)
+		}
+	}
+
+	typeName := normalizeType(strings.Join(typeFields, " "))
+
+	if !takesWholeSlot(typeName) {
+		return storageVariable{}, false, fmt.Errorf("%w: %s %s", errUnsupportedType, typeName, name)
+	}
+
+	return storageVariable{Name: name, Type: typeName}, true, nil
+}
+
+// normalizeType removes the spaces around the symbols in the type
+func normalizeType(typeName string) string {
+	replacer := strings.NewReplacer(" (", "(", "( ", "(", " )", ")", " [", "[", "[ ", "[", " ]", "]")
+
+	for {
+		replaced := replacer.Replace(typeName)
+		if replaced == typeName {
+			return typeName
+		}
+
+		typeName = replaced
+	}
+}
+
+// takesWholeSlot returns whether the variable of the type starts a new slot and the next variable does too
+func takesWholeSlot(typeName string) bool {
+	switch {
+	case strings.HasPrefix(typeName, "mapping("):
+		return true
+	case strings.HasSuffix(typeName, "[]"):
+		// the dynamic arrays keep the length in the slot
+		return true
+	case typeName == "bytes" || typeName == "string":
+		return true
+	default:
+		return wholeSlotValueTypes[typeName]
+	}
+}
+
+// generate returns the Go source declaring the slots of the variables
+func generate(pkg, source string, vars []storageVariable) ([]byte, error) {
+	buf := &bytes.Buffer{}
+
+	fmt.Fprintf(buf, "// Code generated by genlayout from %s. DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(buf, "package %s\n\n", pkg)
+	fmt.Fprintf(buf, "// Slots of the storage variables of the staking contract\n")
+	fmt.Fprintf(buf, "var (\n")
+
+	for _, variable := range vars {
+		fmt.Fprintf(buf, "\t%s = int64(%d) // %s %s\n", slotName(variable.Name), variable.Slot, variable.Type, variable.Name)
+	}
+
+	fmt.Fprintf(buf, ")\n")
+
+	return format.Source(buf.Bytes())
+}
+
+// slotName returns the name of the Go variable of the slot, e.g. _delegatedStake => delegatedStakeSlot
+func slotName(name string) string {
+	name = strings.TrimLeft(name, "_$")
+
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+
+	return string(runes) + "Slot"
+}
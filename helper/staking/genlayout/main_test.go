@@ -0,0 +1,102 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStorageLayoutUpToDate fails if the contract source is changed without regenerating the slots
+func TestStorageLayoutUpToDate(t *testing.T) {
+	t.Parallel()
+
+	code, err := os.ReadFile("../../../contracts/staking/StakingExtensions.sol")
+	require.NoError(t, err)
+
+	vars, err := parseStorageVariables(string(code))
+	require.NoError(t, err)
+
+	generated, err := generate("staking", "StakingExtensions.sol", vars)
+	require.NoError(t, err)
+
+	committed, err := os.ReadFile("../storage_layout.go")
+	require.NoError(t, err)
+
+	assert.Equal(t, string(generated), string(committed), "run make generate-staking-layout")
+}
+
+func Test_parseStorageVariables(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		code     string
+		expected []storageVariable
+		err      error
+	}{
+		{
+			name: "should assign the slots in the order of the declarations",
+			code: `
+				contract C {
+					// address[] internal _commented;
+					address[] internal _list; /* mapping(address => bool) _inline; */
+					mapping(address => mapping(address => uint256)) public _nested;
+					uint256 _total = 1;
+					uint256 internal constant LIMIT = 10;
+					event Updated(address indexed account, uint256 amount);
+
+					function update(address account) public {
+						uint256 amount = _total;
+						_nested[account][msg.sender] = amount;
+					}
+
+					mapping(address => bytes) internal _keys;
+				}`,
+			expected: []storageVariable{
+				{Name: "_list", Type: "address[]", Slot: 0},
+				{Name: "_nested", Type: "mapping(address => mapping(address => uint256))", Slot: 1},
+				{Name: "_total", Type: "uint256", Slot: 2},
+				{Name: "_keys", Type: "mapping(address => bytes)", Slot: 3},
+			},
+		},
+		{
+			name: "should reject the packed value types",
+			code: `
+				contract C {
+					uint256 _total;
+					bool _paused;
+				}`,
+			err: errUnsupportedType,
+		},
+		{
+			name: "should return error if no storage variable is declared",
+			code: `
+				contract C {
+					function f() public {}
+				}`,
+			err: errNoStateVariables,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			vars, err := parseStorageVariables(test.code)
+
+			assert.ErrorIs(t, err, test.err)
+			assert.Equal(t, test.expected, vars)
+		})
+	}
+}
+
+func Test_slotName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "delegatedStakeSlot", slotName("_delegatedStake"))
+	assert.Equal(t, "validatorsSlot", slotName("validators"))
+}
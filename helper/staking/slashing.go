@@ -0,0 +1,84 @@
+package staking
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrNotValidator          = errors.New("account is not a validator")
+	ErrMinimumValidatorCount = errors.New("validators can't be less than the minimum required validator number")
+)
+
+// StorageHost is the interface to access the storage of the staking contract
+type StorageHost interface {
+	GetStorage(addr types.Address, key types.Hash) types.Hash
+	SetStorage(addr types.Address, key types.Hash, value types.Hash, config *chain.ForksInTime) runtime.StorageStatus
+}
+
+// SlashValidator removes the validator from the validator set in the staking contract
+// and confiscates its stake, the confiscated amount is left locked in the contract.
+// It modifies the storage in the same way as the staking contract removes the validator on unstaking,
+// as the slash function of contracts/staking/StakingExtensions.sol
+func SlashValidator(
+	host StorageHost,
+	contract types.Address,
+	validator types.Address,
+	config *chain.ForksInTime,
+) (*big.Int, error) {
+	var (
+		s   = &contractStorage{host: host, contract: contract, config: config}
+		get = s.get
+		set = s.set
+
+		validatorsLenIndex     = big.NewInt(validatorsSlot).Bytes()
+		validatorsBaseIndex    = keccak.Keccak256(nil, common.PadLeftOrTrim(validatorsLenIndex, 32))
+		isValidatorIndex       = getAddressMapping(validator, addressToIsValidatorSlot)
+		stakedAmountIndex      = getAddressMapping(validator, addressToStakedAmountSlot)
+		validatorIndexIndex    = getAddressMapping(validator, addressToValidatorIndexSlot)
+		totalStakedAmountIndex = big.NewInt(stakedAmountSlot).Bytes()
+		minNumValidatorIndex   = big.NewInt(minimumNumValidatorsSlot).Bytes()
+	)
+
+	if get(isValidatorIndex).Sign() == 0 {
+		return nil, ErrNotValidator
+	}
+
+	numValidators := get(validatorsLenIndex)
+	if numValidators.Cmp(get(minNumValidatorIndex)) <= 0 {
+		return nil, ErrMinimumValidatorCount
+	}
+
+	// move the last validator to the position of the slashed one
+	index := get(validatorIndexIndex).Uint64()
+	lastIndex := numValidators.Uint64() - 1
+
+	if index != lastIndex {
+		lastValidator := get(getIndexWithOffset(validatorsBaseIndex, lastIndex))
+
+		set(getIndexWithOffset(validatorsBaseIndex, index), lastValidator)
+		set(
+			getAddressMapping(types.BytesToAddress(lastValidator.Bytes()), addressToValidatorIndexSlot),
+			new(big.Int).SetUint64(index),
+		)
+	}
+
+	set(getIndexWithOffset(validatorsBaseIndex, lastIndex), big.NewInt(0))
+	set(validatorsLenIndex, new(big.Int).SetUint64(lastIndex))
+	set(isValidatorIndex, big.NewInt(0))
+	set(validatorIndexIndex, big.NewInt(0))
+
+	// confiscate the stake
+	amount := get(stakedAmountIndex)
+
+	set(stakedAmountIndex, big.NewInt(0))
+	set(totalStakedAmountIndex, new(big.Int).Sub(get(totalStakedAmountIndex), amount))
+
+	return amount, nil
+}
@@ -144,17 +144,8 @@ type StorageIndexes struct {
 	AddressToValidatorIndexIndex []byte // mapping(address => uint256)
 }
 
-// Slot definitions for SC storage
-var (
-	validatorsSlot              = int64(0) // Slot 0
-	addressToIsValidatorSlot    = int64(1) // Slot 1
-	addressToStakedAmountSlot   = int64(2) // Slot 2
-	addressToValidatorIndexSlot = int64(3) // Slot 3
-	stakedAmountSlot            = int64(4) // Slot 4
-	minNumValidatorSlot         = int64(5) // Slot 5
-	maxNumValidatorSlot         = int64(6) // Slot 6
-	addressToBLSPublicKeySlot   = int64(7) // Slot 7
-)
+// The slots of the SC storage are generated from the storage variables declared in the contract source
+//go:generate go run ./genlayout -source ../../contracts/staking/StakingExtensions.sol -out storage_layout.go
 
 const (
 	DefaultStakedBalance = "0x8AC7230489E80000" // 10 ETH
@@ -240,11 +231,11 @@ func PredeployStakingSC(
 		types.BytesToHash(valsLen.Bytes())
 
 	// Set the value for the minimum number of validators
-	storageMap[types.BytesToHash(big.NewInt(minNumValidatorSlot).Bytes())] =
+	storageMap[types.BytesToHash(big.NewInt(minimumNumValidatorsSlot).Bytes())] =
 		types.BytesToHash(bigMinNumValidators.Bytes())
 
 	// Set the value for the maximum number of validators
-	storageMap[types.BytesToHash(big.NewInt(maxNumValidatorSlot).Bytes())] =
+	storageMap[types.BytesToHash(big.NewInt(maximumNumValidatorsSlot).Bytes())] =
 		types.BytesToHash(bigMaxNumValidators.Bytes())
 
 	// Save the storage map
@@ -0,0 +1,21 @@
+// Code generated by genlayout from StakingExtensions.sol. DO NOT EDIT.
+
+package staking
+
+// Slots of the storage variables of the staking contract
+var (
+	validatorsSlot              = int64(0)  // address[] _validators
+	addressToIsValidatorSlot    = int64(1)  // mapping(address => bool) _addressToIsValidator
+	addressToStakedAmountSlot   = int64(2)  // mapping(address => uint256) _addressToStakedAmount
+	addressToValidatorIndexSlot = int64(3)  // mapping(address => uint256) _addressToValidatorIndex
+	stakedAmountSlot            = int64(4)  // uint256 _stakedAmount
+	minimumNumValidatorsSlot    = int64(5)  // uint256 _minimumNumValidators
+	maximumNumValidatorsSlot    = int64(6)  // uint256 _maximumNumValidators
+	addressToBLSPublicKeySlot   = int64(7)  // mapping(address => bytes) _addressToBLSPublicKey
+	delegationsSlot             = int64(8)  // mapping(address => mapping(address => uint256)) _delegations
+	delegatedStakeSlot          = int64(9)  // mapping(address => uint256) _delegatedStake
+	delegatorsSlot              = int64(10) // mapping(address => address[]) _delegators
+	delegatorIndexSlot          = int64(11) // mapping(address => mapping(address => uint256)) _delegatorIndex
+	delegationCandidatesSlot    = int64(12) // address[] _delegationCandidates
+	candidateIndexSlot          = int64(13) // mapping(address => uint256) _candidateIndex
+)
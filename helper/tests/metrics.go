@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/armon/go-metrics"
+)
+
+// MetricsSink collects the metrics emitted through the global go-metrics instance
+type MetricsSink struct {
+	*metrics.InmemSink
+}
+
+// NewMetricsSink replaces the global metrics with an in-memory sink until the test ends.
+// The metrics are global, so the tests using it must not run in parallel
+func NewMetricsSink(t *testing.T) *MetricsSink {
+	t.Helper()
+
+	conf := metrics.DefaultConfig("")
+	conf.EnableHostname = false
+	conf.EnableRuntimeMetrics = false
+
+	sink := metrics.NewInmemSink(time.Hour, time.Hour)
+	if _, err := metrics.NewGlobal(conf, sink); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_, _ = metrics.NewGlobal(conf, &metrics.BlackholeSink{})
+	})
+
+	return &MetricsSink{sink}
+}
+
+// Counter returns the sum of the counter with the labels, 0 if it has not been incremented
+func (s *MetricsSink) Counter(name []string, labels ...metrics.Label) float64 {
+	key := metricKey(name, labels)
+
+	for _, interval := range s.Data() {
+		if counter, ok := interval.Counters[key]; ok {
+			return counter.Sum
+		}
+	}
+
+	return 0
+}
+
+// Gauge returns the value of the gauge with the labels and whether it has been set
+func (s *MetricsSink) Gauge(name []string, labels ...metrics.Label) (float32, bool) {
+	key := metricKey(name, labels)
+
+	for _, interval := range s.Data() {
+		if gauge, ok := interval.Gauges[key]; ok {
+			return gauge.Value, true
+		}
+	}
+
+	return 0, false
+}
+
+// Samples returns the number of the samples added with the labels
+func (s *MetricsSink) Samples(name []string, labels ...metrics.Label) int {
+	key := metricKey(name, labels)
+
+	for _, interval := range s.Data() {
+		if sample, ok := interval.Samples[key]; ok {
+			return sample.Count
+		}
+	}
+
+	return 0
+}
+
+// metricKey flattens the name and the labels the way the in-memory sink does
+func metricKey(name []string, labels []metrics.Label) string {
+	var sb strings.Builder
+
+	sb.WriteString(strings.Join(name, "."))
+
+	for _, label := range labels {
+		sb.WriteString(";" + label.Name + "=" + label.Value)
+	}
+
+	return sb.String()
+}
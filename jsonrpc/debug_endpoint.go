@@ -47,6 +47,9 @@ type debugBlockchainStore interface {
 
 	// TraceCall traces a single call at the point when the given header is mined
 	TraceCall(*types.Transaction, *types.Header, tracer.Tracer) (interface{}, error)
+
+	// SetHead rewinds the chain to the block with the given number
+	SetHead(number uint64) error
 }
 
 type debugTxPoolStore interface {
@@ -175,6 +178,16 @@ func (d *Debug) TraceCall(
 	return d.store.TraceCall(tx, header, tracer)
 }
 
+// SetHead rewinds the chain to the block with the given number.
+// It's only available with the admin methods enabled
+func (d *Debug) SetHead(number argUint64) (interface{}, error) {
+	if err := d.store.SetHead(uint64(number)); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
 func (d *Debug) traceBlock(
 	block *types.Block,
 	config *TraceConfig,
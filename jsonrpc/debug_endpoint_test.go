@@ -23,6 +23,7 @@ type debugEndpointMockStore struct {
 	traceCallFn         func(*types.Transaction, *types.Header, tracer.Tracer) (interface{}, error)
 	getNonceFn          func(types.Address) uint64
 	getAccountFn        func(types.Hash, types.Address) (*Account, error)
+	setHeadFn           func(uint64) error
 }
 
 func (s *debugEndpointMockStore) Header() *types.Header {
@@ -65,6 +66,10 @@ func (s *debugEndpointMockStore) GetAccount(root types.Hash, addr types.Address)
 	return s.getAccountFn(root, addr)
 }
 
+func (s *debugEndpointMockStore) SetHead(number uint64) error {
+	return s.setHeadFn(number)
+}
+
 func TestDebugTraceConfigDecode(t *testing.T) {
 	timeout15s := "15s"
 
@@ -8,11 +8,23 @@ import (
 	"math"
 	"reflect"
 	"strings"
+	"time"
 	"unicode"
 
+	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
 )
 
+const (
+	jsonrpcMetrics = "jsonrpc"
+)
+
+// adminMethods are the methods changing the node state,
+// which are only available with the admin methods enabled
+var adminMethods = map[string]struct{}{
+	"debug_setHead": {},
+}
+
 type serviceData struct {
 	sv      reflect.Value
 	funcMap map[string]*funcData
@@ -35,6 +47,8 @@ type endpoints struct {
 	Net    *Net
 	TxPool *TxPool
 	Debug  *Debug
+	IBFT   *IBFT
+	Edge   *Edge
 }
 
 // Dispatcher handles all json rpc requests by delegating
@@ -55,6 +69,8 @@ type dispatcherParams struct {
 	priceLimit              uint64
 	jsonRPCBatchLengthLimit uint64
 	blockRangeLimit         uint64
+	indexedBlockRangeLimit  uint64
+	adminEnabled            bool
 }
 
 func newDispatcher(
@@ -68,7 +84,7 @@ func newDispatcher(
 	}
 
 	if store != nil {
-		d.filterManager = NewFilterManager(logger, store, params.blockRangeLimit)
+		d.filterManager = NewFilterManager(logger, store, params.blockRangeLimit, params.indexedBlockRangeLimit)
 		go d.filterManager.Run()
 	}
 
@@ -99,12 +115,20 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) {
 	d.endpoints.Debug = &Debug{
 		store,
 	}
+	d.endpoints.IBFT = &IBFT{
+		store,
+	}
+	d.endpoints.Edge = &Edge{
+		store,
+	}
 
 	d.registerService("eth", d.endpoints.Eth)
 	d.registerService("net", d.endpoints.Net)
 	d.registerService("web3", d.endpoints.Web3)
 	d.registerService("txpool", d.endpoints.TxPool)
 	d.registerService("debug", d.endpoints.Debug)
+	d.registerService("ibft", d.endpoints.IBFT)
+	d.registerService("edge", d.endpoints.Edge)
 }
 
 func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error) {
@@ -113,6 +137,10 @@ func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error)
 		return nil, nil, NewMethodNotFoundError(req.Method)
 	}
 
+	if _, ok := adminMethods[req.Method]; ok && !d.params.adminEnabled {
+		return nil, nil, NewMethodNotFoundError(req.Method)
+	}
+
 	serviceName, funcName := callName[0], callName[1]
 
 	service, ok := d.serviceMap[serviceName]
@@ -330,6 +358,11 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 		return nil, ferr
 	}
 
+	// only the registered methods are labeled to keep the number of series bounded
+	labels := []metrics.Label{{Name: "method", Value: req.Method}}
+
+	defer metrics.MeasureSinceWithLabels([]string{jsonrpcMetrics, "request_duration"}, time.Now(), labels)
+
 	inArgs := make([]reflect.Value, fd.inNum)
 	inArgs[0] = service.sv
 
@@ -343,6 +376,8 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 
 	if fd.numParams() > 0 {
 		if err := json.Unmarshal(req.Params, &inputs); err != nil {
+			metrics.IncrCounterWithLabels([]string{jsonrpcMetrics, "request_errors"}, 1, labels)
+
 			return nil, NewInvalidParamsError("Invalid Params")
 		}
 	}
@@ -350,6 +385,7 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 	output := fd.fv.Call(inArgs)
 	if err := getError(output[1]); err != nil {
 		d.logInternalError(req.Method, err)
+		metrics.IncrCounterWithLabels([]string{jsonrpcMetrics, "request_errors"}, 1, labels)
 
 		return nil, NewInvalidRequestError(err.Error())
 	}
@@ -363,6 +399,7 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 		data, err = json.Marshal(res)
 		if err != nil {
 			d.logInternalError(req.Method, err)
+			metrics.IncrCounterWithLabels([]string{jsonrpcMetrics, "request_errors"}, 1, labels)
 
 			return nil, NewInternalError("Internal error")
 		}
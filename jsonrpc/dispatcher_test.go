@@ -7,7 +7,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/helper/tests"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
 )
@@ -288,6 +290,43 @@ func TestDispatcherFuncDecode(t *testing.T) {
 	}
 }
 
+func TestDispatcher_Metrics(t *testing.T) {
+	// the metrics are global, the test can't run in parallel
+	sink := tests.NewMetricsSink(t)
+
+	srv := &mockService{msgCh: make(chan interface{}, 10)}
+
+	dispatcher := newDispatcher(
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			jsonRPCBatchLengthLimit: 20,
+			blockRangeLimit:         1000,
+		},
+	)
+	dispatcher.registerService("mock", srv)
+
+	_, err := dispatcher.handleReq(Request{Method: "mock_block", Params: []byte(`["latest"]`)})
+	assert.NoError(t, err)
+
+	_, err = dispatcher.handleReq(Request{Method: "mock_block", Params: []byte(`[{}]`)})
+	assert.Error(t, err)
+
+	// the unknown methods are not recorded
+	_, err = dispatcher.handleReq(Request{Method: "mock_unknown", Params: []byte(`[]`)})
+	assert.Error(t, err)
+
+	labels := []metrics.Label{{Name: "method", Value: "mock_block"}}
+
+	assert.Equal(t, 2, sink.Samples([]string{jsonrpcMetrics, "request_duration"}, labels...))
+	assert.Equal(t, float64(1), sink.Counter([]string{jsonrpcMetrics, "request_errors"}, labels...))
+
+	unknown := metrics.Label{Name: "method", Value: "mock_unknown"}
+
+	assert.Zero(t, sink.Samples([]string{jsonrpcMetrics, "request_duration"}, unknown))
+	assert.Zero(t, sink.Counter([]string{jsonrpcMetrics, "request_errors"}, unknown))
+}
+
 func TestDispatcherBatchRequest(t *testing.T) {
 	handle := func(dispatcher *Dispatcher, reqBody []byte) []byte {
 		res, _ := dispatcher.Handle(reqBody)
@@ -455,3 +494,65 @@ func TestDispatcherBatchRequest(t *testing.T) {
 		}
 	}
 }
+
+// setHeadMockStore is the store recording the number of the rewinds
+type setHeadMockStore struct {
+	*mockStore
+
+	heads []uint64
+}
+
+func (m *setHeadMockStore) SetHead(number uint64) error {
+	m.heads = append(m.heads, number)
+
+	return nil
+}
+
+func TestDispatcher_AdminMethods(t *testing.T) {
+	t.Parallel()
+
+	req := []byte(`{"id":1,"jsonrpc":"2.0","method":"debug_setHead","params":["0x5"]}`)
+
+	newTestDispatcher := func(store JSONRPCStore, adminEnabled bool) *Dispatcher {
+		return newDispatcher(
+			hclog.NewNullLogger(),
+			store,
+			&dispatcherParams{
+				jsonRPCBatchLengthLimit: 20,
+				blockRangeLimit:         1000,
+				adminEnabled:            adminEnabled,
+			},
+		)
+	}
+
+	t.Run("admin methods are not available by default", func(t *testing.T) {
+		t.Parallel()
+
+		store := &setHeadMockStore{mockStore: newMockStore()}
+
+		res, err := newTestDispatcher(store, false).Handle(req)
+		assert.NoError(t, err)
+
+		var result interface{}
+
+		var objErr *ObjectError
+
+		assert.ErrorAs(t, expectJSONResult(res, &result), &objErr)
+		assert.Equal(t, -32601, objErr.Code)
+		assert.Empty(t, store.heads)
+	})
+
+	t.Run("admin methods are available when enabled", func(t *testing.T) {
+		t.Parallel()
+
+		store := &setHeadMockStore{mockStore: newMockStore()}
+
+		res, err := newTestDispatcher(store, true).Handle(req)
+		assert.NoError(t, err)
+
+		var result interface{}
+
+		assert.NoError(t, expectJSONResult(res, &result))
+		assert.Equal(t, []uint64{5}, store.heads)
+	})
+}
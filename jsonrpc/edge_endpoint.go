@@ -0,0 +1,145 @@
+package jsonrpc
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// defaultAddressTxsLimit is the number of the transactions returned if the limit is not given
+	defaultAddressTxsLimit = 100
+
+	// maxAddressTxsLimit is the maximum number of the transactions returned at once
+	maxAddressTxsLimit = 1000
+
+	// txCursorLength is the length of the encoded cursor, the block number and the transaction index
+	txCursorLength = 16
+)
+
+var (
+	// ErrAddressIndexUnavailable is an error returned when the node doesn't maintain the address index
+	ErrAddressIndexUnavailable = errors.New("address index is not enabled on the node")
+
+	errInvalidTxCursor = errors.New("invalid cursor")
+	errInvalidTxsLimit = fmt.Errorf("limit must be between 1 and %d", maxAddressTxsLimit)
+)
+
+// TxCursor is the position of the first transaction to be returned
+type TxCursor struct {
+	Number uint64
+	Index  uint64
+}
+
+// AddressTransactions is a page of the transactions sent or received by an address
+type AddressTransactions struct {
+	Transactions []*AddressTransaction
+	// Next is the cursor of the next page, nil if there are no more transactions
+	Next *TxCursor
+	// IndexedFrom is the lowest number of the blocks from which the index is complete
+	IndexedFrom uint64
+}
+
+// AddressTransaction is the transaction with its position in the chain
+type AddressTransaction struct {
+	Transaction *types.Transaction
+	BlockNumber uint64
+	BlockHash   types.Hash
+	Index       uint64
+}
+
+// edgeStore provides access to the methods needed by edge endpoint
+type edgeStore interface {
+	// GetTransactionsByAddress returns at most limit transactions sent or received by the address from the cursor
+	GetTransactionsByAddress(addr types.Address, cursor TxCursor, limit uint64) (*AddressTransactions, error)
+}
+
+// Edge is the edge jsonrpc endpoint for the data indexed by the node
+type Edge struct {
+	store edgeStore
+}
+
+type addressTransactionsResponse struct {
+	Transactions []*transaction `json:"transactions"`
+	NextCursor   *string        `json:"nextCursor"`
+	IndexedFrom  argUint64      `json:"indexedFrom"`
+}
+
+// GetTransactionsByAddress returns the transactions sent or received by the address, including the contract creations,
+// in the order of the blocks. The next page is requested with the cursor returned in the response
+func (e *Edge) GetTransactionsByAddress(
+	address types.Address,
+	cursor *string,
+	limit *argUint64,
+) (interface{}, error) {
+	from := TxCursor{}
+
+	if cursor != nil {
+		var err error
+
+		if from, err = decodeTxCursor(*cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	count := uint64(defaultAddressTxsLimit)
+
+	if limit != nil {
+		count = uint64(*limit)
+
+		if count == 0 || count > maxAddressTxsLimit {
+			return nil, errInvalidTxsLimit
+		}
+	}
+
+	txs, err := e.store.GetTransactionsByAddress(address, from, count)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &addressTransactionsResponse{
+		Transactions: make([]*transaction, len(txs.Transactions)),
+		IndexedFrom:  argUint64(txs.IndexedFrom),
+	}
+
+	for idx, tx := range txs.Transactions {
+		blockNumber := argUint64(tx.BlockNumber)
+		blockHash := tx.BlockHash
+		txIndex := int(tx.Index)
+
+		res.Transactions[idx] = toTransaction(tx.Transaction, &blockNumber, &blockHash, &txIndex)
+	}
+
+	if txs.Next != nil {
+		next := encodeTxCursor(*txs.Next)
+		res.NextCursor = &next
+	}
+
+	return res, nil
+}
+
+// encodeTxCursor encodes the cursor as the hex of the block number and the transaction index
+func encodeTxCursor(cursor TxCursor) string {
+	buf := make([]byte, txCursorLength)
+
+	binary.BigEndian.PutUint64(buf[:8], cursor.Number)
+	binary.BigEndian.PutUint64(buf[8:], cursor.Index)
+
+	return hex.EncodeToHex(buf)
+}
+
+// decodeTxCursor decodes the cursor returned by encodeTxCursor
+func decodeTxCursor(str string) (TxCursor, error) {
+	buf, err := hex.DecodeHex(str)
+	if err != nil || len(buf) != txCursorLength {
+		return TxCursor{}, errInvalidTxCursor
+	}
+
+	return TxCursor{
+		Number: binary.BigEndian.Uint64(buf[:8]),
+		Index:  binary.BigEndian.Uint64(buf[8:]),
+	}, nil
+}
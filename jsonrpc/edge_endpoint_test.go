@@ -0,0 +1,158 @@
+package jsonrpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockEdgeStore struct {
+	txs []*AddressTransaction
+	err error
+
+	// the arguments of the last call
+	cursor TxCursor
+	limit  uint64
+}
+
+func (m *mockEdgeStore) GetTransactionsByAddress(
+	_ types.Address,
+	cursor TxCursor,
+	limit uint64,
+) (*AddressTransactions, error) {
+	m.cursor = cursor
+	m.limit = limit
+
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	res := &AddressTransactions{IndexedFrom: 3}
+
+	for _, tx := range m.txs {
+		if tx.BlockNumber < cursor.Number || (tx.BlockNumber == cursor.Number && tx.Index < cursor.Index) {
+			continue
+		}
+
+		if uint64(len(res.Transactions)) == limit {
+			res.Next = &TxCursor{Number: tx.BlockNumber, Index: tx.Index}
+
+			break
+		}
+
+		res.Transactions = append(res.Transactions, tx)
+	}
+
+	return res, nil
+}
+
+func TestEdgeEndpoint_GetTransactionsByAddress(t *testing.T) {
+	t.Parallel()
+
+	addr := types.StringToAddress("1")
+
+	newAddressTx := func(number, index uint64) *AddressTransaction {
+		tx := &types.Transaction{
+			Nonce:    number,
+			From:     addr,
+			GasPrice: big.NewInt(1),
+			Value:    big.NewInt(1),
+			V:        big.NewInt(1),
+			R:        big.NewInt(1),
+			S:        big.NewInt(1),
+		}
+		tx.ComputeHash()
+
+		return &AddressTransaction{
+			Transaction: tx,
+			BlockNumber: number,
+			BlockHash:   types.StringToHash("block"),
+			Index:       index,
+		}
+	}
+
+	txs := []*AddressTransaction{
+		newAddressTx(5, 0),
+		newAddressTx(5, 2),
+		newAddressTx(9, 1),
+	}
+
+	t.Run("pages the transactions with the cursor", func(t *testing.T) {
+		t.Parallel()
+
+		store := &mockEdgeStore{txs: txs}
+		endpoint := &Edge{store}
+
+		limit := argUint64(2)
+
+		result, err := endpoint.GetTransactionsByAddress(addr, nil, &limit)
+		require.NoError(t, err)
+
+		res, ok := result.(*addressTransactionsResponse)
+		require.True(t, ok)
+
+		assert.Len(t, res.Transactions, 2)
+		assert.Equal(t, txs[0].Transaction.Hash, res.Transactions[0].Hash)
+		assert.Equal(t, argUint64(5), *res.Transactions[1].BlockNumber)
+		assert.Equal(t, argUint64(2), *res.Transactions[1].TxIndex)
+		assert.Equal(t, argUint64(3), res.IndexedFrom)
+		require.NotNil(t, res.NextCursor)
+
+		result, err = endpoint.GetTransactionsByAddress(addr, res.NextCursor, &limit)
+		require.NoError(t, err)
+
+		assert.Equal(t, TxCursor{Number: 9, Index: 1}, store.cursor)
+
+		res, ok = result.(*addressTransactionsResponse)
+		require.True(t, ok)
+
+		assert.Len(t, res.Transactions, 1)
+		assert.Equal(t, txs[2].Transaction.Hash, res.Transactions[0].Hash)
+		assert.Nil(t, res.NextCursor)
+	})
+
+	t.Run("uses the default limit", func(t *testing.T) {
+		t.Parallel()
+
+		store := &mockEdgeStore{txs: txs}
+		endpoint := &Edge{store}
+
+		_, err := endpoint.GetTransactionsByAddress(addr, nil, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(defaultAddressTxsLimit), store.limit)
+	})
+
+	t.Run("rejects the invalid arguments", func(t *testing.T) {
+		t.Parallel()
+
+		endpoint := &Edge{&mockEdgeStore{txs: txs}}
+
+		for _, limit := range []argUint64{0, maxAddressTxsLimit + 1} {
+			limit := limit
+
+			_, err := endpoint.GetTransactionsByAddress(addr, nil, &limit)
+			assert.ErrorIs(t, err, errInvalidTxsLimit)
+		}
+
+		for _, cursor := range []string{"0x1234", "cursor"} {
+			cursor := cursor
+
+			_, err := endpoint.GetTransactionsByAddress(addr, &cursor, nil)
+			assert.ErrorIs(t, err, errInvalidTxCursor)
+		}
+	})
+
+	t.Run("returns the error from the store", func(t *testing.T) {
+		t.Parallel()
+
+		endpoint := &Edge{&mockEdgeStore{err: ErrAddressIndexUnavailable}}
+
+		result, err := endpoint.GetTransactionsByAddress(addr, nil, nil)
+		assert.ErrorIs(t, err, ErrAddressIndexUnavailable)
+		assert.Nil(t, result)
+	})
+}
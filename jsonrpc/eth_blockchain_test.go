@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/bloombits"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -348,6 +349,9 @@ type mockBlockStore struct {
 	isSyncing       bool
 	averageGasPrice int64
 	ethCallError    error
+
+	// bloomBits are the bitsets of the indexed sections matching the queries
+	bloomBits map[uint64][]byte
 }
 
 func newMockBlockStore() *mockBlockStore {
@@ -525,6 +529,12 @@ func (m *mockBlockStore) SubscribeEvents() blockchain.Subscription {
 	return nil
 }
 
+func (m *mockBlockStore) MatchBloomBits(section uint64, _ *bloombits.Matcher) ([]byte, bool, error) {
+	bitset, ok := m.bloomBits[section]
+
+	return bitset, ok, nil
+}
+
 func newTestBlock(number uint64, hash types.Hash) *types.Block {
 	return &types.Block{
 		Header: &types.Header{
@@ -5,12 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/bloombits"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
@@ -176,6 +178,22 @@ func (f *logFilter) appendLog(log *Log) {
 	f.logs = append(f.logs, log)
 }
 
+// dropLogsAbove removes the stored logs of the blocks above the number
+func (f *logFilter) dropLogsAbove(number uint64) {
+	f.Lock()
+	defer f.Unlock()
+
+	logs := make([]*Log, 0, len(f.logs))
+
+	for _, log := range f.logs {
+		if uint64(log.BlockNumber) <= number {
+			logs = append(logs, log)
+		}
+	}
+
+	f.logs = logs
+}
+
 // takeLogUpdates returns all saved logs in filter and set new log slice
 func (f *logFilter) takeLogUpdates() []*Log {
 	f.Lock()
@@ -228,6 +246,10 @@ type filterManagerStore interface {
 
 	// GetBlockByNumber returns a block using the provided number
 	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
+
+	// MatchBloomBits returns the bitset of the blocks of the section which may contain the logs matching the filter,
+	// false if the section is not indexed
+	MatchBloomBits(section uint64, matcher *bloombits.Matcher) ([]byte, bool, error)
 }
 
 // FilterManager manages all running filters
@@ -243,6 +265,11 @@ type FilterManager struct {
 	blockStream     *blockStream
 	blockRangeLimit uint64
 
+	// indexedBlockRangeLimit is the block range limit of the queries filtered by the addresses or the topics,
+	// the blocks which can't contain the matching logs are skipped with the bloom bits index.
+	// The blocks not covered by the index are still limited by blockRangeLimit
+	indexedBlockRangeLimit uint64
+
 	filters  map[string]filter
 	timeouts timeHeapImpl
 
@@ -250,16 +277,22 @@ type FilterManager struct {
 	closeCh  chan struct{}
 }
 
-func NewFilterManager(logger hclog.Logger, store filterManagerStore, blockRangeLimit uint64) *FilterManager {
+func NewFilterManager(
+	logger hclog.Logger,
+	store filterManagerStore,
+	blockRangeLimit uint64,
+	indexedBlockRangeLimit uint64,
+) *FilterManager {
 	m := &FilterManager{
-		logger:          logger.Named("filter"),
-		timeout:         defaultTimeout,
-		store:           store,
-		blockRangeLimit: blockRangeLimit,
-		filters:         make(map[string]filter),
-		timeouts:        timeHeapImpl{},
-		updateCh:        make(chan struct{}),
-		closeCh:         make(chan struct{}),
+		logger:                 logger.Named("filter"),
+		timeout:                defaultTimeout,
+		store:                  store,
+		blockRangeLimit:        blockRangeLimit,
+		indexedBlockRangeLimit: indexedBlockRangeLimit,
+		filters:                make(map[string]filter),
+		timeouts:               timeHeapImpl{},
+		updateCh:               make(chan struct{}),
+		closeCh:                make(chan struct{}),
 	}
 
 	// start blockstream with the current header
@@ -417,14 +450,60 @@ func (f *FilterManager) getLogsFromBlocks(query *LogQuery) ([]*Log, error) {
 		from = 1
 	}
 
+	matcher := bloombits.NewMatcher(query.bloomFilter())
+
+	rangeLimit := f.blockRangeLimit
+	if !matcher.Empty() {
+		rangeLimit = f.indexedBlockRangeLimit
+	}
+
 	// if not disabled, avoid handling large block ranges
-	if f.blockRangeLimit != 0 && to-from > f.blockRangeLimit {
+	if rangeLimit != 0 && to-from > rangeLimit {
 		return nil, ErrBlockRangeTooHigh
 	}
 
+	var (
+		section = uint64(math.MaxUint64)
+		bitset  []byte
+		indexed bool
+
+		// number of the blocks in the range which are not covered by the indexed sections
+		unindexed uint64
+	)
+
 	logs := make([]*Log, 0)
 
 	for i := from; i <= to; i++ {
+		if !matcher.Empty() {
+			if s := i / bloombits.SectionSize; s != section {
+				section = s
+
+				if bitset, indexed, err = f.store.MatchBloomBits(section, matcher); err != nil {
+					return nil, err
+				}
+
+				// the blocks of the unindexed sections are scanned one by one,
+				// so they're limited by the block range limit of the unfiltered queries
+				if !indexed {
+					sectionEnd := (section+1)*bloombits.SectionSize - 1
+					if sectionEnd > to {
+						sectionEnd = to
+					}
+
+					unindexed += sectionEnd - i + 1
+
+					if f.blockRangeLimit != 0 && unindexed > f.blockRangeLimit+1 {
+						return nil, ErrBlockRangeTooHigh
+					}
+				}
+			}
+
+			// skip the block of the indexed section whose bloom doesn't match the query
+			if indexed && !bloombits.IsSet(bitset, i%bloombits.SectionSize) {
+				continue
+			}
+		}
+
 		block, ok := f.store.GetBlockByNumber(i, true)
 		if !ok {
 			break
@@ -634,6 +713,13 @@ func (f *FilterManager) processEvent(evnt *blockchain.Event) {
 	f.RLock()
 	defer f.RUnlock()
 
+	if evnt.Type == blockchain.EventRewind {
+		// the new head is not a new block, only the logs of the removed blocks are dropped
+		f.dropLogsAbove(evnt.Header().Number)
+
+		return
+	}
+
 	for _, header := range evnt.NewChain {
 		block := toBlock(&types.Block{Header: header}, false)
 
@@ -647,6 +733,15 @@ func (f *FilterManager) processEvent(evnt *blockchain.Event) {
 	}
 }
 
+// dropLogsAbove makes each LogFilters drop the stored logs of the blocks above the number
+func (f *FilterManager) dropLogsAbove(number uint64) {
+	for _, f := range f.filters {
+		if logFilter, ok := f.(*logFilter); ok {
+			logFilter.dropLogsAbove(number)
+		}
+	}
+}
+
 // appendLogsToFilters makes each LogFilters append logs in the header
 func (f *FilterManager) appendLogsToFilters(header *block) error {
 	receipts, err := f.store.GetReceiptsByHash(header.Hash)
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/bloombits"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-hclog"
@@ -127,7 +128,7 @@ func Test_GetLogsForQuery(t *testing.T) {
 
 	store.appendBlocksToStore(blocks)
 
-	f := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	f := NewFilterManager(hclog.NewNullLogger(), store, 1000, 1000)
 
 	t.Cleanup(func() {
 		defer f.Close()
@@ -154,12 +155,88 @@ func Test_GetLogsForQuery(t *testing.T) {
 	}
 }
 
+func Test_GetLogsForQuery_BloomBits(t *testing.T) {
+	t.Parallel()
+
+	topic := types.StringToHash("4")
+
+	store := &mockBlockStore{
+		topics: []types.Hash{topic},
+		// only the second block of the indexed section matches the query
+		bloomBits: map[uint64][]byte{
+			0: append([]byte{0x20}, make([]byte, bloombits.BitsetLength-1)...),
+		},
+	}
+	store.setupLogs()
+
+	for i := 0; i < 4; i++ {
+		store.add(&types.Block{
+			Header: &types.Header{
+				Number: uint64(i),
+				Hash:   types.StringToHash(strconv.Itoa(i)),
+			},
+			Transactions: []*types.Transaction{{}, {}, {}},
+		})
+	}
+
+	f := NewFilterManager(hclog.NewNullLogger(), store, 1000, 5000)
+
+	t.Cleanup(func() {
+		defer f.Close()
+	})
+
+	logs, err := f.GetLogsForQuery(&LogQuery{
+		fromBlock: 1,
+		toBlock:   3,
+		Topics:    [][]types.Hash{{topic}},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, logs, 1)
+	assert.Equal(t, argUint64(2), logs[0].BlockNumber)
+
+	// the query filtered by the topics is allowed the larger range
+	_, err = f.GetLogsForQuery(&LogQuery{
+		fromBlock: 1,
+		toBlock:   3000,
+		Topics:    [][]types.Hash{{topic}},
+	})
+	assert.NoError(t, err)
+
+	_, err = f.GetLogsForQuery(&LogQuery{
+		fromBlock: 1,
+		toBlock:   3000,
+	})
+	assert.ErrorIs(t, err, ErrBlockRangeTooHigh)
+
+	_, err = f.GetLogsForQuery(&LogQuery{
+		fromBlock: 1,
+		toBlock:   6000,
+		Topics:    [][]types.Hash{{topic}},
+	})
+	assert.ErrorIs(t, err, ErrBlockRangeTooHigh)
+
+	// the blocks of the unindexed section are limited by the range limit of the unfiltered queries
+	_, err = f.GetLogsForQuery(&LogQuery{
+		fromBlock: 3000,
+		toBlock:   bloombits.SectionSize + 1000,
+		Topics:    [][]types.Hash{{topic}},
+	})
+	assert.NoError(t, err)
+
+	_, err = f.GetLogsForQuery(&LogQuery{
+		fromBlock: 3000,
+		toBlock:   bloombits.SectionSize + 1001,
+		Topics:    [][]types.Hash{{topic}},
+	})
+	assert.ErrorIs(t, err, ErrBlockRangeTooHigh)
+}
+
 func Test_GetLogFilterFromID(t *testing.T) {
 	t.Parallel()
 
 	store := newMockStore()
 
-	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000, 1000)
 	defer m.Close()
 
 	go m.Run()
@@ -182,7 +259,7 @@ func TestFilterLog(t *testing.T) {
 
 	store := newMockStore()
 
-	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000, 1000)
 	defer m.Close()
 
 	go m.Run()
@@ -246,7 +323,7 @@ func TestFilterBlock(t *testing.T) {
 
 	store := newMockStore()
 
-	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000, 1000)
 	defer m.Close()
 
 	go m.Run()
@@ -306,12 +383,47 @@ func TestFilterBlock(t *testing.T) {
 	}
 }
 
+func TestFilterRewind(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000, 1000)
+	defer m.Close()
+
+	id := m.NewLogFilter(&LogQuery{}, nil)
+
+	logFilter, err := m.GetLogFilterFromID(id)
+	assert.NoError(t, err)
+
+	for _, number := range []argUint64{3, 4, 6} {
+		logFilter.appendLog(&Log{BlockNumber: number})
+	}
+
+	head := m.blockStream.getHead()
+
+	m.processEvent(&blockchain.Event{
+		Type: blockchain.EventRewind,
+		NewChain: []*types.Header{
+			{Number: 4},
+		},
+	})
+
+	// the rewind doesn't push the new head
+	assert.Equal(t, head, m.blockStream.getHead())
+
+	logs := logFilter.takeLogUpdates()
+	assert.Len(t, logs, 2)
+	assert.Equal(t, argUint64(3), logs[0].BlockNumber)
+	assert.Equal(t, argUint64(4), logs[1].BlockNumber)
+}
+
 func TestFilterTimeout(t *testing.T) {
 	t.Parallel()
 
 	store := newMockStore()
 
-	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000, 1000)
 	defer m.Close()
 
 	m.timeout = 2 * time.Second
@@ -333,7 +445,7 @@ func TestRemoveFilterByWebsocket(t *testing.T) {
 
 	mock, _ := newMockWsConnWithMsgCh()
 
-	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000, 1000)
 	defer m.Close()
 
 	go m.Run()
@@ -351,7 +463,7 @@ func Test_flushWsFilters(t *testing.T) {
 
 	store := newMockStore()
 
-	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000, 1000)
 
 	t.Cleanup(func() {
 		m.Close()
@@ -440,7 +552,7 @@ func TestFilterWebsocket(t *testing.T) {
 
 	mock, msgCh := newMockWsConnWithMsgCh()
 
-	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000, 1000)
 	defer m.Close()
 
 	go m.Run()
@@ -613,7 +725,7 @@ func TestClosedFilterDeletion(t *testing.T) {
 
 	store := newMockStore()
 
-	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000, 1000)
 	defer m.Close()
 
 	go m.Run()
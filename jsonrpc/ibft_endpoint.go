@@ -0,0 +1,82 @@
+package jsonrpc
+
+import (
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// ErrValidatorStatsUnavailable is an error returned when the consensus doesn't track the validators
+	ErrValidatorStatsUnavailable = errors.New("validator stats are not available in the consensus")
+)
+
+// ValidatorStats is the participation of the validators in the recent blocks
+type ValidatorStats struct {
+	// Window is the maximum number of the blocks to be tracked
+	Window uint64
+	// From and To are the range of the tracked blocks
+	From       uint64
+	To         uint64
+	Validators []*ValidatorParticipation
+}
+
+// ValidatorParticipation is the participation of the validator in the recent blocks
+type ValidatorParticipation struct {
+	Address        types.Address
+	ProposedBlocks uint64
+	CommittedSeals uint64
+	LastSeen       uint64
+}
+
+// ibftStore provides access to the methods needed by ibft endpoint
+type ibftStore interface {
+	// GetValidatorStats returns the participation of the validators in the recent blocks
+	GetValidatorStats() (*ValidatorStats, error)
+}
+
+// IBFT is the ibft jsonrpc endpoint
+type IBFT struct {
+	store ibftStore
+}
+
+type validatorStatsResponse struct {
+	Window     argUint64                         `json:"window"`
+	From       argUint64                         `json:"from"`
+	To         argUint64                         `json:"to"`
+	Validators []*validatorParticipationResponse `json:"validators"`
+}
+
+type validatorParticipationResponse struct {
+	Address        types.Address `json:"address"`
+	ProposedBlocks argUint64     `json:"proposedBlocks"`
+	CommittedSeals argUint64     `json:"committedSeals"`
+	LastSeen       argUint64     `json:"lastSeen"`
+}
+
+// GetValidatorStats returns the blocks proposed, the committed seals contributed
+// and the last seen height of the validators in the rolling window
+func (i *IBFT) GetValidatorStats() (interface{}, error) {
+	stats, err := i.store.GetValidatorStats()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &validatorStatsResponse{
+		Window:     argUint64(stats.Window),
+		From:       argUint64(stats.From),
+		To:         argUint64(stats.To),
+		Validators: make([]*validatorParticipationResponse, len(stats.Validators)),
+	}
+
+	for idx, v := range stats.Validators {
+		res.Validators[idx] = &validatorParticipationResponse{
+			Address:        v.Address,
+			ProposedBlocks: argUint64(v.ProposedBlocks),
+			CommittedSeals: argUint64(v.CommittedSeals),
+			LastSeen:       argUint64(v.LastSeen),
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,70 @@
+package jsonrpc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockIBFTStore struct {
+	stats *ValidatorStats
+	err   error
+}
+
+func (m *mockIBFTStore) GetValidatorStats() (*ValidatorStats, error) {
+	return m.stats, m.err
+}
+
+func TestIBFTEndpoint_GetValidatorStats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns the validator stats", func(t *testing.T) {
+		t.Parallel()
+
+		addr := types.StringToAddress("1")
+		endpoint := &IBFT{&mockIBFTStore{
+			stats: &ValidatorStats{
+				Window: 100,
+				From:   1,
+				To:     10,
+				Validators: []*ValidatorParticipation{
+					{
+						Address:        addr,
+						ProposedBlocks: 3,
+						CommittedSeals: 9,
+						LastSeen:       10,
+					},
+				},
+			},
+		}}
+
+		result, err := endpoint.GetValidatorStats()
+		assert.NoError(t, err)
+		assert.Equal(t, &validatorStatsResponse{
+			Window: 100,
+			From:   1,
+			To:     10,
+			Validators: []*validatorParticipationResponse{
+				{
+					Address:        addr,
+					ProposedBlocks: 3,
+					CommittedSeals: 9,
+					LastSeen:       10,
+				},
+			},
+		}, result)
+	})
+
+	t.Run("returns the error from the store", func(t *testing.T) {
+		t.Parallel()
+
+		storeErr := errors.New("failed")
+		endpoint := &IBFT{&mockIBFTStore{err: storeErr}}
+
+		result, err := endpoint.GetValidatorStats()
+		assert.ErrorIs(t, err, storeErr)
+		assert.Nil(t, result)
+	})
+}
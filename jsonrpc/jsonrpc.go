@@ -56,6 +56,8 @@ type JSONRPCStore interface {
 	txPoolStore
 	filterManagerStore
 	debugStore
+	ibftStore
+	edgeStore
 }
 
 type Config struct {
@@ -67,6 +69,8 @@ type Config struct {
 	PriceLimit               uint64
 	BatchLengthLimit         uint64
 	BlockRangeLimit          uint64
+	IndexedBlockRangeLimit   uint64
+	AdminEnabled             bool
 }
 
 // NewJSONRPC returns the JSONRPC http server
@@ -83,6 +87,8 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 				priceLimit:              config.PriceLimit,
 				jsonRPCBatchLengthLimit: config.BatchLengthLimit,
 				blockRangeLimit:         config.BlockRangeLimit,
+				indexedBlockRangeLimit:  config.IndexedBlockRangeLimit,
+				adminEnabled:            config.AdminEnabled,
 			},
 		),
 	}
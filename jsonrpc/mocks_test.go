@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/bloombits"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -161,6 +162,10 @@ func (m *mockStore) GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
 	return &types.Block{Header: header}, header != nil
 }
 
+func (m *mockStore) MatchBloomBits(uint64, *bloombits.Matcher) ([]byte, bool, error) {
+	return nil, false, nil
+}
+
 func (m *mockStore) GetTxs(inclQueued bool) (
 	map[types.Address][]*types.Transaction,
 	map[types.Address][]*types.Transaction,
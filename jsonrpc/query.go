@@ -213,3 +213,27 @@ func (q *LogQuery) Match(log *types.Log) bool {
 
 	return true
 }
+
+// bloomFilter returns the values of the query in the blooms of the blocks, grouped by the addresses
+// and the positions of the topics. A matching log has one of the values of every non-empty group
+func (q *LogQuery) bloomFilter() [][][]byte {
+	filter := make([][][]byte, 0, len(q.Topics)+1)
+
+	addresses := make([][]byte, len(q.Addresses))
+	for i, addr := range q.Addresses {
+		addresses[i] = addr.Bytes()
+	}
+
+	filter = append(filter, addresses)
+
+	for _, sub := range q.Topics {
+		topics := make([][]byte, len(sub))
+		for i, topic := range sub {
+			topics[i] = topic.Bytes()
+		}
+
+		filter = append(filter, topics)
+	}
+
+	return filter
+}
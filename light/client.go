@@ -0,0 +1,259 @@
+package light
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/light/proto"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/umbracle/fastrlp"
+)
+
+var (
+	ErrNoProofPeer         = errors.New("no peer returned a valid proof")
+	errInvalidCode         = errors.New("code doesn't match the code hash")
+	errMissingStorageProof = errors.New("storage proof is missing")
+)
+
+// ProofClient fetches the state from the peers and verifies it against the state root
+// in the verified header, so that the light client doesn't need to trust the peers
+type ProofClient struct {
+	logger  hclog.Logger
+	network Network
+	timeout time.Duration
+}
+
+// NewProofClient creates ProofClient
+func NewProofClient(logger hclog.Logger, network Network, timeout time.Duration) *ProofClient {
+	return &ProofClient{
+		logger:  logger.Named("proof-client"),
+		network: network,
+		timeout: timeout,
+	}
+}
+
+// GetAccount returns the verified account at the given state root, nil if the account doesn't exist
+func (c *ProofClient) GetAccount(root types.Hash, addr types.Address) (*state.Account, error) {
+	account, _, err := c.getProof(root, addr, nil)
+
+	return account, err
+}
+
+// GetStorage returns the verified storage value of the account at the given state root
+func (c *ProofClient) GetStorage(root types.Hash, addr types.Address, slot types.Hash) (types.Hash, error) {
+	_, values, err := c.getProof(root, addr, []types.Hash{slot})
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	return values[0], nil
+}
+
+// GetCode returns the verified code of the account at the given state root
+func (c *ProofClient) GetCode(root types.Hash, addr types.Address) ([]byte, error) {
+	account, err := c.GetAccount(root, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if account == nil {
+		return nil, nil
+	}
+
+	codeHash := types.BytesToHash(account.CodeHash)
+
+	res, err := c.requestPeers(func(ctx context.Context, clt proto.LightClient) (interface{}, error) {
+		res, err := clt.GetCode(ctx, &proto.GetCodeRequest{
+			CodeHash: codeHash.Bytes(),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if !bytes.Equal(crypto.Keccak256(res.Code), codeHash.Bytes()) {
+			return nil, errInvalidCode
+		}
+
+		return res.Code, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	code, _ := res.([]byte)
+
+	return code, nil
+}
+
+// verifiedProof is the account and the storage values verified by the proofs
+type verifiedProof struct {
+	account *state.Account
+	values  []types.Hash
+}
+
+// getProof requests the account and storage proofs and returns the verified values
+func (c *ProofClient) getProof(
+	root types.Hash,
+	addr types.Address,
+	slots []types.Hash,
+) (*state.Account, []types.Hash, error) {
+	storageKeys := make([][]byte, len(slots))
+	for idx, slot := range slots {
+		storageKeys[idx] = slot.Bytes()
+	}
+
+	res, err := c.requestPeers(func(ctx context.Context, clt proto.LightClient) (interface{}, error) {
+		res, err := clt.GetProof(ctx, &proto.GetProofRequest{
+			StateRoot:   root.Bytes(),
+			Address:     addr.Bytes(),
+			StorageKeys: storageKeys,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		account, values, err := verifyProof(root, addr, slots, res)
+		if err != nil {
+			return nil, err
+		}
+
+		return &verifiedProof{account: account, values: values}, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	proof, _ := res.(*verifiedProof)
+
+	return proof.account, proof.values, nil
+}
+
+// peerHandler requests the data from the peer and returns it once it's verified
+type peerHandler func(ctx context.Context, clt proto.LightClient) (interface{}, error)
+
+// peerResult is the result of peerHandler
+type peerResult struct {
+	res interface{}
+	err error
+}
+
+// requestPeers calls the handler with the connected peers in turn until one of them succeeds
+func (c *ProofClient) requestPeers(handler peerHandler) (interface{}, error) {
+	for _, p := range c.network.Peers() {
+		res, err := c.requestPeer(p.Info.ID, handler)
+		if err != nil {
+			c.logger.Debug("failed to get the proof from peer", "peer", p.Info.ID, "error", err)
+
+			continue
+		}
+
+		return res, nil
+	}
+
+	return nil, ErrNoProofPeer
+}
+
+// requestPeer calls the handler with the given peer, failing if it doesn't respond in time.
+// The request is canceled on the timeout and the connection is closed on return
+func (c *ProofClient) requestPeer(peerID peer.ID, handler peerHandler) (interface{}, error) {
+	conn, err := c.network.NewProtoConnection(lightProto, peerID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	// the channel is buffered so that the handler doesn't block after the timeout
+	resultCh := make(chan peerResult, 1)
+
+	go func() {
+		res, err := handler(ctx, proto.NewLightClient(conn))
+
+		resultCh <- peerResult{res: res, err: err}
+	}()
+
+	select {
+	case result := <-resultCh:
+		return result.res, result.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("timeout after %s", c.timeout)
+	}
+}
+
+// verifyProof verifies the proofs in the response against the state root
+func verifyProof(
+	root types.Hash,
+	addr types.Address,
+	slots []types.Hash,
+	res *proto.Proof,
+) (*state.Account, []types.Hash, error) {
+	rawAccount, err := itrie.VerifyProof(root, crypto.Keccak256(addr.Bytes()), res.AccountProof)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values := make([]types.Hash, len(slots))
+
+	// the storage of non-existent account is empty
+	if rawAccount == nil {
+		return nil, values, nil
+	}
+
+	account := &state.Account{}
+	if err := account.UnmarshalRlp(rawAccount); err != nil {
+		return nil, nil, err
+	}
+
+	if len(res.StorageProofs) != len(slots) {
+		return nil, nil, errMissingStorageProof
+	}
+
+	for idx, slot := range slots {
+		rawValue, err := itrie.VerifyProof(
+			account.Root,
+			crypto.Keccak256(slot.Bytes()),
+			res.StorageProofs[idx].Proof,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if rawValue == nil {
+			continue
+		}
+
+		if values[idx], err = decodeStorageValue(rawValue); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return account, values, nil
+}
+
+// decodeStorageValue decodes the RLP encoded value in the storage trie
+func decodeStorageValue(raw []byte) (types.Hash, error) {
+	p := &fastrlp.Parser{}
+
+	v, err := p.Parse(raw)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	value, err := v.GetBytes(nil)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	return types.BytesToHash(value), nil
+}
@@ -0,0 +1,159 @@
+package light
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/light/proto"
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const bufSize = 1024 * 1024
+
+// mockProofNetwork connects to the light servers of the peers in the memory
+type mockProofNetwork struct {
+	peers     []*network.PeerConnInfo
+	listeners map[peer.ID]*bufconn.Listener
+}
+
+func newMockProofNetwork(t *testing.T, servers map[peer.ID]proto.LightServer, order ...peer.ID) *mockProofNetwork {
+	t.Helper()
+
+	m := &mockProofNetwork{
+		peers:     make([]*network.PeerConnInfo, 0, len(order)),
+		listeners: make(map[peer.ID]*bufconn.Listener, len(servers)),
+	}
+
+	for _, id := range order {
+		lis := bufconn.Listen(bufSize)
+		s := grpc.NewServer()
+		proto.RegisterLightServer(s, servers[id])
+
+		go func() {
+			_ = s.Serve(lis)
+		}()
+
+		t.Cleanup(s.Stop)
+
+		m.peers = append(m.peers, &network.PeerConnInfo{Info: peer.AddrInfo{ID: id}})
+		m.listeners[id] = lis
+	}
+
+	return m
+}
+
+func (m *mockProofNetwork) RegisterProtocol(string, network.Protocol) {}
+
+func (m *mockProofNetwork) Peers() []*network.PeerConnInfo {
+	return m.peers
+}
+
+func (m *mockProofNetwork) NewProtoConnection(_ string, peerID peer.ID) (*grpc.ClientConn, error) {
+	lis := m.listeners[peerID]
+
+	return grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(
+			func(ctx context.Context, address string) (net.Conn, error) {
+				return lis.Dial()
+			},
+		),
+	)
+}
+
+// stalledLightServer doesn't respond until the request is canceled
+type stalledLightServer struct {
+	proto.UnimplementedLightServer
+}
+
+func (s *stalledLightServer) GetProof(ctx context.Context, _ *proto.GetProofRequest) (*proto.Proof, error) {
+	<-ctx.Done()
+
+	return nil, ctx.Err()
+}
+
+func (s *stalledLightServer) GetCode(ctx context.Context, _ *proto.GetCodeRequest) (*proto.Code, error) {
+	<-ctx.Done()
+
+	return nil, ctx.Err()
+}
+
+// forgingLightServer returns the code which doesn't match the hash
+type forgingLightServer struct {
+	*proofService
+}
+
+func (s *forgingLightServer) GetCode(context.Context, *proto.GetCodeRequest) (*proto.Code, error) {
+	return &proto.Code{Code: []byte{0x1}}, nil
+}
+
+func TestProofClient(t *testing.T) {
+	t.Parallel()
+
+	service, root := newTestProofService(t)
+
+	var (
+		stalled = peer.ID("stalled")
+		forging = peer.ID("forging")
+		honest  = peer.ID("honest")
+	)
+
+	servers := map[peer.ID]proto.LightServer{
+		stalled: &stalledLightServer{},
+		forging: &forgingLightServer{proofService: service},
+		honest:  service,
+	}
+
+	t.Run("should skip the peers timing out or returning invalid data", func(t *testing.T) {
+		t.Parallel()
+
+		client := NewProofClient(
+			hclog.NewNullLogger(),
+			newMockProofNetwork(t, servers, stalled, forging, honest),
+			100*time.Millisecond,
+		)
+
+		account, err := client.GetAccount(root, testAddress)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(5), account.Nonce)
+
+		value, err := client.GetStorage(root, testAddress, testStorageSlot)
+		assert.NoError(t, err)
+		assert.Equal(t, testStorageVal, value)
+
+		code, err := client.GetCode(root, testAddress)
+		assert.NoError(t, err)
+		assert.Equal(t, testCode, code)
+	})
+
+	t.Run("should fail if no peer returns valid data", func(t *testing.T) {
+		t.Parallel()
+
+		client := NewProofClient(
+			hclog.NewNullLogger(),
+			newMockProofNetwork(t, servers, stalled),
+			100*time.Millisecond,
+		)
+
+		_, err := client.GetAccount(root, testAddress)
+		assert.ErrorIs(t, err, ErrNoProofPeer)
+
+		// the forging peer proves the account but not the code
+		client = NewProofClient(
+			hclog.NewNullLogger(),
+			newMockProofNetwork(t, servers, stalled, forging),
+			100*time.Millisecond,
+		)
+
+		_, err = client.GetCode(root, testAddress)
+		assert.ErrorIs(t, err, ErrNoProofPeer)
+	})
+}
@@ -0,0 +1,137 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion7
+
+// LightClient is the client API for Light service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LightClient interface {
+	// Returns the merkle proofs of the account and its storage slots
+	GetProof(ctx context.Context, in *GetProofRequest, opts ...grpc.CallOption) (*Proof, error)
+	// Returns the contract code by its hash
+	GetCode(ctx context.Context, in *GetCodeRequest, opts ...grpc.CallOption) (*Code, error)
+}
+
+type lightClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLightClient(cc grpc.ClientConnInterface) LightClient {
+	return &lightClient{cc}
+}
+
+func (c *lightClient) GetProof(ctx context.Context, in *GetProofRequest, opts ...grpc.CallOption) (*Proof, error) {
+	out := new(Proof)
+	err := c.cc.Invoke(ctx, "/v1.Light/GetProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightClient) GetCode(ctx context.Context, in *GetCodeRequest, opts ...grpc.CallOption) (*Code, error) {
+	out := new(Code)
+	err := c.cc.Invoke(ctx, "/v1.Light/GetCode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LightServer is the server API for Light service.
+// All implementations must embed UnimplementedLightServer
+// for forward compatibility
+type LightServer interface {
+	// Returns the merkle proofs of the account and its storage slots
+	GetProof(context.Context, *GetProofRequest) (*Proof, error)
+	// Returns the contract code by its hash
+	GetCode(context.Context, *GetCodeRequest) (*Code, error)
+	mustEmbedUnimplementedLightServer()
+}
+
+// UnimplementedLightServer must be embedded to have forward compatible implementations.
+type UnimplementedLightServer struct {
+}
+
+func (UnimplementedLightServer) GetProof(context.Context, *GetProofRequest) (*Proof, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProof not implemented")
+}
+func (UnimplementedLightServer) GetCode(context.Context, *GetCodeRequest) (*Code, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCode not implemented")
+}
+func (UnimplementedLightServer) mustEmbedUnimplementedLightServer() {}
+
+// UnsafeLightServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LightServer will
+// result in compilation errors.
+type UnsafeLightServer interface {
+	mustEmbedUnimplementedLightServer()
+}
+
+func RegisterLightServer(s grpc.ServiceRegistrar, srv LightServer) {
+	s.RegisterService(&_Light_serviceDesc, srv)
+}
+
+func _Light_GetProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightServer).GetProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Light/GetProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightServer).GetProof(ctx, req.(*GetProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Light_GetCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightServer).GetCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Light/GetCode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightServer).GetCode(ctx, req.(*GetCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Light_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "v1.Light",
+	HandlerType: (*LightServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetProof",
+			Handler:    _Light_GetProof_Handler,
+		},
+		{
+			MethodName: "GetCode",
+			Handler:    _Light_GetCode_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "light/proto/light.proto",
+}
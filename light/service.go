@@ -0,0 +1,128 @@
+package light
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/light/proto"
+	"github.com/0xPolygon/polygon-edge/network/grpc"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	lightProto = "/light/0.1"
+
+	// maxStorageKeys is the max number of storage slots proved in a request
+	maxStorageKeys = 256
+)
+
+var (
+	ErrCodeNotFound       = errors.New("code not found")
+	ErrTooManyStorageKeys = fmt.Errorf("number of storage keys exceeds the limit %d", maxStorageKeys)
+)
+
+type proofService struct {
+	proto.UnimplementedLightServer
+
+	state   State            // reference to the state
+	network Network          // reference to the network module
+	stream  *grpc.GrpcStream // reference to the grpc stream
+}
+
+// NewProofService creates the service serving the state proofs to light clients
+func NewProofService(
+	network Network,
+	state State,
+) ProofService {
+	return &proofService{
+		state:   state,
+		network: network,
+	}
+}
+
+// Start starts proofService
+func (s *proofService) Start() {
+	s.setupGRPCServer()
+}
+
+// Close closes proofService
+func (s *proofService) Close() error {
+	return s.stream.Close()
+}
+
+// setupGRPCServer setup GRPC server
+func (s *proofService) setupGRPCServer() {
+	s.stream = grpc.NewGrpcStream()
+
+	proto.RegisterLightServer(s.stream.GrpcServer(), s)
+	s.stream.Serve()
+	s.network.RegisterProtocol(lightProto, s.stream)
+}
+
+// GetProof is a gRPC endpoint to return the merkle proofs of the account and its storage slots
+func (s *proofService) GetProof(_ context.Context, req *proto.GetProofRequest) (*proto.Proof, error) {
+	if len(req.StorageKeys) > maxStorageKeys {
+		return nil, ErrTooManyStorageKeys
+	}
+
+	root := types.BytesToHash(req.StateRoot)
+	key := crypto.Keccak256(types.BytesToAddress(req.Address).Bytes())
+
+	accountProof, err := s.state.GetProof(root, key)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &proto.Proof{
+		AccountProof:  accountProof,
+		StorageProofs: make([]*proto.StorageProof, 0, len(req.StorageKeys)),
+	}
+
+	if len(req.StorageKeys) == 0 {
+		return res, nil
+	}
+
+	rawAccount, err := itrie.VerifyProof(root, key, accountProof)
+	if err != nil {
+		return nil, err
+	}
+
+	// the absence of the account proves the storage slots are empty
+	if rawAccount == nil {
+		return res, nil
+	}
+
+	var account state.Account
+	if err := account.UnmarshalRlp(rawAccount); err != nil {
+		return nil, err
+	}
+
+	for _, storageKey := range req.StorageKeys {
+		proof, err := s.state.GetProof(account.Root, crypto.Keccak256(types.BytesToHash(storageKey).Bytes()))
+		if err != nil {
+			return nil, err
+		}
+
+		res.StorageProofs = append(res.StorageProofs, &proto.StorageProof{
+			Proof: proof,
+		})
+	}
+
+	return res, nil
+}
+
+// GetCode is a gRPC endpoint to return the contract code by its hash
+func (s *proofService) GetCode(_ context.Context, req *proto.GetCodeRequest) (*proto.Code, error) {
+	code, ok := s.state.GetCode(types.BytesToHash(req.CodeHash))
+	if !ok {
+		return nil, ErrCodeNotFound
+	}
+
+	return &proto.Code{
+		Code: code,
+	}, nil
+}
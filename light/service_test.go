@@ -0,0 +1,159 @@
+package light
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/light/proto"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testAddress     = types.StringToAddress("0x1")
+	testStorageSlot = types.StringToHash("0x2")
+	testStorageVal  = types.StringToHash("0x3")
+	testCode        = []byte{0x60, 0x00, 0x60, 0x00}
+)
+
+func newTestProofService(t *testing.T) (*proofService, types.Hash) {
+	t.Helper()
+
+	st := itrie.NewState(itrie.NewMemoryStorage())
+
+	codeHash := types.BytesToHash(crypto.Keccak256(testCode))
+	st.SetCode(codeHash, testCode)
+
+	_, root := st.NewSnapshot().Commit([]*state.Object{
+		{
+			Address:  testAddress,
+			Balance:  big.NewInt(100),
+			Nonce:    5,
+			Root:     types.EmptyRootHash,
+			CodeHash: codeHash,
+			Storage: []*state.StorageObject{
+				{
+					Key: testStorageSlot.Bytes(),
+					Val: testStorageVal.Bytes(),
+				},
+			},
+		},
+	})
+
+	return &proofService{state: st}, types.BytesToHash(root)
+}
+
+func Test_proofService_GetProof(t *testing.T) {
+	t.Parallel()
+
+	service, root := newTestProofService(t)
+
+	tests := []struct {
+		name            string
+		address         types.Address
+		slots           []types.Hash
+		expectedAccount bool
+		expectedValues  []types.Hash
+	}{
+		{
+			name:            "should prove the account and its storage",
+			address:         testAddress,
+			slots:           []types.Hash{testStorageSlot, types.StringToHash("0x4")},
+			expectedAccount: true,
+			expectedValues:  []types.Hash{testStorageVal, types.ZeroHash},
+		},
+		{
+			name:            "should prove the absence of the account",
+			address:         types.StringToAddress("0x5"),
+			slots:           []types.Hash{testStorageSlot},
+			expectedAccount: false,
+			expectedValues:  []types.Hash{types.ZeroHash},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			storageKeys := make([][]byte, len(test.slots))
+			for idx, slot := range test.slots {
+				storageKeys[idx] = slot.Bytes()
+			}
+
+			res, err := service.GetProof(context.Background(), &proto.GetProofRequest{
+				StateRoot:   root.Bytes(),
+				Address:     test.address.Bytes(),
+				StorageKeys: storageKeys,
+			})
+			assert.NoError(t, err)
+
+			account, values, err := verifyProof(root, test.address, test.slots, res)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedValues, values)
+
+			if !test.expectedAccount {
+				assert.Nil(t, account)
+
+				return
+			}
+
+			assert.Equal(t, uint64(5), account.Nonce)
+			assert.Equal(t, big.NewInt(100), account.Balance)
+		})
+	}
+}
+
+func Test_proofService_GetProofTooManyKeys(t *testing.T) {
+	t.Parallel()
+
+	service, root := newTestProofService(t)
+
+	_, err := service.GetProof(context.Background(), &proto.GetProofRequest{
+		StateRoot:   root.Bytes(),
+		Address:     testAddress.Bytes(),
+		StorageKeys: make([][]byte, maxStorageKeys+1),
+	})
+	assert.ErrorIs(t, err, ErrTooManyStorageKeys)
+}
+
+func Test_proofService_GetCode(t *testing.T) {
+	t.Parallel()
+
+	service, _ := newTestProofService(t)
+
+	res, err := service.GetCode(context.Background(), &proto.GetCodeRequest{
+		CodeHash: crypto.Keccak256(testCode),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, testCode, res.Code)
+
+	_, err = service.GetCode(context.Background(), &proto.GetCodeRequest{
+		CodeHash: types.StringToHash("0x1").Bytes(),
+	})
+	assert.ErrorIs(t, err, ErrCodeNotFound)
+}
+
+func Test_verifyProof_tamperedStorage(t *testing.T) {
+	t.Parallel()
+
+	service, root := newTestProofService(t)
+
+	res, err := service.GetProof(context.Background(), &proto.GetProofRequest{
+		StateRoot:   root.Bytes(),
+		Address:     testAddress.Bytes(),
+		StorageKeys: [][]byte{testStorageSlot.Bytes()},
+	})
+	assert.NoError(t, err)
+
+	// drop the storage proof
+	res.StorageProofs = nil
+
+	_, _, err = verifyProof(root, testAddress, []types.Hash{testStorageSlot}, res)
+	assert.ErrorIs(t, err, errMissingStorageProof)
+}
@@ -0,0 +1,32 @@
+package light
+
+import (
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+	rawGrpc "google.golang.org/grpc"
+)
+
+type State interface {
+	// GetProof returns the merkle proof of the key in the trie with the given root
+	GetProof(root types.Hash, key []byte) ([][]byte, error)
+	// GetCode returns the contract code by its hash
+	GetCode(hash types.Hash) ([]byte, bool)
+}
+
+type Network interface {
+	// RegisterProtocol registers gRPC service
+	RegisterProtocol(string, network.Protocol)
+	// Peers returns current connected peers
+	Peers() []*network.PeerConnInfo
+	// NewProtoConnection opens up a new stream on the set protocol to the peer,
+	// and returns a reference to the connection
+	NewProtoConnection(protocol string, peerID peer.ID) (*rawGrpc.ClientConn, error)
+}
+
+type ProofService interface {
+	// Start starts server
+	Start()
+	// Close terminates running processes for ProofService
+	Close() error
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"reflect"
 
+	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -40,9 +41,16 @@ func (t *Topic) Publish(obj proto.Message) error {
 		return err
 	}
 
+	metrics.IncrCounterWithLabels([]string{networkMetrics, "gossip_published_messages"}, 1, t.metricLabels())
+
 	return t.topic.Publish(context.Background(), data)
 }
 
+// metricLabels returns the labels of the metrics for the topic
+func (t *Topic) metricLabels() []metrics.Label {
+	return []metrics.Label{{Name: "topic", Value: t.topic.String()}}
+}
+
 func (t *Topic) Subscribe(handler func(obj interface{}, from peer.ID)) error {
 	sub, err := t.topic.Subscribe(pubsub.WithBufferSize(subscribeOutputBufferSize))
 	if err != nil {
@@ -70,10 +78,13 @@ func (t *Topic) readLoop(sub *pubsub.Subscription, handler func(obj interface{},
 			continue
 		}
 
+		metrics.IncrCounterWithLabels([]string{networkMetrics, "gossip_received_messages"}, 1, t.metricLabels())
+
 		go func() {
 			obj := t.createObj()
 			if err := proto.Unmarshal(msg.Data, obj); err != nil {
 				t.logger.Error("failed to unmarshal topic", "err", err)
+				metrics.IncrCounterWithLabels([]string{networkMetrics, "gossip_invalid_messages"}, 1, t.metricLabels())
 
 				return
 			}
@@ -7,8 +7,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/helper/tests"
 	testproto "github.com/0xPolygon/polygon-edge/network/proto"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func NumSubscribers(srv *Server, topic string) int {
@@ -107,3 +110,56 @@ func TestSimpleGossip(t *testing.T) {
 		}
 	}
 }
+
+func TestGossip_Metrics(t *testing.T) {
+	// the metrics are global, the test can't run in parallel
+	sink := tests.NewMetricsSink(t)
+
+	servers, createErr := createServers(2, nil)
+	require.NoError(t, createErr)
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	require.Empty(t, MeshJoin(servers...))
+
+	topicName := "msg-metrics"
+
+	publisherTopic, err := servers[0].NewTopic(topicName, &testproto.GenericMessage{})
+	require.NoError(t, err)
+
+	subscriberTopic, err := servers[1].NewTopic(topicName, &testproto.GenericMessage{})
+	require.NoError(t, err)
+
+	messageCh := make(chan interface{}, 1)
+
+	require.NoError(t, subscriberTopic.Subscribe(func(obj interface{}, _ peer.ID) {
+		messageCh <- obj
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	require.NoError(t, WaitForSubscribers(ctx, servers[0], topicName, 1))
+
+	require.NoError(t, publisherTopic.Publish(&testproto.GenericMessage{Message: "valid"}))
+
+	select {
+	case <-messageCh:
+	case <-time.After(10 * time.Second):
+		t.Fatal("message not received before timeout")
+	}
+
+	// the data that can't be unmarshaled is counted as invalid
+	require.NoError(t, publisherTopic.topic.Publish(context.Background(), []byte{0xff}))
+
+	labels := publisherTopic.metricLabels()
+
+	assert.Eventually(t, func() bool {
+		return sink.Counter([]string{networkMetrics, "gossip_invalid_messages"}, labels...) == 1
+	}, 10*time.Second, 100*time.Millisecond)
+
+	assert.Equal(t, float64(1), sink.Counter([]string{networkMetrics, "gossip_published_messages"}, labels...))
+	assert.Equal(t, float64(2), sink.Counter([]string{networkMetrics, "gossip_received_messages"}, labels...))
+}
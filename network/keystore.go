@@ -2,13 +2,24 @@ package network
 
 import (
 	"encoding/hex"
+	"errors"
 
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/libp2p/go-libp2p/core/crypto"
+	pb "github.com/libp2p/go-libp2p/core/crypto/pb"
 )
 
-// ReadLibp2pKey reads the private networking key from the secrets manager
+var (
+	errSignerKeyRaw = errors.New("networking key kept by the secrets manager can't be exported")
+)
+
+// ReadLibp2pKey reads the private networking key from the secrets manager,
+// the key kept by the secrets manager signs through it
 func ReadLibp2pKey(manager secrets.SecretsManager) (crypto.PrivKey, error) {
+	if signer, ok := secrets.GetSigner(manager, secrets.NetworkKey); ok {
+		return newSignerLibp2pKey(signer)
+	}
+
 	libp2pKey, err := manager.GetSecret(secrets.NetworkKey)
 	if err != nil {
 		return nil, err
@@ -46,3 +57,54 @@ func ParseLibp2pKey(key []byte) (crypto.PrivKey, error) {
 
 	return libp2pKey, nil
 }
+
+// signerLibp2pKey is the networking private key kept by the secrets manager,
+// which signs the handshakes of libp2p on its behalf
+type signerLibp2pKey struct {
+	signer secrets.SecretsSigner
+	pubKey crypto.PubKey
+}
+
+func newSignerLibp2pKey(signer secrets.SecretsSigner) (crypto.PrivKey, error) {
+	rawPubKey, err := signer.SigningPublicKey(secrets.NetworkKey)
+	if err != nil {
+		return nil, err
+	}
+
+	pubKey, err := crypto.UnmarshalPublicKey(rawPubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &signerLibp2pKey{
+		signer: signer,
+		pubKey: pubKey,
+	}, nil
+}
+
+// Equals checks whether the key is the same key kept by the secrets manager
+func (k *signerLibp2pKey) Equals(other crypto.Key) bool {
+	otherKey, ok := other.(*signerLibp2pKey)
+
+	return ok && k.pubKey.Equals(otherKey.pubKey)
+}
+
+// Raw returns an error, the key never leaves the secrets manager
+func (k *signerLibp2pKey) Raw() ([]byte, error) {
+	return nil, errSignerKeyRaw
+}
+
+// Type returns the type of the key kept by the secrets manager
+func (k *signerLibp2pKey) Type() pb.KeyType {
+	return k.pubKey.Type()
+}
+
+// Sign signs the data by the secrets manager
+func (k *signerLibp2pKey) Sign(data []byte) ([]byte, error) {
+	return k.signer.Sign(secrets.NetworkKey, data)
+}
+
+// GetPublic returns the public key of the key kept by the secrets manager
+func (k *signerLibp2pKey) GetPublic() crypto.PubKey {
+	return k.pubKey
+}
@@ -14,6 +14,19 @@ type SecretsManagerConfig struct {
 	Name      string                 `json:"name"`       // The name of the current node
 	Namespace string                 `json:"namespace"`  // The namespace of the service
 	Extra     map[string]interface{} `json:"extra"`      // Any kind of arbitrary data
+
+	// RemoteSigner is the external signing service holding the validator keys, optional
+	RemoteSigner *RemoteSignerConfig `json:"remote_signer,omitempty"`
+}
+
+// RemoteSignerConfig is the configuration of the external signing service
+// that signs IBFT messages and seals on behalf of the validator
+type RemoteSignerConfig struct {
+	URL                    string `json:"url"`                      // The URL of the signing service
+	ECDSAPublicKey         string `json:"ecdsa_public_key"`         // The ECDSA public key of the validator in hex
+	BLSPublicKey           string `json:"bls_public_key"`           // The BLS public key of the validator in hex
+	TimeoutSeconds         uint64 `json:"timeout_s"`                // The timeout of a signing request
+	SlashingProtectionPath string `json:"slashing_protection_path"` // The file keeping the signed IBFT messages
 }
 
 // WriteConfig writes the current configuration to the specified path
@@ -0,0 +1,225 @@
+package hashicorpvault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+)
+
+const (
+	authMethodToken      = "token"
+	authMethodAppRole    = "approle"
+	authMethodKubernetes = "kubernetes"
+
+	// defaultJWTPath is the path of the service account token mounted in the Kubernetes pod
+	//nolint:gosec
+	defaultJWTPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+)
+
+var (
+	errUnsupportedAuthMethod = errors.New("unsupported auth method for Vault secrets manager")
+	errNoRoleID              = fmt.Errorf("no %s specified for AppRole auth", roleID)
+	errNoSecretID            = fmt.Errorf("no %s or %s specified for AppRole auth", secretID, secretIDFile)
+	errNoRole                = fmt.Errorf("no %s specified for Kubernetes auth", role)
+	errNoAuthInfo            = errors.New("no auth info returned by Vault login")
+)
+
+// newAuthMethod creates the auth method configured in the extra config,
+// nil if the given token is used
+func newAuthMethod(extra map[string]interface{}) (vault.AuthMethod, error) {
+	method := extraString(extra, authMethod, authMethodToken)
+	mount := extraString(extra, authMount, method)
+
+	switch method {
+	case authMethodToken:
+		return nil, nil
+	case authMethodAppRole:
+		auth := &appRoleAuth{
+			mount:        mount,
+			roleID:       extraString(extra, roleID, ""),
+			secretID:     extraString(extra, secretID, ""),
+			secretIDFile: extraString(extra, secretIDFile, ""),
+		}
+
+		if auth.roleID == "" {
+			return nil, errNoRoleID
+		}
+
+		if auth.secretID == "" && auth.secretIDFile == "" {
+			return nil, errNoSecretID
+		}
+
+		return auth, nil
+	case authMethodKubernetes:
+		auth := &kubernetesAuth{
+			mount:   mount,
+			role:    extraString(extra, role, ""),
+			jwtPath: extraString(extra, jwtPath, defaultJWTPath),
+		}
+
+		if auth.role == "" {
+			return nil, errNoRole
+		}
+
+		return auth, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedAuthMethod, method)
+	}
+}
+
+// readCredentialFile reads the credential from the file, which may be rotated between the logins
+func readCredentialFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read credential file, %w", err)
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// appRoleAuth logs in by the role ID and the secret ID of AppRole
+type appRoleAuth struct {
+	mount        string
+	roleID       string
+	secretID     string
+	secretIDFile string
+}
+
+// Login implements vault.AuthMethod
+func (a *appRoleAuth) Login(ctx context.Context, client *vault.Client) (*vault.Secret, error) {
+	secretID := a.secretID
+
+	if a.secretIDFile != "" {
+		var err error
+
+		if secretID, err = readCredentialFile(a.secretIDFile); err != nil {
+			return nil, err
+		}
+	}
+
+	return client.Logical().WriteWithContext(ctx, fmt.Sprintf("auth/%s/login", a.mount), map[string]interface{}{
+		"role_id":   a.roleID,
+		"secret_id": secretID,
+	})
+}
+
+// kubernetesAuth logs in by the service account token of the pod
+type kubernetesAuth struct {
+	mount   string
+	role    string
+	jwtPath string
+}
+
+// Login implements vault.AuthMethod
+func (a *kubernetesAuth) Login(ctx context.Context, client *vault.Client) (*vault.Secret, error) {
+	jwt, err := readCredentialFile(a.jwtPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Logical().WriteWithContext(ctx, fmt.Sprintf("auth/%s/login", a.mount), map[string]interface{}{
+		"role": a.role,
+		"jwt":  jwt,
+	})
+}
+
+// login logs in by the auth method and sets the token of the client
+func (v *VaultSecretsManager) login() (*vault.Secret, error) {
+	ctx, cancel := v.context()
+	defer cancel()
+
+	secret, err := v.client.Auth().Login(ctx, v.auth)
+	if err != nil {
+		return nil, fmt.Errorf("unable to log in to Vault, %w", err)
+	}
+
+	if secret == nil || secret.Auth == nil {
+		return nil, errNoAuthInfo
+	}
+
+	return secret, nil
+}
+
+// renewToken renews the token obtained by the auth method until its max TTL
+// and logs in again once the token can't be renewed anymore
+func (v *VaultSecretsManager) renewToken(authSecret *vault.Secret) {
+	defer v.renewWg.Done()
+
+	for {
+		if err := v.watchToken(authSecret); err != nil {
+			v.logger.Error("unable to renew Vault token", "err", err)
+
+			if !v.wait(v.loginRetryInterval) {
+				return
+			}
+		}
+
+		select {
+		case <-v.closeCh:
+			return
+		default:
+		}
+
+		for {
+			var err error
+
+			if authSecret, err = v.login(); err == nil {
+				v.logger.Debug("logged in to Vault again")
+
+				break
+			}
+
+			v.logger.Error("unable to log in to Vault", "err", err)
+
+			if !v.wait(v.loginRetryInterval) {
+				return
+			}
+		}
+	}
+}
+
+// watchToken renews the token until it can't be renewed or the secrets manager is closed
+func (v *VaultSecretsManager) watchToken(authSecret *vault.Secret) error {
+	behavior := vault.RenewBehaviorErrorOnErrors
+	if !authSecret.Auth.Renewable {
+		// keep the token until its expiration
+		behavior = vault.RenewBehaviorRenewDisabled
+	}
+
+	watcher, err := v.client.NewLifetimeWatcher(&vault.LifetimeWatcherInput{
+		Secret:        authSecret,
+		RenewBehavior: behavior,
+	})
+	if err != nil {
+		return err
+	}
+
+	go watcher.Start()
+	defer watcher.Stop()
+
+	for {
+		select {
+		case err := <-watcher.DoneCh():
+			return err
+		case renewal := <-watcher.RenewCh():
+			v.logger.Debug("renewed Vault token", "ttl", renewal.Secret.Auth.LeaseDuration)
+		case <-v.closeCh:
+			return nil
+		}
+	}
+}
+
+// wait waits for the duration and returns false if the secrets manager is closed meanwhile
+func (v *VaultSecretsManager) wait(d time.Duration) bool {
+	select {
+	case <-v.closeCh:
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
@@ -1,14 +1,82 @@
 package hashicorpvault
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/hashicorp/go-hclog"
 	vault "github.com/hashicorp/vault/api"
 )
 
+type configExtraParamFields string
+
+// The Vault specific configuration in SecretsManagerConfig.Extra
+const (
+	// authMethod is the method of the authentication, token (default), approle or kubernetes
+	authMethod configExtraParamFields = "auth-method"
+
+	// authMount is the mount path of the auth method, the name of the method by default
+	authMount configExtraParamFields = "auth-mount"
+
+	// roleID is the role ID of AppRole auth
+	roleID configExtraParamFields = "role-id"
+
+	// secretID is the secret ID of AppRole auth
+	//nolint:gosec
+	secretID configExtraParamFields = "secret-id"
+
+	// secretIDFile is the file containing the secret ID of AppRole auth, used instead of secret-id
+	//nolint:gosec
+	secretIDFile configExtraParamFields = "secret-id-file"
+
+	// role is the role of Kubernetes auth
+	role configExtraParamFields = "role"
+
+	// jwtPath is the file containing the service account token of Kubernetes auth
+	jwtPath configExtraParamFields = "jwt-path"
+
+	// kvMount is the mount path of the KV secrets engine
+	kvMount configExtraParamFields = "kv-mount"
+
+	// kvVersion is the version of the KV secrets engine, 1 or 2
+	kvVersion configExtraParamFields = "kv-version"
+
+	// transitMount is the mount path of the transit secrets engine
+	transitMount configExtraParamFields = "transit-mount"
+
+	// transitNetworkKey is the name of the transit key used as the networking key
+	transitNetworkKey configExtraParamFields = "transit-network-key"
+
+	// transitValidatorKey is the name of the transit key used as the ECDSA validator key
+	transitValidatorKey configExtraParamFields = "transit-validator-key"
+)
+
+const (
+	defaultKVMount      = "secret"
+	defaultKVVersion    = 2
+	defaultTransitMount = "transit"
+
+	// vaultRequestTimeout is the timeout of a request to the Vault server
+	vaultRequestTimeout = 30 * time.Second
+
+	// defaultLoginRetryInterval is the interval between the attempts to log in again
+	// after the token couldn't be renewed
+	defaultLoginRetryInterval = 10 * time.Second
+)
+
+var (
+	errNoServerURL        = errors.New("no server URL specified for Vault secrets manager")
+	errNoNodeName         = errors.New("no node name specified for Vault secrets manager")
+	errNoToken            = errors.New("no token specified for Vault secrets manager")
+	errInvalidKVVersion   = fmt.Errorf("%s must be 1 or 2", kvVersion)
+	errInvalidSecretValue = errors.New("invalid type assertion for secret value")
+)
+
 // VaultSecretsManager is a SecretsManager that
 // stores secrets on a Hashicorp Vault instance
 type VaultSecretsManager struct {
@@ -24,14 +92,28 @@ type VaultSecretsManager struct {
 	// The name of the current node, used for prefixing names of secrets
 	name string
 
-	// The base path to store the secrets in the KV-2 Vault storage
-	basePath string
+	// The mount path and the version of the KV secrets engine storing the secrets
+	kvMount   string
+	kvVersion int
 
 	// The HTTP client used for interacting with the Vault server
 	client *vault.Client
 
 	// The namespace under which the secrets are stored
 	namespace string
+
+	// The method used to obtain the token, nil if the token is given
+	auth vault.AuthMethod
+
+	// The interval between the attempts to log in again
+	loginRetryInterval time.Duration
+
+	// The transit engine signing with the keys kept in Vault, nil if not used
+	transit *transitSigner
+
+	closeCh   chan struct{}
+	closeOnce sync.Once
+	renewWg   sync.WaitGroup
 }
 
 // SecretsManagerFactory implements the factory method
@@ -41,20 +123,14 @@ func SecretsManagerFactory(
 ) (secrets.SecretsManager, error) {
 	// Set up the base object
 	vaultManager := &VaultSecretsManager{
-		logger: params.Logger.Named(string(secrets.HashicorpVault)),
+		logger:             params.Logger.Named(string(secrets.HashicorpVault)),
+		loginRetryInterval: defaultLoginRetryInterval,
+		closeCh:            make(chan struct{}),
 	}
 
-	// Check if the token is present
-	if config.Token == "" {
-		return nil, errors.New("no token specified for Vault secrets manager")
-	}
-
-	// Grab the token from the config
-	vaultManager.token = config.Token
-
 	// Check if the server URL is present
 	if config.ServerURL == "" {
-		return nil, errors.New("no server URL specified for Vault secrets manager")
+		return nil, errNoServerURL
 	}
 
 	// Grab the server URL from the config
@@ -62,7 +138,7 @@ func SecretsManagerFactory(
 
 	// Check if the node name is present
 	if config.Name == "" {
-		return nil, errors.New("no node name specified for Vault secrets manager")
+		return nil, errNoNodeName
 	}
 
 	// Grab the node name from the config
@@ -71,15 +147,71 @@ func SecretsManagerFactory(
 	// Grab the namespace from the config
 	vaultManager.namespace = config.Namespace
 
-	// Set the base path to store the secrets in the KV-2 Vault storage
-	vaultManager.basePath = fmt.Sprintf("secret/data/%s", vaultManager.name)
+	auth, err := newAuthMethod(config.Extra)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if the token is present if it's not obtained by the auth method
+	if auth == nil && config.Token == "" {
+		return nil, errNoToken
+	}
+
+	vaultManager.auth = auth
+	vaultManager.token = config.Token
+
+	// Set the KV secrets engine storing the secrets
+	vaultManager.kvMount = extraString(config.Extra, kvMount, defaultKVMount)
+
+	if vaultManager.kvVersion, err = strconv.Atoi(
+		extraString(config.Extra, kvVersion, strconv.Itoa(defaultKVVersion)),
+	); err != nil || (vaultManager.kvVersion != 1 && vaultManager.kvVersion != 2) {
+		return nil, errInvalidKVVersion
+	}
+
+	// Set the transit keys signing instead of the secrets
+	keys := map[string]string{}
+
+	for secretName, field := range map[string]configExtraParamFields{
+		secrets.NetworkKey:   transitNetworkKey,
+		secrets.ValidatorKey: transitValidatorKey,
+	} {
+		if key := extraString(config.Extra, field, ""); key != "" {
+			keys[secretName] = key
+		}
+	}
+
+	if len(keys) > 0 {
+		vaultManager.transit = newTransitSigner(
+			vaultManager,
+			extraString(config.Extra, transitMount, defaultTransitMount),
+			keys,
+		)
+	}
 
 	// Run the initial setup
-	_ = vaultManager.Setup()
+	if err := vaultManager.Setup(); err != nil {
+		return nil, err
+	}
 
 	return vaultManager, nil
 }
 
+// extraString returns the value of the field in the extra config, or the default value if it's not set
+func extraString(extra map[string]interface{}, field configExtraParamFields, defaultValue string) string {
+	value, ok := extra[string(field)]
+	if !ok || value == nil {
+		return defaultValue
+	}
+
+	str := fmt.Sprintf("%v", value)
+	if str == "" {
+		return defaultValue
+	}
+
+	return str
+}
+
 // Setup sets up the Hashicorp Vault secrets manager
 func (v *VaultSecretsManager) Setup() error {
 	config := vault.DefaultConfig()
@@ -92,62 +224,102 @@ func (v *VaultSecretsManager) Setup() error {
 		return fmt.Errorf("unable to initialize Vault client: %w", err)
 	}
 
-	// Set the access token
-	client.SetToken(v.token)
-
 	// Set the namespace
 	client.SetNamespace(v.namespace)
 
 	v.client = client
 
+	if v.auth == nil {
+		// Set the access token
+		client.SetToken(v.token)
+
+		return nil
+	}
+
+	// Log in by the auth method and keep the token renewed
+	authSecret, err := v.login()
+	if err != nil {
+		return err
+	}
+
+	v.renewWg.Add(1)
+
+	go v.renewToken(authSecret)
+
 	return nil
 }
 
-// constructSecretPath is a helper method for constructing a path to the secret
+// Close stops the renewal of the token obtained by the auth method
+func (v *VaultSecretsManager) Close() {
+	v.closeOnce.Do(func() {
+		close(v.closeCh)
+	})
+
+	v.renewWg.Wait()
+}
+
+// context returns the context of a request to the Vault server
+func (v *VaultSecretsManager) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), vaultRequestTimeout)
+}
+
+// constructSecretPath is a helper method for constructing a path to the secret in the KV secrets engine
 func (v *VaultSecretsManager) constructSecretPath(name string) string {
-	return fmt.Sprintf("%s/%s", v.basePath, name)
+	return fmt.Sprintf("%s/%s", v.name, name)
 }
 
-// GetSecret fetches a secret from the Hashicorp Vault server
-func (v *VaultSecretsManager) GetSecret(name string) ([]byte, error) {
-	secret, err := v.client.Logical().Read(v.constructSecretPath(name))
+// readSecret reads the data of the secret from the KV secrets engine
+func (v *VaultSecretsManager) readSecret(name string) (map[string]interface{}, error) {
+	ctx, cancel := v.context()
+	defer cancel()
+
+	var (
+		secret *vault.KVSecret
+		err    error
+	)
+
+	if v.kvVersion == 1 {
+		secret, err = v.client.KVv1(v.kvMount).Get(ctx, v.constructSecretPath(name))
+	} else {
+		secret, err = v.client.KVv2(v.kvMount).Get(ctx, v.constructSecretPath(name))
+	}
+
+	if errors.Is(err, vault.ErrSecretNotFound) {
+		return nil, secrets.ErrSecretNotFound
+	}
+
 	if err != nil {
 		return nil, fmt.Errorf("unable to read secret from Vault, %w", err)
 	}
 
-	if secret == nil {
+	// The data of the deleted version of KV-2 is empty
+	if secret.Data == nil {
 		return nil, secrets.ErrSecretNotFound
 	}
 
-	// KV-2 (versioned key-value storage) in Vault stores data in the following format:
-	// {
-	// "data": {
-	// 	key: value
-	// }
-	// }
-	data, ok := secret.Data["data"]
-	if !ok {
-		return nil, fmt.Errorf(
-			"unable to assert type for secret from Vault, %T %#v",
-			secret.Data["data"],
-			secret.Data["data"],
-		)
+	return secret.Data, nil
+}
+
+// GetSecret fetches a secret from the Hashicorp Vault server
+func (v *VaultSecretsManager) GetSecret(name string) ([]byte, error) {
+	if v.IsSigningKey(name) {
+		return nil, secrets.ErrSigningKey
 	}
 
-	// Check if the data is empty
-	if data == nil {
-		return nil, secrets.ErrSecretNotFound
+	data, err := v.readSecret(name)
+	if err != nil {
+		return nil, err
 	}
 
 	// Grab the value
-	value, ok := data.(map[string]interface{})[name]
+	value, ok := data[name]
 	if !ok {
 		return nil, secrets.ErrSecretNotFound
 	}
 
 	stringVal, ok := value.(string)
 	if !ok {
-		return nil, errors.New("invalid type assertion for secret value")
+		return nil, errInvalidSecretValue
 	}
 
 	return []byte(stringVal), nil
@@ -156,6 +328,10 @@ func (v *VaultSecretsManager) GetSecret(name string) ([]byte, error) {
 // SetSecret saves a secret to the Hashicorp Vault server
 // Secrets saved in Vault need to have a string value (Base64)
 func (v *VaultSecretsManager) SetSecret(name string, value []byte) error {
+	if v.IsSigningKey(name) {
+		return secrets.ErrSigningKey
+	}
+
 	// Check if overwrite is possible
 	_, err := v.GetSecret(name)
 	if err == nil {
@@ -167,12 +343,19 @@ func (v *VaultSecretsManager) SetSecret(name string, value []byte) error {
 	}
 
 	// Construct the data wrapper
-	data := make(map[string]string)
-	data[name] = string(value)
+	data := map[string]interface{}{
+		name: string(value),
+	}
+
+	ctx, cancel := v.context()
+	defer cancel()
+
+	if v.kvVersion == 1 {
+		err = v.client.KVv1(v.kvMount).Put(ctx, v.constructSecretPath(name), data)
+	} else {
+		_, err = v.client.KVv2(v.kvMount).Put(ctx, v.constructSecretPath(name), data)
+	}
 
-	_, err = v.client.Logical().Write(v.constructSecretPath(name), map[string]interface{}{
-		"data": data,
-	})
 	if err != nil {
 		return fmt.Errorf("unable to store secret (%s), %w", name, err)
 	}
@@ -182,6 +365,12 @@ func (v *VaultSecretsManager) SetSecret(name string, value []byte) error {
 
 // HasSecret checks if the secret is present on the Hashicorp Vault server
 func (v *VaultSecretsManager) HasSecret(name string) bool {
+	if v.IsSigningKey(name) {
+		_, err := v.transit.publicKey(name)
+
+		return err == nil
+	}
+
 	_, err := v.GetSecret(name)
 
 	return err == nil
@@ -195,11 +384,42 @@ func (v *VaultSecretsManager) RemoveSecret(name string) error {
 		return err
 	}
 
+	ctx, cancel := v.context()
+	defer cancel()
+
 	// Delete the secret from Vault storage
-	_, err = v.client.Logical().Delete(v.constructSecretPath(name))
+	if v.kvVersion == 1 {
+		err = v.client.KVv1(v.kvMount).Delete(ctx, v.constructSecretPath(name))
+	} else {
+		err = v.client.KVv2(v.kvMount).Delete(ctx, v.constructSecretPath(name))
+	}
+
 	if err != nil {
 		return fmt.Errorf("unable to delete secret (%s), %w", name, err)
 	}
 
 	return nil
 }
+
+// IsSigningKey checks if the secret is a key of the transit secrets engine
+func (v *VaultSecretsManager) IsSigningKey(name string) bool {
+	return v.transit != nil && v.transit.hasKey(name)
+}
+
+// SigningPublicKey returns the public key of the transit key used as the secret
+func (v *VaultSecretsManager) SigningPublicKey(name string) ([]byte, error) {
+	if !v.IsSigningKey(name) {
+		return nil, secrets.ErrSecretNotFound
+	}
+
+	return v.transit.publicKey(name)
+}
+
+// Sign signs the data with the transit key used as the secret
+func (v *VaultSecretsManager) Sign(name string, data []byte) ([]byte, error) {
+	if !v.IsSigningKey(name) {
+		return nil, secrets.ErrSecretNotFound
+	}
+
+	return v.transit.sign(name, data)
+}
@@ -0,0 +1,604 @@
+package hashicorpvault
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testVault is a stand-in of the Vault server with the KV v1 and v2, AppRole,
+// Kubernetes and token auth and the transit engine, as far as the secrets manager uses them
+type testVault struct {
+	t *testing.T
+
+	lock sync.Mutex
+
+	// tokens are the valid tokens
+	tokens map[string]bool
+	// kv is the data of the secrets by the path, including the mount
+	kv map[string]map[string]interface{}
+
+	logins    int
+	renewals  int
+	failRenew bool
+
+	ed25519Key   ed25519.PrivateKey
+	secp256k1Key *ecdsa.PrivateKey
+	// transitKeyTypes are the types of the transit keys by the names
+	transitKeyTypes map[string]string
+}
+
+func newTestVault(t *testing.T) (*testVault, *httptest.Server) {
+	t.Helper()
+
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	secp256k1Key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	v := &testVault{
+		t:            t,
+		tokens:       map[string]bool{"root": true},
+		kv:           map[string]map[string]interface{}{},
+		ed25519Key:   ed25519Key,
+		secp256k1Key: secp256k1Key,
+		transitKeyTypes: map[string]string{
+			"libp2p":    transitKeyTypeEd25519,
+			"validator": transitKeyTypeSecp256k1,
+			"other":     "ecdsa-p256",
+		},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(v.handle))
+	t.Cleanup(srv.Close)
+
+	return v, srv
+}
+
+func (v *testVault) respond(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if body != nil {
+		require.NoError(v.t, json.NewEncoder(w).Encode(body))
+	}
+}
+
+func (v *testVault) login(w http.ResponseWriter) {
+	v.logins++
+
+	token := "token-" + strings.Repeat("x", v.logins)
+	v.tokens[token] = true
+
+	v.respond(w, http.StatusOK, map[string]interface{}{
+		"auth": map[string]interface{}{
+			"client_token":   token,
+			"lease_duration": 1,
+			"renewable":      true,
+		},
+	})
+}
+
+func (v *testVault) handle(w http.ResponseWriter, r *http.Request) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+
+	var body map[string]interface{}
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+
+	switch path {
+	case "auth/approle/login":
+		if body["role_id"] != "role" || body["secret_id"] != "secret" {
+			v.respond(w, http.StatusBadRequest, nil)
+
+			return
+		}
+
+		v.login(w)
+
+		return
+	case "auth/k8s/login":
+		if body["role"] != "node" || body["jwt"] != "jwt" {
+			v.respond(w, http.StatusBadRequest, nil)
+
+			return
+		}
+
+		v.login(w)
+
+		return
+	}
+
+	token := r.Header.Get("X-Vault-Token")
+	if !v.tokens[token] {
+		v.respond(w, http.StatusForbidden, nil)
+
+		return
+	}
+
+	switch {
+	case path == "auth/token/renew-self":
+		v.renewals++
+
+		if v.failRenew {
+			// the token reached its max TTL
+			delete(v.tokens, token)
+			v.respond(w, http.StatusForbidden, nil)
+
+			return
+		}
+
+		v.respond(w, http.StatusOK, map[string]interface{}{
+			"auth": map[string]interface{}{
+				"client_token":   token,
+				"lease_duration": 1,
+				"renewable":      true,
+			},
+		})
+	case strings.HasPrefix(path, "transit/keys/"):
+		v.readTransitKey(w, strings.TrimPrefix(path, "transit/keys/"))
+	case strings.HasPrefix(path, "transit/sign/"):
+		v.signTransit(w, strings.TrimPrefix(path, "transit/sign/"), body)
+	default:
+		v.handleKV(w, r.Method, path, body)
+	}
+}
+
+func (v *testVault) handleKV(w http.ResponseWriter, method, path string, body map[string]interface{}) {
+	isV2 := strings.HasPrefix(path, "secret/data/")
+
+	switch method {
+	case http.MethodGet:
+		data, ok := v.kv[path]
+		if !ok {
+			v.respond(w, http.StatusNotFound, nil)
+
+			return
+		}
+
+		if isV2 {
+			v.respond(w, http.StatusOK, map[string]interface{}{
+				"data": map[string]interface{}{
+					"data":     data,
+					"metadata": map[string]interface{}{"version": 1},
+				},
+			})
+
+			return
+		}
+
+		v.respond(w, http.StatusOK, map[string]interface{}{"data": data})
+	case http.MethodPut, http.MethodPost:
+		if isV2 {
+			data, _ := body["data"].(map[string]interface{})
+			v.kv[path] = data
+
+			v.respond(w, http.StatusOK, map[string]interface{}{
+				"data": map[string]interface{}{"version": 1},
+			})
+
+			return
+		}
+
+		v.kv[path] = body
+		v.respond(w, http.StatusNoContent, nil)
+	case http.MethodDelete:
+		delete(v.kv, path)
+		v.respond(w, http.StatusNoContent, nil)
+	}
+}
+
+func (v *testVault) readTransitKey(w http.ResponseWriter, name string) {
+	keyType, ok := v.transitKeyTypes[name]
+	if !ok {
+		v.respond(w, http.StatusNotFound, nil)
+
+		return
+	}
+
+	var pubKey string
+
+	switch keyType {
+	case transitKeyTypeEd25519:
+		pubKey = base64.StdEncoding.EncodeToString(v.ed25519Key.Public().(ed25519.PublicKey))
+	default:
+		// the PKIX structure of the secp256k1 key
+		der, err := asn1.Marshal(struct {
+			Algorithm pkix.AlgorithmIdentifier
+			PublicKey asn1.BitString
+		}{
+			Algorithm: pkix.AlgorithmIdentifier{
+				Algorithm:  asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1},
+				Parameters: asn1.RawValue{FullBytes: []byte{0x06, 0x05, 0x2b, 0x81, 0x04, 0x00, 0x0a}},
+			},
+			PublicKey: asn1.BitString{Bytes: crypto.MarshalPublicKey(&v.secp256k1Key.PublicKey)},
+		})
+		require.NoError(v.t, err)
+
+		pubKey = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	}
+
+	v.respond(w, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":           keyType,
+			"latest_version": 1,
+			"keys": map[string]interface{}{
+				"1": map[string]interface{}{"public_key": pubKey},
+			},
+		},
+	})
+}
+
+func (v *testVault) signTransit(w http.ResponseWriter, name string, body map[string]interface{}) {
+	input, err := base64.StdEncoding.DecodeString(body["input"].(string))
+	require.NoError(v.t, err)
+
+	var sig []byte
+
+	switch v.transitKeyTypes[name] {
+	case transitKeyTypeEd25519:
+		sig = ed25519.Sign(v.ed25519Key, input)
+	case transitKeyTypeSecp256k1:
+		require.Equal(v.t, true, body["prehashed"])
+
+		r, s, err := ecdsa.Sign(rand.Reader, v.secp256k1Key, input)
+		require.NoError(v.t, err)
+
+		sig, err = asn1.Marshal(struct{ R, S interface{} }{r, s})
+		require.NoError(v.t, err)
+	default:
+		v.respond(w, http.StatusNotFound, nil)
+
+		return
+	}
+
+	v.respond(w, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{
+			"signature": "vault:v1:" + base64.StdEncoding.EncodeToString(sig),
+		},
+	})
+}
+
+func (v *testVault) counts() (int, int) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	return v.logins, v.renewals
+}
+
+func newTestManager(t *testing.T, serverURL, token string, extra map[string]interface{}) *VaultSecretsManager {
+	t.Helper()
+
+	manager, err := SecretsManagerFactory(
+		&secrets.SecretsManagerConfig{
+			Token:     token,
+			ServerURL: serverURL,
+			Type:      secrets.HashicorpVault,
+			Name:      "node",
+			Extra:     extra,
+		},
+		&secrets.SecretsManagerParams{Logger: hclog.NewNullLogger()},
+	)
+	require.NoError(t, err)
+
+	vaultManager, ok := manager.(*VaultSecretsManager)
+	require.True(t, ok)
+
+	t.Cleanup(vaultManager.Close)
+
+	return vaultManager
+}
+
+func writeCredentialFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "credential")
+	require.NoError(t, os.WriteFile(path, []byte(content+"\n"), 0600))
+
+	return path
+}
+
+func TestSecretsManagerFactory_InvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name   string
+		config *secrets.SecretsManagerConfig
+		err    error
+	}{
+		{
+			"no server URL",
+			&secrets.SecretsManagerConfig{Token: "root", Name: "node"},
+			errNoServerURL,
+		},
+		{
+			"no node name",
+			&secrets.SecretsManagerConfig{Token: "root", ServerURL: "http://127.0.0.1:1"},
+			errNoNodeName,
+		},
+		{
+			"no token",
+			&secrets.SecretsManagerConfig{ServerURL: "http://127.0.0.1:1", Name: "node"},
+			errNoToken,
+		},
+		{
+			"no role ID for AppRole",
+			&secrets.SecretsManagerConfig{
+				ServerURL: "http://127.0.0.1:1",
+				Name:      "node",
+				Extra:     map[string]interface{}{"auth-method": "approle", "secret-id": "secret"},
+			},
+			errNoRoleID,
+		},
+		{
+			"no role for Kubernetes",
+			&secrets.SecretsManagerConfig{
+				ServerURL: "http://127.0.0.1:1",
+				Name:      "node",
+				Extra:     map[string]interface{}{"auth-method": "kubernetes"},
+			},
+			errNoRole,
+		},
+		{
+			"unsupported auth method",
+			&secrets.SecretsManagerConfig{
+				ServerURL: "http://127.0.0.1:1",
+				Name:      "node",
+				Extra:     map[string]interface{}{"auth-method": "ldap"},
+			},
+			errUnsupportedAuthMethod,
+		},
+		{
+			"invalid KV version",
+			&secrets.SecretsManagerConfig{
+				Token:     "root",
+				ServerURL: "http://127.0.0.1:1",
+				Name:      "node",
+				Extra:     map[string]interface{}{"kv-version": "3"},
+			},
+			errInvalidKVVersion,
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := SecretsManagerFactory(
+				testCase.config,
+				&secrets.SecretsManagerParams{Logger: hclog.NewNullLogger()},
+			)
+			assert.ErrorIs(t, err, testCase.err)
+		})
+	}
+}
+
+func TestVaultSecretsManager_KV(t *testing.T) {
+	t.Parallel()
+
+	for _, kvConfig := range []map[string]interface{}{
+		{},
+		// the version from the JSON config is a number
+		{"kv-mount": "kv", "kv-version": float64(1)},
+	} {
+		kvConfig := kvConfig
+
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+
+			vault, srv := newTestVault(t)
+			manager := newTestManager(t, srv.URL, "root", kvConfig)
+
+			assert.False(t, manager.HasSecret(secrets.ValidatorKey))
+
+			_, err := manager.GetSecret(secrets.ValidatorKey)
+			assert.ErrorIs(t, err, secrets.ErrSecretNotFound)
+
+			require.NoError(t, manager.SetSecret(secrets.ValidatorKey, []byte("key")))
+
+			value, err := manager.GetSecret(secrets.ValidatorKey)
+			require.NoError(t, err)
+			assert.Equal(t, []byte("key"), value)
+			assert.True(t, manager.HasSecret(secrets.ValidatorKey))
+
+			path := "secret/data/node/" + secrets.ValidatorKey
+			if kvConfig["kv-mount"] != nil {
+				path = "kv/node/" + secrets.ValidatorKey
+			}
+
+			vault.lock.Lock()
+			assert.Equal(t, map[string]interface{}{secrets.ValidatorKey: "key"}, vault.kv[path])
+			vault.lock.Unlock()
+
+			require.NoError(t, manager.RemoveSecret(secrets.ValidatorKey))
+			assert.False(t, manager.HasSecret(secrets.ValidatorKey))
+		})
+	}
+}
+
+func TestVaultSecretsManager_AppRoleAuth(t *testing.T) {
+	t.Parallel()
+
+	vault, srv := newTestVault(t)
+
+	manager := newTestManager(t, srv.URL, "", map[string]interface{}{
+		"auth-method":    "approle",
+		"role-id":        "role",
+		"secret-id-file": writeCredentialFile(t, "secret"),
+	})
+
+	require.NoError(t, manager.SetSecret(secrets.NetworkKey, []byte("key")))
+
+	// the token is renewed
+	assert.Eventually(t, func() bool {
+		_, renewals := vault.counts()
+
+		return renewals > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the token which can't be renewed anymore is replaced by the new login
+	manager.loginRetryInterval = 10 * time.Millisecond
+
+	vault.lock.Lock()
+	vault.failRenew = true
+	vault.lock.Unlock()
+
+	assert.Eventually(t, func() bool {
+		logins, _ := vault.counts()
+
+		return logins > 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	vault.lock.Lock()
+	vault.failRenew = false
+	vault.lock.Unlock()
+
+	assert.Eventually(t, func() bool {
+		value, err := manager.GetSecret(secrets.NetworkKey)
+
+		return err == nil && string(value) == "key"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestVaultSecretsManager_KubernetesAuth(t *testing.T) {
+	t.Parallel()
+
+	vault, srv := newTestVault(t)
+
+	manager := newTestManager(t, srv.URL, "", map[string]interface{}{
+		"auth-method": "kubernetes",
+		"auth-mount":  "k8s",
+		"role":        "node",
+		"jwt-path":    writeCredentialFile(t, "jwt"),
+	})
+
+	logins, _ := vault.counts()
+	assert.Equal(t, 1, logins)
+
+	require.NoError(t, manager.SetSecret(secrets.NetworkKey, []byte("key")))
+	assert.True(t, manager.HasSecret(secrets.NetworkKey))
+
+	// the login fails with the wrong credentials
+	_, err := SecretsManagerFactory(
+		&secrets.SecretsManagerConfig{
+			ServerURL: srv.URL,
+			Name:      "node",
+			Extra: map[string]interface{}{
+				"auth-method": "kubernetes",
+				"auth-mount":  "k8s",
+				"role":        "node",
+				"jwt-path":    writeCredentialFile(t, "other"),
+			},
+		},
+		&secrets.SecretsManagerParams{Logger: hclog.NewNullLogger()},
+	)
+	assert.Error(t, err)
+}
+
+func TestVaultSecretsManager_Transit(t *testing.T) {
+	t.Parallel()
+
+	vault, srv := newTestVault(t)
+
+	manager := newTestManager(t, srv.URL, "root", map[string]interface{}{
+		"transit-network-key":   "libp2p",
+		"transit-validator-key": "validator",
+	})
+
+	// the keys never leave Vault
+	for _, name := range []string{secrets.NetworkKey, secrets.ValidatorKey} {
+		assert.True(t, manager.IsSigningKey(name))
+		assert.True(t, manager.HasSecret(name))
+
+		_, err := manager.GetSecret(name)
+		assert.ErrorIs(t, err, secrets.ErrSigningKey)
+
+		assert.ErrorIs(t, manager.SetSecret(name, []byte("key")), secrets.ErrSigningKey)
+	}
+
+	assert.False(t, manager.IsSigningKey(secrets.ValidatorBLSKey))
+
+	t.Run("validator key", func(t *testing.T) {
+		t.Parallel()
+
+		pubKey, err := manager.SigningPublicKey(secrets.ValidatorKey)
+		require.NoError(t, err)
+		assert.Equal(t, crypto.MarshalPublicKey(&vault.secp256k1Key.PublicKey), pubKey)
+
+		digest := crypto.Keccak256([]byte("message"))
+
+		for i := 0; i < 10; i++ {
+			sig, err := manager.Sign(secrets.ValidatorKey, digest)
+			require.NoError(t, err)
+			require.Len(t, sig, 65)
+
+			recovered, err := crypto.RecoverPubkey(sig, digest)
+			require.NoError(t, err)
+			assert.Equal(t, crypto.PubKeyToAddress(&vault.secp256k1Key.PublicKey), crypto.PubKeyToAddress(recovered))
+		}
+	})
+
+	t.Run("network key", func(t *testing.T) {
+		t.Parallel()
+
+		key, err := network.ReadLibp2pKey(manager)
+		require.NoError(t, err)
+
+		_, err = key.Raw()
+		assert.Error(t, err)
+
+		sig, err := key.Sign([]byte("message"))
+		require.NoError(t, err)
+
+		ok, err := key.GetPublic().Verify([]byte("message"), sig)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		id, err := peer.IDFromPrivateKey(key)
+		require.NoError(t, err)
+
+		expected, err := peer.IDFromPublicKey(key.GetPublic())
+		require.NoError(t, err)
+		assert.Equal(t, expected, id)
+	})
+
+	t.Run("unexpected key type", func(t *testing.T) {
+		t.Parallel()
+
+		manager := newTestManager(t, srv.URL, "root", map[string]interface{}{
+			"transit-validator-key": "other",
+		})
+
+		_, err := manager.Sign(secrets.ValidatorKey, crypto.Keccak256([]byte("message")))
+		assert.ErrorIs(t, err, errUnexpectedKeyType)
+		assert.False(t, manager.HasSecret(secrets.ValidatorKey))
+	})
+}
@@ -0,0 +1,292 @@
+package hashicorpvault
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/secrets"
+	libp2pCrypto "github.com/libp2p/go-libp2p/core/crypto"
+)
+
+const (
+	// transitKeyTypeEd25519 is the type of the transit key used as the networking key
+	transitKeyTypeEd25519 = "ed25519"
+
+	// transitKeyTypeSecp256k1 is the type of the transit key used as the ECDSA validator key.
+	// The transit engine built in Vault doesn't support the curve,
+	// it needs a transit compatible secrets engine that does
+	transitKeyTypeSecp256k1 = "ecdsa-secp256k1"
+
+	// transitSignaturePrefix is the prefix of the signatures returned by the transit engine, followed by the key version
+	transitSignaturePrefix = "vault:v"
+)
+
+var (
+	errUnexpectedKeyType = errors.New("unexpected type of transit key")
+	errNoPublicKey       = errors.New("no public key returned for transit key")
+	errInvalidSignature  = errors.New("invalid signature returned by transit engine")
+)
+
+// transitKeyTypes are the types of the transit keys used as the secrets
+var transitKeyTypes = map[string]string{
+	secrets.NetworkKey:   transitKeyTypeEd25519,
+	secrets.ValidatorKey: transitKeyTypeSecp256k1,
+}
+
+// transitSigner signs with the keys of the transit secrets engine used as the secrets,
+// the private keys never leave Vault
+type transitSigner struct {
+	manager *VaultSecretsManager
+	mount   string
+
+	// keys are the names of the transit keys by the names of the secrets
+	keys map[string]string
+
+	// publicKeys are the public keys of the transit keys by the names of the secrets,
+	// the signatures are verified against them so a rotated key is noticed
+	publicKeys     map[string][]byte
+	publicKeysLock sync.Mutex
+}
+
+func newTransitSigner(manager *VaultSecretsManager, mount string, keys map[string]string) *transitSigner {
+	return &transitSigner{
+		manager:    manager,
+		mount:      mount,
+		keys:       keys,
+		publicKeys: make(map[string][]byte),
+	}
+}
+
+// hasKey checks if the secret is a transit key
+func (t *transitSigner) hasKey(name string) bool {
+	_, ok := t.keys[name]
+
+	return ok
+}
+
+// publicKey returns the public key of the latest version of the transit key, in the format of the secret
+func (t *transitSigner) publicKey(name string) ([]byte, error) {
+	t.publicKeysLock.Lock()
+	defer t.publicKeysLock.Unlock()
+
+	if pubKey, ok := t.publicKeys[name]; ok {
+		return pubKey, nil
+	}
+
+	ctx, cancel := t.manager.context()
+	defer cancel()
+
+	secret, err := t.manager.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/keys/%s", t.mount, t.keys[name]))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read transit key from Vault, %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, secrets.ErrSecretNotFound
+	}
+
+	if keyType, _ := secret.Data["type"].(string); keyType != transitKeyTypes[name] {
+		return nil, fmt.Errorf("%w: %s has type %s, %s expected", errUnexpectedKeyType, name, keyType, transitKeyTypes[name])
+	}
+
+	rawPubKey, err := latestTransitPublicKey(secret.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	var pubKey []byte
+
+	switch name {
+	case secrets.NetworkKey:
+		pubKey, err = parseEd25519PublicKey(rawPubKey)
+	case secrets.ValidatorKey:
+		pubKey, err = parseSecp256k1PublicKey(rawPubKey)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	t.publicKeys[name] = pubKey
+
+	return pubKey, nil
+}
+
+// latestTransitPublicKey returns the public key of the latest version in the data of the transit key
+func latestTransitPublicKey(data map[string]interface{}) (string, error) {
+	keys, _ := data["keys"].(map[string]interface{})
+
+	version := fmt.Sprintf("%v", data["latest_version"])
+
+	key, _ := keys[version].(map[string]interface{})
+
+	pubKey, _ := key["public_key"].(string)
+	if pubKey == "" {
+		return "", errNoPublicKey
+	}
+
+	return pubKey, nil
+}
+
+// parseEd25519PublicKey converts the base64 ed25519 public key into the marshaled libp2p public key
+func parseEd25519PublicKey(raw string) ([]byte, error) {
+	buf, err := base64.StdEncoding.DecodeString(raw)
+	if err != nil || len(buf) != ed25519.PublicKeySize {
+		return nil, errNoPublicKey
+	}
+
+	pubKey, err := libp2pCrypto.UnmarshalEd25519PublicKey(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return libp2pCrypto.MarshalPublicKey(pubKey)
+}
+
+// parseSecp256k1PublicKey converts the PEM encoded public key into the uncompressed secp256k1 public key.
+// The standard library doesn't know the curve, so the key is taken from the PKIX structure as is
+func parseSecp256k1PublicKey(raw string) ([]byte, error) {
+	block, _ := pem.Decode([]byte(raw))
+	if block == nil {
+		return nil, errNoPublicKey
+	}
+
+	var info struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+
+	if _, err := asn1.Unmarshal(block.Bytes, &info); err != nil {
+		return nil, fmt.Errorf("unable to parse transit public key, %w", err)
+	}
+
+	pubKey, err := crypto.ParsePublicKey(info.PublicKey.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.MarshalPublicKey(pubKey), nil
+}
+
+// sign signs the data with the transit key and verifies the signature against the public key
+func (t *transitSigner) sign(name string, data []byte) ([]byte, error) {
+	pubKey, err := t.publicKey(name)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := t.manager.context()
+	defer cancel()
+
+	request := map[string]interface{}{
+		"input": base64.StdEncoding.EncodeToString(data),
+	}
+
+	if name == secrets.ValidatorKey {
+		// the data is the digest signed as is
+		request["prehashed"] = true
+		request["marshaling_algorithm"] = "asn1"
+	}
+
+	secret, err := t.manager.client.Logical().WriteWithContext(
+		ctx,
+		fmt.Sprintf("%s/sign/%s", t.mount, t.keys[name]),
+		request,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign with transit key, %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, errInvalidSignature
+	}
+
+	rawSig, err := decodeTransitSignature(secret.Data["signature"])
+	if err != nil {
+		return nil, err
+	}
+
+	if name == secrets.NetworkKey {
+		return verifyEd25519Signature(pubKey, data, rawSig)
+	}
+
+	return toRecoverableSignature(pubKey, data, rawSig)
+}
+
+// decodeTransitSignature decodes the signature in the format of vault:v<version>:<base64>
+func decodeTransitSignature(value interface{}) ([]byte, error) {
+	sig, _ := value.(string)
+	if !strings.HasPrefix(sig, transitSignaturePrefix) {
+		return nil, errInvalidSignature
+	}
+
+	parts := strings.SplitN(sig, ":", 3)
+	if len(parts) != 3 {
+		return nil, errInvalidSignature
+	}
+
+	rawSig, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errInvalidSignature
+	}
+
+	return rawSig, nil
+}
+
+// verifyEd25519Signature checks the ed25519 signature is made by the networking key
+func verifyEd25519Signature(pubKey, data, sig []byte) ([]byte, error) {
+	key, err := libp2pCrypto.UnmarshalPublicKey(pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok, err := key.Verify(data, sig); err != nil || !ok {
+		return nil, errInvalidSignature
+	}
+
+	return sig, nil
+}
+
+// toRecoverableSignature converts the ASN.1 ECDSA signature of the digest into the signature of 65 bytes
+// in the format of crypto.Sign, with the low S and the recovery ID found by the public key
+func toRecoverableSignature(pubKey, digest, der []byte) ([]byte, error) {
+	var sig struct {
+		R, S *big.Int
+	}
+
+	n := crypto.S256.Params().N
+
+	if rest, err := asn1.Unmarshal(der, &sig); err != nil || len(rest) != 0 ||
+		sig.R.Sign() <= 0 || sig.S.Sign() <= 0 || sig.R.Cmp(n) >= 0 || sig.S.Cmp(n) >= 0 {
+		return nil, errInvalidSignature
+	}
+
+	if sig.S.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+		sig.S = new(big.Int).Sub(n, sig.S)
+	}
+
+	buf := make([]byte, 65)
+	sig.R.FillBytes(buf[:32])
+	sig.S.FillBytes(buf[32:64])
+
+	for _, v := range []byte{0, 1} {
+		buf[64] = v
+
+		recovered, err := crypto.RecoverPubkey(buf, digest)
+		if err == nil && bytes.Equal(crypto.MarshalPublicKey(recovered), pubKey) {
+			return buf, nil
+		}
+	}
+
+	return nil, errInvalidSignature
+}
@@ -1,6 +1,7 @@
 package helper
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 
@@ -18,19 +19,92 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
+// localKeySecrets are the keys stored by the local secrets manager
+var localKeySecrets = []string{
+	secrets.ValidatorKey,
+	secrets.ValidatorBLSKey,
+	secrets.NetworkKey,
+	secrets.ValidatorKeyStaged,
+	secrets.ValidatorBLSKeyStaged,
+}
+
 // SetupLocalSecretsManager is a helper method for boilerplate local secrets manager setup
 func SetupLocalSecretsManager(dataDir string) (secrets.SecretsManager, error) {
+	return SetupEncryptedLocalSecretsManager(dataDir, "")
+}
+
+// SetupEncryptedLocalSecretsManager sets up the local secrets manager encrypting the secrets by the passphrase,
+// the secrets are stored in plaintext if the passphrase is empty
+func SetupEncryptedLocalSecretsManager(dataDir, passphrase string) (secrets.SecretsManager, error) {
 	return local.SecretsManagerFactory(
 		nil, // Local secrets manager doesn't require a config
 		&secrets.SecretsManagerParams{
 			Logger: hclog.NewNullLogger(),
 			Extra: map[string]interface{}{
-				secrets.Path: dataDir,
+				secrets.Path:       dataDir,
+				secrets.Passphrase: passphrase,
 			},
 		},
 	)
 }
 
+// HasEncryptedLocalSecrets checks if any key in the data directory is encrypted
+func HasEncryptedLocalSecrets(dataDir string) (bool, error) {
+	manager, err := SetupLocalSecretsManager(dataDir)
+	if err != nil {
+		return false, err
+	}
+
+	localManager, _ := manager.(*local.LocalSecretsManager)
+
+	for _, name := range localKeySecrets {
+		if !localManager.HasSecret(name) {
+			continue
+		}
+
+		encrypted, err := localManager.IsEncrypted(name)
+		if err != nil {
+			return false, err
+		}
+
+		if encrypted {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// EncryptLocalSecrets encrypts the plaintext keys in the data directory by the passphrase,
+// and returns the names of the encrypted keys
+func EncryptLocalSecrets(dataDir, passphrase string) ([]string, error) {
+	manager, err := SetupEncryptedLocalSecretsManager(dataDir, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	localManager, _ := manager.(*local.LocalSecretsManager)
+
+	encryptedNames := make([]string, 0, len(localKeySecrets))
+
+	for _, name := range localKeySecrets {
+		if !localManager.HasSecret(name) {
+			continue
+		}
+
+		encrypted, err := localManager.EncryptSecret(name)
+		if err != nil {
+			return encryptedNames, err
+		}
+
+		if encrypted {
+			encryptedNames = append(encryptedNames, name)
+		}
+	}
+
+	return encryptedNames, nil
+}
+
 // setupHashicorpVault is a helper method for boilerplate hashicorp vault secrets manager setup
 func setupHashicorpVault(
 	secretsConfig *secrets.SecretsManagerConfig,
@@ -69,6 +143,11 @@ func setupGCPSSM(
 
 // InitECDSAValidatorKey creates new ECDSA key and set as a validator key
 func InitECDSAValidatorKey(secretsManager secrets.SecretsManager) (types.Address, error) {
+	if signer, ok := secrets.GetSigner(secretsManager, secrets.ValidatorKey); ok {
+		// The key kept by the secrets manager is created in the secrets manager
+		return loadSigningValidatorAddress(signer)
+	}
+
 	if secretsManager.HasSecret(secrets.ValidatorKey) {
 		return types.ZeroAddress, fmt.Errorf(`secrets "%s" has been already initialized`, secrets.ValidatorKey)
 	}
@@ -117,7 +196,90 @@ func InitBLSValidatorKey(secretsManager secrets.SecretsManager) ([]byte, error)
 	return pubkeyBytes, nil
 }
 
+// StageECDSAValidatorKey creates new ECDSA key and set as a staged validator key
+// that replaces the validator key after the key rotation is applied
+func StageECDSAValidatorKey(secretsManager secrets.SecretsManager) (types.Address, error) {
+	if secretsManager.HasSecret(secrets.ValidatorKeyStaged) {
+		return types.ZeroAddress, fmt.Errorf(`secrets "%s" has been already initialized`, secrets.ValidatorKeyStaged)
+	}
+
+	validatorKey, validatorKeyEncoded, err := crypto.GenerateAndEncodeECDSAPrivateKey()
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	if setErr := secretsManager.SetSecret(
+		secrets.ValidatorKeyStaged,
+		validatorKeyEncoded,
+	); setErr != nil {
+		return types.ZeroAddress, setErr
+	}
+
+	return crypto.PubKeyToAddress(&validatorKey.PublicKey), nil
+}
+
+// StageBLSValidatorKey creates new BLS key and set as a staged validator BLS key
+// that replaces the validator BLS key after the key rotation is applied
+func StageBLSValidatorKey(secretsManager secrets.SecretsManager) ([]byte, error) {
+	if secretsManager.HasSecret(secrets.ValidatorBLSKeyStaged) {
+		return nil, fmt.Errorf(`secrets "%s" has been already initialized`, secrets.ValidatorBLSKeyStaged)
+	}
+
+	blsSecretKey, blsSecretKeyEncoded, err := crypto.GenerateAndEncodeBLSSecretKey()
+	if err != nil {
+		return nil, err
+	}
+
+	if setErr := secretsManager.SetSecret(
+		secrets.ValidatorBLSKeyStaged,
+		blsSecretKeyEncoded,
+	); setErr != nil {
+		return nil, setErr
+	}
+
+	return crypto.BLSSecretKeyToPubkeyBytes(blsSecretKey)
+}
+
+// PromoteStagedValidatorKey replaces the secret by the staged secret and removes the staged secret.
+// Each step can be retried, so the promotion interrupted in the middle is completed by calling it again
+func PromoteStagedValidatorKey(secretsManager secrets.SecretsManager, name, stagedName string) error {
+	if !secretsManager.HasSecret(stagedName) {
+		return nil
+	}
+
+	stagedKey, err := secretsManager.GetSecret(stagedName)
+	if err != nil {
+		return err
+	}
+
+	if secretsManager.HasSecret(name) {
+		currentKey, err := secretsManager.GetSecret(name)
+		if err != nil {
+			return err
+		}
+
+		if !bytes.Equal(currentKey, stagedKey) {
+			if err := secretsManager.RemoveSecret(name); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !secretsManager.HasSecret(name) {
+		if err := secretsManager.SetSecret(name, stagedKey); err != nil {
+			return err
+		}
+	}
+
+	return secretsManager.RemoveSecret(stagedName)
+}
+
 func InitNetworkingPrivateKey(secretsManager secrets.SecretsManager) (libp2pCrypto.PrivKey, error) {
+	if _, ok := secrets.GetSigner(secretsManager, secrets.NetworkKey); ok {
+		// The key kept by the secrets manager is created in the secrets manager
+		return network.ReadLibp2pKey(secretsManager)
+	}
+
 	if secretsManager.HasSecret(secrets.NetworkKey) {
 		return nil, fmt.Errorf(`secrets "%s" has been already initialized`, secrets.NetworkKey)
 	}
@@ -145,6 +307,10 @@ func LoadValidatorAddress(secretsManager secrets.SecretsManager) (types.Address,
 		return types.ZeroAddress, nil
 	}
 
+	if signer, ok := secrets.GetSigner(secretsManager, secrets.ValidatorKey); ok {
+		return loadSigningValidatorAddress(signer)
+	}
+
 	encodedKey, err := secretsManager.GetSecret(secrets.ValidatorKey)
 	if err != nil {
 		return types.ZeroAddress, err
@@ -158,6 +324,21 @@ func LoadValidatorAddress(secretsManager secrets.SecretsManager) (types.Address,
 	return crypto.PubKeyToAddress(&privateKey.PublicKey), nil
 }
 
+// loadSigningValidatorAddress returns the address of the ECDSA validator key kept by the secrets manager
+func loadSigningValidatorAddress(signer secrets.SecretsSigner) (types.Address, error) {
+	rawPubKey, err := signer.SigningPublicKey(secrets.ValidatorKey)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	pubKey, err := crypto.ParsePublicKey(rawPubKey)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	return crypto.PubKeyToAddress(pubKey), nil
+}
+
 // LoadValidatorAddress loads BLS key by SecretsManager and returns BLS Public Key
 func LoadBLSPublicKey(secretsManager secrets.SecretsManager) (string, error) {
 	if !secretsManager.HasSecret(secrets.ValidatorBLSKey) {
@@ -188,12 +369,7 @@ func LoadNodeID(secretsManager secrets.SecretsManager) (string, error) {
 		return "", nil
 	}
 
-	encodedKey, err := secretsManager.GetSecret(secrets.NetworkKey)
-	if err != nil {
-		return "", err
-	}
-
-	parsedKey, err := network.ParseLibp2pKey(encodedKey)
+	parsedKey, err := network.ReadLibp2pKey(secretsManager)
 	if err != nil {
 		return "", err
 	}
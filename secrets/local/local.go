@@ -1,6 +1,8 @@
 package local
 
 import (
+	"bytes"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"os"
@@ -8,10 +10,20 @@ import (
 	"sync"
 
 	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/keystore"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/hashicorp/go-hclog"
 )
 
+var (
+	// ErrPassphraseRequired is returned on reading the encrypted secret without the passphrase
+	ErrPassphraseRequired = errors.New("secret is encrypted, passphrase required")
+
+	errNoPassphrase      = errors.New("no passphrase specified for encrypting secrets")
+	errNotHexSecret      = errors.New("only hex encoded secrets can be encrypted")
+	errInvalidPassphrase = errors.New("invalid type assertion for passphrase")
+)
+
 // LocalSecretsManager is a SecretsManager that
 // stores secrets locally on disk
 type LocalSecretsManager struct {
@@ -26,6 +38,17 @@ type LocalSecretsManager struct {
 
 	// Mux for the secretPathMap
 	secretPathMapLock sync.RWMutex
+
+	// Passphrase encrypting the secrets written to disk, they are written as is if it's empty
+	passphrase string
+
+	// The scrypt parameters deriving the encryption key from the passphrase
+	scryptN int
+	scryptP int
+
+	// Map of the decrypted secrets, so the key isn't derived on every read
+	decrypted     map[string][]byte
+	decryptedLock sync.Mutex
 }
 
 // SecretsManagerFactory implements the factory method
@@ -37,6 +60,9 @@ func SecretsManagerFactory(
 	localManager := &LocalSecretsManager{
 		logger:        params.Logger.Named(string(secrets.Local)),
 		secretPathMap: make(map[string]string),
+		scryptN:       keystore.StandardScryptN,
+		scryptP:       keystore.StandardScryptP,
+		decrypted:     make(map[string][]byte),
 	}
 
 	// Grab the path to the working directory
@@ -50,6 +76,13 @@ func SecretsManagerFactory(
 		return nil, errors.New("invalid type assertion")
 	}
 
+	// Grab the passphrase encrypting the secrets, if any
+	if passphrase, ok := params.Extra[secrets.Passphrase]; ok {
+		if localManager.passphrase, ok = passphrase.(string); !ok {
+			return nil, errInvalidPassphrase
+		}
+	}
+
 	// Run the initial setup
 	_ = localManager.Setup()
 
@@ -84,6 +117,20 @@ func (l *LocalSecretsManager) Setup() error {
 		secrets.ValidatorBLSKeyLocal,
 	)
 
+	// baseDir/consensus/validator.key.staged
+	l.secretPathMap[secrets.ValidatorKeyStaged] = filepath.Join(
+		l.path,
+		secrets.ConsensusFolderLocal,
+		secrets.ValidatorKeyStagedLocal,
+	)
+
+	// baseDir/consensus/validator-bls.key.staged
+	l.secretPathMap[secrets.ValidatorBLSKeyStaged] = filepath.Join(
+		l.path,
+		secrets.ConsensusFolderLocal,
+		secrets.ValidatorBLSKeyStagedLocal,
+	)
+
 	// baseDir/libp2p/libp2p.key
 	l.secretPathMap[secrets.NetworkKey] = filepath.Join(
 		l.path,
@@ -114,9 +161,47 @@ func (l *LocalSecretsManager) GetSecret(name string) ([]byte, error) {
 		)
 	}
 
+	if !keystore.IsEncryptedKey(secret) {
+		return secret, nil
+	}
+
+	return l.decryptSecret(name, secret)
+}
+
+// decryptSecret decrypts the secret read from disk into the hex encoded key
+func (l *LocalSecretsManager) decryptSecret(name string, encrypted []byte) ([]byte, error) {
+	l.decryptedLock.Lock()
+	defer l.decryptedLock.Unlock()
+
+	if secret, ok := l.decrypted[name]; ok {
+		return secret, nil
+	}
+
+	if l.passphrase == "" {
+		return nil, fmt.Errorf("%w: %s", ErrPassphraseRequired, name)
+	}
+
+	key, err := keystore.DecryptKey(encrypted, l.passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt secret (%s), %w", name, err)
+	}
+
+	secret := []byte(hex.EncodeToString(key))
+	l.decrypted[name] = secret
+
 	return secret, nil
 }
 
+// encryptSecret encrypts the hex encoded key into the format written to disk
+func (l *LocalSecretsManager) encryptSecret(value []byte) ([]byte, error) {
+	key, err := hex.DecodeString(string(bytes.TrimSpace(value)))
+	if err != nil {
+		return nil, errNotHexSecret
+	}
+
+	return keystore.EncryptKey(key, l.passphrase, l.scryptN, l.scryptP)
+}
+
 // SetSecret saves the local SecretsManager's secret to disk
 func (l *LocalSecretsManager) SetSecret(name string, value []byte) error {
 	// If the data directory is not specified, skip write
@@ -139,8 +224,20 @@ func (l *LocalSecretsManager) SetSecret(name string, value []byte) error {
 			secretPath,
 		)
 	}
+
+	// Encrypt the secret if the passphrase is set
+	data := value
+
+	if l.passphrase != "" {
+		var err error
+
+		if data, err = l.encryptSecret(value); err != nil {
+			return err
+		}
+	}
+
 	// Write the secret to disk
-	if err := os.WriteFile(secretPath, value, os.ModePerm); err != nil {
+	if err := os.WriteFile(secretPath, data, os.ModePerm); err != nil {
 		return fmt.Errorf(
 			"unable to write secret to disk (%s), %w",
 			secretPath,
@@ -148,14 +245,30 @@ func (l *LocalSecretsManager) SetSecret(name string, value []byte) error {
 		)
 	}
 
+	if l.passphrase != "" {
+		// Keep the written secret, so the key isn't derived again to read it
+		l.decryptedLock.Lock()
+		l.decrypted[name] = value
+		l.decryptedLock.Unlock()
+	}
+
 	return nil
 }
 
-// HasSecret checks if the secret is present on disk
+// HasSecret checks if the secret is present on disk,
+// the encrypted secret is present even if it can't be decrypted
 func (l *LocalSecretsManager) HasSecret(name string) bool {
-	_, err := l.GetSecret(name)
+	l.secretPathMapLock.RLock()
+	secretPath, ok := l.secretPathMap[name]
+	l.secretPathMapLock.RUnlock()
+
+	if !ok {
+		return false
+	}
 
-	return err == nil
+	info, err := os.Stat(secretPath)
+
+	return err == nil && !info.IsDir()
 }
 
 // RemoveSecret removes the local SecretsManager's secret from disk
@@ -168,11 +281,84 @@ func (l *LocalSecretsManager) RemoveSecret(name string) error {
 		return secrets.ErrSecretNotFound
 	}
 
-	delete(l.secretPathMap, name)
-
 	if removeErr := os.Remove(secretPath); removeErr != nil {
 		return fmt.Errorf("unable to remove secret, %w", removeErr)
 	}
 
+	l.decryptedLock.Lock()
+	delete(l.decrypted, name)
+	l.decryptedLock.Unlock()
+
 	return nil
 }
+
+// IsEncrypted checks if the secret is stored encrypted on disk
+func (l *LocalSecretsManager) IsEncrypted(name string) (bool, error) {
+	l.secretPathMapLock.RLock()
+	secretPath, ok := l.secretPathMap[name]
+	l.secretPathMapLock.RUnlock()
+
+	if !ok {
+		return false, secrets.ErrSecretNotFound
+	}
+
+	secret, err := os.ReadFile(secretPath)
+	if err != nil {
+		return false, fmt.Errorf("unable to read secret from disk (%s), %w", secretPath, err)
+	}
+
+	return keystore.IsEncryptedKey(secret), nil
+}
+
+// EncryptSecret encrypts the plaintext secret on disk by the passphrase.
+// It returns false if the secret is encrypted already, after checking the passphrase decrypts it
+func (l *LocalSecretsManager) EncryptSecret(name string) (bool, error) {
+	if l.passphrase == "" {
+		return false, errNoPassphrase
+	}
+
+	l.secretPathMapLock.RLock()
+	secretPath, ok := l.secretPathMap[name]
+	l.secretPathMapLock.RUnlock()
+
+	if !ok {
+		return false, secrets.ErrSecretNotFound
+	}
+
+	info, err := os.Stat(secretPath)
+	if err != nil {
+		return false, fmt.Errorf("unable to read secret from disk (%s), %w", secretPath, err)
+	}
+
+	secret, err := os.ReadFile(secretPath)
+	if err != nil {
+		return false, fmt.Errorf("unable to read secret from disk (%s), %w", secretPath, err)
+	}
+
+	if keystore.IsEncryptedKey(secret) {
+		// Don't leave the secrets encrypted by different passphrases
+		_, err := l.decryptSecret(name, secret)
+
+		return false, err
+	}
+
+	encrypted, err := l.encryptSecret(secret)
+	if err != nil {
+		return false, fmt.Errorf("unable to encrypt secret (%s), %w", name, err)
+	}
+
+	// Replace the plaintext secret at once, so it's never lost on a failure
+	tmpPath := secretPath + ".tmp"
+
+	if err := os.WriteFile(tmpPath, encrypted, info.Mode().Perm()); err != nil {
+		return false, fmt.Errorf("unable to write secret to disk (%s), %w", tmpPath, err)
+	}
+
+	if err := os.Rename(tmpPath, secretPath); err != nil {
+		_ = os.Remove(tmpPath)
+
+		return false, fmt.Errorf("unable to replace secret on disk (%s), %w", secretPath, err)
+	}
+
+	return true, nil
+}
@@ -3,14 +3,17 @@ package local
 import (
 	"encoding/hex"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/keystore"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/hashicorp/go-hclog"
 	libp2pCrypto "github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestLocalSecretsManagerFactory(t *testing.T) {
@@ -272,3 +275,114 @@ func TestLocalSecretsManager_RemoveSecret(t *testing.T) {
 		})
 	}
 }
+
+// getEncryptedLocalSecretsManager is a helper method for creating an instance of the
+// local secrets manager encrypting the secrets in the directory
+func getEncryptedLocalSecretsManager(t *testing.T, path, passphrase string) *LocalSecretsManager {
+	t.Helper()
+
+	manager, err := SecretsManagerFactory(nil, &secrets.SecretsManagerParams{
+		Logger: hclog.NewNullLogger(),
+		Extra: map[string]interface{}{
+			secrets.Path:       path,
+			secrets.Passphrase: passphrase,
+		},
+	})
+	require.NoError(t, err)
+
+	localManager, ok := manager.(*LocalSecretsManager)
+	require.True(t, ok)
+
+	// the light parameters keep the test fast
+	localManager.scryptN = keystore.LightScryptN
+	localManager.scryptP = keystore.LightScryptP
+
+	return localManager
+}
+
+func TestLocalSecretsManager_EncryptedSecret(t *testing.T) {
+	t.Parallel()
+
+	_, validatorKeyEncoded, err := crypto.GenerateAndEncodeECDSAPrivateKey()
+	require.NoError(t, err)
+
+	path := t.TempDir()
+
+	manager := getEncryptedLocalSecretsManager(t, path, "passphrase")
+	require.NoError(t, manager.SetSecret(secrets.ValidatorKey, validatorKeyEncoded))
+
+	// the key isn't stored in plaintext
+	raw, err := os.ReadFile(filepath.Join(path, secrets.ConsensusFolderLocal, secrets.ValidatorKeyLocal))
+	require.NoError(t, err)
+	assert.True(t, keystore.IsEncryptedKey(raw))
+	assert.NotContains(t, string(raw), string(validatorKeyEncoded))
+
+	encrypted, err := manager.IsEncrypted(secrets.ValidatorKey)
+	require.NoError(t, err)
+	assert.True(t, encrypted)
+
+	value, err := manager.GetSecret(secrets.ValidatorKey)
+	require.NoError(t, err)
+	assert.Equal(t, validatorKeyEncoded, value)
+
+	// the key is present but can't be read without the passphrase
+	plainManager := getEncryptedLocalSecretsManager(t, path, "")
+	assert.True(t, plainManager.HasSecret(secrets.ValidatorKey))
+
+	_, err = plainManager.GetSecret(secrets.ValidatorKey)
+	assert.ErrorIs(t, err, ErrPassphraseRequired)
+
+	_, err = getEncryptedLocalSecretsManager(t, path, "other").GetSecret(secrets.ValidatorKey)
+	assert.ErrorIs(t, err, keystore.ErrDecrypt)
+
+	// only the hex encoded keys are encrypted
+	assert.ErrorIs(t, manager.SetSecret(secrets.NetworkKey, []byte("not hex")), errNotHexSecret)
+	assert.False(t, manager.HasSecret(secrets.NetworkKey))
+
+	// the removed key is not kept decrypted
+	require.NoError(t, manager.RemoveSecret(secrets.ValidatorKey))
+
+	_, err = manager.GetSecret(secrets.ValidatorKey)
+	assert.Error(t, err)
+}
+
+func TestLocalSecretsManager_EncryptSecret(t *testing.T) {
+	t.Parallel()
+
+	_, validatorKeyEncoded, err := crypto.GenerateAndEncodeECDSAPrivateKey()
+	require.NoError(t, err)
+
+	path := t.TempDir()
+
+	plainManager := getEncryptedLocalSecretsManager(t, path, "")
+	require.NoError(t, plainManager.SetSecret(secrets.ValidatorKey, validatorKeyEncoded))
+
+	_, err = plainManager.EncryptSecret(secrets.ValidatorKey)
+	assert.ErrorIs(t, err, errNoPassphrase)
+
+	manager := getEncryptedLocalSecretsManager(t, path, "passphrase")
+
+	encrypted, err := manager.EncryptSecret(secrets.ValidatorKey)
+	require.NoError(t, err)
+	assert.True(t, encrypted)
+
+	isEncrypted, err := manager.IsEncrypted(secrets.ValidatorKey)
+	require.NoError(t, err)
+	assert.True(t, isEncrypted)
+
+	value, err := getEncryptedLocalSecretsManager(t, path, "passphrase").GetSecret(secrets.ValidatorKey)
+	require.NoError(t, err)
+	assert.Equal(t, validatorKeyEncoded, value)
+
+	// the encrypted key is left as is
+	encrypted, err = manager.EncryptSecret(secrets.ValidatorKey)
+	require.NoError(t, err)
+	assert.False(t, encrypted)
+
+	// the key encrypted by another passphrase isn't mixed up
+	_, err = getEncryptedLocalSecretsManager(t, path, "other").EncryptSecret(secrets.ValidatorKey)
+	assert.ErrorIs(t, err, keystore.ErrDecrypt)
+
+	_, err = manager.EncryptSecret(secrets.NetworkKey)
+	assert.Error(t, err)
+}
@@ -19,6 +19,9 @@ const (
 
 	// Name is the name of the current node
 	Name = "name"
+
+	// Passphrase is the passphrase encrypting the secrets stored on disk
+	Passphrase = "passphrase"
 )
 
 // Define constant names for available secrets
@@ -31,6 +34,12 @@ const (
 
 	// NetworkKey is the libp2p private key secret used for networking
 	NetworkKey = "network-key"
+
+	// ValidatorKeyStaged is the private key secret replacing the validator key after the key rotation
+	ValidatorKeyStaged = "validator-key-staged"
+
+	// ValidatorBLSKeyStaged is the bls secret key replacing the validator bls key after the key rotation
+	ValidatorBLSKeyStaged = "validator-bls-key-staged"
 )
 
 // Define constant file names for the local StorageManager
@@ -38,6 +47,9 @@ const (
 	ValidatorKeyLocal    = "validator.key"
 	ValidatorBLSKeyLocal = "validator-bls.key"
 	NetworkKeyLocal      = "libp2p.key"
+
+	ValidatorKeyStagedLocal    = "validator.key.staged"
+	ValidatorBLSKeyStagedLocal = "validator-bls.key.staged"
 )
 
 // Define constant folder names for the local StorageManager
@@ -48,6 +60,9 @@ const (
 
 var (
 	ErrSecretNotFound = errors.New("secret not found")
+
+	// ErrSigningKey is returned on the access to the key which never leaves the secrets manager
+	ErrSigningKey = errors.New("secret is a signing key kept by the secrets manager")
 )
 
 type SecretsManagerType string
@@ -86,6 +101,33 @@ type SecretsManager interface {
 	RemoveSecret(name string) error
 }
 
+// SecretsSigner is implemented by the secrets managers that keep some of the keys in the service
+// and sign with them, so those keys never leave it. The other methods of SecretsManager
+// return ErrSigningKey for the signing keys, except HasSecret
+type SecretsSigner interface {
+	// IsSigningKey checks if the secret is a key kept by the secrets manager for signing
+	IsSigningKey(name string) bool
+
+	// SigningPublicKey returns the public key of the signing key,
+	// in the format of the secret (marshaled libp2p public key for NetworkKey,
+	// uncompressed ECDSA public key for ValidatorKey)
+	SigningPublicKey(name string) ([]byte, error)
+
+	// Sign signs the data with the signing key. ValidatorKey signs the digest into
+	// the recoverable signature of 65 bytes, NetworkKey signs the message by the libp2p key type
+	Sign(name string, data []byte) ([]byte, error)
+}
+
+// GetSigner returns the SecretsSigner of the secrets manager if it signs with the secret
+func GetSigner(manager SecretsManager, name string) (SecretsSigner, bool) {
+	signer, ok := manager.(SecretsSigner)
+	if !ok || !signer.IsSigningKey(name) {
+		return nil, false
+	}
+
+	return signer, true
+}
+
 // SecretsManagerParams defines the configuration params for the
 // secrets manager
 type SecretsManagerParams struct {
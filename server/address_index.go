@@ -0,0 +1,43 @@
+package server
+
+// startAddressIndexer indexes the blocks written before the address index was enabled in the background,
+// the new blocks are indexed by the blockchain on write
+func (s *Server) startAddressIndexer() {
+	if !s.config.AddressIndex {
+		return
+	}
+
+	s.addressIndexerStopCh = make(chan struct{})
+	s.addressIndexerDoneCh = make(chan struct{})
+
+	go func() {
+		defer close(s.addressIndexerDoneCh)
+
+		tail, _ := s.blockchain.AddressIndexTail()
+		if tail == 0 {
+			return
+		}
+
+		s.logger.Info("backfilling address index", "from", tail-1)
+
+		if err := s.blockchain.BackfillAddressIndex(s.addressIndexerStopCh); err != nil {
+			s.logger.Error("failed to backfill address index", "err", err)
+
+			return
+		}
+
+		if tail, _ = s.blockchain.AddressIndexTail(); tail == 0 {
+			s.logger.Info("address index backfilled")
+		}
+	}()
+}
+
+// stopAddressIndexer stops the backfill of the address index and waits for the batch in progress
+func (s *Server) stopAddressIndexer() {
+	if s.addressIndexerStopCh == nil {
+		return
+	}
+
+	close(s.addressIndexerStopCh)
+	<-s.addressIndexerDoneCh
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"github.com/0xPolygon/polygon-edge/blockchain"
+)
+
+// startBloomIndexer adds the sections of the confirmed blocks to the bloom bits index on the new head,
+// the index lets eth_getLogs skip the blocks which can't contain the matching logs
+func (s *Server) startBloomIndexer() {
+	s.bloomIndexerSub = s.blockchain.SubscribeEvents()
+	s.bloomIndexerStopCh = make(chan struct{})
+	s.bloomIndexerDoneCh = make(chan struct{})
+
+	index := func() {
+		if err := s.blockchain.IndexBloomBits(s.bloomIndexerStopCh); err != nil {
+			s.logger.Error("failed to index bloom bits", "err", err)
+		}
+	}
+
+	go func() {
+		defer close(s.bloomIndexerDoneCh)
+
+		index()
+
+		for {
+			evnt := s.bloomIndexerSub.GetEvent()
+			if evnt == nil {
+				return
+			}
+
+			if len(evnt.NewChain) == 0 || evnt.Type == blockchain.EventFork {
+				continue
+			}
+
+			index()
+		}
+	}()
+}
+
+// stopBloomIndexer stops the bloom bits index and waits for the section in progress
+func (s *Server) stopBloomIndexer() {
+	if s.bloomIndexerSub == nil {
+		return
+	}
+
+	close(s.bloomIndexerStopCh)
+	s.bloomIndexerSub.Close()
+	<-s.bloomIndexerDoneCh
+}
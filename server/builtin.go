@@ -1,15 +1,21 @@
 package server
 
 import (
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/pebble"
 	"github.com/0xPolygon/polygon-edge/consensus"
 	consensusDev "github.com/0xPolygon/polygon-edge/consensus/dev"
 	consensusDummy "github.com/0xPolygon/polygon-edge/consensus/dummy"
 	consensusIBFT "github.com/0xPolygon/polygon-edge/consensus/ibft"
+	"github.com/0xPolygon/polygon-edge/helper/dbengine"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/secrets/awsssm"
 	"github.com/0xPolygon/polygon-edge/secrets/gcpssm"
 	"github.com/0xPolygon/polygon-edge/secrets/hashicorpvault"
 	"github.com/0xPolygon/polygon-edge/secrets/local"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/hashicorp/go-hclog"
 )
 
 type ConsensusType string
@@ -35,6 +41,18 @@ var secretsManagerBackends = map[secrets.SecretsManagerType]secrets.SecretsManag
 	secrets.GCPSSM:         gcpssm.SecretsManagerFactory,
 }
 
+// storageBackends defines the blockchain storage factories for different db engines
+var storageBackends = map[dbengine.Engine]storage.Factory{
+	dbengine.LevelDB: leveldb.Factory,
+	dbengine.Pebble:  pebble.Factory,
+}
+
+// stateStorageBackends defines the trie storage factories for different db engines
+var stateStorageBackends = map[dbengine.Engine]func(string, hclog.Logger) (itrie.Storage, error){
+	dbengine.LevelDB: itrie.NewLevelDBStorage,
+	dbengine.Pebble:  itrie.NewPebbleStorage,
+}
+
 func ConsensusSupported(value string) bool {
 	_, ok := consensusBackends[ConsensusType(value)]
 
@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/helper/dbengine"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 )
@@ -30,12 +31,22 @@ type Config struct {
 	Network   *network.Config
 
 	DataDir     string
+	DBEngine    dbengine.Engine
 	RestoreFile *string
 
+	Freezer *Freezer
+
+	AddressIndex bool
+
 	Seal bool
 
+	LightMode bool
+
 	SecretsManager *secrets.SecretsManagerConfig
 
+	// KeystorePassphrase is the passphrase encrypting the secrets of the local secrets manager
+	KeystorePassphrase string
+
 	LogLevel hclog.Level
 
 	JSONLogFormat bool
@@ -48,10 +59,20 @@ type Telemetry struct {
 	PrometheusAddr *net.TCPAddr
 }
 
+// Freezer holds the config details for the store of the old blocks
+type Freezer struct {
+	// Threshold is the number of the latest blocks kept in the blockchain storage,
+	// the freezer is disabled if it's zero
+	Threshold   uint64
+	Compression bool
+}
+
 // JSONRPC holds the config details for the JSON-RPC server
 type JSONRPC struct {
 	JSONRPCAddr              *net.TCPAddr
 	AccessControlAllowOrigin []string
 	BatchLengthLimit         uint64
 	BlockRangeLimit          uint64
+	IndexedBlockRangeLimit   uint64
+	AdminEnabled             bool
 }
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"path/filepath"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/freezer"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// freezerDir is the directory of the freezer in the data dir
+	freezerDir = "ancient"
+)
+
+// setupFreezer wraps the blockchain storage with the freezer
+// moving the blocks older than the threshold to the flat files in the data dir
+func (s *Server) setupFreezer(db storage.Storage, logger hclog.Logger) (storage.Storage, error) {
+	f, err := freezer.Open(
+		filepath.Join(s.config.DataDir, freezerDir),
+		s.config.Freezer.Compression,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	freezerStorage, err := freezer.NewStorage(logger, db, f, s.config.Freezer.Threshold)
+	if err != nil {
+		_ = f.Close()
+
+		return nil, err
+	}
+
+	s.freezerStorage = freezerStorage
+
+	return freezerStorage, nil
+}
+
+// startFreezer starts moving the old blocks to the freezer on the new head.
+// IBFT finalizes the blocks instantly, so the blocks older than the threshold can't be reorganized
+func (s *Server) startFreezer() {
+	if s.freezerStorage == nil {
+		return
+	}
+
+	s.freezerSub = s.blockchain.SubscribeEvents()
+	s.freezerDoneCh = make(chan struct{})
+
+	freeze := func(head uint64) {
+		if err := s.freezerStorage.Freeze(head); err != nil {
+			s.logger.Error("failed to move blocks to freezer", "err", err)
+		}
+	}
+
+	go func() {
+		defer close(s.freezerDoneCh)
+
+		freeze(s.blockchain.Header().Number)
+
+		for {
+			evnt := s.freezerSub.GetEvent()
+			if evnt == nil {
+				return
+			}
+
+			if len(evnt.NewChain) == 0 || evnt.Type == blockchain.EventFork {
+				continue
+			}
+
+			freeze(evnt.Header().Number)
+		}
+	}()
+}
+
+// stopFreezer waits for the move to the freezer in progress
+func (s *Server) stopFreezer() {
+	if s.freezerSub == nil {
+		return
+	}
+
+	s.freezerSub.Close()
+	<-s.freezerDoneCh
+}
@@ -0,0 +1,294 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/consensus"
+	consensusIBFT "github.com/0xPolygon/polygon-edge/consensus/ibft"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
+	"github.com/0xPolygon/polygon-edge/light"
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/syncer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// lightRequestTimeout is the timeout for the requests of the light client to the peers
+	lightRequestTimeout = 30 * time.Second
+)
+
+var (
+	errLightClientUnsupportedEngine = errors.New("light client supports only IBFT consensus")
+	errUnsupportedInLightMode       = errors.New("not supported in light mode")
+)
+
+// setupLightClient sets up the light client which follows the headers only
+// and fetches the state with the proofs from the full nodes
+func (s *Server) setupLightClient() error {
+	engineName := s.config.Chain.Params.GetEngine()
+	if ConsensusType(engineName) != IBFTConsensus {
+		return fmt.Errorf("%w, got '%s'", errLightClientUnsupportedEngine, engineName)
+	}
+
+	// compute the genesis root state in memory, the light client doesn't keep the state
+	genesisExecutor := state.NewExecutor(s.config.Chain.Params, itrie.NewState(itrie.NewMemoryStorage()), s.logger)
+	s.config.Chain.Genesis.StateRoot = genesisExecutor.WriteGenesis(s.config.Chain.Genesis.Alloc)
+
+	// use the eip155 signer
+	signer := crypto.NewEIP155Signer(uint64(s.config.Chain.Params.ChainID))
+
+	// blockchain object keeping only headers, it doesn't execute blocks
+	db, err := s.newBlockchainStorage(s.logger)
+	if err != nil {
+		return err
+	}
+
+	bc, err := blockchain.NewBlockchain(s.logger, db, s.config.Chain, nil, nil, signer)
+	if err != nil {
+		return err
+	}
+
+	s.blockchain = bc
+
+	engineConfig, ok := s.config.Chain.Params.Engine[engineName].(map[string]interface{})
+	if !ok {
+		engineConfig = map[string]interface{}{}
+	}
+
+	verifier, err := consensusIBFT.NewLightVerifier(&consensus.Params{
+		Config: &consensus.Config{
+			Params: s.config.Chain.Params,
+			Config: engineConfig,
+			Path:   filepath.Join(s.config.DataDir, "consensus"),
+		},
+		Blockchain:     s.blockchain,
+		Logger:         s.logger,
+		SecretsManager: s.secretsManager,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.lightVerifier = verifier
+	s.blockchain.SetConsensus(verifier)
+
+	// IBFT uses a custom hash function, so the genesis must be computed after setting the verifier
+	if err := s.blockchain.ComputeGenesis(); err != nil {
+		return err
+	}
+
+	// load the validator snapshots
+	if err := verifier.Initialize(); err != nil {
+		return err
+	}
+
+	// setup and start grpc server
+	if err := s.setupGRPC(); err != nil {
+		return err
+	}
+
+	if err := s.network.Start(); err != nil {
+		return err
+	}
+
+	s.lightSyncer = syncer.NewLightSyncer(
+		s.logger,
+		s.network,
+		s.blockchain,
+		lightRequestTimeout,
+		s.config.Chain.Params.Checkpoints,
+	)
+	if err := s.lightSyncer.Start(); err != nil {
+		return err
+	}
+
+	go func() {
+		if err := s.lightSyncer.Sync(); err != nil {
+			s.logger.Error("light syncer stopped", "err", err)
+		}
+	}()
+
+	// setup and start jsonrpc server
+	return s.setupLightJSONRPC()
+}
+
+// setupLightJSONRPC sets up the JSONRPC server serving the verified state of the light client
+func (s *Server) setupLightJSONRPC() error {
+	hub := &lightJSONRPCHub{
+		proofClient: light.NewProofClient(s.logger, s.network, lightRequestTimeout),
+		syncer:      s.lightSyncer,
+		forks:       s.config.Chain.Params.Forks,
+		Blockchain:  s.blockchain,
+		Server:      s.network,
+	}
+
+	conf := &jsonrpc.Config{
+		Store:                    hub,
+		Addr:                     s.config.JSONRPC.JSONRPCAddr,
+		ChainID:                  uint64(s.config.Chain.Params.ChainID),
+		ChainName:                s.chain.Name,
+		AccessControlAllowOrigin: s.config.JSONRPC.AccessControlAllowOrigin,
+		PriceLimit:               s.config.PriceLimit,
+		BatchLengthLimit:         s.config.JSONRPC.BatchLengthLimit,
+		BlockRangeLimit:          s.config.JSONRPC.BlockRangeLimit,
+		// the light node doesn't keep the bloom bits index
+		IndexedBlockRangeLimit: s.config.JSONRPC.BlockRangeLimit,
+		AdminEnabled:           s.config.JSONRPC.AdminEnabled,
+	}
+
+	srv, err := jsonrpc.NewJSONRPC(s.logger, conf)
+	if err != nil {
+		return err
+	}
+
+	s.jsonrpcServer = srv
+
+	return nil
+}
+
+// lightJSONRPCHub serves the JSON-RPC requests in light mode.
+// The state is fetched from the peers and verified against the state root in the verified headers
+type lightJSONRPCHub struct {
+	proofClient *light.ProofClient
+	syncer      syncer.LightSyncer
+	forks       *chain.Forks
+
+	*blockchain.Blockchain
+	*network.Server
+}
+
+// SetHead rewinds the verified headers to the header with the given number
+func (j *lightJSONRPCHub) SetHead(number uint64) error {
+	_, err := j.Blockchain.SetHead(number)
+
+	return err
+}
+
+func (j *lightJSONRPCHub) GetPeers() int {
+	return len(j.Server.Peers())
+}
+
+func (j *lightJSONRPCHub) GetAccount(root types.Hash, addr types.Address) (*jsonrpc.Account, error) {
+	acct, err := j.proofClient.GetAccount(root, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if acct == nil {
+		return nil, jsonrpc.ErrStateNotFound
+	}
+
+	return &jsonrpc.Account{
+		Nonce:   acct.Nonce,
+		Balance: new(big.Int).Set(acct.Balance),
+	}, nil
+}
+
+// GetForksInTime returns the active forks at the given block height
+func (j *lightJSONRPCHub) GetForksInTime(blockNumber uint64) chain.ForksInTime {
+	return j.forks.At(blockNumber)
+}
+
+func (j *lightJSONRPCHub) GetStorage(stateRoot types.Hash, addr types.Address, slot types.Hash) ([]byte, error) {
+	acct, err := j.proofClient.GetAccount(stateRoot, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if acct == nil {
+		return nil, jsonrpc.ErrStateNotFound
+	}
+
+	res, err := j.proofClient.GetStorage(stateRoot, addr, slot)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Bytes(), nil
+}
+
+func (j *lightJSONRPCHub) GetCode(root types.Hash, addr types.Address) ([]byte, error) {
+	code, err := j.proofClient.GetCode(root, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if code == nil {
+		return nil, jsonrpc.ErrStateNotFound
+	}
+
+	return code, nil
+}
+
+// GetNonce returns the nonce of the account at the latest header, the light client has no pool
+func (j *lightJSONRPCHub) GetNonce(addr types.Address) uint64 {
+	acct, err := j.proofClient.GetAccount(j.Header().StateRoot, addr)
+	if err != nil || acct == nil {
+		return 0
+	}
+
+	return acct.Nonce
+}
+
+func (j *lightJSONRPCHub) AddTx(tx *types.Transaction) error {
+	return errUnsupportedInLightMode
+}
+
+func (j *lightJSONRPCHub) GetPendingTx(txHash types.Hash) (*types.Transaction, bool) {
+	return nil, false
+}
+
+func (j *lightJSONRPCHub) GetTxs(inclQueued bool) (
+	map[types.Address][]*types.Transaction,
+	map[types.Address][]*types.Transaction,
+) {
+	return map[types.Address][]*types.Transaction{}, map[types.Address][]*types.Transaction{}
+}
+
+func (j *lightJSONRPCHub) GetCapacity() (uint64, uint64) {
+	return 0, 0
+}
+
+func (j *lightJSONRPCHub) ApplyTxn(*types.Header, *types.Transaction) (*runtime.ExecutionResult, error) {
+	return nil, errUnsupportedInLightMode
+}
+
+func (j *lightJSONRPCHub) TraceBlock(*types.Block, tracer.Tracer) ([]interface{}, error) {
+	return nil, errUnsupportedInLightMode
+}
+
+func (j *lightJSONRPCHub) TraceTxn(*types.Block, types.Hash, tracer.Tracer) (interface{}, error) {
+	return nil, errUnsupportedInLightMode
+}
+
+func (j *lightJSONRPCHub) TraceCall(*types.Transaction, *types.Header, tracer.Tracer) (interface{}, error) {
+	return nil, errUnsupportedInLightMode
+}
+
+func (j *lightJSONRPCHub) GetValidatorStats() (*jsonrpc.ValidatorStats, error) {
+	return nil, errUnsupportedInLightMode
+}
+
+func (j *lightJSONRPCHub) GetTransactionsByAddress(
+	types.Address,
+	jsonrpc.TxCursor,
+	uint64,
+) (*jsonrpc.AddressTransactions, error) {
+	return nil, errUnsupportedInLightMode
+}
+
+func (j *lightJSONRPCHub) GetSyncProgression() *progress.Progression {
+	return j.syncer.GetSyncProgression()
+}
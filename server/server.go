@@ -13,13 +13,18 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/archive"
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/freezer"
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/consensus"
+	consensusIBFT "github.com/0xPolygon/polygon-edge/consensus/ibft"
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	configHelper "github.com/0xPolygon/polygon-edge/helper/config"
+	"github.com/0xPolygon/polygon-edge/helper/dbengine"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/jsonrpc"
+	"github.com/0xPolygon/polygon-edge/light"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server/proto"
@@ -27,6 +32,7 @@ import (
 	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/syncer"
 	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
@@ -70,13 +76,137 @@ type Server struct {
 
 	// restore
 	restoreProgression *progress.ProgressionWrapper
+
+	// service serving the state proofs to light clients
+	proofService light.ProofService
+
+	// light client
+	lightSyncer   syncer.LightSyncer
+	lightVerifier *consensusIBFT.LightVerifier
+
+	// store of the old blocks
+	freezerStorage *freezer.Storage
+	freezerSub     blockchain.Subscription
+	freezerDoneCh  chan struct{}
+
+	// backfill of the address index
+	addressIndexerStopCh chan struct{}
+	addressIndexerDoneCh chan struct{}
+
+	// bloom bits index of the blocks for the log queries
+	bloomIndexerSub    blockchain.Subscription
+	bloomIndexerStopCh chan struct{}
+	bloomIndexerDoneCh chan struct{}
 }
 
+var errSetHeadWhileSealing = errors.New("the chain can't be rewound while the node is sealing")
+
 var dirPaths = []string{
 	"blockchain",
 	"trie",
 }
 
+// newBlockchainStorage opens the blockchain storage in the data dir with the configured db engine
+func (s *Server) newBlockchainStorage(logger hclog.Logger) (storage.Storage, error) {
+	return openBlockchainStorage(s.config.DataDir, s.config.DBEngine, logger)
+}
+
+// openBlockchainStorage opens the blockchain storage in the data dir with the db engine
+func openBlockchainStorage(dataDir string, engine dbengine.Engine, logger hclog.Logger) (storage.Storage, error) {
+	factory, ok := storageBackends[engine]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", dbengine.ErrUnknownEngine, engine)
+	}
+
+	return factory(
+		map[string]interface{}{
+			"path": filepath.Join(dataDir, "blockchain"),
+		},
+		logger,
+	)
+}
+
+// OpenBlockchainStorage opens the blockchain storage in the data dir of the stopped node
+// with the db engine recorded in the data dir, including the freezer if it exists
+func OpenBlockchainStorage(dataDir string, logger hclog.Logger) (storage.Storage, error) {
+	engine, err := dbengine.ReadEngine(dataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if engine == "" {
+		return nil, dbengine.ErrNoDatabase
+	}
+
+	db, err := openBlockchainStorage(dataDir, engine, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	freezerPath := filepath.Join(dataDir, freezerDir)
+	if _, err := os.Stat(freezerPath); errors.Is(err, os.ErrNotExist) {
+		return db, nil
+	}
+
+	// the blocks are not moved to the freezer with the zero threshold
+	f, err := freezer.Open(freezerPath, false)
+	if err != nil {
+		_ = db.Close()
+
+		return nil, err
+	}
+
+	freezerStorage, err := freezer.NewStorage(logger, db, f, 0)
+	if err != nil {
+		_ = f.Close()
+		_ = db.Close()
+
+		return nil, err
+	}
+
+	return freezerStorage, nil
+}
+
+// OpenStateStorage opens the trie storage in the data dir of the stopped node
+// with the db engine recorded in the data dir
+func OpenStateStorage(dataDir string, logger hclog.Logger) (itrie.Storage, error) {
+	engine, err := dbengine.ReadEngine(dataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if engine == "" {
+		return nil, dbengine.ErrNoDatabase
+	}
+
+	factory, ok := stateStorageBackends[engine]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", dbengine.ErrUnknownEngine, engine)
+	}
+
+	return factory(filepath.Join(dataDir, "trie"), logger)
+}
+
+// SetupDataDir creates the data dir of the stopped node and records the db engine
+// if the data dir has no databases yet
+func SetupDataDir(dataDir string, engine dbengine.Engine) error {
+	if err := common.SetupDataDir(dataDir, dirPaths); err != nil {
+		return err
+	}
+
+	return dbengine.EnsureEngine(dataDir, engine)
+}
+
+// newStateStorage opens the trie storage in the data dir with the configured db engine
+func (s *Server) newStateStorage(logger hclog.Logger) (itrie.Storage, error) {
+	factory, ok := stateStorageBackends[s.config.DBEngine]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", dbengine.ErrUnknownEngine, s.config.DBEngine)
+	}
+
+	return factory(filepath.Join(s.config.DataDir, "trie"), logger)
+}
+
 // newFileLogger returns logger instance that writes all logs to a specified file.
 // If log file can't be created, it returns an error
 func newFileLogger(config *Config) (hclog.Logger, error) {
@@ -140,6 +270,17 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, fmt.Errorf("failed to create data directories: %w", err)
 	}
 
+	if config.DBEngine == "" {
+		config.DBEngine = dbengine.DefaultEngine
+	}
+
+	// Refuse to open the databases created with other db engine
+	if err := dbengine.EnsureEngine(config.DataDir, config.DBEngine); err != nil {
+		return nil, err
+	}
+
+	m.logger.Info("DB engine", "engine", config.DBEngine)
+
 	if config.Telemetry.PrometheusAddr != nil {
 		// Only setup telemetry if `PrometheusAddr` has been configured.
 		if err := m.setupTelemetry(); err != nil {
@@ -173,8 +314,16 @@ func NewServer(config *Config) (*Server, error) {
 		m.network = network
 	}
 
+	if config.LightMode {
+		if err := m.setupLightClient(); err != nil {
+			return nil, err
+		}
+
+		return m, nil
+	}
+
 	// start blockchain object
-	stateStorage, err := itrie.NewLevelDBStorage(filepath.Join(m.config.DataDir, "trie"), logger)
+	stateStorage, err := m.newStateStorage(logger)
 	if err != nil {
 		return nil, err
 	}
@@ -194,7 +343,18 @@ func NewServer(config *Config) (*Server, error) {
 	signer := crypto.NewEIP155Signer(uint64(m.config.Chain.Params.ChainID))
 
 	// blockchain object
-	m.blockchain, err = blockchain.NewBlockchain(logger, m.config.DataDir, config.Chain, nil, m.executor, signer)
+	db, err := m.newBlockchainStorage(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.config.Freezer != nil && m.config.Freezer.Threshold > 0 {
+		if db, err = m.setupFreezer(db, logger); err != nil {
+			return nil, err
+		}
+	}
+
+	m.blockchain, err = blockchain.NewBlockchain(logger, db, config.Chain, nil, m.executor, signer)
 	if err != nil {
 		return nil, err
 	}
@@ -248,6 +408,12 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	if m.config.AddressIndex {
+		if err := m.blockchain.EnableAddressIndex(); err != nil {
+			return nil, err
+		}
+	}
+
 	// initialize data in consensus layer
 	if err := m.consensus.Initialize(); err != nil {
 		return nil, err
@@ -262,6 +428,10 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	// serve the state proofs to light clients
+	m.proofService = light.NewProofService(m.network, st)
+	m.proofService.Start()
+
 	// setup and start jsonrpc server
 	if err := m.setupJSONRPC(); err != nil {
 		return nil, err
@@ -279,6 +449,15 @@ func NewServer(config *Config) (*Server, error) {
 
 	m.txpool.Start()
 
+	// move the old blocks to the freezer
+	m.startFreezer()
+
+	// index the transactions of the existing blocks
+	m.startAddressIndexer()
+
+	// index the blooms of the blocks for the log queries
+	m.startBloomIndexer()
+
 	return m, nil
 }
 
@@ -359,9 +538,10 @@ func (s *Server) setupSecretsManager() error {
 
 	if secretsManagerType == secrets.Local {
 		// Only the base directory is required for
-		// the local secrets manager
+		// the local secrets manager, the passphrase if the secrets are encrypted
 		secretsManagerParams.Extra = map[string]interface{}{
-			secrets.Path: s.config.DataDir,
+			secrets.Path:       s.config.DataDir,
+			secrets.Passphrase: s.config.KeystorePassphrase,
 		}
 	}
 
@@ -406,6 +586,11 @@ func (s *Server) setupConsensus() error {
 		Path:   filepath.Join(s.config.DataDir, "consensus"),
 	}
 
+	var remoteSigner *secrets.RemoteSignerConfig
+	if s.config.SecretsManager != nil {
+		remoteSigner = s.config.SecretsManager.RemoteSigner
+	}
+
 	consensus, err := engine(
 		&consensus.Params{
 			Context:        context.Background(),
@@ -417,6 +602,7 @@ func (s *Server) setupConsensus() error {
 			Grpc:           s.grpcServer,
 			Logger:         s.logger,
 			SecretsManager: s.secretsManager,
+			RemoteSigner:   remoteSigner,
 			BlockTime:      s.config.BlockTime,
 		},
 	)
@@ -441,6 +627,24 @@ type jsonRPCHub struct {
 	consensus.Consensus
 }
 
+// SetHead rewinds the chain to the block with the given number
+// and returns the transactions of the removed blocks to the pool.
+// The chain can't be rewound while the node is sealing the blocks
+func (j *jsonRPCHub) SetHead(number uint64) error {
+	if j.TxPool.IsSealing() {
+		return errSetHeadWhileSealing
+	}
+
+	removed, err := j.Blockchain.SetHead(number)
+	if err != nil {
+		return err
+	}
+
+	j.TxPool.RewindWithHeaders(removed...)
+
+	return nil
+}
+
 func (j *jsonRPCHub) GetPeers() int {
 	return len(j.Server.Peers())
 }
@@ -633,6 +837,93 @@ func (j *jsonRPCHub) TraceCall(
 	return tracer.GetResult()
 }
 
+func (j *jsonRPCHub) GetValidatorStats() (*jsonrpc.ValidatorStats, error) {
+	tracker, ok := j.Consensus.(interface {
+		GetValidatorStats() (*consensusIBFT.ParticipationStats, error)
+	})
+	if !ok {
+		return nil, jsonrpc.ErrValidatorStatsUnavailable
+	}
+
+	stats, err := tracker.GetValidatorStats()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &jsonrpc.ValidatorStats{
+		Window:     stats.Window,
+		From:       stats.From,
+		To:         stats.To,
+		Validators: make([]*jsonrpc.ValidatorParticipation, len(stats.Validators)),
+	}
+
+	for idx, v := range stats.Validators {
+		res.Validators[idx] = &jsonrpc.ValidatorParticipation{
+			Address:        v.Address,
+			ProposedBlocks: v.ProposedBlocks,
+			CommittedSeals: v.CommittedSeals,
+			LastSeen:       v.LastSeen,
+		}
+	}
+
+	return res, nil
+}
+
+func (j *jsonRPCHub) GetTransactionsByAddress(
+	addr types.Address,
+	cursor jsonrpc.TxCursor,
+	limit uint64,
+) (*jsonrpc.AddressTransactions, error) {
+	positions, next, err := j.Blockchain.GetTransactionsByAddress(
+		addr,
+		storage.TxPosition{Number: cursor.Number, Index: cursor.Index},
+		int(limit),
+	)
+	if errors.Is(err, blockchain.ErrAddressIndexDisabled) {
+		return nil, jsonrpc.ErrAddressIndexUnavailable
+	} else if err != nil {
+		return nil, err
+	}
+
+	tail, _ := j.Blockchain.AddressIndexTail()
+
+	res := &jsonrpc.AddressTransactions{
+		Transactions: make([]*jsonrpc.AddressTransaction, len(positions)),
+		IndexedFrom:  tail,
+	}
+
+	// the transactions of the address are often in the same blocks
+	blocks := make(map[types.Hash]*types.Block)
+
+	for idx, pos := range positions {
+		block, ok := blocks[pos.BlockHash]
+		if !ok {
+			if block, ok = j.Blockchain.GetBlockByHash(pos.BlockHash, true); !ok {
+				return nil, fmt.Errorf("block %d not found", pos.Number)
+			}
+
+			blocks[pos.BlockHash] = block
+		}
+
+		if pos.Index >= uint64(len(block.Transactions)) {
+			return nil, fmt.Errorf("transaction %d of block %d not found", pos.Index, pos.Number)
+		}
+
+		res.Transactions[idx] = &jsonrpc.AddressTransaction{
+			Transaction: block.Transactions[pos.Index],
+			BlockNumber: pos.Number,
+			BlockHash:   pos.BlockHash,
+			Index:       pos.Index,
+		}
+	}
+
+	if next != nil {
+		res.Next = &jsonrpc.TxCursor{Number: next.Number, Index: next.Index}
+	}
+
+	return res, nil
+}
+
 func (j *jsonRPCHub) GetSyncProgression() *progress.Progression {
 	// restore progression
 	if restoreProg := j.restoreProgression.GetProgression(); restoreProg != nil {
@@ -670,6 +961,8 @@ func (s *Server) setupJSONRPC() error {
 		PriceLimit:               s.config.PriceLimit,
 		BatchLengthLimit:         s.config.JSONRPC.BatchLengthLimit,
 		BlockRangeLimit:          s.config.JSONRPC.BlockRangeLimit,
+		IndexedBlockRangeLimit:   s.config.JSONRPC.IndexedBlockRangeLimit,
+		AdminEnabled:             s.config.JSONRPC.AdminEnabled,
 	}
 
 	srv, err := jsonrpc.NewJSONRPC(s.logger, conf)
@@ -714,6 +1007,11 @@ func (s *Server) JoinPeer(rawPeerMultiaddr string) error {
 
 // Close closes the Minimal server (blockchain, networking, consensus)
 func (s *Server) Close() {
+	// Stop moving the blocks to the freezer and indexing them before the blockchain storage is closed
+	s.stopFreezer()
+	s.stopAddressIndexer()
+	s.stopBloomIndexer()
+
 	// Close the blockchain layer
 	if err := s.blockchain.Close(); err != nil {
 		s.logger.Error("failed to close blockchain", "err", err.Error())
@@ -725,13 +1023,36 @@ func (s *Server) Close() {
 	}
 
 	// Close the consensus layer
-	if err := s.consensus.Close(); err != nil {
-		s.logger.Error("failed to close consensus", "err", err.Error())
+	if s.consensus != nil {
+		if err := s.consensus.Close(); err != nil {
+			s.logger.Error("failed to close consensus", "err", err.Error())
+		}
+	}
+
+	// Close the light client
+	if s.lightSyncer != nil {
+		if err := s.lightSyncer.Close(); err != nil {
+			s.logger.Error("failed to close light syncer", "err", err.Error())
+		}
+	}
+
+	if s.lightVerifier != nil {
+		if err := s.lightVerifier.Close(); err != nil {
+			s.logger.Error("failed to close light verifier", "err", err.Error())
+		}
+	}
+
+	if s.proofService != nil {
+		if err := s.proofService.Close(); err != nil {
+			s.logger.Error("failed to close proof service", "err", err.Error())
+		}
 	}
 
 	// Close the state storage
-	if err := s.stateStorage.Close(); err != nil {
-		s.logger.Error("failed to close storage for trie", "err", err.Error())
+	if s.stateStorage != nil {
+		if err := s.stateStorage.Close(); err != nil {
+			s.logger.Error("failed to close storage for trie", "err", err.Error())
+		}
 	}
 
 	if s.prometheusServer != nil {
@@ -741,7 +1062,9 @@ func (s *Server) Close() {
 	}
 
 	// close the txpool's main loop
-	s.txpool.Close()
+	if s.txpool != nil {
+		s.txpool.Close()
+	}
 
 	// close DataDog profiler
 	s.closeDataDogProfiler()
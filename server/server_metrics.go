@@ -3,10 +3,14 @@ package server
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/armon/go-metrics"
 	"github.com/armon/go-metrics/prometheus"
+	prom "github.com/prometheus/client_golang/prometheus"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
 	"gopkg.in/DataDog/dd-trace-go.v1/profiler"
 )
@@ -23,12 +27,91 @@ func (s *Server) setupTelemetry() error {
 	metricsConf := metrics.DefaultConfig("edge")
 	metricsConf.EnableHostname = false
 	metrics.NewGlobal(metricsConf, metrics.FanoutSink{
-		inm, promSink,
+		inm, newHistogramSink(promSink, prom.DefaultRegisterer),
 	})
 
 	return nil
 }
 
+// histogramBuckets are the upper bounds of the histogram buckets in milliseconds,
+// the unit of the durations measured by metrics.MeasureSince (1ms up to ~16s)
+var histogramBuckets = prom.ExponentialBuckets(1, 2, 15)
+
+var histogramNameReplacer = strings.NewReplacer(" ", "_", ".", "_", "=", "_", "-", "_", "/", "_")
+
+// histogramSink records the samples in Prometheus histograms instead of the summaries
+// of the go-metrics Prometheus sink, so that the quantiles can be aggregated across
+// the nodes and over time. Counters and gauges are left to the embedded sink
+type histogramSink struct {
+	*prometheus.PrometheusSink
+
+	registerer prom.Registerer
+
+	lock       sync.Mutex
+	histograms map[string]*prom.HistogramVec
+}
+
+func newHistogramSink(sink *prometheus.PrometheusSink, registerer prom.Registerer) *histogramSink {
+	return &histogramSink{
+		PrometheusSink: sink,
+		registerer:     registerer,
+		histograms:     make(map[string]*prom.HistogramVec),
+	}
+}
+
+// AddSample observes the value in the histogram of the key
+func (s *histogramSink) AddSample(key []string, val float32) {
+	s.AddSampleWithLabels(key, val, nil)
+}
+
+// AddSampleWithLabels observes the value in the histogram of the key with the given labels
+func (s *histogramSink) AddSampleWithLabels(key []string, val float32, labels []metrics.Label) {
+	names := make([]string, len(labels))
+	values := make(prom.Labels, len(labels))
+
+	for i, label := range labels {
+		names[i] = label.Name
+		values[label.Name] = label.Value
+	}
+
+	histogram, err := s.histogram(histogramNameReplacer.Replace(strings.Join(key, "_")), names)
+	if err != nil {
+		// the metric has already been registered with other labels
+		return
+	}
+
+	histogram.With(values).Observe(float64(val))
+}
+
+// histogram returns the histogram with the name and the label names,
+// registering it on the first use
+func (s *histogramSink) histogram(name string, labelNames []string) (*prom.HistogramVec, error) {
+	sort.Strings(labelNames)
+
+	id := strings.Join(append([]string{name}, labelNames...), ";")
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if histogram, ok := s.histograms[id]; ok {
+		return histogram, nil
+	}
+
+	histogram := prom.NewHistogramVec(prom.HistogramOpts{
+		Name:    name,
+		Help:    name,
+		Buckets: histogramBuckets,
+	}, labelNames)
+
+	if err := s.registerer.Register(histogram); err != nil {
+		return nil, err
+	}
+
+	s.histograms[id] = histogram
+
+	return histogram, nil
+}
+
 // enableDataDogProfiler enables DataDog profiler. Enable it by setting DD_ENABLE env var.
 // Additional parameters can be set with env vars (DD_) - https://docs.datadoghq.com/profiler/enabling/go/
 func (s *Server) enableDataDogProfiler() error {
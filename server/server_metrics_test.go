@@ -0,0 +1,103 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/armon/go-metrics"
+	"github.com/armon/go-metrics/prometheus"
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHistogramSink(t *testing.T) (*histogramSink, *prom.Registry) {
+	t.Helper()
+
+	registry := prom.NewRegistry()
+
+	promSink, err := prometheus.NewPrometheusSinkFrom(prometheus.PrometheusOpts{
+		Registerer: registry,
+	})
+	require.NoError(t, err)
+
+	return newHistogramSink(promSink, registry), registry
+}
+
+func TestHistogramSink_AddSample(t *testing.T) {
+	t.Parallel()
+
+	sink, registry := newTestHistogramSink(t)
+
+	sink.AddSample([]string{"edge", "state", "commit"}, 3)
+	sink.AddSample([]string{"edge", "state", "commit"}, 300)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != "edge_state_commit" {
+			continue
+		}
+
+		require.Len(t, family.GetMetric(), 1)
+
+		h := family.GetMetric()[0].GetHistogram()
+		require.NotNil(t, h)
+
+		assert.Equal(t, uint64(2), h.GetSampleCount())
+		assert.Equal(t, float64(303), h.GetSampleSum())
+
+		// 3ms falls in the 4ms bucket, 300ms in the 512ms one
+		for _, bucket := range h.GetBucket() {
+			switch bucket.GetUpperBound() {
+			case 2:
+				assert.Equal(t, uint64(0), bucket.GetCumulativeCount())
+			case 4:
+				assert.Equal(t, uint64(1), bucket.GetCumulativeCount())
+			case 512:
+				assert.Equal(t, uint64(2), bucket.GetCumulativeCount())
+			}
+		}
+
+		return
+	}
+
+	assert.Fail(t, "histogram not registered")
+}
+
+func TestHistogramSink_AddSampleWithLabels(t *testing.T) {
+	t.Parallel()
+
+	sink, registry := newTestHistogramSink(t)
+
+	key := []string{"edge", "jsonrpc", "request_duration"}
+
+	sink.AddSampleWithLabels(key, 1, []metrics.Label{{Name: "method", Value: "eth_call"}})
+	sink.AddSampleWithLabels(key, 2, []metrics.Label{{Name: "method", Value: "eth_call"}})
+	sink.AddSampleWithLabels(key, 1, []metrics.Label{{Name: "method", Value: "eth_chainId"}})
+
+	// the samples with other label names can't be added to the same histogram
+	sink.AddSampleWithLabels(key, 1, []metrics.Label{{Name: "topic", Value: "txs"}})
+
+	assert.Equal(t, 2, testutil.CollectAndCount(
+		sink.histograms["edge_jsonrpc_request_duration;method"],
+	))
+
+	count, err := testutil.GatherAndCount(registry, "edge_jsonrpc_request_duration")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestHistogramSink_CountersAndGauges(t *testing.T) {
+	t.Parallel()
+
+	sink, registry := newTestHistogramSink(t)
+
+	sink.IncrCounter([]string{"edge", "syncer", "blocks"}, 2)
+	sink.SetGauge([]string{"edge", "syncer", "lag"}, 5)
+
+	count, err := testutil.GatherAndCount(registry, "edge_syncer_blocks", "edge_syncer_lag")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
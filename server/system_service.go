@@ -168,6 +168,7 @@ func (s *systemService) BlockByNumber(
 
 	return &proto.BlockResponse{
 		Data: block.MarshalRLP(),
+		Hash: block.Hash().Bytes(),
 	}, nil
 }
 
@@ -182,8 +183,8 @@ func (s *systemService) Export(req *proto.ExportRequest, stream proto.System_Exp
 	}
 
 	if req.To != 0 {
-		if from >= req.To {
-			return errors.New("to must be greater than from")
+		if from > req.To {
+			return errors.New("to must be greater than or equal to from")
 		}
 
 		to = &req.To
@@ -227,10 +228,14 @@ const (
 
 	// Number of header fields * bytes per field (From, To, Latest all them uint64)
 	maxHeaderInfoSize int = 3 * 8
+
+	// Bytes per block hash including its field tag and length
+	hashInfoSize int = types.HashLength + 2
 )
 
 type blockStreamWriter struct {
 	buf         bytes.Buffer
+	hashes      [][]byte // hashes of the blocks in buffer
 	blockchain  *blockchain.Blockchain
 	stream      proto.System_ExportServer
 	maxPayload  uint64
@@ -253,7 +258,8 @@ func newBlockStreamWriter(
 
 func (w *blockStreamWriter) appendBlock(b *types.Block) error {
 	data := b.MarshalRLP()
-	if uint64(maxHeaderInfoSize+w.buf.Len()+len(data)) >= w.maxPayload {
+	hashesSize := (len(w.hashes) + 1) * hashInfoSize
+	if uint64(maxHeaderInfoSize+hashesSize+w.buf.Len()+len(data)) >= w.maxPayload {
 		// send buffered data to client first
 		if err := w.flush(); err != nil {
 			return err
@@ -261,6 +267,7 @@ func (w *blockStreamWriter) appendBlock(b *types.Block) error {
 	}
 
 	w.buf.Write(data)
+	w.hashes = append(w.hashes, b.Hash().Bytes())
 
 	n := b.Number()
 	if w.pendingFrom == nil {
@@ -288,6 +295,7 @@ func (w *blockStreamWriter) flush() error {
 		To:     *w.pendingTo,
 		Latest: w.blockchain.Header().Number,
 		Data:   w.buf.Bytes(),
+		Hashes: w.hashes,
 	})
 
 	if err != nil {
@@ -301,6 +309,7 @@ func (w *blockStreamWriter) flush() error {
 
 func (w *blockStreamWriter) reset() {
 	w.buf.Reset()
+	w.hashes = nil
 	w.pendingFrom = nil
 	w.pendingTo = nil
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/crypto"
@@ -13,10 +14,13 @@ import (
 	"github.com/0xPolygon/polygon-edge/state/runtime/precompiled"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
 )
 
 const (
+	stateMetrics = "state"
+
 	spuriousDragonMaxCodeSize = 24576
 
 	TxGas                 uint64 = 21000 // Per transaction not creating a contract
@@ -38,6 +42,13 @@ type Executor struct {
 	GetHash GetHashByNumberHelper
 
 	PostHook func(txn *Transition)
+
+	// SystemRuntime executes the system contracts natively, it's checked before the precompiles
+	SystemRuntime runtime.Runtime
+
+	// WithholdFees reports whether the fees of the block are withheld from the coinbase.
+	// The withheld fees are distributed by the consensus at the end of the block
+	WithholdFees func(header *types.Header) bool
 }
 
 // NewExecutor creates a new executor
@@ -89,6 +100,8 @@ func (e *Executor) ProcessBlock(
 	block *types.Block,
 	blockCreator types.Address,
 ) (*Transition, error) {
+	defer metrics.MeasureSince([]string{stateMetrics, "block_execution"}, time.Now())
+
 	txn, err := e.BeginTxn(parentRoot, block.Header, blockCreator)
 	if err != nil {
 		return nil, err
@@ -164,9 +177,14 @@ func (e *Executor) BeginTxn(
 
 		evm:         evm.NewEVM(),
 		precompiles: precompiled.NewPrecompiled(),
+		system:      e.SystemRuntime,
 		PostHook:    e.PostHook,
 	}
 
+	if e.WithholdFees != nil {
+		txn.withholdFees = e.WithholdFees(header)
+	}
+
 	return txn, nil
 }
 
@@ -184,14 +202,19 @@ type Transition struct {
 	gasPool uint64
 
 	// result
-	receipts []*types.Receipt
-	totalGas uint64
+	receipts  []*types.Receipt
+	totalGas  uint64
+	totalFees big.Int
+
+	// withholdFees doesn't pay the fees to the coinbase, they're only added up in totalFees
+	withholdFees bool
 
 	PostHook func(t *Transition)
 
 	// runtimes
 	evm         *evm.EVM
 	precompiles *precompiled.Precompiled
+	system      runtime.Runtime
 }
 
 func NewTransition(config chain.ForksInTime, snap Snapshot, radix *Txn) *Transition {
@@ -208,6 +231,11 @@ func (t *Transition) TotalGas() uint64 {
 	return t.totalGas
 }
 
+// TotalFees returns the sum of the fees paid to the coinbase or withheld in the transition
+func (t *Transition) TotalFees() *big.Int {
+	return new(big.Int).Set(&t.totalFees)
+}
+
 func (t *Transition) Receipts() []*types.Receipt {
 	return t.receipts
 }
@@ -301,6 +329,8 @@ func (t *Transition) Write(txn *types.Transaction) error {
 
 // Commit commits the final result
 func (t *Transition) Commit() (Snapshot, types.Hash) {
+	defer metrics.MeasureSince([]string{stateMetrics, "commit"}, time.Now())
+
 	objs := t.state.Commit(t.config.EIP155)
 	s2, root := t.snap.Commit(objs)
 
@@ -488,7 +518,11 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 
 	// pay the coinbase
 	coinbaseFee := new(big.Int).Mul(new(big.Int).SetUint64(result.GasUsed), gasPrice)
-	txn.AddBalance(t.ctx.Coinbase, coinbaseFee)
+	if !t.withholdFees {
+		txn.AddBalance(t.ctx.Coinbase, coinbaseFee)
+	}
+
+	t.totalFees.Add(&t.totalFees, coinbaseFee)
 
 	// return gas to the pool
 	t.addGasPool(result.GasLeft)
@@ -521,6 +555,11 @@ func (t *Transition) Call2(
 }
 
 func (t *Transition) run(contract *runtime.Contract, host runtime.Host) *runtime.ExecutionResult {
+	// check the system contracts
+	if t.system != nil && t.system.CanRun(contract, host, &t.config) {
+		return t.system.Run(contract, host, &t.config)
+	}
+
 	// check the precompiles
 	if t.precompiles.CanRun(contract, host, &t.config) {
 		return t.precompiles.Run(contract, host, &t.config)
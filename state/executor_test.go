@@ -0,0 +1,66 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockCommitSnapshot struct {
+	mockSnapshot
+}
+
+func (m *mockCommitSnapshot) Commit(objs []*Object) (Snapshot, []byte) {
+	return m, types.ZeroHash.Bytes()
+}
+
+type mockState struct {
+	snapshot *mockCommitSnapshot
+}
+
+func (m *mockState) NewSnapshotAt(types.Hash) (Snapshot, error) {
+	return m.snapshot, nil
+}
+
+func (m *mockState) NewSnapshot() Snapshot {
+	return m.snapshot
+}
+
+func (m *mockState) GetCode(hash types.Hash) ([]byte, bool) {
+	return nil, false
+}
+
+func TestExecutor_Metrics(t *testing.T) {
+	// the metrics are global, the test can't run in parallel
+	sink := tests.NewMetricsSink(t)
+
+	executor := NewExecutor(
+		&chain.Params{Forks: chain.AllForksEnabled},
+		&mockState{snapshot: &mockCommitSnapshot{mockSnapshot{state: defaultPreState}}},
+		hclog.NewNullLogger(),
+	)
+	executor.GetHash = func(*types.Header) func(uint64) types.Hash {
+		return func(uint64) types.Hash {
+			return types.ZeroHash
+		}
+	}
+
+	block := &types.Block{
+		Header: &types.Header{Number: 1, GasLimit: 1000000},
+	}
+
+	txn, err := executor.ProcessBlock(types.ZeroHash, block, types.ZeroAddress)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, sink.Samples([]string{stateMetrics, "block_execution"}))
+	assert.Zero(t, sink.Samples([]string{stateMetrics, "commit"}))
+
+	txn.Commit()
+
+	assert.Equal(t, 1, sink.Samples([]string{stateMetrics, "commit"}))
+}
@@ -0,0 +1,143 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/fastrlp"
+)
+
+var (
+	ErrMissingProofNode = errors.New("missing trie node in proof")
+	errInvalidProofNode = errors.New("invalid trie node in proof")
+)
+
+// Prove returns the merkle proof of the given key in the trie with the given root.
+// The proof consists of the RLP encoded nodes on the path from the root to the key,
+// it proves the absence of the key if the key doesn't exist in the trie
+func Prove(storage Storage, root types.Hash, key []byte) ([][]byte, error) {
+	proof := make([][]byte, 0)
+
+	if _, err := walkPath(root, key, storage.Get, func(node []byte) {
+		proof = append(proof, append([]byte{}, node...))
+	}); err != nil {
+		return nil, err
+	}
+
+	return proof, nil
+}
+
+// VerifyProof checks the merkle proof of the key against the given root
+// and returns the value of the key. It returns nil value if the proof shows the key doesn't exist
+func VerifyProof(root types.Hash, key []byte, proof [][]byte) ([]byte, error) {
+	nodes := make(map[types.Hash][]byte, len(proof))
+
+	for _, node := range proof {
+		nodes[types.BytesToHash(crypto.Keccak256(node))] = node
+	}
+
+	return walkPath(root, key, func(hash []byte) ([]byte, bool) {
+		node, ok := nodes[types.BytesToHash(hash)]
+
+		return node, ok
+	}, nil)
+}
+
+// GetProof returns the merkle proof of the key in the state trie with the given root
+func (s *State) GetProof(root types.Hash, key []byte) ([][]byte, error) {
+	return Prove(s.storage, root, key)
+}
+
+// walkPath follows the path of the key from the root by resolving the stored nodes with getNode
+// and returns the value of the key. visit is called with the encoded nodes on the path
+func walkPath(
+	root types.Hash,
+	key []byte,
+	getNode func([]byte) ([]byte, bool),
+	visit func([]byte),
+) ([]byte, error) {
+	if root == types.EmptyRootHash {
+		return nil, nil
+	}
+
+	var (
+		nibbles = bytesToHexNibbles(key)
+		ref     = (&fastrlp.Arena{}).NewBytes(root.Bytes())
+	)
+
+	for {
+		node := ref
+
+		if ref.Type() == fastrlp.TypeBytes {
+			hash := ref.Raw()
+			if len(hash) == 0 {
+				// empty child
+				return nil, nil
+			}
+
+			if len(hash) != types.HashLength {
+				return nil, fmt.Errorf("%w: unexpected reference length %d", errInvalidProofNode, len(hash))
+			}
+
+			data, ok := getNode(hash)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrMissingProofNode, types.BytesToHash(hash))
+			}
+
+			if visit != nil {
+				visit(data)
+			}
+
+			// each node gets own parser since the parser reuses the values
+			var err error
+			if node, err = (&fastrlp.Parser{}).Parse(data); err != nil {
+				return nil, err
+			}
+		}
+
+		if node.Type() != fastrlp.TypeArray {
+			return nil, fmt.Errorf("%w: node should be an array", errInvalidProofNode)
+		}
+
+		switch node.Elems() {
+		case 2:
+			// short node
+			nodeKey := decodeCompact(node.Get(0).Raw())
+
+			if hasTerminator(nodeKey) {
+				// leaf node
+				if !bytes.Equal(nodeKey, nibbles) {
+					return nil, nil
+				}
+
+				return append([]byte{}, node.Get(1).Raw()...), nil
+			}
+
+			// extension node
+			if !bytes.HasPrefix(nibbles, nodeKey) {
+				return nil, nil
+			}
+
+			nibbles = nibbles[len(nodeKey):]
+			ref = node.Get(1)
+		case 17:
+			// full node
+			if nibbles[0] == 16 {
+				value := node.Get(16).Raw()
+				if len(value) == 0 {
+					return nil, nil
+				}
+
+				return append([]byte{}, value...), nil
+			}
+
+			ref = node.Get(int(nibbles[0]))
+			nibbles = nibbles[1:]
+		default:
+			return nil, fmt.Errorf("%w: node has incorrect number of items", errInvalidProofNode)
+		}
+	}
+}
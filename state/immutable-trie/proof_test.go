@@ -0,0 +1,129 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildProofTestState(t *testing.T, numAccounts int) (*State, types.Hash, []types.Address) {
+	t.Helper()
+
+	st := NewState(NewMemoryStorage())
+
+	addresses := make([]types.Address, numAccounts)
+	objs := make([]*state.Object, numAccounts)
+
+	for i := 0; i < numAccounts; i++ {
+		addresses[i] = types.BytesToAddress(big.NewInt(int64(i + 1)).Bytes())
+		objs[i] = &state.Object{
+			Address: addresses[i],
+			Balance: big.NewInt(int64(i * 100)),
+			Nonce:   uint64(i),
+			Root:    types.EmptyRootHash,
+			Storage: []*state.StorageObject{
+				{
+					Key: types.BytesToHash([]byte{0x1}).Bytes(),
+					Val: types.BytesToHash(big.NewInt(int64(i + 1)).Bytes()).Bytes(),
+				},
+			},
+		}
+	}
+
+	_, root := st.NewSnapshot().Commit(objs)
+
+	return st, types.BytesToHash(root), addresses
+}
+
+func TestProof_ProveAndVerify(t *testing.T) {
+	t.Parallel()
+
+	st, root, addresses := buildProofTestState(t, 50)
+
+	snap, err := st.NewSnapshotAt(root)
+	assert.NoError(t, err)
+
+	for _, addr := range addresses {
+		key := crypto.Keccak256(addr.Bytes())
+
+		proof, err := st.GetProof(root, key)
+		assert.NoError(t, err)
+
+		value, err := VerifyProof(root, key, proof)
+		assert.NoError(t, err)
+
+		var account state.Account
+		assert.NoError(t, account.UnmarshalRlp(value))
+
+		expected, err := snap.GetAccount(addr)
+		assert.NoError(t, err)
+		assert.Equal(t, expected.Nonce, account.Nonce)
+		assert.Equal(t, expected.Balance, account.Balance)
+		assert.Equal(t, expected.Root, account.Root)
+
+		// storage proof against the storage root of the account
+		storageKey := crypto.Keccak256(types.BytesToHash([]byte{0x1}).Bytes())
+
+		storageProof, err := st.GetProof(account.Root, storageKey)
+		assert.NoError(t, err)
+
+		storageValue, err := VerifyProof(account.Root, storageKey, storageProof)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, storageValue)
+	}
+}
+
+func TestProof_ProveAbsence(t *testing.T) {
+	t.Parallel()
+
+	st, root, _ := buildProofTestState(t, 50)
+
+	key := crypto.Keccak256(types.StringToAddress("0xdeadbeef").Bytes())
+
+	proof, err := st.GetProof(root, key)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, proof)
+
+	value, err := VerifyProof(root, key, proof)
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+
+	// empty trie
+	value, err = VerifyProof(types.EmptyRootHash, key, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestProof_VerifyInvalidProof(t *testing.T) {
+	t.Parallel()
+
+	st, root, addresses := buildProofTestState(t, 50)
+
+	key := crypto.Keccak256(addresses[0].Bytes())
+
+	proof, err := st.GetProof(root, key)
+	assert.NoError(t, err)
+
+	// missing node
+	_, err = VerifyProof(root, key, proof[:len(proof)-1])
+	assert.ErrorIs(t, err, ErrMissingProofNode)
+
+	// modified node doesn't match its hash anymore
+	tampered := make([][]byte, len(proof))
+	copy(tampered, proof)
+
+	last := append([]byte{}, proof[len(proof)-1]...)
+	last[len(last)-1] ^= 0xff
+	tampered[len(tampered)-1] = last
+
+	_, err = VerifyProof(root, key, tampered)
+	assert.ErrorIs(t, err, ErrMissingProofNode)
+
+	// wrong root
+	_, err = VerifyProof(types.StringToHash("0x1"), key, proof)
+	assert.ErrorIs(t, err, ErrMissingProofNode)
+}
@@ -0,0 +1,55 @@
+package itrie
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPebbleStorage(t *testing.T) Storage {
+	t.Helper()
+
+	storage, err := NewPebbleStorage(t.TempDir(), hclog.NewNullLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := storage.Close(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	return storage
+}
+
+func TestState_Pebble(t *testing.T) {
+	state.TestState(t, func(pre state.PreStates) state.Snapshot {
+		return NewState(newTestPebbleStorage(t)).NewSnapshot()
+	})
+}
+
+func TestPebbleStorage(t *testing.T) {
+	storage := newTestPebbleStorage(t)
+
+	_, ok := storage.Get([]byte{0x1})
+	assert.False(t, ok)
+
+	batch := storage.Batch()
+	batch.Put([]byte{0x1}, []byte{0x2})
+	batch.Write()
+
+	value, ok := storage.Get([]byte{0x1})
+	assert.True(t, ok)
+	assert.Equal(t, []byte{0x2}, value)
+
+	codeHash := types.StringToHash("1")
+	storage.SetCode(codeHash, []byte{0x3})
+
+	code, ok := storage.GetCode(codeHash)
+	assert.True(t, ok)
+	assert.Equal(t, []byte{0x3}, code)
+}
@@ -0,0 +1,149 @@
+package itrie
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// ErrMissingNode is returned when a node referenced by the trie is not in the storage
+	ErrMissingNode = errors.New("trie node is missing")
+	// ErrMissingCode is returned when the code of an account is not in the storage
+	ErrMissingCode = errors.New("contract code is missing")
+)
+
+var emptyCodeHash = types.BytesToHash(crypto.Keccak256(nil))
+
+// StateVisitor receives the data of the state while the state is walked
+type StateVisitor interface {
+	// VisitNode is called with the encoded node stored under its hash
+	VisitNode(hash types.Hash, data []byte) error
+	// VisitCode is called with the contract code stored under its hash
+	VisitCode(hash types.Hash, code []byte) error
+}
+
+// WalkState visits every node of the account trie at the given root,
+// every node of the storage tries of the accounts and every contract code.
+// The nodes and the codes shared by several accounts are visited once
+func WalkState(storage Storage, root types.Hash, visitor StateVisitor) error {
+	w := &stateWalker{
+		storage: storage,
+		visitor: visitor,
+		visited: make(map[types.Hash]struct{}),
+		codes:   make(map[types.Hash]struct{}),
+	}
+
+	return w.walkTrie(root, w.visitAccount)
+}
+
+type stateWalker struct {
+	storage Storage
+	visitor StateVisitor
+	visited map[types.Hash]struct{}
+	codes   map[types.Hash]struct{}
+}
+
+// walkTrie visits all the stored nodes of the trie and passes the leaf values to onLeaf
+func (w *stateWalker) walkTrie(root types.Hash, onLeaf func([]byte) error) error {
+	if root == types.EmptyRootHash || root == types.ZeroHash {
+		return nil
+	}
+
+	return w.walkStoredNode(root, onLeaf)
+}
+
+func (w *stateWalker) walkStoredNode(hash types.Hash, onLeaf func([]byte) error) error {
+	if _, ok := w.visited[hash]; ok {
+		return nil
+	}
+
+	data, ok := w.storage.Get(hash.Bytes())
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrMissingNode, hash)
+	}
+
+	if err := w.visitor.VisitNode(hash, data); err != nil {
+		return err
+	}
+
+	node, _, err := GetNode(hash.Bytes(), w.storage)
+	if err != nil {
+		return fmt.Errorf("failed to decode node %s: %w", hash, err)
+	}
+
+	if err := w.walkNode(node, onLeaf); err != nil {
+		return err
+	}
+
+	w.visited[hash] = struct{}{}
+
+	return nil
+}
+
+func (w *stateWalker) walkNode(node Node, onLeaf func([]byte) error) error {
+	switch n := node.(type) {
+	case nil:
+		return nil
+
+	case *ValueNode:
+		if n.hash {
+			// reference to the node stored under the hash
+			return w.walkStoredNode(types.BytesToHash(n.buf), onLeaf)
+		}
+
+		return onLeaf(n.buf)
+
+	case *ShortNode:
+		return w.walkNode(n.child, onLeaf)
+
+	case *FullNode:
+		for _, child := range n.children {
+			if err := w.walkNode(child, onLeaf); err != nil {
+				return err
+			}
+		}
+
+		return w.walkNode(n.value, onLeaf)
+
+	default:
+		return fmt.Errorf("unknown node type %T", n)
+	}
+}
+
+// visitAccount walks the storage trie and the code of the account in the leaf of the account trie
+func (w *stateWalker) visitAccount(data []byte) error {
+	var account state.Account
+	if err := account.UnmarshalRlp(data); err != nil {
+		return err
+	}
+
+	if err := w.walkTrie(account.Root, func([]byte) error { return nil }); err != nil {
+		return err
+	}
+
+	codeHash := types.BytesToHash(account.CodeHash)
+	if codeHash == emptyCodeHash || codeHash == types.ZeroHash {
+		return nil
+	}
+
+	if _, ok := w.codes[codeHash]; ok {
+		return nil
+	}
+
+	code, ok := w.storage.GetCode(codeHash)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrMissingCode, codeHash)
+	}
+
+	if err := w.visitor.VisitCode(codeHash, code); err != nil {
+		return err
+	}
+
+	w.codes[codeHash] = struct{}{}
+
+	return nil
+}
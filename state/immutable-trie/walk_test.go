@@ -0,0 +1,130 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// copyVisitor copies the visited state into the storage
+type copyVisitor struct {
+	storage Storage
+	nodes   int
+	codes   int
+}
+
+func (v *copyVisitor) VisitNode(hash types.Hash, data []byte) error {
+	v.storage.Put(hash.Bytes(), data)
+	v.nodes++
+
+	return nil
+}
+
+func (v *copyVisitor) VisitCode(hash types.Hash, code []byte) error {
+	v.storage.SetCode(hash, code)
+	v.codes++
+
+	return nil
+}
+
+func newWalkTestState(t *testing.T) (Storage, types.Hash) {
+	t.Helper()
+
+	storage := NewMemoryStorage()
+	snap := NewState(storage).NewSnapshot()
+
+	code := []byte{0x60, 0x00, 0x60, 0x00, 0xf3}
+	objs := make([]*state.Object, 0, 32)
+
+	for i := 0; i < 32; i++ {
+		obj := &state.Object{
+			Address:  types.BytesToAddress([]byte{byte(i + 1)}),
+			Balance:  big.NewInt(int64(i)),
+			Nonce:    uint64(i),
+			Root:     types.EmptyRootHash,
+			CodeHash: types.BytesToHash(crypto.Keccak256(nil)),
+		}
+
+		if i%4 == 0 {
+			// contracts sharing the same code
+			obj.CodeHash = types.BytesToHash(crypto.Keccak256(code))
+			obj.Code = code
+			obj.DirtyCode = true
+
+			for j := 0; j < 8; j++ {
+				obj.Storage = append(obj.Storage, &state.StorageObject{
+					Key: types.BytesToHash([]byte{byte(j)}).Bytes(),
+					Val: types.BytesToHash([]byte{byte(i + j + 1)}).Bytes(),
+				})
+			}
+		}
+
+		objs = append(objs, obj)
+	}
+
+	_, root := snap.Commit(objs)
+
+	return storage, types.BytesToHash(root)
+}
+
+func TestWalkState(t *testing.T) {
+	t.Parallel()
+
+	storage, root := newWalkTestState(t)
+
+	visitor := &copyVisitor{storage: NewMemoryStorage()}
+	require.NoError(t, WalkState(storage, root, visitor))
+
+	assert.Greater(t, visitor.nodes, 1)
+	assert.Equal(t, 1, visitor.codes)
+
+	// the copied state is complete
+	copied, err := NewState(visitor.storage).NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	account, err := copied.GetAccount(types.BytesToAddress([]byte{5}))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), account.Nonce)
+
+	value := copied.GetStorage(types.BytesToAddress([]byte{5}), account.Root, types.BytesToHash([]byte{1}))
+	assert.Equal(t, types.BytesToHash([]byte{6}), value)
+
+	assert.NoError(t, WalkState(visitor.storage, root, &copyVisitor{storage: NewMemoryStorage()}))
+}
+
+func TestWalkState_Missing(t *testing.T) {
+	t.Parallel()
+
+	storage, root := newWalkTestState(t)
+
+	t.Run("node", func(t *testing.T) {
+		t.Parallel()
+
+		// the root node is copied only
+		partial := NewMemoryStorage()
+		data, _ := storage.Get(root.Bytes())
+		partial.Put(root.Bytes(), data)
+
+		assert.ErrorIs(t, WalkState(partial, root, &copyVisitor{storage: NewMemoryStorage()}), ErrMissingNode)
+	})
+
+	t.Run("code", func(t *testing.T) {
+		t.Parallel()
+
+		// the nodes are copied without the codes
+		visitor := &copyVisitor{storage: NewMemoryStorage()}
+		require.NoError(t, WalkState(storage, root, visitor))
+
+		partial := NewMemoryStorage()
+		for k, v := range visitor.storage.(*memStorage).db {
+			partial.(*memStorage).db[k] = v
+		}
+
+		assert.ErrorIs(t, WalkState(partial, root, &copyVisitor{storage: NewMemoryStorage()}), ErrMissingCode)
+	})
+}
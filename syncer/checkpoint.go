@@ -0,0 +1,60 @@
+package syncer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	errCheckpointMismatch = errors.New("header conflicts with the checkpoint")
+)
+
+// checkpoints is a map of the trusted hashes by height.
+// A peer serving the chain conflicting with a checkpoint is rejected
+type checkpoints map[uint64]types.Hash
+
+func newCheckpoints(list []*chain.Checkpoint) checkpoints {
+	c := make(checkpoints, len(list))
+
+	for _, checkpoint := range list {
+		c[checkpoint.Number] = checkpoint.Hash
+	}
+
+	return c
+}
+
+// verify returns an error if the header conflicts with the checkpoint at its height
+func (c checkpoints) verify(header *types.Header) error {
+	hash, ok := c[header.Number]
+	if !ok || hash == header.Hash {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"%w at %d, expected %s, but got %s",
+		errCheckpointMismatch,
+		header.Number,
+		hash,
+		header.Hash,
+	)
+}
+
+// latest returns the height of the highest checkpoint, false if there is no checkpoint
+func (c checkpoints) latest() (uint64, bool) {
+	var (
+		number uint64
+		found  bool
+	)
+
+	for n := range c {
+		if !found || n > number {
+			number = n
+			found = true
+		}
+	}
+
+	return number, found
+}
@@ -0,0 +1,67 @@
+package syncer
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_checkpoints_verify(t *testing.T) {
+	t.Parallel()
+
+	headers := createMockLinkedHeaders(3)
+
+	c := newCheckpoints([]*chain.Checkpoint{
+		{
+			Number: 2,
+			Hash:   headers[2].Hash,
+		},
+	})
+
+	tests := []struct {
+		name   string
+		header *types.Header
+		err    error
+	}{
+		{
+			name:   "should accept the header without checkpoint",
+			header: headers[1],
+		},
+		{
+			name:   "should accept the header matching the checkpoint",
+			header: headers[2],
+		},
+		{
+			name:   "should reject the header conflicting with the checkpoint",
+			header: (&types.Header{Number: 2, ExtraData: []byte{0x1}}).ComputeHash(),
+			err:    errCheckpointMismatch,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.ErrorIs(t, c.verify(test.header), test.err)
+		})
+	}
+}
+
+func Test_checkpoints_latest(t *testing.T) {
+	t.Parallel()
+
+	_, ok := newCheckpoints(nil).latest()
+	assert.False(t, ok)
+
+	number, ok := newCheckpoints([]*chain.Checkpoint{
+		{Number: 200},
+		{Number: 300},
+		{Number: 100},
+	}).latest()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(300), number)
+}
@@ -239,10 +239,12 @@ func (m *syncPeerClient) CloseStream(peerID peer.ID) error {
 	return m.network.CloseProtocolStream(syncerProto, peerID)
 }
 
-// GetBlocks returns a stream of blocks from given height to peer's latest
+// GetBlocks returns a stream of blocks from given height to the given end,
+// or to peer's latest if the end is 0
 func (m *syncPeerClient) GetBlocks(
 	peerID peer.ID,
 	from uint64,
+	to uint64,
 	timeoutPerBlock time.Duration,
 ) (<-chan *types.Block, error) {
 	clt, err := m.newSyncPeerClient(peerID)
@@ -254,6 +256,7 @@ func (m *syncPeerClient) GetBlocks(
 
 	stream, err := clt.GetBlocks(ctx, &proto.GetBlocksRequest{
 		From: from,
+		To:   to,
 	})
 	if err != nil {
 		cancel()
@@ -294,6 +297,83 @@ func (m *syncPeerClient) GetBlocks(
 	return blockCh, nil
 }
 
+// GetHeaders returns a stream of headers in the given range.
+// Unlike GetBlocks, the stream is closed by itself at the end
+// so that it doesn't affect the other streams to the peer
+func (m *syncPeerClient) GetHeaders(
+	peerID peer.ID,
+	from uint64,
+	to uint64,
+	timeoutPerHeader time.Duration,
+) (<-chan *types.Header, error) {
+	if to < from {
+		return nil, fmt.Errorf("invalid header range from %d to %d", from, to)
+	}
+
+	conn, err := m.network.NewProtoConnection(syncerProto, peerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open a stream, err %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	stream, err := proto.NewSyncPeerClient(conn).GetHeaders(ctx, &proto.GetHeadersRequest{
+		From: from,
+		To:   to,
+	})
+	if err != nil {
+		cancel()
+		conn.Close()
+
+		return nil, fmt.Errorf("failed to open GetHeaders stream: %w", err)
+	}
+
+	// buffer all the headers so that the stream is never blocked by the reader
+	headerCh := make(chan *types.Header, to-from+1)
+
+	go func() {
+		// cancel the stream if a header doesn't reach within timeout
+		timer := time.AfterFunc(timeoutPerHeader, cancel)
+
+		defer func() {
+			timer.Stop()
+			cancel()
+
+			if err := conn.Close(); err != nil {
+				m.logger.Debug("failed to close GetHeaders stream", "peer", peerID, "err", err)
+			}
+
+			close(headerCh)
+		}()
+
+		for {
+			protoHeader, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			if err != nil {
+				m.logger.Error("failed to get header from gRPC stream", "peer", peerID, "err", err)
+
+				return
+			}
+
+			header := &types.Header{}
+			if err := header.UnmarshalRLP(protoHeader.Header); err != nil {
+				m.logger.Error("failed to unmarshal header", "peer", peerID, "err", err)
+
+				return
+			}
+
+			timer.Reset(timeoutPerHeader)
+
+			headerCh <- header
+		}
+	}()
+
+	return headerCh, nil
+}
+
 // newSyncPeerClient creates gRPC client
 func (m *syncPeerClient) newSyncPeerClient(peerID peer.ID) (proto.SyncPeerClient, error) {
 	conn, err := m.network.NewProtoConnection(syncerProto, peerID)
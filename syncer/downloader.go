@@ -0,0 +1,462 @@
+package syncer
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	downloaderName = "downloader"
+
+	// defaultChunkSize is the number of blocks requested from a peer at once
+	defaultChunkSize = 64
+	// defaultMaxChunksAhead is the number of chunks that can be downloaded or buffered
+	// ahead of the next block to be written, it bounds the size of the reorder buffer
+	defaultMaxChunksAhead = 16
+)
+
+var (
+	errNoDownloadPeer   = errors.New("no peer to download blocks from")
+	errIncompleteChunk  = errors.New("peer closed stream before sending all blocks")
+	errUnexpectedNumber = errors.New("peer sent a block with unexpected number")
+	errDroppedPeer      = errors.New("peer has been dropped")
+)
+
+// blockRange is a range of blocks [from, to] requested from a single peer
+type blockRange struct {
+	from uint64
+	to   uint64
+}
+
+// chunkResult is a result of downloading a block range from a peer
+type chunkResult struct {
+	peerID  peer.ID
+	chunk   blockRange
+	blocks  []*types.Block
+	elapsed time.Duration
+	err     error
+}
+
+// peerThroughput is a download statistics of a peer
+type peerThroughput struct {
+	Blocks   uint64
+	Duration time.Duration
+	Failures uint64
+}
+
+// BlocksPerSecond returns the average download speed of the peer
+func (t peerThroughput) BlocksPerSecond() float64 {
+	if t.Duration <= 0 {
+		return 0
+	}
+
+	return float64(t.Blocks) / t.Duration.Seconds()
+}
+
+// peerThroughputMap keeps download statistics per peer
+type peerThroughputMap struct {
+	sync.RWMutex
+
+	stats map[peer.ID]peerThroughput
+}
+
+func newPeerThroughputMap() *peerThroughputMap {
+	return &peerThroughputMap{
+		stats: make(map[peer.ID]peerThroughput),
+	}
+}
+
+// record adds the result of a download to the statistics of the peer
+func (m *peerThroughputMap) record(peerID peer.ID, blocks int, elapsed time.Duration, failed bool) {
+	m.Lock()
+	defer m.Unlock()
+
+	stat := m.stats[peerID]
+
+	stat.Blocks += uint64(blocks)
+	stat.Duration += elapsed
+
+	if failed {
+		stat.Failures++
+	}
+
+	m.stats[peerID] = stat
+}
+
+// get returns the statistics of the peer
+func (m *peerThroughputMap) get(peerID peer.ID) peerThroughput {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.stats[peerID]
+}
+
+// remove drops the statistics of the peer
+func (m *peerThroughputMap) remove(peerID peer.ID) {
+	m.Lock()
+	defer m.Unlock()
+
+	delete(m.stats, peerID)
+}
+
+// blockDownloader downloads a range of blocks from multiple peers concurrently.
+// The range is split into chunks that are assigned to idle peers,
+// and the downloaded chunks are passed to the handler in ascending order
+type blockDownloader struct {
+	logger     hclog.Logger
+	client     SyncPeerClient
+	throughput *peerThroughputMap
+
+	// Timeout for receiving a block
+	blockTimeout time.Duration
+	// Number of blocks requested from a peer at once
+	chunkSize uint64
+	// Number of chunks that can be requested ahead of the next block to handle
+	maxChunksAhead uint64
+}
+
+func newBlockDownloader(
+	logger hclog.Logger,
+	client SyncPeerClient,
+	blockTimeout time.Duration,
+) *blockDownloader {
+	return &blockDownloader{
+		logger:         logger.Named(downloaderName),
+		client:         client,
+		throughput:     newPeerThroughputMap(),
+		blockTimeout:   blockTimeout,
+		chunkSize:      defaultChunkSize,
+		maxChunksAhead: defaultMaxChunksAhead,
+	}
+}
+
+// downloadState is a state of a single download, owned by the download loop
+type downloadState struct {
+	peers   map[peer.ID]*NoForkPeer // peers that can be assigned a chunk
+	busy    map[peer.ID]blockRange  // peers downloading a chunk
+	failed  map[peer.ID]bool        // peers that failed during the download
+	retries []blockRange            // chunks to be requested again
+	buffer  map[uint64]*chunkResult // downloaded chunks by the first block number
+	nextNew uint64                  // beginning of the next chunk not requested yet
+	next    uint64                  // number of the next block to handle
+	to      uint64                  // number of the last block to download
+	lastErr error                   // the latest error a peer caused
+}
+
+// download fetches the blocks in [from, to] from the given peers and calls handler
+// for each block in ascending order. The handler returns true if download should stop.
+// It returns the number of the last handled block, the flag given by handler and the peers that failed
+func (d *blockDownloader) download(
+	peers []*NoForkPeer,
+	from, to uint64,
+	handler func(*types.Block) (bool, error),
+) (uint64, bool, []peer.ID, error) {
+	state := &downloadState{
+		peers:   make(map[peer.ID]*NoForkPeer, len(peers)),
+		busy:    make(map[peer.ID]blockRange),
+		failed:  make(map[peer.ID]bool),
+		buffer:  make(map[uint64]*chunkResult),
+		nextNew: from,
+		next:    from,
+		to:      to,
+	}
+
+	for _, p := range peers {
+		state.peers[p.ID] = p
+	}
+
+	resultCh := make(chan *chunkResult)
+	doneCh := make(chan struct{})
+
+	defer close(doneCh)
+
+	for state.next <= state.to {
+		d.schedule(state, resultCh, doneCh)
+
+		if len(state.busy) == 0 {
+			// no chunk is being downloaded and no peer can take one
+			return state.next - 1, false, failedPeers(state), d.noPeerError(state)
+		}
+
+		d.processResult(state, <-resultCh)
+
+		shouldTerminate, err := d.handleBufferedChunks(state, handler)
+		if err != nil || shouldTerminate {
+			return state.next - 1, shouldTerminate, failedPeers(state), err
+		}
+	}
+
+	return state.next - 1, false, failedPeers(state), nil
+}
+
+// schedule assigns chunks to the idle peers
+func (d *blockDownloader) schedule(state *downloadState, resultCh chan<- *chunkResult, doneCh <-chan struct{}) {
+	for _, p := range d.idlePeers(state) {
+		chunk, ok := d.nextChunk(state, p.Number)
+		if !ok {
+			continue
+		}
+
+		state.busy[p.ID] = chunk
+
+		go func(peerID peer.ID, chunk blockRange) {
+			result := d.fetchChunk(peerID, chunk)
+
+			select {
+			case resultCh <- result:
+			case <-doneCh:
+			}
+		}(p.ID, chunk)
+	}
+}
+
+// idlePeers returns the peers not downloading any chunk, faster peers come first
+func (d *blockDownloader) idlePeers(state *downloadState) []*NoForkPeer {
+	idle := make([]*NoForkPeer, 0, len(state.peers))
+
+	for id, p := range state.peers {
+		if _, ok := state.busy[id]; !ok {
+			idle = append(idle, p)
+		}
+	}
+
+	speed := make(map[peer.ID]float64, len(idle))
+	for _, p := range idle {
+		speed[p.ID] = d.throughput.get(p.ID).BlocksPerSecond()
+	}
+
+	sort.Slice(idle, func(i, j int) bool {
+		if speed[idle[i].ID] != speed[idle[j].ID] {
+			return speed[idle[i].ID] > speed[idle[j].ID]
+		}
+
+		return idle[i].IsBetter(idle[j])
+	})
+
+	return idle
+}
+
+// nextChunk returns the lowest chunk the peer with the given latest height can serve
+func (d *blockDownloader) nextChunk(state *downloadState, peerLatest uint64) (blockRange, bool) {
+	for i, chunk := range state.retries {
+		if chunk.to <= peerLatest {
+			state.retries = append(state.retries[:i], state.retries[i+1:]...)
+
+			return chunk, true
+		}
+	}
+
+	// keep the reorder buffer bounded
+	if state.nextNew > state.to || state.nextNew >= state.next+d.chunkSize*d.maxChunksAhead {
+		return blockRange{}, false
+	}
+
+	chunk := blockRange{
+		from: state.nextNew,
+		to:   state.nextNew + d.chunkSize - 1,
+	}
+
+	if chunk.to > state.to {
+		chunk.to = state.to
+	}
+
+	if chunk.to > peerLatest {
+		return blockRange{}, false
+	}
+
+	state.nextNew = chunk.to + 1
+
+	return chunk, true
+}
+
+// processResult puts a downloaded chunk into the buffer, or reschedules it on failure
+func (d *blockDownloader) processResult(state *downloadState, result *chunkResult) {
+	delete(state.busy, result.peerID)
+
+	d.throughput.record(result.peerID, len(result.blocks), result.elapsed, result.err != nil)
+
+	if state.failed[result.peerID] {
+		// the peer has sent an invalid block in its previous chunk, don't trust it
+		result.blocks = nil
+		result.err = errDroppedPeer
+	}
+
+	received := uint64(len(result.blocks))
+
+	if received > 0 {
+		state.buffer[result.chunk.from] = &chunkResult{
+			peerID: result.peerID,
+			chunk: blockRange{
+				from: result.chunk.from,
+				to:   result.chunk.from + received - 1,
+			},
+			blocks: result.blocks,
+		}
+	}
+
+	if result.err == nil {
+		d.logger.Debug(
+			"downloaded chunk",
+			"peer", result.peerID,
+			"from", result.chunk.from,
+			"to", result.chunk.to,
+			"blocks/s", d.throughput.get(result.peerID).BlocksPerSecond(),
+		)
+
+		return
+	}
+
+	d.logger.Warn(
+		"failed to download chunk, reassign to another peer",
+		"peer", result.peerID,
+		"from", result.chunk.from,
+		"to", result.chunk.to,
+		"received", received,
+		"err", result.err,
+	)
+
+	d.dropPeer(state, result.peerID, result.err)
+	d.retry(state, blockRange{from: result.chunk.from + received, to: result.chunk.to})
+}
+
+// handleBufferedChunks passes the buffered blocks to handler as long as they are continuous
+func (d *blockDownloader) handleBufferedChunks(
+	state *downloadState,
+	handler func(*types.Block) (bool, error),
+) (bool, error) {
+	for {
+		result, ok := state.buffer[state.next]
+		if !ok {
+			return false, nil
+		}
+
+		delete(state.buffer, state.next)
+
+		for _, block := range result.blocks {
+			shouldTerminate, err := handler(block)
+			if err != nil {
+				d.logger.Warn("peer sent a block that can't be handled", "peer", result.peerID, "number", block.Number(), "err", err)
+
+				d.dropPeer(state, result.peerID, err)
+				d.retry(state, blockRange{from: block.Number(), to: result.chunk.to})
+
+				break
+			}
+
+			state.next = block.Number() + 1
+
+			if shouldTerminate {
+				return true, nil
+			}
+		}
+	}
+}
+
+// retry puts the chunk back to be requested by another peer
+func (d *blockDownloader) retry(state *downloadState, chunk blockRange) {
+	if chunk.from > chunk.to {
+		return
+	}
+
+	state.retries = append(state.retries, chunk)
+
+	sort.Slice(state.retries, func(i, j int) bool {
+		return state.retries[i].from < state.retries[j].from
+	})
+}
+
+// dropPeer excludes the peer from the rest of the download
+func (d *blockDownloader) dropPeer(state *downloadState, peerID peer.ID, err error) {
+	delete(state.peers, peerID)
+
+	state.failed[peerID] = true
+	state.lastErr = err
+}
+
+// noPeerError returns an error explaining why the download can't proceed
+func (d *blockDownloader) noPeerError(state *downloadState) error {
+	if state.lastErr != nil {
+		return state.lastErr
+	}
+
+	return fmt.Errorf("%w for block %d", errNoDownloadPeer, state.next)
+}
+
+// fetchChunk downloads the given range of blocks from the peer.
+// It returns the blocks received so far along with an error if the peer fails in the middle
+func (d *blockDownloader) fetchChunk(peerID peer.ID, chunk blockRange) *chunkResult {
+	var (
+		result = &chunkResult{
+			peerID: peerID,
+			chunk:  chunk,
+			blocks: make([]*types.Block, 0, chunk.to-chunk.from+1),
+		}
+		start = time.Now()
+	)
+
+	defer func() {
+		result.elapsed = time.Since(start)
+	}()
+
+	blockCh, err := d.client.GetBlocks(peerID, chunk.from, chunk.to, d.blockTimeout)
+	if err != nil {
+		result.err = err
+
+		return result
+	}
+
+	defer func() {
+		// unblock the stream in case it's closed before the end
+		go func() {
+			for range blockCh {
+			}
+		}()
+
+		if err := d.client.CloseStream(peerID); err != nil {
+			d.logger.Error("Failed to close stream: ", err)
+		}
+	}()
+
+	for expected := chunk.from; expected <= chunk.to; expected++ {
+		select {
+		case block, ok := <-blockCh:
+			if !ok {
+				result.err = errIncompleteChunk
+
+				return result
+			}
+
+			if block.Number() != expected {
+				result.err = fmt.Errorf("%w, expected=%d, actual=%d", errUnexpectedNumber, expected, block.Number())
+
+				return result
+			}
+
+			result.blocks = append(result.blocks, block)
+		case <-time.After(d.blockTimeout):
+			result.err = errTimeout
+
+			return result
+		}
+	}
+
+	return result
+}
+
+// failedPeers returns the IDs of the peers dropped during the download
+func failedPeers(state *downloadState) []peer.ID {
+	ids := make([]peer.ID, 0, len(state.failed))
+
+	for id := range state.failed {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
@@ -0,0 +1,307 @@
+package syncer
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestBlockDownloader(
+	getBlocksHandler func(peer.ID, uint64, uint64, time.Duration) (<-chan *types.Block, error),
+	chunkSize uint64,
+) *blockDownloader {
+	downloader := newBlockDownloader(
+		hclog.NewNullLogger(),
+		&mockSyncPeerClient{
+			getBlocksHandler: getBlocksHandler,
+		},
+		time.Second,
+	)
+
+	downloader.chunkSize = chunkSize
+
+	return downloader
+}
+
+// newTestPeers creates peers whose distances follow the order of IDs
+func newTestPeers(numbers map[peer.ID]uint64) []*NoForkPeer {
+	peers := make([]*NoForkPeer, 0, len(numbers))
+
+	for id, number := range numbers {
+		peers = append(peers, &NoForkPeer{
+			ID:     id,
+			Number: number,
+		})
+	}
+
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].ID < peers[j].ID
+	})
+
+	for i, p := range peers {
+		p.Distance = big.NewInt(int64(i))
+	}
+
+	return peers
+}
+
+func Test_blockDownloader_download(t *testing.T) {
+	t.Parallel()
+
+	blocks := createMockBlocks(30)
+
+	errPeerNoResponse := errors.New("peer is not responding")
+
+	tests := []struct {
+		name string
+
+		peers            map[peer.ID]uint64
+		getBlocksHandler func(peer.ID, uint64, uint64, time.Duration) (<-chan *types.Block, error)
+		chunkSize        uint64
+		terminateAt      uint64
+		invalidBlock     map[uint64]peer.ID
+
+		// results
+		handledBlocks   []*types.Block
+		lastNumber      uint64
+		shouldTerminate bool
+		failedPeers     []peer.ID
+		err             error
+	}{
+		{
+			name: "should download blocks from multiple peers in order",
+			peers: map[peer.ID]uint64{
+				peer.ID("A"): 30,
+				peer.ID("B"): 30,
+				peer.ID("C"): 30,
+			},
+			getBlocksHandler: newBlockRangeHandler(map[peer.ID][]*types.Block{
+				peer.ID("A"): blocks,
+				peer.ID("B"): blocks,
+				peer.ID("C"): blocks,
+			}, time.Millisecond),
+			chunkSize:     4,
+			handledBlocks: blocks,
+			lastNumber:    30,
+			failedPeers:   []peer.ID{},
+		},
+		{
+			name: "should reassign chunk to another peer if a peer fails",
+			peers: map[peer.ID]uint64{
+				peer.ID("A"): 30,
+				peer.ID("B"): 30,
+			},
+			getBlocksHandler: func(id peer.ID, from, to uint64, d time.Duration) (<-chan *types.Block, error) {
+				if id == peer.ID("B") {
+					return nil, errPeerNoResponse
+				}
+
+				return newBlockRangeHandler(map[peer.ID][]*types.Block{
+					peer.ID("A"): blocks,
+				}, 0)(id, from, to, d)
+			},
+			chunkSize:     4,
+			handledBlocks: blocks,
+			lastNumber:    30,
+			failedPeers:   []peer.ID{peer.ID("B")},
+		},
+		{
+			name: "should download the rest of chunk from another peer if a peer sends part of chunk",
+			peers: map[peer.ID]uint64{
+				peer.ID("A"): 30,
+				peer.ID("B"): 30,
+			},
+			getBlocksHandler: func(id peer.ID, from, to uint64, d time.Duration) (<-chan *types.Block, error) {
+				if id == peer.ID("A") {
+					return newBlockRangeHandler(map[peer.ID][]*types.Block{
+						peer.ID("A"): blocks[:12],
+					}, 0)(id, from, to, d)
+				}
+
+				// B is slower than A
+				return newBlockRangeHandler(map[peer.ID][]*types.Block{
+					peer.ID("B"): blocks,
+				}, 5*time.Millisecond)(id, from, to, d)
+			},
+			chunkSize:     8,
+			handledBlocks: blocks,
+			lastNumber:    30,
+			failedPeers:   []peer.ID{peer.ID("A")},
+		},
+		{
+			name: "should download block again from another peer if handler rejects it",
+			peers: map[peer.ID]uint64{
+				peer.ID("A"): 30,
+				peer.ID("B"): 30,
+			},
+			getBlocksHandler: newBlockRangeHandler(map[peer.ID][]*types.Block{
+				peer.ID("A"): blocks,
+				peer.ID("B"): blocks,
+			}, 0),
+			chunkSize:     30,
+			invalidBlock:  map[uint64]peer.ID{15: ""},
+			handledBlocks: blocks,
+			lastNumber:    30,
+			failedPeers:   []peer.ID{peer.ID("A")},
+		},
+		{
+			name: "should stop when handler requests to terminate",
+			peers: map[peer.ID]uint64{
+				peer.ID("A"): 30,
+			},
+			getBlocksHandler: newBlockRangeHandler(map[peer.ID][]*types.Block{
+				peer.ID("A"): blocks,
+			}, 0),
+			chunkSize:       4,
+			terminateAt:     10,
+			handledBlocks:   blocks[:10],
+			lastNumber:      10,
+			shouldTerminate: true,
+			failedPeers:     []peer.ID{},
+		},
+		{
+			name: "should return error if no peer can serve the blocks",
+			peers: map[peer.ID]uint64{
+				peer.ID("A"): 20,
+			},
+			getBlocksHandler: newBlockRangeHandler(map[peer.ID][]*types.Block{
+				peer.ID("A"): blocks[:20],
+			}, 0),
+			chunkSize:     8,
+			handledBlocks: blocks[:16],
+			lastNumber:    16,
+			failedPeers:   []peer.ID{},
+			err:           errNoDownloadPeer,
+		},
+		{
+			name: "should return the error of the last failed peer",
+			peers: map[peer.ID]uint64{
+				peer.ID("A"): 30,
+			},
+			getBlocksHandler: func(peer.ID, uint64, uint64, time.Duration) (<-chan *types.Block, error) {
+				return nil, errPeerNoResponse
+			},
+			chunkSize:     8,
+			handledBlocks: []*types.Block{},
+			lastNumber:    0,
+			failedPeers:   []peer.ID{peer.ID("A")},
+			err:           errPeerNoResponse,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				handledBlocks = make([]*types.Block, 0, len(test.handledBlocks))
+				rejected      = make(map[uint64]bool)
+				downloader    = newTestBlockDownloader(test.getBlocksHandler, test.chunkSize)
+			)
+
+			lastNumber, shouldTerminate, failedPeers, err := downloader.download(
+				newTestPeers(test.peers),
+				1,
+				30,
+				func(b *types.Block) (bool, error) {
+					if _, ok := test.invalidBlock[b.Number()]; ok && !rejected[b.Number()] {
+						rejected[b.Number()] = true
+
+						return false, errors.New("invalid block")
+					}
+
+					handledBlocks = append(handledBlocks, b)
+
+					return test.terminateAt != 0 && b.Number() >= test.terminateAt, nil
+				},
+			)
+
+			assert.Equal(t, test.handledBlocks, handledBlocks)
+			assert.Equal(t, test.lastNumber, lastNumber)
+			assert.Equal(t, test.shouldTerminate, shouldTerminate)
+			assert.ElementsMatch(t, test.failedPeers, failedPeers)
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
+
+func Test_blockDownloader_downloadWithMultiplePeers(t *testing.T) {
+	t.Parallel()
+
+	var (
+		blocks = createMockBlocks(40)
+		peers  = map[peer.ID]uint64{
+			peer.ID("A"): 40,
+			peer.ID("B"): 40,
+			peer.ID("C"): 40,
+		}
+
+		servedLock sync.Mutex
+		served     = make(map[peer.ID]int)
+
+		rangeHandler = newBlockRangeHandler(map[peer.ID][]*types.Block{
+			peer.ID("A"): blocks,
+			peer.ID("B"): blocks,
+			peer.ID("C"): blocks,
+		}, 5*time.Millisecond)
+	)
+
+	downloader := newTestBlockDownloader(
+		func(id peer.ID, from, to uint64, d time.Duration) (<-chan *types.Block, error) {
+			servedLock.Lock()
+			served[id]++
+			servedLock.Unlock()
+
+			return rangeHandler(id, from, to, d)
+		},
+		4,
+	)
+
+	lastNumber, _, _, err := downloader.download(
+		newTestPeers(peers),
+		1,
+		40,
+		func(b *types.Block) (bool, error) {
+			return false, nil
+		},
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(40), lastNumber)
+
+	// every peer should have served at least one chunk
+	for id := range peers {
+		assert.Greater(t, served[id], 0)
+		assert.Greater(t, downloader.throughput.get(id).Blocks, uint64(0))
+	}
+}
+
+func Test_peerThroughput_BlocksPerSecond(t *testing.T) {
+	t.Parallel()
+
+	throughput := newPeerThroughputMap()
+
+	throughput.record(peer.ID("A"), 10, time.Second, false)
+	throughput.record(peer.ID("A"), 30, time.Second, true)
+
+	stat := throughput.get(peer.ID("A"))
+
+	assert.Equal(t, uint64(40), stat.Blocks)
+	assert.Equal(t, uint64(1), stat.Failures)
+	assert.Equal(t, float64(20), stat.BlocksPerSecond())
+	assert.Equal(t, float64(0), throughput.get(peer.ID("B")).BlocksPerSecond())
+
+	throughput.remove(peer.ID("A"))
+
+	assert.Equal(t, peerThroughput{}, throughput.get(peer.ID("A")))
+}
@@ -0,0 +1,271 @@
+package syncer
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// headerBatchSize is the number of headers requested from a peer at once
+	headerBatchSize = 256
+
+	// maxHeadersAhead is the max number of verified headers kept ahead of the written blocks
+	maxHeadersAhead = 4096
+)
+
+var (
+	errNoHeaderPeer      = errors.New("no peer can serve the headers")
+	errIncompleteHeaders = errors.New("peer didn't send all the headers in the range")
+	errUnexpectedHeader  = errors.New("unexpected header number")
+	errUnlinkedHeader    = errors.New("header doesn't link to its parent")
+)
+
+// headerPipeline fetches the headers ahead of the blocks and verifies their seals in batches,
+// so that the seals of the blocks don't need to be verified one by one while writing them
+type headerPipeline struct {
+	logger   hclog.Logger
+	client   SyncPeerClient
+	verifier HeaderVerifier
+
+	// Timeout for receiving a header
+	timeout time.Duration
+	// Number of headers requested at once
+	batchSize uint64
+
+	// header the pipeline begins after
+	parent *types.Header
+
+	lock *sync.Mutex
+	cond *sync.Cond
+
+	// hashes of the verified headers not consumed yet
+	hashes map[uint64]types.Hash
+	// number of the last verified header
+	verified uint64
+	// number of the last consumed header
+	consumed uint64
+	// whether the pipeline has stopped
+	done bool
+}
+
+func newHeaderPipeline(
+	logger hclog.Logger,
+	client SyncPeerClient,
+	verifier HeaderVerifier,
+	timeout time.Duration,
+	parent *types.Header,
+) *headerPipeline {
+	lock := &sync.Mutex{}
+
+	return &headerPipeline{
+		logger:    logger,
+		client:    client,
+		verifier:  verifier,
+		timeout:   timeout,
+		batchSize: headerBatchSize,
+		lock:      lock,
+		cond:      sync.NewCond(lock),
+		parent:    parent,
+		hashes:    make(map[uint64]types.Hash),
+		verified:  parent.Number,
+		consumed:  parent.Number,
+	}
+}
+
+// run fetches and verifies the headers following the parent up to the given height
+// until all the headers are verified, no peer can serve them or the pipeline is stopped
+func (p *headerPipeline) run(peers []*NoForkPeer, to uint64) {
+	defer p.stop()
+
+	parent := p.parent
+
+	// try the better peers first
+	candidates := make([]*NoForkPeer, len(peers))
+	copy(candidates, peers)
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].IsBetter(candidates[j])
+	})
+
+	failed := make(map[peer.ID]bool)
+
+	for next := parent.Number + 1; next <= to; {
+		if !p.waitForRoom(next) {
+			return
+		}
+
+		last := next + p.batchSize - 1
+		if last > to {
+			last = to
+		}
+
+		syncPeer := pickHeaderPeer(candidates, failed, last)
+		if syncPeer == nil {
+			p.logger.Debug("stop verifying headers in advance", "from", next, "err", errNoHeaderPeer)
+
+			return
+		}
+
+		headers, err := p.fetchHeaders(syncPeer.ID, parent, next, last)
+		if err != nil {
+			p.logger.Debug("failed to fetch headers", "peer", syncPeer.ID, "from", next, "to", last, "err", err)
+
+			failed[syncPeer.ID] = true
+
+			continue
+		}
+
+		if err := p.verifier.PreVerifyHeaders(parent, headers); err != nil {
+			p.logger.Debug("failed to verify headers", "peer", syncPeer.ID, "from", next, "to", last, "err", err)
+
+			failed[syncPeer.ID] = true
+
+			continue
+		}
+
+		if !p.publish(headers) {
+			return
+		}
+
+		parent = headers[len(headers)-1]
+		next = last + 1
+	}
+}
+
+// fetchHeaders downloads the headers in the range from the peer and
+// checks that they are contiguous and linked to the parent
+func (p *headerPipeline) fetchHeaders(
+	peerID peer.ID,
+	parent *types.Header,
+	from, to uint64,
+) ([]*types.Header, error) {
+	headerCh, err := p.client.GetHeaders(peerID, from, to, p.timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make([]*types.Header, 0, to-from+1)
+	prev := parent
+
+	for header := range headerCh {
+		if err == nil {
+			err = checkHeaderLink(prev, header)
+		}
+
+		// keep draining the channel to let the stream end
+		if err != nil {
+			continue
+		}
+
+		headers = append(headers, header)
+		prev = header
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if uint64(len(headers)) != to-from+1 {
+		return nil, errIncompleteHeaders
+	}
+
+	return headers, nil
+}
+
+// checkHeaderLink checks the header follows the parent
+func checkHeaderLink(parent, header *types.Header) error {
+	if header.Number != parent.Number+1 {
+		return fmt.Errorf("%w, expected=%d, actual=%d", errUnexpectedHeader, parent.Number+1, header.Number)
+	}
+
+	if header.ParentHash != parent.Hash {
+		return fmt.Errorf("%w, number=%d", errUnlinkedHeader, header.Number)
+	}
+
+	return nil
+}
+
+// pickHeaderPeer returns the first peer that hasn't failed and has the headers up to the given height
+func pickHeaderPeer(peers []*NoForkPeer, failed map[peer.ID]bool, last uint64) *NoForkPeer {
+	for _, p := range peers {
+		if !failed[p.ID] && p.Number >= last {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// waitForRoom blocks while too many verified headers are waiting for their blocks.
+// It returns false if the pipeline has been stopped
+func (p *headerPipeline) waitForRoom(next uint64) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for !p.done && next > p.consumed+maxHeadersAhead {
+		p.cond.Wait()
+	}
+
+	return !p.done
+}
+
+// publish makes the hashes of the verified headers available.
+// It returns false if the pipeline has been stopped
+func (p *headerPipeline) publish(headers []*types.Header) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.done {
+		return false
+	}
+
+	for _, header := range headers {
+		p.hashes[header.Number] = header.Hash
+	}
+
+	p.verified = headers[len(headers)-1].Number
+	p.cond.Broadcast()
+
+	return true
+}
+
+// hashAt waits until the header at the given height is verified and returns its hash.
+// It returns false if the pipeline stops before verifying the header
+func (p *headerPipeline) hashAt(number uint64) (types.Hash, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for !p.done && p.verified < number {
+		p.cond.Wait()
+	}
+
+	hash, ok := p.hashes[number]
+
+	// blocks are consumed in order, the hashes before the number are no longer needed
+	for n := p.consumed; n < number; n++ {
+		delete(p.hashes, n)
+	}
+
+	if number > p.consumed {
+		p.consumed = number
+		p.cond.Broadcast()
+	}
+
+	return hash, ok
+}
+
+// stop terminates the pipeline and releases the waiting callers
+func (p *headerPipeline) stop() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.done = true
+	p.cond.Broadcast()
+}
@@ -0,0 +1,212 @@
+package syncer
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockHeaderVerifier struct {
+	lock      sync.Mutex
+	verified  []uint64
+	rejectsAt map[uint64]bool
+}
+
+func (m *mockHeaderVerifier) PreVerifyHeaders(parent *types.Header, headers []*types.Header) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, h := range headers {
+		if m.rejectsAt[h.Number] {
+			// reject only once
+			delete(m.rejectsAt, h.Number)
+
+			return errors.New("invalid seals")
+		}
+	}
+
+	for _, h := range headers {
+		m.verified = append(m.verified, h.Number)
+	}
+
+	return nil
+}
+
+// createMockLinkedHeaders creates the headers from 0 to num linked by parent hash
+func createMockLinkedHeaders(num int) []*types.Header {
+	headers := make([]*types.Header, num+1)
+
+	for i := 0; i <= num; i++ {
+		header := &types.Header{
+			Number: uint64(i),
+		}
+
+		if i > 0 {
+			header.ParentHash = headers[i-1].Hash
+		}
+
+		headers[i] = header.ComputeHash()
+	}
+
+	return headers
+}
+
+func newHeaderRangeHandler(
+	peerHeaders map[peer.ID][]*types.Header,
+) func(peer.ID, uint64, uint64, time.Duration) (<-chan *types.Header, error) {
+	return func(id peer.ID, from, to uint64, _ time.Duration) (<-chan *types.Header, error) {
+		headerCh := make(chan *types.Header, to-from+1)
+
+		for _, h := range peerHeaders[id] {
+			if h.Number >= from && h.Number <= to {
+				headerCh <- h
+			}
+		}
+
+		close(headerCh)
+
+		return headerCh, nil
+	}
+}
+
+func Test_headerPipeline_run(t *testing.T) {
+	t.Parallel()
+
+	headers := createMockLinkedHeaders(30)
+
+	// header 10 doesn't link to header 9
+	forkedHeaders := createMockLinkedHeaders(30)
+	forkedHeaders[10] = (&types.Header{Number: 10}).ComputeHash()
+
+	tests := []struct {
+		name string
+
+		peers       map[peer.ID]uint64
+		peerHeaders map[peer.ID][]*types.Header
+		rejectsAt   map[uint64]bool
+
+		// results
+		verifiedTo uint64
+	}{
+		{
+			name: "should verify all headers in batches",
+			peers: map[peer.ID]uint64{
+				peer.ID("A"): 30,
+			},
+			peerHeaders: map[peer.ID][]*types.Header{
+				peer.ID("A"): headers,
+			},
+			verifiedTo: 30,
+		},
+		{
+			name: "should fetch headers from another peer if a peer sends unlinked headers",
+			peers: map[peer.ID]uint64{
+				peer.ID("A"): 30,
+				peer.ID("B"): 30,
+			},
+			peerHeaders: map[peer.ID][]*types.Header{
+				peer.ID("A"): forkedHeaders,
+				peer.ID("B"): headers,
+			},
+			verifiedTo: 30,
+		},
+		{
+			name: "should fetch headers from another peer if verifier rejects headers",
+			peers: map[peer.ID]uint64{
+				peer.ID("A"): 30,
+				peer.ID("B"): 30,
+			},
+			peerHeaders: map[peer.ID][]*types.Header{
+				peer.ID("A"): headers,
+				peer.ID("B"): headers,
+			},
+			rejectsAt:  map[uint64]bool{17: true},
+			verifiedTo: 30,
+		},
+		{
+			name: "should stop if no peer can serve the headers",
+			peers: map[peer.ID]uint64{
+				peer.ID("A"): 20,
+			},
+			peerHeaders: map[peer.ID][]*types.Header{
+				peer.ID("A"): headers[:21],
+			},
+			verifiedTo: 16,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			verifier := &mockHeaderVerifier{
+				rejectsAt: test.rejectsAt,
+			}
+
+			pipeline := newHeaderPipeline(
+				hclog.NewNullLogger(),
+				&mockSyncPeerClient{
+					getHeadersHandler: newHeaderRangeHandler(test.peerHeaders),
+				},
+				verifier,
+				time.Second,
+				headers[0],
+			)
+
+			pipeline.batchSize = 8
+
+			go pipeline.run(newTestPeers(test.peers), 30)
+
+			for number := uint64(1); number <= 30; number++ {
+				hash, ok := pipeline.hashAt(number)
+
+				if number <= test.verifiedTo {
+					assert.True(t, ok)
+					assert.Equal(t, headers[number].Hash, hash)
+				} else {
+					assert.False(t, ok)
+				}
+			}
+
+			assert.Len(t, verifier.verified, int(test.verifiedTo))
+		})
+	}
+}
+
+func Test_headerPipeline_stop(t *testing.T) {
+	t.Parallel()
+
+	pipeline := newHeaderPipeline(
+		hclog.NewNullLogger(),
+		&mockSyncPeerClient{},
+		&mockHeaderVerifier{},
+		time.Second,
+		&types.Header{Number: 0},
+	)
+
+	doneCh := make(chan struct{})
+
+	go func() {
+		defer close(doneCh)
+
+		_, ok := pipeline.hashAt(1)
+
+		assert.False(t, ok)
+	}()
+
+	pipeline.stop()
+
+	select {
+	case <-doneCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("hashAt is not released by stop")
+	}
+}
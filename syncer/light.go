@@ -0,0 +1,213 @@
+package syncer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	lightSyncerName = "light-syncer"
+)
+
+// lightSyncer follows the headers of the best peer without the bodies.
+// It doesn't serve the chain to the other peers
+type lightSyncer struct {
+	*syncer
+
+	blockchain LightBlockchain
+}
+
+func NewLightSyncer(
+	logger hclog.Logger,
+	network Network,
+	blockchain LightBlockchain,
+	headerTimeout time.Duration,
+	checkpointList []*chain.Checkpoint,
+) LightSyncer {
+	return &lightSyncer{
+		syncer: &syncer{
+			logger:          logger.Named(lightSyncerName),
+			blockchain:      blockchain,
+			syncProgression: progress.NewProgressionWrapper(progress.ChainSyncBulk),
+			syncPeerClient:  NewSyncPeerClient(logger, network, blockchain),
+			checkpoints:     newCheckpoints(checkpointList),
+			blockTimeout:    headerTimeout,
+			newStatusCh:     make(chan struct{}),
+			peerMap:         new(PeerMap),
+		},
+		blockchain: blockchain,
+	}
+}
+
+// Start starts goroutine processes
+func (s *lightSyncer) Start() error {
+	if err := s.syncPeerClient.Start(); err != nil {
+		return err
+	}
+
+	// light node can't serve blocks
+	s.syncPeerClient.DisablePublishingPeerStatus()
+
+	s.initializePeerMap()
+
+	go s.startPeerStatusUpdateProcess()
+	go s.startPeerConnectionEventProcess()
+
+	return nil
+}
+
+// Close terminates goroutine processes
+func (s *lightSyncer) Close() error {
+	close(s.newStatusCh)
+
+	s.syncPeerClient.Close()
+
+	return nil
+}
+
+// initializePeerMap fetches peer statuses and initializes map, skipping the peers that don't respond
+func (s *lightSyncer) initializePeerMap() {
+	for _, status := range s.syncPeerClient.GetConnectedPeerStatuses() {
+		if status != nil {
+			s.peerMap.Put(status)
+		}
+	}
+}
+
+// Sync syncs headers with the best peer until the syncer is closed
+func (s *lightSyncer) Sync() error {
+	skipList := make(map[peer.ID]bool)
+
+	for range s.newStatusCh {
+		localLatest := s.blockchain.Header().Number
+
+		// pick one best peer
+		bestPeer := s.peerMap.BestPeer(skipList)
+		if bestPeer == nil {
+			// Empty skipList map if there are no best peers
+			skipList = make(map[peer.ID]bool)
+
+			continue
+		}
+
+		updateSyncMetrics(localLatest, bestPeer.Number)
+
+		// if the bestPeer does not have a new header continue
+		if bestPeer.Number <= localLatest {
+			continue
+		}
+
+		// the node behind the checkpoint starts from it instead of the genesis
+		if err := s.startFromCheckpoint(bestPeer, localLatest); err != nil {
+			s.logger.Warn("failed to start from checkpoint with peer, try to next one", "peer", bestPeer.ID, "error", err)
+
+			skipList[bestPeer.ID] = true
+
+			continue
+		}
+
+		localLatest = s.blockchain.Header().Number
+		if bestPeer.Number <= localLatest {
+			continue
+		}
+
+		if err := s.syncHeadersWithPeer(bestPeer, localLatest+1); err != nil {
+			s.logger.Warn("failed to sync headers with peer, try to next one", "peer", bestPeer.ID, "error", err)
+
+			skipList[bestPeer.ID] = true
+		}
+	}
+
+	return nil
+}
+
+// syncHeadersWithPeer fetches the headers from the given height to the peer's latest in batches
+// and writes them after verification
+func (s *lightSyncer) syncHeadersWithPeer(syncPeer *NoForkPeer, from uint64) error {
+	s.syncProgression.StartProgression(from, s.blockchain.SubscribeEvents())
+	s.syncProgression.UpdateHighestProgression(syncPeer.Number)
+
+	defer s.syncProgression.StopProgression()
+
+	for from <= syncPeer.Number {
+		to := from + headerBatchSize - 1
+		if to > syncPeer.Number {
+			to = syncPeer.Number
+		}
+
+		headerCh, err := s.syncPeerClient.GetHeaders(syncPeer.ID, from, to, s.blockTimeout)
+		if err != nil {
+			return err
+		}
+
+		headers := make([]*types.Header, 0, to-from+1)
+		for header := range headerCh {
+			headers = append(headers, header)
+		}
+
+		// reject the peer serving the chain conflicting with the checkpoint
+		for _, header := range headers {
+			if err := s.checkpoints.verify(header); err != nil {
+				return err
+			}
+		}
+
+		if err := s.blockchain.WriteFinalizedHeaders(headers, lightSyncerName); err != nil {
+			return fmt.Errorf("failed to write headers: %w", err)
+		}
+
+		metrics.IncrCounter([]string{syncerMetrics, "headers"}, float32(len(headers)))
+		updateSyncMetrics(s.blockchain.Header().Number, syncPeer.Number)
+
+		if uint64(len(headers)) != to-from+1 {
+			return errIncompleteHeaders
+		}
+
+		from = to + 1
+	}
+
+	return nil
+}
+
+// startFromCheckpoint fetches the header at the latest checkpoint from the peer and writes it as the trusted head
+// if the local chain is behind the checkpoint, so that the headers are verified from the checkpoint
+// instead of the genesis. It does nothing if the peer hasn't reached the checkpoint
+func (s *lightSyncer) startFromCheckpoint(syncPeer *NoForkPeer, localLatest uint64) error {
+	number, ok := s.checkpoints.latest()
+	if !ok || number <= localLatest || syncPeer.Number < number {
+		return nil
+	}
+
+	headerCh, err := s.syncPeerClient.GetHeaders(syncPeer.ID, number, number, s.blockTimeout)
+	if err != nil {
+		return err
+	}
+
+	var header *types.Header
+	for h := range headerCh {
+		header = h
+	}
+
+	if header == nil || header.Number != number {
+		return errIncompleteHeaders
+	}
+
+	if err := s.checkpoints.verify(header); err != nil {
+		return err
+	}
+
+	if err := s.blockchain.WriteCheckpointHeader(header, lightSyncerName); err != nil {
+		return fmt.Errorf("failed to write checkpoint header: %w", err)
+	}
+
+	s.logger.Info("started from checkpoint", "number", number, "hash", header.Hash)
+
+	return nil
+}
@@ -0,0 +1,294 @@
+package syncer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	testHelper "github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockLightBlockchain struct {
+	mockBlockchain
+
+	writeFinalizedHeadersHandler func([]*types.Header) error
+	writeCheckpointHeaderHandler func(*types.Header) error
+}
+
+func (m *mockLightBlockchain) WriteFinalizedHeaders(headers []*types.Header, _ string) error {
+	return m.writeFinalizedHeadersHandler(headers)
+}
+
+func (m *mockLightBlockchain) WriteCheckpointHeader(header *types.Header, _ string) error {
+	return m.writeCheckpointHeaderHandler(header)
+}
+
+func Test_lightSyncer_syncHeadersWithPeer(t *testing.T) {
+	t.Parallel()
+
+	headers := createMockLinkedHeaders(600)
+
+	errInvalidHeader := errors.New("invalid header")
+
+	tests := []struct {
+		name string
+
+		from        uint64
+		peerLatest  uint64
+		peerHeaders []*types.Header
+		rejectsAt   uint64
+		checkpoints []*chain.Checkpoint
+
+		// results
+		writtenTo uint64
+		err       error
+	}{
+		{
+			name:        "should write all headers in batches",
+			from:        1,
+			peerLatest:  600,
+			peerHeaders: headers,
+			writtenTo:   600,
+		},
+		{
+			name:        "should return error if peer doesn't return all headers",
+			from:        1,
+			peerLatest:  600,
+			peerHeaders: headers[:300],
+			writtenTo:   299,
+			err:         errIncompleteHeaders,
+		},
+		{
+			name:        "should return error if header can't be written",
+			from:        1,
+			peerLatest:  600,
+			peerHeaders: headers,
+			rejectsAt:   400,
+			writtenTo:   399,
+			err:         errInvalidHeader,
+		},
+		{
+			name:        "should return error if peer serves headers conflicting with the checkpoint",
+			from:        1,
+			peerLatest:  600,
+			peerHeaders: headers,
+			checkpoints: []*chain.Checkpoint{
+				{
+					Number: 300,
+					Hash:   types.StringToHash("0x1"),
+				},
+			},
+			writtenTo: 256,
+			err:       errCheckpointMismatch,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				peerID  = peer.ID("A")
+				written uint64
+			)
+
+			chain := &mockLightBlockchain{
+				mockBlockchain: mockBlockchain{
+					headerHandler: func() *types.Header {
+						return &types.Header{Number: written}
+					},
+				},
+				writeFinalizedHeadersHandler: func(hs []*types.Header) error {
+					for _, h := range hs {
+						if h.Number == test.rejectsAt {
+							return errInvalidHeader
+						}
+
+						written = h.Number
+					}
+
+					return nil
+				},
+			}
+
+			s := &lightSyncer{
+				syncer: &syncer{
+					logger:          hclog.NewNullLogger(),
+					syncProgression: &mockProgression{},
+					syncPeerClient: &mockSyncPeerClient{
+						getHeadersHandler: newHeaderRangeHandler(map[peer.ID][]*types.Header{
+							peerID: test.peerHeaders,
+						}),
+					},
+					checkpoints:  newCheckpoints(test.checkpoints),
+					blockTimeout: time.Second,
+				},
+				blockchain: chain,
+			}
+
+			err := s.syncHeadersWithPeer(&NoForkPeer{ID: peerID, Number: test.peerLatest}, test.from)
+
+			assert.ErrorIs(t, err, test.err)
+			assert.Equal(t, test.writtenTo, written)
+		})
+	}
+}
+
+func Test_lightSyncer_Metrics(t *testing.T) {
+	// the metrics are global, the test can't run in parallel
+	sink := testHelper.NewMetricsSink(t)
+
+	var (
+		peerID  = peer.ID("A")
+		written uint64
+	)
+
+	s := &lightSyncer{
+		syncer: &syncer{
+			logger:          hclog.NewNullLogger(),
+			syncProgression: &mockProgression{},
+			syncPeerClient: &mockSyncPeerClient{
+				getHeadersHandler: newHeaderRangeHandler(map[peer.ID][]*types.Header{
+					peerID: createMockLinkedHeaders(300),
+				}),
+			},
+			checkpoints:  newCheckpoints(nil),
+			blockTimeout: time.Second,
+		},
+		blockchain: &mockLightBlockchain{
+			mockBlockchain: mockBlockchain{
+				headerHandler: func() *types.Header {
+					return &types.Header{Number: written}
+				},
+			},
+			writeFinalizedHeadersHandler: func(hs []*types.Header) error {
+				written = hs[len(hs)-1].Number
+
+				return nil
+			},
+		},
+	}
+
+	assert.NoError(t, s.syncHeadersWithPeer(&NoForkPeer{ID: peerID, Number: 300}, 1))
+
+	assert.Equal(t, float64(300), sink.Counter([]string{syncerMetrics, "headers"}))
+
+	lag, ok := sink.Gauge([]string{syncerMetrics, "lag"})
+	assert.True(t, ok)
+	assert.Equal(t, float32(0), lag)
+}
+
+func Test_lightSyncer_startFromCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	headers := createMockLinkedHeaders(600)
+
+	tests := []struct {
+		name string
+
+		localLatest uint64
+		peerLatest  uint64
+		peerHeaders []*types.Header
+		checkpoints []*chain.Checkpoint
+
+		// results
+		written *types.Header
+		err     error
+	}{
+		{
+			name:        "should do nothing without checkpoint",
+			peerLatest:  600,
+			peerHeaders: headers,
+		},
+		{
+			name:        "should write the header at the latest checkpoint",
+			peerLatest:  600,
+			peerHeaders: headers,
+			checkpoints: []*chain.Checkpoint{
+				{Number: 100, Hash: headers[100].Hash},
+				{Number: 500, Hash: headers[500].Hash},
+			},
+			written: headers[500],
+		},
+		{
+			name:        "should do nothing if the local chain has reached the checkpoint",
+			localLatest: 500,
+			peerLatest:  600,
+			peerHeaders: headers,
+			checkpoints: []*chain.Checkpoint{
+				{Number: 500, Hash: headers[500].Hash},
+			},
+		},
+		{
+			name:        "should do nothing if the peer hasn't reached the checkpoint",
+			peerLatest:  400,
+			peerHeaders: headers[:401],
+			checkpoints: []*chain.Checkpoint{
+				{Number: 500, Hash: headers[500].Hash},
+			},
+		},
+		{
+			name:        "should return error if the peer doesn't return the header",
+			peerLatest:  600,
+			peerHeaders: headers[:300],
+			checkpoints: []*chain.Checkpoint{
+				{Number: 500, Hash: headers[500].Hash},
+			},
+			err: errIncompleteHeaders,
+		},
+		{
+			name:        "should return error if the header conflicts with the checkpoint",
+			peerLatest:  600,
+			peerHeaders: headers,
+			checkpoints: []*chain.Checkpoint{
+				{Number: 500, Hash: types.StringToHash("0x1")},
+			},
+			err: errCheckpointMismatch,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				peerID  = peer.ID("A")
+				written *types.Header
+			)
+
+			s := &lightSyncer{
+				syncer: &syncer{
+					logger: hclog.NewNullLogger(),
+					syncPeerClient: &mockSyncPeerClient{
+						getHeadersHandler: newHeaderRangeHandler(map[peer.ID][]*types.Header{
+							peerID: test.peerHeaders,
+						}),
+					},
+					checkpoints:  newCheckpoints(test.checkpoints),
+					blockTimeout: time.Second,
+				},
+				blockchain: &mockLightBlockchain{
+					writeCheckpointHeaderHandler: func(h *types.Header) error {
+						written = h
+
+						return nil
+					},
+				},
+			}
+
+			err := s.startFromCheckpoint(&NoForkPeer{ID: peerID, Number: test.peerLatest}, test.localLatest)
+
+			assert.ErrorIs(t, err, test.err)
+			assert.Equal(t, test.written, written)
+		})
+	}
+}
@@ -67,3 +67,24 @@ func (m *PeerMap) BestPeer(skipMap map[peer.ID]bool) *NoForkPeer {
 
 	return bestPeer
 }
+
+// PeersAhead returns the peers whose latest block is higher than the given number
+func (m *PeerMap) PeersAhead(number uint64, skipMap map[peer.ID]bool) []*NoForkPeer {
+	peers := make([]*NoForkPeer, 0)
+
+	m.Range(func(key, value interface{}) bool {
+		peer, _ := value.(*NoForkPeer)
+
+		if skipMap != nil && skipMap[peer.ID] {
+			return true
+		}
+
+		if peer.Number > number {
+			peers = append(peers, peer)
+		}
+
+		return true
+	})
+
+	return peers
+}
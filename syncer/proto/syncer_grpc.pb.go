@@ -18,10 +18,12 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type SyncPeerClient interface {
-	// Returns stream of blocks beginning specified from
+	// Returns stream of blocks beginning specified from, up to the specified to if it is set
 	GetBlocks(ctx context.Context, in *GetBlocksRequest, opts ...grpc.CallOption) (SyncPeer_GetBlocksClient, error)
 	// Returns server's status
 	GetStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SyncPeerStatus, error)
+	// Returns stream of headers in the specified range
+	GetHeaders(ctx context.Context, in *GetHeadersRequest, opts ...grpc.CallOption) (SyncPeer_GetHeadersClient, error)
 }
 
 type syncPeerClient struct {
@@ -73,14 +75,48 @@ func (c *syncPeerClient) GetStatus(ctx context.Context, in *emptypb.Empty, opts
 	return out, nil
 }
 
+func (c *syncPeerClient) GetHeaders(ctx context.Context, in *GetHeadersRequest, opts ...grpc.CallOption) (SyncPeer_GetHeadersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SyncPeer_serviceDesc.Streams[1], "/v1.SyncPeer/GetHeaders", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &syncPeerGetHeadersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SyncPeer_GetHeadersClient interface {
+	Recv() (*Header, error)
+	grpc.ClientStream
+}
+
+type syncPeerGetHeadersClient struct {
+	grpc.ClientStream
+}
+
+func (x *syncPeerGetHeadersClient) Recv() (*Header, error) {
+	m := new(Header)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // SyncPeerServer is the server API for SyncPeer service.
 // All implementations must embed UnimplementedSyncPeerServer
 // for forward compatibility
 type SyncPeerServer interface {
-	// Returns stream of blocks beginning specified from
+	// Returns stream of blocks beginning specified from, up to the specified to if it is set
 	GetBlocks(*GetBlocksRequest, SyncPeer_GetBlocksServer) error
 	// Returns server's status
 	GetStatus(context.Context, *emptypb.Empty) (*SyncPeerStatus, error)
+	// Returns stream of headers in the specified range
+	GetHeaders(*GetHeadersRequest, SyncPeer_GetHeadersServer) error
 	mustEmbedUnimplementedSyncPeerServer()
 }
 
@@ -94,6 +130,9 @@ func (UnimplementedSyncPeerServer) GetBlocks(*GetBlocksRequest, SyncPeer_GetBloc
 func (UnimplementedSyncPeerServer) GetStatus(context.Context, *emptypb.Empty) (*SyncPeerStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
 }
+func (UnimplementedSyncPeerServer) GetHeaders(*GetHeadersRequest, SyncPeer_GetHeadersServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetHeaders not implemented")
+}
 func (UnimplementedSyncPeerServer) mustEmbedUnimplementedSyncPeerServer() {}
 
 // UnsafeSyncPeerServer may be embedded to opt out of forward compatibility for this service.
@@ -146,6 +185,27 @@ func _SyncPeer_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SyncPeer_GetHeaders_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetHeadersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SyncPeerServer).GetHeaders(m, &syncPeerGetHeadersServer{stream})
+}
+
+type SyncPeer_GetHeadersServer interface {
+	Send(*Header) error
+	grpc.ServerStream
+}
+
+type syncPeerGetHeadersServer struct {
+	grpc.ServerStream
+}
+
+func (x *syncPeerGetHeadersServer) Send(m *Header) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _SyncPeer_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "v1.SyncPeer",
 	HandlerType: (*SyncPeerServer)(nil),
@@ -161,6 +221,11 @@ var _SyncPeer_serviceDesc = grpc.ServiceDesc{
 			Handler:       _SyncPeer_GetBlocks_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetHeaders",
+			Handler:       _SyncPeer_GetHeaders_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "syncer/proto/syncer.proto",
 }
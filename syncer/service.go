@@ -11,7 +11,8 @@ import (
 )
 
 var (
-	ErrBlockNotFound = errors.New("block not found")
+	ErrBlockNotFound  = errors.New("block not found")
+	ErrHeaderNotFound = errors.New("header not found")
 )
 
 type syncPeerService struct {
@@ -51,13 +52,19 @@ func (s *syncPeerService) setupGRPCServer() {
 	s.network.RegisterProtocol(syncerProto, s.stream)
 }
 
-// GetBlocks is a gRPC endpoint to return blocks from the specific height via stream
+// GetBlocks is a gRPC endpoint to return blocks from the specific height via stream.
+// The stream ends at the requested last height if it's set, otherwise at the latest
 func (s *syncPeerService) GetBlocks(
 	req *proto.GetBlocksRequest,
 	stream proto.SyncPeer_GetBlocksServer,
 ) error {
-	// from to latest
-	for i := req.From; i <= s.blockchain.Header().Number; i++ {
+	// from to the requested end, or to latest if the end is not given
+	to := s.blockchain.Header().Number
+	if req.To != 0 && req.To < to {
+		to = req.To
+	}
+
+	for i := req.From; i <= to; i++ {
 		block, ok := s.blockchain.GetBlockByNumber(i, true)
 		if !ok {
 			return ErrBlockNotFound
@@ -74,6 +81,32 @@ func (s *syncPeerService) GetBlocks(
 	return nil
 }
 
+// GetHeaders is a gRPC endpoint to return headers in the specific range via stream.
+// The stream ends at the latest header if the last height is not set
+func (s *syncPeerService) GetHeaders(
+	req *proto.GetHeadersRequest,
+	stream proto.SyncPeer_GetHeadersServer,
+) error {
+	to := s.blockchain.Header().Number
+	if req.To != 0 && req.To < to {
+		to = req.To
+	}
+
+	for i := req.From; i <= to; i++ {
+		header, ok := s.blockchain.GetHeaderByNumber(i)
+		if !ok {
+			return ErrHeaderNotFound
+		}
+
+		// if client closes stream, context.Canceled is given
+		if err := stream.Send(toProtoHeader(header)); err != nil {
+			break
+		}
+	}
+
+	return nil
+}
+
 // GetStatus is a gRPC endpoint to return the latest block number as a node status
 func (s *syncPeerService) GetStatus(
 	ctx context.Context,
@@ -95,3 +128,10 @@ func toProtoBlock(block *types.Block) *proto.Block {
 		Block: block.MarshalRLP(),
 	}
 }
+
+// toProtoHeader converts type.Header -> proto.Header
+func toProtoHeader(header *types.Header) *proto.Header {
+	return &proto.Header{
+		Header: header.MarshalRLP(),
+	}
+}
@@ -60,6 +60,7 @@ func Test_syncPeerService_GetBlocks(t *testing.T) {
 	tests := []struct {
 		name           string
 		from           uint64
+		to             uint64
 		latest         uint64
 		blocks         []*types.Block
 		receivedBlocks []*types.Block
@@ -73,6 +74,24 @@ func Test_syncPeerService_GetBlocks(t *testing.T) {
 			receivedBlocks: blocks[4:], // from 5
 			err:            io.EOF,
 		},
+		{
+			name:           "should send the blocks to the given end",
+			from:           5,
+			to:             7,
+			latest:         10,
+			blocks:         blocks,
+			receivedBlocks: blocks[4:7], // from 5 to 7
+			err:            io.EOF,
+		},
+		{
+			name:           "should send the blocks to the latest if the given end is beyond the latest",
+			from:           5,
+			to:             20,
+			latest:         10,
+			blocks:         blocks,
+			receivedBlocks: blocks[4:], // from 5
+			err:            io.EOF,
+		},
 		{
 			name:           "should return ErrBlockNotFound",
 			from:           5,
@@ -112,6 +131,7 @@ func Test_syncPeerService_GetBlocks(t *testing.T) {
 
 			stream, err := client.GetBlocks(context.Background(), &proto.GetBlocksRequest{
 				From: test.from,
+				To:   test.to,
 			})
 
 			assert.NoError(t, err)
@@ -132,6 +152,102 @@ func Test_syncPeerService_GetBlocks(t *testing.T) {
 
 				count++
 			}
+
+			assert.Equal(t, len(test.receivedBlocks), count)
+		})
+	}
+}
+
+func Test_syncPeerService_GetHeaders(t *testing.T) {
+	t.Parallel()
+
+	blocks := createMockBlocks(10)
+
+	tests := []struct {
+		name            string
+		from            uint64
+		to              uint64
+		latest          uint64
+		blocks          []*types.Block
+		receivedHeaders []*types.Block
+		err             error
+	}{
+		{
+			name:            "should send the headers to the latest",
+			from:            5,
+			latest:          10,
+			blocks:          blocks,
+			receivedHeaders: blocks[4:], // from 5
+			err:             io.EOF,
+		},
+		{
+			name:            "should send the headers to the given end",
+			from:            5,
+			to:              7,
+			latest:          10,
+			blocks:          blocks,
+			receivedHeaders: blocks[4:7], // from 5 to 7
+			err:             io.EOF,
+		},
+		{
+			name:            "should return ErrHeaderNotFound",
+			from:            5,
+			latest:          10,
+			blocks:          blocks[:8],
+			receivedHeaders: blocks[4:8], // from 5
+			err:             ErrHeaderNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			headerMap := make(map[uint64]*types.Header)
+
+			for _, b := range test.blocks {
+				headerMap[b.Number()] = b.Header
+			}
+
+			service := &syncPeerService{
+				blockchain: &mockBlockchain{
+					headerHandler: newSimpleHeaderHandler(test.latest),
+					getHeaderByNumberHandler: func(u uint64) (*types.Header, bool) {
+						header, ok := headerMap[u]
+
+						return header, ok
+					},
+				},
+			}
+
+			client := newMockGrpcClient(t, service)
+
+			stream, err := client.GetHeaders(context.Background(), &proto.GetHeadersRequest{
+				From: test.from,
+				To:   test.to,
+			})
+
+			assert.NoError(t, err)
+
+			count := 0
+
+			for {
+				protoHeader, err := stream.Recv()
+				if err != nil {
+					assert.Contains(t, err.Error(), test.err.Error())
+
+					break
+				}
+
+				expected := test.receivedHeaders[count].Header.MarshalRLP()
+
+				assert.Equal(t, expected, protoHeader.Header)
+
+				count++
+			}
+
+			assert.Equal(t, len(test.receivedHeaders), count)
 		})
 	}
 }
@@ -5,16 +5,19 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/network/event"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
 const (
-	syncerName  = "syncer"
-	syncerProto = "/syncer/0.2"
+	syncerName    = "syncer"
+	syncerProto   = "/syncer/0.2"
+	syncerMetrics = "syncer"
 )
 
 var (
@@ -31,6 +34,9 @@ type syncer struct {
 	peerMap         *PeerMap
 	syncPeerService SyncPeerService
 	syncPeerClient  SyncPeerClient
+	downloader      *blockDownloader
+	headerVerifier  HeaderVerifier
+	checkpoints     checkpoints
 
 	// Timeout for syncing a block
 	blockTimeout time.Duration
@@ -44,13 +50,20 @@ func NewSyncer(
 	network Network,
 	blockchain Blockchain,
 	blockTimeout time.Duration,
+	headerVerifier HeaderVerifier,
+	checkpointList []*chain.Checkpoint,
 ) Syncer {
+	syncPeerClient := NewSyncPeerClient(logger, network, blockchain)
+
 	return &syncer{
 		logger:          logger.Named(syncerName),
 		blockchain:      blockchain,
 		syncProgression: progress.NewProgressionWrapper(progress.ChainSyncBulk),
 		syncPeerService: NewSyncPeerService(network, blockchain),
-		syncPeerClient:  NewSyncPeerClient(logger, network, blockchain),
+		syncPeerClient:  syncPeerClient,
+		downloader:      newBlockDownloader(logger.Named(syncerName), syncPeerClient, blockTimeout),
+		headerVerifier:  headerVerifier,
+		checkpoints:     newCheckpoints(checkpointList),
 		blockTimeout:    blockTimeout,
 		newStatusCh:     make(chan struct{}),
 		peerMap:         new(PeerMap),
@@ -134,6 +147,7 @@ func (s *syncer) putToPeerMap(status *NoForkPeer) {
 // removeFromPeerMap removes the peer from peer map
 func (s *syncer) removeFromPeerMap(peerID peer.ID) {
 	s.peerMap.Remove(peerID)
+	s.downloader.throughput.remove(peerID)
 }
 
 // notifyNewStatusEvent emits signal to newStatusCh
@@ -158,7 +172,7 @@ func (s *syncer) HasSyncPeer() bool {
 	return bestPeer != nil && bestPeer.Number > header.Number
 }
 
-// Sync syncs block with the best peer until callback returns true
+// Sync syncs blocks with the peers ahead of the local chain until callback returns true
 func (s *syncer) Sync(callback func(*types.Block) bool) error {
 	localLatest := s.blockchain.Header().Number
 	skipList := make(map[peer.ID]bool)
@@ -181,21 +195,29 @@ func (s *syncer) Sync(callback func(*types.Block) bool) error {
 			continue
 		}
 
+		updateSyncMetrics(localLatest, bestPeer.Number)
+
 		// if the bestPeer does not have a new block continue
 		if bestPeer.Number <= localLatest {
 			continue
 		}
 
-		// fetch block from the peer
-		lastNumber, shouldTerminate, err := s.bulkSyncWithPeer(bestPeer.ID, callback)
+		// fetch blocks from all the peers ahead of local
+		lastNumber, shouldTerminate, failedPeers, err := s.bulkSyncWithPeers(
+			s.peerMap.PeersAhead(localLatest, skipList),
+			bestPeer.Number,
+			callback,
+		)
 		if err != nil {
-			s.logger.Warn("failed to complete bulk sync with peer, try to next one", "peer ID", "error", bestPeer.ID, err)
+			s.logger.Warn("failed to complete bulk sync with peers, try to next ones", "error", err)
 		}
 
-		if lastNumber < bestPeer.Number {
-			skipList[bestPeer.ID] = true
+		for _, peerID := range failedPeers {
+			skipList[peerID] = true
+		}
 
-			// continue to next peer
+		if lastNumber < bestPeer.Number {
+			// continue to next peers
 			continue
 		}
 
@@ -207,50 +229,69 @@ func (s *syncer) Sync(callback func(*types.Block) bool) error {
 	return nil
 }
 
-// bulkSyncWithPeer syncs block with a given peer
-func (s *syncer) bulkSyncWithPeer(peerID peer.ID, newBlockCallback func(*types.Block) bool) (uint64, bool, error) {
-	localLatest := s.blockchain.Header().Number
-	shouldTerminate := false
-
-	blockCh, err := s.syncPeerClient.GetBlocks(peerID, localLatest+1, s.blockTimeout)
-	if err != nil {
-		return 0, false, err
-	}
+// bulkSyncWithPeers syncs blocks up to the given height by downloading them from the given peers in parallel.
+// If header verifier is set, the headers are fetched and their seals are verified in batches ahead of the blocks.
+// It returns the number of the last written block, the flag given by callback and the peers that failed
+func (s *syncer) bulkSyncWithPeers(
+	peers []*NoForkPeer,
+	target uint64,
+	newBlockCallback func(*types.Block) bool,
+) (uint64, bool, []peer.ID, error) {
+	localHeader := s.blockchain.Header()
 
-	defer func() {
-		err := s.syncPeerClient.CloseStream(peerID)
-		if err != nil {
-			s.logger.Error("Failed to close stream: ", err)
-		}
-	}()
+	var pipeline *headerPipeline
 
-	var lastReceivedNumber uint64
+	if s.headerVerifier != nil {
+		pipeline = newHeaderPipeline(s.logger, s.syncPeerClient, s.headerVerifier, s.blockTimeout, localHeader)
 
-	for {
-		select {
-		case block, ok := <-blockCh:
-			if !ok {
-				return lastReceivedNumber, shouldTerminate, nil
-			}
+		go pipeline.run(peers, target)
 
-			// safe check
-			if block.Number() == 0 {
-				continue
-			}
+		defer pipeline.stop()
+	}
 
-			if err := s.blockchain.VerifyFinalizedBlock(block); err != nil {
-				return lastReceivedNumber, false, fmt.Errorf("unable to verify block, %w", err)
+	return s.downloader.download(peers, localHeader.Number+1, target, func(block *types.Block) (bool, error) {
+		if pipeline != nil {
+			// the seals of the block are verified in advance only if the block matches the verified header
+			if hash, ok := pipeline.hashAt(block.Number()); ok && hash != block.Hash() {
+				s.logger.Warn(
+					"block doesn't match the verified header, stop verifying headers in advance",
+					"number", block.Number(),
+					"header", hash,
+					"block", block.Hash(),
+				)
+
+				pipeline.stop()
 			}
+		}
 
-			if err := s.blockchain.WriteBlock(block, syncerName); err != nil {
-				return lastReceivedNumber, false, fmt.Errorf("failed to write block while bulk syncing: %w", err)
-			}
+		// reject the peer serving the chain conflicting with the checkpoint
+		if err := s.checkpoints.verify(block.Header); err != nil {
+			return false, err
+		}
 
-			shouldTerminate = newBlockCallback(block)
+		if err := s.blockchain.VerifyFinalizedBlock(block); err != nil {
+			return false, fmt.Errorf("unable to verify block, %w", err)
+		}
 
-			lastReceivedNumber = block.Number()
-		case <-time.After(s.blockTimeout):
-			return lastReceivedNumber, shouldTerminate, errTimeout
+		if err := s.blockchain.WriteBlock(block, syncerName); err != nil {
+			return false, fmt.Errorf("failed to write block while bulk syncing: %w", err)
 		}
+
+		// blocks per second is given by the rate of the counter
+		metrics.IncrCounter([]string{syncerMetrics, "blocks"}, 1)
+		updateSyncMetrics(block.Number(), target)
+
+		return newBlockCallback(block), nil
+	})
+}
+
+// updateSyncMetrics records the number of the blocks the local chain is behind the best peer
+func updateSyncMetrics(local, target uint64) {
+	lag := uint64(0)
+	if target > local {
+		lag = target - local
 	}
+
+	metrics.SetGauge([]string{syncerMetrics, "lag"}, float32(lag))
+	metrics.SetGauge([]string{syncerMetrics, "target_height"}, float32(target))
 }
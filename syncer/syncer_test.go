@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
+	testHelper "github.com/0xPolygon/polygon-edge/helper/tests"
 	"github.com/0xPolygon/polygon-edge/network/event"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
@@ -39,6 +41,7 @@ type mockBlockchain struct {
 	subscription                blockchain.Subscription
 	headerHandler               func() *types.Header
 	getBlockByNumberHandler     func(uint64, bool) (*types.Block, bool)
+	getHeaderByNumberHandler    func(uint64) (*types.Header, bool)
 	verifyFinalizedBlockHandler func(*types.Block) error
 	writeBlockHandler           func(*types.Block) error
 }
@@ -55,6 +58,10 @@ func (m *mockBlockchain) GetBlockByNumber(number uint64, full bool) (*types.Bloc
 	return m.getBlockByNumberHandler(number, full)
 }
 
+func (m *mockBlockchain) GetHeaderByNumber(number uint64) (*types.Header, bool) {
+	return m.getHeaderByNumberHandler(number)
+}
+
 func (m *mockBlockchain) VerifyFinalizedBlock(b *types.Block) error {
 	return m.verifyFinalizedBlockHandler(b)
 }
@@ -84,7 +91,8 @@ func (m *mockProgression) StopProgression() {}
 type mockSyncPeerClient struct {
 	getPeerStatusHandler                  func(peer.ID) (*NoForkPeer, error)
 	getConnectedPeerStatusesHandler       func() []*NoForkPeer
-	getBlocksHandler                      func(peer.ID, uint64, time.Duration) (<-chan *types.Block, error)
+	getBlocksHandler                      func(peer.ID, uint64, uint64, time.Duration) (<-chan *types.Block, error)
+	getHeadersHandler                     func(peer.ID, uint64, uint64, time.Duration) (<-chan *types.Header, error)
 	getPeerStatusUpdateChHandler          func() <-chan *NoForkPeer
 	getPeerConnectionUpdateEventChHandler func() <-chan *event.PeerEvent
 }
@@ -110,9 +118,19 @@ func (m *mockSyncPeerClient) GetConnectedPeerStatuses() []*NoForkPeer {
 func (m *mockSyncPeerClient) GetBlocks(
 	id peer.ID,
 	start uint64,
+	end uint64,
 	timeoutPerBlock time.Duration,
 ) (<-chan *types.Block, error) {
-	return m.getBlocksHandler(id, start, timeoutPerBlock)
+	return m.getBlocksHandler(id, start, end, timeoutPerBlock)
+}
+
+func (m *mockSyncPeerClient) GetHeaders(
+	id peer.ID,
+	start uint64,
+	end uint64,
+	timeoutPerHeader time.Duration,
+) (<-chan *types.Header, error) {
+	return m.getHeadersHandler(id, start, end, timeoutPerHeader)
 }
 
 func (m *mockSyncPeerClient) GetPeerStatusUpdateCh() <-chan *NoForkPeer {
@@ -165,6 +183,7 @@ func NewTestSyncer(
 		syncProgression: mockProgression,
 		syncPeerService: &mockSyncPeerService{},
 		syncPeerClient:  mockSyncPeerClient,
+		downloader:      newBlockDownloader(hclog.NewNullLogger(), mockSyncPeerClient, blockTimeout),
 		blockTimeout:    blockTimeout,
 		newStatusCh:     make(chan struct{}),
 		peerMap:         new(PeerMap),
@@ -473,6 +492,25 @@ func blocksToCh(blocks []*types.Block, delay time.Duration) <-chan *types.Block
 	return ch
 }
 
+// newBlockRangeHandler returns a GetBlocks handler that streams the requested range
+// out of the blocks each peer has
+func newBlockRangeHandler(
+	peerBlocks map[peer.ID][]*types.Block,
+	delay time.Duration,
+) func(peer.ID, uint64, uint64, time.Duration) (<-chan *types.Block, error) {
+	return func(id peer.ID, from, to uint64, _ time.Duration) (<-chan *types.Block, error) {
+		blocks := make([]*types.Block, 0)
+
+		for _, b := range peerBlocks[id] {
+			if b.Number() >= from && (to == 0 || b.Number() <= to) {
+				blocks = append(blocks, b)
+			}
+		}
+
+		return blocksToCh(blocks, delay), nil
+	}
+}
+
 func createMockBlocks(num int) []*types.Block {
 	blocks := make([]*types.Block, num)
 	for i := 0; i < num; i++ {
@@ -501,7 +539,7 @@ func TestSync(t *testing.T) {
 		// peers
 		peerStatuses []*NoForkPeer
 
-		peerBlocksCh   map[peer.ID]<-chan *types.Block
+		peerBlocks     map[peer.ID][]*types.Block
 		newStatusDelay time.Duration
 
 		// handlers
@@ -530,8 +568,8 @@ func TestSync(t *testing.T) {
 				},
 			},
 			newStatusDelay: 0,
-			peerBlocksCh: map[peer.ID]<-chan *types.Block{
-				peer.ID("A"): blocksToCh(blocks[:10], 0),
+			peerBlocks: map[peer.ID][]*types.Block{
+				peer.ID("A"): blocks[:10],
 			},
 			createVerifyFinalizedBlockHandler: func() func(*types.Block) error {
 				return func(b *types.Block) error {
@@ -565,9 +603,9 @@ func TestSync(t *testing.T) {
 				},
 			},
 			newStatusDelay: 0,
-			peerBlocksCh: map[peer.ID]<-chan *types.Block{
-				peer.ID("A"): blocksToCh(blocks[:10], 0),
-				peer.ID("B"): blocksToCh(blocks[4:10], 0),
+			peerBlocks: map[peer.ID][]*types.Block{
+				peer.ID("A"): blocks[:10],
+				peer.ID("B"): blocks[4:10],
 			},
 			createVerifyFinalizedBlockHandler: func() func(*types.Block) error {
 				count := 0
@@ -606,7 +644,11 @@ func TestSync(t *testing.T) {
 				syncer = NewTestSyncer(
 					nil,
 					&mockBlockchain{
-						headerHandler:               newSimpleHeaderHandler(latestBlockNumber),
+						headerHandler: func() *types.Header {
+							return &types.Header{
+								Number: latestBlockNumber,
+							}
+						},
 						verifyFinalizedBlockHandler: test.createVerifyFinalizedBlockHandler(),
 						writeBlockHandler: func(b *types.Block) error {
 							syncedBlocks = append(syncedBlocks, b)
@@ -617,12 +659,7 @@ func TestSync(t *testing.T) {
 					},
 					time.Second,
 					&mockSyncPeerClient{
-						getBlocksHandler: func(i peer.ID, u uint64, _ time.Duration) (<-chan *types.Block, error) {
-							// should not panic
-							peerCh := test.peerBlocksCh[i]
-
-							return peerCh, nil
-						},
+						getBlocksHandler: newBlockRangeHandler(test.peerBlocks, 0),
 					},
 					progression,
 				)
@@ -654,7 +691,109 @@ func TestSync(t *testing.T) {
 	}
 }
 
-func Test_bulkSyncWithPeer(t *testing.T) {
+func TestSync_Metrics(t *testing.T) {
+	// the metrics are global, the test can't run in parallel
+	sink := testHelper.NewMetricsSink(t)
+
+	var (
+		blocks            = createMockBlocks(10)
+		latestBlockNumber = uint64(0)
+
+		syncer = NewTestSyncer(
+			nil,
+			&mockBlockchain{
+				headerHandler: func() *types.Header {
+					return &types.Header{
+						Number: latestBlockNumber,
+					}
+				},
+				verifyFinalizedBlockHandler: func(b *types.Block) error {
+					return nil
+				},
+				writeBlockHandler: func(b *types.Block) error {
+					latestBlockNumber = b.Number()
+
+					return nil
+				},
+			},
+			time.Second,
+			&mockSyncPeerClient{
+				getBlocksHandler: newBlockRangeHandler(map[peer.ID][]*types.Block{
+					peer.ID("A"): blocks,
+				}, 0),
+			},
+			&mockProgression{},
+		)
+	)
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- syncer.Sync(func(b *types.Block) bool {
+			return b.Number() >= 10
+		})
+	}()
+
+	syncer.peerMap.Put(&NoForkPeer{
+		ID:       peer.ID("A"),
+		Number:   10,
+		Distance: big.NewInt(0),
+	})
+	syncer.newStatusCh <- struct{}{}
+
+	assert.NoError(t, <-errCh)
+
+	assert.Equal(t, float64(10), sink.Counter([]string{syncerMetrics, "blocks"}))
+
+	lag, ok := sink.Gauge([]string{syncerMetrics, "lag"})
+	assert.True(t, ok)
+	assert.Equal(t, float32(0), lag)
+
+	target, ok := sink.Gauge([]string{syncerMetrics, "target_height"})
+	assert.True(t, ok)
+	assert.Equal(t, float32(10), target)
+}
+
+func Test_updateSyncMetrics(t *testing.T) {
+	tests := []struct {
+		name   string
+		local  uint64
+		target uint64
+		lag    float32
+	}{
+		{
+			name:   "should record the distance to the target",
+			local:  5,
+			target: 12,
+			lag:    7,
+		},
+		{
+			name:   "should not record a negative lag when ahead of the target",
+			local:  12,
+			target: 5,
+			lag:    0,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		// the metrics are global, the subtests can't run in parallel
+		t.Run(test.name, func(t *testing.T) {
+			sink := testHelper.NewMetricsSink(t)
+
+			updateSyncMetrics(test.local, test.target)
+
+			lag, _ := sink.Gauge([]string{syncerMetrics, "lag"})
+			assert.Equal(t, test.lag, lag)
+
+			target, _ := sink.Gauge([]string{syncerMetrics, "target_height"})
+			assert.Equal(t, float32(test.target), target)
+		})
+	}
+}
+
+func Test_bulkSyncWithPeers(t *testing.T) {
 	t.Parallel()
 
 	blockNum := 30
@@ -684,16 +823,18 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 		blockCallback   func(*types.Block) bool
 
 		// peers
-		getBlocksHandler func(id peer.ID, start uint64, timeoutPerBlock time.Duration) (<-chan *types.Block, error)
+		getBlocksHandler func(id peer.ID, start, end uint64, timeoutPerBlock time.Duration) (<-chan *types.Block, error)
 
 		// handlers
 		verifyFinalizedBlockHandler func(*types.Block) error
 		writeBlockHandler           func(*types.Block) error
+		checkpoints                 []*chain.Checkpoint
 
 		// results
 		blocks                []*types.Block
 		lastSyncedBlockNumber uint64
 		shouldTerminate       bool
+		failedPeers           []peer.ID
 		err                   error
 	}{
 		{
@@ -703,7 +844,7 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 			blockCallback: func(b *types.Block) bool {
 				return false
 			},
-			getBlocksHandler: func(id peer.ID, start uint64, _ time.Duration) (<-chan *types.Block, error) {
+			getBlocksHandler: func(id peer.ID, start, end uint64, _ time.Duration) (<-chan *types.Block, error) {
 				return blocksToCh(blocks[:10], 0), nil
 			},
 			verifyFinalizedBlockHandler: func(b *types.Block) error {
@@ -724,7 +865,7 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 			blockCallback: func(b *types.Block) bool {
 				return false
 			},
-			getBlocksHandler: func(id peer.ID, start uint64, _ time.Duration) (<-chan *types.Block, error) {
+			getBlocksHandler: func(id peer.ID, start, end uint64, _ time.Duration) (<-chan *types.Block, error) {
 				return nil, errPeerNoResponse
 			},
 			verifyFinalizedBlockHandler: func(b *types.Block) error {
@@ -736,6 +877,7 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 			blocks:                []*types.Block{},
 			lastSyncedBlockNumber: 0,
 			shouldTerminate:       false,
+			failedPeers:           []peer.ID{peer.ID("X")},
 			err:                   errPeerNoResponse,
 		},
 		{
@@ -745,7 +887,7 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 			blockCallback: func(b *types.Block) bool {
 				return false
 			},
-			getBlocksHandler: func(id peer.ID, start uint64, _ time.Duration) (<-chan *types.Block, error) {
+			getBlocksHandler: func(id peer.ID, start, end uint64, _ time.Duration) (<-chan *types.Block, error) {
 				return blocksToCh(blocks[:10], 0), nil
 			},
 			verifyFinalizedBlockHandler: func(b *types.Block) error {
@@ -761,6 +903,7 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 			blocks:                blocks[:5],
 			lastSyncedBlockNumber: 5,
 			shouldTerminate:       false,
+			failedPeers:           []peer.ID{peer.ID("X")},
 			err:                   errInvalidBlock,
 		},
 		{
@@ -770,7 +913,7 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 			blockCallback: func(b *types.Block) bool {
 				return false
 			},
-			getBlocksHandler: func(id peer.ID, start uint64, _ time.Duration) (<-chan *types.Block, error) {
+			getBlocksHandler: func(id peer.ID, start, end uint64, _ time.Duration) (<-chan *types.Block, error) {
 				return blocksToCh(blocks[:10], 0), nil
 			},
 			verifyFinalizedBlockHandler: func(b *types.Block) error {
@@ -786,6 +929,7 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 			blocks:                blocks[:5],
 			lastSyncedBlockNumber: 5,
 			shouldTerminate:       false,
+			failedPeers:           []peer.ID{peer.ID("X")},
 			err:                   errBlockInsertionFailed,
 		},
 		{
@@ -795,7 +939,7 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 			blockCallback: func(b *types.Block) bool {
 				return false
 			},
-			getBlocksHandler: func(id peer.ID, start uint64, _ time.Duration) (<-chan *types.Block, error) {
+			getBlocksHandler: func(id peer.ID, start, end uint64, _ time.Duration) (<-chan *types.Block, error) {
 				return blocksToCh(blocks[:10], time.Second*1), nil
 			},
 			verifyFinalizedBlockHandler: func(b *types.Block) error {
@@ -807,8 +951,37 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 			blocks:                []*types.Block{},
 			lastSyncedBlockNumber: 0,
 			shouldTerminate:       false,
+			failedPeers:           []peer.ID{peer.ID("X")},
 			err:                   errTimeout,
 		},
+		{
+			name:            "should reject peer if block conflicts with checkpoint",
+			beginningHeight: 0,
+			blockTimeout:    time.Second,
+			blockCallback: func(b *types.Block) bool {
+				return false
+			},
+			getBlocksHandler: func(id peer.ID, start, end uint64, _ time.Duration) (<-chan *types.Block, error) {
+				return blocksToCh(blocks[:10], 0), nil
+			},
+			verifyFinalizedBlockHandler: func(b *types.Block) error {
+				return nil
+			},
+			writeBlockHandler: func(b *types.Block) error {
+				return nil
+			},
+			checkpoints: []*chain.Checkpoint{
+				{
+					Number: 6,
+					Hash:   types.StringToHash("0x1"),
+				},
+			},
+			blocks:                blocks[:5],
+			lastSyncedBlockNumber: 5,
+			shouldTerminate:       false,
+			failedPeers:           []peer.ID{peer.ID("X")},
+			err:                   errCheckpointMismatch,
+		},
 	}
 
 	for _, test := range tests {
@@ -843,10 +1016,23 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 				)
 			)
 
-			lastSynced, shouldTerminate, err := syncer.bulkSyncWithPeer(peer.ID("X"), test.blockCallback)
+			syncer.checkpoints = newCheckpoints(test.checkpoints)
+
+			lastSynced, shouldTerminate, failedPeers, err := syncer.bulkSyncWithPeers(
+				[]*NoForkPeer{
+					{
+						ID:       peer.ID("X"),
+						Number:   uint64(blockNum),
+						Distance: big.NewInt(0),
+					},
+				},
+				10,
+				test.blockCallback,
+			)
 
 			assert.Equal(t, test.lastSyncedBlockNumber, lastSynced)
 			assert.Equal(t, test.shouldTerminate, shouldTerminate)
+			assert.ElementsMatch(t, test.failedPeers, failedPeers)
 			assert.ErrorIs(t, err, test.err)
 			assert.Equal(t, test.blocks, syncedBlocks)
 		})
@@ -23,12 +23,22 @@ type Blockchain interface {
 	Header() *types.Header
 	// GetBlockByNumber returns block by number
 	GetBlockByNumber(uint64, bool) (*types.Block, bool)
+	// GetHeaderByNumber returns header by number
+	GetHeaderByNumber(uint64) (*types.Header, bool)
 	// VerifyFinalizedBlock verifies finalized block
 	VerifyFinalizedBlock(*types.Block) error
 	// WriteBlock writes a given block to chain
 	WriteBlock(*types.Block, string) error
 }
 
+type LightBlockchain interface {
+	Blockchain
+	// WriteFinalizedHeaders verifies and writes the given headers without bodies
+	WriteFinalizedHeaders([]*types.Header, string) error
+	// WriteCheckpointHeader writes the header at the trusted checkpoint as the head without the ancestors
+	WriteCheckpointHeader(*types.Header, string) error
+}
+
 type Network interface {
 	// AddrInfo returns Network Info
 	AddrInfo() *peer.AddrInfo
@@ -66,6 +76,22 @@ type Syncer interface {
 	Sync(func(*types.Block) bool) error
 }
 
+type LightSyncer interface {
+	// Start starts syncer processes
+	Start() error
+	// Close terminates syncer process
+	Close() error
+	// Sync starts routine to sync headers until syncer is closed
+	Sync() error
+	// GetSyncProgression returns sync progression
+	GetSyncProgression() *progress.Progression
+}
+
+type HeaderVerifier interface {
+	// PreVerifyHeaders verifies seals of the contiguous headers following the parent in advance of their blocks
+	PreVerifyHeaders(parent *types.Header, headers []*types.Header) error
+}
+
 type Progression interface {
 	// StartProgression starts progression
 	StartProgression(startingBlock uint64, subscription blockchain.Subscription)
@@ -93,8 +119,10 @@ type SyncPeerClient interface {
 	GetPeerStatus(id peer.ID) (*NoForkPeer, error)
 	// GetConnectedPeerStatuses fetches the statuses of all connecting peers
 	GetConnectedPeerStatuses() []*NoForkPeer
-	// GetBlocks returns a stream of blocks from given height to peer's latest
-	GetBlocks(peer.ID, uint64, time.Duration) (<-chan *types.Block, error)
+	// GetBlocks returns a stream of blocks from given height to the given end, or to peer's latest if the end is 0
+	GetBlocks(peer.ID, uint64, uint64, time.Duration) (<-chan *types.Block, error)
+	// GetHeaders returns a stream of headers in the given range
+	GetHeaders(peer.ID, uint64, uint64, time.Duration) (<-chan *types.Header, error)
 	// GetPeerStatusUpdateCh returns a channel of peer's status update
 	GetPeerStatusUpdateCh() <-chan *NoForkPeer
 	// GetPeerConnectionUpdateEventCh returns peer's connection change event
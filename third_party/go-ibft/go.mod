@@ -0,0 +1,15 @@
+module github.com/0xPolygon/go-ibft
+
+go 1.17
+
+require (
+	github.com/google/uuid v1.3.0
+	github.com/stretchr/testify v1.8.0
+	google.golang.org/protobuf v1.28.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -2,11 +2,13 @@ package txpool
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 	"sync/atomic"
 
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
 	"github.com/google/uuid"
 	"github.com/hashicorp/go-hclog"
 )
@@ -87,6 +89,13 @@ func (em *eventManager) Close() {
 
 // signalEvent is a helper method for alerting listeners of a new TxPool event
 func (em *eventManager) signalEvent(eventType proto.EventType, txHashes ...types.Hash) {
+	// the events are counted regardless of the subscriptions
+	metrics.IncrCounterWithLabels(
+		[]string{txPoolMetrics, "events"},
+		float32(len(txHashes)),
+		[]metrics.Label{{Name: "type", Value: strings.ToLower(eventType.String())}},
+	)
+
 	if atomic.LoadInt64(&em.numSubscriptions) < 1 {
 		// No reason to lock the subscriptions map
 		// if no subscriptions exist
@@ -5,8 +5,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/helper/tests"
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
 )
@@ -133,6 +135,32 @@ func TestEventManager_SignalEvent(t *testing.T) {
 	assert.Equal(t, validEvents, supportedEventsProcessed)
 }
 
+func TestEventManager_SignalEventMetrics(t *testing.T) {
+	// the metrics are global, the test can't run in parallel
+	sink := tests.NewMetricsSink(t)
+
+	em := newEventManager(hclog.NewNullLogger())
+
+	defer em.Close()
+
+	// the events are counted without any subscription
+	em.signalEvent(proto.EventType_ADDED, types.StringToHash("0x1"), types.StringToHash("0x2"))
+	em.signalEvent(proto.EventType_DROPPED, types.StringToHash("0x1"))
+
+	assert.Equal(t, float64(2), sink.Counter(
+		[]string{txPoolMetrics, "events"},
+		metrics.Label{Name: "type", Value: "added"},
+	))
+	assert.Equal(t, float64(1), sink.Counter(
+		[]string{txPoolMetrics, "events"},
+		metrics.Label{Name: "type", Value: "dropped"},
+	))
+	assert.Zero(t, sink.Counter(
+		[]string{txPoolMetrics, "events"},
+		metrics.Label{Name: "type", Value: "promoted"},
+	))
+}
+
 func TestEventManager_SignalEventOrder(t *testing.T) {
 	totalEvents := 1000
 	supportedEventTypes := []proto.EventType{
@@ -344,8 +344,8 @@ func (p *TxPool) SetSealing(sealing bool) {
 	)
 }
 
-// sealing returns the current set sealing flag
-func (p *TxPool) getSealing() bool {
+// IsSealing returns the current set sealing flag
+func (p *TxPool) IsSealing() bool {
 	return atomic.LoadUint32(&p.sealing) == 1
 }
 
@@ -518,6 +518,74 @@ func (p *TxPool) ResetWithHeaders(headers ...*types.Header) {
 	p.processEvent(e)
 }
 
+// RewindWithHeaders returns the transactions of the headers removed
+// by the rewind of the chain to the pool and rolls back the accounts
+// which are ahead of the state of the new head.
+func (p *TxPool) RewindWithHeaders(headers ...*types.Header) {
+	returnedTxs := make([]*types.Transaction, 0)
+
+	for _, header := range headers {
+		block, ok := p.store.GetBlockByHash(header.Hash, true)
+		if !ok {
+			p.logger.Error("could not find block in store", "hash", header.Hash.String())
+
+			continue
+		}
+
+		returnedTxs = append(returnedTxs, block.Transactions...)
+	}
+
+	stateRoot := p.store.Header().StateRoot
+
+	p.accounts.Range(
+		func(key, value interface{}) bool {
+			address, _ := key.(types.Address)
+			account, _ := value.(*account)
+
+			stateNonce := p.store.GetNonce(stateRoot, address)
+			if stateNonce < account.getNonce() {
+				// the txs of the account are enqueued again with the rolled back nonce
+				returnedTxs = append(returnedTxs, p.rewindAccount(account, stateNonce)...)
+			}
+
+			return true
+		},
+	)
+
+	for _, tx := range returnedTxs {
+		if err := p.addTx(reorg, tx); err != nil {
+			p.logger.Debug("failed to return tx after rewind", "hash", tx.Hash.String(), "err", err)
+		}
+	}
+}
+
+// rewindAccount removes all the transactions of the account
+// and sets the nonce to the given one. It returns the removed transactions.
+func (p *TxPool) rewindAccount(account *account, nonce uint64) []*types.Transaction {
+	account.promoted.lock(true)
+	account.enqueued.lock(true)
+
+	defer func() {
+		account.enqueued.unlock()
+		account.promoted.unlock()
+	}()
+
+	account.setNonce(nonce)
+
+	promoted := account.promoted.clear()
+	enqueued := account.enqueued.clear()
+
+	removed := make([]*types.Transaction, 0, len(promoted)+len(enqueued))
+	removed = append(removed, promoted...)
+	removed = append(removed, enqueued...)
+
+	p.index.remove(removed...)
+	p.gauge.decrease(slotsRequired(removed...))
+	p.updatePending(-1 * int64(len(promoted)))
+
+	return removed
+}
+
 // processEvent collects the latest nonces for each account containted
 // in the received event. Resets all known accounts with the new nonce.
 func (p *TxPool) processEvent(event *blockchain.Event) {
@@ -595,7 +663,7 @@ func (p *TxPool) processEvent(event *blockchain.Event) {
 	// reset accounts with the new state
 	p.resetAccounts(stateNonces)
 
-	if !p.getSealing() {
+	if !p.IsSealing() {
 		// only non-validator cleanup inactive accounts
 		p.updateAccountSkipsCounts(stateNonces)
 	}
@@ -822,7 +890,7 @@ func (p *TxPool) handlePromoteRequest(req promoteRequest) {
 // addGossipTx handles receiving transactions
 // gossiped by the network.
 func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
-	if !p.getSealing() {
+	if !p.IsSealing() {
 		return
 	}
 
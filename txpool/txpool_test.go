@@ -2724,8 +2724,88 @@ func TestSetSealing(t *testing.T) {
 			assert.Equal(
 				t,
 				test.expectedValue,
-				pool.getSealing(),
+				pool.IsSealing(),
 			)
 		})
 	}
 }
+
+// rewindMockStore is the store returning the blocks removed by the rewind
+type rewindMockStore struct {
+	defaultMockStore
+
+	nonces map[types.Address]uint64
+	blocks map[types.Hash]*types.Block
+}
+
+func (m *rewindMockStore) GetNonce(_ types.Hash, addr types.Address) uint64 {
+	return m.nonces[addr]
+}
+
+func (m *rewindMockStore) GetBlockByHash(hash types.Hash, _ bool) (*types.Block, bool) {
+	block, ok := m.blocks[hash]
+
+	return block, ok
+}
+
+func TestRewindWithHeaders(t *testing.T) {
+	t.Parallel()
+
+	mockStore := &rewindMockStore{
+		defaultMockStore: NewDefaultMockStore(mockHeader),
+		nonces:           map[types.Address]uint64{},
+		blocks:           map[types.Hash]*types.Block{},
+	}
+
+	pool, err := newTestPool(mockStore)
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	pool.Start()
+	defer pool.Close()
+
+	promotedSubscription := pool.eventManager.subscribe(
+		[]proto.EventType{
+			proto.EventType_PROMOTED,
+		},
+	)
+	defer pool.eventManager.cancelSubscription(promotedSubscription.subscriptionID)
+
+	txs := []*types.Transaction{
+		newTx(addr1, 0, 1),
+		newTx(addr1, 1, 1),
+		newTx(addr1, 2, 1),
+		newTx(addr1, 3, 1),
+	}
+
+	for _, tx := range txs {
+		assert.NoError(t, pool.addTx(local, tx))
+	}
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancelFn()
+
+	assert.Len(t, waitForEvents(ctx, promotedSubscription, len(txs)), len(txs))
+
+	// the first 2 txs are included in the block
+	header := &types.Header{Number: 1}
+	header.ComputeHash()
+
+	mockStore.blocks[header.Hash] = &types.Block{
+		Header:       header,
+		Transactions: txs[:2],
+	}
+
+	pool.resetAccounts(map[types.Address]uint64{addr1: 2})
+	assert.Equal(t, uint64(2), pool.accounts.get(addr1).promoted.length())
+
+	// the block is removed by the rewind and the state goes back to the nonce 0
+	pool.RewindWithHeaders(header)
+
+	assert.Len(t, waitForEvents(ctx, promotedSubscription, len(txs)), len(txs))
+
+	assert.Equal(t, uint64(len(txs)), pool.accounts.get(addr1).getNonce())
+	assert.Equal(t, uint64(len(txs)), pool.accounts.get(addr1).promoted.length())
+	assert.Equal(t, uint64(0), pool.accounts.get(addr1).enqueued.length())
+	assert.Equal(t, slotsRequired(txs...), pool.gauge.read())
+}
@@ -3,6 +3,7 @@ package contract
 import (
 	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -19,8 +20,9 @@ const (
 )
 
 var (
-	ErrSignerNotFound                 = errors.New("signer not found")
-	ErrInvalidValidatorsTypeAssertion = errors.New("invalid type assertion for Validators")
+	ErrSignerNotFound                   = errors.New("signer not found")
+	ErrInvalidValidatorsTypeAssertion   = errors.New("invalid type assertion for Validators")
+	ErrInvalidVotingPowersTypeAssertion = errors.New("invalid type assertion for voting powers")
 )
 
 type ContractValidatorStore struct {
@@ -28,8 +30,14 @@ type ContractValidatorStore struct {
 	blockchain store.HeaderGetter
 	executor   Executor
 
+	// delegation returns whether the validators are selected by the total stake including the delegations
+	// at the given height, nil if the delegation is disabled
+	delegation func(height uint64) bool
+
 	// LRU cache for the validators
 	validatorSetCache *lru.Cache
+	// LRU cache for the voting powers of the validators
+	votingPowerCache *lru.Cache
 }
 
 type Executor interface {
@@ -41,26 +49,53 @@ func NewContractValidatorStore(
 	blockchain store.HeaderGetter,
 	executor Executor,
 	validatorSetCacheSize int,
+	delegation func(height uint64) bool,
 ) (*ContractValidatorStore, error) {
 	var (
-		validatorsCache *lru.Cache
-		err             error
+		validatorsCache   *lru.Cache
+		votingPowersCache *lru.Cache
+		err               error
 	)
 
 	if validatorSetCacheSize > 0 {
 		if validatorsCache, err = lru.New(validatorSetCacheSize); err != nil {
 			return nil, fmt.Errorf("unable to create validator set cache, %w", err)
 		}
+
+		if votingPowersCache, err = lru.New(validatorSetCacheSize); err != nil {
+			return nil, fmt.Errorf("unable to create voting power cache, %w", err)
+		}
 	}
 
 	return &ContractValidatorStore{
 		logger:            logger,
 		blockchain:        blockchain,
 		executor:          executor,
+		delegation:        delegation,
 		validatorSetCache: validatorsCache,
+		votingPowerCache:  votingPowersCache,
 	}, nil
 }
 
+// isDelegated returns whether the delegations are counted in the stake of the validators at the given height
+func (s *ContractValidatorStore) isDelegated(height uint64) bool {
+	return s.delegation != nil && s.delegation(height)
+}
+
+// Rewind drops the cached validators and voting powers,
+// which may have been fetched from the states of the removed blocks
+func (s *ContractValidatorStore) Rewind(_ uint64) error {
+	if s.validatorSetCache != nil {
+		s.validatorSetCache.Purge()
+	}
+
+	if s.votingPowerCache != nil {
+		s.votingPowerCache.Purge()
+	}
+
+	return nil
+}
+
 func (s *ContractValidatorStore) SourceType() store.SourceType {
 	return store.Contract
 }
@@ -83,7 +118,12 @@ func (s *ContractValidatorStore) GetValidatorsByHeight(
 		return nil, err
 	}
 
-	fetchedValidators, err := FetchValidators(validatorType, transition, types.ZeroAddress)
+	fetchValidators := FetchValidators
+	if s.isDelegated(height) {
+		fetchValidators = FetchDelegatedValidators
+	}
+
+	fetchedValidators, err := fetchValidators(validatorType, transition, types.ZeroAddress)
 	if err != nil {
 		return nil, err
 	}
@@ -93,6 +133,41 @@ func (s *ContractValidatorStore) GetValidatorsByHeight(
 	return fetchedValidators, nil
 }
 
+// GetVotingPowersByHeight returns the voting power of each validator at the given height,
+// which is the amount the validator has staked in the contract
+func (s *ContractValidatorStore) GetVotingPowersByHeight(
+	validatorType validators.ValidatorType,
+	height uint64,
+) (map[types.Address]*big.Int, error) {
+	cachedVotingPowers, err := s.loadCachedVotingPowers(height)
+	if err != nil {
+		return nil, err
+	}
+
+	if cachedVotingPowers != nil {
+		return cachedVotingPowers, nil
+	}
+
+	vals, err := s.GetValidatorsByHeight(validatorType, height)
+	if err != nil {
+		return nil, err
+	}
+
+	transition, err := s.getTransitionForQuery(height)
+	if err != nil {
+		return nil, err
+	}
+
+	votingPowers, err := FetchVotingPowers(transition, types.ZeroAddress, vals, s.isDelegated(height))
+	if err != nil {
+		return nil, err
+	}
+
+	s.saveToVotingPowerCache(height, votingPowers)
+
+	return votingPowers, nil
+}
+
 func (s *ContractValidatorStore) getTransitionForQuery(height uint64) (*state.Transition, error) {
 	header, ok := s.blockchain.GetHeaderByNumber(height)
 	if !ok {
@@ -125,3 +200,31 @@ func (s *ContractValidatorStore) saveToValidatorSetCache(height uint64, validato
 
 	return s.validatorSetCache.Add(height, validators)
 }
+
+// loadCachedVotingPowers loads voting powers from votingPowerCache
+func (s *ContractValidatorStore) loadCachedVotingPowers(height uint64) (map[types.Address]*big.Int, error) {
+	if s.votingPowerCache == nil {
+		return nil, nil
+	}
+
+	cachedRawVotingPowers, ok := s.votingPowerCache.Get(height)
+	if !ok {
+		return nil, nil
+	}
+
+	votingPowers, ok := cachedRawVotingPowers.(map[types.Address]*big.Int)
+	if !ok {
+		return nil, ErrInvalidVotingPowersTypeAssertion
+	}
+
+	return votingPowers, nil
+}
+
+// saveToVotingPowerCache saves voting powers to votingPowerCache
+func (s *ContractValidatorStore) saveToVotingPowerCache(height uint64, votingPowers map[types.Address]*big.Int) bool {
+	if s.votingPowerCache == nil {
+		return false
+	}
+
+	return s.votingPowerCache.Add(height, votingPowers)
+}
@@ -2,6 +2,7 @@ package contract
 
 import (
 	"errors"
+	"math/big"
 	"testing"
 
 	"github.com/0xPolygon/polygon-edge/chain"
@@ -11,6 +12,7 @@ import (
 	testHelper "github.com/0xPolygon/polygon-edge/helper/tests"
 	"github.com/0xPolygon/polygon-edge/state"
 	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/validators"
 	"github.com/0xPolygon/polygon-edge/validators/store"
@@ -78,11 +80,21 @@ func newTestTransition(
 ) *state.Transition {
 	t.Helper()
 
+	return newTestTransitionWithSystemRuntime(t, nil)
+}
+
+func newTestTransitionWithSystemRuntime(
+	t *testing.T,
+	systemRuntime runtime.Runtime,
+) *state.Transition {
+	t.Helper()
+
 	st := itrie.NewState(itrie.NewMemoryStorage())
 
 	ex := state.NewExecutor(&chain.Params{
 		Forks: chain.AllForksEnabled,
 	}, st, hclog.NewNullLogger())
+	ex.SystemRuntime = systemRuntime
 
 	rootHash := ex.WriteGenesis(nil)
 
@@ -136,9 +148,14 @@ func newTestContractValidatorStore(
 ) *ContractValidatorStore {
 	t.Helper()
 
-	var cache *lru.Cache
+	var (
+		cache            *lru.Cache
+		votingPowerCache *lru.Cache
+	)
+
 	if cacheSize > 0 {
 		cache = newTestCache(t, cacheSize)
+		votingPowerCache = newTestCache(t, cacheSize)
 	}
 
 	return &ContractValidatorStore{
@@ -146,6 +163,7 @@ func newTestContractValidatorStore(
 		blockchain:        blockchain,
 		executor:          executor,
 		validatorSetCache: cache,
+		votingPowerCache:  votingPowerCache,
 	}
 }
 
@@ -176,6 +194,7 @@ func TestNewContractValidatorStore(t *testing.T) {
 				blockchain:        blockchain,
 				executor:          executor,
 				validatorSetCache: newTestCache(t, 1),
+				votingPowerCache:  newTestCache(t, 1),
 			},
 			expectedErr: nil,
 		},
@@ -212,6 +231,7 @@ func TestNewContractValidatorStore(t *testing.T) {
 				blockchain,
 				executor,
 				test.cacheSize,
+				nil,
 			)
 
 			assert.Equal(t, test.expectedRes, res)
@@ -447,6 +467,69 @@ func TestContractValidatorStoreGetValidators(t *testing.T) {
 	}
 }
 
+func TestContractValidatorStoreGetVotingPowers(t *testing.T) {
+	t.Parallel()
+
+	var (
+		stateRoot = types.StringToHash("1")
+		header    = &types.Header{
+			StateRoot: stateRoot,
+		}
+
+		ecdsaValidators = validators.NewECDSAValidatorSet(
+			validators.NewECDSAValidator(addr1),
+			validators.NewECDSAValidator(addr2),
+		)
+
+		stakedAmount, _ = new(big.Int).SetString(stakingHelper.DefaultStakedBalance[2:], 16)
+
+		expectedVotingPowers = map[types.Address]*big.Int{
+			addr1: stakedAmount,
+			addr2: stakedAmount,
+		}
+
+		store = newTestContractValidatorStore(
+			t,
+			&store.MockBlockchain{
+				GetHeaderByNumberFn: func(height uint64) (*types.Header, bool) {
+					assert.Equal(t, uint64(1), height)
+
+					return header, true
+				},
+			},
+			&mockExecutor{
+				BeginTxnFn: func(hash types.Hash, head *types.Header, addr types.Address) (*state.Transition, error) {
+					assert.Equal(t, stateRoot, hash)
+
+					return newTestTransitionWithPredeployedStakingContract(t, ecdsaValidators), nil
+				},
+			},
+			1,
+		)
+	)
+
+	votingPowers, err := store.GetVotingPowersByHeight(validators.ECDSAValidatorType, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedVotingPowers, votingPowers)
+
+	// the voting powers are cached with the validator set
+	assert.Equal(t, 1, store.validatorSetCache.Len())
+
+	cache, ok := store.votingPowerCache.Get(uint64(1))
+
+	assert.True(t, ok)
+	assert.Equal(t, expectedVotingPowers, cache)
+
+	// invalid data in cache
+	store.votingPowerCache.Add(uint64(1), "fake")
+
+	votingPowers, err = store.GetVotingPowersByHeight(validators.ECDSAValidatorType, 1)
+
+	assert.Nil(t, votingPowers)
+	assert.ErrorIs(t, err, ErrInvalidVotingPowersTypeAssertion)
+}
+
 func TestContractValidatorStore_CacheChange(t *testing.T) {
 	var (
 		cacheSize = 2
@@ -556,3 +639,31 @@ func TestContractValidatorStore_NoCache(t *testing.T) {
 
 	assert.Nil(t, store.validatorSetCache)
 }
+
+func TestContractValidatorStore_Rewind(t *testing.T) {
+	t.Parallel()
+
+	var (
+		store = newTestContractValidatorStore(
+			t,
+			nil,
+			nil,
+			2,
+		)
+
+		ecdsaValidators = validators.NewECDSAValidatorSet(
+			validators.NewECDSAValidator(addr1),
+		)
+	)
+
+	store.saveToValidatorSetCache(10, ecdsaValidators)
+	store.votingPowerCache.Add(uint64(10), map[types.Address]*big.Int{addr1: big.NewInt(1)})
+
+	// the validators fetched from the removed blocks are dropped
+	assert.NoError(t, store.Rewind(5))
+	assert.Equal(t, 0, store.validatorSetCache.Len())
+	assert.Equal(t, 0, store.votingPowerCache.Len())
+
+	// nothing happens without the cache
+	assert.NoError(t, newTestContractValidatorStore(t, nil, nil, 0).Rewind(5))
+}
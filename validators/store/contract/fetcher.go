@@ -2,6 +2,8 @@ package contract
 
 import (
 	"fmt"
+	"math/big"
+	"sort"
 
 	"github.com/0xPolygon/polygon-edge/contracts/staking"
 	"github.com/0xPolygon/polygon-edge/crypto"
@@ -81,3 +83,173 @@ func FetchBLSValidators(
 
 	return blsValidators, nil
 }
+
+// FetchVotingPowers queries a contract for the amount staked by each validator
+// and returns it as the voting power of the validator.
+// The amount delegated to the validator is added if the delegation is enabled
+func FetchVotingPowers(
+	transition *state.Transition,
+	from types.Address,
+	vals validators.Validators,
+	delegation bool,
+) (map[types.Address]*big.Int, error) {
+	votingPowers := make(map[types.Address]*big.Int, vals.Len())
+
+	for idx := 0; idx < vals.Len(); idx++ {
+		addr := vals.At(uint64(idx)).Addr()
+
+		stake, err := fetchTotalStake(transition, from, addr, delegation)
+		if err != nil {
+			return nil, err
+		}
+
+		votingPowers[addr] = stake
+	}
+
+	return votingPowers, nil
+}
+
+// FetchDelegatedValidators queries a contract for the validators and the accounts having delegated stake,
+// and returns the ones with the highest total stake up to the maximum number of validators.
+// The validators keep the order in the contract
+func FetchDelegatedValidators(
+	validatorType validators.ValidatorType,
+	transition *state.Transition,
+	from types.Address,
+) (validators.Validators, error) {
+	vals := validators.NewValidatorSetFromType(validatorType)
+	if vals == nil {
+		return nil, fmt.Errorf("unsupported validator type: %s", validatorType)
+	}
+
+	candidates, err := fetchDelegationCandidates(transition, from)
+	if err != nil {
+		return nil, err
+	}
+
+	threshold, err := staking.QueryValidatorThreshold(transition, from)
+	if err != nil {
+		return nil, err
+	}
+
+	maxValidators, err := staking.QueryMaximumNumValidators(transition, from)
+	if err != nil {
+		return nil, err
+	}
+
+	type candidate struct {
+		index int
+		addr  types.Address
+		stake *big.Int
+	}
+
+	eligibles := make([]candidate, 0, len(candidates))
+
+	for idx, addr := range candidates {
+		stake, err := fetchTotalStake(transition, from, addr, true)
+		if err != nil {
+			return nil, err
+		}
+
+		if stake.Cmp(threshold) < 0 {
+			continue
+		}
+
+		eligibles = append(eligibles, candidate{index: idx, addr: addr, stake: stake})
+	}
+
+	// pick the candidates with the highest stake, the earlier one wins the tie
+	sort.SliceStable(eligibles, func(i, j int) bool {
+		return eligibles[i].stake.Cmp(eligibles[j].stake) > 0
+	})
+
+	if maxValidators.IsUint64() && uint64(len(eligibles)) > maxValidators.Uint64() {
+		eligibles = eligibles[:maxValidators.Uint64()]
+	}
+
+	sort.Slice(eligibles, func(i, j int) bool {
+		return eligibles[i].index < eligibles[j].index
+	})
+
+	for _, c := range eligibles {
+		var val validators.Validator
+
+		switch validatorType {
+		case validators.ECDSAValidatorType:
+			val = validators.NewECDSAValidator(c.addr)
+		case validators.BLSValidatorType:
+			blsPublicKey, err := staking.QueryBLSPublicKey(transition, from, c.addr)
+			if err != nil {
+				return nil, err
+			}
+
+			// ignore the validator whose BLS Key is not set
+			if _, err := crypto.UnmarshalBLSPublicKey(blsPublicKey); err != nil {
+				continue
+			}
+
+			val = validators.NewBLSValidator(c.addr, blsPublicKey)
+		}
+
+		if err := vals.Add(val); err != nil {
+			return nil, err
+		}
+	}
+
+	return vals, nil
+}
+
+// fetchDelegationCandidates returns the validators in the contract followed by
+// the other accounts having delegated stake
+func fetchDelegationCandidates(transition *state.Transition, from types.Address) ([]types.Address, error) {
+	valAddrs, err := staking.QueryValidators(transition, from)
+	if err != nil {
+		return nil, err
+	}
+
+	delegatedAddrs, err := staking.QueryDelegationCandidates(transition, from)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		candidates = make([]types.Address, 0, len(valAddrs)+len(delegatedAddrs))
+		seen       = make(map[types.Address]bool, len(valAddrs)+len(delegatedAddrs))
+	)
+
+	for _, addr := range append(valAddrs, delegatedAddrs...) {
+		if seen[addr] {
+			continue
+		}
+
+		seen[addr] = true
+
+		candidates = append(candidates, addr)
+	}
+
+	return candidates, nil
+}
+
+// fetchTotalStake returns the amount staked by the account and delegated to it if the delegation is enabled
+func fetchTotalStake(
+	transition *state.Transition,
+	from types.Address,
+	addr types.Address,
+	delegation bool,
+) (*big.Int, error) {
+	stake, err := staking.QueryAccountStake(transition, from, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if !delegation {
+		return stake, nil
+	}
+
+	delegated, err := staking.QueryDelegatedStake(transition, from, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).Add(stake, delegated), nil
+}
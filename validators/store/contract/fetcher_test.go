@@ -3,8 +3,12 @@ package contract
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/contracts/staking"
+	stakingHelper "github.com/0xPolygon/polygon-edge/helper/staking"
 	testHelper "github.com/0xPolygon/polygon-edge/helper/tests"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -140,3 +144,65 @@ func TestFetchBLSValidators(t *testing.T) {
 		})
 	}
 }
+
+func TestFetchDelegatedValidators(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr3 = types.StringToAddress("3")
+		addr4 = types.StringToAddress("4")
+		ether = big.NewInt(1e18)
+	)
+
+	// addr1 and addr2 stake 10 ETH by themselves
+	transition := newTestTransitionWithSystemRuntime(t, staking.NewDelegationRuntime())
+
+	contractState, err := stakingHelper.PredeployStakingSC(
+		validators.NewECDSAValidatorSet(
+			validators.NewECDSAValidator(addr1),
+			validators.NewECDSAValidator(addr2),
+		),
+		stakingHelper.PredeployParams{
+			MinValidatorCount: 1,
+			MaxValidatorCount: 2,
+		},
+	)
+	assert.NoError(t, err)
+	assert.NoError(t, transition.SetAccountDirectly(staking.AddrStakingContract, contractState))
+
+	delegations := []struct {
+		validator types.Address
+		amount    *big.Int
+	}{
+		// addr2 has 15 ETH in total
+		{validator: addr2, amount: new(big.Int).Mul(ether, big.NewInt(5))},
+		// addr3 has 20 ETH without staking by itself
+		{validator: addr3, amount: new(big.Int).Mul(ether, big.NewInt(20))},
+		// addr4 is below the threshold
+		{validator: addr4, amount: big.NewInt(1)},
+	}
+
+	for _, d := range delegations {
+		assert.NoError(t, stakingHelper.Delegate(
+			transition,
+			staking.AddrStakingContract,
+			types.StringToAddress("5"),
+			d.validator,
+			d.amount,
+			&chain.ForksInTime{},
+		))
+	}
+
+	res, err := FetchDelegatedValidators(validators.ECDSAValidatorType, transition, types.ZeroAddress)
+	assert.NoError(t, err)
+
+	// addr1 has the lowest stake and is out of the maximum number of validators
+	assert.Equal(
+		t,
+		validators.NewECDSAValidatorSet(
+			validators.NewECDSAValidator(addr2),
+			validators.NewECDSAValidator(addr3),
+		),
+		res,
+	)
+}
@@ -45,3 +45,46 @@ func addsOrDelsCandidate(
 		return validators.Del(candidate)
 	}
 }
+
+// checkKeyRotation is a helper function to check the key rotation can be applied to the validators in snapshot
+func checkKeyRotation(
+	snapshot *Snapshot,
+	rotation *KeyRotation,
+) error {
+	index := snapshot.Set.Index(rotation.Validator)
+	if index == -1 {
+		return ErrRotatingValidatorNotInSet
+	}
+
+	if snapshot.Set.At(uint64(index)).Equal(rotation.NewValidator) {
+		return ErrKeyRotationNoChange
+	}
+
+	newAddr := rotation.NewValidator.Addr()
+	if newAddr == rotation.Validator {
+		return nil
+	}
+
+	if snapshot.Set.Includes(newAddr) {
+		return ErrNewValidatorInSet
+	}
+
+	for _, r := range snapshot.Rotations {
+		if r.Validator != rotation.Validator && r.NewValidator.Addr() == newAddr {
+			return ErrNewValidatorInSet
+		}
+	}
+
+	return nil
+}
+
+// isKeyRotationApplied is a helper function to return the flag
+// indicating whether the validators have the new keys of the rotation
+func isKeyRotationApplied(
+	validators validators.Validators,
+	rotation *KeyRotation,
+) bool {
+	index := validators.Index(rotation.NewValidator.Addr())
+
+	return index != -1 && validators.At(uint64(index)).Equal(rotation.NewValidator)
+}
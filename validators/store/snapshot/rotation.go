@@ -0,0 +1,277 @@
+package snapshot
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/coinbase/kryptology/pkg/signatures/bls/bls_sig"
+	"github.com/umbracle/fastrlp"
+)
+
+var (
+	// Magic nonce number to record the key rotation of the proposer
+	nonceKeyRotation = types.Nonce{'r', 'o', 't', 'a', 't', 'i', 'o', 'n'}
+
+	// keyRotationDomain is prepended to the message signed by the new keys
+	keyRotationDomain = []byte("ibft-key-rotation")
+)
+
+var (
+	ErrInvalidKeyRotation        = errors.New("invalid key rotation")
+	ErrKeyRotationProofMissing   = errors.New("proof of possession of the new key is missing")
+	ErrInvalidKeyRotationProof   = errors.New("invalid proof of possession of the new key")
+	ErrKeyRotationNoChange       = errors.New("key rotation doesn't change any key")
+	ErrAlreadyRotating           = errors.New("key rotation of the validator is already pending")
+	ErrRotatingValidatorNotInSet = errors.New("the rotating validator is not in the snapshot")
+	ErrNewValidatorInSet         = errors.New("the new address is already a validator")
+)
+
+// KeyRotation replaces the keys of the validator at the beginning of the next epoch.
+// The validator keeps the position in the validator set and the rotation doesn't need votes.
+// The address changes only if the ECDSA key is rotated since the address is derived from the key.
+// The rotation is authorized by the proposer seal of the current key,
+// and the new keys prove their possession by signing the rotation
+type KeyRotation struct {
+	// Validator is the current address of the validator
+	Validator types.Address
+	// NewValidator is the validator with the new keys
+	NewValidator validators.Validator
+	// ECDSAProof is the signature by the new ECDSA key, empty if the address doesn't change
+	ECDSAProof []byte
+	// BLSProof is the signature by the BLS key of the new validator, required for BLS validators
+	BLSProof []byte
+}
+
+// NewKeyRotation creates the key rotation signed by the new keys.
+// newECDSAKey is nil if the ECDSA key is not rotated.
+// blsKey is the new BLS key, or the current one if only the ECDSA key is rotated, nil for ECDSA validators
+func NewKeyRotation(
+	validator types.Address,
+	newValidator validators.Validator,
+	newECDSAKey *ecdsa.PrivateKey,
+	blsKey *bls_sig.SecretKey,
+) (*KeyRotation, error) {
+	rotation := &KeyRotation{
+		Validator:    validator,
+		NewValidator: newValidator,
+	}
+
+	digest := rotation.digest()
+
+	var err error
+
+	if newECDSAKey != nil {
+		if rotation.ECDSAProof, err = crypto.Sign(newECDSAKey, digest); err != nil {
+			return nil, err
+		}
+	}
+
+	if blsKey != nil {
+		if rotation.BLSProof, err = crypto.SignByBLS(blsKey, digest); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := rotation.Verify(); err != nil {
+		return nil, err
+	}
+
+	return rotation, nil
+}
+
+// digest returns the message signed by the new keys
+func (r *KeyRotation) digest() []byte {
+	return crypto.Keccak256(keyRotationDomain, r.Validator.Bytes(), r.NewValidator.Bytes())
+}
+
+// Verify checks the proofs of possession of the new keys, which doesn't depend on the snapshot
+func (r *KeyRotation) Verify() error {
+	if r.NewValidator == nil {
+		return ErrInvalidKeyRotation
+	}
+
+	digest := r.digest()
+
+	if r.NewValidator.Addr() != r.Validator {
+		if len(r.ECDSAProof) == 0 {
+			return ErrKeyRotationProofMissing
+		}
+
+		pubKey, err := crypto.RecoverPubkey(r.ECDSAProof, digest)
+		if err != nil || crypto.PubKeyToAddress(pubKey) != r.NewValidator.Addr() {
+			return ErrInvalidKeyRotationProof
+		}
+	} else if len(r.ECDSAProof) != 0 {
+		return ErrInvalidKeyRotation
+	}
+
+	if blsValidator, ok := r.NewValidator.(*validators.BLSValidator); ok {
+		if len(r.BLSProof) == 0 {
+			return ErrKeyRotationProofMissing
+		}
+
+		if err := crypto.VerifyBLSSignatureFromBytes(blsValidator.BLSPublicKey, r.BLSProof, digest); err != nil {
+			return ErrInvalidKeyRotationProof
+		}
+	} else if len(r.BLSProof) != 0 {
+		return ErrInvalidKeyRotation
+	}
+
+	return nil
+}
+
+// Equal checks the rotations are the same
+func (r *KeyRotation) Equal(rr *KeyRotation) bool {
+	return r.Validator == rr.Validator && r.NewValidator.Equal(rr.NewValidator)
+}
+
+// Copy returns a copy of the rotation
+func (r *KeyRotation) Copy() *KeyRotation {
+	return &KeyRotation{
+		Validator:    r.Validator,
+		NewValidator: r.NewValidator.Copy(),
+		ECDSAProof:   append([]byte(nil), r.ECDSAProof...),
+		BLSProof:     append([]byte(nil), r.BLSProof...),
+	}
+}
+
+// MarshalRLPWith is a RLP Marshaller
+func (r *KeyRotation) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewBytes(r.Validator.Bytes()))
+	vv.Set(r.NewValidator.MarshalRLPWith(arena))
+	vv.Set(arena.NewCopyBytes(r.ECDSAProof))
+	vv.Set(arena.NewCopyBytes(r.BLSProof))
+
+	return vv
+}
+
+// UnmarshalRLPFrom is a RLP Unmarshaller, NewValidator needs to be set with the validator type in advance
+func (r *KeyRotation) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) != 4 {
+		return fmt.Errorf("incorrect number of elements to decode key rotation, expected 4 but found %d", len(elems))
+	}
+
+	if err := elems[0].GetAddr(r.Validator[:]); err != nil {
+		return err
+	}
+
+	if err := r.NewValidator.UnmarshalRLPFrom(p, elems[1]); err != nil {
+		return err
+	}
+
+	if r.ECDSAProof, err = elems[2].GetBytes(nil); err != nil {
+		return err
+	}
+
+	if r.BLSProof, err = elems[3].GetBytes(nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Bytes returns the RLP encoded rotation
+func (r *KeyRotation) Bytes() []byte {
+	return types.MarshalRLPTo(r.MarshalRLPWith, nil)
+}
+
+// MarshalJSON is a JSON Marshaller
+func (r *KeyRotation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Validator    types.Address
+		NewValidator validators.Validator
+		ECDSAProof   string
+		BLSProof     string
+	}{
+		Validator:    r.Validator,
+		NewValidator: r.NewValidator,
+		ECDSAProof:   hex.EncodeToHex(r.ECDSAProof),
+		BLSProof:     hex.EncodeToHex(r.BLSProof),
+	})
+}
+
+// UnmarshalJSON is a JSON Unmarshaller, NewValidator needs to be set with the validator type in advance
+func (r *KeyRotation) UnmarshalJSON(data []byte) error {
+	raw := struct {
+		Validator    types.Address
+		NewValidator json.RawMessage
+		ECDSAProof   string
+		BLSProof     string
+	}{}
+
+	var err error
+
+	if err = json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	r.Validator = raw.Validator
+
+	if err = json.Unmarshal(raw.NewValidator, r.NewValidator); err != nil {
+		return err
+	}
+
+	if r.ECDSAProof, err = hex.DecodeHex(raw.ECDSAProof); err != nil {
+		return err
+	}
+
+	if r.BLSProof, err = hex.DecodeHex(raw.BLSProof); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// keyRotationToMiner converts the rotation to bytes for miner field in header
+func keyRotationToMiner(rotation *KeyRotation) []byte {
+	return rotation.Bytes()
+}
+
+// minerToKeyRotation converts bytes in miner field in header to the rotation
+func minerToKeyRotation(validatorType validators.ValidatorType, miner []byte) (*KeyRotation, error) {
+	newValidator, err := validators.NewValidatorFromType(validatorType)
+	if err != nil {
+		return nil, err
+	}
+
+	rotation := &KeyRotation{
+		NewValidator: newValidator,
+	}
+
+	if err := types.UnmarshalRlp(rotation.UnmarshalRLPFrom, miner); err != nil {
+		return nil, err
+	}
+
+	return rotation, nil
+}
+
+// rotateValidator returns the validators whose validator is replaced by the rotation at the same position
+func rotateValidator(set validators.Validators, rotation *KeyRotation) (validators.Validators, error) {
+	newSet := validators.NewValidatorSetFromType(set.Type())
+
+	for idx := 0; idx < set.Len(); idx++ {
+		validator := set.At(uint64(idx))
+		if validator.Addr() == rotation.Validator {
+			validator = rotation.NewValidator
+		}
+
+		if err := newSet.Add(validator.Copy()); err != nil {
+			return nil, err
+		}
+	}
+
+	return newSet, nil
+}
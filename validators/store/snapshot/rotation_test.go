@@ -0,0 +1,350 @@
+package snapshot
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/coinbase/kryptology/pkg/signatures/bls/bls_sig"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestECDSAKey(t *testing.T) (*ecdsa.PrivateKey, types.Address) {
+	t.Helper()
+
+	key, err := crypto.GenerateECDSAKey()
+	assert.NoError(t, err)
+
+	return key, crypto.PubKeyToAddress(&key.PublicKey)
+}
+
+func newTestBLSKey(t *testing.T) (*bls_sig.SecretKey, []byte) {
+	t.Helper()
+
+	key, err := crypto.GenerateBLSKey()
+	assert.NoError(t, err)
+
+	pubkey, err := crypto.BLSSecretKeyToPubkeyBytes(key)
+	assert.NoError(t, err)
+
+	return key, pubkey
+}
+
+func TestNewKeyRotation(t *testing.T) {
+	t.Parallel()
+
+	newECDSAKey, newAddr := newTestECDSAKey(t)
+	newBLSKey, newBLSPubkey := newTestBLSKey(t)
+
+	t.Run("should create the rotation of ECDSA key", func(t *testing.T) {
+		t.Parallel()
+
+		rotation, err := NewKeyRotation(addr1, validators.NewECDSAValidator(newAddr), newECDSAKey, nil)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, rotation.ECDSAProof)
+		assert.Empty(t, rotation.BLSProof)
+	})
+
+	t.Run("should create the rotation of BLS key keeping the address", func(t *testing.T) {
+		t.Parallel()
+
+		rotation, err := NewKeyRotation(addr1, validators.NewBLSValidator(addr1, newBLSPubkey), nil, newBLSKey)
+
+		assert.NoError(t, err)
+		assert.Empty(t, rotation.ECDSAProof)
+		assert.NotEmpty(t, rotation.BLSProof)
+	})
+
+	t.Run("should return error if the new address is not proven", func(t *testing.T) {
+		t.Parallel()
+
+		rotation, err := NewKeyRotation(addr1, validators.NewECDSAValidator(newAddr), nil, nil)
+
+		assert.Nil(t, rotation)
+		assert.ErrorIs(t, err, ErrKeyRotationProofMissing)
+	})
+
+	t.Run("should return error if the proof is signed by other key", func(t *testing.T) {
+		t.Parallel()
+
+		otherKey, _ := newTestECDSAKey(t)
+
+		rotation, err := NewKeyRotation(addr1, validators.NewECDSAValidator(newAddr), otherKey, nil)
+
+		assert.Nil(t, rotation)
+		assert.ErrorIs(t, err, ErrInvalidKeyRotationProof)
+	})
+
+	t.Run("should return error if BLS key is not proven", func(t *testing.T) {
+		t.Parallel()
+
+		otherBLSKey, _ := newTestBLSKey(t)
+
+		rotation, err := NewKeyRotation(addr1, validators.NewBLSValidator(addr1, newBLSPubkey), nil, otherBLSKey)
+
+		assert.Nil(t, rotation)
+		assert.ErrorIs(t, err, ErrInvalidKeyRotationProof)
+	})
+}
+
+func TestKeyRotation_Encoding(t *testing.T) {
+	t.Parallel()
+
+	newECDSAKey, newAddr := newTestECDSAKey(t)
+	newBLSKey, newBLSPubkey := newTestBLSKey(t)
+
+	rotation, err := NewKeyRotation(addr1, validators.NewBLSValidator(newAddr, newBLSPubkey), newECDSAKey, newBLSKey)
+	assert.NoError(t, err)
+
+	t.Run("RLP", func(t *testing.T) {
+		t.Parallel()
+
+		decoded, err := minerToKeyRotation(validators.BLSValidatorType, keyRotationToMiner(rotation))
+
+		assert.NoError(t, err)
+		assert.Equal(t, rotation, decoded)
+		assert.NoError(t, decoded.Verify())
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := json.Marshal(rotation)
+		assert.NoError(t, err)
+
+		decoded := &KeyRotation{NewValidator: &validators.BLSValidator{}}
+
+		assert.NoError(t, json.Unmarshal(data, decoded))
+		assert.Equal(t, rotation, decoded)
+	})
+
+	t.Run("Snapshot JSON", func(t *testing.T) {
+		t.Parallel()
+
+		snapshot := &Snapshot{
+			Number:    10,
+			Hash:      types.BytesToHash(crypto.Keccak256([]byte{10})).String(),
+			Set:       validators.NewBLSValidatorSet(blsValidator1),
+			Rotations: []*KeyRotation{rotation},
+		}
+
+		data, err := json.Marshal(snapshot)
+		assert.NoError(t, err)
+
+		decoded := &Snapshot{}
+
+		assert.NoError(t, json.Unmarshal(data, decoded))
+		assert.True(t, snapshot.Equal(decoded))
+		assert.Equal(t, snapshot.Rotations, decoded.Rotations)
+	})
+}
+
+func Test_rotateValidator(t *testing.T) {
+	t.Parallel()
+
+	_, newAddr := newTestECDSAKey(t)
+
+	set := validators.NewECDSAValidatorSet(ecdsaValidator1, ecdsaValidator2)
+
+	newSet, err := rotateValidator(set, &KeyRotation{
+		Validator:    ecdsaValidator1.Address,
+		NewValidator: validators.NewECDSAValidator(newAddr),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		validators.NewECDSAValidatorSet(validators.NewECDSAValidator(newAddr), ecdsaValidator2),
+		newSet,
+	)
+
+	// the original set must not be changed
+	assert.Equal(t, validators.NewECDSAValidatorSet(ecdsaValidator1, ecdsaValidator2), set)
+}
+
+func Test_processKeyRotation(t *testing.T) {
+	t.Parallel()
+
+	newECDSAKey, newAddr := newTestECDSAKey(t)
+
+	rotation, err := NewKeyRotation(addr1, validators.NewECDSAValidator(newAddr), newECDSAKey, nil)
+	assert.NoError(t, err)
+
+	newSnapshot := func() *Snapshot {
+		return &Snapshot{
+			Set: validators.NewECDSAValidatorSet(ecdsaValidator1, ecdsaValidator2),
+		}
+	}
+
+	t.Run("should record the rotation proposed by the rotating validator", func(t *testing.T) {
+		t.Parallel()
+
+		snapshot := newSnapshot()
+		header := newTestHeader(1, keyRotationToMiner(rotation), nonceKeyRotation)
+
+		assert.NoError(t, processKeyRotation(snapshot, header, validators.ECDSAValidatorType, addr1))
+		assert.Equal(t, []*KeyRotation{rotation}, snapshot.Rotations)
+	})
+
+	t.Run("should return error if other validator proposes the rotation", func(t *testing.T) {
+		t.Parallel()
+
+		snapshot := newSnapshot()
+		header := newTestHeader(1, keyRotationToMiner(rotation), nonceKeyRotation)
+
+		assert.ErrorIs(
+			t,
+			processKeyRotation(snapshot, header, validators.ECDSAValidatorType, addr2),
+			ErrUnauthorizedProposer,
+		)
+		assert.Empty(t, snapshot.Rotations)
+	})
+
+	t.Run("should ignore the rotation to the address of the other validator", func(t *testing.T) {
+		t.Parallel()
+
+		key, err := crypto.GenerateECDSAKey()
+		assert.NoError(t, err)
+
+		snapshot := &Snapshot{
+			Set: validators.NewECDSAValidatorSet(
+				ecdsaValidator1,
+				validators.NewECDSAValidator(crypto.PubKeyToAddress(&key.PublicKey)),
+			),
+		}
+
+		conflicting, err := NewKeyRotation(
+			addr1,
+			validators.NewECDSAValidator(crypto.PubKeyToAddress(&key.PublicKey)),
+			key,
+			nil,
+		)
+		assert.NoError(t, err)
+
+		header := newTestHeader(1, keyRotationToMiner(conflicting), nonceKeyRotation)
+
+		assert.NoError(t, processKeyRotation(snapshot, header, validators.ECDSAValidatorType, addr1))
+		assert.Empty(t, snapshot.Rotations)
+	})
+}
+
+func TestSnapshotValidatorStore_resetSnapshot_KeyRotation(t *testing.T) {
+	t.Parallel()
+
+	newECDSAKey, newAddr := newTestECDSAKey(t)
+
+	rotation, err := NewKeyRotation(addr1, validators.NewECDSAValidator(newAddr), newECDSAKey, nil)
+	assert.NoError(t, err)
+
+	var (
+		header = &types.Header{
+			Number: 20,
+			Hash:   types.BytesToHash(crypto.Keccak256([]byte{20})),
+		}
+
+		parentSnapshot = &Snapshot{
+			Number:    10,
+			Set:       validators.NewECDSAValidatorSet(ecdsaValidator1, ecdsaValidator2),
+			Rotations: []*KeyRotation{rotation},
+		}
+	)
+
+	snapshotStore := newTestSnapshotValidatorStore(
+		nil,
+		nil,
+		10,
+		[]*Snapshot{parentSnapshot},
+		nil,
+		10,
+	)
+
+	snapshotStore.resetSnapshot(parentSnapshot, parentSnapshot.Copy(), header)
+
+	assert.Equal(
+		t,
+		[]*Snapshot{
+			parentSnapshot,
+			{
+				Number: header.Number,
+				Hash:   header.Hash.String(),
+				Set: validators.NewECDSAValidatorSet(
+					validators.NewECDSAValidator(newAddr),
+					ecdsaValidator2,
+				),
+			},
+		},
+		snapshotStore.GetSnapshots(),
+	)
+}
+
+func TestSnapshotValidatorStore_ProposeRotation(t *testing.T) {
+	t.Parallel()
+
+	newECDSAKey, newAddr := newTestECDSAKey(t)
+
+	rotation, err := NewKeyRotation(addr1, validators.NewECDSAValidator(newAddr), newECDSAKey, nil)
+	assert.NoError(t, err)
+
+	newStore := func() *SnapshotValidatorStore {
+		return newTestSnapshotValidatorStore(
+			nil,
+			nil,
+			10,
+			[]*Snapshot{
+				{
+					Number: 10,
+					Set:    validators.NewECDSAValidatorSet(ecdsaValidator1, ecdsaValidator2),
+				},
+			},
+			nil,
+			10,
+		)
+	}
+
+	t.Run("should include the rotation only in the proposal of the rotating validator", func(t *testing.T) {
+		t.Parallel()
+
+		snapshotStore := newStore()
+
+		assert.NoError(t, snapshotStore.ProposeRotation(rotation))
+		assert.Equal(t, rotation, snapshotStore.PendingRotation())
+
+		header := &types.Header{Number: 11}
+
+		assert.NoError(t, snapshotStore.ModifyHeader(header, addr2))
+		assert.NotEqual(t, nonceKeyRotation, header.Nonce)
+
+		assert.NoError(t, snapshotStore.ModifyHeader(header, addr1))
+		assert.Equal(t, nonceKeyRotation, header.Nonce)
+		assert.Equal(t, keyRotationToMiner(rotation), header.Miner)
+	})
+
+	t.Run("should return error if other rotation is pending", func(t *testing.T) {
+		t.Parallel()
+
+		snapshotStore := newStore()
+
+		otherKey, otherAddr := newTestECDSAKey(t)
+
+		other, err := NewKeyRotation(addr1, validators.NewECDSAValidator(otherAddr), otherKey, nil)
+		assert.NoError(t, err)
+
+		assert.NoError(t, snapshotStore.ProposeRotation(rotation))
+		assert.ErrorIs(t, snapshotStore.ProposeRotation(other), ErrAlreadyRotating)
+	})
+
+	t.Run("should return error if the validator is not in the snapshot", func(t *testing.T) {
+		t.Parallel()
+
+		snapshotStore := newStore()
+
+		otherRotation, err := NewKeyRotation(addr3, validators.NewECDSAValidator(newAddr), newECDSAKey, nil)
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, snapshotStore.ProposeRotation(otherRotation), ErrRotatingValidatorNotInSet)
+	})
+}
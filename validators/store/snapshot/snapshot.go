@@ -41,6 +41,7 @@ var (
 	ErrCandidateNotExistInSet       = errors.New("cannot remove a validator if they're not in the snapshot")
 	ErrAlreadyVoted                 = errors.New("already voted for this address")
 	ErrMultipleVotesBySameValidator = errors.New("more than one proposal per validator per address found")
+	ErrCheckpointNotEpochEnd        = errors.New("checkpoint must be the last block of an epoch")
 )
 
 type SnapshotValidatorStore struct {
@@ -50,12 +51,16 @@ type SnapshotValidatorStore struct {
 	getSigner  func(uint64) (SignerInterface, error)
 
 	// configuration
-	epochSize uint64
+	getEpochSize func(uint64) uint64
 
 	// data
 	store          *snapshotStore
 	candidates     []*store.Candidate
 	candidatesLock sync.RWMutex
+
+	// key rotation of the local validator to be proposed
+	rotation     *KeyRotation
+	rotationLock sync.RWMutex
 }
 
 // NewSnapshotValidatorStore creates and initializes *SnapshotValidatorStore
@@ -63,7 +68,7 @@ func NewSnapshotValidatorStore(
 	logger hclog.Logger,
 	blockchain store.HeaderGetter,
 	getSigner func(uint64) (SignerInterface, error),
-	epochSize uint64,
+	getEpochSize func(uint64) uint64,
 	metadata *SnapshotMetadata,
 	snapshots []*Snapshot,
 ) (*SnapshotValidatorStore, error) {
@@ -74,7 +79,7 @@ func NewSnapshotValidatorStore(
 		getSigner:      getSigner,
 		candidates:     make([]*store.Candidate, 0),
 		candidatesLock: sync.RWMutex{},
-		epochSize:      epochSize,
+		getEpochSize:   getEpochSize,
 	}
 
 	if err := set.initialize(); err != nil {
@@ -104,17 +109,17 @@ func (s *SnapshotValidatorStore) initialize() error {
 
 	// Get epoch of latest header and saved metadata
 	var (
-		currentEpoch = header.Number / s.epochSize
-		metaEpoch    = meta.LastBlock / s.epochSize
+		epochSize    = s.getEpochSize(header.Number)
+		currentEpoch = header.Number / epochSize
+		beginHeight  = currentEpoch * epochSize
 
 		snapshot = s.getSnapshot(header.Number)
 	)
 
-	if snapshot == nil || metaEpoch < currentEpoch {
+	if snapshot == nil || meta.LastBlock < beginHeight {
 		// Restore snapshot at the beginning of the current epoch by block header
 		// if list doesn't have any snapshots to calculate snapshot for the next header
 		s.logger.Info("snapshot was not found, restore snapshot at beginning of current epoch", "current epoch", currentEpoch)
-		beginHeight := currentEpoch * s.epochSize
 
 		beginHeader, ok := s.blockchain.GetHeaderByNumber(beginHeight)
 		if !ok {
@@ -216,6 +221,14 @@ func (s *SnapshotValidatorStore) ModifyHeader(header *types.Header, proposer typ
 		return ErrSnapshotNotFound
 	}
 
+	// key rotation has priority since it's proposed only by the rotating validator
+	if rotation := s.getNextRotation(snapshot, proposer); rotation != nil {
+		header.Miner = keyRotationToMiner(rotation)
+		header.Nonce = nonceKeyRotation
+
+		return nil
+	}
+
 	if candidate := s.getNextCandidate(snapshot, proposer); candidate != nil {
 		var err error
 
@@ -239,7 +252,12 @@ func (s *SnapshotValidatorStore) VerifyHeader(header *types.Header) error {
 	// Check the nonce format.
 	// The nonce field must have either an AUTH or DROP vote value.
 	// Block nonce values are not taken into account when the Miner field is set to zeroes, indicating
-	// no vote casting is taking place within a block
+	// no vote casting is taking place within a block.
+	// The nonce of key rotation requires the rotation with the valid proofs in the Miner field
+	if header.Nonce == nonceKeyRotation {
+		return s.verifyKeyRotation(header)
+	}
+
 	if header.Nonce != nonceAuthVote && header.Nonce != nonceDropVote {
 		return ErrInvalidNonce
 	}
@@ -247,6 +265,35 @@ func (s *SnapshotValidatorStore) VerifyHeader(header *types.Header) error {
 	return nil
 }
 
+// verifyKeyRotation verifies the key rotation in the header
+func (s *SnapshotValidatorStore) verifyKeyRotation(header *types.Header) error {
+	signer, err := s.getSigner(header.Number)
+	if err != nil {
+		return err
+	}
+
+	if signer == nil {
+		return fmt.Errorf("signer not found at %d", header.Number)
+	}
+
+	rotation, err := minerToKeyRotation(signer.Type(), header.Miner)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidKeyRotation, err)
+	}
+
+	proposer, err := signer.EcrecoverFromHeader(header)
+	if err != nil {
+		return err
+	}
+
+	// only the validator can rotate its keys
+	if rotation.Validator != proposer {
+		return ErrUnauthorizedProposer
+	}
+
+	return rotation.Verify()
+}
+
 // ProcessHeadersInRange is a helper function process headers in the given range
 func (s *SnapshotValidatorStore) ProcessHeadersInRange(
 	from, to uint64,
@@ -299,7 +346,7 @@ func (s *SnapshotValidatorStore) ProcessHeader(
 	snap := parentSnap.Copy()
 
 	// Reset votes when new epoch
-	if header.Number%s.epochSize == 0 {
+	if header.Number%s.getEpochSize(header.Number) == 0 {
 		s.resetSnapshot(parentSnap, snap, header)
 		s.removeLowerSnapshots(header.Number)
 		s.store.updateLastBlock(header.Number)
@@ -314,8 +361,13 @@ func (s *SnapshotValidatorStore) ProcessHeader(
 		return nil
 	}
 
-	// Process votes in the middle of epoch
-	if err := processVote(snap, header, signer.Type(), proposer); err != nil {
+	if header.Nonce == nonceKeyRotation {
+		// Process key rotation in the middle of epoch
+		if err := processKeyRotation(snap, header, signer.Type(), proposer); err != nil {
+			return err
+		}
+	} else if err := processVote(snap, header, signer.Type(), proposer); err != nil {
+		// Process votes in the middle of epoch
 		return err
 	}
 
@@ -325,6 +377,57 @@ func (s *SnapshotValidatorStore) ProcessHeader(
 	return nil
 }
 
+// Rewind removes the snapshots created by the blocks above the given height.
+// The snapshot is rebuilt from the beginning of the epoch if the snapshots at the height have been pruned
+func (s *SnapshotValidatorStore) Rewind(height uint64) error {
+	if s.store.getLastBlock() <= height {
+		return nil
+	}
+
+	s.store.deleteHigher(height)
+	s.store.updateLastBlock(height)
+
+	if s.getSnapshot(height) != nil {
+		return nil
+	}
+
+	return s.initialize()
+}
+
+// TrustCheckpoint replaces the snapshots with the validators in the header at the trusted checkpoint,
+// so that the following headers are verified from the checkpoint instead of the genesis.
+// The votes before the checkpoint are unknown, so the checkpoint must be the last block of an epoch,
+// where the votes are cleared
+func (s *SnapshotValidatorStore) TrustCheckpoint(header *types.Header) error {
+	if header.Number == 0 || header.Number%s.getEpochSize(header.Number) != 0 {
+		return fmt.Errorf("%w, got %d", ErrCheckpointNotEpochEnd, header.Number)
+	}
+
+	signer, err := s.getSigner(header.Number)
+	if err != nil {
+		return err
+	}
+
+	if signer == nil {
+		return fmt.Errorf("signer not found %d", header.Number)
+	}
+
+	// the header has the validators of the parent snapshot, which seal the header
+	validators, err := signer.GetValidators(header)
+	if err != nil {
+		return err
+	}
+
+	s.store.reset(&Snapshot{
+		Hash:   header.ParentHash.String(),
+		Number: header.Number - 1,
+		Votes:  []*store.Vote{},
+		Set:    validators,
+	})
+
+	return s.ProcessHeader(header)
+}
+
 // Propose adds new candidate for vote
 func (s *SnapshotValidatorStore) Propose(candidate validators.Validator, auth bool, proposer types.Address) error {
 	s.candidatesLock.Lock()
@@ -365,6 +468,55 @@ func (s *SnapshotValidatorStore) Propose(candidate validators.Validator, auth bo
 	)
 }
 
+// ProposeRotation sets the key rotation of the local validator to be included in the next proposal
+func (s *SnapshotValidatorStore) ProposeRotation(rotation *KeyRotation) error {
+	if err := rotation.Verify(); err != nil {
+		return err
+	}
+
+	snap := s.getLatestSnapshot()
+	if snap == nil {
+		return ErrSnapshotNotFound
+	}
+
+	if err := checkKeyRotation(snap, rotation); err != nil {
+		return err
+	}
+
+	if recorded := snap.GetRotation(rotation.Validator); recorded != nil && !recorded.Equal(rotation) {
+		return ErrAlreadyRotating
+	}
+
+	s.rotationLock.Lock()
+	defer s.rotationLock.Unlock()
+
+	if s.rotation != nil && !s.rotation.Equal(rotation) {
+		return ErrAlreadyRotating
+	}
+
+	s.rotation = rotation
+
+	return nil
+}
+
+// PendingRotation returns the key rotation of the local validator that is not applied yet
+func (s *SnapshotValidatorStore) PendingRotation() *KeyRotation {
+	s.rotationLock.RLock()
+	defer s.rotationLock.RUnlock()
+
+	return s.rotation
+}
+
+// Rotations returns the key rotations recorded in the snapshot at the specified height
+func (s *SnapshotValidatorStore) Rotations(height uint64) ([]*KeyRotation, error) {
+	snapshot := s.getSnapshot(height)
+	if snapshot == nil {
+		return nil, ErrSnapshotNotFound
+	}
+
+	return snapshot.Rotations, nil
+}
+
 // AddCandidate adds new candidate to candidate list
 // unsafe against concurrent access
 func (s *SnapshotValidatorStore) addCandidate(
@@ -450,6 +602,34 @@ func (s *SnapshotValidatorStore) getNextCandidate(
 	return s.pickOneCandidate(snap, proposer)
 }
 
+// getNextRotation returns the key rotation of the local validator
+// if the proposer is the rotating validator and the rotation is not recorded yet
+func (s *SnapshotValidatorStore) getNextRotation(
+	snap *Snapshot,
+	proposer types.Address,
+) *KeyRotation {
+	s.rotationLock.Lock()
+	defer s.rotationLock.Unlock()
+
+	rotation := s.rotation
+	if rotation == nil {
+		return nil
+	}
+
+	// remove the rotation once it's applied or the validator has left
+	if isKeyRotationApplied(snap.Set, rotation) || !snap.Set.Includes(rotation.Validator) {
+		s.rotation = nil
+
+		return nil
+	}
+
+	if rotation.Validator != proposer || snap.GetRotation(rotation.Validator) != nil {
+		return nil
+	}
+
+	return rotation
+}
+
 // cleanObsolateCandidates removes useless candidates from candidates field
 // Unsafe against concurrent accesses
 func (s *SnapshotValidatorStore) cleanObsoleteCandidates(set validators.Validators) {
@@ -512,6 +692,26 @@ func (s *SnapshotValidatorStore) resetSnapshot(
 ) {
 	snapshot.Votes = nil
 
+	// the new keys become effective from the new epoch
+	for _, rotation := range snapshot.Rotations {
+		if err := checkKeyRotation(snapshot, rotation); err != nil {
+			s.logger.Warn("skip key rotation", "validator", rotation.Validator, "err", err)
+
+			continue
+		}
+
+		newSet, err := rotateValidator(snapshot.Set, rotation)
+		if err != nil {
+			s.logger.Warn("skip key rotation", "validator", rotation.Validator, "err", err)
+
+			continue
+		}
+
+		snapshot.Set = newSet
+	}
+
+	snapshot.Rotations = nil
+
 	s.saveSnapshotIfChanged(parentSnapshot, snapshot, header)
 }
 
@@ -519,10 +719,12 @@ func (s *SnapshotValidatorStore) resetSnapshot(
 func (s *SnapshotValidatorStore) removeLowerSnapshots(
 	currentHeight uint64,
 ) {
+	epochSize := s.getEpochSize(currentHeight)
+
 	// remove in-memory snapshots from two epochs before this one
-	lowerEpoch := int(currentHeight/s.epochSize) - 2
+	lowerEpoch := int(currentHeight/epochSize) - 2
 	if lowerEpoch > 0 {
-		purgeBlock := uint64(lowerEpoch) * s.epochSize
+		purgeBlock := uint64(lowerEpoch) * epochSize
 		s.store.deleteLower(purgeBlock)
 	}
 }
@@ -587,6 +789,37 @@ func processVote(
 	return nil
 }
 
+// processKeyRotation processes key rotation in the given header and update snapshot
+func processKeyRotation(
+	snapshot *Snapshot,
+	header *types.Header,
+	validatorType validators.ValidatorType,
+	proposer types.Address,
+) error {
+	rotation, err := minerToKeyRotation(validatorType, header.Miner)
+	if err != nil {
+		return err
+	}
+
+	if err := rotation.Verify(); err != nil {
+		return err
+	}
+
+	// only the validator can rotate its keys
+	if rotation.Validator != proposer {
+		return ErrUnauthorizedProposer
+	}
+
+	// the rotation that conflicts with the snapshot is ignored like the processed vote
+	if checkKeyRotation(snapshot, rotation) != nil || snapshot.GetRotation(proposer) != nil {
+		return nil
+	}
+
+	snapshot.AddRotation(rotation)
+
+	return nil
+}
+
 // validatorToMiner converts validator to bytes for miner field in header
 func validatorToMiner(validator validators.Validator) ([]byte, error) {
 	switch validator.(type) {
@@ -73,6 +73,13 @@ func newMockBlockchain(latestHeight uint64, headers map[uint64]*types.Header) st
 	}
 }
 
+// fixedEpochSize returns the getter of the epoch size which doesn't change by height
+func fixedEpochSize(epochSize uint64) func(uint64) uint64 {
+	return func(uint64) uint64 {
+		return epochSize
+	}
+}
+
 func newTestSnapshotValidatorStore(
 	blockchain store.HeaderGetter,
 	getSigner func(uint64) (SignerInterface, error),
@@ -93,7 +100,7 @@ func newTestSnapshotValidatorStore(
 		getSigner:      getSigner,
 		candidates:     candidates,
 		candidatesLock: sync.RWMutex{},
-		epochSize:      epochSize,
+		getEpochSize:   fixedEpochSize(epochSize),
 	}
 }
 
@@ -129,7 +136,7 @@ func TestNewSnapshotValidatorStore(t *testing.T) {
 			func(u uint64) (SignerInterface, error) {
 				return nil, errTest
 			},
-			epochSize,
+			fixedEpochSize(epochSize),
 			metadata,
 			snapshots,
 		)
@@ -166,7 +173,7 @@ func TestNewSnapshotValidatorStore(t *testing.T) {
 			logger,
 			blockchain,
 			getSigner,
-			epochSize,
+			fixedEpochSize(epochSize),
 			metadata,
 			snapshots,
 		)
@@ -192,7 +199,7 @@ func TestNewSnapshotValidatorStore(t *testing.T) {
 
 		assert.Equal(
 			t,
-			snapshotStore.epochSize,
+			snapshotStore.getEpochSize(0),
 			epochSize,
 		)
 
@@ -2456,6 +2463,173 @@ func TestSnapshotValidatorStore_removeLowerSnapshots(t *testing.T) {
 	}
 }
 
+func TestSnapshotValidatorStore_Rewind(t *testing.T) {
+	t.Parallel()
+
+	var (
+		epochSize uint64 = 10
+
+		headerValidators = validators.NewECDSAValidatorSet(
+			ecdsaValidator1,
+			ecdsaValidator2,
+		)
+
+		blockchain = newMockBlockchain(
+			20,
+			map[uint64]*types.Header{
+				20: newTestHeader(
+					20,
+					types.ZeroAddress.Bytes(),
+					types.Nonce{},
+				),
+			},
+		)
+
+		getSigner = func(uint64) (SignerInterface, error) {
+			return &mockSigner{
+				GetValidatorsFn: func(*types.Header) (validators.Validators, error) {
+					return headerValidators, nil
+				},
+			}, nil
+		}
+	)
+
+	tests := []struct {
+		name             string
+		lastBlock        uint64
+		initialSnapshots []*Snapshot
+		finalLastBlock   uint64
+		finalSnapshots   []*Snapshot
+	}{
+		{
+			name:      "should do nothing if the snapshots are not above the height",
+			lastBlock: 20,
+			initialSnapshots: []*Snapshot{
+				{Number: 10},
+			},
+			finalLastBlock: 20,
+			finalSnapshots: []*Snapshot{
+				{Number: 10},
+			},
+		},
+		{
+			name:      "should remove the snapshots above the height",
+			lastBlock: 35,
+			initialSnapshots: []*Snapshot{
+				{Number: 10},
+				{Number: 20},
+				{Number: 30},
+			},
+			finalLastBlock: 20,
+			finalSnapshots: []*Snapshot{
+				{Number: 10},
+				{Number: 20},
+			},
+		},
+		{
+			name:      "should rebuild the snapshot at the beginning of the epoch if no snapshot remains",
+			lastBlock: 45,
+			initialSnapshots: []*Snapshot{
+				{Number: 30},
+				{Number: 40},
+			},
+			finalLastBlock: 20,
+			finalSnapshots: []*Snapshot{
+				{
+					Number: 20,
+					Hash:   newTestHeaderHash(20).String(),
+					Set:    headerValidators,
+					Votes:  []*store.Vote{},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			snapshotStore := newTestSnapshotValidatorStore(
+				blockchain,
+				getSigner,
+				test.lastBlock,
+				test.initialSnapshots,
+				nil,
+				epochSize,
+			)
+
+			assert.NoError(t, snapshotStore.Rewind(20))
+			assert.Equal(t, test.finalLastBlock, snapshotStore.GetSnapshotMetadata().LastBlock)
+			assert.Equal(t, test.finalSnapshots, snapshotStore.GetSnapshots())
+		})
+	}
+}
+
+func TestSnapshotValidatorStore_TrustCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	var (
+		epochSize uint64 = 10
+
+		headerValidators = validators.NewECDSAValidatorSet(
+			ecdsaValidator1,
+			ecdsaValidator2,
+		)
+
+		getSigner = func(uint64) (SignerInterface, error) {
+			return &mockSigner{
+				EcrecoverFromHeaderFn: func(*types.Header) (types.Address, error) {
+					return ecdsaValidator1.Address, nil
+				},
+				GetValidatorsFn: func(*types.Header) (validators.Validators, error) {
+					return headerValidators, nil
+				},
+			}, nil
+		}
+	)
+
+	snapshotStore := newTestSnapshotValidatorStore(
+		nil,
+		getSigner,
+		0,
+		[]*Snapshot{
+			{Number: 0, Set: validators.NewECDSAValidatorSet(ecdsaValidator3)},
+		},
+		nil,
+		epochSize,
+	)
+
+	// the votes before the checkpoint in the middle of an epoch are unknown
+	assert.ErrorIs(
+		t,
+		snapshotStore.TrustCheckpoint(newTestHeader(25, types.ZeroAddress.Bytes(), types.Nonce{})),
+		ErrCheckpointNotEpochEnd,
+	)
+
+	header := newTestHeader(20, types.ZeroAddress.Bytes(), types.Nonce{})
+	header.ParentHash = newTestHeaderHash(19)
+
+	assert.NoError(t, snapshotStore.TrustCheckpoint(header))
+	assert.Equal(t, uint64(20), snapshotStore.GetSnapshotMetadata().LastBlock)
+	assert.Equal(
+		t,
+		[]*Snapshot{
+			{
+				Number: 19,
+				Hash:   newTestHeaderHash(19).String(),
+				Set:    headerValidators,
+				Votes:  []*store.Vote{},
+			},
+		},
+		snapshotStore.GetSnapshots(),
+	)
+
+	vals, err := snapshotStore.GetValidatorsByHeight(20)
+	assert.NoError(t, err)
+	assert.Equal(t, headerValidators, vals)
+}
+
 func TestSnapshotValidatorStore_processVote(t *testing.T) {
 	var (
 		headerNumber uint64 = 21
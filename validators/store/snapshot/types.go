@@ -30,21 +30,26 @@ type Snapshot struct {
 
 	// current set of validators
 	Set validators.Validators
+
+	// key rotations applied at the beginning of the next epoch
+	Rotations []*KeyRotation
 }
 
 func (s *Snapshot) MarshalJSON() ([]byte, error) {
 	jsonData := struct {
-		Number uint64
-		Hash   string
-		Votes  []*store.Vote
-		Type   validators.ValidatorType
-		Set    validators.Validators
+		Number    uint64
+		Hash      string
+		Votes     []*store.Vote
+		Type      validators.ValidatorType
+		Set       validators.Validators
+		Rotations []*KeyRotation `json:",omitempty"`
 	}{
-		Number: s.Number,
-		Hash:   s.Hash,
-		Votes:  s.Votes,
-		Type:   s.Set.Type(),
-		Set:    s.Set,
+		Number:    s.Number,
+		Hash:      s.Hash,
+		Votes:     s.Votes,
+		Type:      s.Set.Type(),
+		Set:       s.Set,
+		Rotations: s.Rotations,
 	}
 
 	return json.Marshal(jsonData)
@@ -52,11 +57,12 @@ func (s *Snapshot) MarshalJSON() ([]byte, error) {
 
 func (s *Snapshot) UnmarshalJSON(data []byte) error {
 	raw := struct {
-		Number uint64
-		Hash   string
-		Type   string
-		Votes  []json.RawMessage
-		Set    json.RawMessage
+		Number    uint64
+		Hash      string
+		Type      string
+		Votes     []json.RawMessage
+		Set       json.RawMessage
+		Rotations []json.RawMessage
 	}{}
 
 	var err error
@@ -88,6 +94,10 @@ func (s *Snapshot) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	if err := s.unmarshalRotationsJSON(valType, raw.Rotations); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -145,9 +155,41 @@ func (s *Snapshot) unmarshalSetJSON(
 	return json.Unmarshal(rawSet, s.Set)
 }
 
+// unmarshalRotationsJSON is a helper function to unmarshal for Rotations field
+func (s *Snapshot) unmarshalRotationsJSON(
+	valType validators.ValidatorType,
+	rawRotations []json.RawMessage,
+) error {
+	if len(rawRotations) == 0 {
+		s.Rotations = nil
+
+		return nil
+	}
+
+	rotations := make([]*KeyRotation, len(rawRotations))
+	for idx := range rotations {
+		newValidator, err := validators.NewValidatorFromType(valType)
+		if err != nil {
+			return err
+		}
+
+		rotations[idx] = &KeyRotation{
+			NewValidator: newValidator,
+		}
+
+		if err := json.Unmarshal(rawRotations[idx], rotations[idx]); err != nil {
+			return err
+		}
+	}
+
+	s.Rotations = rotations
+
+	return nil
+}
+
 // Equal checks if two snapshots are equal
 func (s *Snapshot) Equal(ss *Snapshot) bool {
-	// we only check if Votes and Set are equal since Number and Hash
+	// we only check if Votes, Rotations and Set are equal since Number and Hash
 	// are only meant to be used for indexing
 	if len(s.Votes) != len(ss.Votes) {
 		return false
@@ -159,6 +201,16 @@ func (s *Snapshot) Equal(ss *Snapshot) bool {
 		}
 	}
 
+	if len(s.Rotations) != len(ss.Rotations) {
+		return false
+	}
+
+	for indx := range s.Rotations {
+		if !s.Rotations[indx].Equal(ss.Rotations[indx]) {
+			return false
+		}
+	}
+
 	return s.Set.Equal(ss.Set)
 }
 
@@ -199,9 +251,33 @@ func (s *Snapshot) Copy() *Snapshot {
 		ss.Votes[indx] = vote.Copy()
 	}
 
+	if len(s.Rotations) > 0 {
+		ss.Rotations = make([]*KeyRotation, len(s.Rotations))
+
+		for indx, rotation := range s.Rotations {
+			ss.Rotations[indx] = rotation.Copy()
+		}
+	}
+
 	return ss
 }
 
+// AddRotation adds a key rotation to snapshot
+func (s *Snapshot) AddRotation(rotation *KeyRotation) {
+	s.Rotations = append(s.Rotations, rotation)
+}
+
+// GetRotation returns the key rotation of the given validator, or nil if it's not found
+func (s *Snapshot) GetRotation(validator types.Address) *KeyRotation {
+	for _, rotation := range s.Rotations {
+		if rotation.Validator == validator {
+			return rotation
+		}
+	}
+
+	return nil
+}
+
 // CountByCandidateAndVoter is a helper method to count votes by voter address and candidate
 func (s *Snapshot) CountByVoterAndCandidate(
 	voter types.Address,
@@ -312,6 +388,27 @@ func (s *snapshotStore) deleteLower(num uint64) {
 	s.list = s.list[pruneIndex:]
 }
 
+// reset replaces all the snapshots with the given snapshot
+func (s *snapshotStore) reset(snap *Snapshot) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.list = snapshotSortedList{snap}
+	atomic.StoreUint64(&s.lastNumber, snap.Number)
+}
+
+// deleteHigher deletes snapshots that have a block number higher than the passed in parameter
+func (s *snapshotStore) deleteHigher(num uint64) {
+	s.Lock()
+	defer s.Unlock()
+
+	i := sort.Search(len(s.list), func(i int) bool {
+		return s.list[i].Number > num
+	})
+
+	s.list = s.list[:i]
+}
+
 // findClosestSnapshotIndex finds the closest snapshot index for the specified
 // block number
 func (s *snapshotStore) findClosestSnapshotIndex(blockNum uint64) int {
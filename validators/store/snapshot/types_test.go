@@ -1172,6 +1172,70 @@ func Test_snapshotStore_deleteLower(t *testing.T) {
 	}
 }
 
+func Test_snapshotStore_deleteHigher(t *testing.T) {
+	t.Parallel()
+
+	metadata := &SnapshotMetadata{
+		LastBlock: 30,
+	}
+
+	testTable := []struct {
+		name              string
+		snapshots         []*Snapshot
+		boundary          uint64
+		expectedSnapshots []*Snapshot
+	}{
+		{
+			"Drop higher-number snapshots",
+			[]*Snapshot{
+				{Number: 10},
+				{Number: 19},
+				{Number: 20},
+				{Number: 30},
+			},
+			uint64(20),
+			[]*Snapshot{
+				{Number: 10},
+				{Number: 19},
+				{Number: 20},
+			},
+		},
+		{
+			"Lower block value",
+			[]*Snapshot{
+				{Number: 10},
+				{Number: 11},
+			},
+			uint64(5),
+			[]*Snapshot{},
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := newSnapshotStore(
+				metadata,
+				testCase.snapshots,
+			)
+
+			store.deleteHigher(testCase.boundary)
+
+			assert.Equal(
+				t,
+				&snapshotStore{
+					lastNumber: metadata.LastBlock,
+					list:       testCase.expectedSnapshots,
+				},
+				store,
+			)
+		})
+	}
+}
+
 func Test_snapshotStore_find(t *testing.T) {
 	t.Parallel()
 